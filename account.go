@@ -59,3 +59,26 @@ func (c *Client) UpdateSellerAccount(ctx context.Context, update SellerAccountUp
 
 	return &account, nil
 }
+
+// SetVacationMode takes all of the seller's listings off the marketplace, or
+// puts them back. It sets both singles_live and sealed_live, which are the
+// only account settings the API can change; store name, description, and
+// notification preferences are managed in the ManaPool dashboard.
+//
+// Turning vacation mode off makes both singles and sealed listings live. A
+// seller who lists only one category should call UpdateSellerAccount
+// instead, where a nil field leaves that setting unchanged.
+//
+// Example:
+//
+//	if _, err := client.SetVacationMode(ctx, true); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) SetVacationMode(ctx context.Context, enabled bool) (*Account, error) {
+	live := !enabled
+	account, err := c.UpdateSellerAccount(ctx, SellerAccountUpdate{SinglesLive: &live, SealedLive: &live})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set vacation mode: %w", err)
+	}
+	return account, nil
+}
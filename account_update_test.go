@@ -3,8 +3,11 @@ package manapool
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -39,3 +42,42 @@ func TestClient_UpdateSellerAccount(t *testing.T) {
 		t.Fatalf("singles_live = false, want true")
 	}
 }
+
+func TestClient_SetVacationMode(t *testing.T) {
+	var payloads []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		payloads = append(payloads, string(body))
+		if strings.Contains(string(body), "true") {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"account not verified"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"username":"test","singles_live":false,"sealed_live":false}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	account, err := client.SetVacationMode(ctx, true)
+	if err != nil {
+		t.Fatalf("SetVacationMode(true) error: %v", err)
+	}
+	if account.SinglesLive || account.SealedLive {
+		t.Errorf("account = %+v", account)
+	}
+
+	_, err = client.SetVacationMode(ctx, false)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || !strings.Contains(err.Error(), "failed to set vacation mode") {
+		t.Errorf("SetVacationMode(false) error = %v", err)
+	}
+
+	want := []string{`{"singles_live":false,"sealed_live":false}`, `{"singles_live":true,"sealed_live":true}`}
+	for i, payload := range payloads {
+		if strings.TrimSpace(payload) != want[i] {
+			t.Errorf("payload %d = %s, want %s", i, payload, want[i])
+		}
+	}
+}
@@ -0,0 +1,222 @@
+// Package analyze groups and totals ManaPool inventory.
+//
+// GroupBy and the aggregate functions work on items already in memory, such
+// as a page from GetSellerInventory or a snapshot read with ReadSnapshot. An
+// Aggregator keeps only running totals per group, and its Add method can be
+// passed straight to ListInventoryStream, so large inventories can be
+// summarized without holding them in memory.
+//
+// # Basic Usage
+//
+//	groups := analyze.GroupBy(resp.Inventory, analyze.BySet)
+//	for set, items := range groups {
+//	    fmt.Printf("%s: %d cards worth %d cents\n", set,
+//	        analyze.TotalQuantity(items), analyze.TotalValueCents(items))
+//	}
+//
+// # Streaming
+//
+//	agg := analyze.NewAggregator(analyze.ByCondition)
+//	opts := manapool.InventoryOptions{Limit: 500}
+//	for {
+//	    page, err := client.ListInventoryStream(ctx, opts, agg.Add)
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    var more bool
+//	    if opts, more = page.Next(opts); !more {
+//	        break
+//	    }
+//	}
+//	for _, g := range agg.Groups() {
+//	    fmt.Printf("%-4s %6d %10d\n", g.Key, g.Quantity, g.ValueCents)
+//	}
+package analyze
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/repricah/manapool"
+)
+
+// KeyFunc returns the group an inventory item belongs to. An empty key is a
+// group of its own, for items the grouping does not apply to, such as the
+// condition of sealed product.
+type KeyFunc func(item manapool.InventoryItem) string
+
+// BySet groups items by upper-cased set code, for singles and sealed product.
+func BySet(item manapool.InventoryItem) string {
+	switch {
+	case item.Product.Single != nil:
+		return strings.ToUpper(item.Product.Single.Set)
+	case item.Product.Sealed != nil:
+		return strings.ToUpper(item.Product.Sealed.Set)
+	}
+	return ""
+}
+
+// ByCondition groups singles by condition ID, such as "NM".
+func ByCondition(item manapool.InventoryItem) string {
+	if item.Product.Single == nil {
+		return ""
+	}
+	return item.Product.Single.ConditionID
+}
+
+// ByFinish groups singles by finish ID, such as "FO".
+func ByFinish(item manapool.InventoryItem) string {
+	if item.Product.Single == nil {
+		return ""
+	}
+	return item.Product.Single.FinishID
+}
+
+// ByPriceBucket returns a KeyFunc that groups items by unit price. bounds
+// are ascending upper bounds in cents, as in manapool.ValuationOptions; an
+// item falls in the first bucket whose bound exceeds its price. Keys are
+// price ranges in dollars, such as "1.00-4.99" or "100.00+". With no bounds,
+// manapool.DefaultPriceBuckets is used. It panics if bounds are not positive
+// and ascending.
+func ByPriceBucket(bounds ...int) KeyFunc {
+	if len(bounds) == 0 {
+		bounds = manapool.DefaultPriceBuckets
+	}
+	if err := (manapool.ValuationOptions{PriceBuckets: bounds}).Validate(); err != nil {
+		panic(fmt.Sprintf("analyze: invalid price buckets: %v", err))
+	}
+	bounds = append([]int(nil), bounds...)
+
+	keys := make([]string, len(bounds)+1)
+	low := 0
+	for i, bound := range bounds {
+		keys[i] = manapool.FormatCents(low) + "-" + manapool.FormatCents(bound-1)
+		low = bound
+	}
+	keys[len(bounds)] = manapool.FormatCents(low) + "+"
+
+	return func(item manapool.InventoryItem) string {
+		return keys[sort.SearchInts(bounds, item.PriceCents+1)]
+	}
+}
+
+// GroupBy splits items by key, keeping their order within each group.
+func GroupBy(items []manapool.InventoryItem, key KeyFunc) map[string][]manapool.InventoryItem {
+	groups := make(map[string][]manapool.InventoryItem)
+	for _, item := range items {
+		k := key(item)
+		groups[k] = append(groups[k], item)
+	}
+	return groups
+}
+
+// Count returns the number of listings in items.
+func Count(items []manapool.InventoryItem) int {
+	return len(items)
+}
+
+// TotalQuantity returns the total quantity across items.
+func TotalQuantity(items []manapool.InventoryItem) int {
+	total := 0
+	for _, item := range items {
+		total += item.Quantity
+	}
+	return total
+}
+
+// TotalValueCents returns the total of price times quantity across items.
+func TotalValueCents(items []manapool.InventoryItem) int {
+	total := 0
+	for _, item := range items {
+		total += item.PriceCents * item.Quantity
+	}
+	return total
+}
+
+// Totals aggregates a set of listings.
+type Totals struct {
+	// Listings is the number of inventory listings
+	Listings int
+
+	// Quantity is the total quantity across the listings
+	Quantity int
+
+	// ValueCents is the total of price times quantity
+	ValueCents int
+}
+
+// Add counts item in the totals.
+func (t *Totals) Add(item manapool.InventoryItem) {
+	t.Listings++
+	t.Quantity += item.Quantity
+	t.ValueCents += item.PriceCents * item.Quantity
+}
+
+// Group is the totals for one key.
+type Group struct {
+	Key string
+	Totals
+}
+
+// Summarize totals items by key. Groups are sorted by value, highest first,
+// then by key.
+func Summarize(items []manapool.InventoryItem, key KeyFunc) []Group {
+	agg := NewAggregator(key)
+	for _, item := range items {
+		agg.add(item)
+	}
+	return agg.Groups()
+}
+
+// Aggregator accumulates per-group totals one item at a time. It is not safe
+// for concurrent use.
+type Aggregator struct {
+	key    KeyFunc
+	total  Totals
+	groups map[string]*Totals
+}
+
+// NewAggregator returns an Aggregator grouping by key.
+func NewAggregator(key KeyFunc) *Aggregator {
+	return &Aggregator{key: key, groups: make(map[string]*Totals)}
+}
+
+// Add counts item. It always returns nil; the error result lets Add be passed
+// directly as the callback of ListInventoryStream.
+func (a *Aggregator) Add(item manapool.InventoryItem) error {
+	a.add(item)
+	return nil
+}
+
+func (a *Aggregator) add(item manapool.InventoryItem) {
+	k := a.key(item)
+	t, ok := a.groups[k]
+	if !ok {
+		t = &Totals{}
+		a.groups[k] = t
+	}
+	t.Add(item)
+	a.total.Add(item)
+}
+
+// Total returns the totals across every item added.
+func (a *Aggregator) Total() Totals {
+	return a.total
+}
+
+// Groups returns the totals per key, sorted by value, highest first, then by
+// key.
+func (a *Aggregator) Groups() []Group {
+	groups := make([]Group, 0, len(a.groups))
+	for k, t := range a.groups {
+		groups = append(groups, Group{Key: k, Totals: *t})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].ValueCents != groups[j].ValueCents {
+			return groups[i].ValueCents > groups[j].ValueCents
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
@@ -0,0 +1,112 @@
+package analyze
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool"
+	"github.com/repricah/manapool/manapooltest"
+)
+
+func single(set, condition, finish string, priceCents, quantity int) manapool.InventoryItem {
+	return manapool.InventoryItem{
+		PriceCents: priceCents,
+		Quantity:   quantity,
+		Product: manapool.Product{Single: &manapool.Single{
+			Set: set, ConditionID: condition, FinishID: finish,
+		}},
+	}
+}
+
+func testItems() []manapool.InventoryItem {
+	return []manapool.InventoryItem{
+		single("lea", "NM", "NF", 50, 4),
+		single("LEA", "LP", "FO", 2500, 1),
+		single("mh3", "NM", "FO", 100, 3),
+		{PriceCents: 50000, Quantity: 1, Product: manapool.Product{Sealed: &manapool.Sealed{Set: "mh3"}}},
+	}
+}
+
+func groupsString(groups []Group) string {
+	parts := make([]string, len(groups))
+	for i, g := range groups {
+		parts[i] = fmt.Sprintf("%s:%d/%d/%d", g.Key, g.Listings, g.Quantity, g.ValueCents)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestGroupBy(t *testing.T) {
+	items := testItems()
+	groups := GroupBy(items, BySet)
+	if len(groups) != 2 || len(groups["LEA"]) != 2 || len(groups["MH3"]) != 2 {
+		t.Fatalf("GroupBy(BySet) = %v", groups)
+	}
+	if groups["LEA"][0].PriceCents != 50 || groups["LEA"][1].PriceCents != 2500 {
+		t.Errorf("GroupBy did not keep item order: %+v", groups["LEA"])
+	}
+
+	lea := groups["LEA"]
+	if Count(lea) != 2 || TotalQuantity(lea) != 5 || TotalValueCents(lea) != 200+2500 {
+		t.Errorf("LEA totals = %d/%d/%d", Count(lea), TotalQuantity(lea), TotalValueCents(lea))
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		key  KeyFunc
+		want string
+	}{
+		{"set", BySet, "MH3:2/4/50300 LEA:2/5/2700"},
+		{"condition", ByCondition, ":1/1/50000 LP:1/1/2500 NM:2/7/500"},
+		{"finish", ByFinish, ":1/1/50000 FO:2/4/2800 NF:1/4/200"},
+		{"price", ByPriceBucket(), "100.00+:1/1/50000 20.00-99.99:1/1/2500 1.00-4.99:1/3/300 0.00-0.99:1/4/200"},
+		{"custom price", ByPriceBucket(1000), "10.00+:2/2/52500 0.00-9.99:2/7/500"},
+	} {
+		if got := groupsString(Summarize(testItems(), tt.key)); got != tt.want {
+			t.Errorf("%s groups = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestByPriceBucket_InvalidBounds(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("ByPriceBucket(500, 100) did not panic")
+		}
+	}()
+	ByPriceBucket(500, 100)
+}
+
+func TestAggregator_Stream(t *testing.T) {
+	srv := manapooltest.NewServer(manapooltest.WithInventory(testItems()...))
+	defer srv.Close()
+	client := srv.Client()
+
+	agg := NewAggregator(ByFinish)
+	opts := manapool.InventoryOptions{Limit: 3}
+	pages := 0
+	for {
+		page, err := client.ListInventoryStream(context.Background(), opts, agg.Add)
+		if err != nil {
+			t.Fatalf("ListInventoryStream() error = %v", err)
+		}
+		pages++
+		var more bool
+		if opts, more = page.Next(opts); !more {
+			break
+		}
+	}
+
+	if pages != 2 {
+		t.Errorf("pages = %d, want 2", pages)
+	}
+	if got, want := groupsString(agg.Groups()), groupsString(Summarize(testItems(), ByFinish)); got != want {
+		t.Errorf("streamed groups = %s, want %s", got, want)
+	}
+	if total := agg.Total(); total != (Totals{Listings: 4, Quantity: 9, ValueCents: 53000}) {
+		t.Errorf("Total() = %+v", total)
+	}
+}
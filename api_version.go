@@ -0,0 +1,96 @@
+package manapool
+
+import "strings"
+
+// DefaultAPIVersion is the API version of DefaultBaseURL.
+const DefaultAPIVersion = "v1"
+
+// WithAPIVersion selects the API version used for requests, such as "v2".
+// The version replaces the version segment at the end of the base URL, so
+// "https://manapool.com/api/v1/" becomes "https://manapool.com/api/v2/".
+// A base URL without a version segment, such as a test server's, is used
+// as is. An empty version keeps the base URL's version.
+//
+// During a migration, use WithEndpointVersion to keep endpoints that have
+// not moved yet on the old version.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithAPIVersion("v2"),
+//	    manapool.WithEndpointVersion("/seller/orders", "v1"),
+//	)
+func WithAPIVersion(version string) ClientOption {
+	return func(c *Client) {
+		c.apiVersion = strings.Trim(version, "/")
+	}
+}
+
+// WithEndpointVersion pins the endpoints under prefix, such as
+// "/seller/orders", to version, overriding WithAPIVersion for them. The
+// prefix matches whole path segments, and the longest matching prefix wins.
+// It can be applied more than once.
+func WithEndpointVersion(prefix, version string) ClientOption {
+	return func(c *Client) {
+		if c.endpointVersions == nil {
+			c.endpointVersions = map[string]string{}
+		}
+		c.endpointVersions[strings.Trim(prefix, "/")] = strings.Trim(version, "/")
+	}
+}
+
+// endpointURL returns the URL of endpoint, which has no leading slash, under
+// the base URL for the endpoint's API version.
+func (c *Client) endpointURL(endpoint string) string {
+	return versionedBaseURL(c.baseURL, c.versionFor(endpoint)) + endpoint
+}
+
+// versionFor returns the API version for endpoint, or "" to keep the base
+// URL's version.
+func (c *Client) versionFor(endpoint string) string {
+	version, longest := c.apiVersion, -1
+	for prefix, v := range c.endpointVersions {
+		if len(prefix) > longest && hasPathPrefix(endpoint, prefix) {
+			version, longest = v, len(prefix)
+		}
+	}
+	return version
+}
+
+// hasPathPrefix reports whether path starts with the path segments of
+// prefix.
+func hasPathPrefix(path, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	rest := path[len(prefix):]
+	return prefix == "" || rest == "" || rest[0] == '/' || rest[0] == '?'
+}
+
+// versionedBaseURL replaces the trailing version segment of base, such as
+// "v1" in "https://manapool.com/api/v1/", with version. base is returned
+// unchanged if version is empty or base has no version segment.
+func versionedBaseURL(base, version string) string {
+	if version == "" {
+		return base
+	}
+	trimmed := strings.TrimSuffix(base, "/")
+	i := strings.LastIndex(trimmed, "/")
+	if i < 0 || !isVersionSegment(trimmed[i+1:]) {
+		return base
+	}
+	return trimmed[:i+1] + version + "/"
+}
+
+// isVersionSegment reports whether segment looks like "v1" or "v12".
+func isVersionSegment(segment string) bool {
+	if len(segment) < 2 || segment[0] != 'v' {
+		return false
+	}
+	for _, r := range segment[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
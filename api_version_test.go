@@ -0,0 +1,72 @@
+package manapool
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVersionedBaseURL(t *testing.T) {
+	tests := []struct {
+		base, version, want string
+	}{
+		{"https://manapool.com/api/v1/", "v2", "https://manapool.com/api/v2/"},
+		{"https://manapool.com/api/v1", "v2", "https://manapool.com/api/v2/"},
+		{"https://manapool.com/api/v1/", "", "https://manapool.com/api/v1/"},
+		{"http://127.0.0.1:8080/", "v2", "http://127.0.0.1:8080/"},
+		{"https://example.com/video/", "v2", "https://example.com/video/"},
+	}
+	for _, tt := range tests {
+		if got := versionedBaseURL(tt.base, tt.version); got != tt.want {
+			t.Errorf("versionedBaseURL(%q, %q) = %q, want %q", tt.base, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestClient_APIVersion(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/api/v1/"),
+		WithAPIVersion("v2"),
+		WithEndpointVersion("/seller/orders", "v1"),
+		WithEndpointVersion("seller/orders/pinned", "v3"))
+	ctx := context.Background()
+
+	if _, err := client.GetSellerAccount(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetSellerOrders(ctx, OrdersOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetSellerOrder(ctx, "pinned"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetSellerOrder(ctx, "o1"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"/api/v2/account", "/api/v1/seller/orders", "/api/v3/seller/orders/pinned", "/api/v1/seller/orders/o1"}
+	if len(paths) != len(want) {
+		t.Fatalf("paths = %q, want %q", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("path %d = %q, want %q", i, paths[i], want[i])
+		}
+	}
+}
+
+func TestHasPathPrefix(t *testing.T) {
+	if !hasPathPrefix("seller/orders/1", "seller/orders") || !hasPathPrefix("seller/orders", "seller/orders") {
+		t.Error("expected segment prefix match")
+	}
+	if hasPathPrefix("seller/ordersx", "seller/orders") {
+		t.Error("matched inside a segment")
+	}
+}
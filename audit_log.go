@@ -0,0 +1,218 @@
+package manapool
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// maxAuditSummary is the length at which AuditRecord.Summary is cut.
+const maxAuditSummary = 200
+
+// AuditRecord describes one successful mutating API call.
+type AuditRecord struct {
+	// Time is when the API answered
+	Time time.Time `json:"time"`
+
+	// Actor labels who made the change: the AuditActor request option, or
+	// the account email the request was sent as
+	Actor string `json:"actor"`
+
+	// Method and Endpoint identify the call, such as "PUT" and
+	// "/inventory/tcgsku/123"
+	Method   string `json:"method"`
+	Endpoint string `json:"endpoint"`
+
+	// Query is the encoded query string, if any
+	Query string `json:"query,omitempty"`
+
+	StatusCode int `json:"status_code"`
+
+	// RequestID is the API's identifier for the request, from the
+	// X-Request-Id header (empty if absent)
+	RequestID string `json:"request_id,omitempty"`
+
+	// Summary is a short description of the request body, such as
+	// "price_cents=125 quantity=4" or "3 items"
+	Summary string `json:"summary,omitempty"`
+}
+
+// AuditSink receives audit records. Implementations must be safe for
+// concurrent use.
+type AuditSink interface {
+	Audit(ctx context.Context, record AuditRecord) error
+}
+
+// AuditSinkFunc adapts a function to AuditSink.
+type AuditSinkFunc func(ctx context.Context, record AuditRecord) error
+
+// Audit implements AuditSink.
+func (f AuditSinkFunc) Audit(ctx context.Context, record AuditRecord) error {
+	return f(ctx, record)
+}
+
+// AuditLogWriter is an AuditSink that writes records as JSON Lines, one
+// record per line. It is safe for concurrent use.
+type AuditLogWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewAuditLogWriter creates a sink writing to w, such as a file opened for
+// appending.
+func NewAuditLogWriter(w io.Writer) *AuditLogWriter {
+	return &AuditLogWriter{enc: json.NewEncoder(w)}
+}
+
+// Audit implements AuditSink.
+func (l *AuditLogWriter) Audit(_ context.Context, record AuditRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.enc.Encode(record); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	return nil
+}
+
+// AuditActor labels the requests made with the context as changes made by
+// actor, such as a user name or job name, in the client's audit log.
+//
+// Example:
+//
+//	ctx := manapool.WithRequestOptions(ctx, manapool.AuditActor("nightly-repricer"))
+//	_, err := client.UpdateInventoryBySKU(ctx, sku, update)
+func AuditActor(actor string) RequestOption {
+	return func(ro *requestOptions) {
+		ro.auditActor = actor
+	}
+}
+
+// audited reports whether a request should be recorded in the audit log.
+func (c *Client) audited(method string) bool {
+	return c.auditSink != nil && method != http.MethodGet && method != http.MethodHead
+}
+
+// doAuditedRequest sends a mutating request with send and, if it succeeds,
+// records it in the audit log. body is replaced with a copy, since the
+// original is read for the summary.
+func (c *Client) doAuditedRequest(ctx context.Context, method, endpoint string, params url.Values, body io.Reader, header http.Header, send func(io.Reader) (*http.Response, error)) (*http.Response, error) {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = io.ReadAll(body); err != nil {
+			return nil, NewNetworkError("failed to read request body", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	resp, err := send(body)
+	if err != nil || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp, err
+	}
+
+	actor := c.email
+	if ro := requestOptionsFrom(ctx); ro != nil && ro.auditActor != "" {
+		actor = ro.auditActor
+	} else if _, email, err := c.credentials(ctx); err == nil {
+		actor = email
+	}
+	record := AuditRecord{
+		Time:       time.Now(),
+		Actor:      actor,
+		Method:     method,
+		Endpoint:   endpoint,
+		Query:      params.Encode(),
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		Summary:    summarizeAuditBody(data, header),
+	}
+	// The change has been made, so a sink failure is logged rather than
+	// returned.
+	if err := c.auditSink.Audit(context.WithoutCancel(ctx), record); err != nil {
+		c.logger.Errorf("Failed to audit %s %s: %v", method, endpoint, err)
+	}
+	return resp, nil
+}
+
+// summarizeAuditBody describes a request body in one short line: the scalar
+// fields of a JSON object, the length of a JSON array, or the content type
+// and size of anything else.
+func summarizeAuditBody(data []byte, header http.Header) string {
+	if len(data) == 0 {
+		return ""
+	}
+	if strings.EqualFold(header.Get("Content-Encoding"), "gzip") {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		var plain []byte
+		if err == nil {
+			plain, err = io.ReadAll(zr)
+		}
+		if err != nil {
+			return fmt.Sprintf("gzip, %d bytes", len(data))
+		}
+		data = plain
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	var value any
+	if mediaType != "application/json" || json.Unmarshal(data, &value) != nil {
+		if mediaType == "" {
+			mediaType = "body"
+		}
+		return fmt.Sprintf("%s, %d bytes", mediaType, len(data))
+	}
+
+	var summary string
+	switch v := value.(type) {
+	case []any:
+		summary = pluralItems(len(v))
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys))
+		for _, key := range keys {
+			switch field := v[key].(type) {
+			case []any:
+				parts = append(parts, key+"="+pluralItems(len(field)))
+			case map[string]any:
+				parts = append(parts, key+"={...}")
+			default:
+				encoded, _ := json.Marshal(field)
+				parts = append(parts, key+"="+string(encoded))
+			}
+		}
+		summary = strings.Join(parts, " ")
+	default:
+		summary = string(bytes.TrimSpace(data))
+	}
+	if len(summary) > maxAuditSummary {
+		// Cut on a rune boundary so the summary stays valid UTF-8
+		cut := maxAuditSummary - 3
+		for cut > 0 && !utf8.RuneStart(summary[cut]) {
+			cut--
+		}
+		summary = summary[:cut] + "..."
+	}
+	return summary
+}
+
+func pluralItems(n int) string {
+	if n == 1 {
+		return "1 item"
+	}
+	return fmt.Sprintf("%d items", n)
+}
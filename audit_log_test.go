@@ -0,0 +1,140 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_AuditLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-"+r.Method)
+		if strings.HasSuffix(r.URL.Path, "/999") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var log bytes.Buffer
+	client := NewClient("token", "seller@example.com", WithBaseURL(server.URL+"/"), WithRetry(0, 0),
+		WithRequestCompression(64), WithAuditLog(NewAuditLogWriter(&log)))
+	ctx := context.Background()
+
+	if _, err := client.UpdateInventoryBySKU(ctx, 123, InventoryUpdateRequest{PriceCents: 125, Quantity: 4}); err != nil {
+		t.Fatal(err)
+	}
+	bulk := make([]InventoryBulkItemBySKU, 5)
+	for i := range bulk {
+		bulk[i] = InventoryBulkItemBySKU{TCGPlayerSKU: i + 1, PriceCents: 100, Quantity: 1}
+	}
+	repricer := WithRequestOptions(ctx, AuditActor("nightly-repricer"))
+	if _, err := client.CreateInventoryBulkBySKU(repricer, bulk); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.DeleteInventoryBySKU(ctx, 7); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reads and failed writes are not audited.
+	if _, err := client.GetInventoryBySKU(ctx, 123); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.UpdateInventoryBySKU(ctx, 999, InventoryUpdateRequest{PriceCents: 1, Quantity: 1}); err == nil {
+		t.Fatal("expected not found error")
+	}
+
+	var records []AuditRecord
+	decoder := json.NewDecoder(&log)
+	for decoder.More() {
+		var record AuditRecord
+		if err := decoder.Decode(&record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 3 {
+		t.Fatalf("records = %+v", records)
+	}
+
+	update := records[0]
+	if update.Actor != "seller@example.com" || update.Method != "PUT" || update.Endpoint != "/inventory/tcgsku/123" ||
+		update.StatusCode != 200 || update.RequestID != "req-PUT" || update.Summary != "price_cents=125 quantity=4" || update.Time.IsZero() {
+		t.Errorf("update record = %+v", update)
+	}
+	// The bulk body is gzipped on the wire but summarized from its JSON.
+	if records[1].Actor != "nightly-repricer" || records[1].Summary != "5 items" {
+		t.Errorf("bulk record = %+v", records[1])
+	}
+	if records[2].Method != "DELETE" || records[2].Summary != "" {
+		t.Errorf("delete record = %+v", records[2])
+	}
+}
+
+func TestClient_AuditLog_CredentialsProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var records []AuditRecord
+	sink := AuditSinkFunc(func(_ context.Context, record AuditRecord) error {
+		records = append(records, record)
+		return nil
+	})
+	client := NewClient("", "", WithBaseURL(server.URL+"/"), WithAuditLog(sink),
+		WithCredentialsProvider(StaticCredentials("token", "tenant@example.com")))
+	if _, err := client.UpdateInventoryBySKU(context.Background(), 1, InventoryUpdateRequest{PriceCents: 1, Quantity: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Actor != "tenant@example.com" {
+		t.Errorf("records = %+v, want actor from the credentials provider", records)
+	}
+}
+
+func TestClient_AuditLog_SinkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	sink := AuditSinkFunc(func(context.Context, AuditRecord) error { return errors.New("sink down") })
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithAuditLog(sink))
+	if _, err := client.UpdateInventoryBySKU(context.Background(), 1, InventoryUpdateRequest{PriceCents: 1, Quantity: 1}); err != nil {
+		t.Errorf("UpdateInventoryBySKU() error = %v, want sink errors ignored", err)
+	}
+}
+
+func TestSummarizeAuditBody(t *testing.T) {
+	jsonHeader := http.Header{"Content-Type": {"application/json"}}
+	tests := []struct {
+		name   string
+		body   string
+		header http.Header
+		want   string
+	}{
+		{"object", `{"b":true,"a":"x","items":[1,2],"address":{"line1":"1 Main"}}`, jsonHeader, `a="x" address={...} b=true items=2 items`},
+		{"one item", `[{}]`, jsonHeader, "1 item"},
+		{"scalar", `42`, jsonHeader, "42"},
+		{"multipart", "--boundary--", http.Header{"Content-Type": {"multipart/form-data; boundary=boundary"}}, "multipart/form-data, 12 bytes"},
+		{"untyped", "abc", http.Header{}, "body, 3 bytes"},
+		{"bad gzip", "abc", http.Header{"Content-Encoding": {"gzip"}}, "gzip, 3 bytes"},
+		{"long", `{"note":"` + strings.Repeat("x", 300) + `"}`, jsonHeader, `note="` + strings.Repeat("x", maxAuditSummary-9) + "..."},
+		// 3-byte runes: the cut at 197 bytes falls inside one
+		{"long multibyte", `{"note":"` + strings.Repeat("€", 100) + `"}`, jsonHeader, `note="` + strings.Repeat("€", 63) + "..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarizeAuditBody([]byte(tt.body), tt.header); got != tt.want {
+				t.Errorf("summarizeAuditBody() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package manapool
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores raw GET response bodies keyed by account and request URL.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the cached value for key and whether it was found.
+	// Expired entries must be reported as missing.
+	Get(key string) ([]byte, bool)
+
+	// Set stores value under key for the given TTL.
+	Set(key string, value []byte, ttl time.Duration)
+
+	// Delete removes key from the cache.
+	Delete(key string)
+}
+
+// CacheTTLPolicy returns how long a GET response for endpoint may be cached.
+// The endpoint is the API path relative to the base URL, e.g. "account" or
+// "seller/inventory". A non-positive duration disables caching for it.
+type CacheTTLPolicy func(endpoint string) time.Duration
+
+// FixedTTL returns a CacheTTLPolicy that caches every endpoint for ttl.
+func FixedTTL(ttl time.Duration) CacheTTLPolicy {
+	return func(string) time.Duration {
+		return ttl
+	}
+}
+
+// MemoryCache is an in-memory LRU Cache with per-entry expiry.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+	now        func() time.Time
+}
+
+type memoryCacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCache creates an LRU cache holding at most maxEntries values.
+// A non-positive maxEntries means the cache is unbounded.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+		now:        time.Now,
+	}
+}
+
+// Get implements Cache.
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*memoryCacheEntry)
+	if !m.now().Before(entry.expiresAt) {
+		m.removeElement(elem)
+		return nil, false
+	}
+	m.ll.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set implements Cache.
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		m.Delete(key)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expiresAt := m.now().Add(ttl)
+	if elem, ok := m.items[key]; ok {
+		entry := elem.Value.(*memoryCacheEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		m.ll.MoveToFront(elem)
+		return
+	}
+
+	m.items[key] = m.ll.PushFront(&memoryCacheEntry{key: key, value: value, expiresAt: expiresAt})
+	if m.maxEntries > 0 && m.ll.Len() > m.maxEntries {
+		m.removeElement(m.ll.Back())
+	}
+}
+
+// Delete implements Cache.
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.items[key]; ok {
+		m.removeElement(elem)
+	}
+}
+
+// Len returns the number of entries currently held, including expired ones
+// that have not yet been evicted.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ll.Len()
+}
+
+func (m *MemoryCache) removeElement(elem *list.Element) {
+	m.ll.Remove(elem)
+	delete(m.items, elem.Value.(*memoryCacheEntry).key)
+}
+
+// cachedResponse builds a synthetic 200 response around a cached body.
+func cachedResponse(req *http.Request, header http.Header, body []byte) *http.Response {
+	if header == nil {
+		header = http.Header{"Content-Type": []string{"application/json"}}
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// bufferBody reads the full response body and replaces it with an in-memory
+// copy so it can be read again by the caller.
+func bufferBody(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, NewNetworkError("failed to read response body", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// cacheKey returns the cache key of a GET of reqURL made with the given
+// credentials. Keying on the account keeps one account from being served
+// another's response; the credentials are hashed so a shared cache never
+// holds them.
+func cacheKey(token, email, reqURL string) string {
+	sum := sha256.Sum256([]byte(email + "\x00" + token))
+	return hex.EncodeToString(sum[:8]) + " " + reqURL
+}
+
+// cacheIndex records the keys cached for each endpoint, so that a write can
+// invalidate every account and query variant of it. It is safe for
+// concurrent use.
+type cacheIndex struct {
+	mu   sync.Mutex
+	keys map[string]map[string]bool
+}
+
+func newCacheIndex() *cacheIndex {
+	return &cacheIndex{keys: make(map[string]map[string]bool)}
+}
+
+// add records that key caches a response from endpoint.
+func (x *cacheIndex) add(endpoint, key string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.keys[endpoint] == nil {
+		x.keys[endpoint] = make(map[string]bool)
+	}
+	x.keys[endpoint][key] = true
+}
+
+// take forgets and returns the keys of endpoint and of every endpoint below
+// it, such as "seller/inventory/sku/123" for "seller/inventory".
+func (x *cacheIndex) take(endpoint string) []string {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	var keys []string
+	for cached, set := range x.keys {
+		if cached != endpoint && !strings.HasPrefix(cached, endpoint+"/") {
+			continue
+		}
+		for key := range set {
+			keys = append(keys, key)
+		}
+		delete(x.keys, cached)
+	}
+	return keys
+}
+
+// invalidateCache removes every cached response for endpoint and the
+// endpoints below it.
+func (c *Client) invalidateCache(endpoint string) {
+	for _, key := range c.cacheKeys.take(endpoint) {
+		c.cache.Delete(key)
+	}
+}
+
+// storeCachedResponse saves a successful GET response body in the cache.
+func (c *Client) storeCachedResponse(key, endpoint string, resp *http.Response) (*http.Response, error) {
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+	ttl := c.cacheTTL(endpoint)
+	if ttl <= 0 {
+		return resp, nil
+	}
+
+	body, err := bufferBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Set(key, body, ttl)
+	c.cacheKeys.add(endpoint, key)
+	return resp, nil
+}
@@ -0,0 +1,244 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache(t *testing.T) {
+	t.Run("get and set", func(t *testing.T) {
+		cache := NewMemoryCache(0)
+		if _, ok := cache.Get("a"); ok {
+			t.Fatal("Get on empty cache returned ok")
+		}
+		cache.Set("a", []byte("1"), time.Minute)
+		if got, ok := cache.Get("a"); !ok || string(got) != "1" {
+			t.Fatalf("Get(a) = %q, %v; want 1, true", got, ok)
+		}
+		cache.Set("a", []byte("2"), time.Minute)
+		if got, _ := cache.Get("a"); string(got) != "2" {
+			t.Fatalf("Get(a) after overwrite = %q, want 2", got)
+		}
+	})
+
+	t.Run("evicts least recently used", func(t *testing.T) {
+		cache := NewMemoryCache(2)
+		cache.Set("a", []byte("1"), time.Minute)
+		cache.Set("b", []byte("2"), time.Minute)
+		cache.Get("a")
+		cache.Set("c", []byte("3"), time.Minute)
+
+		if _, ok := cache.Get("b"); ok {
+			t.Error("b should have been evicted")
+		}
+		if _, ok := cache.Get("a"); !ok {
+			t.Error("a should still be cached")
+		}
+		if cache.Len() != 2 {
+			t.Errorf("Len() = %d, want 2", cache.Len())
+		}
+	})
+
+	t.Run("expires entries", func(t *testing.T) {
+		now := time.Now()
+		cache := NewMemoryCache(0)
+		cache.now = func() time.Time { return now }
+		cache.Set("a", []byte("1"), time.Second)
+
+		now = now.Add(2 * time.Second)
+		if _, ok := cache.Get("a"); ok {
+			t.Error("expired entry returned")
+		}
+		if cache.Len() != 0 {
+			t.Errorf("Len() = %d, want 0", cache.Len())
+		}
+	})
+
+	t.Run("non-positive ttl deletes", func(t *testing.T) {
+		cache := NewMemoryCache(0)
+		cache.Set("a", []byte("1"), time.Minute)
+		cache.Set("a", []byte("2"), 0)
+		if _, ok := cache.Get("a"); ok {
+			t.Error("entry with zero ttl returned")
+		}
+	})
+}
+
+func TestClient_WithCache(t *testing.T) {
+	var gets, puts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			atomic.AddInt32(&gets, 1)
+		case http.MethodPut:
+			atomic.AddInt32(&puts, 1)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"username":"seller"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithCache(NewMemoryCache(10), nil),
+	)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		account, err := client.GetSellerAccount(ctx)
+		if err != nil {
+			t.Fatalf("GetSellerAccount() error = %v", err)
+		}
+		if account.Username != "seller" {
+			t.Errorf("Username = %q, want seller", account.Username)
+		}
+	}
+	if got := atomic.LoadInt32(&gets); got != 1 {
+		t.Errorf("GET requests = %d, want 1", got)
+	}
+
+	if _, err := client.UpdateSellerAccount(ctx, SellerAccountUpdate{}); err != nil {
+		t.Fatalf("UpdateSellerAccount() error = %v", err)
+	}
+	if _, err := client.GetSellerAccount(ctx); err != nil {
+		t.Fatalf("GetSellerAccount() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&gets); got != 2 {
+		t.Errorf("GET requests after write = %d, want 2", got)
+	}
+}
+
+func TestClient_WithCache_InvalidatesQueryVariants(t *testing.T) {
+	var gets int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			atomic.AddInt32(&gets, 1)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithCache(NewMemoryCache(10), nil))
+	ctx := context.Background()
+	get := func(endpoint string, params url.Values) {
+		t.Helper()
+		resp, err := client.doRequest(ctx, http.MethodGet, endpoint, params)
+		if err != nil {
+			t.Fatalf("GET %s error = %v", endpoint, err)
+		}
+		_ = resp.Body.Close()
+	}
+	reads := func() {
+		get("seller/inventory", url.Values{"limit": {"10"}, "offset": {"0"}})
+		get("seller/inventory", url.Values{"limit": {"10"}, "offset": {"10"}})
+		get("seller/inventory/tcgsku/1", nil)
+		get("account", nil)
+	}
+
+	reads()
+	reads()
+	if got := atomic.LoadInt32(&gets); got != 4 {
+		t.Fatalf("GET requests = %d, want 4", got)
+	}
+
+	resp, err := client.doRequest(ctx, http.MethodPost, "seller/inventory", nil)
+	if err != nil {
+		t.Fatalf("POST error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	// Both inventory pages and the listing below them are fetched again;
+	// the account is still cached.
+	reads()
+	if got := atomic.LoadInt32(&gets); got != 7 {
+		t.Errorf("GET requests after write = %d, want 7", got)
+	}
+}
+
+func TestClient_WithCache_PerAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"username":"` + r.Header.Get("X-ManaPool-Access-Token") + `"}`))
+	}))
+	defer server.Close()
+
+	type tenantKey struct{}
+	client := NewClient("", "",
+		WithBaseURL(server.URL+"/"),
+		WithCache(NewMemoryCache(10), nil),
+		WithCredentialsProvider(CredentialsFunc(func(ctx context.Context) (string, string, error) {
+			return ctx.Value(tenantKey{}).(string), "email", nil
+		})),
+	)
+
+	for _, token := range []string{"token-a", "token-b", "token-a"} {
+		ctx := context.WithValue(context.Background(), tenantKey{}, token)
+		account, err := client.GetSellerAccount(ctx)
+		if err != nil {
+			t.Fatalf("GetSellerAccount() error = %v", err)
+		}
+		if account.Username != token {
+			t.Errorf("account for %s = %q, served another account's response", token, account.Username)
+		}
+	}
+}
+
+func TestClient_WithCache_TTLPolicy(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	policy := func(endpoint string) time.Duration {
+		if endpoint == "account" {
+			return time.Minute
+		}
+		return 0
+	}
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithCache(NewMemoryCache(10), policy),
+	)
+	ctx := context.Background()
+
+	for _, endpoint := range []string{"/account", "/account", "/webhooks", "/webhooks", "/missing", "/missing"} {
+		resp, err := client.doRequest(ctx, "GET", endpoint, nil)
+		if err != nil {
+			t.Fatalf("doRequest(%s) error = %v", endpoint, err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 5 {
+		t.Errorf("requests = %d, want 5", got)
+	}
+}
+
+func TestClient_storeCachedResponse_ReadError(t *testing.T) {
+	client := NewClient("token", "email", WithCache(NewMemoryCache(1), nil))
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(errReader{errors.New("boom")}),
+	}
+
+	_, err := client.storeCachedResponse("key", "account", resp)
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) {
+		t.Fatalf("error = %v, want NetworkError", err)
+	}
+}
@@ -0,0 +1,204 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// ErrUnsupportedEndpoint is matched, with errors.Is, by the
+// *UnsupportedEndpointError returned when the API or account does not offer
+// an optional part of the API.
+var ErrUnsupportedEndpoint = errors.New("unsupported endpoint")
+
+// Capability names an optional part of the API that an account or API
+// deployment may not offer.
+type Capability string
+
+const (
+	// CapabilitySellerInventory is the seller inventory endpoints, used by
+	// SyncInventory and WatchInventory.
+	CapabilitySellerInventory Capability = "seller_inventory"
+
+	// CapabilitySellerOrders is the seller order endpoints, used by
+	// WatchOrders.
+	CapabilitySellerOrders Capability = "seller_orders"
+
+	// CapabilityWebhooks is webhook registration.
+	CapabilityWebhooks Capability = "webhooks"
+
+	// CapabilityBuyer is the buyer endpoints: credit, the optimizer, and
+	// pending orders.
+	CapabilityBuyer Capability = "buyer"
+)
+
+// capabilityProbes lists the cheap read each capability is detected with.
+var capabilityProbes = []struct {
+	capability Capability
+	endpoint   string
+	params     url.Values
+}{
+	{CapabilitySellerInventory, "/seller/inventory", url.Values{"limit": {"1"}}},
+	{CapabilitySellerOrders, "/seller/orders", url.Values{"limit": {"1"}}},
+	{CapabilityWebhooks, "/webhooks", nil},
+	{CapabilityBuyer, "/buyer/credit", nil},
+}
+
+// UnsupportedEndpointError reports that the API or account does not offer a
+// capability. It matches ErrUnsupportedEndpoint.
+type UnsupportedEndpointError struct {
+	Capability Capability
+
+	// Err is the API error that revealed the missing capability, or nil if
+	// it was already known from an earlier call
+	Err error
+}
+
+// Error implements the error interface.
+func (e *UnsupportedEndpointError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("manapool API does not support %s: %v", e.Capability, e.Err)
+	}
+	return fmt.Sprintf("manapool API does not support %s", e.Capability)
+}
+
+// Is reports whether target is ErrUnsupportedEndpoint.
+func (e *UnsupportedEndpointError) Is(target error) bool {
+	return target == ErrUnsupportedEndpoint
+}
+
+// Unwrap returns the underlying API error.
+func (e *UnsupportedEndpointError) Unwrap() error {
+	return e.Err
+}
+
+// Capabilities records which optional parts of the API are available.
+// Capabilities that could not be checked are absent.
+type Capabilities map[Capability]bool
+
+// Supports reports whether capability was found to be available.
+func (c Capabilities) Supports(capability Capability) bool {
+	return c[capability]
+}
+
+// Capabilities detects which optional parts of the API the account can
+// use, with one cheap read per capability. The API has no discovery
+// document, so a capability is unsupported when its probe is answered with
+// 404 Not Found, 405 Method Not Allowed, or 501 Not Implemented. A 403
+// Forbidden may be a transient permission problem, so it is reported as a
+// probe failure rather than remembered. Probes are not retried and skip the
+// response cache.
+//
+// The results are remembered by the client: SyncInventory, WatchInventory,
+// and WatchOrders then fail with an *UnsupportedEndpointError without
+// calling the API. They also learn this on their own from the first such
+// response, so calling Capabilities is optional.
+//
+// Probes that fail for another reason, such as a server error, leave their
+// capability out of the result, and their errors are joined in the returned
+// error. Invalid credentials stop probing.
+//
+// Example:
+//
+//	caps, err := client.Capabilities(ctx)
+//	if err != nil {
+//	    log.Printf("some capabilities could not be checked: %v", err)
+//	}
+//	if caps.Supports(manapool.CapabilitySellerOrders) {
+//	    go watchOrders(ctx, client)
+//	}
+func (c *Client) Capabilities(ctx context.Context) (Capabilities, error) {
+	ctx = WithRequestOptions(ctx, NoRetry(), BypassCache())
+	caps := Capabilities{}
+	var errs []error
+	for _, probe := range capabilityProbes {
+		resp, err := c.doRequest(ctx, http.MethodGet, probe.endpoint, probe.params)
+		if err == nil {
+			err = c.decodeResponse(resp, nil)
+		}
+
+		var apiErr *APIError
+		switch {
+		case err == nil:
+			caps[probe.capability] = true
+		case errors.As(err, &apiErr) && apiErr.IsUnauthorized():
+			return caps, fmt.Errorf("failed to probe %s: %w", probe.capability, err)
+		case isUnsupportedStatus(err):
+			caps[probe.capability] = false
+		default:
+			errs = append(errs, fmt.Errorf("failed to probe %s: %w", probe.capability, err))
+			continue
+		}
+		c.capabilities.set(probe.capability, caps[probe.capability])
+	}
+	c.logger.Debugf("Capabilities: %v", caps)
+	return caps, errors.Join(errs...)
+}
+
+// requireCapability returns an *UnsupportedEndpointError if capability is
+// known to be unsupported.
+func (c *Client) requireCapability(capability Capability) error {
+	if supported, known := c.capabilities.get(capability); known && !supported {
+		return &UnsupportedEndpointError{Capability: capability}
+	}
+	return nil
+}
+
+// unsupportedEndpoint converts err into an *UnsupportedEndpointError, and
+// remembers that capability is unsupported, if err shows the endpoint is
+// missing. Other errors are returned unchanged.
+func (c *Client) unsupportedEndpoint(capability Capability, err error) error {
+	if err == nil || !isUnsupportedStatus(err) {
+		return err
+	}
+	c.capabilities.set(capability, false)
+	return &UnsupportedEndpointError{Capability: capability, Err: err}
+}
+
+// isUnsupportedStatus reports whether err is an API error showing that an
+// endpoint does not exist. 403 Forbidden is not included: permissions can
+// change, and a watcher that saw one should keep polling.
+func isUnsupportedStatus(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+// capabilitySet remembers which capabilities are known to be supported.
+// A nil set remembers nothing.
+type capabilitySet struct {
+	mu    sync.Mutex
+	known map[Capability]bool
+}
+
+func newCapabilitySet() *capabilitySet {
+	return &capabilitySet{known: map[Capability]bool{}}
+}
+
+func (s *capabilitySet) set(capability Capability, supported bool) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.known[capability] = supported
+}
+
+func (s *capabilitySet) get(capability Capability) (supported, known bool) {
+	if s == nil {
+		return false, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	supported, known = s.known[capability]
+	return supported, known
+}
@@ -0,0 +1,222 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// capabilityServer answers the capability probe endpoints with the given
+// statuses (200 if absent) and counts requests per path.
+func capabilityServer(t *testing.T, statuses map[string]int) (*httptest.Server, func(path string) int) {
+	t.Helper()
+	var mu sync.Mutex
+	counts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		counts[r.URL.Path]++
+		mu.Unlock()
+		if status, ok := statuses[r.URL.Path]; ok {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"detail":"` + http.StatusText(status) + `"}`))
+			return
+		}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/inventory"):
+			_, _ = w.Write([]byte(`{"inventory":[],"pagination":{"total":0,"returned":0,"offset":0,"limit":1}}`))
+		case strings.HasSuffix(r.URL.Path, "/orders"):
+			_, _ = w.Write([]byte(`{"orders":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, func(path string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return counts[path]
+	}
+}
+
+func TestClient_Capabilities(t *testing.T) {
+	server, count := capabilityServer(t, map[string]int{
+		"/seller/orders": http.StatusNotFound,
+		"/webhooks":      http.StatusServiceUnavailable,
+		"/buyer/credit":  http.StatusNotImplemented,
+	})
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(3, 0))
+
+	caps, err := client.Capabilities(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to probe webhooks") {
+		t.Errorf("Capabilities() error = %v, want webhooks probe failure", err)
+	}
+	if !caps.Supports(CapabilitySellerInventory) || caps.Supports(CapabilitySellerOrders) || caps.Supports(CapabilityBuyer) {
+		t.Errorf("Capabilities() = %v", caps)
+	}
+	if _, known := caps[CapabilityWebhooks]; known {
+		t.Errorf("webhooks should be unknown after a server error: %v", caps)
+	}
+	if count("/webhooks") != 1 {
+		t.Errorf("webhooks probed %d times, want 1 (no retries)", count("/webhooks"))
+	}
+
+	// The orders watcher stops without polling the missing endpoint.
+	var watchErr error
+	orders := client.WatchOrders(context.Background(), PollOptions{Interval: time.Millisecond, OnError: func(err error) { watchErr = err }})
+	for range orders {
+		t.Error("unexpected order")
+	}
+	var unsupported *UnsupportedEndpointError
+	if !errors.As(watchErr, &unsupported) || unsupported.Capability != CapabilitySellerOrders || unsupported.Err != nil {
+		t.Errorf("WatchOrders error = %v", watchErr)
+	}
+	if count("/seller/orders") != 1 {
+		t.Errorf("seller orders requested %d times, want only the probe", count("/seller/orders"))
+	}
+}
+
+func TestClient_Capabilities_Unauthorized(t *testing.T) {
+	server, count := capabilityServer(t, map[string]int{"/seller/inventory": http.StatusUnauthorized})
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+
+	caps, err := client.Capabilities(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsUnauthorized() || len(caps) != 0 {
+		t.Errorf("Capabilities() = %v, %v", caps, err)
+	}
+	if count("/seller/orders") != 0 {
+		t.Error("probing continued after an authentication failure")
+	}
+}
+
+func TestClient_Capabilities_Forbidden(t *testing.T) {
+	server, _ := capabilityServer(t, map[string]int{"/seller/orders": http.StatusForbidden})
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+
+	caps, err := client.Capabilities(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to probe seller_orders") {
+		t.Errorf("Capabilities() error = %v, want seller orders probe failure", err)
+	}
+	if _, known := caps[CapabilitySellerOrders]; known || errors.Is(err, ErrUnsupportedEndpoint) {
+		t.Errorf("403 was treated as unsupported: %v, %v", caps, err)
+	}
+	if err := client.requireCapability(CapabilitySellerOrders); err != nil {
+		t.Errorf("requireCapability() = %v after a 403", err)
+	}
+}
+
+// forbiddenOnceServer answers the first request to each path with 403 and
+// later ones with a listing or order whose price rises on every request.
+func forbiddenOnceServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	counts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		counts[r.URL.Path]++
+		n := counts[r.URL.Path]
+		mu.Unlock()
+		if n == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"detail":"Forbidden"}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/seller/inventory":
+			_, _ = fmt.Fprintf(w, `{"inventory":[{"id":"a","price_cents":%d,"quantity":1}],"pagination":{"total":1,"returned":1,"offset":0,"limit":500}}`, 100*n)
+		case "/seller/orders":
+			_, _ = w.Write([]byte(`{"orders":[{"id":"o1","created_at":"2025-08-05T20:38:54Z"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_WatchOrders_SurvivesForbidden(t *testing.T) {
+	server := forbiddenOnceServer(t)
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var errs []error
+	orders := client.WatchOrders(ctx, PollOptions{Interval: time.Millisecond, OnError: func(err error) { errs = append(errs, err) }})
+	order, ok := <-orders
+	if !ok || order.ID != "o1" {
+		t.Fatalf("WatchOrders stopped after a 403: %+v, %v", order, ok)
+	}
+	if len(errs) != 1 || errors.Is(errs[0], ErrUnsupportedEndpoint) {
+		t.Errorf("errors = %v, want one plain 403", errs)
+	}
+}
+
+func TestClient_WatchInventory_SurvivesForbidden(t *testing.T) {
+	server := forbiddenOnceServer(t)
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events := client.WatchInventory(ctx, time.Millisecond)
+	event := <-events
+	var apiErr *APIError
+	if event.Type != InventoryWatchError || !errors.As(event.Err, &apiErr) || !apiErr.IsForbidden() ||
+		errors.Is(event.Err, ErrUnsupportedEndpoint) {
+		t.Fatalf("first event = %+v, want a 403 error", event)
+	}
+	event, ok := <-events
+	if !ok || event.Type != InventoryPriceChanged {
+		t.Errorf("WatchInventory stopped after a 403: %+v, %v", event, ok)
+	}
+}
+
+func TestClient_SyncInventory_Unsupported(t *testing.T) {
+	server, count := capabilityServer(t, map[string]int{"/seller/inventory": http.StatusNotFound})
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	ctx := context.Background()
+
+	// The first failure is learned without probing first.
+	_, err := client.SyncInventory(ctx, nil, SyncOptions{})
+	var unsupported *UnsupportedEndpointError
+	if !errors.Is(err, ErrUnsupportedEndpoint) || !errors.As(err, &unsupported) || unsupported.Err == nil {
+		t.Fatalf("SyncInventory() error = %v, want unsupported endpoint with cause", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("error %v does not wrap the API error", err)
+	}
+
+	// Later calls fail without a request.
+	if _, err := client.SyncInventory(ctx, nil, SyncOptions{}); !errors.Is(err, ErrUnsupportedEndpoint) {
+		t.Errorf("second SyncInventory() error = %v", err)
+	}
+	events := client.WatchInventory(ctx, time.Millisecond)
+	event, ok := <-events
+	if !ok || event.Type != InventoryWatchError || !errors.Is(event.Err, ErrUnsupportedEndpoint) {
+		t.Errorf("WatchInventory event = %+v, %v", event, ok)
+	}
+	if _, ok := <-events; ok {
+		t.Error("WatchInventory kept polling an unsupported endpoint")
+	}
+	if count("/seller/inventory") != 1 {
+		t.Errorf("seller inventory requested %d times, want 1", count("/seller/inventory"))
+	}
+}
+
+func TestUnsupportedEndpointError(t *testing.T) {
+	err := &UnsupportedEndpointError{Capability: CapabilityWebhooks}
+	if err.Error() != "manapool API does not support webhooks" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	cause := NewAPIError(http.StatusNotFound, "not found")
+	err = &UnsupportedEndpointError{Capability: CapabilityWebhooks, Err: cause}
+	if !strings.HasSuffix(err.Error(), cause.Error()) || !errors.Is(err, ErrUnsupportedEndpoint) {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
@@ -0,0 +1,252 @@
+// Package cardmarket prices ManaPool inventory against Cardmarket (MKM)
+// price guides.
+//
+// Cardmarket publishes a daily price guide for Magic singles and sealed
+// product as a JSON download, priced in euros and keyed by Cardmarket
+// product ID (idProduct). LoadPriceGuide reads that file, and Source adapts
+// it to manapool.PriceSource, converting euros to US cents with the
+// caller's manapool.ExchangeRates.
+//
+// ManaPool listings do not carry Cardmarket product IDs, so Source needs a
+// ProductMapper from ManaPool product IDs. MTGJSON's AllIdentifiers dataset
+// links Scryfall IDs to Cardmarket IDs (see mtgjson.Identifiers.CardmarketID).
+//
+// # Basic Usage
+//
+//	f, err := os.Open("price_guide_1.json")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer f.Close()
+//
+//	guide, err := cardmarket.LoadPriceGuide(f)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	rates, err := manapool.NewStaticRates(manapool.ExchangeRate{
+//	    From: manapool.EUR, To: manapool.USD, Rate: 1.08, Source: "ECB", AsOf: published,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	source, err := cardmarket.NewSource(guide, cardmarket.MapProductIDs(ids), rates)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	result, err := repricer.New(nil, rules).WithPrices(source).Evaluate(ctx, inventory)
+package cardmarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/repricah/manapool"
+)
+
+// Entry is one product's prices from a price guide, in euros. Prices the
+// guide omits or reports as null are nil.
+type Entry struct {
+	ProductID  int `json:"idProduct"`
+	CategoryID int `json:"idCategory"`
+
+	Avg   *float64 `json:"avg"`
+	Low   *float64 `json:"low"`
+	Trend *float64 `json:"trend"`
+	Avg1  *float64 `json:"avg1"`
+	Avg7  *float64 `json:"avg7"`
+	Avg30 *float64 `json:"avg30"`
+
+	AvgFoil   *float64 `json:"avg-foil"`
+	LowFoil   *float64 `json:"low-foil"`
+	TrendFoil *float64 `json:"trend-foil"`
+	Avg1Foil  *float64 `json:"avg1-foil"`
+	Avg7Foil  *float64 `json:"avg7-foil"`
+	Avg30Foil *float64 `json:"avg30-foil"`
+}
+
+// PriceField selects which price guide column Source reports.
+type PriceField string
+
+const (
+	// Trend is Cardmarket's trend price, the default
+	Trend PriceField = "trend"
+
+	// Low is the lowest current listing
+	Low PriceField = "low"
+
+	// Avg is the average sell price
+	Avg PriceField = "avg"
+
+	// Avg7 is the 7-day average sell price
+	Avg7 PriceField = "avg7"
+
+	// Avg30 is the 30-day average sell price
+	Avg30 PriceField = "avg30"
+)
+
+// Price returns the entry's price in euros for field, using the foil columns
+// if foil is true. It returns false if the guide has no positive price.
+func (e Entry) Price(field PriceField, foil bool) (float64, bool) {
+	var p *float64
+	switch field {
+	case Trend:
+		p = pick(foil, e.TrendFoil, e.Trend)
+	case Low:
+		p = pick(foil, e.LowFoil, e.Low)
+	case Avg:
+		p = pick(foil, e.AvgFoil, e.Avg)
+	case Avg7:
+		p = pick(foil, e.Avg7Foil, e.Avg7)
+	case Avg30:
+		p = pick(foil, e.Avg30Foil, e.Avg30)
+	}
+	if p == nil || *p <= 0 {
+		return 0, false
+	}
+	return *p, true
+}
+
+func pick(foil bool, foilPrice, price *float64) *float64 {
+	if foil {
+		return foilPrice
+	}
+	return price
+}
+
+// PriceGuide is a loaded Cardmarket price guide.
+type PriceGuide struct {
+	// CreatedAt is when Cardmarket generated the guide
+	CreatedAt manapool.Timestamp
+
+	byProduct map[int]Entry
+}
+
+type priceGuideFile struct {
+	Version     int                `json:"version"`
+	CreatedAt   manapool.Timestamp `json:"createdAt"`
+	PriceGuides []Entry            `json:"priceGuides"`
+}
+
+// LoadPriceGuide reads a Cardmarket price guide JSON file.
+func LoadPriceGuide(r io.Reader) (*PriceGuide, error) {
+	var file priceGuideFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode price guide: %w", err)
+	}
+
+	guide := &PriceGuide{CreatedAt: file.CreatedAt, byProduct: make(map[int]Entry, len(file.PriceGuides))}
+	for _, entry := range file.PriceGuides {
+		guide.byProduct[entry.ProductID] = entry
+	}
+	return guide, nil
+}
+
+// Len returns the number of products in the guide.
+func (g *PriceGuide) Len() int {
+	return len(g.byProduct)
+}
+
+// Get returns the entry for a Cardmarket product ID.
+func (g *PriceGuide) Get(productID int) (Entry, bool) {
+	entry, ok := g.byProduct[productID]
+	return entry, ok
+}
+
+// ProductMapper returns the Cardmarket product ID for a ManaPool product
+// variant, or false if it has none.
+type ProductMapper func(key manapool.ProductKey) (cardmarketID int, ok bool)
+
+// MapProductIDs returns a ProductMapper that looks up ManaPool product IDs in
+// ids.
+func MapProductIDs(ids map[string]int) ProductMapper {
+	return func(key manapool.ProductKey) (int, bool) {
+		id, ok := ids[key.ProductID]
+		return id, ok
+	}
+}
+
+// Source is a manapool.PriceSource backed by a Cardmarket price guide.
+type Source struct {
+	guide  *PriceGuide
+	mapper ProductMapper
+	rates  manapool.ExchangeRates
+	field  PriceField
+}
+
+var _ manapool.PriceSource = (*Source)(nil)
+
+// Option configures a Source.
+type Option func(*Source)
+
+// WithPriceField selects the price guide column to report (default: Trend).
+func WithPriceField(field PriceField) Option {
+	return func(s *Source) {
+		s.field = field
+	}
+}
+
+// NewSource creates a Source. rates must have a EUR to USD rate; it is looked
+// up on every Prices call, so rates that refresh are picked up.
+func NewSource(guide *PriceGuide, mapper ProductMapper, rates manapool.ExchangeRates, opts ...Option) (*Source, error) {
+	s := &Source{guide: guide, mapper: mapper, rates: rates, field: Trend}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	switch {
+	case guide == nil:
+		return nil, manapool.NewValidationError("guide", "price guide is required")
+	case mapper == nil:
+		return nil, manapool.NewValidationError("mapper", "product mapper is required")
+	case rates == nil:
+		return nil, manapool.NewValidationError("rates", "exchange rates are required")
+	}
+	if _, err := rates.Rate(manapool.EUR, manapool.USD); err != nil {
+		return nil, fmt.Errorf("failed to create cardmarket source: %w", err)
+	}
+	switch s.field {
+	case Trend, Low, Avg, Avg7, Avg30:
+	default:
+		return nil, manapool.NewValidationError("field", fmt.Sprintf("unknown price field %q", s.field))
+	}
+	return s, nil
+}
+
+// Prices implements manapool.PriceSource. Foil and etched keys use the
+// guide's foil prices. Prices are rounded to the euro cent, as Cardmarket
+// lists them, then converted to the nearest US cent.
+func (s *Source) Prices(ctx context.Context, keys []manapool.ProductKey) (map[manapool.ProductKey]manapool.ReferencePrice, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if _, err := s.rates.Rate(manapool.EUR, manapool.USD); err != nil {
+		return nil, fmt.Errorf("failed to get cardmarket prices: %w", err)
+	}
+
+	prices := make(map[manapool.ProductKey]manapool.ReferencePrice, len(keys))
+	for _, key := range keys {
+		id, ok := s.mapper(key)
+		if !ok {
+			continue
+		}
+		entry, ok := s.guide.Get(id)
+		if !ok {
+			continue
+		}
+		foil := key.FinishID == "FO" || key.FinishID == "EF"
+		euros, ok := entry.Price(s.field, foil)
+		if !ok {
+			continue
+		}
+		eur := manapool.Money{Amount: int(math.Round(euros * 100)), Currency: manapool.EUR}
+		usd, _, err := eur.ConvertTo(manapool.USD, s.rates)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cardmarket prices: %w", err)
+		}
+		prices[key] = manapool.ReferencePrice{Cents: usd.Amount, AsOf: s.guide.CreatedAt.Time}
+	}
+	return prices, nil
+}
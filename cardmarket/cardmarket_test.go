@@ -0,0 +1,139 @@
+package cardmarket
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool"
+)
+
+const guideSample = `{
+  "version": 1,
+  "createdAt": "2025-03-01T02:37:11+0100",
+  "priceGuides": [
+    {"idProduct": 100, "idCategory": 1, "avg": 1.5, "low": 0.9, "trend": 1.25, "avg1": 1.4, "avg7": 1.3, "avg30": 1.2,
+     "avg-foil": 6, "low-foil": 4.5, "trend-foil": 5.5, "avg1-foil": null, "avg7-foil": 5.8, "avg30-foil": 5.9},
+    {"idProduct": 200, "idCategory": 1, "avg": 10, "low": 8, "trend": 0, "avg-foil": null}
+  ]
+}`
+
+func loadGuide(t *testing.T) *PriceGuide {
+	t.Helper()
+	guide, err := LoadPriceGuide(strings.NewReader(guideSample))
+	if err != nil {
+		t.Fatalf("LoadPriceGuide() error = %v", err)
+	}
+	return guide
+}
+
+func TestLoadPriceGuide(t *testing.T) {
+	guide := loadGuide(t)
+	if guide.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", guide.Len())
+	}
+	if guide.CreatedAt.UTC().Format("2006-01-02T15:04") != "2025-03-01T01:37" {
+		t.Errorf("CreatedAt = %v", guide.CreatedAt)
+	}
+
+	entry, ok := guide.Get(100)
+	if !ok {
+		t.Fatal("Get(100) not found")
+	}
+	for _, tt := range []struct {
+		field  PriceField
+		foil   bool
+		want   float64
+		wantOK bool
+	}{
+		{Trend, false, 1.25, true},
+		{Trend, true, 5.5, true},
+		{Low, false, 0.9, true},
+		{Avg7, true, 5.8, true},
+		{Avg30, false, 1.2, true},
+		{PriceField("avg1"), false, 0, false},
+	} {
+		if got, ok := entry.Price(tt.field, tt.foil); got != tt.want || ok != tt.wantOK {
+			t.Errorf("Price(%s, %v) = %v, %v; want %v, %v", tt.field, tt.foil, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	if _, err := LoadPriceGuide(strings.NewReader("{")); err == nil {
+		t.Error("LoadPriceGuide(truncated) error = nil")
+	}
+}
+
+func eurRates(t *testing.T, rate float64) manapool.ExchangeRates {
+	t.Helper()
+	rates, err := manapool.NewStaticRates(manapool.ExchangeRate{From: manapool.EUR, To: manapool.USD, Rate: rate})
+	if err != nil {
+		t.Fatalf("NewStaticRates() error = %v", err)
+	}
+	return rates
+}
+
+func TestSource_Prices(t *testing.T) {
+	guide := loadGuide(t)
+	source, err := NewSource(guide, MapProductIDs(map[string]int{"bolt": 100, "ring": 200, "gone": 300}), eurRates(t, 1.1))
+	if err != nil {
+		t.Fatalf("NewSource() error = %v", err)
+	}
+
+	nonFoil := manapool.ProductKey{ProductID: "bolt", ConditionID: "NM", FinishID: "NF", LanguageID: "EN"}
+	foil := manapool.ProductKey{ProductID: "bolt", ConditionID: "NM", FinishID: "FO", LanguageID: "EN"}
+	noTrend := manapool.ProductKey{ProductID: "ring"}
+	keys := []manapool.ProductKey{nonFoil, foil, noTrend, {ProductID: "gone"}, {ProductID: "unmapped"}}
+
+	prices, err := source.Prices(context.Background(), keys)
+	if err != nil {
+		t.Fatalf("Prices() error = %v", err)
+	}
+	if len(prices) != 2 || prices[nonFoil].Cents != 138 || prices[foil].Cents != 605 {
+		t.Errorf("Prices() = %+v", prices)
+	}
+	if !prices[nonFoil].AsOf.Equal(guide.CreatedAt.Time) {
+		t.Errorf("AsOf = %v, want %v", prices[nonFoil].AsOf, guide.CreatedAt)
+	}
+
+	low, err := NewSource(guide, MapProductIDs(map[string]int{"ring": 200}), eurRates(t, 1), WithPriceField(Low))
+	if err != nil {
+		t.Fatalf("NewSource(Low) error = %v", err)
+	}
+	if prices, _ := low.Prices(context.Background(), []manapool.ProductKey{noTrend}); prices[noTrend].Cents != 800 {
+		t.Errorf("Prices(Low) = %+v", prices)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := source.Prices(ctx, keys); !errors.Is(err, context.Canceled) {
+		t.Errorf("Prices(cancelled) error = %v", err)
+	}
+}
+
+func TestNewSource_Invalid(t *testing.T) {
+	guide := loadGuide(t)
+	mapper := MapProductIDs(nil)
+	rates := eurRates(t, 1.1)
+	for name, build := range map[string]func() (*Source, error){
+		"no guide":      func() (*Source, error) { return NewSource(nil, mapper, rates) },
+		"no mapper":     func() (*Source, error) { return NewSource(guide, nil, rates) },
+		"no rates":      func() (*Source, error) { return NewSource(guide, mapper, nil) },
+		"unknown field": func() (*Source, error) { return NewSource(guide, mapper, rates, WithPriceField("median")) },
+	} {
+		var validationErr *manapool.ValidationError
+		if _, err := build(); !errors.As(err, &validationErr) {
+			t.Errorf("%s: NewSource() error = %v", name, err)
+		}
+	}
+}
+
+func TestNewSource_MissingRate(t *testing.T) {
+	gbp, err := manapool.NewStaticRates(manapool.ExchangeRate{From: manapool.GBP, To: manapool.USD, Rate: 1.27})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSource(loadGuide(t), MapProductIDs(nil), gbp); !errors.Is(err, manapool.ErrNoExchangeRate) {
+		t.Errorf("NewSource(no EUR rate) error = %v", err)
+	}
+}
@@ -3,7 +3,6 @@ package manapool
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -59,14 +58,23 @@ type Client struct {
 	// baseURL is the base URL for the API
 	baseURL string
 
+	// apiVersion replaces the base URL's version segment ("" keeps it)
+	apiVersion string
+
+	// endpointVersions pins endpoint path prefixes to an API version
+	endpointVersions map[string]string
+
 	// authToken is the API authentication token
 	authToken string
 
 	// email is the account email address
 	email string
 
-	// rateLimiter limits the rate of API requests
-	rateLimiter *rate.Limiter
+	// credentialsProvider, if set, supplies the token and email per request
+	credentialsProvider CredentialsProvider
+
+	// rateLimiter limits the rate of API requests (nil disables)
+	rateLimiter Limiter
 
 	// maxRetries is the maximum number of retry attempts
 	maxRetries int
@@ -74,11 +82,87 @@ type Client struct {
 	// initialBackoff is the initial backoff duration for retries
 	initialBackoff time.Duration
 
+	// retryPolicy selects the retried statuses and methods
+	retryPolicy RetryPolicy
+
+	// maxBackoff caps each backoff sleep between retries (0 disables)
+	maxBackoff time.Duration
+
+	// maxRetryDuration caps the time spent retrying a request (0 disables)
+	maxRetryDuration time.Duration
+
 	// userAgent is the User-Agent header value
 	userAgent string
 
 	// logger is used for debug and error logging
 	logger Logger
+
+	// debugDump receives wire-level request/response dumps (nil disables)
+	debugDump io.Writer
+
+	// etagCache stores ETag-validated GET responses (nil disables)
+	etagCache *etagCache
+
+	// cache stores GET response bodies (nil disables)
+	cache Cache
+
+	// cacheKeys indexes the keys stored in cache by endpoint
+	cacheKeys *cacheIndex
+
+	// cacheTTL decides how long each endpoint's responses are cached
+	cacheTTL CacheTTLPolicy
+
+	// disableCompression turns off gzip for requests and responses
+	disableCompression bool
+
+	// requestCompressionMin is the minimum JSON body size to gzip (0 disables)
+	requestCompressionMin int
+
+	// flights coalesces identical concurrent GET requests (nil disables)
+	flights *flightGroup
+
+	// rateStatus records the quota headers of API responses
+	rateStatus *rateLimitTracker
+
+	// capabilities remembers optional API parts found to be unsupported
+	capabilities *capabilitySet
+
+	// endpointTimeouts bounds each attempt by endpoint class (zero fields
+	// leave the class to httpClient's timeout). NewClient resolves it once
+	// every option has run.
+	endpointTimeouts EndpointTimeouts
+
+	// explicitTimeouts is the value passed to WithEndpointTimeouts (nil if
+	// it was not used)
+	explicitTimeouts *EndpointTimeouts
+
+	// clientTimeoutSet records that WithTimeout or WithHTTPClient was used
+	clientTimeoutSet bool
+
+	// captureRaw keeps raw response bodies on types embedding RawResponse
+	captureRaw bool
+
+	// journal records inventory writes before they are sent (nil disables)
+	journal Journal
+
+	// auditSink records successful mutating calls (nil disables)
+	auditSink AuditSink
+
+	// signingSecret signs every request with an HMAC header ("" disables)
+	signingSecret string
+
+	// jsonMarshal and jsonUnmarshal replace encoding/json (nil keeps it)
+	jsonMarshal   JSONMarshalFunc
+	jsonUnmarshal JSONUnmarshalFunc
+
+	// strictDecoding rejects unknown fields and enum values in responses
+	strictDecoding bool
+
+	// lenientTimestamps accepts timestamps that match no layout
+	lenientTimestamps bool
+
+	// validateResponses runs ValidateResponse on every decoded response
+	validateResponses bool
 }
 
 // Logger is an interface for logging.
@@ -98,7 +182,8 @@ func (l *noopLogger) Debugf(format string, args ...interface{}) {}
 func (l *noopLogger) Errorf(format string, args ...interface{}) {}
 
 // NewClient creates a new Manapool API client.
-// The authToken and email parameters are required for authentication.
+// The authToken and email parameters are required for authentication unless
+// WithCredentialsProvider is used to supply them per request.
 // Additional options can be passed to configure the client.
 //
 // Example:
@@ -120,6 +205,8 @@ func NewClient(authToken, email string, opts ...ClientOption) *Client {
 		initialBackoff: DefaultInitialBackoff,
 		userAgent:      fmt.Sprintf("manapool-go/%s", Version),
 		logger:         &noopLogger{},
+		rateStatus:     newRateLimitTracker(),
+		capabilities:   newCapabilitySet(),
 	}
 
 	// Apply options
@@ -127,26 +214,121 @@ func NewClient(authToken, email string, opts ...ClientOption) *Client {
 		opt(client)
 	}
 
+	client.endpointTimeouts = client.resolveEndpointTimeouts()
+
 	return client
 }
 
 // doRequest executes an HTTP request with rate limiting, retries, and error handling.
 func (c *Client) doRequest(ctx context.Context, method, endpoint string, params url.Values) (*http.Response, error) {
+	if c.journaled(method, endpoint) {
+		return c.doJournaledRequest(ctx, method, endpoint, params, nil, func() (*http.Response, error) {
+			return c.doRequestWithBody(ctx, method, endpoint, params, nil, "")
+		})
+	}
 	return c.doRequestWithBody(ctx, method, endpoint, params, nil, "")
 }
 
 func (c *Client) doRequestWithBody(ctx context.Context, method, endpoint string, params url.Values, body io.Reader, contentType string) (*http.Response, error) {
-	// Wait for rate limiter
-	if err := c.rateLimiter.Wait(ctx); err != nil {
-		return nil, NewNetworkError("rate limiter error", err)
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
 	}
+	return c.doRequestWithHeader(ctx, method, endpoint, params, body, header)
+}
+
+// doRequestWithHeader executes a request with additional request headers.
+func (c *Client) doRequestWithHeader(ctx context.Context, method, endpoint string, params url.Values, body io.Reader, header http.Header) (*http.Response, error) {
+	if c.audited(method) {
+		return c.doAuditedRequest(ctx, method, endpoint, params, body, header, func(body io.Reader) (*http.Response, error) {
+			return c.sendRequest(ctx, method, endpoint, params, body, header)
+		})
+	}
+	if c.flights != nil && method == http.MethodGet && body == nil && requestOptionsFrom(ctx) == nil && !recordsResponseMeta(ctx) {
+		return c.doCoalescedRequest(ctx, endpoint, params, header)
+	}
+	return c.sendRequest(ctx, method, endpoint, params, body, header)
+}
+
+// sendRequest performs a single logical request: cache lookup, rate limiting,
+// attempts with retries, and cache maintenance.
+func (c *Client) sendRequest(ctx context.Context, method, endpoint string, params url.Values, body io.Reader, header http.Header) (*http.Response, error) {
+	began := time.Now()
 
 	// Build URL
-	reqURL := c.baseURL + strings.TrimPrefix(endpoint, "/")
+	endpoint = strings.TrimPrefix(endpoint, "/")
+	reqURL := c.endpointURL(endpoint)
 	if len(params) > 0 {
 		reqURL = reqURL + "?" + params.Encode()
 	}
 
+	// Apply per-request overrides
+	httpClient := c.httpClient
+	maxRetries := c.maxRetries
+	ro := requestOptionsFrom(ctx)
+	if ro == nil {
+		ro = &requestOptions{}
+	}
+	if ro.timeout > 0 {
+		override := *c.httpClient
+		override.Timeout = ro.timeout
+		httpClient = &override
+	}
+	if ro.noRetry || !c.retryPolicy.retriesMethod(method) {
+		maxRetries = 0
+	}
+
+	// Endpoint class timeouts replace the client timeout, through each
+	// attempt's context, unless the request sets its own
+	var attemptTimeout time.Duration
+	if ro.timeout <= 0 {
+		attemptTimeout = c.endpointTimeouts.forClass(classifyEndpoint(method, endpoint))
+		if attemptTimeout > 0 {
+			override := *c.httpClient
+			override.Timeout = 0
+			httpClient = &override
+		}
+	}
+
+	token, email, err := c.credentials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve credentials: %w", err)
+	}
+
+	// Serve cached GET responses without touching the network
+	useCache := c.cache != nil && method == http.MethodGet
+	var key string
+	if useCache {
+		key = cacheKey(token, email, reqURL)
+	}
+	if useCache && !ro.bypassCache {
+		if body, ok := c.cache.Get(key); ok {
+			c.logger.Debugf("Cache hit: %s %s", method, reqURL)
+			resp := cachedResponse(nil, nil, body)
+			recordResponseMeta(ctx, ResponseMeta{Method: method, URL: reqURL, StatusCode: resp.StatusCode,
+				Latency: time.Since(began), FromCache: true, Header: resp.Header})
+			return resp, nil
+		}
+	}
+
+	// Wait for rate limiter
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, NewNetworkError("rate limiter error", err)
+		}
+	}
+
+	// Buffer the body so it can be included in debug dumps and signatures
+	var bodyData []byte
+	if (c.debugDump != nil || c.signingSecret != "") && body != nil {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, NewNetworkError("failed to read request body", err)
+		}
+		bodyData = data
+		body = bytes.NewReader(data)
+	}
+
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
 	if err != nil {
@@ -154,24 +336,74 @@ func (c *Client) doRequestWithBody(ctx context.Context, method, endpoint string,
 	}
 
 	// Add headers
-	req.Header.Set("X-ManaPool-Access-Token", c.authToken)
-	req.Header.Set("X-ManaPool-Email", c.email)
+	req.Header.Set("X-ManaPool-Access-Token", token)
+	req.Header.Set("X-ManaPool-Email", email)
 	req.Header.Set("User-Agent", c.userAgent)
 	req.Header.Set("Accept", "application/json")
-	if contentType != "" {
-		req.Header.Set("Content-Type", contentType)
+	if c.disableCompression {
+		req.Header.Set("Accept-Encoding", "identity")
+	} else {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	for key, values := range ro.header {
+		if !redactedHeaders[http.CanonicalHeaderKey(key)] {
+			req.Header[http.CanonicalHeaderKey(key)] = values
+		}
+	}
+
+	// Revalidate previously cached responses
+	var cached *etagEntry
+	useETags := c.etagCache != nil && method == http.MethodGet
+	if useETags && !ro.bypassCache {
+		if entry, ok := c.etagCache.get(reqURL); ok {
+			cached = entry
+			req.Header.Set("If-None-Match", entry.etag)
+		}
 	}
 
 	// Execute with retries
 	var resp *http.Response
-	backoff := c.initialBackoff
+	backoff := c.capBackoff(c.initialBackoff)
+	var backoffs []time.Duration
+	sleep := func() {
+		backoffs = append(backoffs, backoff)
+		c.logger.Debugf("Backing off %s before retrying %s %s (backoff sequence: %s)", backoff, method, reqURL, formatBackoffs(backoffs))
+		time.Sleep(backoff)
+		backoff = c.capBackoff(backoff * 2)
+	}
+	started := time.Now()
+	attempts := 0
+	var aborted *RetryAbortedError
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		attempts++
+		c.logger.Debugf("API request: %s %s (attempt %d/%d)", method, reqURL, attempt+1, maxRetries+1)
+
+		// Sign each attempt so retries carry a fresh timestamp
+		if c.signingSecret != "" {
+			signRequest(req, c.signingSecret, bodyData, time.Now())
+		}
 
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
-		c.logger.Debugf("API request: %s %s (attempt %d/%d)", method, reqURL, attempt+1, c.maxRetries+1)
+		if c.debugDump != nil {
+			c.dumpRequest(req, bodyData)
+		}
+
+		attemptReq, cancel := req, context.CancelFunc(func() {})
+		if attemptTimeout > 0 {
+			var attemptCtx context.Context
+			attemptCtx, cancel = context.WithTimeout(ctx, attemptTimeout)
+			attemptReq = req.WithContext(attemptCtx)
+		}
 
-		resp, err = c.httpClient.Do(req)
+		attemptStart := time.Now()
+		resp, err = httpClient.Do(attemptReq)
+		latency := time.Since(attemptStart)
 		if err != nil {
-			c.logger.Errorf("Request failed (attempt %d/%d): %v", attempt+1, c.maxRetries+1, err)
+			cancel()
+			c.logger.Errorf("Request failed (attempt %d/%d): %v", attempt+1, maxRetries+1, err)
 
 			// Don't retry on context errors
 			if ctx.Err() != nil {
@@ -179,42 +411,159 @@ func (c *Client) doRequestWithBody(ctx context.Context, method, endpoint string,
 			}
 
 			// Retry on network errors
-			if attempt < c.maxRetries {
-				time.Sleep(backoff)
-				backoff *= 2
+			if attempt < maxRetries && c.canRetry(started, backoff) {
+				if abortErr := c.abortRetry(ctx, attempts, backoff, latency); abortErr != nil {
+					abortErr.Err = NewNetworkError("request failed", err)
+					return nil, abortErr
+				}
+				sleep()
 				continue
 			}
 
 			return nil, NewNetworkError("request failed after retries", err)
 		}
 
+		resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+		c.rateStatus.observe(resp, time.Now())
+
+		if err := decompressResponse(resp); err != nil {
+			return nil, err
+		}
+
+		if c.debugDump != nil {
+			c.dumpResponse(resp)
+		}
+
 		// Success or non-retryable error
-		if resp.StatusCode < 500 || attempt == c.maxRetries {
+		if !c.retryPolicy.retriesStatus(resp.StatusCode) || attempt == maxRetries || !c.canRetry(started, backoff) {
+			break
+		}
+		if aborted = c.abortRetry(ctx, attempts, backoff, latency); aborted != nil {
 			break
 		}
 
-		// Server error - retry
-		c.logger.Errorf("Server error %d (attempt %d/%d), retrying...", resp.StatusCode, attempt+1, c.maxRetries+1)
+		// Retryable status - retry
+		c.logger.Errorf("Retryable status %d (attempt %d/%d), retrying...", resp.StatusCode, attempt+1, maxRetries+1)
 		_ = resp.Body.Close()
-		time.Sleep(backoff)
-		backoff *= 2
+		sleep()
+	}
+
+	recordResponseMeta(ctx, ResponseMeta{
+		Method:     method,
+		URL:        reqURL,
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		Latency:    time.Since(began),
+		Attempts:   attempts,
+		FromCache:  cached != nil && resp.StatusCode == http.StatusNotModified,
+		Header:     resp.Header,
+	})
+
+	if aborted != nil {
+		body, readErr := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if readErr != nil {
+			aborted.Err = NewNetworkError("failed to read response body", readErr)
+		} else {
+			aborted.Err = newAPIErrorFromResponse(resp, body)
+		}
+		return nil, aborted
+	}
+
+	if useETags {
+		resp, err = c.applyETagCache(reqURL, resp, cached)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if useCache {
+		return c.storeCachedResponse(key, endpoint, resp)
+	}
+
+	// Writes invalidate every cached read of the resource and those below
+	// it, whatever their query parameters or account
+	if c.cache != nil && method != http.MethodHead && resp.StatusCode < http.StatusMultipleChoices {
+		c.invalidateCache(endpoint)
 	}
 
 	return resp, nil
 }
 
+// capBackoff limits backoff to the client's maximum backoff, if any.
+func (c *Client) capBackoff(backoff time.Duration) time.Duration {
+	if c.maxBackoff > 0 && backoff > c.maxBackoff {
+		return c.maxBackoff
+	}
+	return backoff
+}
+
+// formatBackoffs renders a backoff sequence as "1s, 2s, 4s".
+func formatBackoffs(backoffs []time.Duration) string {
+	parts := make([]string, len(backoffs))
+	for i, backoff := range backoffs {
+		parts[i] = backoff.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// canRetry reports whether sleeping for backoff and trying again fits within
+// the retry budget.
+func (c *Client) canRetry(started time.Time, backoff time.Duration) bool {
+	if c.maxRetryDuration > 0 && time.Since(started)+backoff > c.maxRetryDuration {
+		c.logger.Debugf("Retry budget of %s exhausted, not retrying", c.maxRetryDuration)
+		return false
+	}
+	return true
+}
+
+// abortRetry returns a *RetryAbortedError, without Err, if sleeping for
+// backoff and making another attempt as slow as the last one would pass the
+// context deadline.
+func (c *Client) abortRetry(ctx context.Context, attempts int, backoff, latency time.Duration) *RetryAbortedError {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+	remaining := time.Until(deadline)
+	if backoff+latency < remaining {
+		return nil
+	}
+	c.logger.Debugf("Context deadline in %s too close for %s backoff and %s attempt, not retrying", remaining, backoff, latency)
+	return &RetryAbortedError{Attempts: attempts, Backoff: backoff, ExpectedLatency: latency, Remaining: remaining}
+}
+
 func (c *Client) doJSONRequest(ctx context.Context, method, endpoint string, params url.Values, payload interface{}) (*http.Response, error) {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+
 	var body io.Reader
+	var data []byte
 	if payload != nil {
-		buf := &bytes.Buffer{}
-		encoder := json.NewEncoder(buf)
-		if err := encoder.Encode(payload); err != nil {
+		var err error
+		data, err = c.marshalJSON(payload)
+		if err != nil {
 			return nil, NewNetworkError("failed to encode request body", err)
 		}
+		buf := bytes.NewBuffer(append(data, '\n'))
 		body = buf
+
+		if c.shouldCompressRequest(buf.Len()) {
+			compressed, err := gzipBytes(buf.Bytes())
+			if err != nil {
+				return nil, NewNetworkError("failed to compress request body", err)
+			}
+			header.Set("Content-Encoding", "gzip")
+			body = compressed
+		}
 	}
 
-	return c.doRequestWithBody(ctx, method, endpoint, params, body, "application/json")
+	if c.journaled(method, endpoint) {
+		return c.doJournaledRequest(ctx, method, endpoint, params, data, func() (*http.Response, error) {
+			return c.doRequestWithHeader(ctx, method, endpoint, params, body, header)
+		})
+	}
+	return c.doRequestWithHeader(ctx, method, endpoint, params, body, header)
 }
 
 // decodeResponse decodes a JSON response and handles HTTP errors.
@@ -233,33 +582,31 @@ func (c *Client) decodeResponse(resp *http.Response, v interface{}) error {
 
 	// Check status code
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		apiErr := &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(body),
-			Response:   resp,
-		}
-
-		// Try to extract a better error message from JSON
-		var errorResp struct {
-			Error   string `json:"error"`
-			Message string `json:"message"`
-		}
-		if json.Unmarshal(body, &errorResp) == nil {
-			if errorResp.Error != "" {
-				apiErr.Message = errorResp.Error
-			} else if errorResp.Message != "" {
-				apiErr.Message = errorResp.Message
-			}
-		}
-
-		return apiErr
+		return newAPIErrorFromResponse(resp, body)
 	}
 
 	// Decode JSON
 	if v != nil && len(body) > 0 {
-		if err := json.Unmarshal(body, v); err != nil {
+		if c.strictDecoding {
+			if err := decodeStrict(body, v); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+		} else if err := c.unmarshalJSON(body, v); err != nil {
 			return fmt.Errorf("failed to decode response: %w", err)
 		}
+		if !c.lenientTimestamps {
+			if err := CheckTimestamps(v); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+		}
+		if c.validateResponses {
+			if err := ValidateResponse(v); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+		}
+		if raw, ok := v.(interface{ setRaw([]byte) }); ok && c.captureRaw {
+			raw.setRaw(body)
+		}
 	}
 
 	return nil
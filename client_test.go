@@ -197,6 +197,200 @@ func TestClient_doRequest_ServerError_Retry(t *testing.T) {
 	}
 }
 
+func TestClient_doRequest_MaxRetryDuration(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithRetry(10, 20*time.Millisecond),
+		WithMaxRetryDuration(70*time.Millisecond),
+	)
+
+	start := time.Now()
+	resp, err := client.doRequest(context.Background(), "GET", "/test", nil)
+	if err != nil {
+		t.Fatalf("doRequest() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	// 20ms + 40ms fit the budget; the 80ms third backoff does not
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("doRequest took %v", elapsed)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+}
+
+// formattedLogger records formatted debug messages.
+type formattedLogger struct {
+	debug []string
+}
+
+func (l *formattedLogger) Debugf(format string, args ...interface{}) {
+	l.debug = append(l.debug, fmt.Sprintf(format, args...))
+}
+
+func (l *formattedLogger) Errorf(string, ...interface{}) {}
+
+func TestClient_doRequest_MaxBackoff(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	logger := &formattedLogger{}
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithRetry(4, 20*time.Millisecond),
+		WithMaxBackoff(30*time.Millisecond),
+		WithLogger(logger),
+	)
+
+	start := time.Now()
+	resp, err := client.doRequest(context.Background(), "GET", "/test", nil)
+	if err != nil {
+		t.Fatalf("doRequest() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if attempts != 5 {
+		t.Errorf("expected 5 attempts, got %d", attempts)
+	}
+	// 20ms + 30ms + 30ms + 30ms rather than 20ms + 40ms + 80ms + 160ms
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Errorf("doRequest took %v, want backoffs capped", elapsed)
+	}
+	var sequence string
+	for _, msg := range logger.debug {
+		if strings.Contains(msg, "backoff sequence") {
+			sequence = msg
+		}
+	}
+	if !strings.HasSuffix(sequence, "(backoff sequence: 20ms, 30ms, 30ms, 30ms)") {
+		t.Errorf("last backoff log = %q", sequence)
+	}
+}
+
+func TestClient_doRequest_RetryStopsBeforeDeadline(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithRetry(3, time.Second),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	_, err := client.doRequest(ctx, "GET", "/test", nil)
+	var aborted *RetryAbortedError
+	if !errors.As(err, &aborted) {
+		t.Fatalf("doRequest() error = %v, want RetryAbortedError", err)
+	}
+	if aborted.Attempts != 1 || aborted.Backoff != time.Second {
+		t.Errorf("RetryAbortedError = %+v", aborted)
+	}
+
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("error %v does not wrap the 500 response", err)
+	}
+}
+
+func TestClient_doRequest_RetryCountsExpectedLatency(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		time.Sleep(150 * time.Millisecond)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithRetry(3, 10*time.Millisecond),
+	)
+
+	// The 10ms backoff fits the deadline, but another 150ms attempt does not.
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+
+	_, err := client.doRequest(ctx, "GET", "/test", nil)
+	var aborted *RetryAbortedError
+	if !errors.As(err, &aborted) || aborted.ExpectedLatency < 150*time.Millisecond {
+		t.Fatalf("doRequest() error = %v, want RetryAbortedError", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+	if ctx.Err() != nil {
+		t.Error("deadline was spent instead of returning early")
+	}
+}
+
+func TestClient_doRequest_NetworkError_RetryAborted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithRetry(5, time.Second),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	_, err := client.doRequest(ctx, "GET", "/test", nil)
+	var aborted *RetryAbortedError
+	var netErr *NetworkError
+	if !errors.As(err, &aborted) || !errors.As(err, &netErr) {
+		t.Fatalf("doRequest() error = %v, want RetryAbortedError wrapping NetworkError", err)
+	}
+	if ctx.Err() != nil {
+		t.Error("deadline was spent instead of returning early")
+	}
+}
+
+func TestClient_doRequest_NetworkError_RetryBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithRetry(5, time.Second),
+		WithMaxRetryDuration(100*time.Millisecond),
+	)
+
+	start := time.Now()
+	_, err := client.doRequest(context.Background(), "GET", "/test", nil)
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) {
+		t.Fatalf("expected NetworkError, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("doRequest took %v, want no retries", elapsed)
+	}
+}
+
 func TestClient_decodeResponse_Success(t *testing.T) {
 	responseBody := `{"username": "testuser", "email": "test@example.com"}`
 	resp := &http.Response{
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/repricah/manapool"
+)
+
+// inventoryColumns is the header for table and CSV inventory output.
+var inventoryColumns = []string{"id", "product_type", "product_id", "tcgplayer_sku", "name", "set", "condition", "finish", "language", "price", "quantity"}
+
+// inventoryList implements "manapool inventory list".
+func (a *app) inventoryList(ctx context.Context, args []string) error {
+	fs := a.flagSet("inventory list")
+	set := fs.String("set", "", "only list items from this set code")
+	name := fs.String("name", "", "only list items whose name contains this text (case-insensitive)")
+	format := fs.String("format", "table", "output format: table, csv, or json")
+	pageSize := fs.Int("page-size", 500, "items per API request (1-500)")
+	quiet := fs.Bool("quiet", false, "suppress progress output")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if *pageSize < 1 || *pageSize > 500 {
+		return fmt.Errorf("-page-size must be between 1 and 500")
+	}
+
+	out, err := newInventoryWriter(a.stdout, *format)
+	if err != nil {
+		return err
+	}
+
+	var filters []manapool.InventoryFilter
+	if *set != "" {
+		filters = append(filters, manapool.InventoryInSet(*set))
+	}
+	if *name != "" {
+		needle := strings.ToLower(*name)
+		filters = append(filters, func(item manapool.InventoryItem) bool {
+			return strings.Contains(strings.ToLower(itemName(item)), needle)
+		})
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	matched := 0
+	err = a.eachInventoryItem(ctx, client, *pageSize, *quiet, func(item manapool.InventoryItem) error {
+		for _, keep := range filters {
+			if !keep(item) {
+				return nil
+			}
+		}
+		matched++
+		return out.write(item)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := out.close(); err != nil {
+		return err
+	}
+	a.progressf(*quiet, "%d items matched", matched)
+	return nil
+}
+
+// inventoryExportEbay implements "manapool inventory export-ebay".
+func (a *app) inventoryExportEbay(ctx context.Context, args []string) error {
+	fs := a.flagSet("inventory export-ebay")
+	minPrice := fs.Float64("min-price", 0, "only export singles priced at or above this many dollars")
+	set := fs.String("set", "", "only export cards from this set code")
+	markup := fs.Float64("markup", 0, "raise eBay prices by this percentage, such as 13 to cover fees")
+	title := fs.String("title", "", "title template (default "+strconv.Quote(manapool.DefaultEbayTitleTemplate)+")")
+	location := fs.String("location", "", "item location, such as a city and state")
+	shipping := fs.String("shipping-profile", "", "eBay shipping policy name")
+	returns := fs.String("return-profile", "", "eBay return policy name")
+	payment := fs.String("payment-profile", "", "eBay payment policy name")
+	pageSize := fs.Int("page-size", 500, "items per API request (1-500)")
+	quiet := fs.Bool("quiet", false, "suppress progress output and warnings")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if *pageSize < 1 || *pageSize > 500 {
+		return fmt.Errorf("-page-size must be between 1 and 500")
+	}
+
+	minCents := int(math.Round(*minPrice * 100))
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	var items []manapool.InventoryItem
+	err = a.eachInventoryItem(ctx, client, *pageSize, *quiet, func(item manapool.InventoryItem) error {
+		if item.Product.Single == nil || item.PriceCents < minCents {
+			return nil
+		}
+		if *set != "" && !manapool.InventoryInSet(*set)(item) {
+			return nil
+		}
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	warnings, err := manapool.WriteEbayCSV(a.stdout, items, manapool.EbayExportOptions{
+		TitleTemplate:     *title,
+		MarkupBasisPoints: manapool.PercentToBasisPoints(*markup),
+		Location:          *location,
+		ShippingProfile:   *shipping,
+		ReturnProfile:     *returns,
+		PaymentProfile:    *payment,
+	})
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		a.progressf(*quiet, "warning: %s", warning)
+	}
+	a.progressf(*quiet, "%d items exported", len(items))
+	return nil
+}
+
+// eachInventoryItem streams the seller's inventory to fn a page at a time,
+// reporting progress after each page.
+func (a *app) eachInventoryItem(ctx context.Context, client *manapool.Client, pageSize int, quiet bool, fn func(manapool.InventoryItem) error) error {
+	seen := 0
+	opts := manapool.InventoryOptions{Limit: pageSize}
+	for {
+		page, err := client.ListInventoryStream(ctx, opts, func(item manapool.InventoryItem) error {
+			seen++
+			return fn(item)
+		})
+		if err != nil {
+			return err
+		}
+		a.progressf(quiet, "fetched %d of %d items", seen, page.Total)
+
+		var more bool
+		if opts, more = page.Next(opts); !more {
+			return nil
+		}
+	}
+}
+
+// inventoryWriter renders inventory items in one output format.
+type inventoryWriter struct {
+	write func(manapool.InventoryItem) error
+	close func() error
+}
+
+func newInventoryWriter(w io.Writer, format string) (*inventoryWriter, error) {
+	switch format {
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		if _, err := fmt.Fprintln(tw, strings.ToUpper(strings.Join(inventoryColumns, "\t"))); err != nil {
+			return nil, err
+		}
+		return &inventoryWriter{
+			write: func(item manapool.InventoryItem) error {
+				_, err := fmt.Fprintln(tw, strings.Join(inventoryRecord(item), "\t"))
+				return err
+			},
+			close: tw.Flush,
+		}, nil
+
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(inventoryColumns); err != nil {
+			return nil, fmt.Errorf("failed to write csv header: %w", err)
+		}
+		return &inventoryWriter{
+			write: func(item manapool.InventoryItem) error { return cw.Write(inventoryRecord(item)) },
+			close: func() error {
+				cw.Flush()
+				return cw.Error()
+			},
+		}, nil
+
+	case "json":
+		// Items are written as they arrive, so the array is assembled by hand.
+		first := true
+		return &inventoryWriter{
+			write: func(item manapool.InventoryItem) error {
+				data, err := json.Marshal(item)
+				if err != nil {
+					return err
+				}
+				sep := ",\n  "
+				if first {
+					sep, first = "[\n  ", false
+				}
+				_, err = fmt.Fprintf(w, "%s%s", sep, data)
+				return err
+			},
+			close: func() error {
+				end := "\n]\n"
+				if first {
+					end = "[]\n"
+				}
+				_, err := io.WriteString(w, end)
+				return err
+			},
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q (want table, csv, or json)", format)
+}
+
+// inventoryRecord returns the inventoryColumns fields for item.
+func inventoryRecord(item manapool.InventoryItem) []string {
+	var sku, set, condition, finish, language string
+	if item.Product.TCGPlayerSKU != nil {
+		sku = strconv.Itoa(*item.Product.TCGPlayerSKU)
+	}
+	switch {
+	case item.Product.Single != nil:
+		s := item.Product.Single
+		set, condition, finish, language = s.Set, s.ConditionID, s.FinishID, s.LanguageID
+	case item.Product.Sealed != nil:
+		set, language = item.Product.Sealed.Set, item.Product.Sealed.LanguageID
+	}
+	return []string{
+		item.ID, item.ProductType, item.ProductID, sku, itemName(item), set, condition, finish, language,
+		manapool.FormatCents(item.PriceCents), strconv.Itoa(item.Quantity),
+	}
+}
+
+// itemName returns the product name of a single or sealed item.
+func itemName(item manapool.InventoryItem) string {
+	switch {
+	case item.Product.Single != nil:
+		return item.Product.Single.Name
+	case item.Product.Sealed != nil:
+		return item.Product.Sealed.Name
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool"
+	"github.com/repricah/manapool/manapooltest"
+)
+
+func inventoryServer(opts ...manapooltest.Option) *manapooltest.Server {
+	sku := func(n int) *int { return &n }
+	items := []manapool.InventoryItem{
+		{ID: "1", ProductType: "mtg_single", PriceCents: 125, Quantity: 2, Product: manapool.Product{
+			TCGPlayerSKU: sku(101),
+			Single:       &manapool.Single{Name: "Lightning Bolt", Set: "NEO", ConditionID: "NM", FinishID: "NF", LanguageID: "EN"},
+		}},
+		{ID: "2", ProductType: "mtg_single", PriceCents: 5000, Quantity: 1, Product: manapool.Product{
+			Single: &manapool.Single{Name: "The Wandering Emperor", Set: "neo", ConditionID: "LP", FinishID: "FO", LanguageID: "EN"},
+		}},
+		{ID: "3", ProductType: "mtg_sealed", PriceCents: 12000, Quantity: 3, Product: manapool.Product{
+			Sealed: &manapool.Sealed{Name: "Dominaria Booster Box", Set: "DMU", LanguageID: "EN"},
+		}},
+	}
+	return manapooltest.NewServer(append([]manapooltest.Option{manapooltest.WithInventory(items...)}, opts...)...)
+}
+
+func TestInventoryList_Formats(t *testing.T) {
+	srv := inventoryServer()
+	defer srv.Close()
+
+	code, stdout, stderr := runCLI(t, srv, "inventory", "list", "-set", "NEO", "-format", "csv", "-page-size", "2")
+	if code != 0 {
+		t.Fatalf("run() = %d, stderr %q", code, stderr)
+	}
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not CSV: %v\n%s", err, stdout)
+	}
+	if len(records) != 3 || strings.Join(records[1], ",") != "1,mtg_single,product-1,101,Lightning Bolt,NEO,NM,NF,EN,1.25,2" ||
+		records[2][4] != "The Wandering Emperor" {
+		t.Errorf("csv = %q", records)
+	}
+	if !strings.Contains(stderr, "fetched 2 of 3 items") || !strings.Contains(stderr, "fetched 3 of 3 items") ||
+		!strings.Contains(stderr, "2 items matched") {
+		t.Errorf("progress = %q", stderr)
+	}
+
+	code, stdout, stderr = runCLI(t, srv, "inventory", "list", "-name", "booster", "-format", "json", "-quiet")
+	if code != 0 || stderr != "" {
+		t.Fatalf("run() = %d, stderr %q", code, stderr)
+	}
+	var items []manapool.InventoryItem
+	if err := json.Unmarshal([]byte(stdout), &items); err != nil || len(items) != 1 || items[0].ID != "3" {
+		t.Errorf("json = %s (%v)", stdout, err)
+	}
+
+	code, stdout, _ = runCLI(t, srv, "inventory", "list", "-name", "nothing", "-format", "json", "-quiet")
+	if code != 0 || stdout != "[]\n" {
+		t.Errorf("empty json = %q", stdout)
+	}
+
+	code, stdout, _ = runCLI(t, srv, "inventory", "list", "-quiet")
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if code != 0 || len(lines) != 4 || !strings.HasPrefix(lines[0], "ID  ") || !strings.Contains(lines[3], "Dominaria Booster Box") {
+		t.Errorf("table = %q", stdout)
+	}
+}
+
+func TestInventoryList_Errors(t *testing.T) {
+	srv := inventoryServer()
+	defer srv.Close()
+
+	if code, _, stderr := runCLI(t, srv, "inventory", "list", "-format", "xml"); code != 1 || !strings.Contains(stderr, `unknown format "xml"`) {
+		t.Errorf("run(-format xml) = %d, stderr %q", code, stderr)
+	}
+	if code, _, stderr := runCLI(t, srv, "inventory", "list", "extra"); code != 1 || !strings.Contains(stderr, "unexpected arguments: extra") {
+		t.Errorf("run(extra) = %d, stderr %q", code, stderr)
+	}
+	if code, _, stderr := runCLI(t, srv, "inventory", "list", "-page-size", "0"); code != 1 || !strings.Contains(stderr, "-page-size must be between 1 and 500") {
+		t.Errorf("run(-page-size 0) = %d, stderr %q", code, stderr)
+	}
+
+	srv.InjectFault(manapooltest.Fault{Method: http.MethodGet, Path: "/seller/inventory", Status: http.StatusInternalServerError})
+	if code, _, stderr := runCLI(t, srv, "inventory", "list"); code != 1 || !strings.Contains(stderr, "manapool inventory list:") {
+		t.Errorf("run() = %d, stderr %q", code, stderr)
+	}
+}
+
+func TestInventoryExportEbay(t *testing.T) {
+	srv := inventoryServer()
+	defer srv.Close()
+
+	code, stdout, stderr := runCLI(t, srv, "inventory", "export-ebay", "-min-price", "10", "-markup", "10", "-location", "Portland, OR")
+	if code != 0 {
+		t.Fatalf("run() = %d, stderr %q", code, stderr)
+	}
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not CSV: %v\n%s", err, stdout)
+	}
+	if len(records) != 2 {
+		t.Fatalf("records = %q, want header and one row", records)
+	}
+	row := strings.Join(records[1], "|")
+	for _, want := range []string{"|2|", "The Wandering Emperor NEO Foil LP MTG", "|Excellent|", "|55.00|1|Portland, OR|"} {
+		if !strings.Contains(row, want) {
+			t.Errorf("row %q does not contain %q", row, want)
+		}
+	}
+	if !strings.Contains(stderr, "1 items exported") {
+		t.Errorf("progress = %q", stderr)
+	}
+
+	if code, _, stderr := runCLI(t, srv, "inventory", "export-ebay", "-title", "{{.Name"); code != 1 || !strings.Contains(stderr, "title_template") {
+		t.Errorf("run(bad title) = %d, stderr %q", code, stderr)
+	}
+}
@@ -0,0 +1,202 @@
+// Command manapool is a command-line interface to the ManaPool seller API.
+//
+// Credentials come from a config file (see package config) given with
+// -config or the MANAPOOL_CONFIG environment variable, or otherwise from
+// the MANAPOOL_ACCESS_TOKEN and MANAPOOL_EMAIL environment variables.
+//
+// Usage:
+//
+//	manapool [-config file] [-locale tag] <command> [arguments]
+//
+// Commands:
+//
+//	inventory list         list or export seller inventory
+//	inventory export-ebay  write singles as an eBay File Exchange CSV
+//	orders list            list seller orders
+//	orders pull-sheet      print a combined pick list for orders
+//	orders ship            mark an order shipped
+//	reprice                preview or apply prices from a rules file
+//	status                 check the API and credentials
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/repricah/manapool"
+	"github.com/repricah/manapool/config"
+)
+
+// EnvConfig names the environment variable holding the default config path.
+const EnvConfig = "MANAPOOL_CONFIG"
+
+// errUsage reports a command-line error whose message the flag package has
+// already printed.
+var errUsage = errors.New("usage")
+
+// app holds the CLI's I/O so commands can be tested.
+type app struct {
+	stdout io.Writer
+	stderr io.Writer
+
+	// configPath is set by the -config flag
+	configPath string
+
+	// locale is set by the -locale flag; empty means plain amounts
+	locale string
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	a := &app{stdout: os.Stdout, stderr: os.Stderr}
+	os.Exit(a.run(ctx, os.Args[1:]))
+}
+
+// command is a subcommand. run receives the arguments after the command
+// name.
+type command struct {
+	name    string
+	summary string
+	run     func(a *app, ctx context.Context, args []string) error
+}
+
+// commands lists every subcommand, keyed by its space-separated name.
+var commands = []command{
+	{"inventory list", "list or export seller inventory", (*app).inventoryList},
+	{"inventory export-ebay", "write singles as an eBay File Exchange CSV", (*app).inventoryExportEbay},
+	{"orders list", "list seller orders", (*app).ordersList},
+	{"orders pull-sheet", "print a combined pick list for orders", (*app).ordersPullSheet},
+	{"orders ship", "mark an order shipped", (*app).ordersShip},
+	{"reprice", "preview or apply prices from a rules file", (*app).reprice},
+	{"status", "check the API and credentials", (*app).status},
+}
+
+// run parses global flags, dispatches to a command, and returns the exit
+// code.
+func (a *app) run(ctx context.Context, args []string) int {
+	fs := flag.NewFlagSet("manapool", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(&a.configPath, "config", "", "path to a TOML, YAML, or JSON config file (default $"+EnvConfig+")")
+	fs.StringVar(&a.locale, "locale", "", "format prices in reports for a locale such as en-US or de-DE (default plain amounts)")
+	fs.Usage = a.usage
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	cmd, rest, ok := findCommand(fs.Args())
+	if !ok {
+		a.usage()
+		return 2
+	}
+
+	if err := cmd.run(a, ctx, rest); err != nil {
+		switch {
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errUsage):
+			return 2
+		}
+		fmt.Fprintf(a.stderr, "manapool %s: %v\n", cmd.name, err)
+		return 1
+	}
+	return 0
+}
+
+// findCommand finds the command whose name starts args.
+func findCommand(args []string) (command, []string, bool) {
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) < len(words) {
+			continue
+		}
+		if strings.Join(args[:len(words)], " ") == cmd.name {
+			return cmd, args[len(words):], true
+		}
+	}
+	return command{}, nil, false
+}
+
+func (a *app) usage() {
+	fmt.Fprintln(a.stderr, "Usage: manapool [-config file] [-locale tag] <command> [arguments]")
+	fmt.Fprintln(a.stderr)
+	fmt.Fprintln(a.stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(a.stderr, "  %-20s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(a.stderr)
+	fmt.Fprintln(a.stderr, "Run 'manapool <command> -h' for command flags.")
+}
+
+// flagSet creates a flag set for a command that reports errors to stderr.
+func (a *app) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("manapool "+name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
+
+// parseFlags parses args, mapping parse failures to errUsage.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	return nil
+}
+
+// parseArgs parses args like parseFlags but also accepts flags after
+// positional arguments, as in "orders ship <id> -tracking ...". It returns
+// the positional arguments.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := parseFlags(fs, args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		if consumed := len(args) - fs.NArg(); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, fs.Args()...), nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// client creates an API client from the config file, if any, or from the
+// environment.
+func (a *app) client() (*manapool.Client, error) {
+	path := a.configPath
+	if path == "" {
+		path = os.Getenv(EnvConfig)
+	}
+	if path == "" {
+		return manapool.NewClientFromEnv()
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.NewClient()
+}
+
+// progressf writes a progress line to stderr unless quiet is set.
+func (a *app) progressf(quiet bool, format string, args ...interface{}) {
+	if !quiet {
+		fmt.Fprintf(a.stderr, format+"\n", args...)
+	}
+}
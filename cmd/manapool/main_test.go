@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool"
+	"github.com/repricah/manapool/manapooltest"
+)
+
+// runCLI runs the CLI against srv with credentials from the environment and
+// returns the exit code, stdout, and stderr.
+func runCLI(t *testing.T, srv *manapooltest.Server, args ...string) (int, string, string) {
+	t.Helper()
+	t.Setenv(EnvConfig, "")
+	t.Setenv(manapool.EnvAccessToken, "test-token")
+	t.Setenv(manapool.EnvEmail, "seller@example.com")
+	t.Setenv(manapool.EnvMaxRetries, "0")
+	if srv != nil {
+		t.Setenv(manapool.EnvBaseURL, srv.URL+"/")
+	}
+
+	var stdout, stderr bytes.Buffer
+	a := &app{stdout: &stdout, stderr: &stderr}
+	code := a.run(context.Background(), args)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_Usage(t *testing.T) {
+	code, _, stderr := runCLI(t, nil)
+	if code != 2 || !strings.Contains(stderr, "inventory list") {
+		t.Errorf("run() = %d, stderr %q", code, stderr)
+	}
+
+	if code, _, _ := runCLI(t, nil, "-h"); code != 0 {
+		t.Errorf("run(-h) = %d", code)
+	}
+	if code, _, _ := runCLI(t, nil, "-bogus"); code != 2 {
+		t.Errorf("run(-bogus) = %d", code)
+	}
+	if code, _, stderr := runCLI(t, nil, "inventory", "frobnicate"); code != 2 || !strings.Contains(stderr, "Usage") {
+		t.Errorf("run(unknown command) = %d, stderr %q", code, stderr)
+	}
+	if code, _, _ := runCLI(t, nil, "inventory", "list", "-h"); code != 0 {
+		t.Errorf("run(inventory list -h) = %d", code)
+	}
+	if code, _, _ := runCLI(t, nil, "inventory", "list", "-bogus"); code != 2 {
+		t.Errorf("run(inventory list -bogus) = %d", code)
+	}
+}
+
+func TestRun_ConfigFile(t *testing.T) {
+	srv := manapooltest.NewServer(manapooltest.WithCredentials("file-token", "file@example.com"))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "manapool.json")
+	cfg := `{"access_token":"file-token","email":"file@example.com","base_url":"` + srv.URL + `"}`
+	if err := os.WriteFile(path, []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Environment variables override the file, so clear them.
+	t.Setenv(manapool.EnvAccessToken, "")
+	t.Setenv(manapool.EnvEmail, "")
+	run := func(args ...string) (int, string) {
+		var stdout, stderr bytes.Buffer
+		code := (&app{stdout: &stdout, stderr: &stderr}).run(context.Background(), args)
+		return code, stderr.String()
+	}
+
+	if code, stderr := run("-config", path, "inventory", "list", "-quiet"); code != 0 {
+		t.Errorf("run(-config) = %d, stderr %q", code, stderr)
+	}
+
+	t.Setenv(EnvConfig, path)
+	if code, stderr := run("inventory", "list", "-quiet"); code != 0 {
+		t.Errorf("run($%s) = %d, stderr %q", EnvConfig, code, stderr)
+	}
+
+	t.Setenv(EnvConfig, filepath.Join(t.TempDir(), "missing.json"))
+	if code, stderr := run("inventory", "list"); code != 1 || !strings.Contains(stderr, "failed to open config") {
+		t.Errorf("run(missing config) = %d, stderr %q", code, stderr)
+	}
+}
+
+func TestRun_MissingCredentials(t *testing.T) {
+	t.Setenv(EnvConfig, "")
+	t.Setenv(manapool.EnvAccessToken, "")
+	t.Setenv(manapool.EnvEmail, "")
+
+	var stdout, stderr bytes.Buffer
+	code := (&app{stdout: &stdout, stderr: &stderr}).run(context.Background(), []string{"inventory", "list"})
+	if code != 1 || !strings.Contains(stderr.String(), manapool.EnvAccessToken) {
+		t.Errorf("run() = %d, stderr %q", code, stderr.String())
+	}
+}
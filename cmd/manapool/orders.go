@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/repricah/manapool"
+	"github.com/repricah/manapool/fulfillment"
+)
+
+// orderColumns is the header for table and CSV order output.
+var orderColumns = []string{"id", "created_at", "label", "status", "shipping_method", "total"}
+
+// ordersPageSize is the number of orders requested per page.
+const ordersPageSize = 500
+
+// ordersList implements "manapool orders list".
+func (a *app) ordersList(ctx context.Context, args []string) error {
+	fs := a.flagSet("orders list")
+	status := fs.String("status", "", "only list orders in this state: paid (awaiting shipment), fulfilled, or a fulfillment status such as shipped")
+	since := fs.String("since", "", "only list orders created on or after this date (YYYY-MM-DD or RFC 3339)")
+	label := fs.String("label", "", "only list orders with this label")
+	format := fs.String("format", "table", "output format: table, csv, or json")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	opts, keep, err := orderFilter(*status)
+	if err != nil {
+		return err
+	}
+	opts.Label = *label
+	if *since != "" {
+		t, err := parseDate(*since)
+		if err != nil {
+			return err
+		}
+		opts.Since = &manapool.Timestamp{Time: t}
+	}
+	switch *format {
+	case "table", "csv", "json":
+	default:
+		return fmt.Errorf("unknown format %q (want table, csv, or json)", *format)
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	summaries, err := listOrders(ctx, client, opts)
+	if err != nil {
+		return err
+	}
+	var orders []manapool.OrderSummary
+	for _, order := range summaries {
+		if keep(order) {
+			orders = append(orders, order)
+		}
+	}
+	return writeOrders(a.stdout, *format, orders)
+}
+
+// listOrders fetches every order matching opts a page at a time.
+func listOrders(ctx context.Context, client *manapool.Client, opts manapool.OrdersOptions) ([]manapool.OrderSummary, error) {
+	var orders []manapool.OrderSummary
+	opts.Limit = ordersPageSize
+	for {
+		page, err := client.GetSellerOrders(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, page.Orders...)
+		if len(page.Orders) < ordersPageSize {
+			return orders, nil
+		}
+		opts.Offset += len(page.Orders)
+	}
+}
+
+// orderFilter maps a -status value to server-side options and a client-side
+// filter. The API has no paid state because orders are paid when placed, so
+// "paid" means paid and awaiting shipment.
+func orderFilter(status string) (manapool.OrdersOptions, func(manapool.OrderSummary) bool, error) {
+	all := func(manapool.OrderSummary) bool { return true }
+	yes := true
+	switch status {
+	case "":
+		return manapool.OrdersOptions{}, all, nil
+	case "paid", "unfulfilled":
+		return manapool.OrdersOptions{IsUnfulfilled: &yes}, all, nil
+	case "fulfilled":
+		return manapool.OrdersOptions{IsFulfilled: &yes}, all, nil
+	}
+
+	want, err := manapool.ParseOrderStatus(status)
+	if err != nil {
+		return manapool.OrdersOptions{}, nil, fmt.Errorf("unknown status %q (want paid, fulfilled, pending, processing, shipped, delivered, refunded, replaced, or error)", status)
+	}
+	return manapool.OrdersOptions{}, func(order manapool.OrderSummary) bool {
+		return order.FulfillmentStatus() == want
+	}, nil
+}
+
+// parseDate parses a YYYY-MM-DD date (as UTC midnight) or an RFC 3339 time.
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (want YYYY-MM-DD or RFC 3339)", s)
+	}
+	return t, nil
+}
+
+func writeOrders(w io.Writer, format string, orders []manapool.OrderSummary) error {
+	switch format {
+	case "json":
+		if orders == nil {
+			orders = []manapool.OrderSummary{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(orders)
+
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(orderColumns); err != nil {
+			return fmt.Errorf("failed to write csv header: %w", err)
+		}
+		for _, order := range orders {
+			if err := cw.Write(orderRecord(order)); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(orderColumns, "\t")))
+	for _, order := range orders {
+		fmt.Fprintln(tw, strings.Join(orderRecord(order), "\t"))
+	}
+	return tw.Flush()
+}
+
+// orderRecord returns the orderColumns fields for order.
+func orderRecord(order manapool.OrderSummary) []string {
+	return []string{
+		order.ID, order.CreatedAt.Format(time.RFC3339), order.Label, order.FulfillmentStatus().String(),
+		order.ShippingMethod, manapool.FormatCents(order.TotalCents),
+	}
+}
+
+// ordersPullSheet implements "manapool orders pull-sheet".
+func (a *app) ordersPullSheet(ctx context.Context, args []string) error {
+	fs := a.flagSet("orders pull-sheet")
+	unfulfilled := fs.Bool("unfulfilled", false, "include every order awaiting shipment")
+	ids, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 && !*unfulfilled {
+		return fmt.Errorf("give order IDs or -unfulfilled")
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	if *unfulfilled {
+		yes := true
+		open, err := listOrders(ctx, client, manapool.OrdersOptions{IsUnfulfilled: &yes})
+		if err != nil {
+			return err
+		}
+		for _, order := range open {
+			ids = append(ids, order.ID)
+		}
+	}
+
+	orders := make([]manapool.OrderDetails, 0, len(ids))
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		resp, err := client.GetSellerOrder(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get order %s: %w", id, err)
+		}
+		orders = append(orders, resp.Order)
+	}
+
+	sheet := fulfillment.NewPullSheet(orders...)
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "QTY\tSET\tNO.\tCONDITION\tNAME\tORDERS")
+	for _, line := range sheet.Lines {
+		refs := make([]string, len(line.Orders))
+		for i, o := range line.Orders {
+			ref := o.OrderID
+			if o.Label != "" {
+				ref = o.Label
+			}
+			refs[i] = fmt.Sprintf("%s x%d", ref, o.Quantity)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", line.Quantity, line.Set, line.Number, line.Condition, line.Name, strings.Join(refs, ", "))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(a.stdout, "\n%d items across %d orders\n", sheet.TotalQuantity, len(orders))
+	return err
+}
+
+// ordersShip implements "manapool orders ship".
+func (a *app) ordersShip(ctx context.Context, args []string) error {
+	fs := a.flagSet("orders ship")
+	tracking := fs.String("tracking", "", "tracking number")
+	carrier := fs.String("carrier", "", "shipping carrier, such as USPS")
+	trackingURL := fs.String("url", "", "tracking URL")
+	ids, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(ids) != 1 {
+		return fmt.Errorf("give exactly one order ID")
+	}
+	id := ids[0]
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetSellerOrder(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get order %s: %w", id, err)
+	}
+	if current := resp.Order.FulfillmentStatus(); !current.CanTransitionTo(manapool.OrderStatusShipped) {
+		return fmt.Errorf("order %s is %s and cannot be shipped", id, current)
+	}
+
+	label := fulfillment.Label{Carrier: *carrier, TrackingNumber: *tracking, TrackingURL: *trackingURL}
+	if _, err := client.UpdateSellerOrderFulfillment(ctx, id, label.FulfillmentRequest(time.Now())); err != nil {
+		return fmt.Errorf("failed to mark order %s shipped: %w", id, err)
+	}
+
+	if *tracking != "" {
+		_, err = fmt.Fprintf(a.stdout, "Order %s marked shipped with tracking %s.\n", id, *tracking)
+	} else {
+		_, err = fmt.Fprintf(a.stdout, "Order %s marked shipped.\n", id)
+	}
+	return err
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/repricah/manapool"
+	"github.com/repricah/manapool/manapooltest"
+)
+
+func ordersServer() *manapooltest.Server {
+	bolt := manapool.Product{Single: &manapool.Single{Name: "Lightning Bolt", Set: "LEA", Number: "161", ConditionID: "NM", FinishID: "NF"}}
+	order := func(id, label string, day int, status manapool.OrderStatus, items ...manapool.OrderItem) manapool.OrderDetails {
+		o := manapool.OrderDetails{Items: items}
+		o.ID, o.Label, o.TotalCents, o.ShippingMethod = id, label, 1000*day, "standard"
+		o.CreatedAt = manapool.Timestamp{Time: time.Date(2026, 5, day, 12, 0, 0, 0, time.UTC)}
+		o.LatestFulfillmentStatus = status.Ptr()
+		return o
+	}
+	return manapooltest.NewServer(manapooltest.WithOrders(
+		order("o1", "A1", 1, manapool.OrderStatusPending,
+			manapool.OrderItem{ProductType: "mtg_single", ProductID: "bolt", Quantity: 2, Product: bolt},
+			manapool.OrderItem{ProductType: "mtg_single", ProductID: "ring", Quantity: 1, Product: manapool.Product{
+				Single: &manapool.Single{Name: "Sol Ring", Set: "2ED", Number: "270", ConditionID: "LP", FinishID: "NF"},
+			}},
+		),
+		order("o2", "", 2, manapool.OrderStatusProcessing,
+			manapool.OrderItem{ProductType: "mtg_single", ProductID: "bolt", Quantity: 1, Product: bolt},
+		),
+		order("o3", "C3", 3, manapool.OrderStatusShipped),
+	))
+}
+
+func TestOrdersList(t *testing.T) {
+	srv := ordersServer()
+	defer srv.Close()
+
+	code, stdout, stderr := runCLI(t, srv, "orders", "list", "-status", "paid", "-format", "csv")
+	if code != 0 {
+		t.Fatalf("run() = %d, stderr %q", code, stderr)
+	}
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil || len(records) != 3 {
+		t.Fatalf("csv = %q (%v)", stdout, err)
+	}
+	// The server lists newest orders first.
+	if got := strings.Join(records[2], ","); got != "o1,2026-05-01T12:00:00Z,A1,pending,standard,10.00" {
+		t.Errorf("row = %s", got)
+	}
+	if records[1][3] != "processing" {
+		t.Errorf("row = %q", records[1])
+	}
+
+	code, stdout, _ = runCLI(t, srv, "orders", "list", "-status", "shipped", "-since", "2026-05-02", "-format", "json")
+	var orders []manapool.OrderSummary
+	if err := json.Unmarshal([]byte(stdout), &orders); code != 0 || err != nil || len(orders) != 1 || orders[0].ID != "o3" {
+		t.Errorf("json = %s (%v)", stdout, err)
+	}
+
+	code, stdout, _ = runCLI(t, srv, "orders", "list", "-status", "refunded", "-format", "json")
+	if code != 0 || strings.TrimSpace(stdout) != "[]" {
+		t.Errorf("run(refunded) = %d, %q", code, stdout)
+	}
+
+	code, stdout, _ = runCLI(t, srv, "orders", "list")
+	if lines := strings.Split(strings.TrimSpace(stdout), "\n"); code != 0 || len(lines) != 4 || !strings.HasPrefix(lines[0], "ID") {
+		t.Errorf("table = %q", stdout)
+	}
+
+	for _, args := range [][]string{
+		{"-status", "paid-ish"},
+		{"-since", "yesterday"},
+		{"-format", "xml"},
+		{"extra"},
+	} {
+		if code, _, _ := runCLI(t, srv, append([]string{"orders", "list"}, args...)...); code != 1 {
+			t.Errorf("run(%q) = %d, want 1", args, code)
+		}
+	}
+}
+
+func TestOrdersPullSheet(t *testing.T) {
+	srv := ordersServer()
+	defer srv.Close()
+
+	code, stdout, stderr := runCLI(t, srv, "orders", "pull-sheet", "o1", "o2", "o1")
+	if code != 0 {
+		t.Fatalf("run() = %d, stderr %q", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("output = %q", stdout)
+	}
+	if got := strings.Join(strings.Fields(lines[1]), " "); got != "1 2ED 270 Lightly Played Sol Ring A1 x1" {
+		t.Errorf("line 1 = %q", got)
+	}
+	if got := strings.Join(strings.Fields(lines[2]), " "); got != "3 LEA 161 Near Mint Lightning Bolt A1 x2, o2 x1" {
+		t.Errorf("line 2 = %q", got)
+	}
+	if lines[4] != "4 items across 2 orders" {
+		t.Errorf("summary = %q", lines[4])
+	}
+
+	code, stdout, _ = runCLI(t, srv, "orders", "pull-sheet", "-unfulfilled")
+	if code != 0 || !strings.Contains(stdout, "4 items across 2 orders") {
+		t.Errorf("run(-unfulfilled) = %d, %q", code, stdout)
+	}
+
+	if code, _, stderr := runCLI(t, srv, "orders", "pull-sheet"); code != 1 || !strings.Contains(stderr, "give order IDs") {
+		t.Errorf("run(no ids) = %d, %q", code, stderr)
+	}
+	if code, _, stderr := runCLI(t, srv, "orders", "pull-sheet", "missing"); code != 1 || !strings.Contains(stderr, "failed to get order missing") {
+		t.Errorf("run(missing) = %d, %q", code, stderr)
+	}
+}
+
+func TestOrdersShip(t *testing.T) {
+	srv := ordersServer()
+	defer srv.Close()
+
+	code, stdout, stderr := runCLI(t, srv, "orders", "ship", "o1", "--tracking", "9400", "-carrier", "USPS")
+	if code != 0 || strings.TrimSpace(stdout) != "Order o1 marked shipped with tracking 9400." {
+		t.Fatalf("run() = %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	order, _ := srv.Order("o1")
+	if order.FulfillmentStatus() != manapool.OrderStatusShipped || len(order.Fulfillments) != 1 {
+		t.Fatalf("order = %+v", order)
+	}
+	f := order.Fulfillments[0]
+	if *f.TrackingNumber != "9400" || *f.TrackingCompany != "USPS" || f.TrackingURL != nil || f.InTransitAt == nil {
+		t.Errorf("fulfillment = %+v", f)
+	}
+
+	if code, stdout, _ := runCLI(t, srv, "orders", "ship", "o2"); code != 0 || strings.TrimSpace(stdout) != "Order o2 marked shipped." {
+		t.Errorf("run(no tracking) = %d, %q", code, stdout)
+	}
+	if code, _, stderr := runCLI(t, srv, "orders", "ship", "o3"); code != 1 || !strings.Contains(stderr, "order o3 is shipped and cannot be shipped") {
+		t.Errorf("run(shipped) = %d, %q", code, stderr)
+	}
+	if code, _, stderr := runCLI(t, srv, "orders", "ship"); code != 1 || !strings.Contains(stderr, "exactly one order ID") {
+		t.Errorf("run(no id) = %d, %q", code, stderr)
+	}
+	if code, _, _ := runCLI(t, srv, "orders", "ship", "o1", "-bogus"); code != 2 {
+		t.Errorf("run(-bogus) = %d, want 2", code)
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	a := &app{stderr: &strings.Builder{}}
+	fs := a.flagSet("test")
+	v := fs.Bool("v", false, "")
+	got, err := parseArgs(fs, []string{"a", "-v", "b", "--", "-c"})
+	if err != nil || !*v || strings.Join(got, " ") != "a b -c" {
+		t.Errorf("parseArgs() = %q, %v (v=%v)", got, err, *v)
+	}
+}
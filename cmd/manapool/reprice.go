@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/repricah/manapool"
+	"github.com/repricah/manapool/repricer"
+)
+
+// repriceColumns is the header for the price diff table.
+var repriceColumns = []string{"id", "name", "quantity", "old", "new", "change", "reason"}
+
+// reprice implements "manapool reprice".
+func (a *app) reprice(ctx context.Context, args []string) error {
+	fs := a.flagSet("reprice")
+	rulesPath := fs.String("rules", "", "path to a TOML, YAML, or JSON repricing rules file (required)")
+	dryRun := fs.Bool("dry-run", false, "preview the price changes without applying them (the default)")
+	apply := fs.Bool("apply", false, "apply the price changes")
+	market := fs.Bool("market", true, "load ManaPool market prices for market-based rules")
+	quiet := fs.Bool("quiet", false, "suppress progress output")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if *rulesPath == "" {
+		return fmt.Errorf("-rules is required")
+	}
+	if *dryRun && *apply {
+		return fmt.Errorf("-dry-run and -apply cannot be used together")
+	}
+
+	rules, err := repricer.LoadRules(*rulesPath)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	var items []manapool.InventoryItem
+	err = a.eachInventoryItem(ctx, client, 500, *quiet, func(item manapool.InventoryItem) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var source repricer.PriceSource
+	if *market {
+		index, err := client.LoadMarketPriceIndex(ctx)
+		if err != nil {
+			return err
+		}
+		a.progressf(*quiet, "loaded market prices for %d products", index.Len())
+		source = index
+	}
+
+	result, err := repricer.New(source, rules).Evaluate(ctx, items)
+	if err != nil {
+		return err
+	}
+	if err := a.writePriceDiff(items, result); err != nil {
+		return err
+	}
+
+	if !*apply {
+		if len(result.Updates) > 0 {
+			a.progressf(*quiet, "dry run: re-run with -apply to update prices")
+		}
+		return nil
+	}
+
+	if len(result.Updates) == 0 {
+		return nil
+	}
+	bulk, err := client.UpsertInventory(ctx, result.Upserts(), manapool.BulkOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to apply prices: %w", err)
+	}
+	for _, failure := range bulk.Failures() {
+		fmt.Fprintf(a.stderr, "not updated: %s: %s\n", failure.Identifier, failure.Message)
+	}
+	fmt.Fprintf(a.stdout, "Applied %d of %d price updates.\n", bulk.Count(manapool.BulkItemSucceeded), len(bulk.Items))
+	if err := bulk.Err(); err != nil {
+		return fmt.Errorf("failed to apply prices: %w", err)
+	}
+	return nil
+}
+
+// writePriceDiff prints a table of the proposed updates and a summary of
+// the change in total inventory value (price times quantity).
+func (a *app) writePriceDiff(items []manapool.InventoryItem, result *repricer.Result) error {
+	before := 0
+	for _, item := range items {
+		before += item.PriceCents * item.Quantity
+	}
+
+	change := 0
+	if len(result.Updates) > 0 {
+		tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(repriceColumns, "\t")))
+		for _, update := range result.Updates {
+			change += (update.NewCents - update.OldCents) * update.Item.Quantity
+			fmt.Fprintln(tw, strings.Join([]string{
+				update.Item.ID, itemName(update.Item), strconv.Itoa(update.Item.Quantity),
+				a.price(update.OldCents), a.price(update.NewCents),
+				a.signedPrice(update.NewCents - update.OldCents), strings.Join(update.Explanation, "; "),
+			}, "\t"))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stdout)
+	}
+
+	_, err := fmt.Fprintf(a.stdout, "%d of %d items repriced; total value %s -> %s (%s)\n",
+		len(result.Updates), len(items), a.price(before), a.price(before+change), a.signedPrice(change))
+	return err
+}
+
+// price formats cents for the price diff: in the -locale format if one was
+// given, such as "$1.25" or "1,25 $", and otherwise as a plain amount such as
+// "1.25".
+func (a *app) price(cents int) string {
+	if a.locale == "" {
+		return manapool.FormatCents(cents)
+	}
+	return manapool.FormatPrice(cents, manapool.USD, manapool.Locale(a.locale))
+}
+
+// signedPrice formats cents like price with an explicit sign, such as
+// "+1.25" or "-0.10".
+func (a *app) signedPrice(cents int) string {
+	if cents < 0 {
+		return a.price(cents)
+	}
+	return "+" + a.price(cents)
+}
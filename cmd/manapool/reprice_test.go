@@ -0,0 +1,143 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool/manapooltest"
+)
+
+func writeRules(t *testing.T, rules string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte(rules), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const singlesRules = `singles:
+  product_type: mtg_single
+  fixed: 2.00
+`
+
+func TestReprice_DryRun(t *testing.T) {
+	srv := inventoryServer()
+	defer srv.Close()
+	rules := writeRules(t, singlesRules)
+
+	code, stdout, stderr := runCLI(t, srv, "reprice", "-rules", rules, "-market=false")
+	if code != 0 {
+		t.Fatalf("run() = %d, stderr %q", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 5 || !strings.HasPrefix(lines[0], "ID") ||
+		strings.Join(strings.Fields(lines[1]), " ") != "1 Lightning Bolt 2 1.25 2.00 +0.75 fixed $2.00" ||
+		!strings.Contains(lines[2], "-48.00") {
+		t.Errorf("table = %q", stdout)
+	}
+	if lines[4] != "2 of 3 items repriced; total value 412.50 -> 366.00 (-46.50)" {
+		t.Errorf("summary = %q", lines[4])
+	}
+	if !strings.Contains(stderr, "dry run") {
+		t.Errorf("stderr = %q", stderr)
+	}
+	if got := srv.Inventory()[0].PriceCents; got != 125 {
+		t.Errorf("dry run changed price to %d", got)
+	}
+}
+
+func TestReprice_Locale(t *testing.T) {
+	srv := inventoryServer()
+	defer srv.Close()
+	rules := writeRules(t, singlesRules)
+
+	code, stdout, stderr := runCLI(t, srv, "-locale", "de-DE", "reprice", "-rules", rules, "-market=false", "-quiet")
+	if code != 0 {
+		t.Fatalf("run() = %d, stderr %q", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if strings.Join(strings.Fields(lines[1]), " ") != "1 Lightning Bolt 2 1,25 $ 2,00 $ +0,75 $ fixed $2.00" {
+		t.Errorf("table row = %q", lines[1])
+	}
+	if got := lines[len(lines)-1]; got != "2 of 3 items repriced; total value 412,50 $ -> 366,00 $ (-46,50 $)" {
+		t.Errorf("summary = %q", got)
+	}
+}
+
+func TestReprice_Apply(t *testing.T) {
+	srv := inventoryServer()
+	defer srv.Close()
+	rules := writeRules(t, singlesRules)
+
+	code, stdout, stderr := runCLI(t, srv, "reprice", "-rules", rules, "-market=false", "-apply", "-quiet")
+	if code != 0 {
+		t.Fatalf("run() = %d, stderr %q", code, stderr)
+	}
+	if !strings.Contains(stdout, "Applied 2 of 2 price updates.") {
+		t.Errorf("stdout = %q", stdout)
+	}
+	// Item 2 has no SKU, so it is updated by product.
+	for _, item := range srv.Inventory() {
+		if item.ProductType == "mtg_single" && item.PriceCents != 200 {
+			t.Errorf("item %s price = %d, want 200", item.ID, item.PriceCents)
+		}
+	}
+}
+
+func TestReprice_ApplyFailure(t *testing.T) {
+	srv := inventoryServer()
+	defer srv.Close()
+	rules := writeRules(t, singlesRules)
+	srv.InjectFault(manapooltest.Fault{Method: http.MethodPost, Path: "/seller/inventory/product", Status: http.StatusBadRequest})
+
+	code, stdout, stderr := runCLI(t, srv, "reprice", "-rules", rules, "-market=false", "-apply", "-quiet")
+	if code != 1 {
+		t.Fatalf("run() = %d, want 1", code)
+	}
+	if !strings.Contains(stdout, "Applied 1 of 2 price updates.") ||
+		!strings.Contains(stderr, "not updated: product:mtg_single/product-2") ||
+		!strings.Contains(stderr, "1 of 2 inventory upserts failed") {
+		t.Errorf("stdout = %q, stderr = %q", stdout, stderr)
+	}
+}
+
+func TestReprice_NoChanges(t *testing.T) {
+	srv := inventoryServer()
+	defer srv.Close()
+	rules := writeRules(t, "cheap:\n  ceiling: 1000\n")
+
+	code, stdout, _ := runCLI(t, srv, "reprice", "-rules", rules, "-market=false", "-quiet")
+	if code != 0 || strings.TrimSpace(stdout) != "0 of 3 items repriced; total value 412.50 -> 412.50 (+0.00)" {
+		t.Errorf("run() = %d, stdout %q", code, stdout)
+	}
+}
+
+func TestReprice_Errors(t *testing.T) {
+	srv := inventoryServer()
+	defer srv.Close()
+	rules := writeRules(t, singlesRules)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"missing rules", nil, "-rules is required"},
+		{"both modes", []string{"-rules", rules, "-dry-run", "-apply"}, "cannot be used together"},
+		{"bad rules", []string{"-rules", writeRules(t, "fixed: 1\n")}, "invalid rules"},
+		{"market prices", []string{"-rules", rules}, "failed to load market prices"},
+		{"arguments", []string{"-rules", rules, "extra"}, "unexpected arguments"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := runCLI(t, srv, append([]string{"reprice", "-quiet"}, tt.args...)...)
+			if code != 1 || !strings.Contains(stderr, tt.want) {
+				t.Errorf("run() = %d, stderr %q, want containing %q", code, stderr, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// status implements "manapool status". It exits non-zero unless the API is
+// reachable and accepts the credentials, so it can serve as a health check.
+func (a *app) status(ctx context.Context, args []string) error {
+	fs := a.flagSet("status")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	result, err := client.Ping(ctx)
+	if result != nil {
+		line := fmt.Sprintf("API %s in %s", result.Status, result.Latency.Round(time.Millisecond))
+		if result.StatusCode != 0 {
+			line += fmt.Sprintf(" (HTTP %d)", result.StatusCode)
+		}
+		if result.RequestID != "" {
+			line += ", request " + result.RequestID
+		}
+		fmt.Fprintln(a.stdout, line)
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool/manapooltest"
+)
+
+func TestStatus(t *testing.T) {
+	srv := manapooltest.NewServer()
+	defer srv.Close()
+
+	code, stdout, stderr := runCLI(t, srv, "status")
+	if code != 0 || !strings.HasPrefix(stdout, "API ok in ") || !strings.Contains(stdout, "(HTTP 200)") {
+		t.Errorf("run() = %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	if requests := srv.Requests(); len(requests) != 1 || requests[0].Method != http.MethodHead {
+		t.Errorf("requests = %+v, want one HEAD", requests)
+	}
+
+	srv.InjectFault(manapooltest.Fault{Status: http.StatusServiceUnavailable})
+	code, stdout, stderr = runCLI(t, srv, "status")
+	if code != 1 || !strings.Contains(stdout, "API unavailable") || !strings.Contains(stderr, "manapool status:") {
+		t.Errorf("run() = %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+
+	if code, _, _ := runCLI(t, srv, "status", "extra"); code != 1 {
+		t.Errorf("run(status extra) = %d", code)
+	}
+}
+
+func TestStatus_BadCredentials(t *testing.T) {
+	srv := manapooltest.NewServer(manapooltest.WithCredentials("other-token", "seller@example.com"))
+	defer srv.Close()
+
+	code, stdout, _ := runCLI(t, srv, "status")
+	if code != 1 || !strings.Contains(stdout, "API unauthorized") {
+		t.Errorf("run() = %d, stdout %q", code, stdout)
+	}
+}
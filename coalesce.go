@@ -0,0 +1,97 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// flightGroup collapses concurrent calls with the same key into one.
+// It is safe for concurrent use.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// flightCall is an in-progress or completed call shared by several callers.
+type flightCall struct {
+	done chan struct{}
+	resp *http.Response
+	body []byte
+	err  error
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: make(map[string]*flightCall)}
+}
+
+// do runs fn once for all concurrent callers with the same key and returns
+// a private copy of the buffered response to each of them. fn runs in its own
+// goroutine, so a caller whose ctx is cancelled returns early without
+// cancelling the call for the others. shared reports whether the result was
+// produced for another caller.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (*http.Response, error)) (resp *http.Response, shared bool, err error) {
+	g.mu.Lock()
+	call, shared := g.calls[key]
+	if !shared {
+		call = &flightCall{done: make(chan struct{})}
+		g.calls[key] = call
+		go g.run(key, call, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, shared, NewNetworkError("request cancelled", ctx.Err())
+	case <-call.done:
+	}
+
+	if call.err != nil {
+		return nil, shared, call.err
+	}
+	clone := *call.resp
+	clone.Header = call.resp.Header.Clone()
+	clone.Body = io.NopCloser(bytes.NewReader(call.body))
+	return &clone, shared, nil
+}
+
+// run executes fn, buffers its response, and releases the waiting callers.
+func (g *flightGroup) run(key string, call *flightCall, fn func() (*http.Response, error)) {
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	resp, err := fn()
+	if err != nil {
+		call.err = err
+		return
+	}
+	call.body, call.err = bufferBody(resp)
+	call.resp = resp
+}
+
+// doCoalescedRequest sends a GET through the client's flight group so that
+// identical concurrent requests share one upstream call. Requests are only
+// shared between callers using the same credentials.
+func (c *Client) doCoalescedRequest(ctx context.Context, endpoint string, params url.Values, header http.Header) (*http.Response, error) {
+	token, email, err := c.credentials(ctx)
+	if err != nil {
+		return c.sendRequest(ctx, http.MethodGet, endpoint, params, nil, header)
+	}
+
+	key := email + "\x00" + token + "\x00" + endpoint + "?" + params.Encode()
+	sharedCtx := context.WithoutCancel(ctx)
+	resp, shared, err := c.flights.do(ctx, key, func() (*http.Response, error) {
+		return c.sendRequest(sharedCtx, http.MethodGet, endpoint, params, nil, header)
+	})
+	if shared {
+		c.logger.Debugf("Coalesced request: GET %s", endpoint)
+	}
+	return resp, err
+}
@@ -0,0 +1,183 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_WithRequestCoalescing(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"username":"seller"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithRequestCoalescing(),
+		WithLimiter(nil),
+	)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			account, err := client.GetSellerAccount(context.Background())
+			if err == nil && account.Username != "seller" {
+				err = errors.New("wrong username " + account.Username)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetSellerAccount() error = %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("upstream requests = %d, want 1", got)
+	}
+
+	// Sequential requests are not coalesced
+	if _, err := client.GetSellerAccount(context.Background()); err != nil {
+		t.Fatalf("GetSellerAccount() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("upstream requests = %d, want 2", got)
+	}
+}
+
+func TestClient_WithRequestCoalescing_SharedError(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithRequestCoalescing(),
+		WithLimiter(nil),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetSellerAccount(context.Background())
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || !apiErr.IsNotFound() || apiErr.Message != "not found" {
+				t.Errorf("error = %v, want 404 APIError", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("upstream requests = %d, want 1", got)
+	}
+}
+
+func TestClient_WithRequestCoalescing_Cancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"username":"seller"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithRequestCoalescing(),
+		WithLimiter(nil),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderDone := make(chan error, 1)
+	go func() {
+		_, err := client.GetSellerAccount(ctx)
+		leaderDone <- err
+	}()
+
+	followerDone := make(chan error, 1)
+	time.Sleep(50 * time.Millisecond)
+	go func() {
+		_, err := client.GetSellerAccount(context.Background())
+		followerDone <- err
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	var netErr *NetworkError
+	if err := <-leaderDone; !errors.As(err, &netErr) || !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled caller error = %v, want cancellation", err)
+	}
+
+	close(release)
+	if err := <-followerDone; err != nil {
+		t.Errorf("follower error = %v", err)
+	}
+}
+
+func TestClient_WithRequestCoalescing_Bypass(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	type tenantKey struct{}
+	client := NewClient("", "",
+		WithBaseURL(server.URL+"/"),
+		WithRequestCoalescing(),
+		WithLimiter(nil),
+		WithCredentialsProvider(CredentialsFunc(func(ctx context.Context) (string, string, error) {
+			return ctx.Value(tenantKey{}).(string), "email", nil
+		})),
+	)
+
+	tenantA := context.WithValue(context.Background(), tenantKey{}, "token-a")
+	tenantB := context.WithValue(context.Background(), tenantKey{}, "token-b")
+
+	var wg sync.WaitGroup
+	for _, ctx := range []context.Context{
+		tenantA,
+		tenantB,
+		WithRequestOptions(tenantA, NoRetry()),
+	} {
+		wg.Add(1)
+		go func(ctx context.Context) {
+			defer wg.Done()
+			if _, err := client.GetSellerAccount(ctx); err != nil {
+				t.Errorf("GetSellerAccount() error = %v", err)
+			}
+		}(ctx)
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("upstream requests = %d, want 3", got)
+	}
+}
@@ -0,0 +1,123 @@
+package manapool
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CompareCollectorNumbers orders collector numbers the way cards are filed
+// in a binder, returning -1, 0, or +1.
+//
+// Numbers compare numerically, so "2" sorts before "10". A suffix follows
+// its number, so "123" < "123a" < "123b" < "123★". Numbers with a letter
+// prefix, such as "S1" or "GR-01", come after plain numbers and are grouped
+// by prefix, ignoring case, then ordered by number. Empty numbers sort
+// last. Numbers that differ only in leading zeros are ordered as strings
+// so the result is deterministic.
+//
+// Example:
+//
+//	sort.Slice(cards, func(i, j int) bool {
+//	    return manapool.CompareCollectorNumbers(cards[i].Number, cards[j].Number) < 0
+//	})
+func CompareCollectorNumbers(a, b string) int {
+	if a == b {
+		return 0
+	}
+	switch {
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	ap, an, as := splitCollectorNumber(a)
+	bp, bn, bs := splitCollectorNumber(b)
+	if c := compareFold(ap, bp); c != 0 {
+		// Plain numbers, with an empty prefix, come first.
+		return c
+	}
+	if an != bn {
+		if an < bn {
+			return -1
+		}
+		return 1
+	}
+	if c := compareFold(as, bs); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// SortInventoryByPrinting sorts items in binder order: singles by set,
+// collector number, and name, then sealed product by set and name, then
+// anything else. Listings of the same printing are ordered by ID.
+//
+// Example:
+//
+//	manapool.SortInventoryByPrinting(items)
+//	err := manapool.WriteInventoryCSV(w, items, manapool.DefaultColumnMap())
+func SortInventoryByPrinting(items []InventoryItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i].Product, items[j].Product
+		if ra, rb := printingRank(a), printingRank(b); ra != rb {
+			return ra < rb
+		}
+		switch {
+		case a.Single != nil:
+			if c := compareFold(a.Single.Set, b.Single.Set); c != 0 {
+				return c < 0
+			}
+			if c := CompareCollectorNumbers(a.Single.Number, b.Single.Number); c != 0 {
+				return c < 0
+			}
+			if a.Single.Name != b.Single.Name {
+				return a.Single.Name < b.Single.Name
+			}
+		case a.Sealed != nil:
+			if c := compareFold(a.Sealed.Set, b.Sealed.Set); c != 0 {
+				return c < 0
+			}
+			if a.Sealed.Name != b.Sealed.Name {
+				return a.Sealed.Name < b.Sealed.Name
+			}
+		}
+		return items[i].ID < items[j].ID
+	})
+}
+
+// printingRank orders singles before sealed product before anything else.
+func printingRank(p Product) int {
+	switch {
+	case p.Single != nil:
+		return 0
+	case p.Sealed != nil:
+		return 1
+	}
+	return 2
+}
+
+// splitCollectorNumber splits s into a non-digit prefix, the first run of
+// digits (-1 if none), and the remainder: "GR-01" is ("GR-", 1, ""), "123a"
+// is ("", 123, "a"), and "★" is ("★", -1, "").
+func splitCollectorNumber(s string) (prefix string, number int, suffix string) {
+	start := strings.IndexAny(s, "0123456789")
+	if start < 0 {
+		return s, -1, ""
+	}
+	end := start
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	n, err := strconv.Atoi(s[start:end])
+	if err != nil {
+		return s, -1, ""
+	}
+	return s[:start], n, s[end:]
+}
+
+// compareFold compares strings ignoring case.
+func compareFold(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
@@ -0,0 +1,76 @@
+package manapool
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestCompareCollectorNumbers(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"9", "10", -1},
+		{"2", "10", -1},
+		{"10", "10a", -1},
+		{"10a", "10b", -1},
+		{"10A", "10b", -1},
+		{"123b", "123★", -1},
+		{"123★", "124", -1},
+		{"100", "S1", -1},
+		{"S1", "5", 1},
+		{"GR-01", "GR-02", -1},
+		{"GR-10", "gr-2", 1},
+		{"GR-99", "S1", -1},
+		{"★", "1", 1},
+		{"7", "7", 0},
+		{"007", "7", -1},
+		{"", "1", 1},
+		{"1", "", -1},
+	}
+	for _, tt := range tests {
+		if got := CompareCollectorNumbers(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareCollectorNumbers(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := CompareCollectorNumbers(tt.b, tt.a); got != -tt.want {
+			t.Errorf("CompareCollectorNumbers(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestCompareCollectorNumbers_BinderOrder(t *testing.T) {
+	numbers := []string{"", "S1", "10", "123★", "GR-02", "2", "123a", "123", "GR-01", "1"}
+	sort.Slice(numbers, func(i, j int) bool {
+		return CompareCollectorNumbers(numbers[i], numbers[j]) < 0
+	})
+	want := "1,2,10,123,123a,123★,GR-01,GR-02,S1,"
+	if got := strings.Join(numbers, ","); got != want {
+		t.Errorf("order = %s, want %s", got, want)
+	}
+}
+
+func TestSortInventoryByPrinting(t *testing.T) {
+	single := func(id, set, number, name string) InventoryItem {
+		return InventoryItem{ID: id, Product: Product{Single: &Single{Set: set, Number: number, Name: name}}}
+	}
+	items := []InventoryItem{
+		{ID: "box", Product: Product{Sealed: &Sealed{Set: "ICE", Name: "Ice Age Booster Box"}}},
+		{ID: "other"},
+		single("bolt-b", "M10", "146", "Lightning Bolt"),
+		single("forest", "M10", "246", "Forest"),
+		single("bolt-a", "M10", "146", "Lightning Bolt"),
+		single("ring", "cmr", "472", "Sol Ring"),
+		single("angel", "M10", "1", "Ajani Goldmane"),
+	}
+	SortInventoryByPrinting(items)
+
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	want := "ring,angel,bolt-a,bolt-b,forest,box,other"
+	if got := strings.Join(ids, ","); got != want {
+		t.Errorf("order = %s, want %s", got, want)
+	}
+}
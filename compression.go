@@ -0,0 +1,60 @@
+package manapool
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// shouldCompressRequest reports whether a request body of size bytes should
+// be gzipped before sending.
+func (c *Client) shouldCompressRequest(size int) bool {
+	return !c.disableCompression && c.requestCompressionMin > 0 && size >= c.requestCompressionMin
+}
+
+// gzipBytes returns a gzip-compressed copy of data.
+func gzipBytes(data []byte) (*bytes.Buffer, error) {
+	buf := &bytes.Buffer{}
+	zw := gzip.NewWriter(buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// decompressResponse replaces a gzip-encoded response body with a reader that
+// yields the decompressed content.
+func decompressResponse(resp *http.Response) error {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	zr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		_ = resp.Body.Close()
+		return NewNetworkError("failed to decompress response", err)
+	}
+
+	resp.Body = &gzipReadCloser{Reader: zr, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// gzipReadCloser closes both the gzip reader and the underlying body.
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (g *gzipReadCloser) Close() error {
+	_ = g.Reader.Close()
+	return g.body.Close()
+}
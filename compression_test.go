@@ -0,0 +1,150 @@
+package manapool
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_GzipResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept-Encoding"); got != "gzip" {
+			t.Errorf("Accept-Encoding = %q, want gzip", got)
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusOK)
+		zw := gzip.NewWriter(w)
+		_, _ = zw.Write([]byte(`{"username":"seller"}`))
+		_ = zw.Close()
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	account, err := client.GetSellerAccount(context.Background())
+	if err != nil {
+		t.Fatalf("GetSellerAccount() error = %v", err)
+	}
+	if account.Username != "seller" {
+		t.Errorf("Username = %q, want seller", account.Username)
+	}
+}
+
+func TestClient_GzipResponse_Invalid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("not gzip"))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	_, err := client.GetSellerAccount(context.Background())
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) {
+		t.Fatalf("error = %v, want NetworkError", err)
+	}
+}
+
+func TestClient_WithCompression_Disabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept-Encoding"); got != "identity" {
+			t.Errorf("Accept-Encoding = %q, want identity", got)
+		}
+		if got := r.Header.Get("Content-Encoding"); got != "" {
+			t.Errorf("Content-Encoding = %q, want empty", got)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"inventory":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithCompression(false),
+		WithRequestCompression(1),
+	)
+	items := []InventoryBulkItemBySKU{{TCGPlayerSKU: 1, PriceCents: 100, Quantity: 1}}
+	if _, err := client.CreateInventoryBulk(context.Background(), items); err != nil {
+		t.Fatalf("CreateInventoryBulk() error = %v", err)
+	}
+}
+
+func TestClient_WithRequestCompression(t *testing.T) {
+	tests := []struct {
+		name         string
+		minBytes     int
+		wantEncoding string
+	}{
+		{name: "below threshold", minBytes: 1 << 20, wantEncoding: ""},
+		{name: "above threshold", minBytes: 16, wantEncoding: "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Content-Encoding"); got != tt.wantEncoding {
+					t.Errorf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+				}
+				var reader io.Reader = r.Body
+				if tt.wantEncoding == "gzip" {
+					zr, err := gzip.NewReader(r.Body)
+					if err != nil {
+						t.Fatalf("gzip.NewReader: %v", err)
+					}
+					reader = zr
+				}
+				var items []InventoryBulkItemBySKU
+				if err := json.NewDecoder(reader).Decode(&items); err != nil {
+					t.Fatalf("decode body: %v", err)
+				}
+				if len(items) != 2 {
+					t.Errorf("items = %d, want 2", len(items))
+				}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"inventory":[]}`))
+			}))
+			defer server.Close()
+
+			client := NewClient("token", "email",
+				WithBaseURL(server.URL+"/"),
+				WithRequestCompression(tt.minBytes),
+			)
+			items := []InventoryBulkItemBySKU{
+				{TCGPlayerSKU: 1, PriceCents: 100, Quantity: 1},
+				{TCGPlayerSKU: 2, PriceCents: 200, Quantity: 2},
+			}
+			if _, err := client.CreateInventoryBulk(context.Background(), items); err != nil {
+				t.Fatalf("CreateInventoryBulk() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestGzipReadCloser_Close(t *testing.T) {
+	compressed, err := gzipBytes([]byte("hello"))
+	if err != nil {
+		t.Fatalf("gzipBytes: %v", err)
+	}
+	resp := &http.Response{
+		Header: http.Header{"Content-Encoding": []string{"gzip"}},
+		Body:   io.NopCloser(compressed),
+	}
+	if err := decompressResponse(resp); err != nil {
+		t.Fatalf("decompressResponse: %v", err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	if string(data) != "hello" {
+		t.Errorf("body = %q, want hello", data)
+	}
+	if err := resp.Body.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if resp.Header.Get("Content-Encoding") != "" {
+		t.Error("Content-Encoding header not removed")
+	}
+}
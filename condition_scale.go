@@ -0,0 +1,130 @@
+package manapool
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConditionScale is another marketplace's card condition scale.
+type ConditionScale string
+
+const (
+	// ScaleCardmarket is Cardmarket's scale: MT (Mint), NM (Near Mint), EX
+	// (Excellent), GD (Good), LP (Light Played), PL (Played), PO (Poor).
+	ScaleCardmarket ConditionScale = "cardmarket"
+
+	// ScaleEbay is eBay's ungraded trading card conditions: "Near Mint or
+	// Better", "Excellent", "Very Good", and "Poor".
+	ScaleEbay ConditionScale = "ebay"
+)
+
+// ConditionConversion is the result of converting a condition between
+// ManaPool's scale and another marketplace's.
+type ConditionConversion struct {
+	// Condition is the converted condition: a code on the target scale, or
+	// a ManaPool condition ID
+	Condition string
+
+	// Lossy is true when converting Condition back does not return the
+	// original condition, because the scales have no matching grade
+	Lossy bool
+
+	// Warning explains a lossy conversion, for import and cross-listing
+	// reports
+	Warning string
+}
+
+type conditionStep struct {
+	to      string
+	warning string
+}
+
+var (
+	toScale = map[ConditionScale]map[string]conditionStep{
+		ScaleCardmarket: {
+			"NM":  {to: "NM"},
+			"LP":  {to: "EX"},
+			"MP":  {to: "GD"},
+			"HP":  {to: "PL"},
+			"DMG": {to: "PO"},
+		},
+		ScaleEbay: {
+			"NM":  {to: "Near Mint or Better"},
+			"LP":  {to: "Excellent"},
+			"MP":  {to: "Very Good"},
+			"HP":  {to: "Poor", warning: "eBay has no grade between Very Good and Poor; Heavily Played listed as Poor"},
+			"DMG": {to: "Poor"},
+		},
+	}
+
+	// fromScale is keyed by lower-case condition.
+	fromScale = map[ConditionScale]map[string]conditionStep{
+		ScaleCardmarket: {
+			"mt": {to: "NM", warning: "ManaPool has no Mint grade; Cardmarket Mint imported as Near Mint"},
+			"nm": {to: "NM"},
+			"ex": {to: "LP"},
+			"gd": {to: "MP"},
+			"lp": {to: "HP", warning: "Cardmarket Light Played falls between Moderately and Heavily Played; imported as Heavily Played"},
+			"pl": {to: "HP"},
+			"po": {to: "DMG"},
+		},
+		ScaleEbay: {
+			"near mint or better": {to: "NM"},
+			"excellent":           {to: "LP"},
+			"very good":           {to: "MP"},
+			"poor":                {to: "DMG"},
+		},
+	}
+)
+
+// ConvertCondition converts a ManaPool condition ID to the nearest grade on
+// another scale. Grades without an exact match are converted to the nearest
+// equivalent and reported as Lossy with a Warning; cross-listing pipelines
+// should surface these rather than drop them. Unknown IDs or scales return
+// a *ValidationError.
+//
+// Example:
+//
+//	conv, err := manapool.ConvertCondition(single.ConditionID, manapool.ScaleCardmarket)
+//	if err != nil {
+//	    return err
+//	}
+//	if conv.Lossy {
+//	    log.Printf("listing %s: %s", item.ID, conv.Warning)
+//	}
+func ConvertCondition(conditionID string, to ConditionScale) (ConditionConversion, error) {
+	steps, ok := toScale[to]
+	if !ok {
+		return ConditionConversion{}, NewValidationError("scale", fmt.Sprintf("unknown condition scale %q", to))
+	}
+	step, ok := steps[conditionID]
+	if !ok {
+		return ConditionConversion{}, NewValidationError("condition_id", fmt.Sprintf("unknown condition ID %q", conditionID))
+	}
+	return step.conversion(), nil
+}
+
+// ParseCondition converts a condition on another scale to a ManaPool
+// condition ID. Conditions are matched ignoring case and surrounding space.
+// Lossy conversions err toward the worse ManaPool grade, so imported cards
+// are never described better than the source did.
+//
+// Example:
+//
+//	conv, err := manapool.ParseCondition("EX", manapool.ScaleCardmarket)
+//	// conv.Condition == "LP"
+func ParseCondition(condition string, from ConditionScale) (ConditionConversion, error) {
+	steps, ok := fromScale[from]
+	if !ok {
+		return ConditionConversion{}, NewValidationError("scale", fmt.Sprintf("unknown condition scale %q", from))
+	}
+	step, ok := steps[strings.ToLower(strings.TrimSpace(condition))]
+	if !ok {
+		return ConditionConversion{}, NewValidationError("condition", fmt.Sprintf("unknown %s condition %q", from, condition))
+	}
+	return step.conversion(), nil
+}
+
+func (s conditionStep) conversion() ConditionConversion {
+	return ConditionConversion{Condition: s.to, Lossy: s.warning != "", Warning: s.warning}
+}
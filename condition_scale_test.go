@@ -0,0 +1,139 @@
+package manapool
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConvertCondition(t *testing.T) {
+	tests := []struct {
+		id        string
+		scale     ConditionScale
+		want      string
+		wantLossy bool
+	}{
+		{"NM", ScaleCardmarket, "NM", false},
+		{"LP", ScaleCardmarket, "EX", false},
+		{"MP", ScaleCardmarket, "GD", false},
+		{"HP", ScaleCardmarket, "PL", false},
+		{"DMG", ScaleCardmarket, "PO", false},
+		{"NM", ScaleEbay, "Near Mint or Better", false},
+		{"LP", ScaleEbay, "Excellent", false},
+		{"MP", ScaleEbay, "Very Good", false},
+		{"HP", ScaleEbay, "Poor", true},
+		{"DMG", ScaleEbay, "Poor", false},
+	}
+	covered := make(map[ConditionScale]int)
+	for _, tt := range tests {
+		covered[tt.scale]++
+		conv, err := ConvertCondition(tt.id, tt.scale)
+		if err != nil {
+			t.Errorf("ConvertCondition(%s, %s) error = %v", tt.id, tt.scale, err)
+			continue
+		}
+		if conv.Condition != tt.want || conv.Lossy != tt.wantLossy || (conv.Warning != "") != tt.wantLossy {
+			t.Errorf("ConvertCondition(%s, %s) = %+v, want %s lossy=%v", tt.id, tt.scale, conv, tt.want, tt.wantLossy)
+		}
+	}
+	for scale, steps := range toScale {
+		if covered[scale] != len(steps) {
+			t.Errorf("%s: %d of %d conversions tested", scale, covered[scale], len(steps))
+		}
+	}
+}
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		condition string
+		scale     ConditionScale
+		want      string
+		wantLossy bool
+	}{
+		{"MT", ScaleCardmarket, "NM", true},
+		{"nm", ScaleCardmarket, "NM", false},
+		{" EX ", ScaleCardmarket, "LP", false},
+		{"GD", ScaleCardmarket, "MP", false},
+		{"LP", ScaleCardmarket, "HP", true},
+		{"PL", ScaleCardmarket, "HP", false},
+		{"PO", ScaleCardmarket, "DMG", false},
+		{"Near Mint or Better", ScaleEbay, "NM", false},
+		{"excellent", ScaleEbay, "LP", false},
+		{"Very Good", ScaleEbay, "MP", false},
+		{"Poor", ScaleEbay, "DMG", false},
+	}
+	covered := make(map[ConditionScale]int)
+	for _, tt := range tests {
+		covered[tt.scale]++
+		conv, err := ParseCondition(tt.condition, tt.scale)
+		if err != nil {
+			t.Errorf("ParseCondition(%q, %s) error = %v", tt.condition, tt.scale, err)
+			continue
+		}
+		if conv.Condition != tt.want || conv.Lossy != tt.wantLossy || (conv.Warning != "") != tt.wantLossy {
+			t.Errorf("ParseCondition(%q, %s) = %+v, want %s lossy=%v", tt.condition, tt.scale, conv, tt.want, tt.wantLossy)
+		}
+	}
+	for scale, steps := range fromScale {
+		if covered[scale] != len(steps) {
+			t.Errorf("%s: %d of %d conversions tested", scale, covered[scale], len(steps))
+		}
+	}
+}
+
+// TestConditionScale_LossyMatchesRoundTrip checks every pair in both
+// directions: a conversion is lossy exactly when converting back does not
+// return the original condition.
+func TestConditionScale_LossyMatchesRoundTrip(t *testing.T) {
+	for scale, steps := range toScale {
+		for id := range steps {
+			conv, _ := ConvertCondition(id, scale)
+			back, err := ParseCondition(conv.Condition, scale)
+			if err != nil {
+				t.Errorf("ParseCondition(%q, %s) error = %v", conv.Condition, scale, err)
+				continue
+			}
+			if conv.Lossy != (back.Condition != id) {
+				t.Errorf("%s: %s -> %s -> %s, lossy = %v", scale, id, conv.Condition, back.Condition, conv.Lossy)
+			}
+		}
+	}
+	for scale, steps := range fromScale {
+		for condition := range steps {
+			conv, _ := ParseCondition(condition, scale)
+			back, err := ConvertCondition(conv.Condition, scale)
+			if err != nil {
+				t.Errorf("ConvertCondition(%s, %s) error = %v", conv.Condition, scale, err)
+				continue
+			}
+			if roundTrip := strings.EqualFold(back.Condition, condition); conv.Lossy == roundTrip {
+				t.Errorf("%s: %s -> %s -> %s, lossy = %v", scale, condition, conv.Condition, back.Condition, conv.Lossy)
+			}
+		}
+	}
+	for id := range knownConditionIDs {
+		for scale := range toScale {
+			if _, err := ConvertCondition(id, scale); err != nil {
+				t.Errorf("ConvertCondition(%s, %s) error = %v", id, scale, err)
+			}
+		}
+	}
+}
+
+func TestConditionScale_Errors(t *testing.T) {
+	for name, err := range map[string]error{
+		"unknown id":           errOnly(ConvertCondition("GD", ScaleCardmarket)),
+		"unknown target scale": errOnly(ConvertCondition("NM", "tcgplayer")),
+		"unknown condition":    errOnly(ParseCondition("Mint", ScaleEbay)),
+		"unknown source scale": errOnly(ParseCondition("NM", "")),
+	} {
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) {
+			t.Errorf("%s: error = %v, want *ValidationError", name, err)
+		}
+	}
+}
+
+func errOnly(_ ConditionConversion, err error) error {
+	return err
+}
@@ -0,0 +1,435 @@
+// Package config loads manapool client settings from a file.
+//
+// Settings can be written in TOML, YAML, or JSON (a dependency-free subset
+// of each: flat keys plus one level of sections). Every key is checked
+// against the schema below, environment variables override file values,
+// and the result converts to a []manapool.ClientOption.
+//
+//	# manapool.toml
+//	email = "seller@example.com"
+//	access_token_env = "MANAPOOL_TOKEN"  # or access_token / access_token_file
+//	base_url = "https://manapool.com/api/v1/"
+//	api_version = "v1"
+//	user_agent = "my-sync/1.0"
+//	timeout = "30s"
+//	log_level = "error"                  # none, error, or debug
+//
+//	[rate_limit]
+//	requests_per_second = 5
+//	burst = 2
+//
+//	[retry]
+//	max_retries = 3
+//	initial_backoff = "1s"
+//
+// # Basic Usage
+//
+//	cfg, err := config.Load("manapool.toml")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	client, err := cfg.NewClient()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+package config
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+// EnvLogLevel overrides log_level. The other overrides are the variables
+// read by manapool.NewClientFromEnv.
+const EnvLogLevel = "MANAPOOL_LOG_LEVEL"
+
+// Log levels accepted by log_level.
+const (
+	LogLevelNone  = "none"
+	LogLevelError = "error"
+	LogLevelDebug = "debug"
+)
+
+// apiVersionPattern matches api_version values such as "v2".
+var apiVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+// Config holds client settings.
+type Config struct {
+	// AccessToken is the API token. Prefer AccessTokenEnv or AccessTokenFile
+	// so secrets stay out of config files.
+	AccessToken string
+
+	// AccessTokenEnv names an environment variable holding the token
+	AccessTokenEnv string
+
+	// AccessTokenFile is a path to a file holding the token
+	AccessTokenFile string
+
+	Email     string
+	BaseURL   string
+	UserAgent string
+	Timeout   time.Duration
+
+	// APIVersion selects the API version, such as "v2" ("" keeps the base
+	// URL's version)
+	APIVersion string
+
+	// RequestsPerSecond and Burst configure rate limiting (0 keeps defaults)
+	RequestsPerSecond float64
+	Burst             int
+
+	// MaxRetries configures retries (nil keeps the default)
+	MaxRetries     *int
+	InitialBackoff time.Duration
+
+	// LogLevel is one of LogLevelNone (default), LogLevelError, or LogLevelDebug
+	LogLevel string
+
+	// LogOutput receives log lines when LogLevel is not none (default: os.Stderr)
+	LogOutput io.Writer
+}
+
+// FieldError describes a problem with a single config setting.
+type FieldError struct {
+	Key string
+
+	// Line is the 1-based source line (0 if unknown)
+	Line int
+
+	Message string
+}
+
+// Error implements the error interface.
+func (e FieldError) Error() string {
+	switch {
+	case e.Line > 0 && e.Key != "":
+		return fmt.Sprintf("line %d: %s: %s", e.Line, e.Key, e.Message)
+	case e.Line > 0:
+		return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+	case e.Key != "":
+		return fmt.Sprintf("%s: %s", e.Key, e.Message)
+	}
+	return e.Message
+}
+
+// ValidationError lists every problem found in a config.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		msgs[i] = fieldErr.Error()
+	}
+	return "invalid config: " + strings.Join(msgs, "; ")
+}
+
+// fieldKind is the type of a schema key.
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindInt
+	kindFloat
+	kindDuration
+)
+
+// schema maps every accepted key to its type and destination.
+var schema = map[string]struct {
+	kind fieldKind
+	set  func(c *Config, v interface{})
+}{
+	"access_token":                   {kindString, func(c *Config, v interface{}) { c.AccessToken = v.(string) }},
+	"access_token_env":               {kindString, func(c *Config, v interface{}) { c.AccessTokenEnv = v.(string) }},
+	"access_token_file":              {kindString, func(c *Config, v interface{}) { c.AccessTokenFile = v.(string) }},
+	"email":                          {kindString, func(c *Config, v interface{}) { c.Email = v.(string) }},
+	"base_url":                       {kindString, func(c *Config, v interface{}) { c.BaseURL = v.(string) }},
+	"api_version":                    {kindString, func(c *Config, v interface{}) { c.APIVersion = v.(string) }},
+	"user_agent":                     {kindString, func(c *Config, v interface{}) { c.UserAgent = v.(string) }},
+	"timeout":                        {kindDuration, func(c *Config, v interface{}) { c.Timeout = v.(time.Duration) }},
+	"log_level":                      {kindString, func(c *Config, v interface{}) { c.LogLevel = v.(string) }},
+	"rate_limit.requests_per_second": {kindFloat, func(c *Config, v interface{}) { c.RequestsPerSecond = v.(float64) }},
+	"rate_limit.burst":               {kindInt, func(c *Config, v interface{}) { c.Burst = v.(int) }},
+	"retry.max_retries":              {kindInt, func(c *Config, v interface{}) { n := v.(int); c.MaxRetries = &n }},
+	"retry.initial_backoff":          {kindDuration, func(c *Config, v interface{}) { c.InitialBackoff = v.(time.Duration) }},
+}
+
+// envOverrides maps environment variables to schema keys.
+var envOverrides = []struct {
+	env string
+	key string
+}{
+	{manapool.EnvAccessToken, "access_token"},
+	{manapool.EnvEmail, "email"},
+	{manapool.EnvBaseURL, "base_url"},
+	{manapool.EnvAPIVersion, "api_version"},
+	{manapool.EnvRateLimit, "rate_limit.requests_per_second"},
+	{manapool.EnvRateBurst, "rate_limit.burst"},
+	{manapool.EnvMaxRetries, "retry.max_retries"},
+	{manapool.EnvRetryBackoff, "retry.initial_backoff"},
+	{manapool.EnvTimeout, "timeout"},
+	{EnvLogLevel, "log_level"},
+}
+
+// Load reads the config file at path, applies environment overrides, and
+// validates the result. The format is chosen by extension: .toml, .yaml or
+// .yml, or .json.
+func Load(path string) (*Config, error) {
+	format, err := FormatForPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	cfg, err := Parse(f, format)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.ApplyEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Parse decodes settings from r without applying environment overrides or
+// validating required fields. Unknown keys, duplicate keys, and malformed
+// values are reported together in a *ValidationError.
+func Parse(r io.Reader, format Format) (*Config, error) {
+	entries, err := parse(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	var problems []FieldError
+	seen := make(map[string]int)
+	for _, e := range entries {
+		if first, dup := seen[e.key]; dup {
+			problems = append(problems, FieldError{Key: e.key, Line: e.line, Message: fmt.Sprintf("duplicate key (first set on line %d)", first)})
+			continue
+		}
+		seen[e.key] = e.line
+		if err := cfg.set(e.key, e.value); err != nil {
+			problems = append(problems, FieldError{Key: e.key, Line: e.line, Message: err.Error()})
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &ValidationError{Errors: problems}
+	}
+	return cfg, nil
+}
+
+// ApplyEnv overrides settings from environment variables looked up with
+// lookup (typically os.LookupEnv). Empty variables are ignored.
+func (c *Config) ApplyEnv(lookup func(string) (string, bool)) error {
+	var problems []FieldError
+	for _, override := range envOverrides {
+		value, _ := lookup(override.env)
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		if err := c.set(override.key, value); err != nil {
+			problems = append(problems, FieldError{Key: override.env, Message: err.Error()})
+		}
+	}
+	if len(problems) > 0 {
+		return &ValidationError{Errors: problems}
+	}
+	return nil
+}
+
+// set parses value according to the schema and stores it.
+func (c *Config) set(key, value string) error {
+	field, ok := schema[key]
+	if !ok {
+		return fmt.Errorf("unknown setting")
+	}
+
+	switch field.kind {
+	case kindString:
+		field.set(c, value)
+	case kindInt:
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("must be a non-negative integer, got %q", value)
+		}
+		field.set(c, n)
+	case kindFloat:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil || n < 0 {
+			return fmt.Errorf("must be a non-negative number, got %q", value)
+		}
+		field.set(c, n)
+	case kindDuration:
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			return fmt.Errorf("must be a duration such as \"30s\", got %q", value)
+		}
+		field.set(c, d)
+	}
+	return nil
+}
+
+// Validate checks that required settings are present and consistent.
+func (c *Config) Validate() error {
+	var problems []FieldError
+
+	sources := 0
+	for _, s := range []string{c.AccessToken, c.AccessTokenEnv, c.AccessTokenFile} {
+		if s != "" {
+			sources++
+		}
+	}
+	switch {
+	case sources == 0:
+		problems = append(problems, FieldError{Key: "access_token", Message: "one of access_token, access_token_env, or access_token_file is required"})
+	case sources > 1 && c.AccessToken == "":
+		problems = append(problems, FieldError{Key: "access_token", Message: "access_token_env and access_token_file are mutually exclusive"})
+	}
+	if c.Email == "" {
+		problems = append(problems, FieldError{Key: "email", Message: "is required"})
+	}
+	if c.BaseURL != "" && !strings.HasPrefix(c.BaseURL, "http://") && !strings.HasPrefix(c.BaseURL, "https://") {
+		problems = append(problems, FieldError{Key: "base_url", Message: "must be an http or https URL"})
+	}
+	if c.APIVersion != "" && !apiVersionPattern.MatchString(c.APIVersion) {
+		problems = append(problems, FieldError{Key: "api_version", Message: fmt.Sprintf("must be a version such as v2, got %q", c.APIVersion)})
+	}
+	switch c.LogLevel {
+	case "", LogLevelNone, LogLevelError, LogLevelDebug:
+	default:
+		problems = append(problems, FieldError{Key: "log_level", Message: fmt.Sprintf("must be none, error, or debug, got %q", c.LogLevel)})
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Errors: problems}
+	}
+	return nil
+}
+
+// Token resolves the access token. A literal access_token (for example from
+// MANAPOOL_ACCESS_TOKEN) takes precedence over the env and file references.
+func (c *Config) Token() (string, error) {
+	switch {
+	case c.AccessToken != "":
+		return c.AccessToken, nil
+	case c.AccessTokenEnv != "":
+		token := strings.TrimSpace(os.Getenv(c.AccessTokenEnv))
+		if token == "" {
+			return "", fmt.Errorf("access token variable %s is not set", c.AccessTokenEnv)
+		}
+		return token, nil
+	case c.AccessTokenFile != "":
+		data, err := os.ReadFile(c.AccessTokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read access token file: %w", err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("access token file %s is empty", c.AccessTokenFile)
+		}
+		return token, nil
+	}
+	return "", fmt.Errorf("no access token configured")
+}
+
+// ClientOptions converts the settings into client options. Credentials are
+// not included; see Token and NewClient.
+func (c *Config) ClientOptions() []manapool.ClientOption {
+	var opts []manapool.ClientOption
+
+	if c.BaseURL != "" {
+		baseURL := c.BaseURL
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		opts = append(opts, manapool.WithBaseURL(baseURL))
+	}
+	if c.APIVersion != "" {
+		opts = append(opts, manapool.WithAPIVersion(c.APIVersion))
+	}
+	if c.UserAgent != "" {
+		opts = append(opts, manapool.WithUserAgent(c.UserAgent))
+	}
+	if c.Timeout > 0 {
+		opts = append(opts, manapool.WithTimeout(c.Timeout))
+	}
+	if c.RequestsPerSecond > 0 || c.Burst > 0 {
+		rps, burst := c.RequestsPerSecond, c.Burst
+		if rps <= 0 {
+			rps = manapool.DefaultRateLimit
+		}
+		if burst <= 0 {
+			burst = manapool.DefaultRateBurst
+		}
+		opts = append(opts, manapool.WithRateLimit(rps, burst))
+	}
+	if c.MaxRetries != nil || c.InitialBackoff > 0 {
+		retries, backoff := manapool.DefaultMaxRetries, manapool.DefaultInitialBackoff
+		if c.MaxRetries != nil {
+			retries = *c.MaxRetries
+		}
+		if c.InitialBackoff > 0 {
+			backoff = c.InitialBackoff
+		}
+		opts = append(opts, manapool.WithRetry(retries, backoff))
+	}
+	if c.LogLevel == LogLevelError || c.LogLevel == LogLevelDebug {
+		out := c.LogOutput
+		if out == nil {
+			out = os.Stderr
+		}
+		opts = append(opts, manapool.WithLogger(&levelLogger{
+			logger: log.New(out, "manapool: ", log.LstdFlags),
+			debug:  c.LogLevel == LogLevelDebug,
+		}))
+	}
+
+	return opts
+}
+
+// NewClient resolves credentials and creates a client. extra options are
+// applied after the configured ones.
+func (c *Config) NewClient(extra ...manapool.ClientOption) (*manapool.Client, error) {
+	token, err := c.Token()
+	if err != nil {
+		return nil, err
+	}
+	return manapool.NewClient(token, c.Email, append(c.ClientOptions(), extra...)...), nil
+}
+
+// levelLogger is a manapool.Logger that writes to a standard logger.
+type levelLogger struct {
+	logger *log.Logger
+	debug  bool
+}
+
+func (l *levelLogger) Debugf(format string, args ...interface{}) {
+	if l.debug {
+		l.logger.Printf("DEBUG "+format, args...)
+	}
+}
+
+func (l *levelLogger) Errorf(format string, args ...interface{}) {
+	l.logger.Printf("ERROR "+format, args...)
+}
@@ -0,0 +1,228 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	var gotToken, gotAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("X-ManaPool-Access-Token")
+		gotAgent = r.Header.Get("User-Agent")
+		_, _ = w.Write([]byte(`{"username":"seller"}`))
+	}))
+	defer server.Close()
+
+	tokenFile := writeFile(t, "token", "file-token\n")
+	path := writeFile(t, "manapool.toml", `
+email = "seller@example.com"
+access_token_file = "`+tokenFile+`"
+base_url = "`+server.URL+`"
+user_agent = "sync/1.0"
+timeout = "5s"
+log_level = "debug"
+
+[rate_limit]
+requests_per_second = 100
+
+[retry]
+max_retries = 0
+`)
+	t.Setenv(manapool.EnvAccessToken, "")
+	t.Setenv(manapool.EnvEmail, "")
+	t.Setenv(manapool.EnvBaseURL, "")
+	t.Setenv(manapool.EnvRateLimit, "")
+	t.Setenv(manapool.EnvRateBurst, "")
+	t.Setenv(manapool.EnvMaxRetries, "")
+	t.Setenv(manapool.EnvRetryBackoff, "")
+	t.Setenv(manapool.EnvTimeout, "")
+	t.Setenv(EnvLogLevel, "")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Timeout != 5*time.Second || cfg.RequestsPerSecond != 100 || cfg.MaxRetries == nil || *cfg.MaxRetries != 0 {
+		t.Errorf("cfg = %+v", cfg)
+	}
+
+	var logs bytes.Buffer
+	cfg.LogOutput = &logs
+	client, err := cfg.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := client.GetSellerAccount(context.Background()); err != nil {
+		t.Fatalf("GetSellerAccount() error = %v", err)
+	}
+	if gotToken != "file-token" || gotAgent != "sync/1.0" {
+		t.Errorf("request token = %q, agent = %q", gotToken, gotAgent)
+	}
+	if !strings.Contains(logs.String(), "DEBUG API request") {
+		t.Errorf("debug logs missing: %q", logs.String())
+	}
+}
+
+func TestLoad_EnvOverrides(t *testing.T) {
+	path := writeFile(t, "manapool.yaml", "email: file@example.com\naccess_token_env: MY_TOKEN\nlog_level: error\n")
+	t.Setenv("MY_TOKEN", "env-ref-token")
+	t.Setenv(manapool.EnvEmail, "env@example.com")
+	t.Setenv(manapool.EnvAccessToken, "")
+	t.Setenv(manapool.EnvMaxRetries, "5")
+	t.Setenv(EnvLogLevel, "none")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Email != "env@example.com" || *cfg.MaxRetries != 5 || cfg.LogLevel != LogLevelNone {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	if token, err := cfg.Token(); err != nil || token != "env-ref-token" {
+		t.Errorf("Token() = %q, %v", token, err)
+	}
+
+	t.Setenv(manapool.EnvAccessToken, "literal")
+	cfg, _ = Load(path)
+	if token, _ := cfg.Token(); token != "literal" {
+		t.Errorf("Token() with MANAPOOL_ACCESS_TOKEN = %q, want literal", token)
+	}
+
+	t.Setenv(manapool.EnvTimeout, "forever")
+	_, err = Load(path)
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) || valErr.Errors[0].Key != manapool.EnvTimeout {
+		t.Errorf("Load() with bad env error = %v", err)
+	}
+}
+
+func TestParse_SchemaErrors(t *testing.T) {
+	input := `{"email":"e","colour":"blue","timeout":"soon","rate_limit":{"burst":-1,"requests_per_second":"x"},"retry":{"max_retries":"many"}}`
+	_, err := Parse(strings.NewReader(input), FormatJSON)
+
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) {
+		t.Fatalf("Parse() error = %v, want ValidationError", err)
+	}
+	if len(valErr.Errors) != 5 {
+		t.Errorf("errors = %v, want 5", valErr.Errors)
+	}
+	for _, want := range []string{"colour: unknown setting", "timeout: must be a duration", "rate_limit.burst: must be a non-negative integer", "rate_limit.requests_per_second", "retry.max_retries"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+
+	_, err = Parse(strings.NewReader("email = \"a\"\nemail = \"b\"\n"), FormatTOML)
+	if err == nil || !strings.Contains(err.Error(), "line 2: email: duplicate key (first set on line 1)") {
+		t.Errorf("duplicate key error = %v", err)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"missing everything", Config{}, "one of access_token"},
+		{"missing email", Config{AccessToken: "t"}, "email: is required"},
+		{"two references", Config{AccessTokenEnv: "A", AccessTokenFile: "f", Email: "e"}, "mutually exclusive"},
+		{"bad base url", Config{AccessToken: "t", Email: "e", BaseURL: "ftp://x"}, "base_url"},
+		{"bad api version", Config{AccessToken: "t", Email: "e", APIVersion: "2.0"}, "api_version"},
+		{"bad log level", Config{AccessToken: "t", Email: "e", LogLevel: "trace"}, "log_level"},
+		{"valid", Config{AccessToken: "t", Email: "e"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_TokenErrors(t *testing.T) {
+	t.Setenv("EMPTY_TOKEN", "")
+	empty := writeFile(t, "empty", "  \n")
+	for name, cfg := range map[string]Config{
+		"none":         {},
+		"unset env":    {AccessTokenEnv: "EMPTY_TOKEN"},
+		"missing file": {AccessTokenFile: filepath.Join(t.TempDir(), "nope")},
+		"empty file":   {AccessTokenFile: empty},
+	} {
+		if _, err := cfg.Token(); err == nil {
+			t.Errorf("%s: Token() error = nil", name)
+		}
+		if _, err := cfg.NewClient(); err == nil {
+			t.Errorf("%s: NewClient() error = nil", name)
+		}
+	}
+}
+
+func TestConfig_ClientOptions(t *testing.T) {
+	if n := len((&Config{}).ClientOptions()); n != 0 {
+		t.Errorf("empty config produced %d options", n)
+	}
+	cfg := &Config{Burst: 4, InitialBackoff: time.Second, LogLevel: LogLevelError, APIVersion: "v2"}
+	if n := len(cfg.ClientOptions()); n != 4 {
+		t.Errorf("ClientOptions() = %d options, want 4", n)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	if _, err := Load("config.ini"); err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Errorf("Load(ini) error = %v", err)
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Load(missing) error = nil")
+	}
+	if _, err := Load(writeFile(t, "bad.json", `{"nope":1}`)); err == nil {
+		t.Error("Load(unknown key) error = nil")
+	}
+	t.Setenv(manapool.EnvAccessToken, "")
+	t.Setenv(manapool.EnvEmail, "")
+	if _, err := Load(writeFile(t, "incomplete.yml", "email: e\n")); err == nil {
+		t.Error("Load(incomplete) error = nil")
+	}
+}
+
+func TestFieldError(t *testing.T) {
+	for want, err := range map[string]FieldError{
+		"line 2: k: m": {Key: "k", Line: 2, Message: "m"},
+		"line 2: m":    {Line: 2, Message: "m"},
+		"k: m":         {Key: "k", Message: "m"},
+		"m":            {Message: "m"},
+	} {
+		if got := err.Error(); got != want {
+			t.Errorf("Error() = %q, want %q", got, want)
+		}
+	}
+}
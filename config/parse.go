@@ -0,0 +1,297 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Format identifies a config file syntax.
+type Format string
+
+const (
+	// FormatTOML is a TOML subset: [section] tables and key = value pairs
+	// with string, number, and boolean values.
+	FormatTOML Format = "toml"
+
+	// FormatYAML is a YAML subset: key: value pairs with at most one level
+	// of nested mappings and scalar values.
+	FormatYAML Format = "yaml"
+
+	// FormatJSON is a JSON object with at most one level of nested objects.
+	FormatJSON Format = "json"
+)
+
+// FormatForPath returns the format for a file by its extension: .toml,
+// .yaml or .yml, or .json.
+func FormatForPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return FormatTOML, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".json":
+		return FormatJSON, nil
+	}
+	return "", fmt.Errorf("unsupported config file extension %q", filepath.Ext(path))
+}
+
+// Setting is a single key/value pair read from a settings file. Keys inside
+// a section are joined with ".", as in "retry.max_retries".
+type Setting struct {
+	Key   string
+	Value string
+
+	// Line is the 1-based line the setting appears on, or 0 for JSON
+	Line int
+}
+
+// ParseSettings reads r in the given format and returns its settings in file
+// order without applying the client config schema, so other files can share
+// the config syntax. JSON objects are unordered, so their keys are returned
+// sorted.
+func ParseSettings(r io.Reader, format Format) ([]Setting, error) {
+	entries, err := parse(r, format)
+	if err != nil {
+		return nil, err
+	}
+	settings := make([]Setting, len(entries))
+	for i, e := range entries {
+		settings[i] = Setting{Key: e.key, Value: e.value, Line: e.line}
+	}
+	return settings, nil
+}
+
+// entry is a single parsed setting. Nested keys are joined with ".".
+type entry struct {
+	key   string
+	value string
+	line  int
+}
+
+// parse reads r in the given format and returns its settings in file order.
+func parse(r io.Reader, format Format) ([]entry, error) {
+	switch format {
+	case FormatTOML:
+		return parseTOML(r)
+	case FormatYAML:
+		return parseYAML(r)
+	case FormatJSON:
+		return parseJSON(r)
+	default:
+		return nil, fmt.Errorf("unsupported config format %q", format)
+	}
+}
+
+func parseTOML(r io.Reader) ([]entry, error) {
+	var entries []entry
+	var problems []FieldError
+	section := ""
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(stripComment(scanner.Text()))
+		if text == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") {
+			if !strings.HasSuffix(text, "]") || strings.HasPrefix(text, "[[") {
+				problems = append(problems, FieldError{Line: line, Message: "invalid table header"})
+				continue
+			}
+			section = strings.TrimSpace(text[1 : len(text)-1])
+			continue
+		}
+
+		key, raw, ok := strings.Cut(text, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			problems = append(problems, FieldError{Line: line, Message: "expected key = value"})
+			continue
+		}
+		value, err := unquote(strings.TrimSpace(raw))
+		if err != nil {
+			problems = append(problems, FieldError{Key: qualify(section, key), Line: line, Message: err.Error()})
+			continue
+		}
+		entries = append(entries, entry{key: qualify(section, key), value: value, line: line})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if len(problems) > 0 {
+		return nil, &ValidationError{Errors: problems}
+	}
+	return entries, nil
+}
+
+func parseYAML(r io.Reader) ([]entry, error) {
+	var entries []entry
+	var problems []FieldError
+	section, sectionIndent := "", -1
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		raw := strings.TrimRight(stripComment(scanner.Text()), " \t")
+		text := strings.TrimSpace(raw)
+		if text == "" || text == "---" {
+			continue
+		}
+		if strings.Contains(raw[:len(raw)-len(strings.TrimLeft(raw, " \t"))], "\t") {
+			problems = append(problems, FieldError{Line: line, Message: "tabs are not allowed for indentation"})
+			continue
+		}
+		indent := len(raw) - len(strings.TrimLeft(raw, " "))
+
+		key, value, ok := strings.Cut(text, ":")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if !ok || key == "" || strings.HasPrefix(text, "- ") {
+			problems = append(problems, FieldError{Line: line, Message: "expected key: value"})
+			continue
+		}
+
+		if indent == 0 {
+			section, sectionIndent = "", -1
+			if value == "" {
+				section = key
+				continue
+			}
+		} else {
+			if section == "" {
+				problems = append(problems, FieldError{Line: line, Message: "unexpected indentation"})
+				continue
+			}
+			if sectionIndent < 0 {
+				sectionIndent = indent
+			}
+			if indent != sectionIndent || value == "" {
+				problems = append(problems, FieldError{Key: qualify(section, key), Line: line, Message: "only one level of nesting is supported"})
+				continue
+			}
+		}
+
+		value, err := unquote(value)
+		if err != nil {
+			problems = append(problems, FieldError{Key: qualify(section, key), Line: line, Message: err.Error()})
+			continue
+		}
+		entries = append(entries, entry{key: qualify(section, key), value: value, line: line})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if len(problems) > 0 {
+		return nil, &ValidationError{Errors: problems}
+	}
+	return entries, nil
+}
+
+func parseJSON(r io.Reader) ([]entry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var root map[string]interface{}
+	if err := decoder.Decode(&root); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	var entries []entry
+	var problems []FieldError
+	var walk func(prefix string, m map[string]interface{}, depth int)
+	walk = func(prefix string, m map[string]interface{}, depth int) {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			key := qualify(prefix, k)
+			switch v := m[k].(type) {
+			case map[string]interface{}:
+				if depth > 0 {
+					problems = append(problems, FieldError{Key: key, Message: "only one level of nesting is supported"})
+					continue
+				}
+				walk(key, v, depth+1)
+			case string:
+				entries = append(entries, entry{key: key, value: v})
+			case json.Number:
+				entries = append(entries, entry{key: key, value: v.String()})
+			case bool:
+				entries = append(entries, entry{key: key, value: strconv.FormatBool(v)})
+			case nil:
+				// null leaves the setting unset
+			default:
+				problems = append(problems, FieldError{Key: key, Message: "must be a scalar value"})
+			}
+		}
+	}
+	walk("", root, 0)
+
+	if len(problems) > 0 {
+		return nil, &ValidationError{Errors: problems}
+	}
+	return entries, nil
+}
+
+// stripComment removes a trailing # comment that is not inside quotes.
+func stripComment(s string) string {
+	var quote rune
+	escaped := false
+	for i, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return s[:i]
+		}
+	}
+	return s
+}
+
+// unquote returns the value of a scalar, removing surrounding quotes.
+func unquote(s string) (string, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted string %s", s)
+		}
+		return v, nil
+	case strings.HasPrefix(s, "'"):
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return "", fmt.Errorf("invalid quoted string %s", s)
+		}
+		return s[1 : len(s)-1], nil
+	case s == "":
+		return "", fmt.Errorf("missing value")
+	}
+	return s, nil
+}
+
+func qualify(section, key string) string {
+	if section == "" {
+		return key
+	}
+	return section + "." + key
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func entriesString(entries []entry) string {
+	parts := make([]string, len(entries))
+	for i, e := range entries {
+		parts[i] = e.key + "=" + e.value
+	}
+	return strings.Join(parts, ",")
+}
+
+func TestParseFormats(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+		want   string
+	}{
+		{
+			name:   "toml",
+			format: FormatTOML,
+			input: `# comment
+email = "a#b@example.com" # trailing
+base_url = 'https://x/'
+[retry]
+max_retries = 2
+`,
+			want: "email=a#b@example.com,base_url=https://x/,retry.max_retries=2",
+		},
+		{
+			name:   "yaml",
+			format: FormatYAML,
+			input: `---
+email: "seller@example.com"
+base_url: https://x/api  # comment
+rate_limit:
+  requests_per_second: 2.5
+  burst: 3
+timeout: 10s
+`,
+			want: "email=seller@example.com,base_url=https://x/api,rate_limit.requests_per_second=2.5,rate_limit.burst=3,timeout=10s",
+		},
+		{
+			name:   "json",
+			format: FormatJSON,
+			input:  `{"email":"e","retry":{"max_retries":1,"initial_backoff":"2s"},"user_agent":null,"x":true}`,
+			want:   "email=e,retry.initial_backoff=2s,retry.max_retries=1,x=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := parse(strings.NewReader(tt.input), tt.format)
+			if err != nil {
+				t.Fatalf("parse() error = %v", err)
+			}
+			if got := entriesString(entries); got != tt.want {
+				t.Errorf("parse() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+		want   string
+	}{
+		{"toml missing equals", FormatTOML, "email\n", "line 1: expected key = value"},
+		{"toml bad header", FormatTOML, "[[retry]]\n", "line 1: invalid table header"},
+		{"toml bad string", FormatTOML, "email = \"open\n", "line 1: email: invalid quoted string"},
+		{"toml missing value", FormatTOML, "email =\n", "missing value"},
+		{"yaml deep nesting", FormatYAML, "retry:\n  a:\n    b: 1\n", "only one level of nesting"},
+		{"yaml unexpected indent", FormatYAML, "  email: x\n", "unexpected indentation"},
+		{"yaml list", FormatYAML, "- a\n", "expected key: value"},
+		{"yaml tabs", FormatYAML, "retry:\n\tmax_retries: 1\n", "tabs are not allowed"},
+		{"json nested", FormatJSON, `{"a":{"b":{"c":1}}}`, "a.b: only one level"},
+		{"json array", FormatJSON, `{"a":[1]}`, "a: must be a scalar"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse(strings.NewReader(tt.input), tt.format)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parse() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := parse(strings.NewReader("{"), FormatJSON); err == nil {
+		t.Error("parse(invalid json) error = nil")
+	}
+	var valErr *ValidationError
+	if _, err := parse(strings.NewReader("x"), Format("ini")); err == nil || errors.As(err, &valErr) {
+		t.Errorf("parse(ini) error = %v, want plain error", err)
+	}
+}
+
+func TestParseSettings(t *testing.T) {
+	settings, err := ParseSettings(strings.NewReader("a: 1\nb:\n  c: x\n"), FormatYAML)
+	if err != nil {
+		t.Fatalf("ParseSettings() error = %v", err)
+	}
+	want := []Setting{{Key: "a", Value: "1", Line: 1}, {Key: "b.c", Value: "x", Line: 3}}
+	if len(settings) != len(want) || settings[0] != want[0] || settings[1] != want[1] {
+		t.Errorf("ParseSettings() = %+v, want %+v", settings, want)
+	}
+
+	if _, err := ParseSettings(strings.NewReader("x"), Format("ini")); err == nil {
+		t.Error("ParseSettings(ini) error = nil")
+	}
+}
+
+func TestFormatForPath(t *testing.T) {
+	for path, want := range map[string]Format{"a.toml": FormatTOML, "a.YML": FormatYAML, "a.yaml": FormatYAML, "dir/a.json": FormatJSON} {
+		if got, err := FormatForPath(path); err != nil || got != want {
+			t.Errorf("FormatForPath(%q) = %q, %v, want %q", path, got, err, want)
+		}
+	}
+	if _, err := FormatForPath("a.ini"); err == nil {
+		t.Error("FormatForPath(a.ini) error = nil")
+	}
+}
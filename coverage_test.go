@@ -21,7 +21,7 @@ func TestCoverageImprovements(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("BuyerOrdersOptions_toParams", func(t *testing.T) {
-		since := Timestamp{time.Now()}
+		since := Timestamp{Time: time.Now()}
 		opts := BuyerOrdersOptions{
 			Since:  &since,
 			Limit:  10,
@@ -36,7 +36,7 @@ func TestCoverageImprovements(t *testing.T) {
 	})
 
 	t.Run("OrdersOptions_buildParams", func(t *testing.T) {
-		since := Timestamp{time.Now()}
+		since := Timestamp{Time: time.Now()}
 		opts := OrdersOptions{
 			Since:           &since,
 			IsUnfulfilled:   boolPtr(true),
@@ -0,0 +1,110 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// CredentialsProvider supplies the access token and account email used to
+// authenticate API requests. The client consults it before every request, so
+// implementations backed by a secrets manager or a rotating token store take
+// effect without recreating the client.
+//
+// Implementations must be safe for concurrent use. Wrap slow providers with
+// NewCachingCredentials to avoid a lookup per request.
+type CredentialsProvider interface {
+	// Credentials returns the current access token and account email.
+	Credentials(ctx context.Context) (token, email string, err error)
+}
+
+// CredentialsFunc adapts a function to the CredentialsProvider interface.
+type CredentialsFunc func(ctx context.Context) (token, email string, err error)
+
+// Credentials calls f(ctx).
+func (f CredentialsFunc) Credentials(ctx context.Context) (string, string, error) {
+	return f(ctx)
+}
+
+// StaticCredentials returns a provider that always returns token and email.
+func StaticCredentials(token, email string) CredentialsProvider {
+	return CredentialsFunc(func(context.Context) (string, string, error) {
+		return token, email, nil
+	})
+}
+
+// CachingCredentials caches the result of another provider for a fixed
+// duration. Failed lookups are not cached.
+type CachingCredentials struct {
+	provider CredentialsProvider
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	email   string
+	expires time.Time
+}
+
+// NewCachingCredentials wraps provider so that it is consulted at most once
+// per ttl. Call Invalidate to force a refresh, for example after rotating a
+// token.
+//
+// Example:
+//
+//	creds := manapool.NewCachingCredentials(manapool.CredentialsFunc(
+//	    func(ctx context.Context) (string, string, error) {
+//	        secret, err := vault.Read(ctx, "secret/manapool")
+//	        if err != nil {
+//	            return "", "", err
+//	        }
+//	        return secret["token"], secret["email"], nil
+//	    }), 5*time.Minute)
+//
+//	client := manapool.NewClient("", "", manapool.WithCredentialsProvider(creds))
+func NewCachingCredentials(provider CredentialsProvider, ttl time.Duration) *CachingCredentials {
+	return &CachingCredentials{provider: provider, ttl: ttl, now: time.Now}
+}
+
+// Credentials returns the cached credentials, refreshing them from the
+// wrapped provider when they have expired.
+func (c *CachingCredentials) Credentials(ctx context.Context) (string, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.now().Before(c.expires) {
+		return c.token, c.email, nil
+	}
+
+	token, email, err := c.provider.Credentials(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	c.token, c.email = token, email
+	c.expires = c.now().Add(c.ttl)
+	return token, email, nil
+}
+
+// Invalidate discards the cached credentials so the next call refreshes them.
+func (c *CachingCredentials) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expires = time.Time{}
+}
+
+// credentials resolves the token and email for a request.
+func (c *Client) credentials(ctx context.Context) (string, string, error) {
+	if c.credentialsProvider == nil {
+		return c.authToken, c.email, nil
+	}
+
+	token, email, err := c.credentialsProvider.Credentials(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if token == "" || email == "" {
+		return "", "", errors.New("credentials provider returned an empty token or email")
+	}
+	return token, email, nil
+}
@@ -0,0 +1,133 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_WithCredentialsProvider(t *testing.T) {
+	var gotTokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTokens = append(gotTokens, r.Header.Get("X-ManaPool-Access-Token"))
+		if r.Header.Get("X-ManaPool-Email") != "rotated@example.com" {
+			t.Errorf("email = %q", r.Header.Get("X-ManaPool-Email"))
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"username":"seller"}`))
+	}))
+	defer server.Close()
+
+	var calls int32
+	provider := CredentialsFunc(func(ctx context.Context) (string, string, error) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			return "token-1", "rotated@example.com", nil
+		}
+		return "token-2", "rotated@example.com", nil
+	})
+
+	client := NewClient("static", "static@example.com",
+		WithBaseURL(server.URL+"/"),
+		WithCredentialsProvider(provider),
+	)
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetSellerAccount(context.Background()); err != nil {
+			t.Fatalf("GetSellerAccount() error = %v", err)
+		}
+	}
+
+	if len(gotTokens) != 2 || gotTokens[0] != "token-1" || gotTokens[1] != "token-2" {
+		t.Errorf("tokens = %v, want [token-1 token-2]", gotTokens)
+	}
+}
+
+func TestClient_WithCredentialsProvider_Errors(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	vaultErr := errors.New("vault sealed")
+	tests := []struct {
+		name     string
+		provider CredentialsProvider
+		wantErr  error
+	}{
+		{
+			name: "provider error",
+			provider: CredentialsFunc(func(context.Context) (string, string, error) {
+				return "", "", vaultErr
+			}),
+			wantErr: vaultErr,
+		},
+		{
+			name:     "empty token",
+			provider: StaticCredentials("", "seller@example.com"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient("", "", WithBaseURL(server.URL+"/"), WithCredentialsProvider(tt.provider))
+			_, err := client.GetSellerAccount(context.Background())
+			if err == nil {
+				t.Fatal("GetSellerAccount() error = nil")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want wrapping %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 0 {
+		t.Errorf("requests sent = %d, want 0", got)
+	}
+}
+
+func TestNewCachingCredentials(t *testing.T) {
+	var calls int32
+	fail := false
+	provider := CredentialsFunc(func(context.Context) (string, string, error) {
+		if fail {
+			return "", "", errors.New("unavailable")
+		}
+		n := atomic.AddInt32(&calls, 1)
+		return "token-" + string(rune('0'+n)), "seller@example.com", nil
+	})
+
+	now := time.Now()
+	creds := NewCachingCredentials(provider, time.Minute)
+	creds.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if token, _, _ := creds.Credentials(ctx); token != "token-1" {
+			t.Fatalf("Credentials() token = %q, want token-1", token)
+		}
+	}
+
+	now = now.Add(2 * time.Minute)
+	if token, _, _ := creds.Credentials(ctx); token != "token-2" {
+		t.Errorf("Credentials() after expiry = %q, want token-2", token)
+	}
+
+	creds.Invalidate()
+	fail = true
+	if _, _, err := creds.Credentials(ctx); err == nil {
+		t.Error("Credentials() error = nil, want provider error")
+	}
+	fail = false
+	if token, _, _ := creds.Credentials(ctx); token != "token-3" {
+		t.Errorf("Credentials() after invalidate = %q, want token-3", token)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("provider calls = %d, want 3", got)
+	}
+}
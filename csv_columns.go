@@ -0,0 +1,270 @@
+package manapool
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Inventory CSV fields that a ColumnMap can map headers to.
+const (
+	CSVFieldTCGPlayerSKU = "tcgplayer_sku"
+	CSVFieldQuantity     = "quantity"
+	CSVFieldPriceCents   = "price_cents"
+	CSVFieldPrice        = "price"
+	CSVFieldConditionID  = "condition_id"
+	CSVFieldFinishID     = "finish_id"
+	CSVFieldLanguageID   = "language_id"
+)
+
+// csvFields lists the fields in the order WriteInventoryCSV writes them.
+var csvFields = []string{
+	CSVFieldTCGPlayerSKU,
+	CSVFieldQuantity,
+	CSVFieldPrice,
+	CSVFieldPriceCents,
+	CSVFieldConditionID,
+	CSVFieldFinishID,
+	CSVFieldLanguageID,
+}
+
+// ValueTransform rewrites a raw CSV value before it is parsed, such as
+// turning a spreadsheet's "Yes" foil flag into finish "FO". It returns an
+// error if the value is invalid; the error becomes a CSVRowError.
+type ValueTransform func(value string) (string, error)
+
+// MapValues returns a ValueTransform that replaces values found in values,
+// matched case-insensitively, and passes other values through unchanged so
+// they are validated as usual.
+//
+// Example:
+//
+//	manapool.MapValues(map[string]string{"yes": "FO", "foil": "FO", "no": "NF", "": "NF"})
+func MapValues(values map[string]string) ValueTransform {
+	lookup := make(map[string]string, len(values))
+	for from, to := range values {
+		lookup[strings.ToLower(strings.TrimSpace(from))] = to
+	}
+	return func(value string) (string, error) {
+		if to, ok := lookup[strings.ToLower(value)]; ok {
+			return to, nil
+		}
+		return value, nil
+	}
+}
+
+// ColumnMap describes the layout of a seller's inventory spreadsheet for
+// ParseInventoryCSVColumns and WriteInventoryCSV.
+//
+// Each field may be mapped from at most one header. The file must map
+// tcgplayer_sku, quantity, and one of price_cents or price; condition_id,
+// finish_id, and language_id are optional.
+//
+// Example:
+//
+//	columns := manapool.ColumnMap{
+//	    Headers: map[string]string{
+//	        "SKU":   manapool.CSVFieldTCGPlayerSKU,
+//	        "Qty":   manapool.CSVFieldQuantity,
+//	        "Price": manapool.CSVFieldPrice,
+//	        "Foil":  manapool.CSVFieldFinishID,
+//	    },
+//	    Transforms: map[string]manapool.ValueTransform{
+//	        manapool.CSVFieldFinishID: manapool.MapValues(map[string]string{"yes": "FO", "no": "NF", "": "NF"}),
+//	    },
+//	}
+//	rows, err := manapool.ParseInventoryCSVColumns(f, columns)
+type ColumnMap struct {
+	// Headers maps header names in the file, matched case-insensitively, to
+	// CSVField constants
+	Headers map[string]string
+
+	// Transforms rewrites raw values of a field before they are parsed
+	Transforms map[string]ValueTransform
+
+	// ValidateIDs rejects condition_id, finish_id, and language_id values
+	// that are not ManaPool IDs. When false they are passed through as
+	// written, upper-cased.
+	ValidateIDs bool
+}
+
+// DefaultColumnMap returns the layout ParseInventoryCSV accepts: each field
+// under a header of the same name, with ValidateIDs off.
+func DefaultColumnMap() ColumnMap {
+	headers := make(map[string]string, len(csvFields))
+	for _, field := range csvFields {
+		headers[field] = field
+	}
+	return ColumnMap{Headers: headers}
+}
+
+// Validate checks that every header maps to a known field, that no field is
+// mapped twice, and that the required fields are mapped. It returns a
+// *ValidationError describing the first problem.
+func (m ColumnMap) Validate() error {
+	headers := make([]string, 0, len(m.Headers))
+	for header := range m.Headers {
+		headers = append(headers, header)
+	}
+	sort.Strings(headers)
+
+	mapped := make(map[string]string, len(m.Headers))
+	for _, header := range headers {
+		field := m.Headers[header]
+		if !isCSVField(field) {
+			return NewValidationError("columns", fmt.Sprintf("header %q maps to unknown field %q", header, field))
+		}
+		if other, dup := mapped[field]; dup {
+			return NewValidationError("columns", fmt.Sprintf("headers %q and %q both map to %s", other, header, field))
+		}
+		mapped[field] = header
+	}
+	for field := range m.Transforms {
+		if !isCSVField(field) {
+			return NewValidationError("columns", fmt.Sprintf("transform for unknown field %q", field))
+		}
+	}
+
+	switch {
+	case mapped[CSVFieldTCGPlayerSKU] == "":
+		return NewValidationError("columns", "required field tcgplayer_sku is not mapped")
+	case mapped[CSVFieldQuantity] == "":
+		return NewValidationError("columns", "required field quantity is not mapped")
+	case mapped[CSVFieldPriceCents] == "" && mapped[CSVFieldPrice] == "":
+		return NewValidationError("columns", "required field price_cents or price is not mapped")
+	}
+	return nil
+}
+
+// headerFor returns the header mapped to field, or "" if there is none.
+func (m ColumnMap) headerFor(field string) string {
+	for header, f := range m.Headers {
+		if f == field {
+			return header
+		}
+	}
+	return ""
+}
+
+// csvColumn is a field located in a file's header.
+type csvColumn struct {
+	index  int
+	header string
+}
+
+// resolve locates each mapped field in a file's header row.
+func (m ColumnMap) resolve(header []string) (map[string]csvColumn, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		positions[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	columns := make(map[string]csvColumn, len(m.Headers))
+	for name, field := range m.Headers {
+		if i, ok := positions[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[field] = csvColumn{index: i, header: strings.TrimSpace(header[i])}
+		}
+	}
+
+	missing := func(field string) string {
+		if header := m.headerFor(field); !strings.EqualFold(header, field) {
+			return fmt.Sprintf("%s (header %q)", field, header)
+		}
+		return field
+	}
+	switch {
+	case !hasColumn(columns, CSVFieldTCGPlayerSKU):
+		return nil, NewValidationError("csv", "missing required column "+missing(CSVFieldTCGPlayerSKU))
+	case !hasColumn(columns, CSVFieldQuantity):
+		return nil, NewValidationError("csv", "missing required column "+missing(CSVFieldQuantity))
+	case !hasColumn(columns, CSVFieldPriceCents) && !hasColumn(columns, CSVFieldPrice):
+		return nil, NewValidationError("csv", "missing required column price_cents or price")
+	}
+	return columns, nil
+}
+
+func hasColumn(columns map[string]csvColumn, field string) bool {
+	_, ok := columns[field]
+	return ok
+}
+
+func isCSVField(field string) bool {
+	for _, f := range csvFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteInventoryCSV writes items as CSV using the headers in columns, in the
+// field order tcgplayer_sku, quantity, price, price_cents, condition_id,
+// finish_id, language_id; unmapped fields are omitted. Values are written in
+// canonical form, such as "12.50" for price and "FO" for finish_id;
+// transforms are not applied. Items without a TCGplayer SKU have an empty
+// tcgplayer_sku.
+func WriteInventoryCSV(w io.Writer, items []InventoryItem, columns ColumnMap) error {
+	if err := columns.Validate(); err != nil {
+		return err
+	}
+
+	var fields, header []string
+	for _, field := range csvFields {
+		if name := columns.headerFor(field); name != "" {
+			fields = append(fields, field)
+			header = append(header, name)
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+	for _, item := range items {
+		record := make([]string, len(fields))
+		for i, field := range fields {
+			record[i] = inventoryCSVValue(item, field)
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write csv row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
+
+func inventoryCSVValue(item InventoryItem, field string) string {
+	single := item.Product.Single
+	switch field {
+	case CSVFieldTCGPlayerSKU:
+		if item.Product.TCGPlayerSKU != nil {
+			return strconv.Itoa(*item.Product.TCGPlayerSKU)
+		}
+	case CSVFieldQuantity:
+		return strconv.Itoa(item.Quantity)
+	case CSVFieldPrice:
+		return FormatCents(item.PriceCents)
+	case CSVFieldPriceCents:
+		return strconv.Itoa(item.PriceCents)
+	case CSVFieldConditionID:
+		if single != nil {
+			return single.ConditionID
+		}
+	case CSVFieldFinishID:
+		if single != nil {
+			return single.FinishID
+		}
+	case CSVFieldLanguageID:
+		if single != nil {
+			return single.LanguageID
+		}
+	}
+	return ""
+}
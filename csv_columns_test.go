@@ -0,0 +1,181 @@
+package manapool
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func sellerColumns() ColumnMap {
+	return ColumnMap{
+		Headers: map[string]string{
+			"SKU":       CSVFieldTCGPlayerSKU,
+			"Qty":       CSVFieldQuantity,
+			"Our Price": CSVFieldPrice,
+			"Foil?":     CSVFieldFinishID,
+			"Cond":      CSVFieldConditionID,
+		},
+		Transforms: map[string]ValueTransform{
+			CSVFieldFinishID:    MapValues(map[string]string{"Yes": "FO", "Foil": "FO", "No": "NF", "": "NF"}),
+			CSVFieldConditionID: MapValues(map[string]string{"Near Mint": "NM", "Lightly Played": "LP"}),
+		},
+		ValidateIDs: true,
+	}
+}
+
+func TestParseInventoryCSVColumns(t *testing.T) {
+	input := "Name,SKU,Qty,Our Price,Foil?,Cond\n" +
+		"Lightning Bolt,100,4,$1.25,yes,Near Mint\n" +
+		"Counterspell,200,1,2.00,,lightly played\n" +
+		"Opt,300,2,0.10,EF,mp\n"
+
+	rows, err := ParseInventoryCSVColumns(strings.NewReader(input), sellerColumns())
+	if err != nil {
+		t.Fatalf("ParseInventoryCSVColumns() error = %v", err)
+	}
+
+	want := []InventoryImportRow{
+		{Line: 2, Item: InventoryBulkItemBySKU{TCGPlayerSKU: 100, Quantity: 4, PriceCents: 125}, ConditionID: "NM", FinishID: "FO"},
+		{Line: 3, Item: InventoryBulkItemBySKU{TCGPlayerSKU: 200, Quantity: 1, PriceCents: 200}, ConditionID: "LP", FinishID: "NF"},
+		{Line: 4, Item: InventoryBulkItemBySKU{TCGPlayerSKU: 300, Quantity: 2, PriceCents: 10}, ConditionID: "MP", FinishID: "EF"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %d, want %d", len(rows), len(want))
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("rows[%d] = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestParseInventoryCSVColumns_RowErrors(t *testing.T) {
+	columns := sellerColumns()
+	columns.Transforms[CSVFieldQuantity] = func(v string) (string, error) {
+		if v == "lots" {
+			return "", errors.New("must be a number")
+		}
+		return v, nil
+	}
+	input := "SKU,Qty,Our Price,Foil?,Cond\n" +
+		"100,lots,1.00,yes,NM\n" +
+		"200,1,1.00,shiny,NM\n"
+
+	_, err := ParseInventoryCSVColumns(strings.NewReader(input), columns)
+	var csvErr *CSVValidationError
+	if !errors.As(err, &csvErr) {
+		t.Fatalf("error = %v, want *CSVValidationError", err)
+	}
+	want := []CSVRowError{
+		{Line: 2, Column: "Qty", Message: "must be a number"},
+		{Line: 3, Column: "Foil?", Message: `unknown value "SHINY"`},
+	}
+	if len(csvErr.Errors) != len(want) {
+		t.Fatalf("errors = %v, want %v", csvErr.Errors, want)
+	}
+	for i := range want {
+		if csvErr.Errors[i] != want[i] {
+			t.Errorf("errors[%d] = %+v, want %+v", i, csvErr.Errors[i], want[i])
+		}
+	}
+}
+
+func TestParseInventoryCSV_UnvalidatedIDs(t *testing.T) {
+	input := "tcgplayer_sku,quantity,price,condition_id,finish_id,language_id\n" +
+		"100,1,1.00,mint,shiny,en\n"
+
+	rows, err := ParseInventoryCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseInventoryCSV() error = %v", err)
+	}
+	if len(rows) != 1 || rows[0].ConditionID != "MINT" || rows[0].FinishID != "SHINY" || rows[0].LanguageID != "EN" {
+		t.Errorf("rows = %+v", rows)
+	}
+}
+
+func TestParseInventoryCSVColumns_MissingColumn(t *testing.T) {
+	_, err := ParseInventoryCSVColumns(strings.NewReader("SKU,Our Price\n"), sellerColumns())
+	if err == nil || !strings.Contains(err.Error(), `missing required column quantity (header "Qty")`) {
+		t.Errorf("error = %v, want missing Qty", err)
+	}
+}
+
+func TestColumnMap_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns ColumnMap
+		wantErr string
+	}{
+		{"default", DefaultColumnMap(), ""},
+		{"seller", sellerColumns(), ""},
+		{"unknown field", ColumnMap{Headers: map[string]string{"Set": "set_code"}}, `maps to unknown field "set_code"`},
+		{"duplicate", ColumnMap{Headers: map[string]string{
+			"SKU": CSVFieldTCGPlayerSKU, "Id": CSVFieldTCGPlayerSKU, "Qty": CSVFieldQuantity, "Price": CSVFieldPrice,
+		}}, `headers "Id" and "SKU" both map to tcgplayer_sku`},
+		{"no sku", ColumnMap{Headers: map[string]string{"Qty": CSVFieldQuantity, "Price": CSVFieldPrice}}, "tcgplayer_sku is not mapped"},
+		{"no quantity", ColumnMap{Headers: map[string]string{"SKU": CSVFieldTCGPlayerSKU, "Price": CSVFieldPrice}}, "quantity is not mapped"},
+		{"no price", ColumnMap{Headers: map[string]string{"SKU": CSVFieldTCGPlayerSKU, "Qty": CSVFieldQuantity}}, "price_cents or price is not mapped"},
+		{"unknown transform", ColumnMap{
+			Headers:    DefaultColumnMap().Headers,
+			Transforms: map[string]ValueTransform{"rarity": MapValues(nil)},
+		}, `transform for unknown field "rarity"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.columns.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want ValidationError containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteInventoryCSV(t *testing.T) {
+	sku := 100
+	items := []InventoryItem{
+		{
+			PriceCents: 1250,
+			Quantity:   3,
+			Product: Product{
+				TCGPlayerSKU: &sku,
+				Single:       &Single{ConditionID: "NM", FinishID: "FO", LanguageID: "EN"},
+			},
+		},
+		{PriceCents: 500, Quantity: 1, Product: Product{Sealed: &Sealed{}}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteInventoryCSV(&buf, items, sellerColumns()); err != nil {
+		t.Fatalf("WriteInventoryCSV() error = %v", err)
+	}
+	want := "SKU,Qty,Our Price,Cond,Foil?\n" +
+		"100,3,12.50,NM,FO\n" +
+		",1,5.00,,\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := WriteInventoryCSV(&buf, items[:1], sellerColumns()); err != nil {
+		t.Fatalf("WriteInventoryCSV() error = %v", err)
+	}
+	rows, err := ParseInventoryCSVColumns(&buf, sellerColumns())
+	if err != nil {
+		t.Fatalf("round trip error = %v", err)
+	}
+	if rows[0].Item.PriceCents != 1250 || rows[0].FinishID != "FO" {
+		t.Errorf("round trip row = %+v", rows[0])
+	}
+
+	if err := WriteInventoryCSV(&buf, items, ColumnMap{}); err == nil {
+		t.Error("WriteInventoryCSV() with empty map error = nil")
+	}
+}
@@ -0,0 +1,106 @@
+package manapool
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// debugDumpBodyLimit is the maximum number of body bytes written per dump.
+const debugDumpBodyLimit = 4096
+
+// redactedValue replaces sensitive values in debug dumps.
+const redactedValue = "[REDACTED]"
+
+// redactedHeaders lists the request headers whose values are never dumped.
+var redactedHeaders = map[string]bool{
+	"X-Manapool-Access-Token": true,
+	"X-Manapool-Email":        true,
+}
+
+// dumpRequest writes the request line, headers, and body to the debug writer.
+func (c *Client) dumpRequest(req *http.Request, body []byte) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "> %s %s %s\n", req.Method, req.URL.String(), req.Proto)
+	c.writeDumpHeaders(&buf, ">", req.Header)
+	c.writeDumpBody(&buf, ">", body, req.Header.Get("X-ManaPool-Email"))
+	_, _ = c.debugDump.Write(buf.Bytes())
+}
+
+// dumpResponse writes the status line, headers, and body to the debug writer.
+// The response body is buffered and replaced so callers can still read it.
+func (c *Client) dumpResponse(resp *http.Response) {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{err}))
+	} else {
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "< %s %s\n", resp.Proto, resp.Status)
+	email := c.email
+	if resp.Request != nil {
+		email = resp.Request.Header.Get("X-ManaPool-Email")
+	}
+	c.writeDumpHeaders(&buf, "<", resp.Header)
+	c.writeDumpBody(&buf, "<", body, email)
+	if err != nil {
+		fmt.Fprintf(&buf, "< [error reading body: %v]\n", err)
+	}
+	_, _ = c.debugDump.Write(buf.Bytes())
+}
+
+// writeDumpHeaders writes headers in sorted order, redacting credentials.
+func (c *Client) writeDumpHeaders(buf *bytes.Buffer, prefix string, header http.Header) {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range header[key] {
+			if redactedHeaders[http.CanonicalHeaderKey(key)] {
+				value = redactedValue
+			}
+			fmt.Fprintf(buf, "%s %s: %s\n", prefix, key, value)
+		}
+	}
+	fmt.Fprintf(buf, "%s\n", prefix)
+}
+
+// writeDumpBody writes a truncated body with the account email redacted.
+func (c *Client) writeDumpBody(buf *bytes.Buffer, prefix string, body []byte, email string) {
+	if len(body) == 0 {
+		return
+	}
+
+	truncated := len(body) > debugDumpBodyLimit
+	if truncated {
+		body = body[:debugDumpBodyLimit]
+	}
+
+	text := strings.TrimRight(string(body), "\n")
+	if email != "" {
+		text = strings.ReplaceAll(text, email, redactedValue)
+	}
+	buf.WriteString(text)
+	buf.WriteString("\n")
+	if truncated {
+		fmt.Fprintf(buf, "%s [body truncated to %d bytes]\n", prefix, debugDumpBodyLimit)
+	}
+}
+
+// errReader is an io.Reader that always returns err.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
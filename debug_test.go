@@ -0,0 +1,110 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_WithDebugDump(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "{\"singles_live\":true,\"sealed_live\":null}\n" {
+			t.Errorf("request body = %q", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"username":"seller","email":"test@example.com"}`))
+	}))
+	defer server.Close()
+
+	var dump bytes.Buffer
+	client := NewClient("secret-token", "test@example.com",
+		WithBaseURL(server.URL+"/"),
+		WithDebugDump(&dump),
+	)
+
+	live := true
+	account, err := client.UpdateSellerAccount(context.Background(), SellerAccountUpdate{SinglesLive: &live})
+	if err != nil {
+		t.Fatalf("UpdateSellerAccount() error = %v", err)
+	}
+	if account.Email != "test@example.com" {
+		t.Errorf("account.Email = %q, want %q", account.Email, "test@example.com")
+	}
+
+	out := dump.String()
+	for _, want := range []string{
+		"> PUT " + server.URL + "/account HTTP/1.1",
+		"> X-Manapool-Access-Token: [REDACTED]",
+		"> X-Manapool-Email: [REDACTED]",
+		`> Content-Type: application/json`,
+		`{"singles_live":true,"sealed_live":null}`,
+		"< HTTP/1.1 200 OK",
+		`{"username":"seller","email":"[REDACTED]"}`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dump missing %q\n%s", want, out)
+		}
+	}
+	for _, secret := range []string{"secret-token", "test@example.com"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("dump leaked %q\n%s", secret, out)
+		}
+	}
+}
+
+func TestClient_WithDebugDump_TruncatesBody(t *testing.T) {
+	large := strings.Repeat("x", debugDumpBodyLimit+100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(large))
+	}))
+	defer server.Close()
+
+	var dump bytes.Buffer
+	client := NewClient("token", "email@example.com",
+		WithBaseURL(server.URL+"/"),
+		WithDebugDump(&dump),
+	)
+
+	resp, err := client.doRequest(context.Background(), "GET", "/large", nil)
+	if err != nil {
+		t.Fatalf("doRequest() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	if string(body) != large {
+		t.Errorf("response body length = %d, want %d", len(body), len(large))
+	}
+	if !strings.Contains(dump.String(), "[body truncated to 4096 bytes]") {
+		t.Errorf("dump missing truncation marker")
+	}
+	if strings.Contains(dump.String(), large) {
+		t.Errorf("dump contains full body")
+	}
+}
+
+func TestClient_WithDebugDump_ReadError(t *testing.T) {
+	client := NewClient("token", "email", WithDebugDump(io.Discard))
+	resp := &http.Response{
+		Proto:  "HTTP/1.1",
+		Status: "200 OK",
+		Header: http.Header{},
+		Body:   io.NopCloser(errReader{io.ErrUnexpectedEOF}),
+	}
+
+	client.dumpResponse(resp)
+
+	if _, err := io.ReadAll(resp.Body); err != io.ErrUnexpectedEOF {
+		t.Errorf("read error = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
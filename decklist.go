@@ -0,0 +1,261 @@
+package manapool
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DeckSection is a part of a decklist, such as the main deck or sideboard.
+type DeckSection string
+
+// Decklist sections recognized by ParseDecklist.
+const (
+	SectionMain       DeckSection = "main"
+	SectionSideboard  DeckSection = "sideboard"
+	SectionCommander  DeckSection = "commander"
+	SectionCompanion  DeckSection = "companion"
+	SectionMaybeboard DeckSection = "maybeboard"
+)
+
+// DecklistEntry is one card line from a decklist.
+type DecklistEntry struct {
+	// Line is the 1-based line number of the entry in the source text
+	Line int
+
+	Section  DeckSection
+	Quantity int
+	Name     string
+
+	// Set and Number identify a specific printing, if the list gives one,
+	// such as "M10" and "146"
+	Set    string
+	Number string
+
+	// FinishID is "FO" or "EF" if the list marks the card foil or etched,
+	// or "" if any finish will do
+	FinishID string
+}
+
+// Decklist is a parsed decklist.
+type Decklist struct {
+	Entries []DecklistEntry
+}
+
+// Section returns the entries in section, in list order.
+func (d *Decklist) Section(section DeckSection) []DecklistEntry {
+	var entries []DecklistEntry
+	for _, entry := range d.Entries {
+		if entry.Section == section {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// DeckCreateRequest converts the list for CreateDeck validation: commanders
+// by name, and main deck cards with their quantities. Sideboard, companion,
+// and maybeboard cards are not part of the deck and are left out.
+func (d *Decklist) DeckCreateRequest() DeckCreateRequest {
+	var req DeckCreateRequest
+	for _, entry := range d.Entries {
+		switch entry.Section {
+		case SectionCommander:
+			req.CommanderNames = append(req.CommanderNames, entry.Name)
+		case SectionMain:
+			req.OtherCards = append(req.OtherCards, OtherCard{Name: entry.Name, Quantity: entry.Quantity})
+		}
+	}
+	return req
+}
+
+// DecklistLineError describes a decklist line that could not be parsed.
+type DecklistLineError struct {
+	Line    int
+	Text    string
+	Message string
+}
+
+// Error implements the error interface.
+func (e DecklistLineError) Error() string {
+	return fmt.Sprintf("line %d: %s: %q", e.Line, e.Message, e.Text)
+}
+
+// DecklistError collects every line error found while parsing a decklist.
+type DecklistError struct {
+	Errors []DecklistLineError
+}
+
+// Error implements the error interface.
+func (e *DecklistError) Error() string {
+	if len(e.Errors) == 1 {
+		return "decklist parse failed: " + e.Errors[0].Error()
+	}
+	msgs := make([]string, len(e.Errors))
+	for i, lineErr := range e.Errors {
+		msgs[i] = lineErr.Error()
+	}
+	return fmt.Sprintf("decklist parse failed with %d errors: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+var (
+	// decklistEntryPattern matches "4 Lightning Bolt" and "4x Lightning Bolt"
+	decklistEntryPattern = regexp.MustCompile(`^(\d+)x?\s+(.+)$`)
+
+	// decklistPrintingPattern matches a trailing "(M10) 146" or "(M10)"
+	decklistPrintingPattern = regexp.MustCompile(`^(.+?)\s+\(([A-Za-z0-9]+)\)(?:\s+(\S+))?$`)
+
+	// decklistMarkerPattern matches a trailing Moxfield marker such as "*F*"
+	decklistMarkerPattern = regexp.MustCompile(`\s+\*([A-Za-z]+)\*$`)
+
+	decklistHeaders = map[string]DeckSection{
+		"deck":        SectionMain,
+		"main":        SectionMain,
+		"mainboard":   SectionMain,
+		"main deck":   SectionMain,
+		"sideboard":   SectionSideboard,
+		"side":        SectionSideboard,
+		"commander":   SectionCommander,
+		"commanders":  SectionCommander,
+		"companion":   SectionCompanion,
+		"maybeboard":  SectionMaybeboard,
+		"considering": SectionMaybeboard,
+	}
+)
+
+// ParseDecklist parses a decklist in the plain-text formats exported by
+// MTGO, MTG Arena, and Moxfield:
+//
+//	4 Lightning Bolt
+//	4x Lightning Bolt
+//	4 Lightning Bolt (M10) 146
+//	1 Sol Ring (CMR) 472 *F*
+//
+// Section headers such as "Deck", "Sideboard", "Commander", "Companion", and
+// "Maybeboard" (optionally followed by ":") start a section, and MTGO's
+// "SB: " prefix marks a sideboard line. In a list without headers, cards
+// after the first blank line are the sideboard, as in MTGO exports. Arena's
+// "About" section and lines starting with "//" or "#" are ignored. Moxfield's
+// "*F*" and "*E*" markers request foil and etched finishes; other markers
+// are ignored.
+//
+// If any line cannot be parsed, no entries are returned and the error is a
+// *DecklistError listing every problem.
+//
+// Example:
+//
+//	deck, err := manapool.ParseDecklist(strings.NewReader(text))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	resp, err := client.CreateDeck(ctx, deck.DeckCreateRequest())
+func ParseDecklist(r io.Reader) (*Decklist, error) {
+	deck := &Decklist{}
+	var lineErrs []DecklistLineError
+
+	var (
+		section    = SectionMain
+		sawHeader  bool
+		afterBlank bool
+		inAbout    bool
+	)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		switch {
+		case text == "":
+			afterBlank = len(deck.Entries) > 0
+			continue
+		case strings.HasPrefix(text, "//"), strings.HasPrefix(text, "#"):
+			continue
+		}
+
+		header := strings.ToLower(strings.TrimSuffix(text, ":"))
+		if next, ok := decklistHeaders[header]; ok {
+			section, sawHeader, afterBlank, inAbout = next, true, false, false
+			continue
+		}
+		if header == "about" {
+			sawHeader, afterBlank, inAbout = true, false, true
+			continue
+		}
+		if inAbout {
+			continue
+		}
+
+		entrySection := section
+		if afterBlank && !sawHeader && section == SectionMain {
+			section, entrySection = SectionSideboard, SectionSideboard
+		}
+		afterBlank = false
+		if rest, ok := cutPrefixFold(text, "SB:"); ok {
+			text, entrySection = strings.TrimSpace(rest), SectionSideboard
+		}
+
+		entry, err := parseDecklistEntry(text)
+		if err != nil {
+			lineErrs = append(lineErrs, DecklistLineError{Line: line, Text: text, Message: err.Error()})
+			continue
+		}
+		entry.Line = line
+		entry.Section = entrySection
+		deck.Entries = append(deck.Entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read decklist: %w", err)
+	}
+
+	if len(lineErrs) > 0 {
+		return nil, &DecklistError{Errors: lineErrs}
+	}
+	return deck, nil
+}
+
+// parseDecklistEntry parses one card line without its section.
+func parseDecklistEntry(text string) (DecklistEntry, error) {
+	m := decklistEntryPattern.FindStringSubmatch(text)
+	if m == nil {
+		return DecklistEntry{}, errors.New("expected a quantity and card name")
+	}
+	quantity, err := strconv.Atoi(m[1])
+	if err != nil || quantity <= 0 {
+		return DecklistEntry{}, errors.New("quantity must be a positive integer")
+	}
+
+	entry := DecklistEntry{Quantity: quantity}
+	rest := m[2]
+	for {
+		marker := decklistMarkerPattern.FindStringSubmatch(rest)
+		if marker == nil {
+			break
+		}
+		switch strings.ToUpper(marker[1]) {
+		case "F":
+			entry.FinishID = "FO"
+		case "E":
+			entry.FinishID = "EF"
+		}
+		rest = rest[:len(rest)-len(marker[0])]
+	}
+	if p := decklistPrintingPattern.FindStringSubmatch(rest); p != nil {
+		rest, entry.Set, entry.Number = p[1], strings.ToUpper(p[2]), p[3]
+	}
+	entry.Name = strings.TrimSpace(rest)
+	return entry, nil
+}
+
+// cutPrefixFold is strings.CutPrefix with a case-insensitive prefix.
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return s, false
+}
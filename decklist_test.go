@@ -0,0 +1,95 @@
+package manapool
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseDecklist_Formats(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []DecklistEntry
+	}{
+		{
+			name:  "mtgo",
+			input: "4 Lightning Bolt\n20 Mountain\n\n2 Smash to Smithereens\nSB: 1 Pyroblast\n",
+			want: []DecklistEntry{
+				{Line: 1, Section: SectionMain, Quantity: 4, Name: "Lightning Bolt"},
+				{Line: 2, Section: SectionMain, Quantity: 20, Name: "Mountain"},
+				{Line: 4, Section: SectionSideboard, Quantity: 2, Name: "Smash to Smithereens"},
+				{Line: 5, Section: SectionSideboard, Quantity: 1, Name: "Pyroblast"},
+			},
+		},
+		{
+			name: "arena",
+			input: "About\nName Burn\n\nCommander\n1 Krenko, Mob Boss (M13) 139\n\n" +
+				"Deck\n4 Lightning Bolt (M10) 146\n1 Fire // Ice (MH2) 290\n\nSideboard\n2 Abrade (DMU)\n",
+			want: []DecklistEntry{
+				{Line: 5, Section: SectionCommander, Quantity: 1, Name: "Krenko, Mob Boss", Set: "M13", Number: "139"},
+				{Line: 8, Section: SectionMain, Quantity: 4, Name: "Lightning Bolt", Set: "M10", Number: "146"},
+				{Line: 9, Section: SectionMain, Quantity: 1, Name: "Fire // Ice", Set: "MH2", Number: "290"},
+				{Line: 12, Section: SectionSideboard, Quantity: 2, Name: "Abrade", Set: "DMU"},
+			},
+		},
+		{
+			name: "moxfield",
+			input: "// exported from Moxfield\n1x Sol Ring (cmr) 472 *F*\n1x Arcane Signet (CMR) 297 *E*\n" +
+				"1x Command Tower *CMDR*\n\nSIDEBOARD:\n1x Swords to Plowshares\n",
+			want: []DecklistEntry{
+				{Line: 2, Section: SectionMain, Quantity: 1, Name: "Sol Ring", Set: "CMR", Number: "472", FinishID: "FO"},
+				{Line: 3, Section: SectionMain, Quantity: 1, Name: "Arcane Signet", Set: "CMR", Number: "297", FinishID: "EF"},
+				{Line: 4, Section: SectionMain, Quantity: 1, Name: "Command Tower"},
+				{Line: 7, Section: SectionSideboard, Quantity: 1, Name: "Swords to Plowshares"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deck, err := ParseDecklist(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ParseDecklist() error = %v", err)
+			}
+			if len(deck.Entries) != len(tt.want) {
+				t.Fatalf("entries = %+v, want %+v", deck.Entries, tt.want)
+			}
+			for i := range tt.want {
+				if deck.Entries[i] != tt.want[i] {
+					t.Errorf("entries[%d] = %+v, want %+v", i, deck.Entries[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseDecklist_Errors(t *testing.T) {
+	_, err := ParseDecklist(strings.NewReader("4 Lightning Bolt\nLightning Bolt\n0 Shock\n"))
+	var deckErr *DecklistError
+	if !errors.As(err, &deckErr) {
+		t.Fatalf("error = %v, want *DecklistError", err)
+	}
+	if len(deckErr.Errors) != 2 || deckErr.Errors[0].Line != 2 || deckErr.Errors[1].Line != 3 {
+		t.Errorf("errors = %+v", deckErr.Errors)
+	}
+	if !strings.Contains(err.Error(), "2 errors") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestDecklist_DeckCreateRequest(t *testing.T) {
+	deck, err := ParseDecklist(strings.NewReader("Commander\n1 Krenko, Mob Boss\nDeck\n30 Mountain\nSideboard\n1 Shock\n"))
+	if err != nil {
+		t.Fatalf("ParseDecklist() error = %v", err)
+	}
+	req := deck.DeckCreateRequest()
+	if len(req.CommanderNames) != 1 || req.CommanderNames[0] != "Krenko, Mob Boss" {
+		t.Errorf("CommanderNames = %v", req.CommanderNames)
+	}
+	if len(req.OtherCards) != 1 || req.OtherCards[0] != (OtherCard{Name: "Mountain", Quantity: 30}) {
+		t.Errorf("OtherCards = %v", req.OtherCards)
+	}
+	if got := deck.Section(SectionSideboard); len(got) != 1 || got[0].Name != "Shock" {
+		t.Errorf("Section(sideboard) = %+v", got)
+	}
+}
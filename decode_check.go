@@ -0,0 +1,154 @@
+package manapool
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Known enum values, as listed in the API's OpenAPI document.
+var (
+	knownConditionIDs = map[string]bool{"NM": true, "LP": true, "MP": true, "HP": true, "DMG": true}
+	knownFinishIDs    = map[string]bool{"NF": true, "FO": true, "EF": true}
+	knownLanguageIDs  = languageIDSet()
+)
+
+// enumChecker is implemented by decoded types with enum fields. checkEnums
+// returns a problem for each field holding an unknown value, prefixed with
+// path.
+type enumChecker interface {
+	checkEnums(path string) []string
+}
+
+func (s Single) checkEnums(path string) []string {
+	var problems []string
+	check := func(field, value string, known map[string]bool) {
+		if value != "" && !known[value] {
+			problems = append(problems, fmt.Sprintf("%s: unknown value %q", fieldPath(path, field), value))
+		}
+	}
+	check("condition_id", s.ConditionID, knownConditionIDs)
+	check("finish_id", s.FinishID, knownFinishIDs)
+	check("language_id", s.LanguageID, knownLanguageIDs)
+	return problems
+}
+
+func (s Sealed) checkEnums(path string) []string {
+	if s.LanguageID != "" && !knownLanguageIDs[s.LanguageID] {
+		return []string{fmt.Sprintf("%s: unknown value %q", fieldPath(path, "language_id"), s.LanguageID)}
+	}
+	return nil
+}
+
+func (o OrderSummary) checkEnums(path string) []string {
+	return checkOrderStatus(fieldPath(path, "latest_fulfillment_status"), o.LatestFulfillmentStatus)
+}
+
+func (f OrderFulfillment) checkEnums(path string) []string {
+	return checkOrderStatus(fieldPath(path, "status"), f.Status)
+}
+
+func checkOrderStatus(path string, status *string) []string {
+	if status != nil && !statusFromPtr(status).Valid() {
+		return []string{fmt.Sprintf("%s: unknown value %q", path, *status)}
+	}
+	return nil
+}
+
+// decodeStrict decodes data into v, rejecting fields v does not model and
+// unknown enum values.
+func decodeStrict(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("strict decoding: %w", err)
+	}
+	if problems := checkDecodedEnums(v); len(problems) > 0 {
+		return fmt.Errorf("strict decoding: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// checkDecodedEnums returns the unknown enum values anywhere in v.
+func checkDecodedEnums(v any) []string {
+	var problems []string
+	walkDecoded(reflect.ValueOf(v), "", func(path string, value reflect.Value) {
+		if checker, ok := value.Interface().(enumChecker); ok {
+			problems = append(problems, checker.checkEnums(path)...)
+		}
+	})
+	return problems
+}
+
+// walkDecoded calls visit for every struct reachable from v, with its path
+// in JSON terms, such as "inventory[3].product.single". Embedded structs are
+// visited at their parent's path.
+func walkDecoded(v reflect.Value, path string, visit func(path string, v reflect.Value)) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			walkDecoded(v.Elem(), path, visit)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walkDecoded(v.Index(i), path+"["+strconv.Itoa(i)+"]", visit)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			walkDecoded(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key()), visit)
+		}
+	case reflect.Struct:
+		visit(strings.TrimPrefix(path, "."), v)
+		if v.Type() == reflect.TypeOf(Timestamp{}) {
+			return
+		}
+		walkDecodedFields(v, path, visit)
+	}
+}
+
+// walkDecodedFields walks the fields of a struct without visiting the struct
+// itself. Embedded structs are walked at the same path, since their methods
+// are promoted to the already visited parent.
+func walkDecodedFields(v reflect.Value, path string, visit func(path string, v reflect.Value)) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous {
+			walkDecodedFields(v.Field(i), path, visit)
+			continue
+		}
+		walkDecoded(v.Field(i), path+"."+jsonFieldName(field), visit)
+	}
+}
+
+// fieldPath joins a struct's path and one of its field names.
+func fieldPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// jsonFieldName returns the JSON name of a struct field.
+func jsonFieldName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
@@ -0,0 +1,59 @@
+package manapool
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithStrictDecoding(t *testing.T) {
+	bodies := map[string]string{
+		"/account": `{"username":"seller","seller_tier":"gold"}`,
+		"/seller/inventory": `{"inventory":[{"id":"a","product":{"single":{"condition_id":"NM","finish_id":"FO","language_id":"EN"}}},` +
+			`{"id":"b","product":{"single":{"condition_id":"GD","finish_id":"NF","language_id":"XX"}}}],"pagination":{"total":2}}`,
+		"/seller/orders/o1": `{"order":{"id":"o1","latest_fulfillment_status":"lost","fulfillments":[{"status":"shipped"},{"status":"teleported"}]}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(bodies[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	lenient := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	strict := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithStrictDecoding())
+	ctx := context.Background()
+
+	if _, err := lenient.GetSellerAccount(ctx); err != nil {
+		t.Errorf("lenient GetSellerAccount() error = %v", err)
+	}
+	if _, err := strict.GetSellerAccount(ctx); err == nil || !strings.Contains(err.Error(), `unknown field "seller_tier"`) {
+		t.Errorf("strict GetSellerAccount() error = %v", err)
+	}
+
+	if _, err := lenient.GetSellerInventory(ctx, InventoryOptions{}); err != nil {
+		t.Errorf("lenient GetSellerInventory() error = %v", err)
+	}
+	_, err := strict.GetSellerInventory(ctx, InventoryOptions{})
+	for _, want := range []string{
+		`inventory[1].product.single.condition_id: unknown value "GD"`,
+		`inventory[1].product.single.language_id: unknown value "XX"`,
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("strict GetSellerInventory() error = %v, want %s", err, want)
+		}
+	}
+	if err != nil && strings.Contains(err.Error(), "inventory[0]") {
+		t.Errorf("strict GetSellerInventory() flagged a valid item: %v", err)
+	}
+
+	_, err = strict.GetSellerOrder(ctx, "o1")
+	for _, want := range []string{
+		`order.latest_fulfillment_status: unknown value "lost"`,
+		`order.fulfillments[1].status: unknown value "teleported"`,
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("strict GetSellerOrder() error = %v, want %s", err, want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Do calls an arbitrary API endpoint with the client's authentication, rate
+// limiting, retries, caching, and error handling. It is meant for endpoints
+// this package does not wrap yet.
+//
+// path is relative to the base URL, such as "/seller/inventory", and may
+// include a query string. body, if not nil, is sent as JSON. The response is
+// decoded as JSON into out unless out is nil. Non-2xx responses are returned
+// as *APIError.
+//
+// Example:
+//
+//	var resp struct {
+//	    Items []manapool.InventoryItem `json:"items"`
+//	}
+//	err := client.Do(ctx, http.MethodGet, "/seller/new-endpoint?limit=10", nil, &resp)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) Do(ctx context.Context, method, path string, body, out any) error {
+	if method == "" {
+		return NewValidationError("method", "method cannot be empty")
+	}
+	if path == "" {
+		return NewValidationError("path", "path cannot be empty")
+	}
+	method = strings.ToUpper(method)
+
+	endpoint, rawQuery, _ := strings.Cut(path, "?")
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return NewValidationError("path", fmt.Sprintf("invalid query string: %v", err))
+	}
+
+	var resp *http.Response
+	if body != nil {
+		resp, err = c.doJSONRequest(ctx, method, endpoint, params, body)
+	} else {
+		resp, err = c.doRequest(ctx, method, endpoint, params)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to call %s %s: %w", method, endpoint, err)
+	}
+
+	if err := c.decodeResponse(resp, out); err != nil {
+		return fmt.Errorf("failed to decode %s %s: %w", method, endpoint, err)
+	}
+	return nil
+}
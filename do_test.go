@@ -0,0 +1,57 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Do(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-ManaPool-Access-Token") != "token" {
+			t.Errorf("missing auth header")
+		}
+		switch r.URL.Path {
+		case "/beta/widgets":
+			if r.Method != http.MethodPost || r.URL.Query().Get("dry_run") != "true" || r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("request = %s %s", r.Method, r.URL)
+			}
+			var in map[string]int
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			_ = json.NewEncoder(w).Encode(map[string]int{"count": in["count"] + 1})
+		case "/beta/empty":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"no such endpoint"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	ctx := context.Background()
+
+	var out struct {
+		Count int `json:"count"`
+	}
+	if err := client.Do(ctx, "post", "/beta/widgets?dry_run=true", map[string]int{"count": 1}, &out); err != nil || out.Count != 2 {
+		t.Errorf("Do(POST) = %+v, %v", out, err)
+	}
+	if err := client.Do(ctx, http.MethodDelete, "beta/empty", nil, nil); err != nil {
+		t.Errorf("Do(DELETE) error = %v", err)
+	}
+
+	var apiErr *APIError
+	if err := client.Do(ctx, http.MethodGet, "/beta/missing", nil, &out); !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("Do(missing) error = %v", err)
+	}
+	var validationErr *ValidationError
+	for _, tt := range [][2]string{{"", "/x"}, {"GET", ""}, {"GET", "/x?%zz"}} {
+		if err := client.Do(ctx, tt[0], tt[1], nil, nil); !errors.As(err, &validationErr) {
+			t.Errorf("Do(%q, %q) error = %v", tt[0], tt[1], err)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package manapool
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+)
+
+// EbayCategoryCCGSingles is eBay's category for individual collectible card
+// game cards.
+const EbayCategoryCCGSingles = 183454
+
+// ebayConditionUngraded is eBay's condition ID for ungraded trading cards.
+const ebayConditionUngraded = "4000"
+
+// ebayTitleLimit is the maximum length of an eBay listing title.
+const ebayTitleLimit = 80
+
+// Default templates for eBay titles and descriptions.
+const (
+	DefaultEbayTitleTemplate       = `{{.Name}} {{.Set}} {{.Number}}{{with .Finish}} {{.}}{{end}}{{if ne .LanguageID "EN"}} {{.Language}}{{end}} {{.ConditionID}} MTG`
+	DefaultEbayDescriptionTemplate = `{{.Name}} from {{.Set}}{{with .Number}}, collector number {{.}}{{end}}. {{.Condition}}, {{.Language}}.`
+)
+
+// ebayCSVHeader is the column layout of eBay's File Exchange add template
+// for fixed-price card listings.
+var ebayCSVHeader = []string{
+	"*Action(SiteID=US|Country=US|Currency=USD|Version=1193)",
+	"CustomLabel",
+	"*Category",
+	"*Title",
+	"*ConditionID",
+	"C:Card Condition",
+	"*Description",
+	"*Format",
+	"*Duration",
+	"*StartPrice",
+	"*Quantity",
+	"Location",
+	"ShippingProfileName",
+	"ReturnProfileName",
+	"PaymentProfileName",
+	"C:Game",
+	"C:Card Name",
+	"C:Set",
+	"C:Card Number",
+	"C:Finish",
+	"C:Language",
+}
+
+// EbayExportOptions configures WriteEbayCSV.
+type EbayExportOptions struct {
+	// TitleTemplate and DescriptionTemplate are text/template sources
+	// executed with an EbayTitleData (default: DefaultEbayTitleTemplate and
+	// DefaultEbayDescriptionTemplate). Runs of spaces are collapsed.
+	TitleTemplate       string
+	DescriptionTemplate string
+
+	// CategoryID is the eBay category (default: EbayCategoryCCGSingles)
+	CategoryID int
+
+	// Duration is the listing duration (default: "GTC")
+	Duration string
+
+	// MarkupBasisPoints raises each price to cover eBay's fees, such as
+	// 1500 for 15% (default: 0)
+	MarkupBasisPoints int
+
+	// Location and the business policy names are copied to every row
+	Location        string
+	ShippingProfile string
+	ReturnProfile   string
+	PaymentProfile  string
+}
+
+// EbayTitleData is the data available to title and description templates.
+type EbayTitleData struct {
+	Name string
+
+	// Set is the upper-case set code, such as "M10"
+	Set    string
+	Number string
+
+	// Finish is "Foil" or "Etched Foil", or "" for non-foil
+	Finish string
+
+	// Language is the display name, such as "Japanese"
+	Language   string
+	LanguageID string
+
+	// Condition is ManaPool's condition name with finish, such as "Near
+	// Mint Foil", and EbayCondition the nearest eBay grade, such as "Near
+	// Mint or Better"
+	Condition     string
+	ConditionID   string
+	EbayCondition string
+}
+
+// EbayExportWarning is a listing that was skipped or changed on export.
+type EbayExportWarning struct {
+	InventoryID string
+	Message     string
+}
+
+// String renders the warning as "listing <id>: <message>".
+func (w EbayExportWarning) String() string {
+	return fmt.Sprintf("listing %s: %s", w.InventoryID, w.Message)
+}
+
+// WriteEbayCSV writes items as an eBay File Exchange CSV for creating
+// fixed-price listings, including the header line. Each row's custom label
+// is the ManaPool listing ID, so the listings can be revised or ended by
+// label later.
+//
+// Only singles in stock are exported; cards are listed as ungraded, with
+// ManaPool's condition converted to eBay's scale. The returned warnings list
+// skipped sealed product, lossy condition conversions, and titles cut to
+// eBay's 80-character limit. Filter items first to cross-list a subset,
+// such as singles above a price.
+//
+// Example:
+//
+//	var valuable []manapool.InventoryItem
+//	for _, item := range items {
+//	    if item.Product.Single != nil && item.PriceCents >= 2500 {
+//	        valuable = append(valuable, item)
+//	    }
+//	}
+//	warnings, err := manapool.WriteEbayCSV(f, valuable, manapool.EbayExportOptions{
+//	    MarkupBasisPoints: 1300,
+//	    ShippingProfile:   "Cards - Tracked",
+//	})
+func WriteEbayCSV(w io.Writer, items []InventoryItem, opts EbayExportOptions) ([]EbayExportWarning, error) {
+	title, err := parseEbayTemplate("title", opts.TitleTemplate, DefaultEbayTitleTemplate)
+	if err != nil {
+		return nil, err
+	}
+	description, err := parseEbayTemplate("description", opts.DescriptionTemplate, DefaultEbayDescriptionTemplate)
+	if err != nil {
+		return nil, err
+	}
+	category := opts.CategoryID
+	if category == 0 {
+		category = EbayCategoryCCGSingles
+	}
+	duration := opts.Duration
+	if duration == "" {
+		duration = "GTC"
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(ebayCSVHeader); err != nil {
+		return nil, fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	var warnings []EbayExportWarning
+	warn := func(item InventoryItem, format string, args ...any) {
+		warnings = append(warnings, EbayExportWarning{InventoryID: item.ID, Message: fmt.Sprintf(format, args...)})
+	}
+	for _, item := range items {
+		single := item.Product.Single
+		switch {
+		case item.Quantity <= 0:
+			continue
+		case single == nil:
+			warn(item, "skipped: only singles are exported")
+			continue
+		}
+
+		conv, err := ConvertCondition(single.ConditionID, ScaleEbay)
+		if err != nil {
+			warn(item, "skipped: %v", err)
+			continue
+		}
+		if conv.Lossy {
+			warn(item, "%s", conv.Warning)
+		}
+
+		data := ebayTitleData(*single, conv.Condition)
+		titleText, err := executeEbayTemplate(title, data)
+		if err != nil {
+			return warnings, err
+		}
+		if utf8.RuneCountInString(titleText) > ebayTitleLimit {
+			titleText = strings.TrimSpace(string([]rune(titleText)[:ebayTitleLimit]))
+			warn(item, "title shortened to %d characters", ebayTitleLimit)
+		}
+		descriptionText, err := executeEbayTemplate(description, data)
+		if err != nil {
+			return warnings, err
+		}
+
+		price := item.PriceCents + PercentOfCents(item.PriceCents, opts.MarkupBasisPoints)
+		record := []string{
+			"Add",
+			item.ID,
+			strconv.Itoa(category),
+			titleText,
+			ebayConditionUngraded,
+			conv.Condition,
+			descriptionText,
+			"FixedPrice",
+			duration,
+			FormatCents(price),
+			strconv.Itoa(item.Quantity),
+			opts.Location,
+			opts.ShippingProfile,
+			opts.ReturnProfile,
+			opts.PaymentProfile,
+			"Magic: The Gathering",
+			data.Name,
+			data.Set,
+			data.Number,
+			data.Finish,
+			data.Language,
+		}
+		if err := writer.Write(record); err != nil {
+			return warnings, fmt.Errorf("failed to write csv row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return warnings, fmt.Errorf("failed to write csv: %w", err)
+	}
+	return warnings, nil
+}
+
+func ebayTitleData(single Single, ebayCondition string) EbayTitleData {
+	data := EbayTitleData{
+		Name:          single.Name,
+		Set:           strings.ToUpper(single.Set),
+		Number:        single.Number,
+		Language:      single.LanguageName(),
+		LanguageID:    single.LanguageID,
+		Condition:     single.ConditionFinishLabel(LabelStyleStandard),
+		ConditionID:   single.ConditionID,
+		EbayCondition: ebayCondition,
+	}
+	if single.FinishID == "FO" || single.FinishID == "EF" {
+		data.Finish = single.FinishName()
+	}
+	return data
+}
+
+func parseEbayTemplate(name, text, fallback string) (*template.Template, error) {
+	if text == "" {
+		text = fallback
+	}
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, NewValidationError(name+"_template", err.Error())
+	}
+	return tmpl, nil
+}
+
+func executeEbayTemplate(tmpl *template.Template, data EbayTitleData) (string, error) {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", tmpl.Name(), err)
+	}
+	return strings.Join(strings.Fields(b.String()), " "), nil
+}
@@ -0,0 +1,117 @@
+package manapool
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func ebayTestItems() []InventoryItem {
+	return []InventoryItem{
+		{ID: "bolt", PriceCents: 1000, Quantity: 2, Product: Product{Single: &Single{
+			Name: "Lightning Bolt", Set: "M10", Number: "146", ConditionID: "NM", FinishID: "FO", LanguageID: "EN",
+		}}},
+		{ID: "ring", PriceCents: 2500, Quantity: 1, Product: Product{Single: &Single{
+			Name: "Sol Ring", Set: "CMR", Number: "472", ConditionID: "HP", FinishID: "NF", LanguageID: "JA",
+		}}},
+		{ID: "sold", PriceCents: 100, Quantity: 0, Product: Product{Single: &Single{Name: "Forest", ConditionID: "NM"}}},
+		{ID: "box", PriceCents: 9999, Quantity: 1, Product: Product{Sealed: &Sealed{Name: "Ice Age Booster Box"}}},
+	}
+}
+
+func TestWriteEbayCSV(t *testing.T) {
+	var buf bytes.Buffer
+	warnings, err := WriteEbayCSV(&buf, ebayTestItems(), EbayExportOptions{
+		MarkupBasisPoints: 1000,
+		Location:          "Portland, OR",
+		ShippingProfile:   "Cards",
+	})
+	if err != nil {
+		t.Fatalf("WriteEbayCSV() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("records = %d, want header and 2 rows", len(records))
+	}
+	col := make(map[string]int)
+	for i, name := range records[0] {
+		col[name] = i
+	}
+	bolt, ring := records[1], records[2]
+
+	for _, tt := range []struct {
+		record []string
+		column string
+		want   string
+	}{
+		{bolt, "*Action(SiteID=US|Country=US|Currency=USD|Version=1193)", "Add"},
+		{bolt, "CustomLabel", "bolt"},
+		{bolt, "*Category", "183454"},
+		{bolt, "*Title", "Lightning Bolt M10 146 Foil NM MTG"},
+		{bolt, "*ConditionID", "4000"},
+		{bolt, "C:Card Condition", "Near Mint or Better"},
+		{bolt, "*Description", "Lightning Bolt from M10, collector number 146. Near Mint Foil, English."},
+		{bolt, "*Duration", "GTC"},
+		{bolt, "*StartPrice", "11.00"},
+		{bolt, "*Quantity", "2"},
+		{bolt, "Location", "Portland, OR"},
+		{bolt, "ShippingProfileName", "Cards"},
+		{bolt, "C:Finish", "Foil"},
+		{ring, "*Title", "Sol Ring CMR 472 Japanese HP MTG"},
+		{ring, "C:Card Condition", "Poor"},
+		{ring, "*StartPrice", "27.50"},
+		{ring, "C:Finish", ""},
+		{ring, "C:Language", "Japanese"},
+	} {
+		if got := tt.record[col[tt.column]]; got != tt.want {
+			t.Errorf("%s %s = %q, want %q", tt.record[1], tt.column, got, tt.want)
+		}
+	}
+
+	var msgs []string
+	for _, w := range warnings {
+		msgs = append(msgs, w.String())
+	}
+	want := "listing ring: eBay has no grade between Very Good and Poor; Heavily Played listed as Poor|" +
+		"listing box: skipped: only singles are exported"
+	if got := strings.Join(msgs, "|"); got != want {
+		t.Errorf("warnings = %s, want %s", got, want)
+	}
+}
+
+func TestWriteEbayCSV_Templates(t *testing.T) {
+	long := ebayTestItems()[:1]
+	long[0].Product.Single.Name = strings.Repeat("Very Long Name ", 8)
+
+	var buf bytes.Buffer
+	warnings, err := WriteEbayCSV(&buf, long, EbayExportOptions{
+		TitleTemplate:       "{{.Name}}  {{.EbayCondition}}",
+		DescriptionTemplate: "{{.Condition}}",
+	})
+	if err != nil {
+		t.Fatalf("WriteEbayCSV() error = %v", err)
+	}
+	records, _ := csv.NewReader(&buf).ReadAll()
+	if title := records[1][3]; len(title) > 80 || strings.Contains(title, "  ") || strings.HasSuffix(title, " ") {
+		t.Errorf("title = %q, want at most 80 collapsed characters", title)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Message, "title shortened") {
+		t.Errorf("warnings = %+v", warnings)
+	}
+
+	_, err = WriteEbayCSV(&buf, long, EbayExportOptions{TitleTemplate: "{{.Name"})
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) || valErr.Field != "title_template" {
+		t.Errorf("bad template error = %v", err)
+	}
+	_, err = WriteEbayCSV(&buf, long, EbayExportOptions{DescriptionTemplate: "{{.Missing}}"})
+	if err == nil || !strings.Contains(err.Error(), "failed to render description") {
+		t.Errorf("unknown field error = %v", err)
+	}
+}
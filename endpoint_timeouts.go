@@ -0,0 +1,138 @@
+package manapool
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Default per-endpoint timeouts; see WithEndpointTimeouts.
+const (
+	// DefaultReadTimeout bounds reads of a single resource, such as the
+	// account or one listing.
+	DefaultReadTimeout = 10 * time.Second
+
+	// DefaultListTimeout bounds collection reads, such as inventory and
+	// order pages or price exports, which can be several megabytes.
+	DefaultListTimeout = 2 * time.Minute
+
+	// DefaultWriteTimeout bounds creates, updates, and deletes.
+	DefaultWriteTimeout = 30 * time.Second
+)
+
+// EndpointClass groups endpoints with similar response times.
+type EndpointClass string
+
+const (
+	// EndpointRead is a GET of a single resource.
+	EndpointRead EndpointClass = "read"
+
+	// EndpointList is a GET of a collection or bulk export.
+	EndpointList EndpointClass = "list"
+
+	// EndpointWrite is any other request.
+	EndpointWrite EndpointClass = "write"
+)
+
+// listEndpoints are the collection endpoints, without a leading slash.
+var listEndpoints = map[string]bool{
+	"seller/inventory":            true,
+	"inventory/listings":          true,
+	"orders":                      true,
+	"seller/orders":               true,
+	"buyer/orders":                true,
+	"buyer/orders/pending-orders": true,
+	"webhooks":                    true,
+	"prices/singles":              true,
+	"prices/variants":             true,
+	"prices/sealed":               true,
+}
+
+// classifyEndpoint returns the class of a request to endpoint, which has no
+// leading slash.
+func classifyEndpoint(method, endpoint string) EndpointClass {
+	switch {
+	case method != http.MethodGet && method != http.MethodHead:
+		return EndpointWrite
+	case listEndpoints[endpoint]:
+		return EndpointList
+	default:
+		return EndpointRead
+	}
+}
+
+// EndpointTimeouts are the per-attempt timeouts for each EndpointClass. A
+// zero timeout leaves the class to the HTTP client's timeout.
+type EndpointTimeouts struct {
+	Read  time.Duration
+	List  time.Duration
+	Write time.Duration
+}
+
+// DefaultEndpointTimeouts returns the timeouts a new client uses.
+func DefaultEndpointTimeouts() EndpointTimeouts {
+	return EndpointTimeouts{Read: DefaultReadTimeout, List: DefaultListTimeout, Write: DefaultWriteTimeout}
+}
+
+// forClass returns the timeout for class.
+func (t EndpointTimeouts) forClass(class EndpointClass) time.Duration {
+	switch class {
+	case EndpointRead:
+		return t.Read
+	case EndpointList:
+		return t.List
+	default:
+		return t.Write
+	}
+}
+
+// WithEndpointTimeouts sets the timeout of each attempt by endpoint class,
+// so quick reads fail fast while multi-megabyte inventory pages get time to
+// download. Each timeout covers one attempt, including reading the response
+// body, and is applied through the request's context in place of the HTTP
+// client's timeout. The RequestTimeout request option takes precedence.
+//
+// New clients use DefaultEndpointTimeouts, unless WithTimeout or
+// WithHTTPClient is given, in which case the single client timeout applies
+// to every request. WithEndpointTimeouts takes effect alongside either of
+// them, in any order, and its zero fields fall back to the client timeout.
+//
+// Example:
+//
+//	timeouts := manapool.DefaultEndpointTimeouts()
+//	timeouts.List = 5 * time.Minute
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithEndpointTimeouts(timeouts),
+//	)
+func WithEndpointTimeouts(timeouts EndpointTimeouts) ClientOption {
+	return func(c *Client) {
+		c.explicitTimeouts = &timeouts
+	}
+}
+
+// resolveEndpointTimeouts returns the endpoint timeouts for the options
+// applied to c, regardless of their order.
+func (c *Client) resolveEndpointTimeouts() EndpointTimeouts {
+	switch {
+	case c.explicitTimeouts != nil:
+		return *c.explicitTimeouts
+	case c.clientTimeoutSet:
+		return EndpointTimeouts{}
+	default:
+		return DefaultEndpointTimeouts()
+	}
+}
+
+// cancelOnClose releases an attempt's context when its response body is
+// closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,105 @@
+package manapool
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClassifyEndpoint(t *testing.T) {
+	tests := []struct {
+		method, endpoint string
+		want             EndpointClass
+	}{
+		{http.MethodGet, "account", EndpointRead},
+		{http.MethodHead, "account", EndpointRead},
+		{http.MethodGet, "seller/inventory/tcgsku/1", EndpointRead},
+		{http.MethodGet, "seller/inventory", EndpointList},
+		{http.MethodGet, "prices/singles", EndpointList},
+		{http.MethodPost, "seller/inventory", EndpointWrite},
+		{http.MethodDelete, "seller/inventory/tcgsku/1", EndpointWrite},
+	}
+	for _, tt := range tests {
+		if got := classifyEndpoint(tt.method, tt.endpoint); got != tt.want {
+			t.Errorf("classifyEndpoint(%s, %s) = %s, want %s", tt.method, tt.endpoint, got, tt.want)
+		}
+	}
+}
+
+func TestClient_EndpointTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Send headers at once and the body late, so the timeout must
+		// cover reading the body.
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		time.Sleep(200 * time.Millisecond)
+		if r.URL.Path == "/seller/inventory" {
+			_, _ = w.Write([]byte(`{"inventory":[],"pagination":{}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	// The endpoint timeouts replace the 50ms client timeout.
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0),
+		WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond}),
+		WithEndpointTimeouts(EndpointTimeouts{Read: 50 * time.Millisecond, List: 2 * time.Second}))
+
+	if _, err := client.GetSellerAccount(ctx); err == nil {
+		t.Error("GetSellerAccount() should exceed the read timeout")
+	}
+	if _, err := client.GetSellerInventory(ctx, InventoryOptions{}); err != nil {
+		t.Errorf("GetSellerInventory() error = %v, want the list timeout to apply", err)
+	}
+
+	// A request timeout takes precedence.
+	slow := WithRequestOptions(ctx, RequestTimeout(2*time.Second))
+	if _, err := client.GetSellerAccount(slow); err != nil {
+		t.Errorf("GetSellerAccount() with RequestTimeout error = %v", err)
+	}
+
+	// Writes have no endpoint timeout here, so the client timeout applies.
+	if _, err := client.UpdateSellerAccount(ctx, SellerAccountUpdate{}); err == nil {
+		t.Error("UpdateSellerAccount() should exceed the client timeout")
+	}
+}
+
+func TestWithTimeout_DisablesEndpointTimeouts(t *testing.T) {
+	client := NewClient("token", "email")
+	if client.endpointTimeouts != DefaultEndpointTimeouts() {
+		t.Errorf("endpointTimeouts = %+v, want defaults", client.endpointTimeouts)
+	}
+	client = NewClient("token", "email", WithTimeout(time.Minute))
+	if client.endpointTimeouts != (EndpointTimeouts{}) {
+		t.Errorf("endpointTimeouts = %+v, want none after WithTimeout", client.endpointTimeouts)
+	}
+}
+
+func TestWithEndpointTimeouts_AnyOrder(t *testing.T) {
+	timeouts := EndpointTimeouts{Read: time.Second, List: time.Minute, Write: 5 * time.Second}
+	for name, opts := range map[string][]ClientOption{
+		"before WithTimeout":    {WithEndpointTimeouts(timeouts), WithTimeout(time.Minute)},
+		"after WithTimeout":     {WithTimeout(time.Minute), WithEndpointTimeouts(timeouts)},
+		"before WithHTTPClient": {WithEndpointTimeouts(timeouts), WithHTTPClient(&http.Client{})},
+	} {
+		client := NewClient("token", "email", opts...)
+		if client.endpointTimeouts != timeouts {
+			t.Errorf("%s: endpointTimeouts = %+v, want %+v", name, client.endpointTimeouts, timeouts)
+		}
+	}
+
+	t.Setenv(EnvAccessToken, "token")
+	t.Setenv(EnvEmail, "email")
+	t.Setenv(EnvTimeout, "5s")
+	client, err := NewClientFromEnv(WithEndpointTimeouts(timeouts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.endpointTimeouts != timeouts || client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("NewClientFromEnv: endpointTimeouts = %+v, timeout = %v", client.endpointTimeouts, client.httpClient.Timeout)
+	}
+}
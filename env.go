@@ -0,0 +1,145 @@
+package manapool
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables read by NewClientFromEnv.
+const (
+	EnvAccessToken  = "MANAPOOL_ACCESS_TOKEN"
+	EnvEmail        = "MANAPOOL_EMAIL"
+	EnvBaseURL      = "MANAPOOL_BASE_URL"
+	EnvAPIVersion   = "MANAPOOL_API_VERSION"
+	EnvRateLimit    = "MANAPOOL_RATE_LIMIT"
+	EnvRateBurst    = "MANAPOOL_RATE_BURST"
+	EnvMaxRetries   = "MANAPOOL_MAX_RETRIES"
+	EnvRetryBackoff = "MANAPOOL_RETRY_BACKOFF"
+	EnvTimeout      = "MANAPOOL_TIMEOUT"
+)
+
+// NewClientFromEnv creates a client configured from environment variables:
+//
+//   - MANAPOOL_ACCESS_TOKEN (required): API access token
+//   - MANAPOOL_EMAIL (required): account email address
+//   - MANAPOOL_BASE_URL: API base URL (default: DefaultBaseURL)
+//   - MANAPOOL_API_VERSION: API version, e.g. "v2" (default: the base URL's)
+//   - MANAPOOL_RATE_LIMIT: requests per second, e.g. "5" or "0.5"
+//   - MANAPOOL_RATE_BURST: rate limit burst size
+//   - MANAPOOL_MAX_RETRIES: maximum retry attempts
+//   - MANAPOOL_RETRY_BACKOFF: initial retry backoff, e.g. "500ms"
+//   - MANAPOOL_TIMEOUT: HTTP client timeout, e.g. "1m"
+//
+// Unset tunables keep their defaults. opts are applied after the environment,
+// so they take precedence. If required variables are missing or values are
+// malformed, the error is a *ValidationError listing every problem.
+//
+// Example:
+//
+//	client, err := manapool.NewClientFromEnv(
+//	    manapool.WithLogger(logger),
+//	)
+//	if err != nil {
+//	    log.Fatal(err) // e.g. "missing MANAPOOL_ACCESS_TOKEN, MANAPOOL_EMAIL"
+//	}
+func NewClientFromEnv(opts ...ClientOption) (*Client, error) {
+	return newClientFromLookup(os.LookupEnv, opts...)
+}
+
+func newClientFromLookup(lookup func(string) (string, bool), opts ...ClientOption) (*Client, error) {
+	get := func(name string) string {
+		value, _ := lookup(name)
+		return strings.TrimSpace(value)
+	}
+
+	var missing, invalid []string
+	token, email := get(EnvAccessToken), get(EnvEmail)
+	if token == "" {
+		missing = append(missing, EnvAccessToken)
+	}
+	if email == "" {
+		missing = append(missing, EnvEmail)
+	}
+
+	var envOpts []ClientOption
+	if baseURL := get(EnvBaseURL); baseURL != "" {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		envOpts = append(envOpts, WithBaseURL(baseURL))
+	}
+	if v := get(EnvAPIVersion); v != "" {
+		if !isVersionSegment(v) {
+			invalid = append(invalid, fmt.Sprintf("%s must be a version such as v2, got %q", EnvAPIVersion, v))
+		} else {
+			envOpts = append(envOpts, WithAPIVersion(v))
+		}
+	}
+
+	rateLimit, burst := DefaultRateLimit, DefaultRateBurst
+	rateSet := false
+	if v := get(EnvRateLimit); v != "" {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil || n <= 0 {
+			invalid = append(invalid, fmt.Sprintf("%s must be a positive number, got %q", EnvRateLimit, v))
+		} else {
+			rateLimit, rateSet = n, true
+		}
+	}
+	if v := get(EnvRateBurst); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			invalid = append(invalid, fmt.Sprintf("%s must be a positive integer, got %q", EnvRateBurst, v))
+		} else {
+			burst, rateSet = n, true
+		}
+	}
+	if rateSet {
+		envOpts = append(envOpts, WithRateLimit(rateLimit, burst))
+	}
+
+	maxRetries, backoff := DefaultMaxRetries, DefaultInitialBackoff
+	retrySet := false
+	if v := get(EnvMaxRetries); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			invalid = append(invalid, fmt.Sprintf("%s must be a non-negative integer, got %q", EnvMaxRetries, v))
+		} else {
+			maxRetries, retrySet = n, true
+		}
+	}
+	if v := get(EnvRetryBackoff); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			invalid = append(invalid, fmt.Sprintf("%s must be a non-negative duration, got %q", EnvRetryBackoff, v))
+		} else {
+			backoff, retrySet = d, true
+		}
+	}
+	if retrySet {
+		envOpts = append(envOpts, WithRetry(maxRetries, backoff))
+	}
+
+	if v := get(EnvTimeout); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			invalid = append(invalid, fmt.Sprintf("%s must be a positive duration, got %q", EnvTimeout, v))
+		} else {
+			envOpts = append(envOpts, WithTimeout(d))
+		}
+	}
+
+	if len(missing) > 0 || len(invalid) > 0 {
+		var problems []string
+		if len(missing) > 0 {
+			problems = append(problems, "missing "+strings.Join(missing, ", "))
+		}
+		problems = append(problems, invalid...)
+		return nil, NewValidationError("environment", strings.Join(problems, "; "))
+	}
+
+	return NewClient(token, email, append(envOpts, opts...)...), nil
+}
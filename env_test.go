@@ -0,0 +1,93 @@
+package manapool
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestNewClientFromEnv(t *testing.T) {
+	t.Setenv(EnvAccessToken, "token")
+	t.Setenv(EnvEmail, " seller@example.com ")
+	t.Setenv(EnvBaseURL, "http://localhost:8080/api")
+	t.Setenv(EnvRateLimit, "2.5")
+	t.Setenv(EnvRateBurst, "")
+	t.Setenv(EnvMaxRetries, "0")
+	t.Setenv(EnvRetryBackoff, "")
+	t.Setenv(EnvTimeout, "5s")
+	t.Setenv(EnvAPIVersion, "v2")
+
+	client, err := NewClientFromEnv(WithUserAgent("daemon/1.0"))
+	if err != nil {
+		t.Fatalf("NewClientFromEnv() error = %v", err)
+	}
+
+	if client.authToken != "token" || client.email != "seller@example.com" {
+		t.Errorf("credentials = %q, %q", client.authToken, client.email)
+	}
+	if client.baseURL != "http://localhost:8080/api/" || client.apiVersion != "v2" {
+		t.Errorf("baseURL = %q, apiVersion = %q", client.baseURL, client.apiVersion)
+	}
+	limiter := client.rateLimiter.(*rate.Limiter)
+	if limiter.Limit() != rate.Limit(2.5) || limiter.Burst() != DefaultRateBurst {
+		t.Errorf("rate limit = %v/%d", limiter.Limit(), limiter.Burst())
+	}
+	if client.maxRetries != 0 || client.initialBackoff != DefaultInitialBackoff {
+		t.Errorf("retry = %d, %v", client.maxRetries, client.initialBackoff)
+	}
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("timeout = %v", client.httpClient.Timeout)
+	}
+	if client.userAgent != "daemon/1.0" {
+		t.Errorf("userAgent = %q", client.userAgent)
+	}
+}
+
+func TestNewClientFromEnv_Defaults(t *testing.T) {
+	env := map[string]string{EnvAccessToken: "token", EnvEmail: "email"}
+	client, err := newClientFromLookup(func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}, WithRetry(7, 0))
+	if err != nil {
+		t.Fatalf("newClientFromLookup() error = %v", err)
+	}
+	if limiter := client.rateLimiter.(*rate.Limiter); client.baseURL != DefaultBaseURL || limiter.Limit() != DefaultRateLimit {
+		t.Errorf("defaults not kept: %q %v", client.baseURL, limiter.Limit())
+	}
+	if client.maxRetries != 7 {
+		t.Errorf("explicit option did not override: maxRetries = %d", client.maxRetries)
+	}
+}
+
+func TestNewClientFromEnv_Errors(t *testing.T) {
+	env := map[string]string{
+		EnvEmail:        "",
+		EnvRateLimit:    "fast",
+		EnvRateBurst:    "0",
+		EnvMaxRetries:   "-1",
+		EnvRetryBackoff: "soon",
+		EnvTimeout:      "0s",
+		EnvAPIVersion:   "2",
+	}
+	_, err := newClientFromLookup(func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	})
+
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) {
+		t.Fatalf("error = %v, want ValidationError", err)
+	}
+	for _, want := range []string{
+		"missing MANAPOOL_ACCESS_TOKEN, MANAPOOL_EMAIL",
+		EnvRateLimit, EnvRateBurst, EnvMaxRetries, EnvRetryBackoff, EnvTimeout, EnvAPIVersion,
+	} {
+		if !strings.Contains(valErr.Message, want) {
+			t.Errorf("error %q does not mention %s", valErr.Message, want)
+		}
+	}
+}
@@ -1,10 +1,21 @@
 package manapool
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 )
 
+// ErrPreconditionFailed reports a conditional write that was refused because
+// the resource changed since it was read. Match it with errors.Is; it also
+// matches an *APIError with status 412 Precondition Failed.
+var ErrPreconditionFailed = errors.New("precondition failed")
+
 // APIError represents an error returned by the Manapool API.
 // It contains the HTTP status code, error message, and optional request ID
 // for debugging purposes.
@@ -15,20 +26,53 @@ type APIError struct {
 	// Message is the error message from the API or a descriptive error message
 	Message string
 
+	// Code is the machine-readable error code from the API (if available)
+	Code string
+
 	// RequestID is the unique identifier for the request (if available)
 	RequestID string
 
+	// FieldErrors lists per-field validation problems reported by the API
+	FieldErrors []FieldError
+
 	// Response is the raw HTTP response (may be nil)
 	Response *http.Response
 }
 
 // Error implements the error interface.
 func (e *APIError) Error() string {
+	message := e.Message
+	if len(e.FieldErrors) > 0 {
+		msgs := make([]string, len(e.FieldErrors))
+		for i, fieldErr := range e.FieldErrors {
+			msgs[i] = fieldErr.Error()
+		}
+		message += " (" + strings.Join(msgs, "; ") + ")"
+	}
 	if e.RequestID != "" {
 		return fmt.Sprintf("manapool API error (status %d, request %s): %s",
-			e.StatusCode, e.RequestID, e.Message)
+			e.StatusCode, e.RequestID, message)
 	}
-	return fmt.Sprintf("manapool API error (status %d): %s", e.StatusCode, e.Message)
+	return fmt.Sprintf("manapool API error (status %d): %s", e.StatusCode, message)
+}
+
+// FieldError returns the first field error reported for field.
+//
+// Example:
+//
+//	var apiErr *manapool.APIError
+//	if errors.As(err, &apiErr) {
+//	    if fieldErr, ok := apiErr.FieldError("price_cents"); ok {
+//	        log.Printf("bad price: %s", fieldErr.Message)
+//	    }
+//	}
+func (e *APIError) FieldError(field string) (FieldError, bool) {
+	for _, fieldErr := range e.FieldErrors {
+		if fieldErr.Field == field {
+			return fieldErr, true
+		}
+	}
+	return FieldError{}, false
 }
 
 // IsNotFound returns true if the error is a 404 Not Found error.
@@ -56,6 +100,39 @@ func (e *APIError) IsServerError() bool {
 	return e.StatusCode >= 500 && e.StatusCode < 600
 }
 
+// IsPreconditionFailed returns true if the error is a 412 Precondition Failed
+// error.
+func (e *APIError) IsPreconditionFailed() bool {
+	return e.StatusCode == http.StatusPreconditionFailed
+}
+
+// Is reports whether the error matches target. A 412 response matches
+// ErrPreconditionFailed.
+func (e *APIError) Is(target error) bool {
+	return target == ErrPreconditionFailed && e.IsPreconditionFailed()
+}
+
+// FieldError is a validation problem with a single request field, as
+// reported by the API.
+type FieldError struct {
+	// Field is the name or dotted path of the offending field
+	Field string
+
+	// Code is the machine-readable reason (if available)
+	Code string
+
+	// Message describes the problem
+	Message string
+}
+
+// Error implements the error interface.
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
 // ValidationError represents an error that occurs during input validation.
 type ValidationError struct {
 	Field   string
@@ -86,6 +163,38 @@ func (e *NetworkError) Unwrap() error {
 	return e.Err
 }
 
+// RetryAbortedError reports that a failed request was not retried because
+// the backoff sleep plus the expected latency of another attempt would pass
+// the context's deadline. Err is the last attempt's error: a *NetworkError,
+// or an *APIError for a retryable status.
+type RetryAbortedError struct {
+	// Attempts is the number of attempts made
+	Attempts int
+
+	// Backoff is the sleep that was skipped
+	Backoff time.Duration
+
+	// ExpectedLatency is the latency of the last attempt, used as the
+	// estimate for the next one
+	ExpectedLatency time.Duration
+
+	// Remaining is the time that was left before the deadline
+	Remaining time.Duration
+
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RetryAbortedError) Error() string {
+	return fmt.Sprintf("retry aborted after attempt %d: %v backoff plus %v expected latency exceeds the %v left before the deadline: %v",
+		e.Attempts, e.Backoff, e.ExpectedLatency.Round(time.Millisecond), e.Remaining.Round(time.Millisecond), e.Err)
+}
+
+// Unwrap returns the last attempt's error.
+func (e *RetryAbortedError) Unwrap() error {
+	return e.Err
+}
+
 // Common error constructors
 
 // NewAPIError creates a new APIError.
@@ -111,3 +220,189 @@ func NewNetworkError(message string, err error) *NetworkError {
 		Err:     err,
 	}
 }
+
+// newAPIErrorFromResponse builds an APIError from a non-2xx response and its
+// body. Structured error payloads are parsed when present; the supported
+// shapes are a top-level or nested "error" object with "code", "message",
+// "request_id", and "errors" or "details" fields. Field errors may be a list
+// of objects, a list of messages, or a map of field name to message(s). If
+// the body is not JSON, the raw body is used as the message.
+func newAPIErrorFromResponse(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    string(body),
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		Response:   resp,
+	}
+
+	var payload errorPayload
+	if json.Unmarshal(body, &payload) != nil {
+		return apiErr
+	}
+
+	// {"error": {...}} wraps the structured payload
+	var nested errorPayload
+	if json.Unmarshal(payload.Error, &nested) == nil {
+		payload.merge(nested)
+	}
+
+	for _, raw := range []json.RawMessage{payload.Error, payload.Message, payload.Detail} {
+		if msg := jsonString(raw); msg != "" {
+			apiErr.Message = msg
+			break
+		}
+	}
+	apiErr.Code = jsonString(payload.Code)
+	if requestID := jsonString(payload.RequestID); requestID != "" {
+		apiErr.RequestID = requestID
+	} else if requestID := jsonString(payload.RequestIDAlt); requestID != "" {
+		apiErr.RequestID = requestID
+	}
+
+	for _, raw := range []json.RawMessage{payload.Errors, payload.Details, payload.Detail} {
+		apiErr.FieldErrors = append(apiErr.FieldErrors, parseFieldErrors(raw)...)
+	}
+	if apiErr.Message == string(body) && len(apiErr.FieldErrors) > 0 {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+
+	return apiErr
+}
+
+// errorPayload is the union of the error body shapes the client understands.
+// Fields are kept raw because their types vary between endpoints.
+type errorPayload struct {
+	Error        json.RawMessage `json:"error"`
+	Message      json.RawMessage `json:"message"`
+	Detail       json.RawMessage `json:"detail"`
+	Code         json.RawMessage `json:"code"`
+	RequestID    json.RawMessage `json:"request_id"`
+	RequestIDAlt json.RawMessage `json:"requestId"`
+	Errors       json.RawMessage `json:"errors"`
+	Details      json.RawMessage `json:"details"`
+}
+
+// merge fills empty fields of p from other.
+func (p *errorPayload) merge(other errorPayload) {
+	fill := func(dst *json.RawMessage, src json.RawMessage) {
+		if len(*dst) == 0 {
+			*dst = src
+		}
+	}
+	fill(&p.Message, other.Message)
+	fill(&p.Detail, other.Detail)
+	fill(&p.Code, other.Code)
+	fill(&p.RequestID, other.RequestID)
+	fill(&p.RequestIDAlt, other.RequestIDAlt)
+	fill(&p.Errors, other.Errors)
+	fill(&p.Details, other.Details)
+}
+
+// parseFieldErrors decodes field errors from a list of objects or a map of
+// field name to a message or list of messages. Anything else yields nil.
+func parseFieldErrors(raw json.RawMessage) []FieldError {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil
+	}
+
+	switch raw[0] {
+	case '[':
+		var messages []string
+		if json.Unmarshal(raw, &messages) == nil {
+			fieldErrs := make([]FieldError, 0, len(messages))
+			for _, message := range messages {
+				fieldErrs = append(fieldErrs, FieldError{Message: message})
+			}
+			return fieldErrs
+		}
+
+		var items []struct {
+			Field   json.RawMessage `json:"field"`
+			Loc     []interface{}   `json:"loc"`
+			Code    json.RawMessage `json:"code"`
+			Type    json.RawMessage `json:"type"`
+			Message json.RawMessage `json:"message"`
+			Msg     json.RawMessage `json:"msg"`
+		}
+		if json.Unmarshal(raw, &items) != nil {
+			return nil
+		}
+		fieldErrs := make([]FieldError, 0, len(items))
+		for _, item := range items {
+			fieldErr := FieldError{
+				Field:   jsonString(item.Field),
+				Code:    jsonString(item.Code),
+				Message: jsonString(item.Message),
+			}
+			if fieldErr.Field == "" && len(item.Loc) > 0 {
+				fieldErr.Field = locPath(item.Loc)
+			}
+			if fieldErr.Code == "" {
+				fieldErr.Code = jsonString(item.Type)
+			}
+			if fieldErr.Message == "" {
+				fieldErr.Message = jsonString(item.Msg)
+			}
+			if fieldErr.Field != "" || fieldErr.Message != "" {
+				fieldErrs = append(fieldErrs, fieldErr)
+			}
+		}
+		return fieldErrs
+
+	case '{':
+		var byField map[string]json.RawMessage
+		if json.Unmarshal(raw, &byField) != nil {
+			return nil
+		}
+		fields := make([]string, 0, len(byField))
+		for field := range byField {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		var fieldErrs []FieldError
+		for _, field := range fields {
+			var messages []string
+			if json.Unmarshal(byField[field], &messages) != nil {
+				messages = []string{jsonString(byField[field])}
+			}
+			for _, message := range messages {
+				if message != "" {
+					fieldErrs = append(fieldErrs, FieldError{Field: field, Message: message})
+				}
+			}
+		}
+		return fieldErrs
+	}
+
+	return nil
+}
+
+// locPath joins a validation "loc" path such as ["body", "items", 0, "price"]
+// into "items.0.price", dropping the leading request section.
+func locPath(loc []interface{}) string {
+	parts := make([]string, 0, len(loc))
+	for i, part := range loc {
+		s := fmt.Sprint(part)
+		if i == 0 && len(loc) > 1 && (s == "body" || s == "query" || s == "path") {
+			continue
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, ".")
+}
+
+// jsonString returns a JSON string or number as a string, or "" for any
+// other value.
+func jsonString(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		return n.String()
+	}
+	return ""
+}
@@ -2,8 +2,11 @@ package manapool
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestAPIError_Error(t *testing.T) {
@@ -29,6 +32,15 @@ func TestAPIError_Error(t *testing.T) {
 			},
 			wantError: "manapool API error (status 500): internal server error",
 		},
+		{
+			name: "with field errors",
+			err: &APIError{
+				StatusCode:  422,
+				Message:     "invalid request",
+				FieldErrors: []FieldError{{Field: "quantity", Message: "must be positive"}, {Message: "bad"}},
+			},
+			wantError: "manapool API error (status 422): invalid request (quantity: must be positive; bad)",
+		},
 	}
 
 	for _, tt := range tests {
@@ -226,6 +238,38 @@ func TestAPIError_IsServerError(t *testing.T) {
 	}
 }
 
+func TestAPIError_IsPreconditionFailed(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		want       bool
+	}{
+		{
+			name:       "412 Precondition Failed",
+			statusCode: http.StatusPreconditionFailed,
+			want:       true,
+		},
+		{
+			name:       "409 Conflict",
+			statusCode: http.StatusConflict,
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &APIError{StatusCode: tt.statusCode}
+			if got := err.IsPreconditionFailed(); got != tt.want {
+				t.Errorf("APIError.IsPreconditionFailed() = %v, want %v", got, tt.want)
+			}
+			wrapped := fmt.Errorf("failed to update: %w", err)
+			if got := errors.Is(wrapped, ErrPreconditionFailed); got != tt.want {
+				t.Errorf("errors.Is(ErrPreconditionFailed) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestValidationError_Error(t *testing.T) {
 	err := &ValidationError{
 		Field:   "limit",
@@ -345,3 +389,120 @@ func TestAPIError_Response(t *testing.T) {
 		t.Errorf("APIError.Response = %v, want %v", err.Response, resp)
 	}
 }
+
+func TestNewAPIErrorFromResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      http.Header
+		body        string
+		wantMessage string
+		wantCode    string
+		wantRequest string
+		wantFields  []FieldError
+	}{
+		{
+			name:        "plain text",
+			body:        "bad gateway",
+			wantMessage: "bad gateway",
+		},
+		{
+			name:        "error string",
+			body:        `{"error":"not found","message":"ignored"}`,
+			wantMessage: "not found",
+		},
+		{
+			name:        "flat structured",
+			header:      http.Header{"X-Request-Id": []string{"hdr-1"}},
+			body:        `{"code":"invalid_inventory","message":"invalid request","request_id":"req-1","errors":[{"field":"price_cents","code":"min","message":"must be positive"},{"field":"quantity","message":"required"}]}`,
+			wantMessage: "invalid request",
+			wantCode:    "invalid_inventory",
+			wantRequest: "req-1",
+			wantFields: []FieldError{
+				{Field: "price_cents", Code: "min", Message: "must be positive"},
+				{Field: "quantity", Message: "required"},
+			},
+		},
+		{
+			name:        "nested error object",
+			header:      http.Header{"X-Request-Id": []string{"hdr-2"}},
+			body:        `{"error":{"code":4001,"message":"validation failed","details":{"sku":"unknown","quantity":["too large","not an integer"]}}}`,
+			wantMessage: "validation failed",
+			wantCode:    "4001",
+			wantRequest: "hdr-2",
+			wantFields: []FieldError{
+				{Field: "quantity", Message: "too large"},
+				{Field: "quantity", Message: "not an integer"},
+				{Field: "sku", Message: "unknown"},
+			},
+		},
+		{
+			name:        "detail list",
+			body:        `{"detail":[{"loc":["body",0,"price_cents"],"msg":"value is not a valid integer","type":"type_error.integer"}]}`,
+			wantMessage: "Bad Request",
+			wantFields: []FieldError{
+				{Field: "0.price_cents", Code: "type_error.integer", Message: "value is not a valid integer"},
+			},
+		},
+		{
+			name:        "string details",
+			body:        `{"status":400,"message":"Bad request","details":["price_cents must be at least 1"]}`,
+			wantMessage: "Bad request",
+			wantFields:  []FieldError{{Message: "price_cents must be at least 1"}},
+		},
+		{
+			name:        "detail string",
+			body:        `{"detail":"Not authenticated","requestId":"req-3","errors":"ignored"}`,
+			wantMessage: "Not authenticated",
+			wantRequest: "req-3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := tt.header
+			if header == nil {
+				header = http.Header{}
+			}
+			resp := &http.Response{StatusCode: http.StatusBadRequest, Header: header}
+			err := newAPIErrorFromResponse(resp, []byte(tt.body))
+
+			if err.StatusCode != http.StatusBadRequest || err.Response != resp {
+				t.Errorf("StatusCode = %d, Response = %p", err.StatusCode, err.Response)
+			}
+			if err.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", err.Message, tt.wantMessage)
+			}
+			if err.Code != tt.wantCode {
+				t.Errorf("Code = %q, want %q", err.Code, tt.wantCode)
+			}
+			if err.RequestID != tt.wantRequest {
+				t.Errorf("RequestID = %q, want %q", err.RequestID, tt.wantRequest)
+			}
+			if !reflect.DeepEqual(err.FieldErrors, tt.wantFields) {
+				t.Errorf("FieldErrors = %+v, want %+v", err.FieldErrors, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestAPIError_FieldError(t *testing.T) {
+	err := &APIError{FieldErrors: []FieldError{{Field: "quantity", Message: "required"}}}
+	if fieldErr, ok := err.FieldError("quantity"); !ok || fieldErr.Message != "required" {
+		t.Errorf("FieldError(quantity) = %+v, %v", fieldErr, ok)
+	}
+	if _, ok := err.FieldError("price_cents"); ok {
+		t.Error("FieldError(price_cents) ok = true")
+	}
+}
+
+func TestRetryAbortedError(t *testing.T) {
+	cause := NewAPIError(http.StatusBadGateway, "bad gateway")
+	err := &RetryAbortedError{Attempts: 2, Backoff: 2 * time.Second, ExpectedLatency: 300 * time.Millisecond, Remaining: 1500 * time.Millisecond, Err: cause}
+	want := "retry aborted after attempt 2: 2s backoff plus 300ms expected latency exceeds the 1.5s left before the deadline: " + cause.Error()
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("RetryAbortedError does not unwrap to the last attempt's error")
+	}
+}
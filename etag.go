@@ -0,0 +1,65 @@
+package manapool
+
+import (
+	"net/http"
+	"sync"
+)
+
+// etagEntry is a cached response validated by an ETag.
+type etagEntry struct {
+	etag   string
+	header http.Header
+	body   []byte
+}
+
+// etagCache stores ETag-validated GET responses keyed by request URL.
+// It is safe for concurrent use.
+type etagCache struct {
+	mu      sync.Mutex
+	entries map[string]*etagEntry
+}
+
+func newETagCache() *etagCache {
+	return &etagCache{entries: make(map[string]*etagEntry)}
+}
+
+func (c *etagCache) get(key string) (*etagEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+func (c *etagCache) set(key string, entry *etagEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry
+}
+
+// applyETagCache serves 304 Not Modified responses from the cache and stores
+// fresh responses that carry an ETag header.
+func (c *Client) applyETagCache(key string, resp *http.Response, cached *etagEntry) (*http.Response, error) {
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		_ = resp.Body.Close()
+		c.logger.Debugf("Serving %s from cache (ETag %s)", key, cached.etag)
+		return cachedResponse(resp.Request, cached.header.Clone(), cached.body), nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || etag == "" {
+		return resp, nil
+	}
+
+	body, err := bufferBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	c.etagCache.set(key, &etagEntry{
+		etag:   etag,
+		header: resp.Header.Clone(),
+		body:   body,
+	})
+
+	return resp, nil
+}
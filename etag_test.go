@@ -0,0 +1,88 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_WithConditionalRequests(t *testing.T) {
+	var requests, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"username":"seller","email":"test@example.com"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithConditionalRequests(),
+	)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		account, err := client.GetSellerAccount(ctx)
+		if err != nil {
+			t.Fatalf("GetSellerAccount() call %d error = %v", i, err)
+		}
+		if account.Username != "seller" {
+			t.Errorf("call %d Username = %q, want %q", i, account.Username, "seller")
+		}
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("requests = %d, want 3", got)
+	}
+	if got := atomic.LoadInt32(&notModified); got != 2 {
+		t.Errorf("304 responses = %d, want 2", got)
+	}
+}
+
+func TestClient_WithConditionalRequests_SkipsNonGET(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			t.Errorf("unexpected If-None-Match on %s", r.Method)
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"username":"seller"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithConditionalRequests(),
+	)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.UpdateSellerAccount(context.Background(), SellerAccountUpdate{}); err != nil {
+			t.Fatalf("UpdateSellerAccount() error = %v", err)
+		}
+	}
+}
+
+func TestClient_applyETagCache_ReadError(t *testing.T) {
+	client := NewClient("token", "email", WithConditionalRequests())
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Etag": []string{`"v1"`}},
+		Body:       io.NopCloser(errReader{errors.New("boom")}),
+	}
+
+	_, err := client.applyETagCache("key", resp, nil)
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) {
+		t.Fatalf("error = %v, want NetworkError", err)
+	}
+}
@@ -0,0 +1,232 @@
+// Package eventbus publishes ManaPool events to an existing event bus.
+//
+// A Bridge turns webhook deliveries, inventory changes from
+// manapool.Client.WatchInventory, and new orders from
+// manapool.Client.WatchOrders into Messages and sends them to a Publisher,
+// so larger operations can fan ManaPool events into the infrastructure they
+// already run. Implement Publisher over any client library, or build with a
+// tag to use a bundled adapter:
+//
+//   - -tags nats: NATSPublisher speaks the NATS core protocol
+//   - -tags kafka: KafkaRESTPublisher produces through a Kafka REST Proxy
+//
+// The adapters use only the standard library, so they add no dependencies
+// to builds without the tags.
+//
+// # Basic Usage
+//
+//	bridge := eventbus.NewBridge(publisher, eventbus.WithSubjectPrefix("shop.manapool"))
+//
+//	// Webhooks: publish every verified order_created delivery.
+//	http.Handle("/manapool/webhook", manapool.NewWebhookHandler(secret, bridge.HandleWebhook))
+//
+//	// Watchers: publish inventory changes until ctx is cancelled.
+//	go bridge.PublishInventory(ctx, client.WatchInventory(ctx, 5*time.Minute))
+//
+// # Messages
+//
+// Each Message has a subject such as "manapool.inventory.price_changed" and
+// a JSON Envelope as its data:
+//
+//	{"type":"inventory.price_changed","id":"<listing id>","time":"...","data":{"item":{...},"previous":{...}}}
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+// DefaultSubjectPrefix is the prefix of message subjects.
+const DefaultSubjectPrefix = "manapool"
+
+// Message is an event ready to publish.
+type Message struct {
+	// Subject is the NATS subject or Kafka topic, such as
+	// "manapool.webhook.order_created"
+	Subject string
+
+	// Key identifies the entity the event is about, such as a listing or
+	// order ID, for partitioning and deduplication
+	Key string
+
+	// Data is the JSON-encoded Envelope
+	Data []byte
+}
+
+// Publisher sends messages to an event bus. Implementations must be safe
+// for concurrent use.
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// PublisherFunc adapts a function to Publisher.
+type PublisherFunc func(ctx context.Context, msg Message) error
+
+// Publish implements Publisher.
+func (f PublisherFunc) Publish(ctx context.Context, msg Message) error {
+	return f(ctx, msg)
+}
+
+// Envelope is the JSON body of every message.
+type Envelope struct {
+	// Type is the subject without its prefix, such as
+	// "webhook.order_created"
+	Type string `json:"type"`
+
+	// ID is the message Key
+	ID string `json:"id"`
+
+	// Time is when the event was observed
+	Time time.Time `json:"time"`
+
+	// Replay is true for webhooks re-run by manapool.ReplayEvents
+	Replay bool `json:"replay,omitempty"`
+
+	// Data is the event payload: the webhook body, an InventoryChange, or an
+	// order summary
+	Data json.RawMessage `json:"data"`
+}
+
+// InventoryChange is the data of inventory messages.
+type InventoryChange struct {
+	Item     manapool.InventoryItem  `json:"item"`
+	Previous *manapool.InventoryItem `json:"previous,omitempty"`
+}
+
+// Bridge publishes ManaPool events to a Publisher.
+type Bridge struct {
+	publisher Publisher
+	prefix    string
+	onError   func(error)
+	now       func() time.Time
+}
+
+// Option configures a Bridge.
+type Option func(*Bridge)
+
+// WithSubjectPrefix sets the prefix of message subjects.
+//
+// Default: DefaultSubjectPrefix
+func WithSubjectPrefix(prefix string) Option {
+	return func(b *Bridge) {
+		b.prefix = prefix
+	}
+}
+
+// WithOnError sets a function called with each event the watcher methods
+// fail to publish, and with each watcher poll error. Without it those
+// errors are dropped.
+func WithOnError(onError func(error)) Option {
+	return func(b *Bridge) {
+		b.onError = onError
+	}
+}
+
+// NewBridge creates a bridge that publishes to publisher.
+func NewBridge(publisher Publisher, opts ...Option) *Bridge {
+	bridge := &Bridge{publisher: publisher, prefix: DefaultSubjectPrefix, now: time.Now}
+	for _, opt := range opts {
+		opt(bridge)
+	}
+	return bridge
+}
+
+// HandleWebhook publishes a webhook event. It is a
+// manapool.WebhookHandlerFunc, so it can serve webhooks directly or run
+// inside a manapool.WebhookConsumer for at-least-once publishing.
+func (b *Bridge) HandleWebhook(ctx context.Context, event *manapool.WebhookEvent) error {
+	at := event.SignedAt
+	if at.IsZero() {
+		at = b.now()
+	}
+	return b.publish(ctx, "webhook."+event.Topic, event.ID, at, event.Replay, event.Body)
+}
+
+// PublishInventoryEvent publishes one inventory change. InventoryWatchError
+// events are not published.
+func (b *Bridge) PublishInventoryEvent(ctx context.Context, event manapool.InventoryEvent) error {
+	if event.Type == manapool.InventoryWatchError {
+		return nil
+	}
+	data, err := json.Marshal(InventoryChange{Item: event.Item, Previous: event.Previous})
+	if err != nil {
+		return fmt.Errorf("failed to encode inventory event: %w", err)
+	}
+	return b.publish(ctx, "inventory."+string(event.Type), event.Item.ID, b.now(), false, data)
+}
+
+// PublishOrder publishes a new order.
+func (b *Bridge) PublishOrder(ctx context.Context, order manapool.OrderSummary) error {
+	data, err := json.Marshal(order)
+	if err != nil {
+		return fmt.Errorf("failed to encode order: %w", err)
+	}
+	return b.publish(ctx, "order.created", order.ID, order.CreatedAt.Time, false, data)
+}
+
+// PublishInventory publishes every event from a WatchInventory channel
+// until it closes or ctx is cancelled. Publish failures and watcher errors
+// go to the WithOnError function; the event is not retried.
+func (b *Bridge) PublishInventory(ctx context.Context, events <-chan manapool.InventoryEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if event.Type == manapool.InventoryWatchError {
+				b.reportError(event.Err)
+				continue
+			}
+			if err := b.PublishInventoryEvent(ctx, event); err != nil {
+				b.reportError(err)
+			}
+		}
+	}
+}
+
+// PublishOrders publishes every order from a WatchOrders channel until it
+// closes or ctx is cancelled. Publish failures go to the WithOnError
+// function; the order is not retried.
+func (b *Bridge) PublishOrders(ctx context.Context, orders <-chan manapool.OrderSummary) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case order, ok := <-orders:
+			if !ok {
+				return
+			}
+			if err := b.PublishOrder(ctx, order); err != nil {
+				b.reportError(err)
+			}
+		}
+	}
+}
+
+func (b *Bridge) publish(ctx context.Context, eventType, key string, at time.Time, replay bool, data []byte) error {
+	body, err := json.Marshal(Envelope{Type: eventType, ID: key, Time: at.UTC(), Replay: replay, Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+	subject := eventType
+	if b.prefix != "" {
+		subject = b.prefix + "." + eventType
+	}
+	if err := b.publisher.Publish(ctx, Message{Subject: subject, Key: key, Data: body}); err != nil {
+		return fmt.Errorf("failed to publish %s %s: %w", subject, key, err)
+	}
+	return nil
+}
+
+func (b *Bridge) reportError(err error) {
+	if b.onError != nil {
+		b.onError(err)
+	}
+}
@@ -0,0 +1,143 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+// recorder is a Publisher that keeps messages.
+type recorder struct {
+	mu       sync.Mutex
+	messages []Message
+	fail     bool
+}
+
+func (r *recorder) Publish(_ context.Context, msg Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail {
+		return errors.New("bus unavailable")
+	}
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+func decodeEnvelope(t *testing.T, msg Message) Envelope {
+	t.Helper()
+	var envelope Envelope
+	if err := json.Unmarshal(msg.Data, &envelope); err != nil {
+		t.Fatalf("decode %s: %v", msg.Subject, err)
+	}
+	return envelope
+}
+
+func TestBridge_HandleWebhook(t *testing.T) {
+	pub := &recorder{}
+	bridge := NewBridge(pub, WithSubjectPrefix("shop"))
+	signedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	event := &manapool.WebhookEvent{ID: "order_created:o1", Topic: manapool.WebhookTopicOrderCreated, SignedAt: signedAt,
+		Body: json.RawMessage(`{"order":{"id":"o1"}}`), Replay: true}
+	if err := bridge.HandleWebhook(context.Background(), event); err != nil {
+		t.Fatalf("HandleWebhook() error = %v", err)
+	}
+
+	msg := pub.messages[0]
+	if msg.Subject != "shop.webhook.order_created" || msg.Key != "order_created:o1" {
+		t.Errorf("message = %s %s", msg.Subject, msg.Key)
+	}
+	envelope := decodeEnvelope(t, msg)
+	if envelope.Type != "webhook.order_created" || !envelope.Time.Equal(signedAt) || !envelope.Replay ||
+		string(envelope.Data) != `{"order":{"id":"o1"}}` {
+		t.Errorf("envelope = %+v", envelope)
+	}
+}
+
+func TestBridge_PublishInventory(t *testing.T) {
+	pub := &recorder{}
+	var errs []error
+	bridge := NewBridge(pub, WithOnError(func(err error) { errs = append(errs, err) }))
+
+	asOf := manapool.Timestamp{Time: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)}
+	prev := []manapool.InventoryItem{{ID: "a", PriceCents: 100, Quantity: 1, EffectiveAsOf: asOf}}
+	next := []manapool.InventoryItem{
+		{ID: "a", PriceCents: 150, Quantity: 1, EffectiveAsOf: asOf},
+		{ID: "b", PriceCents: 50, Quantity: 2, EffectiveAsOf: asOf},
+	}
+	events := make(chan manapool.InventoryEvent, 4)
+	for _, event := range manapool.DiffInventory(prev, next) {
+		events <- event
+	}
+	events <- manapool.InventoryEvent{Type: manapool.InventoryWatchError, Err: errors.New("poll failed")}
+	close(events)
+
+	bridge.PublishInventory(context.Background(), events)
+
+	var subjects []string
+	for _, msg := range pub.messages {
+		subjects = append(subjects, msg.Subject+" "+msg.Key)
+	}
+	if got := strings.Join(subjects, ","); got != "manapool.inventory.added b,manapool.inventory.price_changed a" {
+		t.Errorf("published = %s", got)
+	}
+	var change InventoryChange
+	if err := json.Unmarshal(decodeEnvelope(t, pub.messages[1]).Data, &change); err != nil {
+		t.Fatal(err)
+	}
+	if change.Item.PriceCents != 150 || change.Previous == nil || change.Previous.PriceCents != 100 {
+		t.Errorf("change = %+v", change)
+	}
+	if len(errs) != 1 || errs[0].Error() != "poll failed" {
+		t.Errorf("errors = %v", errs)
+	}
+}
+
+func TestBridge_PublishOrders(t *testing.T) {
+	pub := &recorder{fail: true}
+	var errs []error
+	bridge := NewBridge(pub, WithOnError(func(err error) { errs = append(errs, err) }))
+
+	orders := make(chan manapool.OrderSummary, 2)
+	orders <- manapool.OrderSummary{ID: "o1"}
+	close(orders)
+	bridge.PublishOrders(context.Background(), orders)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "failed to publish manapool.order.created o1: bus unavailable") {
+		t.Errorf("errors = %v", errs)
+	}
+
+	pub.fail = false
+	created := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)
+	if err := bridge.PublishOrder(context.Background(), manapool.OrderSummary{ID: "o2", CreatedAt: manapool.Timestamp{Time: created}}); err != nil {
+		t.Fatal(err)
+	}
+	if envelope := decodeEnvelope(t, pub.messages[0]); envelope.Type != "order.created" || envelope.ID != "o2" || !envelope.Time.Equal(created) {
+		t.Errorf("envelope = %+v", envelope)
+	}
+
+	// Publishing stops when ctx is cancelled even if the channel stays open.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bridge.PublishOrders(ctx, make(chan manapool.OrderSummary))
+}
+
+func TestPublisherFunc(t *testing.T) {
+	var got Message
+	pub := PublisherFunc(func(_ context.Context, msg Message) error {
+		got = msg
+		return nil
+	})
+	if err := NewBridge(pub, WithSubjectPrefix("")).PublishInventoryEvent(context.Background(),
+		manapool.InventoryEvent{Type: manapool.InventoryRemoved, Item: manapool.InventoryItem{ID: "x"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got.Subject != "inventory.removed" {
+		t.Errorf("subject = %q", got.Subject)
+	}
+}
@@ -0,0 +1,165 @@
+//go:build kafka
+
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/repricah/manapool"
+)
+
+// kafkaJSONContentType is the Kafka REST Proxy v2 content type for JSON
+// keys and values.
+const kafkaJSONContentType = "application/vnd.kafka.json.v2+json"
+
+// KafkaRESTPublisher produces to Kafka through a Kafka REST Proxy, such as
+// Confluent's, using the v2 API. Each message becomes one record with the
+// message key as its key, on the topic named by the message subject. It is
+// safe for concurrent use.
+type KafkaRESTPublisher struct {
+	httpClient *http.Client
+	baseURL    string
+	user       string
+	password   string
+	topic      func(subject string) string
+}
+
+// KafkaOption configures a KafkaRESTPublisher.
+type KafkaOption func(*KafkaRESTPublisher)
+
+// WithKafkaHTTPClient sets a custom HTTP client.
+func WithKafkaHTTPClient(httpClient *http.Client) KafkaOption {
+	return func(p *KafkaRESTPublisher) {
+		p.httpClient = httpClient
+	}
+}
+
+// WithKafkaBasicAuth authenticates to the proxy with HTTP basic auth.
+func WithKafkaBasicAuth(user, password string) KafkaOption {
+	return func(p *KafkaRESTPublisher) {
+		p.user, p.password = user, password
+	}
+}
+
+// WithKafkaTopic maps message subjects to topics, for example to send every
+// event to one topic.
+//
+// Default: the subject is the topic
+func WithKafkaTopic(topic func(subject string) string) KafkaOption {
+	return func(p *KafkaRESTPublisher) {
+		p.topic = topic
+	}
+}
+
+// NewKafkaRESTPublisher creates a publisher for the REST Proxy at baseURL,
+// such as "http://kafka-rest:8082".
+//
+// Example:
+//
+//	kafka := eventbus.NewKafkaRESTPublisher("http://kafka-rest:8082",
+//	    eventbus.WithKafkaTopic(func(string) string { return "manapool-events" }))
+//	bridge := eventbus.NewBridge(kafka)
+func NewKafkaRESTPublisher(baseURL string, opts ...KafkaOption) *KafkaRESTPublisher {
+	p := &KafkaRESTPublisher{
+		httpClient: &http.Client{Timeout: manapool.DefaultTimeout},
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		topic:      func(subject string) string { return subject },
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		Partition int     `json:"partition"`
+		Offset    int64   `json:"offset"`
+		ErrorCode *int    `json:"error_code"`
+		Error     *string `json:"error"`
+	} `json:"offsets"`
+}
+
+type kafkaErrorResponse struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+// Publish implements Publisher.
+func (p *KafkaRESTPublisher) Publish(ctx context.Context, msg Message) error {
+	topic := p.topic(msg.Subject)
+	if topic == "" {
+		return manapool.NewValidationError("subject", fmt.Sprintf("no topic for subject %q", msg.Subject))
+	}
+	if !json.Valid(msg.Data) {
+		return manapool.NewValidationError("data", "message data is not valid JSON")
+	}
+	body, err := json.Marshal(kafkaProduceRequest{Records: []kafkaRecord{{Key: msg.Key, Value: msg.Data}}})
+	if err != nil {
+		return fmt.Errorf("failed to encode kafka request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/topics/"+url.PathEscape(topic), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create kafka request: %w", err)
+	}
+	req.Header.Set("Content-Type", kafkaJSONContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if p.user != "" {
+		req.SetBasicAuth(p.user, p.password)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return manapool.NewNetworkError("failed to reach kafka rest proxy", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return manapool.NewNetworkError("failed to read response body", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &manapool.APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(respBody)), Response: resp}
+		var errResp kafkaErrorResponse
+		if json.Unmarshal(respBody, &errResp) == nil && errResp.Message != "" {
+			apiErr.Message = errResp.Message
+			apiErr.Code = fmt.Sprint(errResp.ErrorCode)
+		}
+		return apiErr
+	}
+
+	var produced kafkaProduceResponse
+	if err := json.Unmarshal(respBody, &produced); err != nil {
+		return fmt.Errorf("failed to decode kafka produce response: %w", err)
+	}
+	for _, offset := range produced.Offsets {
+		if offset.ErrorCode != nil || offset.Error != nil {
+			message := "unknown error"
+			if offset.Error != nil {
+				message = *offset.Error
+			}
+			return fmt.Errorf("kafka rejected record for %s: %s", topic, message)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+//go:build kafka
+
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool"
+)
+
+func TestKafkaRESTPublisher_Publish(t *testing.T) {
+	var gotPath, gotBody, gotType, gotUser string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotPath, gotBody, gotType = r.URL.Path, string(body), r.Header.Get("Content-Type")
+		gotUser, _, _ = r.BasicAuth()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/missing"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error_code":40401,"message":"Topic not found."}`)
+		case strings.HasSuffix(r.URL.Path, "/full"):
+			_, _ = io.WriteString(w, `{"offsets":[{"partition":null,"offset":null,"error_code":1,"error":"record too large"}]}`)
+		default:
+			_, _ = io.WriteString(w, `{"offsets":[{"partition":0,"offset":42,"error_code":null,"error":null}]}`)
+		}
+	}))
+	defer server.Close()
+
+	kafka := NewKafkaRESTPublisher(server.URL+"/", WithKafkaBasicAuth("svc", "pw"),
+		WithKafkaTopic(func(subject string) string { return strings.TrimPrefix(subject, "manapool.") }))
+	ctx := context.Background()
+
+	if err := kafka.Publish(ctx, Message{Subject: "manapool.events", Key: "o1", Data: []byte(`{"id":"o1"}`)}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if gotPath != "/topics/events" || gotType != kafkaJSONContentType || gotUser != "svc" ||
+		gotBody != `{"records":[{"key":"o1","value":{"id":"o1"}}]}` {
+		t.Errorf("request = %s %s %s %s", gotPath, gotType, gotUser, gotBody)
+	}
+
+	err := kafka.Publish(ctx, Message{Subject: "manapool.missing", Data: []byte(`{}`)})
+	var apiErr *manapool.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Code != "40401" || apiErr.Message != "Topic not found." {
+		t.Errorf("missing topic error = %v", err)
+	}
+
+	err = kafka.Publish(ctx, Message{Subject: "manapool.full", Data: []byte(`{}`)})
+	if err == nil || err.Error() != "kafka rejected record for full: record too large" {
+		t.Errorf("rejected error = %v", err)
+	}
+
+	if err := kafka.Publish(ctx, Message{Subject: "manapool.events", Data: []byte(`not json`)}); err == nil {
+		t.Error("expected invalid data error")
+	}
+
+	// A request that cannot be built is not a network error, so callers
+	// that retry network errors do not retry it forever.
+	err = NewKafkaRESTPublisher("http://bad host").Publish(ctx, Message{Subject: "events", Data: []byte(`{}`)})
+	var netErr *manapool.NetworkError
+	if err == nil || errors.As(err, &netErr) {
+		t.Errorf("bad URL error = %v, want a plain error", err)
+	}
+}
@@ -0,0 +1,220 @@
+//go:build nats
+
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+// natsDialTimeout bounds connecting when ctx has no deadline.
+const natsDialTimeout = 10 * time.Second
+
+// NATSPublisher publishes to a NATS server with the core protocol. Each
+// Publish is confirmed with a PING round trip, so an error means the server
+// did not accept the message. The connection is opened on first use and
+// reopened after a failure. It is safe for concurrent use.
+type NATSPublisher struct {
+	addr      string
+	name      string
+	user      string
+	password  string
+	token     string
+	tlsConfig *tls.Config
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NATSOption configures a NATSPublisher.
+type NATSOption func(*NATSPublisher)
+
+// WithNATSUserPassword authenticates with a user and password.
+func WithNATSUserPassword(user, password string) NATSOption {
+	return func(p *NATSPublisher) {
+		p.user, p.password = user, password
+	}
+}
+
+// WithNATSToken authenticates with a token.
+func WithNATSToken(token string) NATSOption {
+	return func(p *NATSPublisher) {
+		p.token = token
+	}
+}
+
+// WithNATSTLS upgrades the connection to TLS after the server's greeting.
+func WithNATSTLS(config *tls.Config) NATSOption {
+	return func(p *NATSPublisher) {
+		p.tlsConfig = config
+	}
+}
+
+// WithNATSName sets the connection name shown in server monitoring.
+//
+// Default: "manapool-go"
+func WithNATSName(name string) NATSOption {
+	return func(p *NATSPublisher) {
+		p.name = name
+	}
+}
+
+// NewNATSPublisher creates a publisher for the server at addr, such as
+// "nats://localhost:4222" or "localhost:4222".
+//
+// Example:
+//
+//	nats := eventbus.NewNATSPublisher("nats://bus.internal:4222", eventbus.WithNATSToken(token))
+//	defer nats.Close()
+//	bridge := eventbus.NewBridge(nats)
+func NewNATSPublisher(addr string, opts ...NATSOption) *NATSPublisher {
+	p := &NATSPublisher{addr: strings.TrimPrefix(addr, "nats://"), name: "manapool-go"}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Publish implements Publisher. The message key is not sent; NATS core
+// messages have no key.
+func (p *NATSPublisher) Publish(ctx context.Context, msg Message) error {
+	if msg.Subject == "" || strings.ContainsAny(msg.Subject, " \t\r\n") {
+		return manapool.NewValidationError("subject", fmt.Sprintf("%q is not a valid NATS subject", msg.Subject))
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.connect(ctx); err != nil {
+		return err
+	}
+	if err := p.publish(ctx, msg); err != nil {
+		p.closeConn()
+		return err
+	}
+	return nil
+}
+
+// Close closes the connection, if open.
+func (p *NATSPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closeConn()
+}
+
+func (p *NATSPublisher) closeConn() error {
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn, p.reader = nil, nil
+	return err
+}
+
+// connect dials and greets the server if not connected.
+func (p *NATSPublisher) connect(ctx context.Context) error {
+	if p.conn != nil {
+		return nil
+	}
+
+	dialer := net.Dialer{Timeout: natsDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return manapool.NewNetworkError("failed to connect to nats", err)
+	}
+	p.setDeadline(ctx, conn)
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err == nil && !strings.HasPrefix(line, "INFO ") {
+		err = fmt.Errorf("unexpected greeting %q", strings.TrimSpace(line))
+	}
+	if err != nil {
+		_ = conn.Close()
+		return manapool.NewNetworkError("failed to read nats server info", err)
+	}
+	if p.tlsConfig != nil {
+		tlsConn := tls.Client(conn, p.tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return manapool.NewNetworkError("failed nats tls handshake", err)
+		}
+		conn, reader = tlsConn, bufio.NewReader(tlsConn)
+	}
+
+	options, err := json.Marshal(map[string]any{
+		"verbose":    false,
+		"pedantic":   false,
+		"lang":       "go",
+		"version":    manapool.Version,
+		"name":       p.name,
+		"user":       p.user,
+		"pass":       p.password,
+		"auth_token": p.token,
+	})
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to encode nats connect options: %w", err)
+	}
+	p.conn, p.reader = conn, reader
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", options); err != nil {
+		p.closeConn()
+		return manapool.NewNetworkError("failed to send nats connect", err)
+	}
+	if err := p.awaitPong(); err != nil {
+		p.closeConn()
+		return err
+	}
+	return nil
+}
+
+// publish sends one message and waits for the server to confirm it.
+func (p *NATSPublisher) publish(ctx context.Context, msg Message) error {
+	p.setDeadline(ctx, p.conn)
+	frame := fmt.Sprintf("PUB %s %d\r\n%s\r\nPING\r\n", msg.Subject, len(msg.Data), msg.Data)
+	if _, err := p.conn.Write([]byte(frame)); err != nil {
+		return manapool.NewNetworkError("failed to publish to nats", err)
+	}
+	return p.awaitPong()
+}
+
+// awaitPong reads until the reply to our PING, answering server PINGs and
+// failing on -ERR.
+func (p *NATSPublisher) awaitPong() error {
+	for {
+		line, err := p.reader.ReadString('\n')
+		if err != nil {
+			return manapool.NewNetworkError("failed to read from nats", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return manapool.NewNetworkError("failed to answer nats ping", err)
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			message := strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'")
+			return fmt.Errorf("nats server error: %s", message)
+		}
+	}
+}
+
+// setDeadline applies ctx's deadline, or the dial timeout, to conn.
+func (p *NATSPublisher) setDeadline(ctx context.Context, conn net.Conn) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(natsDialTimeout)
+	}
+	_ = conn.SetDeadline(deadline)
+}
@@ -0,0 +1,97 @@
+//go:build nats
+
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+)
+
+// fakeNATS accepts one connection, records the frames it receives, and
+// replies -ERR to subjects starting with "deny.".
+func fakeNATS(t *testing.T) (addr string, frames chan string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	frames = make(chan string, 16)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = fmt.Fprint(conn, "INFO {\"server_id\":\"test\"}\r\n")
+		reader := bufio.NewReader(conn)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			switch {
+			case line == "PING":
+				// Exercise the client's handling of server pings first.
+				_, _ = fmt.Fprint(conn, "PING\r\nPONG\r\n")
+			case strings.HasPrefix(line, "PUB deny."):
+				payload, _ := reader.ReadString('\n')
+				frames <- line + " " + strings.TrimSpace(payload)
+				_, _ = fmt.Fprint(conn, "-ERR 'Permissions Violation for Publish'\r\n")
+				return
+			case strings.HasPrefix(line, "PUB "):
+				payload, _ := reader.ReadString('\n')
+				frames <- line + " " + strings.TrimSpace(payload)
+			case line == "PONG":
+			default:
+				frames <- line
+			}
+		}
+	}()
+	return listener.Addr().String(), frames
+}
+
+func TestNATSPublisher_Publish(t *testing.T) {
+	addr, frames := fakeNATS(t)
+	nats := NewNATSPublisher("nats://"+addr, WithNATSToken("s3cret"), WithNATSName("test"))
+	defer nats.Close()
+
+	ctx := context.Background()
+	if err := nats.Publish(ctx, Message{Subject: "manapool.order.created", Key: "o1", Data: []byte(`{"id":"o1"}`)}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if connect := <-frames; !strings.Contains(connect, `"auth_token":"s3cret"`) || !strings.Contains(connect, `"name":"test"`) {
+		t.Errorf("connect = %s", connect)
+	}
+	if pub := <-frames; pub != `PUB manapool.order.created 11 {"id":"o1"}` {
+		t.Errorf("pub = %s", pub)
+	}
+
+	err := nats.Publish(ctx, Message{Subject: "deny.me", Data: []byte(`{}`)})
+	if err == nil || err.Error() != "nats server error: Permissions Violation for Publish" {
+		t.Errorf("denied error = %v", err)
+	}
+	if err := nats.Publish(ctx, Message{Subject: "bad subject", Data: []byte(`{}`)}); err == nil {
+		t.Error("expected invalid subject error")
+	}
+}
+
+func TestNATSPublisher_ConnectFailure(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	err = NewNATSPublisher(addr).Publish(context.Background(), Message{Subject: "x", Data: []byte(`{}`)})
+	if err == nil || !strings.Contains(err.Error(), "failed to connect to nats") {
+		t.Errorf("error = %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+// Package fees computes ManaPool seller fees and net proceeds.
+//
+// Fee rates change over time, so a Calculator holds a list of Schedules,
+// each effective from a given date, and picks the one in force when an order
+// was placed. The package ships no built-in rates: configure schedules from
+// your seller agreement, and check them against the fee_cents ManaPool
+// reports on each order with Reported.
+//
+// # Basic Usage
+//
+//	calc, err := fees.NewCalculator(
+//	    fees.Schedule{CommissionBasisPoints: 790, ProcessingBasisPoints: 290, ProcessingFixedCents: 30},
+//	    fees.Schedule{EffectiveFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), CommissionBasisPoints: 850},
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	b, err := calc.ForListing(1500, time.Now())
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("fees %d, net %d\n", b.FeeCents, b.NetCents)
+package fees
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+// Schedule is a fee schedule in force from EffectiveFrom until the next
+// schedule starts. Rates are in basis points: 100 is 1%.
+type Schedule struct {
+	// EffectiveFrom is when the schedule takes effect. The zero time means
+	// it applies to all earlier orders.
+	EffectiveFrom time.Time
+
+	// CommissionBasisPoints is the marketplace commission on the item
+	// subtotal.
+	CommissionBasisPoints int
+
+	// CommissionOnShipping also charges commission on shipping.
+	CommissionOnShipping bool
+
+	// ProcessingBasisPoints is the payment processing rate on the order
+	// total (subtotal plus shipping).
+	ProcessingBasisPoints int
+
+	// ProcessingFixedCents is the fixed payment processing fee per order.
+	ProcessingFixedCents int
+}
+
+// Validate checks that the schedule's rates are in range.
+func (s Schedule) Validate() error {
+	if s.CommissionBasisPoints < 0 || s.CommissionBasisPoints > 10000 {
+		return manapool.NewValidationError("commission_basis_points", "must be between 0 and 10000")
+	}
+	if s.ProcessingBasisPoints < 0 || s.ProcessingBasisPoints > 10000 {
+		return manapool.NewValidationError("processing_basis_points", "must be between 0 and 10000")
+	}
+	if s.ProcessingFixedCents < 0 {
+		return manapool.NewValidationError("processing_fixed_cents", "cannot be negative")
+	}
+	return nil
+}
+
+// Compute returns the fee breakdown for an order with the given subtotal and
+// shipping under this schedule. Percentages are rounded half up to the cent.
+func (s Schedule) Compute(subtotalCents, shippingCents int) Breakdown {
+	gross := subtotalCents + shippingCents
+
+	commissionBase := subtotalCents
+	if s.CommissionOnShipping {
+		commissionBase = gross
+	}
+
+	b := Breakdown{
+		SubtotalCents:   subtotalCents,
+		ShippingCents:   shippingCents,
+		GrossCents:      gross,
+		CommissionCents: basisPoints(commissionBase, s.CommissionBasisPoints),
+		Schedule:        s,
+	}
+	if gross > 0 {
+		b.ProcessingCents = basisPoints(gross, s.ProcessingBasisPoints) + s.ProcessingFixedCents
+	}
+	b.FeeCents = b.CommissionCents + b.ProcessingCents
+	b.NetCents = gross - b.FeeCents
+	return b
+}
+
+// Breakdown itemizes the fees on an order or listing.
+type Breakdown struct {
+	SubtotalCents int
+	ShippingCents int
+
+	// GrossCents is what the buyer paid: subtotal plus shipping
+	GrossCents int
+
+	CommissionCents int
+	ProcessingCents int
+
+	// FeeCents is the total of all fees
+	FeeCents int
+
+	// NetCents is the seller's payout: gross minus fees
+	NetCents int
+
+	// Schedule is the schedule the fees were computed with
+	Schedule Schedule
+}
+
+// String renders a one-line summary of the breakdown.
+func (b Breakdown) String() string {
+	return fmt.Sprintf("gross %d, commission %d, processing %d, net %d",
+		b.GrossCents, b.CommissionCents, b.ProcessingCents, b.NetCents)
+}
+
+// Calculator computes fees using the schedule in force at a given time.
+type Calculator struct {
+	schedules []Schedule
+}
+
+// NewCalculator creates a calculator from one or more schedules. Schedules
+// may be given in any order, but no two may share an EffectiveFrom.
+func NewCalculator(schedules ...Schedule) (*Calculator, error) {
+	if len(schedules) == 0 {
+		return nil, manapool.NewValidationError("schedules", "at least one schedule is required")
+	}
+
+	sorted := append([]Schedule(nil), schedules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].EffectiveFrom.Before(sorted[j].EffectiveFrom)
+	})
+	for i, s := range sorted {
+		if err := s.Validate(); err != nil {
+			return nil, err
+		}
+		if i > 0 && s.EffectiveFrom.Equal(sorted[i-1].EffectiveFrom) {
+			return nil, manapool.NewValidationError("schedules",
+				fmt.Sprintf("two schedules take effect at %s", s.EffectiveFrom.Format(time.RFC3339)))
+		}
+	}
+	return &Calculator{schedules: sorted}, nil
+}
+
+// ScheduleAt returns the schedule in force at t. It fails if t is before
+// the earliest schedule.
+func (c *Calculator) ScheduleAt(t time.Time) (Schedule, error) {
+	i := sort.Search(len(c.schedules), func(i int) bool {
+		return c.schedules[i].EffectiveFrom.After(t)
+	})
+	if i == 0 {
+		return Schedule{}, manapool.NewValidationError("time",
+			fmt.Sprintf("no fee schedule in force at %s", t.Format(time.RFC3339)))
+	}
+	return c.schedules[i-1], nil
+}
+
+// ForListing returns the fees on selling one item at priceCents at time t,
+// with no shipping.
+func (c *Calculator) ForListing(priceCents int, t time.Time) (Breakdown, error) {
+	s, err := c.ScheduleAt(t)
+	if err != nil {
+		return Breakdown{}, err
+	}
+	return s.Compute(priceCents, 0), nil
+}
+
+// ForOrder returns the fees on order using the schedule in force when it was
+// created and the subtotal and shipping from its payment.
+func (c *Calculator) ForOrder(order manapool.OrderDetails) (Breakdown, error) {
+	s, err := c.ScheduleAt(order.CreatedAt.Time)
+	if err != nil {
+		return Breakdown{}, fmt.Errorf("failed to compute fees for order %s: %w", order.ID, err)
+	}
+	return s.Compute(order.Payment.SubtotalCents, order.Payment.ShippingCents), nil
+}
+
+// Reported returns the breakdown ManaPool reported in the order's payment.
+// Reported fees are not itemized, so CommissionCents and ProcessingCents are
+// zero and Schedule is empty.
+func Reported(order manapool.OrderDetails) Breakdown {
+	p := order.Payment
+	return Breakdown{
+		SubtotalCents: p.SubtotalCents,
+		ShippingCents: p.ShippingCents,
+		GrossCents:    p.TotalCents,
+		FeeCents:      p.FeeCents,
+		NetCents:      p.NetCents,
+	}
+}
+
+// basisPoints returns cents * bps / 10000, rounded half up.
+func basisPoints(cents, bps int) int {
+	return (cents*bps + 5000) / 10000
+}
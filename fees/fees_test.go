@@ -0,0 +1,106 @@
+package fees
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+var cutover = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func testCalculator(t *testing.T) *Calculator {
+	t.Helper()
+	calc, err := NewCalculator(
+		Schedule{EffectiveFrom: cutover, CommissionBasisPoints: 1000, CommissionOnShipping: true},
+		Schedule{CommissionBasisPoints: 790, ProcessingBasisPoints: 290, ProcessingFixedCents: 30},
+	)
+	if err != nil {
+		t.Fatalf("NewCalculator() error = %v", err)
+	}
+	return calc
+}
+
+func TestSchedule_Compute(t *testing.T) {
+	s := Schedule{CommissionBasisPoints: 790, ProcessingBasisPoints: 290, ProcessingFixedCents: 30}
+
+	b := s.Compute(1000, 150)
+	// commission 7.9% of 1000 = 79; processing 2.9% of 1150 = 33.35 -> 33, +30
+	want := Breakdown{SubtotalCents: 1000, ShippingCents: 150, GrossCents: 1150, CommissionCents: 79, ProcessingCents: 63, FeeCents: 142, NetCents: 1008, Schedule: s}
+	if b != want {
+		t.Errorf("Compute() = %+v, want %+v", b, want)
+	}
+	if b.String() != "gross 1150, commission 79, processing 63, net 1008" {
+		t.Errorf("String() = %s", b)
+	}
+
+	// Half-cent amounts round up.
+	if got := (Schedule{CommissionBasisPoints: 50}).Compute(100, 0).CommissionCents; got != 1 {
+		t.Errorf("0.5%% of 100 = %d, want 1", got)
+	}
+	// Free orders pay no fixed processing fee.
+	if got := s.Compute(0, 0); got.FeeCents != 0 || got.NetCents != 0 {
+		t.Errorf("Compute(0, 0) = %+v", got)
+	}
+}
+
+func TestCalculator_Schedules(t *testing.T) {
+	calc := testCalculator(t)
+
+	before, err := calc.ForListing(1000, cutover.Add(-time.Second))
+	if err != nil || before.CommissionCents != 79 || before.ProcessingCents != 59 {
+		t.Errorf("ForListing(before) = %+v, %v", before, err)
+	}
+	after, err := calc.ForListing(1000, cutover)
+	if err != nil || after.CommissionCents != 100 || after.ProcessingCents != 0 || after.NetCents != 900 {
+		t.Errorf("ForListing(after) = %+v, %v", after, err)
+	}
+
+	order := manapool.OrderDetails{
+		OrderSummary: manapool.OrderSummary{ID: "o1", CreatedAt: manapool.Timestamp{Time: cutover.Add(time.Hour)}},
+		Payment:      manapool.OrderPayment{SubtotalCents: 2000, ShippingCents: 500, TotalCents: 2500, FeeCents: 250, NetCents: 2250},
+	}
+	computed, err := calc.ForOrder(order)
+	if err != nil {
+		t.Fatalf("ForOrder() error = %v", err)
+	}
+	reported := Reported(order)
+	if computed.FeeCents != reported.FeeCents || computed.NetCents != reported.NetCents || reported.GrossCents != 2500 {
+		t.Errorf("ForOrder() = %+v, Reported() = %+v", computed, reported)
+	}
+}
+
+func TestCalculator_Errors(t *testing.T) {
+	var validationErr *manapool.ValidationError
+
+	if _, err := NewCalculator(); !errors.As(err, &validationErr) {
+		t.Errorf("NewCalculator() error = %v", err)
+	}
+	if _, err := NewCalculator(Schedule{}, Schedule{CommissionBasisPoints: 5}); !errors.As(err, &validationErr) ||
+		!strings.Contains(err.Error(), "two schedules") {
+		t.Errorf("NewCalculator(duplicate) error = %v", err)
+	}
+	for _, s := range []Schedule{
+		{CommissionBasisPoints: -1},
+		{ProcessingBasisPoints: 10001},
+		{ProcessingFixedCents: -1},
+	} {
+		if _, err := NewCalculator(s); !errors.As(err, &validationErr) {
+			t.Errorf("NewCalculator(%+v) error = %v", s, err)
+		}
+	}
+
+	calc, err := NewCalculator(Schedule{EffectiveFrom: cutover})
+	if err != nil {
+		t.Fatalf("NewCalculator() error = %v", err)
+	}
+	if _, err := calc.ForListing(100, cutover.Add(-time.Hour)); !errors.As(err, &validationErr) {
+		t.Errorf("ForListing(before first schedule) error = %v", err)
+	}
+	order := manapool.OrderDetails{OrderSummary: manapool.OrderSummary{ID: "old"}}
+	if _, err := calc.ForOrder(order); err == nil || !strings.Contains(err.Error(), "order old") {
+		t.Errorf("ForOrder() error = %v", err)
+	}
+}
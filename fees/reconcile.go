@@ -0,0 +1,272 @@
+package fees
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+// Payout is a transfer to the seller covering a set of orders. The API does
+// not list payouts, so build these from the dashboard payout export or bank
+// statements.
+type Payout struct {
+	ID          string
+	PaidAt      time.Time
+	AmountCents int
+	OrderIDs    []string
+}
+
+// Charge is a deduction taken from a payout, such as a seller charge on a
+// reported order.
+type Charge struct {
+	PayoutID string
+	OrderID  string
+	Cents    int
+}
+
+// ChargesFromReports extracts the seller charges that were applied to a
+// payout from order reports (see Client.GetSellerOrderReports). Charges
+// without an amount or payout are skipped, as are rescinded reports.
+func ChargesFromReports(reports []manapool.OrderReport) []Charge {
+	var charges []Charge
+	for _, report := range reports {
+		if report.OrderReportedIssues.Rescinded {
+			continue
+		}
+		for _, c := range report.OrderReportedIssues.Charges {
+			if c.SellerChargeCents == nil || c.PayoutID == nil || *c.SellerChargeCents == 0 {
+				continue
+			}
+			charges = append(charges, Charge{PayoutID: *c.PayoutID, OrderID: report.OrderID, Cents: *c.SellerChargeCents})
+		}
+	}
+	return charges
+}
+
+// DiscrepancyType classifies a reconciliation problem.
+type DiscrepancyType string
+
+const (
+	// MissingFromPayouts is a paid order that is not in any payout.
+	MissingFromPayouts DiscrepancyType = "missing_from_payouts"
+
+	// UnknownOrder is an order listed in a payout but not among the orders
+	// being reconciled.
+	UnknownOrder DiscrepancyType = "unknown_order"
+
+	// DuplicatePayout is an order listed in more than one payout.
+	DuplicatePayout DiscrepancyType = "duplicate_payout"
+
+	// AmountMismatch is a payout whose amount differs from its orders' net
+	// proceeds less charges, which indicates an unexpected adjustment.
+	AmountMismatch DiscrepancyType = "amount_mismatch"
+
+	// FeeMismatch is an order whose reported fee differs from the fee
+	// computed with ReconcileOptions.Calculator.
+	FeeMismatch DiscrepancyType = "fee_mismatch"
+)
+
+// Discrepancy is one reconciliation problem.
+type Discrepancy struct {
+	Type     DiscrepancyType
+	PayoutID string
+	OrderID  string
+
+	// ExpectedCents and ActualCents are the amounts compared, where relevant
+	ExpectedCents int
+	ActualCents   int
+
+	Message string
+}
+
+// PayoutLine is the reconciliation of one payout.
+type PayoutLine struct {
+	Payout Payout
+
+	// NetCents is the sum of the known orders' reported net proceeds
+	NetCents int
+
+	// ChargesCents is the sum of charges taken from the payout
+	ChargesCents int
+
+	// ExpectedCents is NetCents minus ChargesCents
+	ExpectedCents int
+
+	// DifferenceCents is the payout amount minus ExpectedCents
+	DifferenceCents int
+}
+
+// ReconcileOptions configures Reconcile.
+type ReconcileOptions struct {
+	// Calculator, if set, recomputes each order's fee and reports
+	// differences from the fee ManaPool reported.
+	Calculator *Calculator
+
+	// Charges are deductions taken from payouts.
+	Charges []Charge
+
+	// ToleranceCents is the largest difference in a payout amount or fee that
+	// is not reported (default: 0).
+	ToleranceCents int
+}
+
+// Reconciliation is the result of Reconcile.
+type Reconciliation struct {
+	Payouts       []PayoutLine
+	Discrepancies []Discrepancy
+}
+
+// OK reports whether there are no discrepancies.
+func (r *Reconciliation) OK() bool {
+	return len(r.Discrepancies) == 0
+}
+
+// Count returns the number of discrepancies of the given type.
+func (r *Reconciliation) Count(t DiscrepancyType) int {
+	n := 0
+	for _, d := range r.Discrepancies {
+		if d.Type == t {
+			n++
+		}
+	}
+	return n
+}
+
+// reconcileCSVHeader is the header line written by WriteCSV.
+var reconcileCSVHeader = []string{"Type", "Payout ID", "Order ID", "Expected", "Actual", "Difference", "Message"}
+
+// WriteCSV writes the discrepancies as CSV, including the header line.
+// Amounts are in dollars, such as "12.50".
+func (r *Reconciliation) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(reconcileCSVHeader); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	for _, d := range r.Discrepancies {
+		record := []string{
+			string(d.Type),
+			d.PayoutID,
+			d.OrderID,
+			manapool.FormatCents(d.ExpectedCents),
+			manapool.FormatCents(d.ActualCents),
+			manapool.FormatCents(d.ActualCents - d.ExpectedCents),
+			d.Message,
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write csv row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
+
+// Reconcile joins payouts with their orders and charges and reports
+// discrepancies: paid orders missing from every payout, payouts listing
+// unknown orders or orders already paid, payout amounts that differ from the
+// orders' reported net proceeds less charges, and, with a Calculator, fees
+// that differ from the configured schedule.
+//
+// orders should cover the payouts' period. Refunded orders are not expected
+// in a payout.
+//
+// Example:
+//
+//	rec := fees.Reconcile(payouts, orders, fees.ReconcileOptions{Calculator: calc})
+//	if !rec.OK() {
+//	    _ = rec.WriteCSV(os.Stdout)
+//	}
+func Reconcile(payouts []Payout, orders []manapool.OrderDetails, opts ReconcileOptions) *Reconciliation {
+	rec := &Reconciliation{}
+	add := func(d Discrepancy) {
+		rec.Discrepancies = append(rec.Discrepancies, d)
+	}
+
+	byID := make(map[string]manapool.OrderDetails, len(orders))
+	for _, order := range orders {
+		byID[order.ID] = order
+	}
+
+	charged := make(map[string]int)
+	for _, c := range opts.Charges {
+		charged[c.PayoutID] += c.Cents
+	}
+
+	paidIn := make(map[string]string)
+	for _, payout := range payouts {
+		line := PayoutLine{Payout: payout, ChargesCents: charged[payout.ID]}
+		for _, id := range payout.OrderIDs {
+			if first, ok := paidIn[id]; ok {
+				add(Discrepancy{Type: DuplicatePayout, PayoutID: payout.ID, OrderID: id,
+					Message: fmt.Sprintf("order already paid in payout %s", first)})
+				continue
+			}
+			paidIn[id] = payout.ID
+
+			order, ok := byID[id]
+			if !ok {
+				add(Discrepancy{Type: UnknownOrder, PayoutID: payout.ID, OrderID: id,
+					Message: "order is not among the reconciled orders"})
+				continue
+			}
+			line.NetCents += order.Payment.NetCents
+		}
+		line.ExpectedCents = line.NetCents - line.ChargesCents
+		line.DifferenceCents = payout.AmountCents - line.ExpectedCents
+		if abs(line.DifferenceCents) > opts.ToleranceCents {
+			add(Discrepancy{Type: AmountMismatch, PayoutID: payout.ID,
+				ExpectedCents: line.ExpectedCents, ActualCents: payout.AmountCents,
+				Message: fmt.Sprintf("payout differs from order net less charges by %s", manapool.FormatCents(line.DifferenceCents))})
+		}
+		rec.Payouts = append(rec.Payouts, line)
+	}
+
+	for _, order := range orders {
+		if _, ok := paidIn[order.ID]; !ok && order.FulfillmentStatus() != manapool.OrderStatusRefunded {
+			add(Discrepancy{Type: MissingFromPayouts, OrderID: order.ID, ExpectedCents: order.Payment.NetCents,
+				Message: "order is not in any payout"})
+		}
+		if opts.Calculator == nil {
+			continue
+		}
+		computed, err := opts.Calculator.ForOrder(order)
+		if err != nil {
+			add(Discrepancy{Type: FeeMismatch, PayoutID: paidIn[order.ID], OrderID: order.ID,
+				ActualCents: order.Payment.FeeCents, Message: err.Error()})
+			continue
+		}
+		if abs(computed.FeeCents-order.Payment.FeeCents) > opts.ToleranceCents {
+			add(Discrepancy{Type: FeeMismatch, PayoutID: paidIn[order.ID], OrderID: order.ID,
+				ExpectedCents: computed.FeeCents, ActualCents: order.Payment.FeeCents,
+				Message: "reported fee differs from the fee schedule"})
+		}
+	}
+
+	order := map[DiscrepancyType]int{MissingFromPayouts: 0, UnknownOrder: 1, DuplicatePayout: 2, AmountMismatch: 3, FeeMismatch: 4}
+	sort.SliceStable(rec.Discrepancies, func(i, j int) bool {
+		a, b := rec.Discrepancies[i], rec.Discrepancies[j]
+		if a.Type != b.Type {
+			return order[a.Type] < order[b.Type]
+		}
+		if a.PayoutID != b.PayoutID {
+			return a.PayoutID < b.PayoutID
+		}
+		return a.OrderID < b.OrderID
+	})
+	return rec
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
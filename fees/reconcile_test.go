@@ -0,0 +1,123 @@
+package fees
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+func paidOrder(id string, net, fee int) manapool.OrderDetails {
+	return manapool.OrderDetails{
+		OrderSummary: manapool.OrderSummary{ID: id, CreatedAt: manapool.Timestamp{Time: cutover.Add(time.Hour)}},
+		Payment:      manapool.OrderPayment{SubtotalCents: net + fee, TotalCents: net + fee, FeeCents: fee, NetCents: net},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	refunded := paidOrder("refunded", 500, 50)
+	status := string(manapool.OrderStatusRefunded)
+	refunded.LatestFulfillmentStatus = &status
+
+	orders := []manapool.OrderDetails{
+		paidOrder("a", 900, 100),
+		paidOrder("b", 1800, 200),
+		paidOrder("c", 450, 60), // fee should be 51
+		paidOrder("unpaid", 900, 100),
+		refunded,
+	}
+	payouts := []Payout{
+		{ID: "p1", AmountCents: 2700 - 300, OrderIDs: []string{"a", "b"}},
+		{ID: "p2", AmountCents: 460, OrderIDs: []string{"c", "a", "ghost"}},
+	}
+	charge, payoutID := 300, "p1"
+	charges := ChargesFromReports([]manapool.OrderReport{
+		{OrderID: "b", OrderReportedIssues: manapool.OrderReportedIssues{Charges: []manapool.OrderReportedCharge{
+			{SellerChargeCents: &charge, PayoutID: &payoutID}, {SellerChargeCents: &charge},
+		}}},
+		{OrderID: "a", OrderReportedIssues: manapool.OrderReportedIssues{Rescinded: true, Charges: []manapool.OrderReportedCharge{
+			{SellerChargeCents: &charge, PayoutID: &payoutID},
+		}}},
+	})
+	if len(charges) != 1 || charges[0] != (Charge{PayoutID: "p1", OrderID: "b", Cents: 300}) {
+		t.Fatalf("ChargesFromReports() = %+v", charges)
+	}
+
+	rec := Reconcile(payouts, orders, ReconcileOptions{
+		Calculator:     testCalculator(t),
+		Charges:        charges,
+		ToleranceCents: 5,
+	})
+
+	if len(rec.Payouts) != 2 || rec.Payouts[0].ExpectedCents != 2400 || rec.Payouts[0].DifferenceCents != 0 ||
+		rec.Payouts[1].NetCents != 450 || rec.Payouts[1].DifferenceCents != 10 {
+		t.Errorf("Payouts = %+v", rec.Payouts)
+	}
+
+	want := []struct {
+		typ    DiscrepancyType
+		payout string
+		order  string
+	}{
+		{MissingFromPayouts, "", "unpaid"},
+		{UnknownOrder, "p2", "ghost"},
+		{DuplicatePayout, "p2", "a"},
+		{AmountMismatch, "p2", ""},
+		{FeeMismatch, "p2", "c"},
+	}
+	if len(rec.Discrepancies) != len(want) {
+		t.Fatalf("Discrepancies = %+v", rec.Discrepancies)
+	}
+	for i, w := range want {
+		d := rec.Discrepancies[i]
+		if d.Type != w.typ || d.PayoutID != w.payout || d.OrderID != w.order {
+			t.Errorf("discrepancy %d = %+v, want %s %s %s", i, d, w.typ, w.payout, w.order)
+		}
+	}
+	if rec.OK() || rec.Count(FeeMismatch) != 1 {
+		t.Errorf("OK() = %v, Count(FeeMismatch) = %d", rec.OK(), rec.Count(FeeMismatch))
+	}
+	if d := rec.Discrepancies[4]; d.ExpectedCents != 51 || d.ActualCents != 60 {
+		t.Errorf("fee mismatch = %+v", d)
+	}
+
+	var buf bytes.Buffer
+	if err := rec.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 6 || lines[0] != "Type,Payout ID,Order ID,Expected,Actual,Difference,Message" ||
+		lines[4] != "amount_mismatch,p2,,4.50,4.60,0.10,payout differs from order net less charges by 0.10" {
+		t.Errorf("WriteCSV() = %s", buf.String())
+	}
+}
+
+func TestReconcile_Clean(t *testing.T) {
+	orders := []manapool.OrderDetails{paidOrder("a", 900, 100)}
+	rec := Reconcile([]Payout{{ID: "p1", AmountCents: 900, OrderIDs: []string{"a"}}}, orders, ReconcileOptions{})
+	if !rec.OK() {
+		t.Errorf("Discrepancies = %+v", rec.Discrepancies)
+	}
+
+	// Orders outside every schedule are flagged rather than skipped.
+	calc, _ := NewCalculator(Schedule{EffectiveFrom: cutover.AddDate(1, 0, 0)})
+	rec = Reconcile(nil, orders, ReconcileOptions{Calculator: calc})
+	if rec.Count(MissingFromPayouts) != 1 || rec.Count(FeeMismatch) != 1 ||
+		!strings.Contains(rec.Discrepancies[1].Message, "no fee schedule") {
+		t.Errorf("Discrepancies = %+v", rec.Discrepancies)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestReconciliation_WriteCSVError(t *testing.T) {
+	rec := &Reconciliation{Discrepancies: []Discrepancy{{Type: UnknownOrder, Message: "x"}}}
+	if err := rec.WriteCSV(failingWriter{}); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("WriteCSV() error = %v", err)
+	}
+}
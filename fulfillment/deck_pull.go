@@ -0,0 +1,219 @@
+package fulfillment
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/repricah/manapool"
+)
+
+// DeckPullOptions configures NewDeckPullSheet.
+type DeckPullOptions struct {
+	// Sections lists the decklist sections to pull (default: every section
+	// except the maybeboard)
+	Sections []manapool.DeckSection
+}
+
+// DeckPullLine is one listing to pull for a decklist. SlipLine.InventoryID
+// is the listing the cards are pulled from.
+type DeckPullLine struct {
+	SlipLine
+
+	FinishID string
+}
+
+// DeckShortfall is a decklist entry that inventory could not fully cover.
+type DeckShortfall struct {
+	Entry manapool.DecklistEntry
+
+	// Missing is how many of Entry.Quantity were not found
+	Missing int
+}
+
+// DeckPullSheet is a pick list for building a decklist from inventory.
+type DeckPullSheet struct {
+	Lines     []DeckPullLine
+	Shortfall []DeckShortfall
+
+	// TotalQuantity is the total number of cards to pull
+	TotalQuantity int
+}
+
+// Complete reports whether inventory covers the whole list.
+func (s *DeckPullSheet) Complete() bool {
+	return len(s.Shortfall) == 0
+}
+
+// conditionRank orders condition IDs from best to worst.
+var conditionRank = map[string]int{"NM": 0, "LP": 1, "MP": 2, "HP": 3, "DMG": 4}
+
+// NewDeckPullSheet matches a decklist against inventory and returns the
+// listings to pull and the cards that are missing.
+//
+// Cards match by name, ignoring case, and by set, collector number, and
+// finish where the entry gives them. Entries that name a printing are
+// matched first so that generic entries do not use up the listings they
+// need.
+//
+// Among matching listings, non-foil copies are pulled before foils, then
+// better conditions before worse, then cheaper listings first. An entry
+// that asks for no finish therefore falls back to foils only when the
+// non-foil copies run out. A listing's quantity is shared across entries,
+// so a card in both main deck and sideboard is never counted twice.
+//
+// Lines are sorted in the same pick order as packing slips; use
+// SortByLocation for warehouse order.
+//
+// Example:
+//
+//	deck, err := manapool.ParseDecklist(f)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	sheet := fulfillment.NewDeckPullSheet(deck, inventory, fulfillment.DeckPullOptions{})
+//	for _, short := range sheet.Shortfall {
+//	    fmt.Printf("missing %d x %s\n", short.Missing, short.Entry.Name)
+//	}
+func NewDeckPullSheet(deck *manapool.Decklist, inventory []manapool.InventoryItem, opts DeckPullOptions) *DeckPullSheet {
+	sections := make(map[manapool.DeckSection]bool)
+	for _, section := range opts.Sections {
+		sections[section] = true
+	}
+	wanted := func(section manapool.DeckSection) bool {
+		if len(opts.Sections) == 0 {
+			return section != manapool.SectionMaybeboard
+		}
+		return sections[section]
+	}
+
+	var entries []manapool.DecklistEntry
+	for _, entry := range deck.Entries {
+		if wanted(entry.Section) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entrySpecificity(entries[i]) > entrySpecificity(entries[j])
+	})
+
+	remaining := make(map[string]int, len(inventory))
+	var singles []manapool.InventoryItem
+	for _, item := range inventory {
+		if item.Product.Single != nil && item.Quantity > 0 {
+			singles = append(singles, item)
+			remaining[item.ID] = item.Quantity
+		}
+	}
+
+	sheet := &DeckPullSheet{}
+	byListing := make(map[string]int)
+	for _, entry := range entries {
+		candidates := matchingListings(entry, singles)
+		need := entry.Quantity
+		for _, item := range candidates {
+			if need == 0 {
+				break
+			}
+			take := min(need, remaining[item.ID])
+			if take == 0 {
+				continue
+			}
+			remaining[item.ID] -= take
+			need -= take
+			sheet.TotalQuantity += take
+
+			i, ok := byListing[item.ID]
+			if !ok {
+				sheet.Lines = append(sheet.Lines, newDeckPullLine(item))
+				i = len(sheet.Lines) - 1
+				byListing[item.ID] = i
+			}
+			sheet.Lines[i].Quantity += take
+		}
+		if need > 0 {
+			sheet.Shortfall = append(sheet.Shortfall, DeckShortfall{Entry: entry, Missing: need})
+		}
+	}
+
+	sort.SliceStable(sheet.Lines, func(i, j int) bool {
+		a, b := sheet.Lines[i], sheet.Lines[j]
+		switch {
+		case lessPickOrder(a.SlipLine, b.SlipLine):
+			return true
+		case lessPickOrder(b.SlipLine, a.SlipLine):
+			return false
+		}
+		return a.InventoryID < b.InventoryID
+	})
+	sort.SliceStable(sheet.Shortfall, func(i, j int) bool {
+		return sheet.Shortfall[i].Entry.Line < sheet.Shortfall[j].Entry.Line
+	})
+	return sheet
+}
+
+// entrySpecificity ranks entries naming a collector number above those
+// naming only a set, above those naming only a card.
+func entrySpecificity(entry manapool.DecklistEntry) int {
+	switch {
+	case entry.Number != "":
+		return 2
+	case entry.Set != "":
+		return 1
+	}
+	return 0
+}
+
+// matchingListings returns the singles matching entry in the order they
+// should be pulled.
+func matchingListings(entry manapool.DecklistEntry, singles []manapool.InventoryItem) []manapool.InventoryItem {
+	var matches []manapool.InventoryItem
+	for _, item := range singles {
+		single := item.Product.Single
+		switch {
+		case !strings.EqualFold(single.Name, entry.Name):
+		case entry.Set != "" && !strings.EqualFold(single.Set, entry.Set):
+		case entry.Number != "" && !strings.EqualFold(single.Number, entry.Number):
+		case entry.FinishID != "" && single.FinishID != entry.FinishID:
+		default:
+			matches = append(matches, item)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i].Product.Single, matches[j].Product.Single
+		if fa, fb := a.FinishID != "NF", b.FinishID != "NF"; fa != fb {
+			return !fa
+		}
+		if ra, rb := rankCondition(a.ConditionID), rankCondition(b.ConditionID); ra != rb {
+			return ra < rb
+		}
+		if matches[i].PriceCents != matches[j].PriceCents {
+			return matches[i].PriceCents < matches[j].PriceCents
+		}
+		return matches[i].ID < matches[j].ID
+	})
+	return matches
+}
+
+func rankCondition(conditionID string) int {
+	if rank, ok := conditionRank[conditionID]; ok {
+		return rank
+	}
+	return len(conditionRank)
+}
+
+func newDeckPullLine(item manapool.InventoryItem) DeckPullLine {
+	single := item.Product.Single
+	return DeckPullLine{
+		SlipLine: SlipLine{
+			Name:         single.Name,
+			Set:          single.Set,
+			Number:       single.Number,
+			Condition:    single.ConditionName(),
+			TCGPlayerSKU: item.Product.TCGPlayerSKU,
+			PriceCents:   item.PriceCents,
+			InventoryID:  item.ID,
+		},
+		FinishID: single.FinishID,
+	}
+}
@@ -0,0 +1,76 @@
+package fulfillment
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool"
+)
+
+func deckInventory() []manapool.InventoryItem {
+	single := func(id, name, set, number, condition, finish string, qty, price int) manapool.InventoryItem {
+		return manapool.InventoryItem{ID: id, Quantity: qty, PriceCents: price, Product: manapool.Product{
+			Single: &manapool.Single{Name: name, Set: set, Number: number, ConditionID: condition, FinishID: finish},
+		}}
+	}
+	return []manapool.InventoryItem{
+		single("bolt-lp", "Lightning Bolt", "M10", "146", "LP", "NF", 5, 150),
+		single("bolt-nm", "Lightning Bolt", "M11", "149", "NM", "NF", 2, 200),
+		single("bolt-foil", "Lightning Bolt", "M10", "146", "NM", "FO", 1, 900),
+		single("ring", "Sol Ring", "CMR", "472", "NM", "NF", 3, 100),
+		single("empty", "Counterspell", "ICE", "64", "NM", "NF", 0, 100),
+		{ID: "box", Quantity: 1, Product: manapool.Product{Sealed: &manapool.Sealed{Name: "Counterspell"}}},
+	}
+}
+
+func TestNewDeckPullSheet(t *testing.T) {
+	deck, err := manapool.ParseDecklist(strings.NewReader(
+		"Deck\n4 Lightning Bolt\n1 Sol Ring (CMR) 472 *F*\n2 Counterspell\n" +
+			"Sideboard\n2 Lightning Bolt (M10)\nMaybeboard\n1 Sol Ring\n"))
+	if err != nil {
+		t.Fatalf("ParseDecklist() error = %v", err)
+	}
+
+	sheet := NewDeckPullSheet(deck, deckInventory(), DeckPullOptions{})
+
+	var lines []string
+	for _, line := range sheet.Lines {
+		lines = append(lines, fmt.Sprintf("%d %s %s %s", line.Quantity, line.InventoryID, line.Set, line.Condition))
+	}
+	// The M10 sideboard entry is matched first and takes two non-foil LP
+	// copies; the main deck then takes both M11 NM copies and two more LP.
+	// The foil is left alone, and there is no foil Sol Ring.
+	want := []string{
+		"4 bolt-lp M10 Lightly Played",
+		"2 bolt-nm M11 Near Mint",
+	}
+	if got := strings.Join(lines, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("lines:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+	if sheet.TotalQuantity != 6 {
+		t.Errorf("TotalQuantity = %d, want 6", sheet.TotalQuantity)
+	}
+
+	if sheet.Complete() {
+		t.Fatal("Complete() = true, want false")
+	}
+	var short []string
+	for _, s := range sheet.Shortfall {
+		short = append(short, fmt.Sprintf("%d %s", s.Missing, s.Entry.Name))
+	}
+	if got := strings.Join(short, ", "); got != "1 Sol Ring, 2 Counterspell" {
+		t.Errorf("shortfall = %s", got)
+	}
+}
+
+func TestNewDeckPullSheet_Sections(t *testing.T) {
+	deck, err := manapool.ParseDecklist(strings.NewReader("4 Lightning Bolt\n\n1 Sol Ring\n"))
+	if err != nil {
+		t.Fatalf("ParseDecklist() error = %v", err)
+	}
+	sheet := NewDeckPullSheet(deck, deckInventory(), DeckPullOptions{Sections: []manapool.DeckSection{manapool.SectionSideboard}})
+	if len(sheet.Lines) != 1 || sheet.Lines[0].InventoryID != "ring" || !sheet.Complete() {
+		t.Errorf("sheet = %+v", sheet)
+	}
+}
@@ -0,0 +1,129 @@
+package fulfillment
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/repricah/manapool"
+)
+
+// Locator returns where a line's cards are stored, such as "B03-R2-S14",
+// or "" if unknown. Stores typically look locations up by TCGPlayerSKU,
+// ProductID, or InventoryID in their own records.
+type Locator func(line SlipLine) string
+
+// InventoryLocator returns a Locator that reads locations from inventory
+// listings with location, such as a field of the seller's own data. Lines
+// with an InventoryID use that listing; others, such as order lines, use
+// the first listing of the same product with a location.
+//
+// Example:
+//
+//	locate := fulfillment.InventoryLocator(inventory, func(item manapool.InventoryItem) string {
+//	    return binOf[item.ID]
+//	})
+//	sheet.SortByLocation(locate)
+func InventoryLocator(items []manapool.InventoryItem, location func(item manapool.InventoryItem) string) Locator {
+	byListing := make(map[string]string, len(items))
+	byProduct := make(map[string]string, len(items))
+	for _, item := range items {
+		loc := location(item)
+		if loc == "" {
+			continue
+		}
+		byListing[item.ID] = loc
+		if _, ok := byProduct[item.ProductID]; !ok {
+			byProduct[item.ProductID] = loc
+		}
+	}
+	return func(line SlipLine) string {
+		if line.InventoryID != "" {
+			return byListing[line.InventoryID]
+		}
+		return byProduct[line.ProductID]
+	}
+}
+
+// SortByLocation sets each line's Location with locate and sorts the sheet
+// in warehouse walk order rather than set order. Locations are compared
+// piece by piece, with numbers compared as numbers and letters ignoring
+// case, so box/row/slot codes such as "B2-R1-S9" come before "B2-R1-S10"
+// and "B10-R1-S1". Lines without a location come last, in the usual set and
+// collector number order.
+func (s *PullSheet) SortByLocation(locate Locator) {
+	sortByLocation(s.Lines, func(line *PullLine) *SlipLine { return &line.SlipLine }, locate)
+}
+
+// SortByLocation sets each line's Location with locate and sorts the sheet
+// in warehouse walk order. See PullSheet.SortByLocation.
+func (s *DeckPullSheet) SortByLocation(locate Locator) {
+	sortByLocation(s.Lines, func(line *DeckPullLine) *SlipLine { return &line.SlipLine }, locate)
+}
+
+// sortByLocation sets the Location of each line's SlipLine, reached through
+// slip, and sorts lines by it.
+func sortByLocation[L any](lines []L, slip func(*L) *SlipLine, locate Locator) {
+	for i := range lines {
+		line := slip(&lines[i])
+		line.Location = locate(*line)
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lessLocation(*slip(&lines[i]), *slip(&lines[j]))
+	})
+}
+
+// lessLocation orders lines by location, then in the usual pick order.
+func lessLocation(a, b SlipLine) bool {
+	switch {
+	case a.Location == "" && b.Location == "":
+		return lessPickOrder(a, b)
+	case a.Location == "":
+		return false
+	case b.Location == "":
+		return true
+	}
+	if c := compareLocations(a.Location, b.Location); c != 0 {
+		return c < 0
+	}
+	return lessPickOrder(a, b)
+}
+
+// compareLocations compares location codes chunk by chunk, with digit runs
+// compared as numbers and other runs compared case-insensitively.
+func compareLocations(a, b string) int {
+	a, b = strings.ToUpper(a), strings.ToUpper(b)
+	for a != "" && b != "" {
+		ac, arest := nextLocationChunk(a)
+		bc, brest := nextLocationChunk(b)
+		an, aerr := strconv.Atoi(ac)
+		bn, berr := strconv.Atoi(bc)
+		switch {
+		case aerr == nil && berr == nil:
+			if an != bn {
+				if an < bn {
+					return -1
+				}
+				return 1
+			}
+		case ac != bc:
+			return strings.Compare(ac, bc)
+		}
+		a, b = arest, brest
+	}
+	return strings.Compare(a, b)
+}
+
+// nextLocationChunk splits off the leading run of digits or non-digits.
+func nextLocationChunk(s string) (string, string) {
+	digit := isDigit(s[0])
+	end := 1
+	for end < len(s) && isDigit(s[end]) == digit {
+		end++
+	}
+	return s[:end], s[end:]
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,83 @@
+package fulfillment
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool"
+)
+
+func TestCompareLocations(t *testing.T) {
+	ordered := []string{"A1", "a2", "B2-R1-S9", "B2-R1-S10", "B2-R2", "B10-R1-S1", "Shelf", "shelf 2"}
+	for i := range ordered {
+		for j := range ordered {
+			got := compareLocations(ordered[i], ordered[j])
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			if got != want {
+				t.Errorf("compareLocations(%q, %q) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+}
+
+func TestPullSheet_SortByLocation(t *testing.T) {
+	sheet := NewPullSheet(testOrder())
+	bins := map[string]string{"p10": "B10-S1", "p9": "B2-S10", "box": "b2-s9"}
+	sheet.SortByLocation(func(line SlipLine) string { return bins[line.ProductID] })
+
+	var got []string
+	for _, line := range sheet.Lines {
+		got = append(got, line.ProductID+"@"+line.Location)
+	}
+	// Unlocated lines follow in the usual pick order.
+	want := "box@b2-s9 p9@B2-S10 p10@B10-S1 unknown@ p1@"
+	if strings.Join(got, " ") != want {
+		t.Errorf("order = %s, want %s", strings.Join(got, " "), want)
+	}
+}
+
+func TestInventoryLocator(t *testing.T) {
+	inventory := []manapool.InventoryItem{
+		{ID: "bolt-lp", ProductID: "bolt", Quantity: 4, Product: manapool.Product{
+			Single: &manapool.Single{Name: "Lightning Bolt", Set: "M10", Number: "146", ConditionID: "LP", FinishID: "NF"},
+		}},
+		{ID: "bolt-nm", ProductID: "bolt", Quantity: 4, Product: manapool.Product{
+			Single: &manapool.Single{Name: "Lightning Bolt", Set: "M10", Number: "146", ConditionID: "NM", FinishID: "NF"},
+		}},
+		{ID: "opt", ProductID: "opt", Quantity: 4, Product: manapool.Product{
+			Single: &manapool.Single{Name: "Opt", Set: "XLN", Number: "65", ConditionID: "NM", FinishID: "NF"},
+		}},
+	}
+	bins := map[string]string{"bolt-lp": "C3", "bolt-nm": "A1"}
+	locate := InventoryLocator(inventory, func(item manapool.InventoryItem) string { return bins[item.ID] })
+
+	if got := locate(SlipLine{InventoryID: "bolt-nm", ProductID: "bolt"}); got != "A1" {
+		t.Errorf("listing location = %q, want A1", got)
+	}
+	if got := locate(SlipLine{ProductID: "bolt"}); got != "C3" {
+		t.Errorf("product location = %q, want C3 (first listing)", got)
+	}
+	if got := locate(SlipLine{ProductID: "opt"}); got != "" {
+		t.Errorf("unlocated = %q", got)
+	}
+
+	deck, err := manapool.ParseDecklist(strings.NewReader("6 Lightning Bolt\n1 Opt\n"))
+	if err != nil {
+		t.Fatalf("ParseDecklist() error = %v", err)
+	}
+	sheet := NewDeckPullSheet(deck, inventory, DeckPullOptions{})
+	sheet.SortByLocation(locate)
+	var got []string
+	for _, line := range sheet.Lines {
+		got = append(got, line.InventoryID+"@"+line.Location)
+	}
+	if want := "bolt-nm@A1 bolt-lp@C3 opt@"; strings.Join(got, " ") != want {
+		t.Errorf("deck order = %s, want %s", strings.Join(got, " "), want)
+	}
+}
@@ -0,0 +1,311 @@
+// Package fulfillment provides helpers for shipping ManaPool orders.
+//
+// # Packing Slips
+//
+// A PackingSlip is built from an order and rendered with a Renderer. Lines
+// are sorted for efficient picking: singles by set, collector number, and
+// condition, followed by sealed product.
+//
+//	order, err := client.GetSellerOrder(ctx, orderID)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	slip := fulfillment.NewPackingSlip(order.Order)
+//	if err := (fulfillment.HTMLRenderer{}).Render(w, slip); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// PDF output is not built in; implement Renderer with the PDF library of
+// your choice.
+//
+// # Pull Sheets
+//
+// NewPullSheet merges the items of several orders into one pick list, with
+// each line cross-referenced to the orders it belongs to, so a batch can be
+// pulled in a single pass before packing. BuildPullSheet fetches the orders
+// by ID first:
+//
+//	sheet, err := fulfillment.BuildPullSheet(ctx, client, orderIDs)
+//
+// Pull sheets are in set and collector number order. Stores that file stock
+// by box, row, and slot can call SortByLocation with a Locator to get picks
+// in walk order instead.
+//
+// NewDeckPullSheet picks the listings needed to build a decklist parsed
+// with manapool.ParseDecklist, and reports any cards that are short.
+//
+// # Shipping
+//
+// ShippingProvider abstracts label services. Adapters translate Shipment,
+// Rate, and Label to and from the provider's API:
+//
+//	shipment := fulfillment.ShipmentForOrder(order.Order, returnAddress, parcel)
+//	rates, err := provider.GetRates(ctx, shipment)
+//	...
+//	rate, _ := fulfillment.CheapestRate(rates)
+//	label, err := provider.CreateLabel(ctx, shipment, rate)
+//	...
+//	_, err = client.UpdateSellerOrderFulfillment(ctx, order.Order.ID,
+//	    label.FulfillmentRequest(time.Now()))
+package fulfillment
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+// SlipLine is a single picked line on a packing slip.
+type SlipLine struct {
+	Name      string
+	Set       string
+	Number    string
+	Condition string
+
+	// Sealed is true for sealed product
+	Sealed bool
+
+	TCGPlayerSKU *int
+	Quantity     int
+	PriceCents   int
+
+	// InventoryID is the listing the item is pulled from, when known;
+	// orders do not say which listing an item came from
+	InventoryID string
+
+	// ProductID is the ManaPool product
+	ProductID string
+
+	// Location is where the item is stored, set by SortByLocation
+	Location string
+}
+
+// PackingSlip is a printable summary of an order's contents.
+type PackingSlip struct {
+	OrderID        string
+	Label          string
+	CreatedAt      time.Time
+	ShippingMethod string
+	ShipTo         manapool.Address
+
+	Lines []SlipLine
+
+	// TotalQuantity is the total number of cards and sealed items
+	TotalQuantity int
+}
+
+// NewPackingSlip builds a packing slip from an order. Lines are sorted for
+// picking: singles first by set, collector number, name, and condition, then
+// sealed product by set and name.
+func NewPackingSlip(order manapool.OrderDetails) *PackingSlip {
+	slip := &PackingSlip{
+		OrderID:        order.ID,
+		Label:          order.Label,
+		CreatedAt:      order.CreatedAt.Time,
+		ShippingMethod: order.ShippingMethod,
+		ShipTo:         order.ShippingAddress,
+		Lines:          make([]SlipLine, 0, len(order.Items)),
+	}
+
+	for _, item := range order.Items {
+		slip.Lines = append(slip.Lines, newSlipLine(item))
+		slip.TotalQuantity += item.Quantity
+	}
+
+	sort.SliceStable(slip.Lines, func(i, j int) bool {
+		return lessPickOrder(slip.Lines[i], slip.Lines[j])
+	})
+
+	return slip
+}
+
+// newSlipLine converts an order item to a slip line.
+func newSlipLine(item manapool.OrderItem) SlipLine {
+	line := SlipLine{
+		TCGPlayerSKU: item.TCGSKU,
+		Quantity:     item.Quantity,
+		PriceCents:   item.PriceCents,
+		ProductID:    item.ProductID,
+	}
+	switch {
+	case item.Product.Single != nil:
+		single := item.Product.Single
+		line.Name = single.Name
+		line.Set = single.Set
+		line.Number = single.Number
+		line.Condition = single.ConditionName()
+	case item.Product.Sealed != nil:
+		line.Name = item.Product.Sealed.Name
+		line.Set = item.Product.Sealed.Set
+		line.Sealed = true
+	default:
+		line.Name = item.ProductID
+	}
+	return line
+}
+
+func lessPickOrder(a, b SlipLine) bool {
+	if a.Sealed != b.Sealed {
+		return !a.Sealed
+	}
+	if a.Set != b.Set {
+		return strings.ToUpper(a.Set) < strings.ToUpper(b.Set)
+	}
+	if c := manapool.CompareCollectorNumbers(a.Number, b.Number); c != 0 {
+		return c < 0
+	}
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	return a.Condition < b.Condition
+}
+
+// Renderer writes a packing slip in some output format.
+type Renderer interface {
+	Render(w io.Writer, slip *PackingSlip) error
+}
+
+// RendererFunc adapts a function to the Renderer interface.
+type RendererFunc func(w io.Writer, slip *PackingSlip) error
+
+// Render implements Renderer.
+func (f RendererFunc) Render(w io.Writer, slip *PackingSlip) error {
+	return f(w, slip)
+}
+
+var templateFuncs = map[string]any{
+	"dollars": func(cents int) string { return manapool.USDCents(cents).String() },
+	"address": formatAddress,
+	"price":   formatPrice,
+}
+
+var defaultTextTemplate = template.Must(template.New("slip").Funcs(templateFuncs).Parse(
+	`PACKING SLIP
+Order: {{.OrderID}}{{if .Label}} ({{.Label}}){{end}}
+{{- if not .CreatedAt.IsZero}}
+Date: {{.CreatedAt.Format "2006-01-02"}}{{end}}
+{{- if .ShippingMethod}}
+Shipping: {{.ShippingMethod}}{{end}}
+
+Ship to:
+{{address .ShipTo}}
+
+QTY  SET    NO.     CONDITION             NAME
+{{- range .Lines}}
+{{printf "%-4d %-6s %-7s %-21s %s" .Quantity .Set .Number .Condition .Name}}
+{{- end}}
+
+Total items: {{.TotalQuantity}}
+`))
+
+var defaultHTMLTemplate = htmltemplate.Must(htmltemplate.New("slip").Funcs(templateFuncs).Parse(
+	`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Packing slip {{.OrderID}}</title></head>
+<body>
+<h1>Packing Slip</h1>
+<p>Order: {{.OrderID}}{{if .Label}} ({{.Label}}){{end}}</p>
+{{- if not .CreatedAt.IsZero}}
+<p>Date: {{.CreatedAt.Format "2006-01-02"}}</p>{{end}}
+{{- if .ShippingMethod}}
+<p>Shipping: {{.ShippingMethod}}</p>{{end}}
+<h2>Ship to</h2>
+<pre>{{address .ShipTo}}</pre>
+<table>
+<thead><tr><th>Qty</th><th>Set</th><th>No.</th><th>Condition</th><th>Name</th></tr></thead>
+<tbody>
+{{- range .Lines}}
+<tr><td>{{.Quantity}}</td><td>{{.Set}}</td><td>{{.Number}}</td><td>{{.Condition}}</td><td>{{.Name}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+<p>Total items: {{.TotalQuantity}}</p>
+</body>
+</html>
+`))
+
+// TextRenderer renders a plain-text packing slip suitable for receipt
+// printers. A custom template may be supplied; it is executed with the
+// *PackingSlip and has "dollars", "price", and "address" helper functions.
+type TextRenderer struct {
+	Template *template.Template
+}
+
+// Render implements Renderer.
+func (r TextRenderer) Render(w io.Writer, slip *PackingSlip) error {
+	tmpl := r.Template
+	if tmpl == nil {
+		tmpl = defaultTextTemplate
+	}
+	if err := tmpl.Execute(w, slip); err != nil {
+		return fmt.Errorf("failed to render packing slip: %w", err)
+	}
+	return nil
+}
+
+// HTMLRenderer renders an HTML packing slip for browser printing. A custom
+// template may be supplied; it is executed with the *PackingSlip and has
+// "dollars", "price", and "address" helper functions.
+type HTMLRenderer struct {
+	Template *htmltemplate.Template
+}
+
+// Render implements Renderer.
+func (r HTMLRenderer) Render(w io.Writer, slip *PackingSlip) error {
+	tmpl := r.Template
+	if tmpl == nil {
+		tmpl = defaultHTMLTemplate
+	}
+	if err := tmpl.Execute(w, slip); err != nil {
+		return fmt.Errorf("failed to render packing slip: %w", err)
+	}
+	return nil
+}
+
+// Funcs returns the helper functions available to packing slip templates,
+// for use when parsing a custom template.
+func Funcs() map[string]any {
+	funcs := make(map[string]any, len(templateFuncs))
+	for name, fn := range templateFuncs {
+		funcs[name] = fn
+	}
+	return funcs
+}
+
+// formatAddress formats an address as newline-separated lines.
+func formatAddress(a manapool.Address) string {
+	var lines []string
+	add := func(s string) {
+		if s = strings.TrimSpace(s); s != "" {
+			lines = append(lines, s)
+		}
+	}
+	add(a.Name)
+	add(a.Line1)
+	if a.Line2 != nil {
+		add(*a.Line2)
+	}
+	if a.Line3 != nil {
+		add(*a.Line3)
+	}
+	region := strings.TrimSpace(a.State + " " + a.PostalCode)
+	if city := strings.TrimSpace(a.City); city != "" && region != "" {
+		add(city + ", " + region)
+	} else {
+		add(city + region)
+	}
+	add(a.Country)
+	return strings.Join(lines, "\n")
+}
+
+// formatPrice formats US cents for a locale, e.g. {{price .PriceCents "de-DE"}}
+// gives "299,99 $". See manapool.FormatPrice.
+func formatPrice(cents int, locale string) string {
+	return manapool.FormatPrice(cents, manapool.USD, manapool.Locale(locale))
+}
@@ -0,0 +1,169 @@
+package fulfillment
+
+import (
+	"bytes"
+	"errors"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+func testOrder() manapool.OrderDetails {
+	sku := 42
+	line2 := "Apt 4"
+	order := manapool.OrderDetails{
+		BuyerID: "buyer",
+		ShippingAddress: manapool.Address{
+			Name:       "Jo Buyer",
+			Line1:      "1 Main St",
+			Line2:      &line2,
+			City:       "Springfield",
+			State:      "IL",
+			PostalCode: "62701",
+			Country:    "US",
+		},
+		Items: []manapool.OrderItem{
+			{ProductID: "box", Quantity: 1, PriceCents: 29999, Product: manapool.Product{
+				Sealed: &manapool.Sealed{Name: "Ice Age Booster Box", Set: "ICE"},
+			}},
+			{ProductID: "p10", Quantity: 2, PriceCents: 100, Product: manapool.Product{
+				Single: &manapool.Single{Name: "Card Ten", Set: "LEA", Number: "10", ConditionID: "NM", FinishID: "NF"},
+			}},
+			{ProductID: "p9", Quantity: 1, PriceCents: 100, TCGSKU: &sku, Product: manapool.Product{
+				Single: &manapool.Single{Name: "Card <Nine>", Set: "LEA", Number: "9", ConditionID: "LP", FinishID: "FO"},
+			}},
+			{ProductID: "p1", Quantity: 1, PriceCents: 100, Product: manapool.Product{
+				Single: &manapool.Single{Name: "Card One", Set: "2ED", Number: "1", ConditionID: "NM", FinishID: "NF"},
+			}},
+			{ProductID: "unknown", Quantity: 1},
+		},
+	}
+	order.ID = "order-1"
+	order.Label = "A1"
+	order.ShippingMethod = "standard"
+	order.CreatedAt = manapool.Timestamp{Time: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)}
+	return order
+}
+
+func TestNewPackingSlip(t *testing.T) {
+	slip := NewPackingSlip(testOrder())
+
+	var names []string
+	for _, line := range slip.Lines {
+		names = append(names, line.Name)
+	}
+	want := "unknown,Card One,Card <Nine>,Card Ten,Ice Age Booster Box"
+	if got := strings.Join(names, ","); got != want {
+		t.Errorf("line order = %s, want %s", got, want)
+	}
+	if slip.TotalQuantity != 6 {
+		t.Errorf("TotalQuantity = %d, want 6", slip.TotalQuantity)
+	}
+	if slip.Lines[2].Condition != "Lightly Played Foil" || *slip.Lines[2].TCGPlayerSKU != 42 {
+		t.Errorf("line = %+v", slip.Lines[2])
+	}
+	if !slip.Lines[4].Sealed {
+		t.Error("sealed line not marked Sealed")
+	}
+}
+
+func TestTextRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (TextRenderer{}).Render(&buf, NewPackingSlip(testOrder())); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"Order: order-1 (A1)",
+		"Date: 2026-03-04",
+		"Jo Buyer\n1 Main St\nApt 4\nSpringfield, IL 62701\nUS",
+		"1    LEA    9       Lightly Played Foil   Card <Nine>",
+		"Total items: 6",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestHTMLRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (HTMLRenderer{}).Render(&buf, NewPackingSlip(testOrder())); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "Card &lt;Nine&gt;") {
+		t.Errorf("output does not escape names:\n%s", out)
+	}
+	if !strings.Contains(out, "<td>Lightly Played Foil</td>") {
+		t.Errorf("output missing condition:\n%s", out)
+	}
+}
+
+func TestRenderers_CustomTemplate(t *testing.T) {
+	slip := NewPackingSlip(testOrder())
+
+	text := template.Must(template.New("t").Funcs(Funcs()).Parse(`{{range .Lines}}{{dollars .PriceCents}} {{end}}`))
+	var buf bytes.Buffer
+	if err := (TextRenderer{Template: text}).Render(&buf, slip); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got := buf.String(); got != "$0.00 $1.00 $1.00 $1.00 $299.99 " {
+		t.Errorf("text output = %q", got)
+	}
+
+	localized := template.Must(template.New("t").Funcs(Funcs()).Parse(`{{range .Lines}}{{price .PriceCents "de-DE"}}|{{end}}`))
+	buf.Reset()
+	if err := (TextRenderer{Template: localized}).Render(&buf, slip); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got := buf.String(); got != "0,00 $|1,00 $|1,00 $|1,00 $|299,99 $|" {
+		t.Errorf("localized output = %q", got)
+	}
+
+	html := htmltemplate.Must(htmltemplate.New("h").Funcs(Funcs()).Parse(`{{.OrderID}}`))
+	buf.Reset()
+	if err := (HTMLRenderer{Template: html}).Render(&buf, slip); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if buf.String() != "order-1" {
+		t.Errorf("html output = %q", buf.String())
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestRenderers_WriteError(t *testing.T) {
+	slip := NewPackingSlip(testOrder())
+	for name, renderer := range map[string]Renderer{"text": TextRenderer{}, "html": HTMLRenderer{}} {
+		if err := renderer.Render(failingWriter{}, slip); err == nil {
+			t.Errorf("%s Render() error = nil, want error", name)
+		}
+	}
+}
+
+func TestRendererFunc(t *testing.T) {
+	var called bool
+	renderer := RendererFunc(func(w io.Writer, slip *PackingSlip) error {
+		called = slip.OrderID == "order-1"
+		return nil
+	})
+	if err := renderer.Render(io.Discard, NewPackingSlip(testOrder())); err != nil || !called {
+		t.Errorf("RendererFunc.Render() = %v, called = %v", err, called)
+	}
+}
+
+func TestFormatAddress(t *testing.T) {
+	if got := formatAddress(manapool.Address{City: "Paris", Country: "FR"}); got != "Paris\nFR" {
+		t.Errorf("formatAddress() = %q", got)
+	}
+}
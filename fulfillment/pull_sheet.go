@@ -0,0 +1,120 @@
+package fulfillment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/repricah/manapool"
+)
+
+// PullOrder is one order's share of a pull sheet line.
+type PullOrder struct {
+	OrderID  string
+	Label    string
+	Quantity int
+}
+
+// PullLine is a product to pick for one or more orders.
+type PullLine struct {
+	SlipLine
+
+	// Orders lists the orders the product goes to, in the order they were
+	// given to NewPullSheet
+	Orders []PullOrder
+}
+
+// PullSheet is a combined pick list for a batch of orders, so each product
+// is pulled from stock once.
+type PullSheet struct {
+	OrderIDs []string
+	Lines    []PullLine
+
+	// TotalQuantity is the total number of cards and sealed items
+	TotalQuantity int
+}
+
+// NewPullSheet combines the items of orders into a pull sheet. Items for the
+// same product are merged into one line whose quantity is the sum across
+// orders. Lines are sorted in the same pick order as packing slips, and
+// PriceCents is left zero because it may differ between orders.
+//
+// Example:
+//
+//	sheet := fulfillment.NewPullSheet(orders...)
+//	for _, line := range sheet.Lines {
+//	    fmt.Printf("%d x %s (%s %s)\n", line.Quantity, line.Name, line.Set, line.Number)
+//	}
+func NewPullSheet(orders ...manapool.OrderDetails) *PullSheet {
+	sheet := &PullSheet{OrderIDs: make([]string, 0, len(orders))}
+	byProduct := make(map[string]int)
+	for _, order := range orders {
+		sheet.OrderIDs = append(sheet.OrderIDs, order.ID)
+		for _, item := range order.Items {
+			key := item.ProductType + "/" + item.ProductID
+			i, ok := byProduct[key]
+			if !ok {
+				line := newSlipLine(item)
+				line.Quantity, line.PriceCents = 0, 0
+				sheet.Lines = append(sheet.Lines, PullLine{SlipLine: line})
+				i = len(sheet.Lines) - 1
+				byProduct[key] = i
+			}
+
+			line := &sheet.Lines[i]
+			line.Quantity += item.Quantity
+			if n := len(line.Orders); n > 0 && line.Orders[n-1].OrderID == order.ID {
+				line.Orders[n-1].Quantity += item.Quantity
+			} else {
+				line.Orders = append(line.Orders, PullOrder{OrderID: order.ID, Label: order.Label, Quantity: item.Quantity})
+			}
+			sheet.TotalQuantity += item.Quantity
+		}
+	}
+
+	sort.SliceStable(sheet.Lines, func(i, j int) bool {
+		return lessPickOrder(sheet.Lines[i].SlipLine, sheet.Lines[j].SlipLine)
+	})
+	return sheet
+}
+
+// OrderGetter fetches a seller order. *manapool.Client and
+// manapooltest.FakeClient implement it.
+type OrderGetter interface {
+	GetSellerOrder(ctx context.Context, id string) (*manapool.OrderDetailsResponse, error)
+}
+
+// BuildPullSheet fetches the given orders and combines them with
+// NewPullSheet, so a batch of open orders can be picked in one pass through
+// the binders: lines are sorted by set, then collector number, and each
+// lists the orders it belongs to. Duplicate IDs are fetched once. Orders
+// are fetched one at a time through client's rate limiting; if any fetch
+// fails, BuildPullSheet returns the error and no sheet.
+//
+// Example:
+//
+//	sheet, err := fulfillment.BuildPullSheet(ctx, client, []string{"order-1", "order-2"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func BuildPullSheet(ctx context.Context, client OrderGetter, orderIDs []string) (*PullSheet, error) {
+	if len(orderIDs) == 0 {
+		return nil, manapool.NewValidationError("order_ids", "at least one order ID is required")
+	}
+
+	seen := make(map[string]bool, len(orderIDs))
+	orders := make([]manapool.OrderDetails, 0, len(orderIDs))
+	for _, id := range orderIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		resp, err := client.GetSellerOrder(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build pull sheet: order %s: %w", id, err)
+		}
+		orders = append(orders, resp.Order)
+	}
+	return NewPullSheet(orders...), nil
+}
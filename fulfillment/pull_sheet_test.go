@@ -0,0 +1,96 @@
+package fulfillment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool"
+	"github.com/repricah/manapool/manapooltest"
+)
+
+func TestNewPullSheet(t *testing.T) {
+	first := testOrder()
+	second := testOrder()
+	second.ID, second.Label = "order-2", "B2"
+	second.Items = []manapool.OrderItem{
+		{ProductID: "p10", Quantity: 3, PriceCents: 120, Product: first.Items[1].Product},
+		{ProductID: "p2", Quantity: 1, Product: manapool.Product{
+			Single: &manapool.Single{Name: "Card Two", Set: "2ED", Number: "2", ConditionID: "NM", FinishID: "NF"},
+		}},
+		{ProductID: "p10", Quantity: 1, Product: first.Items[1].Product},
+	}
+
+	sheet := NewPullSheet(first, second)
+
+	var lines []string
+	for _, line := range sheet.Lines {
+		var refs []string
+		for _, o := range line.Orders {
+			refs = append(refs, fmt.Sprintf("%s:%d", o.OrderID, o.Quantity))
+		}
+		lines = append(lines, fmt.Sprintf("%d %s [%s]", line.Quantity, line.Name, strings.Join(refs, " ")))
+	}
+	want := []string{
+		"1 unknown [order-1:1]",
+		"1 Card One [order-1:1]",
+		"1 Card Two [order-2:1]",
+		"1 Card <Nine> [order-1:1]",
+		"6 Card Ten [order-1:2 order-2:4]",
+		"1 Ice Age Booster Box [order-1:1]",
+	}
+	if got := strings.Join(lines, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("lines =\n%s\nwant\n%s", got, strings.Join(want, "\n"))
+	}
+
+	if sheet.TotalQuantity != 11 || strings.Join(sheet.OrderIDs, ",") != "order-1,order-2" {
+		t.Errorf("sheet = %+v", sheet)
+	}
+	if ten := sheet.Lines[4]; ten.PriceCents != 0 || ten.Orders[1].Label != "B2" {
+		t.Errorf("merged line = %+v", ten)
+	}
+	if empty := NewPullSheet(); len(empty.Lines) != 0 || empty.TotalQuantity != 0 {
+		t.Errorf("NewPullSheet() = %+v", empty)
+	}
+}
+
+func TestBuildPullSheet(t *testing.T) {
+	first := testOrder()
+	second := testOrder()
+	second.ID = "order-2"
+	second.Items = second.Items[1:2]
+	fake := manapooltest.NewFakeClient(manapooltest.WithOrders(first, second))
+
+	sheet, err := BuildPullSheet(context.Background(), fake, []string{"order-2", "order-1", "order-2"})
+	if err != nil {
+		t.Fatalf("BuildPullSheet() error = %v", err)
+	}
+	if got := strings.Join(sheet.OrderIDs, ","); got != "order-2,order-1" {
+		t.Errorf("OrderIDs = %s", got)
+	}
+	if n := len(fake.Calls()); n != 2 {
+		t.Errorf("calls = %d, want 2", n)
+	}
+	var ten *PullLine
+	for i := range sheet.Lines {
+		if sheet.Lines[i].Name == "Card Ten" {
+			ten = &sheet.Lines[i]
+		}
+	}
+	if ten == nil || ten.Quantity != 4 || len(ten.Orders) != 2 || ten.Orders[0].OrderID != "order-2" {
+		t.Errorf("Card Ten line = %+v", ten)
+	}
+
+	_, err = BuildPullSheet(context.Background(), fake, []string{"order-1", "missing"})
+	var apiErr *manapool.APIError
+	if !errors.As(err, &apiErr) || !strings.Contains(err.Error(), "order missing") {
+		t.Errorf("missing order error = %v", err)
+	}
+
+	var validationErr *manapool.ValidationError
+	if _, err := BuildPullSheet(context.Background(), fake, nil); !errors.As(err, &validationErr) {
+		t.Errorf("empty IDs error = %v, want ValidationError", err)
+	}
+}
@@ -0,0 +1,179 @@
+package fulfillment
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+// Address is a postal address in the shape most shipping APIs expect.
+type Address struct {
+	Name       string
+	Company    string
+	Street1    string
+	Street2    string
+	Street3    string
+	City       string
+	State      string
+	PostalCode string
+
+	// Country is an ISO 3166-1 alpha-2 code such as "US"
+	Country string
+
+	Phone string
+	Email string
+}
+
+// AddressFromOrder converts a ManaPool order address.
+func AddressFromOrder(a manapool.Address) Address {
+	addr := Address{
+		Name:       a.Name,
+		Street1:    a.Line1,
+		City:       a.City,
+		State:      a.State,
+		PostalCode: a.PostalCode,
+		Country:    strings.ToUpper(a.Country),
+	}
+	if a.Line2 != nil {
+		addr.Street2 = *a.Line2
+	}
+	if a.Line3 != nil {
+		addr.Street3 = *a.Line3
+	}
+	return addr
+}
+
+// Parcel describes the package being shipped. Dimensions are in inches and
+// weight is in ounces, matching US carrier APIs; adapters for other regions
+// should convert as needed.
+type Parcel struct {
+	LengthIn float64
+	WidthIn  float64
+	HeightIn float64
+	WeightOz float64
+
+	// PredefinedPackage is a carrier-specific package name such as "Letter"
+	// or "FlatRateEnvelope" (optional)
+	PredefinedPackage string
+}
+
+// Shipment is a request to ship a parcel between two addresses.
+type Shipment struct {
+	// OrderID is the ManaPool order being shipped, for reference fields
+	OrderID string
+
+	From   Address
+	To     Address
+	Parcel Parcel
+}
+
+// ShipmentForOrder builds a shipment for a ManaPool order.
+func ShipmentForOrder(order manapool.OrderDetails, from Address, parcel Parcel) Shipment {
+	return Shipment{
+		OrderID: order.ID,
+		From:    from,
+		To:      AddressFromOrder(order.ShippingAddress),
+		Parcel:  parcel,
+	}
+}
+
+// Rate is a quoted price for a carrier service.
+type Rate struct {
+	// ID is the provider's identifier for the rate, used to buy a label
+	ID string
+
+	Carrier     string
+	Service     string
+	AmountCents int
+	Currency    string
+
+	// EstimatedDays is the estimated transit time (0 if unknown)
+	EstimatedDays int
+}
+
+// Label is a purchased shipping label.
+type Label struct {
+	Carrier        string
+	Service        string
+	TrackingNumber string
+	TrackingURL    string
+
+	// LabelURL links to the printable label, if the provider hosts it
+	LabelURL string
+
+	// Data and Format hold the label file when returned inline
+	// (e.g. Format "PDF", "PNG", or "ZPL")
+	Data   []byte
+	Format string
+
+	CostCents int
+}
+
+// FulfillmentRequest returns a ManaPool fulfillment update marking the order
+// shipped with this label's tracking details.
+func (l *Label) FulfillmentRequest(shippedAt time.Time) manapool.OrderFulfillmentRequest {
+	req := manapool.OrderFulfillmentRequest{
+		Status:      manapool.OrderStatusShipped.Ptr(),
+		InTransitAt: &manapool.Timestamp{Time: shippedAt},
+	}
+	if l.Carrier != "" {
+		req.TrackingCompany = &l.Carrier
+	}
+	if l.TrackingNumber != "" {
+		req.TrackingNumber = &l.TrackingNumber
+	}
+	if l.TrackingURL != "" {
+		req.TrackingURL = &l.TrackingURL
+	}
+	return req
+}
+
+// TrackingEvent is a single carrier scan.
+type TrackingEvent struct {
+	Time        time.Time
+	Status      string
+	Description string
+	Location    string
+}
+
+// TrackingStatus is the current state of a shipment.
+type TrackingStatus struct {
+	Carrier        string
+	TrackingNumber string
+
+	// Status is the provider's normalized status, e.g. "in_transit" or "delivered"
+	Status string
+
+	EstimatedDeliveryAt *time.Time
+	DeliveredAt         *time.Time
+	Events              []TrackingEvent
+}
+
+// ShippingProvider is implemented by adapters for label services such as
+// EasyPost, Shippo, or Pirate Ship.
+type ShippingProvider interface {
+	// GetRates returns the available rates for a shipment.
+	GetRates(ctx context.Context, shipment Shipment) ([]Rate, error)
+
+	// CreateLabel purchases a label for shipment at the given rate.
+	CreateLabel(ctx context.Context, shipment Shipment, rate Rate) (*Label, error)
+
+	// Track returns the current tracking status of a shipment.
+	Track(ctx context.Context, carrier, trackingNumber string) (*TrackingStatus, error)
+}
+
+// CheapestRate returns the lowest-priced rate. It returns false if rates is empty.
+func CheapestRate(rates []Rate) (Rate, bool) {
+	if len(rates) == 0 {
+		return Rate{}, false
+	}
+	best := rates[0]
+	for _, rate := range rates[1:] {
+		if rate.AmountCents < best.AmountCents {
+			best = rate
+		}
+	}
+	return best, true
+}
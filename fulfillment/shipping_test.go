@@ -0,0 +1,84 @@
+package fulfillment
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type stubProvider struct {
+	rates []Rate
+}
+
+func (p *stubProvider) GetRates(_ context.Context, _ Shipment) ([]Rate, error) {
+	return p.rates, nil
+}
+
+func (p *stubProvider) CreateLabel(_ context.Context, shipment Shipment, rate Rate) (*Label, error) {
+	return &Label{
+		Carrier:        rate.Carrier,
+		Service:        rate.Service,
+		TrackingNumber: "TRACK-" + shipment.OrderID,
+		CostCents:      rate.AmountCents,
+	}, nil
+}
+
+func (p *stubProvider) Track(_ context.Context, carrier, trackingNumber string) (*TrackingStatus, error) {
+	return &TrackingStatus{Carrier: carrier, TrackingNumber: trackingNumber, Status: "in_transit"}, nil
+}
+
+func TestShipmentForOrder(t *testing.T) {
+	from := Address{Name: "Seller", Country: "US"}
+	shipment := ShipmentForOrder(testOrder(), from, Parcel{WeightOz: 1})
+
+	if shipment.OrderID != "order-1" || shipment.From.Name != "Seller" || shipment.Parcel.WeightOz != 1 {
+		t.Errorf("shipment = %+v", shipment)
+	}
+	to := shipment.To
+	if to.Name != "Jo Buyer" || to.Street1 != "1 Main St" || to.Street2 != "Apt 4" || to.Street3 != "" ||
+		to.City != "Springfield" || to.State != "IL" || to.PostalCode != "62701" || to.Country != "US" {
+		t.Errorf("To = %+v", to)
+	}
+}
+
+func TestShippingProvider_Flow(t *testing.T) {
+	ctx := context.Background()
+	var provider ShippingProvider = &stubProvider{rates: []Rate{
+		{ID: "a", Carrier: "USPS", Service: "Priority", AmountCents: 900},
+		{ID: "b", Carrier: "USPS", Service: "Ground Advantage", AmountCents: 450},
+		{ID: "c", Carrier: "UPS", Service: "Ground", AmountCents: 1200},
+	}}
+	shipment := ShipmentForOrder(testOrder(), Address{}, Parcel{WeightOz: 3})
+
+	rates, err := provider.GetRates(ctx, shipment)
+	if err != nil {
+		t.Fatalf("GetRates() error = %v", err)
+	}
+	rate, ok := CheapestRate(rates)
+	if !ok || rate.ID != "b" {
+		t.Fatalf("CheapestRate() = %+v, %v", rate, ok)
+	}
+
+	label, err := provider.CreateLabel(ctx, shipment, rate)
+	if err != nil {
+		t.Fatalf("CreateLabel() error = %v", err)
+	}
+
+	shippedAt := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+	req := label.FulfillmentRequest(shippedAt)
+	if *req.Status != "shipped" || *req.TrackingCompany != "USPS" || *req.TrackingNumber != "TRACK-order-1" {
+		t.Errorf("FulfillmentRequest() = %+v", req)
+	}
+	if req.TrackingURL != nil {
+		t.Errorf("TrackingURL = %q, want nil", *req.TrackingURL)
+	}
+	if !req.InTransitAt.Equal(shippedAt) {
+		t.Errorf("InTransitAt = %v, want %v", req.InTransitAt, shippedAt)
+	}
+}
+
+func TestCheapestRate_Empty(t *testing.T) {
+	if _, ok := CheapestRate(nil); ok {
+		t.Error("CheapestRate(nil) returned ok")
+	}
+}
@@ -2,6 +2,7 @@ package manapool
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -25,8 +26,8 @@ import (
 //	fmt.Printf("Total items: %d, Returned: %d\n",
 //	    resp.Pagination.Total, resp.Pagination.Returned)
 //	for _, item := range resp.Inventory {
-//	    fmt.Printf("  %s: $%.2f (qty: %d)\n",
-//	        item.Product.Single.Name, item.PriceDollars(), item.Quantity)
+//	    fmt.Printf("  %s: %s (qty: %d)\n",
+//	        item.Product.Single.Name, item.Price(), item.Quantity)
 //	}
 //
 // Parameters:
@@ -42,6 +43,10 @@ func (c *Client) GetSellerInventory(ctx context.Context, opts InventoryOptions)
 		return nil, err
 	}
 
+	if opts.TCGPlayerSKU > 0 {
+		return c.sellerInventoryPageBySKU(ctx, opts)
+	}
+
 	c.logger.Debugf("Getting seller inventory: limit=%d, offset=%d", opts.Limit, opts.Offset)
 
 	// Build query parameters
@@ -81,8 +86,8 @@ func (c *Client) GetSellerInventory(ctx context.Context, opts InventoryOptions)
 //	    }
 //	    log.Fatal(err)
 //	}
-//	fmt.Printf("Found: %s - $%.2f (qty: %d)\n",
-//	    item.Product.Single.Name, item.PriceDollars(), item.Quantity)
+//	fmt.Printf("Found: %s - %s (qty: %d)\n",
+//	    item.Product.Single.Name, item.Price(), item.Quantity)
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeouts
@@ -124,6 +129,55 @@ func (c *Client) GetInventoryByTCGPlayerID(ctx context.Context, tcgplayerID stri
 	return &item, nil
 }
 
+// FindByTCGSKU returns the seller's listing with the given TCGPlayer SKU, or
+// nil if there is none. Unlike GetSellerInventoryBySKU, a missing listing is
+// not an error, which suits systems keyed by TCGPlayer SKU that look up
+// listings that may not exist yet.
+//
+// Example:
+//
+//	item, err := client.FindByTCGSKU(ctx, 4549403)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if item == nil {
+//	    fmt.Println("Not listed")
+//	    return
+//	}
+//	fmt.Printf("%d at %s\n", item.Quantity, item.Price())
+func (c *Client) FindByTCGSKU(ctx context.Context, sku int) (*InventoryItem, error) {
+	listing, err := c.GetSellerInventoryBySKU(ctx, sku)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &listing.Inventory, nil
+}
+
+// sellerInventoryPageBySKU serves an inventory page restricted to one
+// TCGPlayer SKU, using the by-SKU endpoint since the list endpoint cannot
+// filter.
+func (c *Client) sellerInventoryPageBySKU(ctx context.Context, opts InventoryOptions) (*InventoryResponse, error) {
+	item, err := c.FindByTCGSKU(ctx, opts.TCGPlayerSKU)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &InventoryResponse{Inventory: []InventoryItem{}}
+	resp.Pagination = Pagination{Offset: opts.Offset, Limit: opts.Limit}
+	if item != nil {
+		resp.Pagination.Total = 1
+		if opts.Offset == 0 {
+			resp.Inventory = append(resp.Inventory, *item)
+			resp.Pagination.Returned = 1
+		}
+	}
+	return resp, nil
+}
+
 // IterateInventory is a helper function that automatically handles pagination
 // and calls the provided callback for each inventory item.
 //
@@ -134,8 +188,8 @@ func (c *Client) GetInventoryByTCGPlayerID(ctx context.Context, tcgplayerID stri
 // Example:
 //
 //	err := manapool.IterateInventory(ctx, client, func(item *manapool.InventoryItem) error {
-//	    fmt.Printf("%s: $%.2f (qty: %d)\n",
-//	        item.Product.Single.Name, item.PriceDollars(), item.Quantity)
+//	    fmt.Printf("%s: %s (qty: %d)\n",
+//	        item.Product.Single.Name, item.Price(), item.Quantity)
 //	    return nil
 //	})
 //	if err != nil {
@@ -0,0 +1,87 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// adjustAttempts is the number of times AdjustQuantity reads and writes a
+// listing before giving up on a conflict.
+const adjustAttempts = 5
+
+// AdjustQuantity adds delta to a listing's quantity and returns the updated
+// listing. A negative delta decrements stock, such as for a sale made
+// elsewhere; the adjustment fails with a *ValidationError if it would leave
+// the quantity below zero.
+//
+// The adjustment is optimistic: the listing is read, the new quantity is
+// computed, and the listing is read again just before writing. If its
+// quantity or effective_as_of timestamp changed in between, the adjustment
+// starts over from the fresh value, up to a few times with a growing pause.
+// The write is made with UpdateInventoryIfUnchanged, so the same caveat
+// applies: a write landing between the final check and the update can still
+// be lost.
+//
+// Example:
+//
+//	// Record an in-store sale of two copies
+//	item, err := client.AdjustQuantity(ctx, "listing-id", -2)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d left\n", item.Quantity)
+func (c *Client) AdjustQuantity(ctx context.Context, itemID string, delta int) (*InventoryItem, error) {
+	if itemID == "" {
+		return nil, NewValidationError("itemID", "itemID cannot be empty")
+	}
+
+	// The first read of each attempt must see the API's current state too.
+	ctx = WithRequestOptions(ctx, BypassCache())
+	backoff := c.initialBackoff
+	for attempt := 1; ; attempt++ {
+		item, err := c.adjustQuantityOnce(ctx, itemID, delta)
+		if !errors.Is(err, ErrPreconditionFailed) {
+			return item, err
+		}
+		if attempt == adjustAttempts {
+			return nil, fmt.Errorf("failed to adjust listing %s after %d attempts: %w", itemID, attempt, err)
+		}
+
+		c.logger.Debugf("Listing %s changed during adjustment, retrying in %v", itemID, backoff)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// adjustQuantityOnce makes one read-check-write pass of AdjustQuantity. It
+// returns an error matching ErrPreconditionFailed if the listing changed
+// before the write.
+func (c *Client) adjustQuantityOnce(ctx context.Context, itemID string, delta int) (*InventoryItem, error) {
+	read, err := c.GetInventoryListing(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	item := read.InventoryItem
+
+	quantity := item.Quantity + delta
+	if quantity < 0 {
+		return nil, NewValidationError("delta",
+			fmt.Sprintf("adjusting quantity %d by %d would leave listing %s below zero", item.Quantity, delta, itemID))
+	}
+
+	update := InventoryUpdateRequest{PriceCents: item.PriceCents, Quantity: quantity}
+	if _, err := c.UpdateInventoryIfUnchanged(ctx, item, update); err != nil {
+		return nil, err
+	}
+
+	item.Quantity = quantity
+	return &item, nil
+}
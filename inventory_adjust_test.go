@@ -0,0 +1,132 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// adjustTestServer extends deleteTestServer with single listing reads and
+// updates by product. A read whose number is in sellOnRead first sells one
+// copy of the listing, as a concurrent writer would.
+type adjustTestServer struct {
+	*deleteTestServer
+	reads      int
+	sellOnRead map[int]bool
+	updates    []InventoryUpdateRequest
+}
+
+func (s *adjustTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/inventory/listings/"):
+		s.reads++
+		item := s.find(func(item *InventoryItem) bool { return "/inventory/listings/"+item.ID == r.URL.Path })
+		if item == nil {
+			http.NotFound(w, r)
+			return
+		}
+		if s.sellOnRead[s.reads] {
+			item.Quantity--
+			item.EffectiveAsOf = Timestamp{Time: item.EffectiveAsOf.Add(time.Second)}
+		}
+		_ = json.NewEncoder(w).Encode(InventoryItemResponse{InventoryItem: *item})
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/seller/inventory/product/"):
+		var update InventoryUpdateRequest
+		_ = json.NewDecoder(r.Body).Decode(&update)
+		s.updates = append(s.updates, update)
+		item := s.find(func(item *InventoryItem) bool { return strings.HasSuffix(r.URL.Path, "/"+item.ProductID) })
+		item.PriceCents, item.Quantity = update.PriceCents, update.Quantity
+		item.EffectiveAsOf = Timestamp{Time: item.EffectiveAsOf.Add(time.Second)}
+		_ = json.NewEncoder(w).Encode(InventoryItemResponse{InventoryItem: *item})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *adjustTestServer) find(match func(*InventoryItem) bool) *InventoryItem {
+	for i := range s.inventory {
+		if match(&s.inventory[i]) {
+			return &s.inventory[i]
+		}
+	}
+	return nil
+}
+
+func newAdjustBackend(t *testing.T) (*adjustTestServer, *Client) {
+	t.Helper()
+	backend := &adjustTestServer{deleteTestServer: newDeleteTestServer(), sellOnRead: make(map[int]bool)}
+	backend.inventory[0].Quantity = 5
+	server := httptest.NewServer(backend)
+	t.Cleanup(server.Close)
+	return backend, NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+}
+
+func TestClient_AdjustQuantity(t *testing.T) {
+	backend, client := newAdjustBackend(t)
+
+	item, err := client.AdjustQuantity(context.Background(), "a", -2)
+	if err != nil {
+		t.Fatalf("AdjustQuantity() error = %v", err)
+	}
+	if item.Quantity != 3 || backend.inventory[0].Quantity != 3 {
+		t.Errorf("quantity = %d, stored %d, want 3", item.Quantity, backend.inventory[0].Quantity)
+	}
+	if len(backend.updates) != 1 || backend.updates[0] != (InventoryUpdateRequest{PriceCents: 100, Quantity: 3}) {
+		t.Errorf("updates = %+v", backend.updates)
+	}
+
+	if _, err := client.AdjustQuantity(context.Background(), "a", 4); err != nil || backend.inventory[0].Quantity != 7 {
+		t.Errorf("AdjustQuantity(+4) = %d, %v", backend.inventory[0].Quantity, err)
+	}
+}
+
+func TestClient_AdjustQuantity_Conflict(t *testing.T) {
+	backend, client := newAdjustBackend(t)
+	// A sale lands between the first read and the check.
+	backend.sellOnRead[2] = true
+
+	item, err := client.AdjustQuantity(context.Background(), "a", -2)
+	if err != nil {
+		t.Fatalf("AdjustQuantity() error = %v", err)
+	}
+	if item.Quantity != 2 || backend.reads != 4 || len(backend.updates) != 1 {
+		t.Errorf("quantity = %d after %d reads and %d updates, want 2 after 4 and 1", item.Quantity, backend.reads, len(backend.updates))
+	}
+
+	backend, client = newAdjustBackend(t)
+	for n := 2; n <= 2*adjustAttempts; n += 2 {
+		backend.sellOnRead[n] = true
+	}
+	_, err = client.AdjustQuantity(context.Background(), "a", -1)
+	if !errors.Is(err, ErrPreconditionFailed) || !strings.Contains(err.Error(), "after 5 attempts") || len(backend.updates) != 0 {
+		t.Errorf("AdjustQuantity(always changing) error = %v, updates %d", err, len(backend.updates))
+	}
+}
+
+func TestClient_AdjustQuantity_Errors(t *testing.T) {
+	backend, client := newAdjustBackend(t)
+	var validationErr *ValidationError
+
+	if _, err := client.AdjustQuantity(context.Background(), "", 1); !errors.As(err, &validationErr) {
+		t.Errorf("AdjustQuantity(empty id) error = %v", err)
+	}
+	if _, err := client.AdjustQuantity(context.Background(), "a", -6); !errors.As(err, &validationErr) || len(backend.updates) != 0 {
+		t.Errorf("AdjustQuantity(-6) error = %v", err)
+	}
+	var apiErr *APIError
+	if _, err := client.AdjustQuantity(context.Background(), "missing", 1); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("AdjustQuantity(missing) error = %v", err)
+	}
+	backend.inventory[0].ProductID = ""
+	if _, err := client.AdjustQuantity(context.Background(), "a", 1); !errors.As(err, &validationErr) {
+		t.Errorf("AdjustQuantity(no product) error = %v", err)
+	}
+}
@@ -0,0 +1,303 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Operation is one write in a RunBatch batch: a new price, a new quantity,
+// or both, for one listing.
+type Operation struct {
+	// ListingID is the inventory item ID
+	ListingID string
+
+	// PriceCents is the new price (0 keeps the current price)
+	PriceCents int
+
+	// Quantity is the new quantity (nil keeps the current quantity)
+	Quantity *int
+}
+
+// PriceOperation returns an operation that sets a listing's price.
+func PriceOperation(listingID string, priceCents int) Operation {
+	return Operation{ListingID: listingID, PriceCents: priceCents}
+}
+
+// QuantityOperation returns an operation that sets a listing's quantity.
+func QuantityOperation(listingID string, quantity int) Operation {
+	return Operation{ListingID: listingID, Quantity: &quantity}
+}
+
+// String renders the operation for logs, such as "abc: price 125, quantity 3".
+func (o Operation) String() string {
+	s := o.ListingID + ":"
+	if o.PriceCents != 0 {
+		s += fmt.Sprintf(" price %d", o.PriceCents)
+	}
+	if o.Quantity != nil {
+		if o.PriceCents != 0 {
+			s += ","
+		}
+		s += fmt.Sprintf(" quantity %d", *o.Quantity)
+	}
+	return s
+}
+
+// validate checks o without contacting the API.
+func (o Operation) validate() error {
+	switch {
+	case o.ListingID == "":
+		return NewValidationError("listing_id", "listing_id cannot be empty")
+	case o.PriceCents < 0:
+		return NewValidationError("price_cents", fmt.Sprintf("listing %s: must not be negative", o.ListingID))
+	case o.Quantity != nil && *o.Quantity < 0:
+		return NewValidationError("quantity", fmt.Sprintf("listing %s: must not be negative", o.ListingID))
+	case o.PriceCents == 0 && o.Quantity == nil:
+		return NewValidationError("operation", fmt.Sprintf("listing %s: sets neither price nor quantity", o.ListingID))
+	}
+	return nil
+}
+
+// RollbackPolicy decides what RunBatch does when a write fails.
+type RollbackPolicy string
+
+const (
+	// RollbackManual stops the batch and returns the compensating
+	// operations without running them, so the caller can review them and
+	// pass them to RunBatch later.
+	RollbackManual RollbackPolicy = "manual"
+
+	// RollbackAutomatic stops the batch and runs the compensating
+	// operations right away, restoring every listing the batch changed.
+	RollbackAutomatic RollbackPolicy = "automatic"
+)
+
+// BatchStatus is the outcome of one operation in a batch.
+type BatchStatus string
+
+const (
+	// BatchApplied means the operation was written and is still in effect.
+	BatchApplied BatchStatus = "applied"
+
+	// BatchFailed means the operation could not be written. It is the
+	// operation that stopped the batch.
+	BatchFailed BatchStatus = "failed"
+
+	// BatchSkipped means the operation was not attempted because an earlier
+	// one failed.
+	BatchSkipped BatchStatus = "skipped"
+
+	// BatchReverted means the operation was written and then undone.
+	BatchReverted BatchStatus = "reverted"
+
+	// BatchRevertFailed means the operation was written but undoing it
+	// failed; see BatchOperationResult.RevertErr.
+	BatchRevertFailed BatchStatus = "revert_failed"
+)
+
+// BatchOperationResult is the outcome of one input operation.
+type BatchOperationResult struct {
+	// Index is the operation's position in the input slice
+	Index int
+
+	Operation Operation
+	Status    BatchStatus
+
+	// Previous is the listing as read just before the write, or nil if the
+	// operation was not attempted or the read failed
+	Previous *InventoryItem
+
+	// Err is the write error for BatchFailed operations
+	Err error
+
+	// RevertErr is the compensation error for BatchRevertFailed operations
+	RevertErr error
+}
+
+// BatchReport is the outcome of RunBatch.
+type BatchReport struct {
+	// Results holds one entry per input operation, in input order
+	Results []BatchOperationResult
+
+	// Compensations are the operations that restore every applied write to
+	// its previous price and quantity, newest first. It is empty if nothing
+	// failed. With RollbackAutomatic it lists what was run.
+	Compensations []Operation
+}
+
+// Count returns the number of operations with the given status.
+func (r *BatchReport) Count(status BatchStatus) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Err returns an error describing the failed operation and any failed
+// reverts, or nil if every operation was applied.
+func (r *BatchReport) Err() error {
+	var errs []error
+	for _, result := range r.Results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("operation %d (%s): %w", result.Index, result.Operation, result.Err))
+		}
+		if result.RevertErr != nil {
+			errs = append(errs, fmt.Errorf("reverting operation %d (%s): %w", result.Index, result.Operation, result.RevertErr))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("batch failed: %w", errors.Join(errs...))
+}
+
+// String renders a one-line summary of the report.
+func (r *BatchReport) String() string {
+	return fmt.Sprintf("%d applied, %d failed, %d skipped, %d reverted, %d revert failed",
+		r.Count(BatchApplied), r.Count(BatchFailed), r.Count(BatchSkipped),
+		r.Count(BatchReverted), r.Count(BatchRevertFailed))
+}
+
+// RunBatch applies operations in order as one client-side transaction. The
+// API has no transactions, so each listing is read just before it is
+// written, and if a write fails the batch stops and the writes already made
+// are compensated by restoring each listing's previous price and quantity.
+// policy decides whether the compensations are run or only returned.
+//
+// A compensation is not run if the listing has changed since the batch
+// wrote it, such as when a sale lands in between, so it cannot undo someone
+// else's change; it is reported with an error matching
+// ErrPreconditionFailed instead. The write that failed is not compensated,
+// so after a network error check that listing by hand. Compensations run
+// even if ctx has been cancelled, so an interrupted batch is still rolled
+// back.
+//
+// All operations are validated before anything is written. The returned
+// error is non-nil only for invalid operations or an unknown policy; check
+// BatchReport.Err for write failures.
+//
+// Example:
+//
+//	report, err := client.RunBatch(ctx, []manapool.Operation{
+//	    manapool.PriceOperation("listing-a", 450),
+//	    manapool.QuantityOperation("listing-b", 0),
+//	}, manapool.RollbackAutomatic)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if err := report.Err(); err != nil {
+//	    log.Printf("batch rolled back: %s: %v", report, err)
+//	}
+func (c *Client) RunBatch(ctx context.Context, ops []Operation, policy RollbackPolicy) (*BatchReport, error) {
+	if policy != RollbackManual && policy != RollbackAutomatic {
+		return nil, NewValidationError("policy", fmt.Sprintf("unknown rollback policy %q", policy))
+	}
+	for _, op := range ops {
+		if err := op.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &BatchReport{Results: make([]BatchOperationResult, len(ops))}
+	for i, op := range ops {
+		report.Results[i] = BatchOperationResult{Index: i, Operation: op, Status: BatchSkipped}
+	}
+
+	var applied []int
+	for i, op := range ops {
+		result := &report.Results[i]
+		if err := ctx.Err(); err != nil {
+			result.Status, result.Err = BatchFailed, err
+			break
+		}
+		previous, err := c.applyOperation(ctx, op)
+		result.Previous = previous
+		if err != nil {
+			result.Status, result.Err = BatchFailed, err
+			break
+		}
+		result.Status = BatchApplied
+		applied = append(applied, i)
+	}
+	if len(applied) == len(ops) {
+		return report, nil
+	}
+
+	for n := len(applied) - 1; n >= 0; n-- {
+		previous := report.Results[applied[n]].Previous
+		quantity := previous.Quantity
+		report.Compensations = append(report.Compensations,
+			Operation{ListingID: previous.ID, PriceCents: previous.PriceCents, Quantity: &quantity})
+	}
+	if policy == RollbackManual {
+		return report, nil
+	}
+
+	// Roll back even if ctx was what stopped the batch.
+	rollbackCtx := context.WithoutCancel(ctx)
+	for n := len(applied) - 1; n >= 0; n-- {
+		result := &report.Results[applied[n]]
+		if err := c.revertOperation(rollbackCtx, result.Operation, *result.Previous); err != nil {
+			c.logger.Errorf("Failed to revert batch operation %d (%s): %v", result.Index, result.Operation, err)
+			result.Status, result.RevertErr = BatchRevertFailed, err
+			continue
+		}
+		result.Status = BatchReverted
+	}
+	return report, nil
+}
+
+// applyOperation reads the listing, writes op over it, and returns the
+// listing as it was before the write.
+func (c *Client) applyOperation(ctx context.Context, op Operation) (*InventoryItem, error) {
+	read, err := c.GetInventoryListing(WithRequestOptions(ctx, BypassCache()), op.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	previous := read.InventoryItem
+	if err := validateConditionalListing(previous); err != nil {
+		return nil, err
+	}
+
+	update := InventoryUpdateRequest{PriceCents: previous.PriceCents, Quantity: previous.Quantity}
+	if op.PriceCents != 0 {
+		update.PriceCents = op.PriceCents
+	}
+	if op.Quantity != nil {
+		update.Quantity = *op.Quantity
+	}
+	if _, err := c.UpdateSellerInventoryByProduct(ctx, previous.ProductType, previous.ProductID, update); err != nil {
+		return &previous, err
+	}
+	return &previous, nil
+}
+
+// revertOperation restores previous if the listing still holds what op
+// wrote.
+func (c *Client) revertOperation(ctx context.Context, op Operation, previous InventoryItem) error {
+	read, err := c.GetInventoryListing(WithRequestOptions(ctx, BypassCache()), previous.ID)
+	if err != nil {
+		return err
+	}
+	current := read.InventoryItem
+
+	wantPrice, wantQuantity := previous.PriceCents, previous.Quantity
+	if op.PriceCents != 0 {
+		wantPrice = op.PriceCents
+	}
+	if op.Quantity != nil {
+		wantQuantity = *op.Quantity
+	}
+	if current.PriceCents != wantPrice || current.Quantity != wantQuantity {
+		return fmt.Errorf("listing %s changed since the batch wrote it (price %d, quantity %d): %w",
+			previous.ID, current.PriceCents, current.Quantity, ErrPreconditionFailed)
+	}
+
+	restore := InventoryUpdateRequest{PriceCents: previous.PriceCents, Quantity: previous.Quantity}
+	_, err = c.UpdateSellerInventoryByProduct(ctx, previous.ProductType, previous.ProductID, restore)
+	return err
+}
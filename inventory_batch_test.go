@@ -0,0 +1,122 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestClient_RunBatch(t *testing.T) {
+	ctx := context.Background()
+	backend, client := newAdjustBackend(t)
+
+	report, err := client.RunBatch(ctx, []Operation{
+		PriceOperation("a", 250),
+		{ListingID: "b", PriceCents: 300, Quantity: intPtr(4)},
+	}, RollbackAutomatic)
+	if err != nil {
+		t.Fatalf("RunBatch() error = %v", err)
+	}
+	if report.Err() != nil || report.String() != "2 applied, 0 failed, 0 skipped, 0 reverted, 0 revert failed" || len(report.Compensations) != 0 {
+		t.Errorf("report = %s, %v", report, report.Err())
+	}
+	a, b := backend.inventory[0], backend.inventory[1]
+	if a.PriceCents != 250 || a.Quantity != 5 || b.PriceCents != 300 || b.Quantity != 4 {
+		t.Errorf("listings = %+v, %+v", a, b)
+	}
+	if report.Results[0].Previous.PriceCents != 100 {
+		t.Errorf("previous = %+v", report.Results[0].Previous)
+	}
+}
+
+func TestClient_RunBatch_Rollback(t *testing.T) {
+	ctx := context.Background()
+	ops := []Operation{PriceOperation("a", 250), QuantityOperation("b", 4), PriceOperation("missing", 10), PriceOperation("c", 999)}
+
+	backend, client := newAdjustBackend(t)
+	report, err := client.RunBatch(ctx, ops, RollbackAutomatic)
+	if err != nil {
+		t.Fatalf("RunBatch() error = %v", err)
+	}
+	if report.String() != "0 applied, 1 failed, 1 skipped, 2 reverted, 0 revert failed" {
+		t.Errorf("report = %s", report)
+	}
+	var apiErr *APIError
+	if err := report.Err(); !errors.As(err, &apiErr) || !strings.Contains(err.Error(), "operation 2 (missing: price 10)") {
+		t.Errorf("Err() = %v", err)
+	}
+	if got := fmt.Sprint(report.Compensations); got != "[b: price 100, quantity 1 a: price 100, quantity 5]" {
+		t.Errorf("compensations = %s", got)
+	}
+	a, b := backend.inventory[0], backend.inventory[1]
+	if a.PriceCents != 100 || a.Quantity != 5 || b.Quantity != 1 || backend.inventory[2].PriceCents != 100 {
+		t.Errorf("listings after rollback = %+v, %+v", a, b)
+	}
+
+	// A manual rollback leaves the writes in place for the caller to undo.
+	backend, client = newAdjustBackend(t)
+	report, _ = client.RunBatch(ctx, ops, RollbackManual)
+	if report.Count(BatchApplied) != 2 || backend.inventory[0].PriceCents != 250 || len(report.Compensations) != 2 {
+		t.Fatalf("manual report = %s", report)
+	}
+	undo, err := client.RunBatch(ctx, report.Compensations, RollbackManual)
+	if err != nil || undo.Err() != nil || backend.inventory[0].PriceCents != 100 || backend.inventory[1].Quantity != 1 {
+		t.Errorf("undo = %v, %v; listings %+v", undo, err, backend.inventory[:2])
+	}
+}
+
+func TestClient_RunBatch_RevertConflict(t *testing.T) {
+	backend, client := newAdjustBackend(t)
+	// Reads 1-3 apply the batch, read 4 reverts b, and a sale lands on a
+	// just before read 5 reverts it.
+	backend.sellOnRead[5] = true
+
+	report, err := client.RunBatch(context.Background(),
+		[]Operation{PriceOperation("a", 250), QuantityOperation("b", 4), PriceOperation("missing", 10)}, RollbackAutomatic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := report.Results[0]
+	if result.Status != BatchRevertFailed || !errors.Is(result.RevertErr, ErrPreconditionFailed) || report.Results[1].Status != BatchReverted {
+		t.Errorf("results = %+v", report.Results)
+	}
+	if a := backend.inventory[0]; a.PriceCents != 250 || a.Quantity != 4 {
+		t.Errorf("listing a = %+v, want the sale kept", a)
+	}
+	if err := report.Err(); err == nil || !strings.Contains(err.Error(), "reverting operation 0") {
+		t.Errorf("Err() = %v", err)
+	}
+}
+
+func TestClient_RunBatch_Validation(t *testing.T) {
+	_, client := newAdjustBackend(t)
+	ctx := context.Background()
+
+	var validationErr *ValidationError
+	for _, ops := range [][]Operation{
+		{{PriceCents: 100}},
+		{PriceOperation("a", -1)},
+		{QuantityOperation("a", -1)},
+		{{ListingID: "a"}},
+	} {
+		if _, err := client.RunBatch(ctx, ops, RollbackManual); !errors.As(err, &validationErr) {
+			t.Errorf("RunBatch(%v) error = %v", ops, err)
+		}
+	}
+	if _, err := client.RunBatch(ctx, nil, "sometimes"); !errors.As(err, &validationErr) {
+		t.Errorf("unknown policy error = %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	report, err := client.RunBatch(cancelled, []Operation{PriceOperation("a", 250)}, RollbackAutomatic)
+	if err != nil || !errors.Is(report.Err(), context.Canceled) {
+		t.Errorf("cancelled batch = %v, %v", report, err)
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
@@ -0,0 +1,505 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MaxBulkItems is the largest number of items the API accepts in a single
+// bulk inventory write.
+const MaxBulkItems = 2000
+
+// DefaultBulkConcurrency is the default number of chunks UpsertInventory
+// sends at the same time.
+const DefaultBulkConcurrency = 2
+
+// InventoryUpsert sets the price and quantity of one listing. The listing is
+// identified by exactly one of:
+//   - TCGPlayerSKU
+//   - ProductType and ProductID
+//   - ScryfallID with LanguageID, FinishID, and ConditionID
+//   - TCGPlayerID with LanguageID (FinishID and ConditionID optional)
+//
+// UpsertInventory routes each item to the bulk endpoint for its identifier,
+// so a single batch may mix identifier kinds.
+type InventoryUpsert struct {
+	TCGPlayerSKU int
+
+	ProductType string
+	ProductID   string
+
+	ScryfallID  string
+	TCGPlayerID int
+	LanguageID  string
+	FinishID    string
+	ConditionID string
+
+	PriceCents int
+	Quantity   int
+}
+
+// upsertKind identifies the bulk endpoint an InventoryUpsert is sent to.
+type upsertKind string
+
+const (
+	upsertBySKU         upsertKind = "tcgsku"
+	upsertByProduct     upsertKind = "product"
+	upsertByScryfall    upsertKind = "scryfall_id"
+	upsertByTCGPlayerID upsertKind = "tcgplayer_id"
+)
+
+// kind validates u and returns the endpoint it belongs to.
+func (u InventoryUpsert) kind() (upsertKind, error) {
+	var kinds []upsertKind
+	if u.TCGPlayerSKU != 0 {
+		kinds = append(kinds, upsertBySKU)
+	}
+	if u.ProductType != "" || u.ProductID != "" {
+		kinds = append(kinds, upsertByProduct)
+	}
+	if u.ScryfallID != "" {
+		kinds = append(kinds, upsertByScryfall)
+	}
+	if u.TCGPlayerID != 0 {
+		kinds = append(kinds, upsertByTCGPlayerID)
+	}
+
+	switch {
+	case len(kinds) == 0:
+		return "", NewValidationError("item", "one of tcgplayer_sku, product_id, scryfall_id, or tcgplayer_id is required")
+	case len(kinds) > 1:
+		return "", NewValidationError("item", fmt.Sprintf("identifiers are mutually exclusive, got %v", kinds))
+	case u.PriceCents <= 0:
+		return "", NewValidationError("price_cents", "must be positive")
+	case u.Quantity < 0:
+		return "", NewValidationError("quantity", "must not be negative")
+	}
+	if u.LanguageID != "" {
+		if err := ValidateLanguageID(u.LanguageID); err != nil {
+			return "", err
+		}
+	}
+
+	switch kind := kinds[0]; kind {
+	case upsertBySKU:
+		if u.TCGPlayerSKU < 0 {
+			return "", NewValidationError("tcgplayer_sku", "must be positive")
+		}
+		return kind, nil
+	case upsertByProduct:
+		if u.ProductType == "" || u.ProductID == "" {
+			return "", NewValidationError("product", "product_type and product_id are both required")
+		}
+		return kind, nil
+	case upsertByScryfall:
+		if u.LanguageID == "" || u.FinishID == "" || u.ConditionID == "" {
+			return "", NewValidationError("scryfall_id", "language_id, finish_id, and condition_id are required")
+		}
+		return kind, nil
+	default:
+		if u.TCGPlayerID < 0 {
+			return "", NewValidationError("tcgplayer_id", "must be positive")
+		}
+		if u.LanguageID == "" {
+			return "", NewValidationError("language_id", "is required")
+		}
+		return kind, nil
+	}
+}
+
+// identifier renders the listing identifier for failure reports.
+func (u InventoryUpsert) identifier() string {
+	switch {
+	case u.TCGPlayerSKU != 0:
+		return fmt.Sprintf("tcgplayer_sku:%d", u.TCGPlayerSKU)
+	case u.ProductID != "":
+		return fmt.Sprintf("product:%s/%s", u.ProductType, u.ProductID)
+	case u.ScryfallID != "":
+		return fmt.Sprintf("scryfall_id:%s/%s/%s/%s", u.ScryfallID, u.LanguageID, u.FinishID, u.ConditionID)
+	case u.TCGPlayerID != 0:
+		return fmt.Sprintf("tcgplayer_id:%d/%s", u.TCGPlayerID, u.LanguageID)
+	default:
+		return ""
+	}
+}
+
+// BulkOptions configures UpsertInventory.
+type BulkOptions struct {
+	// ChunkSize is the maximum number of items per request
+	// (default and maximum: MaxBulkItems).
+	ChunkSize int
+
+	// Concurrency is the number of chunks in flight at once
+	// (default: DefaultBulkConcurrency). Every request still waits on the
+	// client's rate limiter.
+	Concurrency int
+
+	// ChunkRetries is the number of times a chunk is retried after a network,
+	// server, or rate limit error (default: 0).
+	ChunkRetries int
+
+	// RetryBackoff is the pause before the first chunk retry, doubled on each
+	// further retry (default: 1s).
+	RetryBackoff time.Duration
+
+	// Rounder, if set, rounds every item's price before it is validated and
+	// sent. BulkItemResult.Item holds the rounded price; the caller's slice
+	// is not modified.
+	Rounder Rounder
+
+	// Guard, if set, checks every item's price after rounding. Clamped
+	// prices are sent and listed by BulkResult.ClampedItems; rejected items
+	// are reported as invalid. The change limit does not apply, since
+	// InventoryUpsert does not carry the current price.
+	Guard *PriceGuard
+}
+
+// BulkItemStatus is the outcome of a single item in a bulk upsert.
+type BulkItemStatus string
+
+const (
+	// BulkItemSucceeded means the item's chunk was accepted.
+	BulkItemSucceeded BulkItemStatus = "succeeded"
+
+	// BulkItemFailed means the item's chunk was rejected or could not be sent.
+	BulkItemFailed BulkItemStatus = "failed"
+
+	// BulkItemInvalid means the item failed local validation and was not sent.
+	BulkItemInvalid BulkItemStatus = "invalid"
+
+	// BulkItemSkipped means the item was not sent because ctx was cancelled.
+	BulkItemSkipped BulkItemStatus = "skipped"
+)
+
+// BulkItemResult is the outcome of one input item.
+type BulkItemResult struct {
+	// Index is the item's position in the input slice
+	Index int
+
+	Item   InventoryUpsert
+	Status BulkItemStatus
+
+	// Chunk is the index into BulkResult.Chunks, or -1 for invalid items
+	Chunk int
+
+	// Err is the validation or request error (nil on success)
+	Err error
+
+	// Clamped lists the price guard bounds the item's price was clamped to
+	// meet, or is empty
+	Clamped []string
+}
+
+// BulkChunkResult is the outcome of one bulk request.
+type BulkChunkResult struct {
+	// Endpoint is the bulk endpoint suffix, such as "tcgsku" or "scryfall_id"
+	Endpoint string
+
+	// Items are the input indexes sent in the chunk
+	Items []int
+
+	// Attempts is the number of times the chunk was sent
+	Attempts int
+
+	// Err is the final error (nil on success)
+	Err error
+}
+
+// Retried reports whether the chunk needed more than one attempt.
+func (r BulkChunkResult) Retried() bool {
+	return r.Attempts > 1
+}
+
+// BulkFailure is a machine-readable description of a failed item, suitable
+// for logging as JSON or writing to a retry queue.
+type BulkFailure struct {
+	Index      int    `json:"index"`
+	Identifier string `json:"identifier"`
+	Status     string `json:"status"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Code       string `json:"code,omitempty"`
+	Message    string `json:"message"`
+	Retryable  bool   `json:"retryable"`
+}
+
+// BulkResult reports the per-item and per-chunk outcome of UpsertInventory.
+type BulkResult struct {
+	Items  []BulkItemResult
+	Chunks []BulkChunkResult
+}
+
+// Count returns the number of items with the given status.
+func (r *BulkResult) Count(status BulkItemStatus) int {
+	n := 0
+	for _, item := range r.Items {
+		if item.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// RetriedChunks returns the chunks that needed more than one attempt.
+func (r *BulkResult) RetriedChunks() []BulkChunkResult {
+	var retried []BulkChunkResult
+	for _, chunk := range r.Chunks {
+		if chunk.Retried() {
+			retried = append(retried, chunk)
+		}
+	}
+	return retried
+}
+
+// ClampedItems returns the items whose price was clamped by the price guard.
+func (r *BulkResult) ClampedItems() []BulkItemResult {
+	var clamped []BulkItemResult
+	for _, item := range r.Items {
+		if len(item.Clamped) > 0 {
+			clamped = append(clamped, item)
+		}
+	}
+	return clamped
+}
+
+// Failures returns every item that did not succeed, in input order.
+func (r *BulkResult) Failures() []BulkFailure {
+	var failures []BulkFailure
+	for _, item := range r.Items {
+		if item.Status == BulkItemSucceeded {
+			continue
+		}
+		failure := BulkFailure{
+			Index:      item.Index,
+			Identifier: item.Item.identifier(),
+			Status:     string(item.Status),
+		}
+		switch {
+		case item.Err != nil:
+			failure.Message = item.Err.Error()
+			failure.Retryable = isTransientError(item.Err)
+		case item.Status == BulkItemSkipped:
+			failure.Message = "not sent"
+			failure.Retryable = true
+		}
+		var apiErr *APIError
+		if errors.As(item.Err, &apiErr) {
+			failure.StatusCode = apiErr.StatusCode
+			failure.Code = apiErr.Code
+		}
+		failures = append(failures, failure)
+	}
+	return failures
+}
+
+// Err returns an error summarizing failed items, or nil if all succeeded.
+func (r *BulkResult) Err() error {
+	failed := len(r.Items) - r.Count(BulkItemSucceeded)
+	if failed == 0 {
+		return nil
+	}
+
+	var errs []error
+	seen := make(map[error]bool)
+	for _, item := range r.Items {
+		if item.Err != nil && !seen[item.Err] {
+			seen[item.Err] = true
+			errs = append(errs, item.Err)
+		}
+	}
+	return fmt.Errorf("%d of %d inventory upserts failed: %w", failed, len(r.Items), errors.Join(errs...))
+}
+
+// String renders a one-line summary of the result.
+func (r *BulkResult) String() string {
+	return fmt.Sprintf("%d succeeded, %d failed, %d invalid, %d skipped in %d chunks (%d retried)",
+		r.Count(BulkItemSucceeded), r.Count(BulkItemFailed), r.Count(BulkItemInvalid),
+		r.Count(BulkItemSkipped), len(r.Chunks), len(r.RetriedChunks()))
+}
+
+// UpsertInventory sets price and quantity for any number of listings.
+//
+// Items are validated locally first; invalid items are reported and never
+// sent. Valid items are grouped by identifier kind, split into chunks of at
+// most opts.ChunkSize, and sent to the matching bulk endpoint with up to
+// opts.Concurrency requests in flight. A failed chunk does not stop the
+// others. The returned error is non-nil only if ctx is cancelled; check
+// BulkResult.Err for item failures.
+//
+// Example:
+//
+//	result, err := client.UpsertInventory(ctx, upserts, manapool.BulkOptions{
+//	    Concurrency:  4,
+//	    ChunkRetries: 2,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(result)
+//	for _, failure := range result.Failures() {
+//	    log.Printf("%s: %s", failure.Identifier, failure.Message)
+//	}
+func (c *Client) UpsertInventory(ctx context.Context, items []InventoryUpsert, opts BulkOptions) (*BulkResult, error) {
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 || chunkSize > MaxBulkItems {
+		chunkSize = MaxBulkItems
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+	backoff := opts.RetryBackoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+
+	if opts.Rounder != nil || opts.Guard != nil {
+		items = append([]InventoryUpsert(nil), items...)
+	}
+	if opts.Rounder != nil {
+		for i := range items {
+			items[i].PriceCents = opts.Rounder.Round(items[i].PriceCents)
+		}
+	}
+
+	result := &BulkResult{Items: make([]BulkItemResult, len(items))}
+	groups := make(map[upsertKind][]int)
+	for i, item := range items {
+		result.Items[i] = BulkItemResult{Index: i, Item: item, Status: BulkItemSkipped, Chunk: -1}
+		if check := opts.Guard.Check(0, item.PriceCents); len(check.Violations) > 0 {
+			if check.Rejected {
+				result.Items[i].Status = BulkItemInvalid
+				result.Items[i].Err = check.Err()
+				continue
+			}
+			items[i].PriceCents = check.Cents
+			result.Items[i].Item.PriceCents = check.Cents
+			result.Items[i].Clamped = check.Violations
+		}
+		kind, err := items[i].kind()
+		if err != nil {
+			result.Items[i].Status = BulkItemInvalid
+			result.Items[i].Err = err
+			continue
+		}
+		groups[kind] = append(groups[kind], i)
+	}
+
+	for _, kind := range []upsertKind{upsertBySKU, upsertByProduct, upsertByScryfall, upsertByTCGPlayerID} {
+		indexes := groups[kind]
+		for start := 0; start < len(indexes); start += chunkSize {
+			end := start + chunkSize
+			if end > len(indexes) {
+				end = len(indexes)
+			}
+			result.Chunks = append(result.Chunks, BulkChunkResult{Endpoint: string(kind), Items: indexes[start:end]})
+		}
+	}
+
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(result.Chunks); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range work {
+				chunk := &result.Chunks[n]
+				chunk.Err = retrySyncStep(ctx, opts.ChunkRetries, backoff, func() error {
+					chunk.Attempts++
+					return c.sendUpsertChunk(ctx, upsertKind(chunk.Endpoint), items, chunk.Items)
+				})
+			}
+		}()
+	}
+
+	sent := make([]bool, len(result.Chunks))
+	for n := range result.Chunks {
+		if ctx.Err() != nil {
+			break
+		}
+		work <- n
+		sent[n] = true
+	}
+	close(work)
+	wg.Wait()
+
+	for n, chunk := range result.Chunks {
+		for _, i := range chunk.Items {
+			result.Items[i].Chunk = n
+		}
+		if !sent[n] {
+			continue
+		}
+		if chunk.Err != nil && ctx.Err() == nil {
+			c.logger.Errorf("Bulk upsert chunk %d (%s, %d items) failed: %v", n, chunk.Endpoint, len(chunk.Items), chunk.Err)
+		}
+		for _, i := range chunk.Items {
+			if chunk.Err == nil {
+				result.Items[i].Status = BulkItemSucceeded
+			} else {
+				result.Items[i].Status = BulkItemFailed
+				result.Items[i].Err = chunk.Err
+			}
+		}
+	}
+
+	return result, ctx.Err()
+}
+
+// sendUpsertChunk sends the items at indexes to the bulk endpoint for kind.
+func (c *Client) sendUpsertChunk(ctx context.Context, kind upsertKind, items []InventoryUpsert, indexes []int) error {
+	var err error
+	switch kind {
+	case upsertBySKU:
+		batch := make([]InventoryBulkItemBySKU, len(indexes))
+		for i, index := range indexes {
+			item := items[index]
+			batch[i] = InventoryBulkItemBySKU{TCGPlayerSKU: item.TCGPlayerSKU, PriceCents: item.PriceCents, Quantity: item.Quantity}
+		}
+		_, err = c.CreateInventoryBulkBySKU(ctx, batch)
+	case upsertByProduct:
+		batch := make([]InventoryBulkItemByProduct, len(indexes))
+		for i, index := range indexes {
+			item := items[index]
+			batch[i] = InventoryBulkItemByProduct{ProductType: item.ProductType, ProductID: item.ProductID, PriceCents: item.PriceCents, Quantity: item.Quantity}
+		}
+		_, err = c.CreateInventoryBulkByProduct(ctx, batch)
+	case upsertByScryfall:
+		batch := make([]InventoryBulkItemByScryfall, len(indexes))
+		for i, index := range indexes {
+			item := items[index]
+			batch[i] = InventoryBulkItemByScryfall{
+				ScryfallID:  item.ScryfallID,
+				LanguageID:  item.LanguageID,
+				FinishID:    item.FinishID,
+				ConditionID: item.ConditionID,
+				PriceCents:  item.PriceCents,
+				Quantity:    item.Quantity,
+			}
+		}
+		_, err = c.CreateInventoryBulkByScryfall(ctx, batch)
+	case upsertByTCGPlayerID:
+		batch := make([]InventoryBulkItemByTCGPlayerID, len(indexes))
+		for i, index := range indexes {
+			item := items[index]
+			batch[i] = InventoryBulkItemByTCGPlayerID{
+				TCGPlayerID: item.TCGPlayerID,
+				LanguageID:  item.LanguageID,
+				PriceCents:  item.PriceCents,
+				Quantity:    item.Quantity,
+			}
+			if item.FinishID != "" {
+				finish := item.FinishID
+				batch[i].FinishID = &finish
+			}
+			if item.ConditionID != "" {
+				condition := item.ConditionID
+				batch[i].ConditionID = &condition
+			}
+		}
+		_, err = c.CreateInventoryBulkByTCGPlayerID(ctx, batch)
+	}
+	return err
+}
@@ -0,0 +1,246 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInventoryUpsert_kind(t *testing.T) {
+	tests := []struct {
+		name    string
+		item    InventoryUpsert
+		want    upsertKind
+		wantErr string
+	}{
+		{"sku", InventoryUpsert{TCGPlayerSKU: 1, PriceCents: 1}, upsertBySKU, ""},
+		{"product", InventoryUpsert{ProductType: "mtg_single", ProductID: "p", PriceCents: 1}, upsertByProduct, ""},
+		{"scryfall", InventoryUpsert{ScryfallID: "s", LanguageID: "EN", FinishID: "NF", ConditionID: "NM", PriceCents: 1}, upsertByScryfall, ""},
+		{"tcgplayer id", InventoryUpsert{TCGPlayerID: 4, LanguageID: "EN", PriceCents: 1}, upsertByTCGPlayerID, ""},
+		{"no identifier", InventoryUpsert{PriceCents: 1}, "", "is required"},
+		{"two identifiers", InventoryUpsert{TCGPlayerSKU: 1, ScryfallID: "s", PriceCents: 1}, "", "mutually exclusive"},
+		{"zero price", InventoryUpsert{TCGPlayerSKU: 1}, "", "price_cents"},
+		{"negative quantity", InventoryUpsert{TCGPlayerSKU: 1, PriceCents: 1, Quantity: -1}, "", "quantity"},
+		{"negative sku", InventoryUpsert{TCGPlayerSKU: -1, PriceCents: 1}, "", "tcgplayer_sku"},
+		{"partial product", InventoryUpsert{ProductID: "p", PriceCents: 1}, "", "product_type"},
+		{"partial scryfall", InventoryUpsert{ScryfallID: "s", LanguageID: "EN", PriceCents: 1}, "", "condition_id"},
+		{"tcgplayer id without language", InventoryUpsert{TCGPlayerID: 4, PriceCents: 1}, "", "language_id"},
+		{"negative tcgplayer id", InventoryUpsert{TCGPlayerID: -4, LanguageID: "EN", PriceCents: 1}, "", "tcgplayer_id"},
+		{"unknown language", InventoryUpsert{TCGPlayerID: 4, LanguageID: "jp", PriceCents: 1}, "", "unknown language ID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.item.kind()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("kind() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("kind() = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_UpsertInventory(t *testing.T) {
+	var mu sync.Mutex
+	sizes := make(map[string][]int)
+	var failOnce int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var items []map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		endpoint := strings.TrimPrefix(r.URL.Path, "/seller/inventory/")
+
+		mu.Lock()
+		sizes[endpoint] = append(sizes[endpoint], len(items))
+		mu.Unlock()
+
+		switch {
+		case endpoint == "tcgplayer_id":
+			if items[0]["finish_id"] != nil || items[0]["condition_id"] != "LP" {
+				t.Errorf("tcgplayer_id item = %v", items[0])
+			}
+		case endpoint == "product" && atomic.AddInt32(&failOnce, 1) == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		case endpoint == "scryfall_id":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status":400,"message":"Bad request","code":"invalid_language"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"inventory":[]}`))
+	}))
+	defer server.Close()
+
+	var items []InventoryUpsert
+	for sku := 1; sku <= 5; sku++ {
+		items = append(items, InventoryUpsert{TCGPlayerSKU: sku, PriceCents: 100, Quantity: 1})
+	}
+	items = append(items,
+		InventoryUpsert{ProductType: "mtg_sealed", ProductID: "box", PriceCents: 9999, Quantity: 2},
+		InventoryUpsert{ScryfallID: "s", LanguageID: "JA", FinishID: "NF", ConditionID: "NM", PriceCents: 50},
+		InventoryUpsert{TCGPlayerID: 9, LanguageID: "EN", ConditionID: "LP", PriceCents: 75},
+		InventoryUpsert{PriceCents: 1},
+	)
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0), WithLimiter(nil))
+	result, err := client.UpsertInventory(context.Background(), items, BulkOptions{
+		ChunkSize:    2,
+		Concurrency:  3,
+		ChunkRetries: 1,
+		RetryBackoff: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("UpsertInventory() error = %v", err)
+	}
+
+	if got := sizes["tcgsku"]; len(got) != 3 || got[0]+got[1]+got[2] != 5 {
+		t.Errorf("tcgsku chunk sizes = %v, want three chunks totalling 5", got)
+	}
+	if len(result.Chunks) != 6 {
+		t.Errorf("chunks = %d, want 6", len(result.Chunks))
+	}
+	if got := result.String(); got != "7 succeeded, 1 failed, 1 invalid, 0 skipped in 6 chunks (1 retried)" {
+		t.Errorf("String() = %q", got)
+	}
+	if retried := result.RetriedChunks(); len(retried) != 1 || retried[0].Endpoint != "product" || retried[0].Attempts != 2 {
+		t.Errorf("RetriedChunks() = %+v", retried)
+	}
+
+	failures := result.Failures()
+	if len(failures) != 2 {
+		t.Fatalf("Failures() = %+v, want 2", failures)
+	}
+	if f := failures[0]; f.Index != 6 || f.Status != "failed" || f.StatusCode != 400 || f.Code != "invalid_language" || f.Retryable ||
+		f.Identifier != "scryfall_id:s/JA/NF/NM" {
+		t.Errorf("failures[0] = %+v", f)
+	}
+	if f := failures[1]; f.Index != 8 || f.Status != "invalid" {
+		t.Errorf("failures[1] = %+v", f)
+	}
+	if result.Items[8].Chunk != -1 || result.Items[0].Chunk != 0 {
+		t.Errorf("item chunks = %d, %d", result.Items[8].Chunk, result.Items[0].Chunk)
+	}
+
+	err = result.Err()
+	var apiErr *APIError
+	var valErr *ValidationError
+	if err == nil || !errors.As(err, &apiErr) || !errors.As(err, &valErr) {
+		t.Errorf("Err() = %v, want joined API and validation errors", err)
+	}
+}
+
+func TestClient_UpsertInventory_Rounder(t *testing.T) {
+	var sent []InventoryBulkItemBySKU
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"inventory":[]}`))
+	}))
+	defer server.Close()
+
+	items := []InventoryUpsert{{TCGPlayerSKU: 1, PriceCents: 487, Quantity: 1}, {TCGPlayerSKU: 2, PriceCents: 3, Quantity: 1}}
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0), WithLimiter(nil))
+	result, err := client.UpsertInventory(context.Background(), items, BulkOptions{Rounder: RoundToNearest(25)})
+	if err != nil || result.Err() != nil {
+		t.Fatalf("UpsertInventory() error = %v, %v", err, result.Err())
+	}
+	if len(sent) != 2 || sent[0].PriceCents != 475 || sent[1].PriceCents != 25 {
+		t.Errorf("sent = %+v", sent)
+	}
+	if result.Items[0].Item.PriceCents != 475 || items[0].PriceCents != 487 {
+		t.Errorf("result price = %d, caller price = %d", result.Items[0].Item.PriceCents, items[0].PriceCents)
+	}
+}
+
+func TestClient_UpsertInventory_Guard(t *testing.T) {
+	var sent []InventoryBulkItemBySKU
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"inventory":[]}`))
+	}))
+	defer server.Close()
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0), WithLimiter(nil))
+	items := []InventoryUpsert{{TCGPlayerSKU: 1, PriceCents: 1, Quantity: 1}, {TCGPlayerSKU: 2, PriceCents: 300, Quantity: 1}}
+
+	clamp, _ := NewPriceGuard(WithPriceFloor(25))
+	result, err := client.UpsertInventory(context.Background(), items, BulkOptions{Guard: clamp})
+	if err != nil || result.Err() != nil {
+		t.Fatalf("UpsertInventory() error = %v, %v", err, result.Err())
+	}
+	if len(sent) != 2 || sent[0].PriceCents != 25 || sent[1].PriceCents != 300 {
+		t.Errorf("sent = %+v", sent)
+	}
+	if clamped := result.ClampedItems(); len(clamped) != 1 || clamped[0].Index != 0 || clamped[0].Item.PriceCents != 25 {
+		t.Errorf("ClampedItems() = %+v", clamped)
+	}
+
+	reject, _ := NewPriceGuard(WithPriceFloor(25), RejectOutOfBounds())
+	result, err = client.UpsertInventory(context.Background(), items, BulkOptions{Guard: reject})
+	if err != nil {
+		t.Fatalf("UpsertInventory() error = %v", err)
+	}
+	if result.Items[0].Status != BulkItemInvalid || !strings.Contains(result.Items[0].Err.Error(), "below floor") {
+		t.Errorf("rejected item = %+v", result.Items[0])
+	}
+	if len(sent) != 1 || sent[0].TCGPlayerSKU != 2 || items[0].PriceCents != 1 {
+		t.Errorf("sent = %+v, caller price = %d", sent, items[0].PriceCents)
+	}
+}
+
+func TestClient_UpsertInventory_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"inventory":[]}`))
+	}))
+	defer server.Close()
+
+	items := []InventoryUpsert{
+		{TCGPlayerSKU: 1, PriceCents: 1},
+		{TCGPlayerSKU: 2, PriceCents: 1},
+		{TCGPlayerSKU: 3, PriceCents: 1},
+	}
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0), WithLimiter(nil))
+	result, err := client.UpsertInventory(ctx, items, BulkOptions{ChunkSize: 1, Concurrency: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("UpsertInventory() error = %v, want context.Canceled", err)
+	}
+	if result.Count(BulkItemSkipped) == 0 {
+		t.Errorf("result = %s, want skipped items", result)
+	}
+	for _, failure := range result.Failures() {
+		if failure.Status == "skipped" && (!failure.Retryable || failure.Message != "not sent") {
+			t.Errorf("skipped failure = %+v", failure)
+		}
+	}
+	if result.Err() == nil {
+		t.Error("Err() = nil")
+	}
+}
+
+func TestBulkResult_Err_Success(t *testing.T) {
+	result := &BulkResult{Items: []BulkItemResult{{Status: BulkItemSucceeded}}}
+	if err := result.Err(); err != nil {
+		t.Errorf("Err() = %v", err)
+	}
+	if failures := result.Failures(); failures != nil {
+		t.Errorf("Failures() = %v", failures)
+	}
+}
@@ -0,0 +1,82 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// UpdateInventoryIfUnchanged updates a listing only if it is unchanged since
+// read was fetched, such as with GetInventoryListing. The listing's
+// effective_as_of timestamp is its version: if that, its quantity, or its
+// price differ from read, the update is not sent and the returned error
+// matches ErrPreconditionFailed.
+//
+// The API has no If-Match header or version field on writes, so the check is
+// made by the client, by reading the listing again just before the update.
+// It catches writes made while the caller was working from read, but a write
+// landing between the check and the update can still be lost. The check
+// bypasses the response cache. If the API starts enforcing preconditions, a
+// 412 response matches ErrPreconditionFailed too.
+//
+// Example:
+//
+//	read, err := client.GetInventoryListing(ctx, "listing-id")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	item := read.InventoryItem
+//	update := manapool.InventoryUpdateRequest{PriceCents: item.PriceCents - 25, Quantity: item.Quantity}
+//	_, err = client.UpdateInventoryIfUnchanged(ctx, item, update)
+//	if errors.Is(err, manapool.ErrPreconditionFailed) {
+//	    // Someone else changed the listing; read it again and decide anew
+//	}
+func (c *Client) UpdateInventoryIfUnchanged(ctx context.Context, read InventoryItem, update InventoryUpdateRequest) (*InventoryListingResponse, error) {
+	if err := validateConditionalListing(read); err != nil {
+		return nil, err
+	}
+	if err := c.checkListingUnchanged(ctx, read); err != nil {
+		return nil, err
+	}
+	return c.UpdateSellerInventoryByProduct(ctx, read.ProductType, read.ProductID, update)
+}
+
+// DeleteInventoryIfUnchanged deletes a listing only if it is unchanged since
+// read was fetched. The check works as for UpdateInventoryIfUnchanged.
+func (c *Client) DeleteInventoryIfUnchanged(ctx context.Context, read InventoryItem) (*InventoryListingResponse, error) {
+	if err := validateConditionalListing(read); err != nil {
+		return nil, err
+	}
+	if err := c.checkListingUnchanged(ctx, read); err != nil {
+		return nil, err
+	}
+	return c.DeleteSellerInventoryByProduct(ctx, read.ProductType, read.ProductID)
+}
+
+// validateConditionalListing checks that read identifies a listing and the
+// product it is written through.
+func validateConditionalListing(read InventoryItem) error {
+	if read.ID == "" {
+		return NewValidationError("id", "id cannot be empty")
+	}
+	if read.ProductType == "" || read.ProductID == "" {
+		return NewValidationError("product", fmt.Sprintf("listing %s has no product type or ID", read.ID))
+	}
+	return nil
+}
+
+// checkListingUnchanged reads the listing again and returns an error matching
+// ErrPreconditionFailed if its quantity, price, or effective_as_of timestamp
+// differ from read.
+func (c *Client) checkListingUnchanged(ctx context.Context, read InventoryItem) error {
+	current, err := c.GetInventoryListing(WithRequestOptions(ctx, BypassCache()), read.ID)
+	if err != nil {
+		return err
+	}
+	now := current.InventoryItem
+	if now.Quantity != read.Quantity || now.PriceCents != read.PriceCents || !now.EffectiveAsOf.Equal(read.EffectiveAsOf.Time) {
+		return fmt.Errorf("listing %s changed since it was read (effective as of %s, was %s): %w",
+			read.ID, now.EffectiveAsOf.Format(time.RFC3339), read.EffectiveAsOf.Format(time.RFC3339), ErrPreconditionFailed)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_UpdateInventoryIfUnchanged(t *testing.T) {
+	backend, client := newAdjustBackend(t)
+	read := backend.inventory[0]
+
+	update := InventoryUpdateRequest{PriceCents: 90, Quantity: read.Quantity}
+	if _, err := client.UpdateInventoryIfUnchanged(context.Background(), read, update); err != nil {
+		t.Fatalf("UpdateInventoryIfUnchanged() error = %v", err)
+	}
+	if len(backend.updates) != 1 || backend.inventory[0].PriceCents != 90 {
+		t.Errorf("updates = %+v", backend.updates)
+	}
+
+	// read is now stale: the update above changed the price and version.
+	_, err := client.UpdateInventoryIfUnchanged(context.Background(), read, update)
+	if !errors.Is(err, ErrPreconditionFailed) || !strings.Contains(err.Error(), "listing a changed since it was read") {
+		t.Errorf("UpdateInventoryIfUnchanged(stale) error = %v", err)
+	}
+	if len(backend.updates) != 1 {
+		t.Errorf("stale update was sent: %+v", backend.updates)
+	}
+
+	var validationErr *ValidationError
+	if _, err := client.UpdateInventoryIfUnchanged(context.Background(), InventoryItem{ID: "a"}, update); !errors.As(err, &validationErr) {
+		t.Errorf("UpdateInventoryIfUnchanged(no product) error = %v", err)
+	}
+}
+
+func TestClient_DeleteInventoryIfUnchanged(t *testing.T) {
+	backend, client := newAdjustBackend(t)
+	read := backend.inventory[1]
+	backend.inventory[1].EffectiveAsOf = Timestamp{Time: read.EffectiveAsOf.Add(time.Minute)}
+
+	if _, err := client.DeleteInventoryIfUnchanged(context.Background(), read); !errors.Is(err, ErrPreconditionFailed) {
+		t.Errorf("DeleteInventoryIfUnchanged(stale) error = %v", err)
+	}
+	if _, err := client.DeleteInventoryIfUnchanged(context.Background(), InventoryItem{}); err == nil {
+		t.Error("DeleteInventoryIfUnchanged(empty) error = nil")
+	}
+}
+
+func TestClient_UpdateInventoryIfUnchanged_ServerPrecondition(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"inventory_item":{"id":"a","product_type":"mtg_single","product_id":"p-a"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusPreconditionFailed)
+		_, _ = w.Write([]byte(`{"message":"stale"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	read := InventoryItem{ID: "a", ProductType: "mtg_single", ProductID: "p-a"}
+	_, err := client.UpdateInventoryIfUnchanged(context.Background(), read, InventoryUpdateRequest{Quantity: 1})
+	if !errors.Is(err, ErrPreconditionFailed) {
+		t.Errorf("UpdateInventoryIfUnchanged(412) error = %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+package manapool
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// InventoryFilter selects inventory items for bulk operations.
+type InventoryFilter func(item InventoryItem) bool
+
+// AllInventory matches every inventory item.
+func AllInventory() InventoryFilter {
+	return func(InventoryItem) bool { return true }
+}
+
+// InventoryInSet matches singles and sealed products from the set with the
+// given code, compared case-insensitively.
+func InventoryInSet(set string) InventoryFilter {
+	return func(item InventoryItem) bool {
+		switch {
+		case item.Product.Single != nil:
+			return strings.EqualFold(item.Product.Single.Set, set)
+		case item.Product.Sealed != nil:
+			return strings.EqualFold(item.Product.Sealed.Set, set)
+		default:
+			return false
+		}
+	}
+}
+
+// ConfirmToken acknowledges a specific set of listings for deletion. It is
+// derived from the matched listings and their quantities and prices, so a
+// token only confirms the exact selection it was issued for.
+type ConfirmToken string
+
+// InventoryDeletionPlan is a preview of the listings DeleteInventoryByFilter
+// would remove.
+type InventoryDeletionPlan struct {
+	// Items are the matching listings
+	Items []InventoryItem
+
+	// Token must be passed to DeleteInventoryByFilter to delete Items
+	Token ConfirmToken
+}
+
+// DeleteProgress reports the outcome of one deletion.
+type DeleteProgress struct {
+	// Item is the listing that was processed
+	Item InventoryItem
+
+	// Err is the deletion error (nil on success)
+	Err error
+
+	// Done is the number of listings processed so far, including this one
+	Done int
+
+	// Total is the number of listings being deleted
+	Total int
+}
+
+// DeleteOptions configures DeleteInventoryByFilter.
+type DeleteOptions struct {
+	// Progress, if set, is called after each listing is processed.
+	Progress func(DeleteProgress)
+
+	// Retries is the number of times a failed deletion is retried when the
+	// error is a network or server error (default: 0).
+	Retries int
+
+	// RetryBackoff is the pause between retries (default: 1s).
+	RetryBackoff time.Duration
+}
+
+// DeleteFailure records a listing that could not be deleted.
+type DeleteFailure struct {
+	Item InventoryItem
+	Err  error
+}
+
+// DeleteReport summarizes a DeleteInventoryByFilter run.
+type DeleteReport struct {
+	Total    int
+	Deleted  int
+	Failures []DeleteFailure
+}
+
+// String renders a one-line summary of the report.
+func (r *DeleteReport) String() string {
+	return fmt.Sprintf("%d of %d deleted, %d failed", r.Deleted, r.Total, len(r.Failures))
+}
+
+// PlanInventoryDeletion loads the seller's full inventory and returns the
+// listings matching filter, along with the token that confirms deleting them.
+func (c *Client) PlanInventoryDeletion(ctx context.Context, filter InventoryFilter) (*InventoryDeletionPlan, error) {
+	if filter == nil {
+		return nil, NewValidationError("filter", "filter is required")
+	}
+
+	plan := &InventoryDeletionPlan{}
+	err := IterateInventory(ctx, c, func(item *InventoryItem) error {
+		if filter(*item) {
+			plan.Items = append(plan.Items, *item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load remote inventory: %w", err)
+	}
+
+	plan.Token = deletionToken(plan.Items)
+	return plan, nil
+}
+
+// DeleteInventoryByFilter deletes every listing matching filter.
+//
+// Deletion is irreversible, so it requires confirm to be the token from a
+// PlanInventoryDeletion call with the same filter. The matching listings are
+// reloaded first; if they no longer match the plan (for example because an
+// item was added, sold, or repriced in between), nothing is deleted and a
+// ValidationError is returned. Listings are then deleted one at a time by
+// product ID. Failures are collected in the report rather than aborting,
+// except when ctx is cancelled.
+//
+// Example:
+//
+//	filter := manapool.InventoryInSet("LEA")
+//	plan, err := client.PlanInventoryDeletion(ctx, filter)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Delete %d listings? Type %s to confirm: ", len(plan.Items), plan.Token)
+//	// ... read and compare the confirmation ...
+//	report, err := client.DeleteInventoryByFilter(ctx, filter, plan.Token, manapool.DeleteOptions{
+//	    Progress: func(p manapool.DeleteProgress) {
+//	        log.Printf("%d/%d", p.Done, p.Total)
+//	    },
+//	})
+func (c *Client) DeleteInventoryByFilter(ctx context.Context, filter InventoryFilter, confirm ConfirmToken, opts DeleteOptions) (*DeleteReport, error) {
+	if confirm == "" {
+		return nil, NewValidationError("confirm", "a confirmation token from PlanInventoryDeletion is required")
+	}
+
+	plan, err := c.PlanInventoryDeletion(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Token != confirm {
+		return nil, NewValidationError("confirm", "confirmation token does not match the current inventory selection")
+	}
+
+	backoff := opts.RetryBackoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+
+	report := &DeleteReport{Total: len(plan.Items)}
+	for i, item := range plan.Items {
+		err := retrySyncStep(ctx, opts.Retries, backoff, func() error {
+			_, err := c.DeleteSellerInventoryByProduct(ctx, item.ProductType, item.ProductID)
+			return err
+		})
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+		if err != nil {
+			c.logger.Errorf("Delete of %s %s failed: %v", item.ProductType, item.ProductID, err)
+			report.Failures = append(report.Failures, DeleteFailure{Item: item, Err: err})
+		} else {
+			report.Deleted++
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(DeleteProgress{Item: item, Err: err, Done: i + 1, Total: report.Total})
+		}
+	}
+
+	return report, nil
+}
+
+// deletionToken derives a short token from the product IDs, quantities, and
+// prices of items, so a partial sale invalidates it.
+func deletionToken(items []InventoryItem) ConfirmToken {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = fmt.Sprintf("%s/%s %d %d", item.ProductType, item.ProductID, item.Quantity, item.PriceCents)
+	}
+	sort.Strings(ids)
+
+	sum := sha256.Sum256([]byte(strings.Join(ids, "\n")))
+	return ConfirmToken(fmt.Sprintf("delete-%d-%s", len(items), hex.EncodeToString(sum[:4])))
+}
@@ -0,0 +1,195 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// deleteTestServer is an in-memory inventory backend for deletion tests.
+type deleteTestServer struct {
+	mu        sync.Mutex
+	inventory []InventoryItem
+	deleted   []string
+	fail      map[string]bool
+}
+
+func newDeleteTestServer() *deleteTestServer {
+	single := func(id, set string) InventoryItem {
+		return InventoryItem{
+			ID: id, ProductType: "mtg_single", ProductID: "p-" + id, PriceCents: 100, Quantity: 1,
+			Product:       Product{Single: &Single{Name: id, Set: set}},
+			EffectiveAsOf: Timestamp{Time: time.Now()},
+		}
+	}
+	sealed := single("box", "")
+	sealed.ProductType = "mtg_sealed"
+	sealed.Product = Product{Sealed: &Sealed{Name: "Alpha Booster Box", Set: "LEA"}}
+
+	return &deleteTestServer{
+		inventory: []InventoryItem{single("a", "LEA"), single("b", "lea"), single("c", "MH3"), sealed, {ID: "bare", ProductType: "mtg_single", ProductID: "p-bare", EffectiveAsOf: Timestamp{Time: time.Now()}}},
+		fail:      make(map[string]bool),
+	}
+}
+
+func (s *deleteTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/seller/inventory":
+		resp := InventoryResponse{Inventory: s.inventory}
+		resp.Pagination = Pagination{Total: len(s.inventory), Returned: len(s.inventory)}
+		_ = json.NewEncoder(w).Encode(resp)
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/seller/inventory/product/"):
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		if s.fail[id] {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"locked"}`))
+			return
+		}
+		s.deleted = append(s.deleted, id)
+		for i, item := range s.inventory {
+			if item.ProductID == id {
+				s.inventory = append(s.inventory[:i], s.inventory[i+1:]...)
+				break
+			}
+		}
+		_, _ = w.Write([]byte(`{"inventory":{}}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestInventoryFilters(t *testing.T) {
+	items := newDeleteTestServer().inventory
+	var inSet []string
+	for _, item := range items {
+		if InventoryInSet("LEA")(item) {
+			inSet = append(inSet, item.ID)
+		}
+		if !AllInventory()(item) {
+			t.Errorf("AllInventory() rejected %s", item.ID)
+		}
+	}
+	if strings.Join(inSet, ",") != "a,b,box" {
+		t.Errorf("InventoryInSet(LEA) = %v", inSet)
+	}
+}
+
+func TestClient_DeleteInventoryByFilter(t *testing.T) {
+	backend := newDeleteTestServer()
+	backend.fail["p-b"] = true
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	ctx := context.Background()
+	filter := InventoryInSet("lea")
+
+	plan, err := client.PlanInventoryDeletion(ctx, filter)
+	if err != nil {
+		t.Fatalf("PlanInventoryDeletion() error = %v", err)
+	}
+	if len(plan.Items) != 3 || !strings.HasPrefix(string(plan.Token), "delete-3-") {
+		t.Fatalf("plan = %d items, token %q", len(plan.Items), plan.Token)
+	}
+
+	var progress []DeleteProgress
+	report, err := client.DeleteInventoryByFilter(ctx, filter, plan.Token, DeleteOptions{
+		Progress: func(p DeleteProgress) { progress = append(progress, p) },
+	})
+	if err != nil {
+		t.Fatalf("DeleteInventoryByFilter() error = %v", err)
+	}
+
+	if report.String() != "2 of 3 deleted, 1 failed" {
+		t.Errorf("report = %s", report)
+	}
+	if len(report.Failures) != 1 || report.Failures[0].Item.ID != "b" {
+		t.Errorf("failures = %+v", report.Failures)
+	}
+	if strings.Join(backend.deleted, ",") != "p-a,p-box" {
+		t.Errorf("deleted = %v", backend.deleted)
+	}
+	if len(progress) != 3 || progress[2].Done != 3 || progress[2].Total != 3 || progress[1].Err == nil {
+		t.Errorf("progress = %+v", progress)
+	}
+}
+
+func TestClient_DeleteInventoryByFilter_Confirmation(t *testing.T) {
+	backend := newDeleteTestServer()
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	ctx := context.Background()
+
+	var valErr *ValidationError
+	if _, err := client.DeleteInventoryByFilter(ctx, AllInventory(), "", DeleteOptions{}); !errors.As(err, &valErr) {
+		t.Errorf("empty token error = %v, want ValidationError", err)
+	}
+	if _, err := client.PlanInventoryDeletion(ctx, nil); !errors.As(err, &valErr) {
+		t.Errorf("nil filter error = %v, want ValidationError", err)
+	}
+
+	plan, err := client.PlanInventoryDeletion(ctx, InventoryInSet("MH3"))
+	if err != nil {
+		t.Fatalf("PlanInventoryDeletion() error = %v", err)
+	}
+	if _, err := client.DeleteInventoryByFilter(ctx, AllInventory(), plan.Token, DeleteOptions{}); !errors.As(err, &valErr) {
+		t.Errorf("mismatched filter error = %v, want ValidationError", err)
+	}
+
+	// A listing's quantity changes between planning and deleting
+	backend.mu.Lock()
+	backend.inventory[2].Quantity++
+	backend.mu.Unlock()
+	if _, err := client.DeleteInventoryByFilter(ctx, InventoryInSet("MH3"), plan.Token, DeleteOptions{}); !errors.As(err, &valErr) {
+		t.Errorf("partial sale error = %v, want ValidationError", err)
+	}
+	plan, err = client.PlanInventoryDeletion(ctx, InventoryInSet("MH3"))
+	if err != nil {
+		t.Fatalf("PlanInventoryDeletion() error = %v", err)
+	}
+
+	// The selection changes between planning and deleting
+	backend.mu.Lock()
+	backend.inventory[2].ProductID = "p-c2"
+	backend.mu.Unlock()
+	if _, err := client.DeleteInventoryByFilter(ctx, InventoryInSet("MH3"), plan.Token, DeleteOptions{}); !errors.As(err, &valErr) {
+		t.Errorf("stale token error = %v, want ValidationError", err)
+	}
+	if len(backend.deleted) != 0 {
+		t.Errorf("deleted = %v, want none", backend.deleted)
+	}
+}
+
+func TestClient_DeleteInventoryByFilter_Cancelled(t *testing.T) {
+	backend := newDeleteTestServer()
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	plan, err := client.PlanInventoryDeletion(context.Background(), AllInventory())
+	if err != nil {
+		t.Fatalf("PlanInventoryDeletion() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	report, err := client.DeleteInventoryByFilter(ctx, AllInventory(), plan.Token, DeleteOptions{
+		Progress: func(DeleteProgress) { cancel() },
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	if report.Deleted != 1 {
+		t.Errorf("Deleted = %d, want 1", report.Deleted)
+	}
+}
@@ -0,0 +1,372 @@
+package manapool
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// InventoryImportRow is a validated row from an inventory CSV file.
+type InventoryImportRow struct {
+	// Line is the 1-based line number of the row in the source file
+	Line int
+
+	// Item is the inventory update parsed from the row
+	Item InventoryBulkItemBySKU
+
+	// ConditionID, FinishID, and LanguageID are set from the optional
+	// columns of the same names. A TCGplayer SKU already identifies the
+	// variant, so they are not sent to ManaPool; they let callers check the
+	// file against the listings it updates.
+	ConditionID string
+	FinishID    string
+	LanguageID  string
+}
+
+// CSVRowError describes a validation problem on a single CSV row.
+type CSVRowError struct {
+	Line    int
+	Column  string
+	Message string
+}
+
+// Error implements the error interface.
+func (e CSVRowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("line %d: column %q: %s", e.Line, e.Column, e.Message)
+}
+
+// CSVValidationError collects every row error found while parsing a CSV file.
+type CSVValidationError struct {
+	Errors []CSVRowError
+}
+
+// Error implements the error interface.
+func (e *CSVValidationError) Error() string {
+	if len(e.Errors) == 1 {
+		return "csv validation failed: " + e.Errors[0].Error()
+	}
+	msgs := make([]string, len(e.Errors))
+	for i, rowErr := range e.Errors {
+		msgs[i] = rowErr.Error()
+	}
+	return fmt.Sprintf("csv validation failed with %d errors: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// ParseInventoryCSV parses a seller inventory spreadsheet into bulk update rows.
+//
+// The first line must be a header. Column names are matched case-insensitively:
+//   - tcgplayer_sku (required): positive TCGPlayer SKU
+//   - quantity (required): non-negative integer
+//   - price_cents or price (one required): price in cents, or in dollars
+//     such as "1.25" or "$1.25"
+//   - condition_id, finish_id, language_id (optional): ManaPool IDs such as
+//     "NM", "FO", and "EN", passed through without validation
+//
+// Unknown columns are ignored. Every row is validated; if any row is invalid,
+// no rows are returned and the error is a *CSVValidationError listing all
+// problems with their line numbers. Use ParseInventoryCSVColumns for files
+// with other headers.
+func ParseInventoryCSV(r io.Reader) ([]InventoryImportRow, error) {
+	return ParseInventoryCSVColumns(r, DefaultColumnMap())
+}
+
+// ParseInventoryCSVColumns is ParseInventoryCSV for a file laid out as
+// described by columns. Transforms run on trimmed values before they are
+// parsed, and row errors name the file's own headers. It returns a
+// *ValidationError if columns is invalid or the file lacks a required
+// column.
+func ParseInventoryCSVColumns(r io.Reader, columns ColumnMap) ([]InventoryImportRow, error) {
+	if err := columns.Validate(); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, NewValidationError("csv", "missing header row")
+		}
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	located, err := columns.resolve(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []InventoryImportRow
+	var rowErrs []CSVRowError
+	seen := make(map[int]int)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrs = append(rowErrs, CSVRowError{Line: parseErr.Line, Message: parseErr.Err.Error()})
+				continue
+			}
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		row := InventoryImportRow{Line: line}
+		rowOK := true
+		fail := func(column, message string) {
+			rowErrs = append(rowErrs, CSVRowError{Line: line, Column: column, Message: message})
+			rowOK = false
+		}
+		// value returns the transformed value of field and its header, and
+		// false if the field is not in the file or its transform failed.
+		value := func(field string) (string, string, bool) {
+			col, ok := located[field]
+			if !ok {
+				return "", "", false
+			}
+			v := csvField(record, col.index)
+			if transform := columns.Transforms[field]; transform != nil {
+				if v, err = transform(v); err != nil {
+					fail(col.header, err.Error())
+					return "", col.header, false
+				}
+				v = strings.TrimSpace(v)
+			}
+			return v, col.header, true
+		}
+
+		if v, column, ok := value(CSVFieldTCGPlayerSKU); ok {
+			sku, err := strconv.Atoi(v)
+			if err != nil || sku <= 0 {
+				fail(column, "must be a positive integer")
+			} else if first, dup := seen[sku]; dup {
+				fail(column, fmt.Sprintf("duplicate sku %d (first seen on line %d)", sku, first))
+			} else {
+				seen[sku] = line
+				row.Item.TCGPlayerSKU = sku
+			}
+		}
+
+		if v, column, ok := value(CSVFieldQuantity); ok {
+			qty, err := strconv.Atoi(v)
+			if err != nil || qty < 0 {
+				fail(column, "must be a non-negative integer")
+			} else {
+				row.Item.Quantity = qty
+			}
+		}
+
+		if _, inFile := located[CSVFieldPriceCents]; inFile {
+			if v, column, ok := value(CSVFieldPriceCents); ok {
+				cents, err := strconv.Atoi(v)
+				if err != nil || cents < 0 {
+					fail(column, "must be a non-negative integer")
+				} else {
+					row.Item.PriceCents = cents
+				}
+			}
+		} else if v, column, ok := value(CSVFieldPrice); ok {
+			cents, err := parseDollarsToCents(v)
+			if err != nil {
+				fail(column, err.Error())
+			} else {
+				row.Item.PriceCents = cents
+			}
+		}
+
+		for _, id := range []struct {
+			field string
+			known map[string]bool
+			dst   *string
+		}{
+			{CSVFieldConditionID, knownConditionIDs, &row.ConditionID},
+			{CSVFieldFinishID, knownFinishIDs, &row.FinishID},
+			{CSVFieldLanguageID, knownLanguageIDs, &row.LanguageID},
+		} {
+			v, column, ok := value(id.field)
+			if !ok || v == "" {
+				continue
+			}
+			if v = strings.ToUpper(v); columns.ValidateIDs && !id.known[v] {
+				fail(column, fmt.Sprintf("unknown value %q", v))
+				continue
+			}
+			*id.dst = v
+		}
+
+		if rowOK {
+			rows = append(rows, row)
+		}
+	}
+
+	if len(rowErrs) > 0 {
+		return nil, &CSVValidationError{Errors: rowErrs}
+	}
+
+	return rows, nil
+}
+
+// csvField returns the trimmed value at index, or "" if the record is short.
+func csvField(record []string, index int) string {
+	if index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+// isBlankRecord reports whether every field in record is empty.
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDollarsToCents converts a non-negative dollar amount such as "1.25"
+// or "$1,000.5" to cents. See ParseDollars.
+func parseDollarsToCents(s string) (int, error) {
+	cents, err := ParseDollars(s)
+	switch {
+	case errors.Is(err, errDollarDecimals):
+		return 0, errDollarDecimals
+	case err != nil || strings.HasPrefix(strings.TrimSpace(s), "-"):
+		return 0, errors.New("must be a non-negative dollar amount")
+	}
+	return cents, nil
+}
+
+// InventoryImportChange describes how a single imported row would change
+// remote inventory.
+type InventoryImportChange struct {
+	// Line is the source line of the imported row
+	Line int
+
+	// Desired is the inventory state requested by the row
+	Desired InventoryBulkItemBySKU
+
+	// Current is the matching remote inventory item (nil for creates)
+	Current *InventoryItem
+}
+
+// InventoryImportDiff is a dry-run preview of an inventory import.
+type InventoryImportDiff struct {
+	// Creates are rows whose SKU is not in remote inventory
+	Creates []InventoryImportChange
+
+	// Updates are rows whose price or quantity differ from remote inventory
+	Updates []InventoryImportChange
+
+	// Unchanged are rows that already match remote inventory
+	Unchanged []InventoryImportChange
+}
+
+// HasChanges reports whether applying the import would modify inventory.
+func (d *InventoryImportDiff) HasChanges() bool {
+	return len(d.Creates) > 0 || len(d.Updates) > 0
+}
+
+// Items returns the bulk update payload for the creates and updates.
+func (d *InventoryImportDiff) Items() []InventoryBulkItemBySKU {
+	items := make([]InventoryBulkItemBySKU, 0, len(d.Creates)+len(d.Updates))
+	for _, change := range d.Creates {
+		items = append(items, change.Desired)
+	}
+	for _, change := range d.Updates {
+		items = append(items, change.Desired)
+	}
+	return items
+}
+
+// String renders the diff as a human-readable preview.
+func (d *InventoryImportDiff) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d to create, %d to update, %d unchanged\n",
+		len(d.Creates), len(d.Updates), len(d.Unchanged))
+	for _, change := range d.Creates {
+		fmt.Fprintf(&b, "+ line %d: sku %d qty %d price %d\n",
+			change.Line, change.Desired.TCGPlayerSKU, change.Desired.Quantity, change.Desired.PriceCents)
+	}
+	for _, change := range d.Updates {
+		fmt.Fprintf(&b, "~ line %d: sku %d qty %d -> %d price %d -> %d\n",
+			change.Line, change.Desired.TCGPlayerSKU,
+			change.Current.Quantity, change.Desired.Quantity,
+			change.Current.PriceCents, change.Desired.PriceCents)
+	}
+	return b.String()
+}
+
+// DiffInventoryImport compares imported rows against remote inventory items
+// matched by TCGPlayer SKU.
+func DiffInventoryImport(rows []InventoryImportRow, remote []InventoryItem) *InventoryImportDiff {
+	bySKU := make(map[int]*InventoryItem, len(remote))
+	for i := range remote {
+		if sku := remote[i].Product.TCGPlayerSKU; sku != nil {
+			bySKU[*sku] = &remote[i]
+		}
+	}
+
+	diff := &InventoryImportDiff{}
+	for _, row := range rows {
+		change := InventoryImportChange{Line: row.Line, Desired: row.Item}
+		current, ok := bySKU[row.Item.TCGPlayerSKU]
+		switch {
+		case !ok:
+			diff.Creates = append(diff.Creates, change)
+		case current.Quantity != row.Item.Quantity || current.PriceCents != row.Item.PriceCents:
+			change.Current = current
+			diff.Updates = append(diff.Updates, change)
+		default:
+			change.Current = current
+			diff.Unchanged = append(diff.Unchanged, change)
+		}
+	}
+
+	return diff
+}
+
+// PreviewInventoryImport fetches the seller's full remote inventory and
+// returns a dry-run diff for the imported rows. Nothing is written.
+//
+// Example:
+//
+//	rows, err := manapool.ParseInventoryCSV(file)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	diff, err := manapool.PreviewInventoryImport(ctx, client, rows)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Print(diff)
+//	if diff.HasChanges() {
+//	    _, err = client.CreateInventoryBulk(ctx, diff.Items())
+//	}
+func PreviewInventoryImport(ctx context.Context, client APIClient, rows []InventoryImportRow) (*InventoryImportDiff, error) {
+	var remote []InventoryItem
+	err := IterateInventory(ctx, client, func(item *InventoryItem) error {
+		remote = append(remote, *item)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load remote inventory: %w", err)
+	}
+
+	return DiffInventoryImport(rows, remote), nil
+}
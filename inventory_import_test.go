@@ -0,0 +1,220 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseInventoryCSV(t *testing.T) {
+	input := "TCGPlayer_SKU,Name,Quantity,Price\n" +
+		"100,Lightning Bolt,4,$1.25\n" +
+		"\n" +
+		"200,Counterspell,0,\"1,000.5\"\n"
+
+	rows, err := ParseInventoryCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseInventoryCSV() error = %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("rows = %d, want 2", len(rows))
+	}
+
+	want := []InventoryImportRow{
+		{Line: 2, Item: InventoryBulkItemBySKU{TCGPlayerSKU: 100, Quantity: 4, PriceCents: 125}},
+		{Line: 4, Item: InventoryBulkItemBySKU{TCGPlayerSKU: 200, Quantity: 0, PriceCents: 100050}},
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("rows[%d] = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestParseInventoryCSV_PriceCents(t *testing.T) {
+	rows, err := ParseInventoryCSV(strings.NewReader("tcgplayer_sku,quantity,price_cents\n5,1,99\n"))
+	if err != nil {
+		t.Fatalf("ParseInventoryCSV() error = %v", err)
+	}
+	if rows[0].Item.PriceCents != 99 {
+		t.Errorf("PriceCents = %d, want 99", rows[0].Item.PriceCents)
+	}
+}
+
+func TestParseInventoryCSV_CollectsAllErrors(t *testing.T) {
+	input := "tcgplayer_sku,quantity,price\n" +
+		"abc,1,1.00\n" +
+		"10,-1,1.00\n" +
+		"11,1,1.001\n" +
+		"10,1,1.00\n" +
+		"12,1\n" +
+		"13,1,\"bad\n"
+
+	rows, err := ParseInventoryCSV(strings.NewReader(input))
+	if rows != nil {
+		t.Errorf("rows = %v, want nil", rows)
+	}
+
+	var csvErr *CSVValidationError
+	if !errors.As(err, &csvErr) {
+		t.Fatalf("error = %v, want CSVValidationError", err)
+	}
+
+	want := []CSVRowError{
+		{Line: 2, Column: "tcgplayer_sku", Message: "must be a positive integer"},
+		{Line: 3, Column: "quantity", Message: "must be a non-negative integer"},
+		{Line: 4, Column: "price", Message: "must have at most two decimal places"},
+		{Line: 5, Column: "tcgplayer_sku", Message: "duplicate sku 10 (first seen on line 3)"},
+		{Line: 6, Column: "price", Message: "must be a non-negative dollar amount"},
+	}
+	if len(csvErr.Errors) != len(want)+1 {
+		t.Fatalf("errors = %v, want %d", csvErr.Errors, len(want)+1)
+	}
+	for i := range want {
+		if csvErr.Errors[i] != want[i] {
+			t.Errorf("Errors[%d] = %+v, want %+v", i, csvErr.Errors[i], want[i])
+		}
+	}
+	if csvErr.Errors[len(want)].Column != "" {
+		t.Errorf("parse error column = %q, want empty", csvErr.Errors[len(want)].Column)
+	}
+	if !strings.Contains(err.Error(), "6 errors") {
+		t.Errorf("Error() = %q, want error count", err.Error())
+	}
+}
+
+func TestParseInventoryCSV_HeaderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "empty", input: "", wantErr: "missing header row"},
+		{name: "no sku", input: "quantity,price\n", wantErr: "tcgplayer_sku"},
+		{name: "no quantity", input: "tcgplayer_sku,price\n", wantErr: "quantity"},
+		{name: "no price", input: "tcgplayer_sku,quantity\n", wantErr: "price_cents or price"},
+		{name: "bad header", input: "\"a\n", wantErr: "failed to read csv header"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseInventoryCSV(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseDollarsToCents(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{in: "1", want: 100},
+		{in: "1.5", want: 150},
+		{in: ".05", want: 5},
+		{in: "$12.34", want: 1234},
+		{in: "1.", want: 100},
+		{in: "", wantErr: true},
+		{in: "-1", wantErr: true},
+		{in: "-0.50", wantErr: true},
+		{in: "$-0.50", wantErr: true},
+		{in: "+1", wantErr: true},
+		{in: "1.-", wantErr: true},
+		{in: "1.x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDollarsToCents(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDollarsToCents(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseDollarsToCents(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDiffInventoryImport(t *testing.T) {
+	sku := func(n int) *int { return &n }
+	remote := []InventoryItem{
+		{ID: "a", PriceCents: 100, Quantity: 1, Product: Product{TCGPlayerSKU: sku(1)}},
+		{ID: "b", PriceCents: 200, Quantity: 2, Product: Product{TCGPlayerSKU: sku(2)}},
+		{ID: "sealed", PriceCents: 300, Quantity: 3},
+	}
+	rows := []InventoryImportRow{
+		{Line: 2, Item: InventoryBulkItemBySKU{TCGPlayerSKU: 1, PriceCents: 100, Quantity: 1}},
+		{Line: 3, Item: InventoryBulkItemBySKU{TCGPlayerSKU: 2, PriceCents: 250, Quantity: 2}},
+		{Line: 4, Item: InventoryBulkItemBySKU{TCGPlayerSKU: 3, PriceCents: 50, Quantity: 8}},
+	}
+
+	diff := DiffInventoryImport(rows, remote)
+	if len(diff.Creates) != 1 || diff.Creates[0].Desired.TCGPlayerSKU != 3 {
+		t.Errorf("Creates = %+v", diff.Creates)
+	}
+	if len(diff.Updates) != 1 || diff.Updates[0].Current.ID != "b" {
+		t.Errorf("Updates = %+v", diff.Updates)
+	}
+	if len(diff.Unchanged) != 1 || diff.Unchanged[0].Line != 2 {
+		t.Errorf("Unchanged = %+v", diff.Unchanged)
+	}
+	if !diff.HasChanges() {
+		t.Error("HasChanges() = false, want true")
+	}
+	if items := diff.Items(); len(items) != 2 {
+		t.Errorf("Items() = %v, want 2 items", items)
+	}
+
+	out := diff.String()
+	for _, want := range []string{
+		"1 to create, 1 to update, 1 unchanged",
+		"+ line 4: sku 3 qty 8 price 50",
+		"~ line 3: sku 2 qty 2 -> 2 price 200 -> 250",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("String() missing %q\n%s", want, out)
+		}
+	}
+
+	if (&InventoryImportDiff{}).HasChanges() {
+		t.Error("empty diff HasChanges() = true")
+	}
+}
+
+func TestPreviewInventoryImport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"inventory":[{"id":"a","price_cents":100,"quantity":1,"product":{"tcgplayer_sku":1}}],"pagination":{"total":1,"returned":1}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	rows := []InventoryImportRow{{Line: 2, Item: InventoryBulkItemBySKU{TCGPlayerSKU: 1, PriceCents: 150, Quantity: 1}}}
+
+	diff, err := PreviewInventoryImport(context.Background(), client, rows)
+	if err != nil {
+		t.Fatalf("PreviewInventoryImport() error = %v", err)
+	}
+	if len(diff.Updates) != 1 {
+		t.Errorf("Updates = %d, want 1", len(diff.Updates))
+	}
+}
+
+func TestPreviewInventoryImport_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	_, err := PreviewInventoryImport(context.Background(), client, nil)
+	if err == nil || !strings.Contains(err.Error(), "failed to load remote inventory") {
+		t.Fatalf("error = %v, want load error", err)
+	}
+}
@@ -294,6 +294,11 @@ func (c *Client) CreateInventoryBulkByScryfall(ctx context.Context, items []Inve
 	if len(items) == 0 {
 		return nil, NewValidationError("items", "items cannot be empty")
 	}
+	for i, item := range items {
+		if err := validateLanguageID(fmt.Sprintf("items[%d].language_id", i), item.LanguageID); err != nil {
+			return nil, err
+		}
+	}
 
 	resp, err := c.doJSONRequest(ctx, "POST", "/seller/inventory/scryfall_id", nil, items)
 	if err != nil {
@@ -336,6 +341,10 @@ func (c *Client) UpdateSellerInventoryByScryfall(ctx context.Context, scryfallID
 		return nil, NewValidationError("scryfall_id", "scryfallID cannot be empty")
 	}
 
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
 	params := opts.toParams()
 
 	endpoint := fmt.Sprintf("/seller/inventory/scryfall_id/%s", scryfallID)
@@ -358,6 +367,10 @@ func (c *Client) DeleteSellerInventoryByScryfall(ctx context.Context, scryfallID
 		return nil, NewValidationError("scryfall_id", "scryfallID cannot be empty")
 	}
 
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
 	params := opts.toParams()
 
 	endpoint := fmt.Sprintf("/seller/inventory/scryfall_id/%s", scryfallID)
@@ -379,6 +392,11 @@ func (c *Client) CreateInventoryBulkByTCGPlayerID(ctx context.Context, items []I
 	if len(items) == 0 {
 		return nil, NewValidationError("items", "items cannot be empty")
 	}
+	for i, item := range items {
+		if err := validateLanguageID(fmt.Sprintf("items[%d].language_id", i), item.LanguageID); err != nil {
+			return nil, err
+		}
+	}
 
 	resp, err := c.doJSONRequest(ctx, "POST", "/seller/inventory/tcgplayer_id", nil, items)
 	if err != nil {
@@ -421,6 +439,10 @@ func (c *Client) UpdateSellerInventoryByTCGPlayerID(ctx context.Context, tcgplay
 		return nil, NewValidationError("tcgplayer_id", "tcgplayerID must be positive")
 	}
 
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
 	params := opts.toParams()
 
 	endpoint := fmt.Sprintf("/seller/inventory/tcgplayer_id/%d", tcgplayerID)
@@ -443,6 +465,10 @@ func (c *Client) DeleteSellerInventoryByTCGPlayerID(ctx context.Context, tcgplay
 		return nil, NewValidationError("tcgplayer_id", "tcgplayerID must be positive")
 	}
 
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
 	params := opts.toParams()
 
 	endpoint := fmt.Sprintf("/seller/inventory/tcgplayer_id/%d", tcgplayerID)
@@ -0,0 +1,248 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ListingGroup is a set of listings for the same card or sealed product.
+type ListingGroup struct {
+	// Key is the ListingKey shared by the listings
+	Key   string
+	Items []InventoryItem
+}
+
+// ListingKey identifies what a listing sells, independent of its product ID:
+// the printing, condition, finish, and language of a single, or the product
+// and language of sealed product. Singles without a Scryfall ID fall back to
+// set, collector number, and name. It returns "" for listings without
+// product details.
+func ListingKey(item InventoryItem) string {
+	switch p := item.Product; {
+	case p.Single != nil:
+		s := p.Single
+		printing := s.ScryfallID
+		if printing == "" {
+			printing = strings.ToUpper(s.Set) + "/" + s.Number + "/" + strings.ToLower(s.Name)
+		}
+		return strings.Join([]string{"single", printing, s.ConditionID, s.FinishID, s.LanguageID}, "|")
+	case p.Sealed != nil:
+		s := p.Sealed
+		id := s.MTGJsonID
+		if id == "" {
+			id = strings.ToUpper(s.Set) + "/" + strings.ToLower(s.Name)
+		}
+		return strings.Join([]string{"sealed", id, s.LanguageID}, "|")
+	}
+	return ""
+}
+
+// FindDuplicateListings groups listings that share a ListingKey. Listings
+// are keyed by product, so duplicates are the same card sold under more than
+// one product ID, such as after importing by different identifiers. Only
+// groups of two or more are returned, sorted by key, with listings sorted by
+// ID.
+func FindDuplicateListings(items []InventoryItem) []ListingGroup {
+	byKey := make(map[string][]InventoryItem)
+	for _, item := range items {
+		if key := ListingKey(item); key != "" {
+			byKey[key] = append(byKey[key], item)
+		}
+	}
+
+	var groups []ListingGroup
+	for key, listings := range byKey {
+		if len(listings) < 2 {
+			continue
+		}
+		sort.Slice(listings, func(i, j int) bool { return listings[i].ID < listings[j].ID })
+		groups = append(groups, ListingGroup{Key: key, Items: listings})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Key < groups[j].Key })
+	return groups
+}
+
+// MergeStrategy chooses which listing of a group survives a merge.
+type MergeStrategy string
+
+const (
+	// MergeKeepLowestPrice keeps the cheapest listing, so the merged
+	// quantity is sold at the lowest price.
+	MergeKeepLowestPrice MergeStrategy = "lowest_price"
+
+	// MergeKeepNewest keeps the most recently updated listing and its price.
+	MergeKeepNewest MergeStrategy = "newest"
+)
+
+// MergeResult is the outcome of a successful merge.
+type MergeResult struct {
+	// Kept is the surviving listing with its merged quantity
+	Kept InventoryItem
+
+	// Deleted are the listings that were removed
+	Deleted []InventoryItem
+}
+
+// MergeError reports a merge that failed part way. The API has no
+// transactions, so MergeListings undoes what it can: if a deletion fails,
+// the kept listing's quantity is reset to its original quantity plus that of
+// the listings already deleted, so total stock is unchanged.
+type MergeError struct {
+	// Kept is the listing that was chosen to survive
+	Kept InventoryItem
+
+	// Deleted are the listings removed before the failure
+	Deleted []InventoryItem
+
+	// Remaining are the listings that were not removed
+	Remaining []InventoryItem
+
+	// RolledBack reports whether the kept listing's quantity was restored.
+	// It is false if the failure happened before anything changed.
+	RolledBack bool
+
+	// RollbackErr is the error from restoring the kept listing, if any. In
+	// that case the kept listing still carries the full merged quantity.
+	RollbackErr error
+
+	// Err is the error that stopped the merge
+	Err error
+}
+
+// Error implements the error interface.
+func (e *MergeError) Error() string {
+	msg := fmt.Sprintf("failed to merge into listing %s after deleting %d of %d listings: %v",
+		e.Kept.ID, len(e.Deleted), len(e.Deleted)+len(e.Remaining), e.Err)
+	if e.RollbackErr != nil {
+		msg += fmt.Sprintf(" (rollback failed: %v)", e.RollbackErr)
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e *MergeError) Unwrap() error {
+	return e.Err
+}
+
+// MergeListings consolidates a group of duplicate listings into one. The
+// listing chosen by strategy is updated to the group's total quantity, and
+// the other listings are then deleted. If any step fails, the merge stops
+// and a *MergeError describes what changed and whether it was rolled back.
+//
+// The total is summed from group, so every listing is read again first,
+// bypassing the cache, and the merge is abandoned without changes if any of
+// them sold or changed since group was built; the error then matches
+// ErrPreconditionFailed. Each deletion is checked the same way, as with
+// DeleteInventoryIfUnchanged, and a listing that changed by then stops the
+// merge and rolls it back.
+//
+// Example:
+//
+//	var items []manapool.InventoryItem
+//	err := manapool.IterateInventory(ctx, client, func(item *manapool.InventoryItem) error {
+//	    items = append(items, *item)
+//	    return nil
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, group := range manapool.FindDuplicateListings(items) {
+//	    result, err := client.MergeListings(ctx, group, manapool.MergeKeepLowestPrice)
+//	    if err != nil {
+//	        log.Printf("merge %s: %v", group.Key, err)
+//	        continue
+//	    }
+//	    log.Printf("kept %s with quantity %d", result.Kept.ID, result.Kept.Quantity)
+//	}
+func (c *Client) MergeListings(ctx context.Context, group ListingGroup, strategy MergeStrategy) (*MergeResult, error) {
+	if len(group.Items) < 2 {
+		return nil, NewValidationError("group", "at least two listings are required to merge")
+	}
+	for _, item := range group.Items {
+		if err := validateConditionalListing(item); err != nil {
+			return nil, err
+		}
+	}
+
+	keep, err := chooseMergeTarget(group.Items, strategy)
+	if err != nil {
+		return nil, err
+	}
+	kept := group.Items[keep]
+	others := make([]InventoryItem, 0, len(group.Items)-1)
+	total := 0
+	for i, item := range group.Items {
+		total += item.Quantity
+		if i != keep {
+			others = append(others, item)
+		}
+	}
+
+	for _, item := range group.Items {
+		if err := c.checkListingUnchanged(ctx, item); err != nil {
+			return nil, &MergeError{Kept: kept, Remaining: others, Err: err}
+		}
+	}
+
+	update := InventoryUpdateRequest{PriceCents: kept.PriceCents, Quantity: total}
+	if _, err := c.UpdateSellerInventoryByProduct(ctx, kept.ProductType, kept.ProductID, update); err != nil {
+		return nil, &MergeError{Kept: kept, Remaining: others, Err: err}
+	}
+
+	var deleted []InventoryItem
+	for i, item := range others {
+		if _, err := c.DeleteInventoryIfUnchanged(ctx, item); err != nil {
+			mergeErr := &MergeError{Kept: kept, Deleted: deleted, Remaining: others[i:], Err: err}
+
+			restored := kept.Quantity
+			for _, d := range deleted {
+				restored += d.Quantity
+			}
+			rollback := InventoryUpdateRequest{PriceCents: kept.PriceCents, Quantity: restored}
+			if _, err := c.UpdateSellerInventoryByProduct(ctx, kept.ProductType, kept.ProductID, rollback); err != nil {
+				mergeErr.RollbackErr = err
+			} else {
+				mergeErr.RolledBack = true
+			}
+			return nil, mergeErr
+		}
+		deleted = append(deleted, item)
+	}
+
+	c.logger.Debugf("Merged %d listings into %s (quantity %d)", len(deleted), kept.ID, total)
+	kept.Quantity = total
+	return &MergeResult{Kept: kept, Deleted: deleted}, nil
+}
+
+// chooseMergeTarget returns the index of the listing to keep. Ties are
+// broken by the other criterion, then by ID.
+func chooseMergeTarget(items []InventoryItem, strategy MergeStrategy) (int, error) {
+	byPrice := func(a, b InventoryItem) int { return a.PriceCents - b.PriceCents }
+	byAge := func(a, b InventoryItem) int { return b.EffectiveAsOf.Compare(a.EffectiveAsOf.Time) }
+
+	var criteria []func(a, b InventoryItem) int
+	switch strategy {
+	case MergeKeepLowestPrice:
+		criteria = append(criteria, byPrice, byAge)
+	case MergeKeepNewest:
+		criteria = append(criteria, byAge, byPrice)
+	default:
+		return 0, NewValidationError("strategy", fmt.Sprintf("unknown merge strategy %q", strategy))
+	}
+	criteria = append(criteria, func(a, b InventoryItem) int { return strings.Compare(a.ID, b.ID) })
+
+	best := 0
+	for i := 1; i < len(items); i++ {
+		for _, cmp := range criteria {
+			if c := cmp(items[i], items[best]); c != 0 {
+				if c < 0 {
+					best = i
+				}
+				break
+			}
+		}
+	}
+	return best, nil
+}
@@ -0,0 +1,209 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mergeTestServer extends deleteTestServer with updates by product.
+type mergeTestServer struct {
+	*deleteTestServer
+	updates    []InventoryUpdateRequest
+	failUpdate map[int]bool
+}
+
+func (s *mergeTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/seller/inventory/product/") {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		var update InventoryUpdateRequest
+		_ = json.NewDecoder(r.Body).Decode(&update)
+		s.updates = append(s.updates, update)
+		if s.failUpdate[len(s.updates)] {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"rejected"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"inventory":{}}`))
+		return
+	}
+	if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/inventory/listings/") {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, item := range s.inventory {
+			if "/inventory/listings/"+item.ID == r.URL.Path {
+				_ = json.NewEncoder(w).Encode(InventoryItemResponse{InventoryItem: item})
+				return
+			}
+		}
+		http.NotFound(w, r)
+		return
+	}
+	s.deleteTestServer.ServeHTTP(w, r)
+}
+
+func duplicateItems() []InventoryItem {
+	now := time.Now()
+	bolt := func(id string, price, quantity int, age time.Duration) InventoryItem {
+		return InventoryItem{
+			ID: id, ProductType: "mtg_single", ProductID: "p-" + id, PriceCents: price, Quantity: quantity,
+			Product:       Product{Single: &Single{ScryfallID: "bolt", Name: "Lightning Bolt", ConditionID: "NM", FinishID: "NF", LanguageID: "EN"}},
+			EffectiveAsOf: Timestamp{Time: now.Add(-age)},
+		}
+	}
+	foil := bolt("foil", 500, 1, 0)
+	foil.Product.Single = &Single{ScryfallID: "bolt", ConditionID: "NM", FinishID: "FO", LanguageID: "EN"}
+	box := InventoryItem{ID: "box", ProductType: "mtg_sealed", ProductID: "p-box",
+		Product: Product{Sealed: &Sealed{Name: "Alpha Booster Box", Set: "lea"}}}
+	box2 := box
+	box2.ID, box2.ProductID = "box2", "p-box2"
+	box2.Product = Product{Sealed: &Sealed{Name: "ALPHA BOOSTER BOX", Set: "LEA"}}
+
+	return []InventoryItem{
+		bolt("c", 150, 2, time.Hour),
+		bolt("a", 100, 1, 2*time.Hour),
+		bolt("b", 200, 3, 0),
+		foil,
+		box,
+		box2,
+		{ID: "bare", ProductType: "mtg_single", ProductID: "p-bare"},
+	}
+}
+
+func TestFindDuplicateListings(t *testing.T) {
+	groups := FindDuplicateListings(duplicateItems())
+	if len(groups) != 2 {
+		t.Fatalf("FindDuplicateListings() = %d groups, want 2: %+v", len(groups), groups)
+	}
+
+	var ids []string
+	for _, item := range groups[0].Items {
+		ids = append(ids, item.ID)
+	}
+	if groups[0].Key != "sealed|LEA/alpha booster box|" || strings.Join(ids, ",") != "box,box2" {
+		t.Errorf("group 0 = %s %v", groups[0].Key, ids)
+	}
+	ids = ids[:0]
+	for _, item := range groups[1].Items {
+		ids = append(ids, item.ID)
+	}
+	if groups[1].Key != "single|bolt|NM|NF|EN" || strings.Join(ids, ",") != "a,b,c" {
+		t.Errorf("group 1 = %s %v", groups[1].Key, ids)
+	}
+
+	if got := ListingKey(InventoryItem{Product: Product{Single: &Single{Set: "lea", Number: "161", Name: "Bolt"}}}); got != "single|LEA/161/bolt|||" {
+		t.Errorf("ListingKey(no scryfall) = %q", got)
+	}
+}
+
+func newMergeBackend(t *testing.T) (*mergeTestServer, *Client, ListingGroup) {
+	t.Helper()
+	backend := &mergeTestServer{deleteTestServer: newDeleteTestServer(), failUpdate: make(map[int]bool)}
+	backend.inventory = duplicateItems()
+	server := httptest.NewServer(backend)
+	t.Cleanup(server.Close)
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0), WithLimiter(nil))
+	return backend, client, FindDuplicateListings(backend.inventory)[1]
+}
+
+func TestClient_MergeListings(t *testing.T) {
+	backend, client, group := newMergeBackend(t)
+
+	result, err := client.MergeListings(context.Background(), group, MergeKeepLowestPrice)
+	if err != nil {
+		t.Fatalf("MergeListings() error = %v", err)
+	}
+	if result.Kept.ID != "a" || result.Kept.Quantity != 6 || len(result.Deleted) != 2 {
+		t.Errorf("MergeListings() = %+v", result)
+	}
+	if len(backend.updates) != 1 || backend.updates[0] != (InventoryUpdateRequest{PriceCents: 100, Quantity: 6}) {
+		t.Errorf("updates = %+v", backend.updates)
+	}
+	if strings.Join(backend.deleted, ",") != "p-b,p-c" {
+		t.Errorf("deleted = %v", backend.deleted)
+	}
+
+	backend, client, group = newMergeBackend(t)
+	result, err = client.MergeListings(context.Background(), group, MergeKeepNewest)
+	if err != nil || result.Kept.ID != "b" || backend.updates[0].PriceCents != 200 {
+		t.Errorf("MergeListings(newest) = %+v, %v", result, err)
+	}
+}
+
+func TestClient_MergeListings_Changed(t *testing.T) {
+	backend, client, group := newMergeBackend(t)
+	// One of "b" sells after the group was built.
+	for i := range backend.inventory {
+		if backend.inventory[i].ID == "b" {
+			backend.inventory[i].Quantity--
+		}
+	}
+
+	_, err := client.MergeListings(context.Background(), group, MergeKeepLowestPrice)
+	var mergeErr *MergeError
+	if !errors.As(err, &mergeErr) || !errors.Is(err, ErrPreconditionFailed) || mergeErr.RolledBack {
+		t.Fatalf("MergeListings() error = %v, want unchanged-listing failure", err)
+	}
+	if len(backend.updates) != 0 || len(backend.deleted) != 0 {
+		t.Errorf("wrote after a change: updates %+v, deleted %v", backend.updates, backend.deleted)
+	}
+}
+
+func TestClient_MergeListings_Rollback(t *testing.T) {
+	backend, client, group := newMergeBackend(t)
+	backend.fail["p-c"] = true
+
+	_, err := client.MergeListings(context.Background(), group, MergeKeepLowestPrice)
+	var mergeErr *MergeError
+	if !errors.As(err, &mergeErr) {
+		t.Fatalf("MergeListings() error = %v, want *MergeError", err)
+	}
+	if !mergeErr.RolledBack || len(mergeErr.Deleted) != 1 || len(mergeErr.Remaining) != 1 || mergeErr.Remaining[0].ID != "c" {
+		t.Errorf("MergeError = %+v", mergeErr)
+	}
+	// The kept listing goes back to its own quantity plus the deleted one's.
+	if len(backend.updates) != 2 || backend.updates[1] != (InventoryUpdateRequest{PriceCents: 100, Quantity: 4}) {
+		t.Errorf("updates = %+v", backend.updates)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !strings.Contains(err.Error(), "after deleting 1 of 2 listings") {
+		t.Errorf("error = %v", err)
+	}
+
+	backend, client, group = newMergeBackend(t)
+	backend.fail["p-b"] = true
+	backend.failUpdate[2] = true
+	_, err = client.MergeListings(context.Background(), group, MergeKeepLowestPrice)
+	if !errors.As(err, &mergeErr) || mergeErr.RolledBack || mergeErr.RollbackErr == nil || !strings.Contains(err.Error(), "rollback failed") {
+		t.Errorf("MergeListings(rollback fails) error = %v", err)
+	}
+
+	backend, client, group = newMergeBackend(t)
+	backend.failUpdate[1] = true
+	_, err = client.MergeListings(context.Background(), group, MergeKeepLowestPrice)
+	if !errors.As(err, &mergeErr) || mergeErr.RolledBack || len(mergeErr.Remaining) != 2 || len(backend.deleted) != 0 {
+		t.Errorf("MergeListings(update fails) error = %v", err)
+	}
+}
+
+func TestClient_MergeListings_Validation(t *testing.T) {
+	_, client, group := newMergeBackend(t)
+	var validationErr *ValidationError
+
+	if _, err := client.MergeListings(context.Background(), ListingGroup{Items: group.Items[:1]}, MergeKeepNewest); !errors.As(err, &validationErr) {
+		t.Errorf("MergeListings(one item) error = %v", err)
+	}
+	if _, err := client.MergeListings(context.Background(), group, "cheapest"); !errors.As(err, &validationErr) {
+		t.Errorf("MergeListings(bad strategy) error = %v", err)
+	}
+	bare := ListingGroup{Items: []InventoryItem{group.Items[0], {ID: "x"}}}
+	if _, err := client.MergeListings(context.Background(), bare, MergeKeepNewest); !errors.As(err, &validationErr) {
+		t.Errorf("MergeListings(no product) error = %v", err)
+	}
+}
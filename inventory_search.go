@@ -0,0 +1,242 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// searchPageSize is the page size used when streaming inventory for a search
+// or lookup.
+const searchPageSize = 500
+
+// SearchOptions configures an inventory search.
+type SearchOptions struct {
+	// Limit caps the number of results (0 returns every match)
+	Limit int
+
+	// Filter, if set, limits the search to items it returns true for, such
+	// as InventoryInSet.
+	Filter InventoryFilter
+}
+
+// Validate validates the search options.
+func (o SearchOptions) Validate() error {
+	if o.Limit < 0 {
+		return NewValidationError("limit", fmt.Sprintf("limit must be non-negative, got %d", o.Limit))
+	}
+	return nil
+}
+
+// Search finds inventory items whose card or product name matches query,
+// ranked by relevance: exact names first, then names starting with the
+// query, then names with words starting with each query word, then names
+// containing the query anywhere. Matching ignores case and extra spaces.
+// Ties are broken by shorter name, then name, then ID.
+//
+// The API has no search or name filter on the seller inventory endpoint, so
+// Search streams the inventory a page at a time and ranks it on the client.
+// Only matching items are kept in memory, but every page is still fetched;
+// callers searching repeatedly should keep a local copy, such as a
+// snapshot, and use SearchInventory.
+//
+// Example:
+//
+//	items, err := client.Search(ctx, "lightning bolt", manapool.SearchOptions{Limit: 10})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, item := range items {
+//	    fmt.Printf("%s %s %s\n", item.ID, item.Product.Single.Name, manapool.USDCents(item.PriceCents))
+//	}
+func (c *Client) Search(ctx context.Context, query string, opts SearchOptions) ([]InventoryItem, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	s, err := newInventorySearch(query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.streamInventory(ctx, searchPageSize, "search inventory", func(item InventoryItem) error {
+		s.add(item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.results(), nil
+}
+
+// FindByScryfallIDs returns the seller's listings for each of the given
+// Scryfall IDs, keyed by ID. A card can have several listings, one per
+// condition, finish, and language; IDs without listings are left out of the
+// map. IDs are matched case-insensitively and keyed as given.
+//
+// The API's by-Scryfall-ID lookup needs the exact language, finish, and
+// condition and takes one ID per request, so FindByScryfallIDs instead
+// streams the inventory once, a page at a time, and keeps only the matching
+// listings. The number of requests depends on inventory size, not on how
+// many IDs are asked for.
+//
+// Example:
+//
+//	found, err := client.FindByScryfallIDs(ctx, []string{boltID, ringID})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, item := range found[boltID] {
+//	    fmt.Printf("%s %s: %d\n", item.Product.Single.ConditionID, item.Product.Single.FinishID, item.Quantity)
+//	}
+func (c *Client) FindByScryfallIDs(ctx context.Context, ids []string) (map[string][]InventoryItem, error) {
+	wanted := make(map[string]string, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return nil, NewValidationError("ids", "ids cannot contain an empty id")
+		}
+		wanted[strings.ToLower(id)] = id
+	}
+
+	found := make(map[string][]InventoryItem)
+	if len(wanted) == 0 {
+		return found, nil
+	}
+
+	err := c.streamInventory(ctx, searchPageSize, "find inventory by scryfall id", func(item InventoryItem) error {
+		if item.Product.Single == nil {
+			return nil
+		}
+		if id, ok := wanted[strings.ToLower(item.Product.Single.ScryfallID)]; ok {
+			found[id] = append(found[id], item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// SearchInventory ranks items already in memory against query, as Search
+// does for the live inventory.
+func SearchInventory(items []InventoryItem, query string, opts SearchOptions) ([]InventoryItem, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	s, err := newInventorySearch(query, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		s.add(item)
+	}
+	return s.results(), nil
+}
+
+// Relevance scores, highest first.
+const (
+	searchContains = iota + 1
+	searchWordPrefix
+	searchPrefix
+	searchExact
+)
+
+// inventorySearch collects and ranks the matches for one query.
+type inventorySearch struct {
+	query   string
+	words   []string
+	opts    SearchOptions
+	matches []searchMatch
+}
+
+type searchMatch struct {
+	item  InventoryItem
+	name  string
+	score int
+}
+
+func newInventorySearch(query string, opts SearchOptions) (*inventorySearch, error) {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return nil, NewValidationError("query", "query cannot be empty")
+	}
+	return &inventorySearch{query: strings.Join(words, " "), words: words, opts: opts}, nil
+}
+
+func (s *inventorySearch) add(item InventoryItem) {
+	if s.opts.Filter != nil && !s.opts.Filter(item) {
+		return
+	}
+	var name string
+	switch {
+	case item.Product.Single != nil:
+		name = item.Product.Single.Name
+	case item.Product.Sealed != nil:
+		name = item.Product.Sealed.Name
+	}
+	name = strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	if score := s.score(name); score > 0 {
+		s.matches = append(s.matches, searchMatch{item: item, name: name, score: score})
+	}
+}
+
+// score returns the relevance of name, or 0 if it does not match.
+func (s *inventorySearch) score(name string) int {
+	switch {
+	case name == "":
+		return 0
+	case name == s.query:
+		return searchExact
+	case strings.HasPrefix(name, s.query):
+		return searchPrefix
+	}
+
+	nameWords := strings.Fields(name)
+	allPrefixed := true
+	for _, word := range s.words {
+		found := false
+		for _, nameWord := range nameWords {
+			if strings.HasPrefix(nameWord, word) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			allPrefixed = false
+			break
+		}
+	}
+	switch {
+	case allPrefixed:
+		return searchWordPrefix
+	case strings.Contains(name, s.query):
+		return searchContains
+	}
+	return 0
+}
+
+func (s *inventorySearch) results() []InventoryItem {
+	sort.Slice(s.matches, func(i, j int) bool {
+		a, b := s.matches[i], s.matches[j]
+		switch {
+		case a.score != b.score:
+			return a.score > b.score
+		case len(a.name) != len(b.name):
+			return len(a.name) < len(b.name)
+		case a.name != b.name:
+			return a.name < b.name
+		}
+		return a.item.ID < b.item.ID
+	})
+
+	n := len(s.matches)
+	if s.opts.Limit > 0 && s.opts.Limit < n {
+		n = s.opts.Limit
+	}
+	items := make([]InventoryItem, n)
+	for i := range items {
+		items[i] = s.matches[i].item
+	}
+	return items
+}
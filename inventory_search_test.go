@@ -0,0 +1,128 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func searchItems() []InventoryItem {
+	single := func(id, name, set string) InventoryItem {
+		return InventoryItem{ID: id, ProductType: "mtg_single", ProductID: "p-" + id,
+			Product: Product{Single: &Single{Name: name, Set: set}}}
+	}
+	items := []InventoryItem{
+		single("storm", "Lightning Storm", "ONS"),
+		single("bolt2", "Lightning  Bolt", "M10"),
+		single("helix", "Lightning Helix", "RAV"),
+		single("bolt", "Lightning Bolt", "LEA"),
+		single("bolter", "Lightning Bolter", "FUT"),
+		single("chain", "Chain Lightning", "LEG"),
+		single("bb", "Boltbender Lightning", "XYZ"),
+		{ID: "box", ProductType: "mtg_sealed", ProductID: "p-box",
+			Product: Product{Sealed: &Sealed{Name: "Lightning Bolt Box", Set: "LEA"}}},
+		{ID: "bare", ProductType: "mtg_single", ProductID: "p-bare"},
+	}
+	for i := range items {
+		items[i].EffectiveAsOf = Timestamp{Time: time.Now()}
+	}
+	return items
+}
+
+func itemIDs(items []InventoryItem) string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestSearchInventory(t *testing.T) {
+	tests := []struct {
+		query string
+		opts  SearchOptions
+		want  string
+	}{
+		{"lightning bolt", SearchOptions{}, "bolt,bolt2,bolter,box,bb"},
+		{"  BOLT lightning ", SearchOptions{}, "bolt,bolt2,bolter,box,bb"},
+		{"lightning", SearchOptions{Limit: 2}, "bolt,bolt2"},
+		{"ning", SearchOptions{}, "bolt,bolt2,chain,helix,storm,bolter,box,bb"},
+		{"lightning bolt", SearchOptions{Filter: InventoryInSet("LEA")}, "bolt,box"},
+		{"counterspell", SearchOptions{}, ""},
+	}
+	for _, tt := range tests {
+		got, err := SearchInventory(searchItems(), tt.query, tt.opts)
+		if err != nil {
+			t.Fatalf("SearchInventory(%q) error = %v", tt.query, err)
+		}
+		if ids := itemIDs(got); ids != tt.want {
+			t.Errorf("SearchInventory(%q) = %s, want %s", tt.query, ids, tt.want)
+		}
+	}
+
+	var validationErr *ValidationError
+	if _, err := SearchInventory(nil, " ", SearchOptions{}); !errors.As(err, &validationErr) {
+		t.Errorf("SearchInventory(blank) error = %v", err)
+	}
+	if _, err := SearchInventory(nil, "bolt", SearchOptions{Limit: -1}); !errors.As(err, &validationErr) {
+		t.Errorf("SearchInventory(limit -1) error = %v", err)
+	}
+}
+
+func TestClient_Search(t *testing.T) {
+	backend := newDeleteTestServer()
+	backend.inventory = searchItems()
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	items, err := client.Search(context.Background(), "Lightning Bolt", SearchOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if itemIDs(items) != "bolt" {
+		t.Errorf("Search() = %s", itemIDs(items))
+	}
+
+	if _, err := client.Search(context.Background(), "", SearchOptions{}); err == nil {
+		t.Error("Search(empty) error = nil")
+	}
+	server.Close()
+	if _, err := client.Search(context.Background(), "bolt", SearchOptions{}); err == nil || !strings.Contains(err.Error(), "failed to search inventory") {
+		t.Errorf("Search(closed) error = %v", err)
+	}
+}
+
+func TestClient_FindByScryfallIDs(t *testing.T) {
+	backend := newDeleteTestServer()
+	backend.inventory = searchItems()
+	backend.inventory[0].Product.Single.ScryfallID = "AAA"
+	backend.inventory[1].Product.Single.ScryfallID = "bbb"
+	backend.inventory[3].Product.Single.ScryfallID = "bbb"
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	found, err := client.FindByScryfallIDs(context.Background(), []string{"aaa", "bbb", "ccc", "bbb"})
+	if err != nil {
+		t.Fatalf("FindByScryfallIDs() error = %v", err)
+	}
+	if len(found) != 2 || itemIDs(found["aaa"]) != "storm" || itemIDs(found["bbb"]) != "bolt2,bolt" {
+		t.Errorf("FindByScryfallIDs() = %v", found)
+	}
+
+	if found, err := client.FindByScryfallIDs(context.Background(), nil); err != nil || len(found) != 0 {
+		t.Errorf("FindByScryfallIDs(nil) = %v, %v", found, err)
+	}
+	var validationErr *ValidationError
+	if _, err := client.FindByScryfallIDs(context.Background(), []string{"aaa", ""}); !errors.As(err, &validationErr) {
+		t.Errorf("FindByScryfallIDs(empty id) error = %v", err)
+	}
+	server.Close()
+	if _, err := client.FindByScryfallIDs(context.Background(), []string{"aaa"}); err == nil {
+		t.Error("FindByScryfallIDs(closed) error = nil")
+	}
+}
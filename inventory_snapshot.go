@@ -0,0 +1,249 @@
+package manapool
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// SnapshotFormat identifies inventory snapshot files.
+const SnapshotFormat = "manapool-inventory-snapshot"
+
+// SnapshotVersion is the snapshot format version written by SnapshotInventory.
+const SnapshotVersion = 1
+
+// snapshotPageSize is the page size used when streaming inventory.
+const snapshotPageSize = 500
+
+// RestoreMode controls how RestoreInventory treats listings that are not in
+// the snapshot.
+type RestoreMode string
+
+const (
+	// RestoreMerge upserts the snapshot's listings and leaves any other
+	// listings untouched.
+	RestoreMerge RestoreMode = "merge"
+
+	// RestoreReplace upserts the snapshot's listings and deletes every other
+	// listing, so inventory matches the snapshot exactly.
+	RestoreReplace RestoreMode = "replace"
+)
+
+// SnapshotHeader describes an inventory snapshot.
+type SnapshotHeader struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// snapshotRecord is one line of a snapshot file. The file is JSON Lines: a
+// header record, one item record per listing, and a footer record carrying
+// the item count so truncated files are detected.
+type snapshotRecord struct {
+	Type   string          `json:"type"`
+	Header *SnapshotHeader `json:"header,omitempty"`
+	Item   *InventoryItem  `json:"item,omitempty"`
+	Count  *int            `json:"count,omitempty"`
+}
+
+// SnapshotInventory streams the seller's full inventory to w in a versioned
+// JSON Lines format that RestoreInventory can replay. Pages are written as
+// they are received, so memory use does not grow with inventory size. It
+// returns the number of listings written.
+//
+// Example:
+//
+//	f, err := os.Create("inventory-" + time.Now().Format("20060102") + ".jsonl")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer f.Close()
+//	n, err := client.SnapshotInventory(ctx, f)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	log.Printf("saved %d listings", n)
+func (c *Client) SnapshotInventory(ctx context.Context, w io.Writer) (int, error) {
+	buf := bufio.NewWriter(w)
+	enc := json.NewEncoder(buf)
+
+	header := &SnapshotHeader{Format: SnapshotFormat, Version: SnapshotVersion, CreatedAt: time.Now().UTC()}
+	if err := enc.Encode(snapshotRecord{Type: "header", Header: header}); err != nil {
+		return 0, fmt.Errorf("failed to write snapshot header: %w", err)
+	}
+
+	count := 0
+	err := c.streamInventory(ctx, snapshotPageSize, "snapshot inventory", func(item InventoryItem) error {
+		count++
+		return enc.Encode(snapshotRecord{Type: "item", Item: &item})
+	})
+	if err != nil {
+		return count, err
+	}
+
+	if err := enc.Encode(snapshotRecord{Type: "footer", Count: &count}); err != nil {
+		return count, fmt.Errorf("failed to write snapshot footer: %w", err)
+	}
+	if err := buf.Flush(); err != nil {
+		return count, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	c.logger.Debugf("Snapshot wrote %d inventory items", count)
+	return count, nil
+}
+
+// ReadSnapshot parses a snapshot written by SnapshotInventory. It fails if
+// the format or version is not recognized, or if the file is truncated.
+func ReadSnapshot(r io.Reader) (*SnapshotHeader, []InventoryItem, error) {
+	dec := json.NewDecoder(r)
+
+	var first snapshotRecord
+	if err := dec.Decode(&first); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, NewValidationError("snapshot", "snapshot is empty")
+		}
+		return nil, nil, fmt.Errorf("failed to read snapshot header: %w", err)
+	}
+	header := first.Header
+	if first.Type != "header" || header == nil || header.Format != SnapshotFormat {
+		return nil, nil, NewValidationError("snapshot", "not an inventory snapshot")
+	}
+	if header.Version != SnapshotVersion {
+		return nil, nil, NewValidationError("snapshot", fmt.Sprintf("unsupported snapshot version %d", header.Version))
+	}
+
+	var items []InventoryItem
+	for {
+		var record snapshotRecord
+		if err := dec.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, nil, NewValidationError("snapshot", fmt.Sprintf("snapshot is truncated after %d items", len(items)))
+			}
+			return nil, nil, fmt.Errorf("failed to read snapshot item %d: %w", len(items)+1, err)
+		}
+
+		switch {
+		case record.Type == "item" && record.Item != nil:
+			items = append(items, *record.Item)
+		case record.Type == "footer" && record.Count != nil:
+			if *record.Count != len(items) {
+				return nil, nil, NewValidationError("snapshot",
+					fmt.Sprintf("snapshot footer counts %d items but %d were read", *record.Count, len(items)))
+			}
+			return header, items, nil
+		default:
+			return nil, nil, NewValidationError("snapshot", fmt.Sprintf("unexpected %q record after %d items", record.Type, len(items)))
+		}
+	}
+}
+
+// RestoreReport summarizes a RestoreInventory run.
+type RestoreReport struct {
+	// Upserts is the outcome of writing the snapshot's listings
+	Upserts *BulkResult
+
+	// Deleted is the number of listings removed in RestoreReplace mode
+	Deleted int
+
+	// DeleteFailures are listings that could not be removed
+	DeleteFailures []DeleteFailure
+}
+
+// Err returns an error joining all upsert and delete failures, or nil.
+func (r *RestoreReport) Err() error {
+	var errs []error
+	if r.Upserts != nil {
+		if err := r.Upserts.Err(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, failure := range r.DeleteFailures {
+		errs = append(errs, failure.Err)
+	}
+	return errors.Join(errs...)
+}
+
+// RestoreInventory replays a snapshot written by SnapshotInventory. Every
+// listing in the snapshot is upserted by product ID with its saved price and
+// quantity. In RestoreReplace mode, listings that are not in the snapshot
+// are then deleted; in RestoreMerge mode they are left alone.
+//
+// The whole snapshot is read and validated before anything is written, so a
+// corrupt or truncated file changes nothing. Individual write failures are
+// collected in the report; check RestoreReport.Err.
+//
+// Example:
+//
+//	f, err := os.Open("inventory-20240101.jsonl")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer f.Close()
+//	report, err := client.RestoreInventory(ctx, f, manapool.RestoreReplace)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if err := report.Err(); err != nil {
+//	    log.Printf("restore incomplete: %v", err)
+//	}
+func (c *Client) RestoreInventory(ctx context.Context, r io.Reader, mode RestoreMode) (*RestoreReport, error) {
+	if mode != RestoreMerge && mode != RestoreReplace {
+		return nil, NewValidationError("mode", fmt.Sprintf("unknown restore mode %q", mode))
+	}
+
+	header, items, err := ReadSnapshot(r)
+	if err != nil {
+		return nil, err
+	}
+	c.logger.Debugf("Restoring %d inventory items from snapshot taken %s (%s)", len(items), header.CreatedAt, mode)
+
+	upserts := make([]InventoryUpsert, len(items))
+	keep := make(map[string]bool, len(items))
+	for i, item := range items {
+		upserts[i] = InventoryUpsert{
+			ProductType: item.ProductType,
+			ProductID:   item.ProductID,
+			PriceCents:  item.PriceCents,
+			Quantity:    item.Quantity,
+		}
+		keep[item.ProductType+"/"+item.ProductID] = true
+	}
+
+	report := &RestoreReport{}
+	report.Upserts, err = c.UpsertInventory(ctx, upserts, BulkOptions{})
+	if err != nil {
+		return report, err
+	}
+	if mode == RestoreMerge {
+		return report, nil
+	}
+
+	var extra []InventoryItem
+	err = IterateInventory(ctx, c, func(item *InventoryItem) error {
+		if !keep[item.ProductType+"/"+item.ProductID] {
+			extra = append(extra, *item)
+		}
+		return nil
+	})
+	if err != nil {
+		return report, fmt.Errorf("failed to load remote inventory: %w", err)
+	}
+
+	for _, item := range extra {
+		_, err := c.DeleteSellerInventoryByProduct(ctx, item.ProductType, item.ProductID)
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+		if err != nil {
+			report.DeleteFailures = append(report.DeleteFailures, DeleteFailure{Item: item, Err: err})
+			continue
+		}
+		report.Deleted++
+	}
+
+	return report, nil
+}
@@ -0,0 +1,160 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// restoreTestServer extends deleteTestServer with bulk writes by product.
+type restoreTestServer struct {
+	*deleteTestServer
+	upserted []InventoryBulkItemByProduct
+}
+
+func (s *restoreTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost && r.URL.Path == "/seller/inventory/product" {
+		var items []InventoryBulkItemByProduct
+		_ = json.NewDecoder(r.Body).Decode(&items)
+		s.mu.Lock()
+		s.upserted = append(s.upserted, items...)
+		s.mu.Unlock()
+		_, _ = w.Write([]byte(`{"inventory":[]}`))
+		return
+	}
+	s.deleteTestServer.ServeHTTP(w, r)
+}
+
+func TestClient_SnapshotAndRestoreInventory(t *testing.T) {
+	backend := &restoreTestServer{deleteTestServer: newDeleteTestServer()}
+	backend.inventory = backend.inventory[:4]
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	ctx := context.Background()
+
+	var snapshot bytes.Buffer
+	n, err := client.SnapshotInventory(ctx, &snapshot)
+	if err != nil {
+		t.Fatalf("SnapshotInventory() error = %v", err)
+	}
+	if n != 4 {
+		t.Errorf("SnapshotInventory() = %d, want 4", n)
+	}
+	lines := strings.Split(strings.TrimSpace(snapshot.String()), "\n")
+	if len(lines) != 6 || !strings.Contains(lines[0], `"format":"manapool-inventory-snapshot","version":1`) ||
+		lines[5] != `{"type":"footer","count":4}` {
+		t.Errorf("snapshot = %s", snapshot.String())
+	}
+
+	header, items, err := ReadSnapshot(bytes.NewReader(snapshot.Bytes()))
+	if err != nil {
+		t.Fatalf("ReadSnapshot() error = %v", err)
+	}
+	if header.Version != SnapshotVersion || len(items) != 4 || items[3].ProductType != "mtg_sealed" {
+		t.Errorf("ReadSnapshot() = %+v, %d items", header, len(items))
+	}
+
+	// A bad bulk edit after the snapshot: one listing added, prices changed
+	backend.mu.Lock()
+	backend.inventory = append(backend.inventory, InventoryItem{ID: "new", ProductType: "mtg_single", ProductID: "p-new", EffectiveAsOf: items[0].EffectiveAsOf})
+	backend.mu.Unlock()
+
+	report, err := client.RestoreInventory(ctx, bytes.NewReader(snapshot.Bytes()), RestoreMerge)
+	if err != nil || report.Err() != nil {
+		t.Fatalf("RestoreInventory(merge) error = %v, %v", err, report.Err())
+	}
+	if len(backend.upserted) != 4 || backend.upserted[0].ProductID != "p-a" || backend.upserted[0].PriceCents != 100 || report.Deleted != 0 {
+		t.Errorf("merge upserted = %+v, deleted %d", backend.upserted, report.Deleted)
+	}
+
+	report, err = client.RestoreInventory(ctx, bytes.NewReader(snapshot.Bytes()), RestoreReplace)
+	if err != nil || report.Err() != nil {
+		t.Fatalf("RestoreInventory(replace) error = %v, %v", err, report.Err())
+	}
+	if report.Deleted != 1 || strings.Join(backend.deleted, ",") != "p-new" {
+		t.Errorf("replace deleted %d: %v", report.Deleted, backend.deleted)
+	}
+}
+
+func TestClient_RestoreInventory_DeleteFailure(t *testing.T) {
+	backend := &restoreTestServer{deleteTestServer: newDeleteTestServer()}
+	backend.fail["p-bare"] = true
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	snapshot := `{"type":"header","header":{"format":"manapool-inventory-snapshot","version":1,"created_at":"2024-01-01T00:00:00Z"}}
+{"type":"item","item":{"id":"a","product_type":"mtg_single","product_id":"p-a","product":{},"price_cents":100,"quantity":1,"effective_as_of":"2024-01-01T00:00:00Z"}}
+{"type":"footer","count":1}
+`
+	report, err := client.RestoreInventory(context.Background(), strings.NewReader(snapshot), RestoreReplace)
+	if err != nil {
+		t.Fatalf("RestoreInventory() error = %v", err)
+	}
+	if report.Deleted != 3 || len(report.DeleteFailures) != 1 || report.Err() == nil {
+		t.Errorf("report = deleted %d, failures %+v", report.Deleted, report.DeleteFailures)
+	}
+}
+
+func TestReadSnapshot_Errors(t *testing.T) {
+	header := `{"type":"header","header":{"format":"manapool-inventory-snapshot","version":1,"created_at":"2024-01-01T00:00:00Z"}}` + "\n"
+	item := `{"type":"item","item":{"id":"a","effective_as_of":"2024-01-01T00:00:00Z"}}` + "\n"
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", "snapshot is empty"},
+		{"not a snapshot", `{"inventory":[]}`, "not an inventory snapshot"},
+		{"future version", strings.Replace(header, `"version":1`, `"version":2`, 1), "unsupported snapshot version 2"},
+		{"truncated", header + item, "truncated after 1 items"},
+		{"count mismatch", header + item + `{"type":"footer","count":2}`, "footer counts 2 items but 1 were read"},
+		{"unknown record", header + `{"type":"comment"}`, `unexpected "comment" record`},
+		{"bad json", header + "{", "failed to read snapshot item 1"},
+		{"bad header json", "nope", "failed to read snapshot header"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ReadSnapshot(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ReadSnapshot() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	client := NewClient("token", "email")
+	var valErr *ValidationError
+	if _, err := client.RestoreInventory(context.Background(), strings.NewReader(header), "overwrite"); !errors.As(err, &valErr) {
+		t.Errorf("RestoreInventory(bad mode) error = %v", err)
+	}
+	if _, err := client.RestoreInventory(context.Background(), strings.NewReader(header+item), RestoreMerge); !errors.As(err, &valErr) {
+		t.Errorf("RestoreInventory(truncated) error = %v", err)
+	}
+}
+
+func TestClient_SnapshotInventory_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	if _, err := client.SnapshotInventory(context.Background(), &bytes.Buffer{}); err == nil {
+		t.Error("SnapshotInventory() with server error = nil")
+	}
+
+	backend := httptest.NewServer(newDeleteTestServer())
+	defer backend.Close()
+	client = NewClient("token", "email", WithBaseURL(backend.URL+"/"))
+	if _, err := client.SnapshotInventory(context.Background(), failingWriter{}); err == nil || !strings.Contains(err.Error(), "failed to write snapshot") {
+		t.Errorf("SnapshotInventory() with failing writer error = %v", err)
+	}
+}
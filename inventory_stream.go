@@ -0,0 +1,178 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ListInventoryStream retrieves a page of seller inventory and calls fn for
+// each item as it is decoded, without materializing the whole page.
+//
+// Unlike GetSellerInventory, items are decoded one at a time from the
+// response stream, which keeps memory usage flat for large pages. If fn
+// returns an error, decoding stops and that error is returned wrapped.
+//
+// Example:
+//
+//	page, err := client.ListInventoryStream(ctx, manapool.InventoryOptions{Limit: 500},
+//	    func(item manapool.InventoryItem) error {
+//	        fmt.Printf("%s: %d\n", item.ID, item.Quantity)
+//	        return nil
+//	    })
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Total items: %d\n", page.Total)
+//
+// Returns:
+//   - *Pagination: The pagination metadata for the page
+//   - error: Any error that occurred during the request or callback
+func (c *Client) ListInventoryStream(ctx context.Context, opts InventoryOptions, fn func(InventoryItem) error) (*Pagination, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.TCGPlayerSKU > 0 {
+		page, err := c.sellerInventoryPageBySKU(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Inventory {
+			if err := fn(item); err != nil {
+				return nil, fmt.Errorf("callback error at item 0: %w", err)
+			}
+		}
+		return &page.Pagination, nil
+	}
+
+	c.logger.Debugf("Streaming seller inventory: limit=%d, offset=%d", opts.Limit, opts.Offset)
+
+	params := url.Values{}
+	params.Add("limit", strconv.Itoa(opts.Limit))
+	params.Add("offset", strconv.Itoa(opts.Offset))
+
+	resp, err := c.doRequest(ctx, "GET", "/seller/inventory", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seller inventory: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("failed to decode seller inventory: %w", c.decodeResponse(resp, nil))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	pagination, err := decodeInventoryStream(json.NewDecoder(resp.Body), c.lenientTimestamps, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Debugf("Streamed %d inventory items (total: %d)", pagination.Returned, pagination.Total)
+
+	return pagination, nil
+}
+
+// streamInventory calls fn for every item in the seller's inventory,
+// fetching it with ListInventoryStream in pages of pageSize. Errors read
+// "failed to <action> at offset N".
+func (c *Client) streamInventory(ctx context.Context, pageSize int, action string, fn func(InventoryItem) error) error {
+	opts := InventoryOptions{Limit: pageSize}
+	for {
+		page, err := c.ListInventoryStream(ctx, opts, fn)
+		if err != nil {
+			return fmt.Errorf("failed to %s at offset %d: %w", action, opts.Offset, err)
+		}
+		var more bool
+		if opts, more = page.Next(opts); !more {
+			return nil
+		}
+	}
+}
+
+// decodeInventoryStream walks an inventory response object token by token,
+// decoding each element of the "inventory" array individually. Unless
+// lenient, an item with a timestamp that matched no layout is an error.
+func decodeInventoryStream(dec *json.Decoder, lenient bool, fn func(InventoryItem) error) (*Pagination, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var pagination Pagination
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode seller inventory: %w", err)
+		}
+		key, _ := tok.(string)
+
+		switch key {
+		case "inventory":
+			if err := decodeInventoryItems(dec, lenient, fn); err != nil {
+				return nil, err
+			}
+		case "pagination":
+			if err := dec.Decode(&pagination); err != nil {
+				return nil, fmt.Errorf("failed to decode pagination: %w", err)
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil, fmt.Errorf("failed to decode seller inventory: %w", err)
+			}
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	return &pagination, nil
+}
+
+// decodeInventoryItems decodes an inventory array, calling fn per element.
+func decodeInventoryItems(dec *json.Decoder, lenient bool, fn func(InventoryItem) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to decode seller inventory: %w", err)
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("failed to decode seller inventory: expected array, got %v", tok)
+	}
+
+	for index := 0; dec.More(); index++ {
+		var item InventoryItem
+		if err := dec.Decode(&item); err != nil {
+			return fmt.Errorf("failed to decode inventory item %d: %w", index, err)
+		}
+		if !lenient {
+			if err := CheckTimestamps(&item); err != nil {
+				return fmt.Errorf("failed to decode inventory item %d: %w", index, err)
+			}
+		}
+		if err := fn(item); err != nil {
+			return fmt.Errorf("callback error at item %d: %w", index, err)
+		}
+	}
+
+	return expectDelim(dec, ']')
+}
+
+// expectDelim reads the next token and checks that it is the given delimiter.
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to decode seller inventory: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("failed to decode seller inventory: expected %v, got %v", want, tok)
+	}
+	return nil
+}
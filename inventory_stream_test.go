@@ -0,0 +1,191 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_ListInventoryStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/seller/inventory" {
+			t.Errorf("path = %s, want /seller/inventory", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("limit = %q, want 2", got)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"meta": {"ignored": [1, 2, 3]},
+			"inventory": [
+				{"id": "a", "price_cents": 100, "quantity": 1},
+				{"id": "b", "price_cents": 250, "quantity": 4}
+			],
+			"pagination": {"total": 10, "returned": 2, "offset": 0, "limit": 2}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+
+	var ids []string
+	page, err := client.ListInventoryStream(context.Background(), InventoryOptions{Limit: 2}, func(item InventoryItem) error {
+		ids = append(ids, item.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ListInventoryStream() error = %v", err)
+	}
+	if strings.Join(ids, ",") != "a,b" {
+		t.Errorf("ids = %v, want [a b]", ids)
+	}
+	if page.Total != 10 || page.Returned != 2 {
+		t.Errorf("pagination = %+v, want total 10 returned 2", page)
+	}
+}
+
+func TestClient_ListInventoryStream_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		opts     InventoryOptions
+		callback func(InventoryItem) error
+		wantErr  string
+	}{
+		{
+			name:    "invalid options",
+			opts:    InventoryOptions{Limit: -1},
+			wantErr: "limit must be non-negative",
+		},
+		{
+			name:    "api error",
+			status:  http.StatusUnauthorized,
+			body:    `{"error":"bad token"}`,
+			wantErr: "bad token",
+		},
+		{
+			name:    "not an object",
+			status:  http.StatusOK,
+			body:    `[]`,
+			wantErr: "expected {",
+		},
+		{
+			name:    "inventory not an array",
+			status:  http.StatusOK,
+			body:    `{"inventory": {}}`,
+			wantErr: "expected array",
+		},
+		{
+			name:    "bad item",
+			status:  http.StatusOK,
+			body:    `{"inventory": [{"id": 5}]}`,
+			wantErr: "failed to decode inventory item 0",
+		},
+		{
+			name:    "bad pagination",
+			status:  http.StatusOK,
+			body:    `{"pagination": "nope"}`,
+			wantErr: "failed to decode pagination",
+		},
+		{
+			name:    "truncated",
+			status:  http.StatusOK,
+			body:    `{"inventory": [`,
+			wantErr: "failed to decode",
+		},
+		{
+			name:   "callback error",
+			status: http.StatusOK,
+			body:   `{"inventory": [{"id": "a"}]}`,
+			callback: func(InventoryItem) error {
+				return errors.New("stop")
+			},
+			wantErr: "callback error at item 0: stop",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			callback := tt.callback
+			if callback == nil {
+				callback = func(InventoryItem) error { return nil }
+			}
+
+			client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+			_, err := client.ListInventoryStream(context.Background(), tt.opts, callback)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_ListInventoryStream_NullInventory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"inventory": null, "pagination": {"total": 0}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	page, err := client.ListInventoryStream(context.Background(), InventoryOptions{}, func(InventoryItem) error {
+		t.Error("callback called for null inventory")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ListInventoryStream() error = %v", err)
+	}
+	if page.Total != 0 {
+		t.Errorf("Total = %d, want 0", page.Total)
+	}
+}
+
+func TestClient_streamInventory(t *testing.T) {
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset := r.URL.Query().Get("offset")
+		offsets = append(offsets, offset)
+		switch offset {
+		case "0":
+			_, _ = w.Write([]byte(`{"inventory":[{"id":"a"},{"id":"b"}],"pagination":{"total":5,"returned":2,"offset":0,"limit":2}}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"inventory":[{"id":"c"},{"id":"d"}],"pagination":{"total":5,"returned":2,"offset":2,"limit":2}}`))
+		case "4":
+			_, _ = w.Write([]byte(`{"inventory":[{"id":"e"}],"pagination":{"total":5,"returned":1,"offset":4,"limit":2}}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+
+	var ids []string
+	err := client.streamInventory(context.Background(), 2, "list inventory", func(item InventoryItem) error {
+		ids = append(ids, item.ID)
+		return nil
+	})
+	if err != nil || strings.Join(ids, ",") != "a,b,c,d,e" || strings.Join(offsets, ",") != "0,2,4" {
+		t.Errorf("streamInventory() = %v, ids %v, offsets %v", err, ids, offsets)
+	}
+
+	boom := errors.New("boom")
+	err = client.streamInventory(context.Background(), 2, "list inventory", func(item InventoryItem) error {
+		if item.ID == "c" {
+			return boom
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) || !strings.HasPrefix(err.Error(), "failed to list inventory at offset 2: ") {
+		t.Errorf("streamInventory() error = %v", err)
+	}
+}
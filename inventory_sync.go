@@ -0,0 +1,363 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultSyncChunkSize is the default number of items per bulk write when
+// applying a sync plan.
+const DefaultSyncChunkSize = 100
+
+// SyncActionType identifies the kind of change in a sync plan.
+type SyncActionType string
+
+const (
+	// SyncCreate adds a SKU that is not in remote inventory.
+	SyncCreate SyncActionType = "create"
+
+	// SyncUpdate changes the price (and possibly quantity) of a listing.
+	SyncUpdate SyncActionType = "update"
+
+	// SyncQuantity changes only the quantity of a listing.
+	SyncQuantity SyncActionType = "quantity"
+
+	// SyncDelete removes a listing that is not in the desired state.
+	SyncDelete SyncActionType = "delete"
+)
+
+// SyncAction is a single planned change to remote inventory.
+type SyncAction struct {
+	Type SyncActionType
+
+	// SKU is the TCGPlayer SKU the action applies to
+	SKU int
+
+	// Current is the remote listing (nil for creates)
+	Current *InventoryItem
+
+	// Desired is the target state (nil for deletes)
+	Desired *InventoryBulkItemBySKU
+}
+
+// SyncPlan is the set of changes needed to make remote inventory match a
+// desired local state. Actions are ordered by type, then SKU.
+type SyncPlan struct {
+	Actions []SyncAction
+
+	// Unchanged is the number of desired items that already match remote
+	Unchanged int
+}
+
+// Count returns the number of actions of the given type.
+func (p *SyncPlan) Count(actionType SyncActionType) int {
+	n := 0
+	for _, action := range p.Actions {
+		if action.Type == actionType {
+			n++
+		}
+	}
+	return n
+}
+
+// IsEmpty reports whether the plan has no changes.
+func (p *SyncPlan) IsEmpty() bool {
+	return len(p.Actions) == 0
+}
+
+// String renders a one-line summary of the plan.
+func (p *SyncPlan) String() string {
+	return fmt.Sprintf("%d create, %d update, %d quantity, %d delete, %d unchanged",
+		p.Count(SyncCreate), p.Count(SyncUpdate), p.Count(SyncQuantity), p.Count(SyncDelete), p.Unchanged)
+}
+
+// SyncOptions configures planning and applying an inventory sync.
+type SyncOptions struct {
+	// DeleteMissing deletes remote listings whose SKU is not in the desired
+	// state. Remote listings without a TCGPlayer SKU are never deleted.
+	DeleteMissing bool
+
+	// ChunkSize is the maximum number of items per bulk write
+	// (default: DefaultSyncChunkSize).
+	ChunkSize int
+
+	// ChunkRetries is the number of times a failed chunk or delete is retried
+	// when the error is a network or server error (default: 0).
+	ChunkRetries int
+
+	// RetryBackoff is the pause between chunk retries (default: 1s).
+	RetryBackoff time.Duration
+
+	// DryRun computes the plan without applying it.
+	DryRun bool
+
+	// Recorder, if set, snapshots listing prices into price history after
+	// SyncInventory applies a plan. Dry runs are not recorded.
+	Recorder *PriceRecorder
+
+	// CrossLister, if set, mirrors the changes SyncInventory applies to
+	// other marketplaces. Inventory is reloaded after applying so that new
+	// listings are mirrored with their IDs. Dry runs are not mirrored.
+	CrossLister *CrossLister
+}
+
+// SyncFailure records a change that could not be applied.
+type SyncFailure struct {
+	Actions []SyncAction
+	Err     error
+}
+
+// SyncReport summarizes the result of applying a sync plan.
+type SyncReport struct {
+	Plan      *SyncPlan
+	Created   int
+	Updated   int
+	Deleted   int
+	Unchanged int
+	Failures  []SyncFailure
+
+	// CrossList reports the changes mirrored by SyncOptions.CrossLister, or
+	// is nil
+	CrossList *CrossListReport
+}
+
+// Failed returns the number of actions that could not be applied.
+func (r *SyncReport) Failed() int {
+	n := 0
+	for _, failure := range r.Failures {
+		n += len(failure.Actions)
+	}
+	return n
+}
+
+// Err returns an error joining all failures, or nil if every action succeeded.
+func (r *SyncReport) Err() error {
+	if len(r.Failures) == 0 {
+		return nil
+	}
+	errs := make([]error, len(r.Failures))
+	for i, failure := range r.Failures {
+		errs[i] = failure.Err
+	}
+	return fmt.Errorf("%d of %d sync actions failed: %w", r.Failed(), len(r.Plan.Actions), errors.Join(errs...))
+}
+
+// String renders a one-line summary of the report.
+func (r *SyncReport) String() string {
+	return fmt.Sprintf("%d created, %d updated, %d deleted, %d unchanged, %d failed",
+		r.Created, r.Updated, r.Deleted, r.Unchanged, r.Failed())
+}
+
+// PlanSync computes the changes needed to make remote match desired.
+// Items are matched by TCGPlayer SKU; later duplicates in desired win.
+func PlanSync(desired []InventoryBulkItemBySKU, remote []InventoryItem, opts SyncOptions) *SyncPlan {
+	wanted := make(map[int]InventoryBulkItemBySKU, len(desired))
+	for _, item := range desired {
+		wanted[item.TCGPlayerSKU] = item
+	}
+
+	current := make(map[int]*InventoryItem, len(remote))
+	for i := range remote {
+		if sku := remote[i].Product.TCGPlayerSKU; sku != nil {
+			current[*sku] = &remote[i]
+		}
+	}
+
+	plan := &SyncPlan{}
+	for sku, want := range wanted {
+		have, ok := current[sku]
+		switch {
+		case !ok:
+			plan.Actions = append(plan.Actions, SyncAction{Type: SyncCreate, SKU: sku, Desired: &want})
+		case have.PriceCents != want.PriceCents:
+			plan.Actions = append(plan.Actions, SyncAction{Type: SyncUpdate, SKU: sku, Current: have, Desired: &want})
+		case have.Quantity != want.Quantity:
+			plan.Actions = append(plan.Actions, SyncAction{Type: SyncQuantity, SKU: sku, Current: have, Desired: &want})
+		default:
+			plan.Unchanged++
+		}
+	}
+
+	if opts.DeleteMissing {
+		for sku, have := range current {
+			if _, ok := wanted[sku]; !ok {
+				plan.Actions = append(plan.Actions, SyncAction{Type: SyncDelete, SKU: sku, Current: have})
+			}
+		}
+	}
+
+	order := map[SyncActionType]int{SyncCreate: 0, SyncUpdate: 1, SyncQuantity: 2, SyncDelete: 3}
+	sort.Slice(plan.Actions, func(i, j int) bool {
+		a, b := plan.Actions[i], plan.Actions[j]
+		if a.Type != b.Type {
+			return order[a.Type] < order[b.Type]
+		}
+		return a.SKU < b.SKU
+	})
+
+	return plan
+}
+
+// ApplySyncPlan applies plan to remote inventory. Creates, updates, and
+// quantity changes are sent as chunked bulk upserts by SKU; deletes are sent
+// individually. Failures are collected in the report rather than aborting,
+// except when ctx is cancelled.
+func (c *Client) ApplySyncPlan(ctx context.Context, plan *SyncPlan, opts SyncOptions) (*SyncReport, error) {
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultSyncChunkSize
+	}
+	backoff := opts.RetryBackoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+
+	report := &SyncReport{Plan: plan, Unchanged: plan.Unchanged}
+
+	var upserts, deletes []SyncAction
+	for _, action := range plan.Actions {
+		if action.Type == SyncDelete {
+			deletes = append(deletes, action)
+		} else {
+			upserts = append(upserts, action)
+		}
+	}
+
+	for start := 0; start < len(upserts); start += chunkSize {
+		end := start + chunkSize
+		if end > len(upserts) {
+			end = len(upserts)
+		}
+		chunk := upserts[start:end]
+
+		items := make([]InventoryBulkItemBySKU, len(chunk))
+		for i, action := range chunk {
+			items[i] = *action.Desired
+		}
+
+		err := retrySyncStep(ctx, opts.ChunkRetries, backoff, func() error {
+			_, err := c.CreateInventoryBulkBySKU(ctx, items)
+			return err
+		})
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+		if err != nil {
+			c.logger.Errorf("Sync chunk %d-%d failed: %v", start, end, err)
+			report.Failures = append(report.Failures, SyncFailure{Actions: chunk, Err: err})
+			continue
+		}
+		for _, action := range chunk {
+			if action.Type == SyncCreate {
+				report.Created++
+			} else {
+				report.Updated++
+			}
+		}
+	}
+
+	for _, action := range deletes {
+		err := retrySyncStep(ctx, opts.ChunkRetries, backoff, func() error {
+			_, err := c.DeleteSellerInventoryBySKU(ctx, action.SKU)
+			return err
+		})
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+		if err != nil {
+			c.logger.Errorf("Sync delete of sku %d failed: %v", action.SKU, err)
+			report.Failures = append(report.Failures, SyncFailure{Actions: []SyncAction{action}, Err: err})
+			continue
+		}
+		report.Deleted++
+	}
+
+	return report, nil
+}
+
+// SyncInventory loads the seller's full remote inventory, plans the changes
+// needed to reach desired, and applies them unless opts.DryRun is set. If
+// opts.Recorder is set, the resulting listing prices are recorded, and if
+// opts.CrossLister is set, the changes are mirrored to other marketplaces.
+// If the account has no seller inventory, it fails with an
+// *UnsupportedEndpointError before planning anything.
+//
+// Example:
+//
+//	report, err := client.SyncInventory(ctx, desired, manapool.SyncOptions{
+//	    DeleteMissing: true,
+//	    ChunkRetries:  2,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(report)
+//	if err := report.Err(); err != nil {
+//	    log.Printf("some changes failed: %v", err)
+//	}
+func (c *Client) SyncInventory(ctx context.Context, desired []InventoryBulkItemBySKU, opts SyncOptions) (*SyncReport, error) {
+	remote, err := c.loadInventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := PlanSync(desired, remote, opts)
+	c.logger.Debugf("Sync plan: %s", plan)
+
+	if opts.DryRun {
+		return &SyncReport{Plan: plan, Unchanged: plan.Unchanged}, nil
+	}
+
+	report, err := c.ApplySyncPlan(ctx, plan, opts)
+	if err != nil {
+		return report, err
+	}
+
+	if opts.Recorder != nil {
+		if err := opts.Recorder.Record(ctx, syncedInventory(remote, report)); err != nil {
+			return report, fmt.Errorf("failed to record price history: %w", err)
+		}
+	}
+	if opts.CrossLister != nil && !plan.IsEmpty() {
+		synced, err := c.loadInventory(ctx)
+		if err != nil {
+			return report, fmt.Errorf("failed to reload inventory for cross-listing: %w", err)
+		}
+		report.CrossList = opts.CrossLister.Propagate(ctx, DiffInventory(remote, synced))
+	}
+	return report, nil
+}
+
+// retrySyncStep runs fn, retrying transient failures up to retries times.
+func retrySyncStep(ctx context.Context, retries int, backoff time.Duration, fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if err = fn(); err == nil || !isTransientError(err) || attempt == retries {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+	return err
+}
+
+// isTransientError reports whether err is a network or server error that may
+// succeed on retry.
+func isTransientError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.IsServerError() || apiErr.IsRateLimited()
+	}
+	var netErr *NetworkError
+	return errors.As(err, &netErr) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,171 @@
+package manapool
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// RenderFormat selects the output of SyncPlan.Render.
+type RenderFormat string
+
+const (
+	// FormatTable is an aligned plain-text table for terminals.
+	FormatTable RenderFormat = "table"
+
+	// FormatJSON is a JSON document for tooling.
+	FormatJSON RenderFormat = "json"
+
+	// FormatMarkdown is a Markdown table, for pasting into a pull request
+	// or ticket for review.
+	FormatMarkdown RenderFormat = "markdown"
+)
+
+// SyncListingState is the price and quantity of a listing on one side of a
+// rendered sync action.
+type SyncListingState struct {
+	PriceCents int `json:"price_cents"`
+	Quantity   int `json:"quantity"`
+}
+
+// renderedSyncAction is one action in FormatJSON output.
+type renderedSyncAction struct {
+	Type      SyncActionType    `json:"type"`
+	SKU       int               `json:"sku"`
+	ListingID string            `json:"listing_id,omitempty"`
+	Name      string            `json:"name,omitempty"`
+	Before    *SyncListingState `json:"before,omitempty"`
+	After     *SyncListingState `json:"after,omitempty"`
+}
+
+// renderedSyncPlan is the FormatJSON document.
+type renderedSyncPlan struct {
+	Summary map[SyncActionType]int `json:"summary"`
+	Actions []renderedSyncAction   `json:"actions"`
+}
+
+// render returns the action's listing details and both sides of the change.
+func (a SyncAction) render() renderedSyncAction {
+	rendered := renderedSyncAction{Type: a.Type, SKU: a.SKU}
+	if a.Current != nil {
+		rendered.ListingID = a.Current.ID
+		switch p := a.Current.Product; {
+		case p.Single != nil:
+			rendered.Name = p.Single.Name
+		case p.Sealed != nil:
+			rendered.Name = p.Sealed.Name
+		}
+		rendered.Before = &SyncListingState{PriceCents: a.Current.PriceCents, Quantity: a.Current.Quantity}
+	}
+	if a.Desired != nil {
+		rendered.After = &SyncListingState{PriceCents: a.Desired.PriceCents, Quantity: a.Desired.Quantity}
+	}
+	return rendered
+}
+
+// Render writes the plan for review before it is applied: every create,
+// update, quantity change, and delete with the listing's price and quantity
+// before and after, followed by a summary. Prices are in dollars, such as
+// "12.50". Creates have no before state and deletes no after state.
+//
+// Example:
+//
+//	plan := manapool.PlanSync(desired, remote, opts)
+//	if err := plan.Render(os.Stdout, manapool.FormatTable); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	// SKU     ACTION  NAME            PRICE         QUANTITY
+//	// 123456  update  Lightning Bolt  1.00 -> 1.25  4
+//	// 789012  delete  Dark Ritual     3.50 -> -     2 -> -
+//	//
+//	// 0 create, 1 update, 0 quantity, 1 delete, 12 unchanged
+func (p *SyncPlan) Render(w io.Writer, format RenderFormat) error {
+	actions := make([]renderedSyncAction, len(p.Actions))
+	for i, action := range p.Actions {
+		actions[i] = action.render()
+	}
+
+	var err error
+	switch format {
+	case FormatTable:
+		err = p.renderTable(w, actions)
+	case FormatMarkdown:
+		err = p.renderMarkdown(w, actions)
+	case FormatJSON:
+		doc := renderedSyncPlan{
+			Summary: map[SyncActionType]int{
+				SyncCreate: p.Count(SyncCreate), SyncUpdate: p.Count(SyncUpdate),
+				SyncQuantity: p.Count(SyncQuantity), SyncDelete: p.Count(SyncDelete),
+				"unchanged": p.Unchanged,
+			},
+			Actions: actions,
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(doc)
+	default:
+		return NewValidationError("format", fmt.Sprintf("unknown render format %q", format))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to render sync plan: %w", err)
+	}
+	return nil
+}
+
+func (p *SyncPlan) renderTable(w io.Writer, actions []renderedSyncAction) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if len(actions) > 0 {
+		fmt.Fprintln(tw, "SKU\tACTION\tNAME\tPRICE\tQUANTITY")
+		for _, a := range actions {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", a.SKU, a.Type, a.Name, a.priceChange(), a.quantityChange())
+		}
+		fmt.Fprintln(tw)
+	}
+	fmt.Fprintln(tw, p.String())
+	return tw.Flush()
+}
+
+func (p *SyncPlan) renderMarkdown(w io.Writer, actions []renderedSyncAction) error {
+	var b strings.Builder
+	if len(actions) > 0 {
+		b.WriteString("| SKU | Action | Name | Price | Quantity |\n")
+		b.WriteString("| ---: | --- | --- | ---: | ---: |\n")
+		for _, a := range actions {
+			name := strings.ReplaceAll(a.Name, "|", `\|`)
+			fmt.Fprintf(&b, "| %d | %s | %s | %s | %s |\n", a.SKU, a.Type, name, a.priceChange(), a.quantityChange())
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "**Summary:** %s\n", p.String())
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// priceChange renders the price as "before -> after", or a single value if
+// it does not change.
+func (a renderedSyncAction) priceChange() string {
+	return renderChange(a.Before, a.After, func(s *SyncListingState) string { return FormatCents(s.PriceCents) })
+}
+
+// quantityChange renders the quantity like priceChange.
+func (a renderedSyncAction) quantityChange() string {
+	return renderChange(a.Before, a.After, func(s *SyncListingState) string { return strconv.Itoa(s.Quantity) })
+}
+
+func renderChange(before, after *SyncListingState, value func(*SyncListingState) string) string {
+	from, to := "-", "-"
+	if before != nil {
+		from = value(before)
+	}
+	if after != nil {
+		to = value(after)
+	}
+	if from == to {
+		return to
+	}
+	return from + " -> " + to
+}
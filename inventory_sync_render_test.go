@@ -0,0 +1,99 @@
+package manapool
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func renderTestPlan() *SyncPlan {
+	sku := func(n int) *int { return &n }
+	remote := []InventoryItem{
+		{ID: "l1", PriceCents: 100, Quantity: 4, Product: Product{TCGPlayerSKU: sku(1), Single: &Single{Name: "Lightning Bolt"}}},
+		{ID: "l2", PriceCents: 200, Quantity: 1, Product: Product{TCGPlayerSKU: sku(2), Single: &Single{Name: "Counterspell"}}},
+		{ID: "l3", PriceCents: 350, Quantity: 2, Product: Product{TCGPlayerSKU: sku(3), Sealed: &Sealed{Name: "Box | Set"}}},
+		{ID: "l4", PriceCents: 50, Quantity: 9, Product: Product{TCGPlayerSKU: sku(4)}},
+	}
+	desired := []InventoryBulkItemBySKU{
+		{TCGPlayerSKU: 1, PriceCents: 125, Quantity: 4},
+		{TCGPlayerSKU: 2, PriceCents: 200, Quantity: 3},
+		{TCGPlayerSKU: 4, PriceCents: 50, Quantity: 9},
+		{TCGPlayerSKU: 5, PriceCents: 999, Quantity: 1},
+	}
+	return PlanSync(desired, remote, SyncOptions{DeleteMissing: true})
+}
+
+func TestSyncPlan_Render(t *testing.T) {
+	plan := renderTestPlan()
+
+	var table bytes.Buffer
+	if err := plan.Render(&table, FormatTable); err != nil {
+		t.Fatal(err)
+	}
+	want := `SKU  ACTION    NAME            PRICE         QUANTITY
+5    create                    - -> 9.99     - -> 1
+1    update    Lightning Bolt  1.00 -> 1.25  4
+2    quantity  Counterspell    2.00          1 -> 3
+3    delete    Box | Set       3.50 -> -     2 -> -
+
+1 create, 1 update, 1 quantity, 1 delete, 1 unchanged
+`
+	if table.String() != want {
+		t.Errorf("table =\n%s\nwant\n%s", table.String(), want)
+	}
+
+	var markdown bytes.Buffer
+	if err := plan.Render(&markdown, FormatMarkdown); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(markdown.String(), "| SKU | Action | Name | Price | Quantity |\n| ---: |") ||
+		!strings.Contains(markdown.String(), "| 3 | delete | Box \\| Set | 3.50 -> - | 2 -> - |\n") ||
+		!strings.HasSuffix(markdown.String(), "\n\n**Summary:** 1 create, 1 update, 1 quantity, 1 delete, 1 unchanged\n") {
+		t.Errorf("markdown =\n%s", markdown.String())
+	}
+
+	var doc bytes.Buffer
+	if err := plan.Render(&doc, FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Summary map[string]int `json:"summary"`
+		Actions []struct {
+			Type      string            `json:"type"`
+			ListingID string            `json:"listing_id"`
+			Before    *SyncListingState `json:"before"`
+			After     *SyncListingState `json:"after"`
+		} `json:"actions"`
+	}
+	if err := json.Unmarshal(doc.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Summary["unchanged"] != 1 || decoded.Summary["delete"] != 1 || len(decoded.Actions) != 4 {
+		t.Errorf("json = %s", doc.String())
+	}
+	if update := decoded.Actions[1]; update.ListingID != "l1" || *update.Before != (SyncListingState{100, 4}) || *update.After != (SyncListingState{125, 4}) {
+		t.Errorf("update = %+v", update)
+	}
+	if decoded.Actions[0].Before != nil || decoded.Actions[3].After != nil {
+		t.Errorf("create/delete sides = %s", doc.String())
+	}
+}
+
+func TestSyncPlan_Render_Empty(t *testing.T) {
+	plan := &SyncPlan{Unchanged: 3}
+	var out bytes.Buffer
+	if err := plan.Render(&out, FormatTable); err != nil || out.String() != "0 create, 0 update, 0 quantity, 0 delete, 3 unchanged\n" {
+		t.Errorf("table = %q, %v", out.String(), err)
+	}
+	out.Reset()
+	if err := plan.Render(&out, FormatJSON); err != nil || !strings.Contains(out.String(), `"actions": []`) {
+		t.Errorf("json = %s, %v", out.String(), err)
+	}
+
+	var validationErr *ValidationError
+	if err := plan.Render(&out, "yaml"); !errors.As(err, &validationErr) {
+		t.Errorf("unknown format error = %v", err)
+	}
+}
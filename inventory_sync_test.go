@@ -0,0 +1,257 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func syncTestRemote() []InventoryItem {
+	sku := func(n int) *int { return &n }
+	return []InventoryItem{
+		{ID: "a", ProductID: "p-a", PriceCents: 100, Quantity: 1, Product: Product{TCGPlayerSKU: sku(1)}},
+		{ID: "b", ProductID: "p-b", PriceCents: 200, Quantity: 2, Product: Product{TCGPlayerSKU: sku(2)}},
+		{ID: "c", ProductID: "p-c", PriceCents: 300, Quantity: 3, Product: Product{TCGPlayerSKU: sku(3)}},
+		{ID: "d", ProductID: "p-d", PriceCents: 400, Quantity: 4, Product: Product{TCGPlayerSKU: sku(4)}},
+		{ID: "sealed", PriceCents: 500, Quantity: 5},
+	}
+}
+
+func syncTestDesired() []InventoryBulkItemBySKU {
+	return []InventoryBulkItemBySKU{
+		{TCGPlayerSKU: 1, PriceCents: 100, Quantity: 1},
+		{TCGPlayerSKU: 2, PriceCents: 250, Quantity: 2},
+		{TCGPlayerSKU: 3, PriceCents: 300, Quantity: 9},
+		{TCGPlayerSKU: 6, PriceCents: 50, Quantity: 1},
+		{TCGPlayerSKU: 5, PriceCents: 60, Quantity: 1},
+	}
+}
+
+func TestPlanSync(t *testing.T) {
+	plan := PlanSync(syncTestDesired(), syncTestRemote(), SyncOptions{DeleteMissing: true})
+
+	var got []string
+	for _, action := range plan.Actions {
+		got = append(got, fmt.Sprintf("%s:%d", action.Type, action.SKU))
+	}
+	want := "create:5,create:6,update:2,quantity:3,delete:4"
+	if strings.Join(got, ",") != want {
+		t.Errorf("actions = %v, want %s", got, want)
+	}
+	if plan.Unchanged != 1 {
+		t.Errorf("Unchanged = %d, want 1", plan.Unchanged)
+	}
+	if plan.String() != "2 create, 1 update, 1 quantity, 1 delete, 1 unchanged" {
+		t.Errorf("String() = %q", plan.String())
+	}
+	if plan.IsEmpty() {
+		t.Error("IsEmpty() = true")
+	}
+
+	noDelete := PlanSync(syncTestDesired(), syncTestRemote(), SyncOptions{})
+	if noDelete.Count(SyncDelete) != 0 {
+		t.Errorf("deletes without DeleteMissing = %d", noDelete.Count(SyncDelete))
+	}
+}
+
+// syncTestServer is an in-memory inventory backend for sync tests.
+type syncTestServer struct {
+	mu         sync.Mutex
+	bulkCalls  [][]InventoryBulkItemBySKU
+	deleted    []string
+	failBulk   int
+	failDelete map[string]int
+
+	// synced, if set, is the inventory returned once a bulk write succeeds
+	synced []InventoryItem
+}
+
+func (s *syncTestServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/seller/inventory":
+			resp := InventoryResponse{Inventory: syncTestRemote()}
+			if s.synced != nil && len(s.bulkCalls) > 0 {
+				resp.Inventory = append([]InventoryItem(nil), s.synced...)
+			}
+			for i := range resp.Inventory {
+				resp.Inventory[i].EffectiveAsOf = Timestamp{Time: time.Now()}
+			}
+			resp.Pagination = Pagination{Total: len(resp.Inventory), Returned: len(resp.Inventory)}
+			_ = json.NewEncoder(w).Encode(resp)
+		case r.Method == http.MethodPost && r.URL.Path == "/seller/inventory/tcgsku":
+			if s.failBulk > 0 {
+				s.failBulk--
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			var items []InventoryBulkItemBySKU
+			if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+				t.Errorf("decode bulk body: %v", err)
+			}
+			s.bulkCalls = append(s.bulkCalls, items)
+			_, _ = w.Write([]byte(`{"inventory":[]}`))
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/seller/inventory/tcgsku/"):
+			sku := strings.TrimPrefix(r.URL.Path, "/seller/inventory/tcgsku/")
+			if s.failDelete[sku] > 0 {
+				s.failDelete[sku]--
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			s.deleted = append(s.deleted, sku)
+			_, _ = w.Write([]byte(`{"inventory":{}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func TestClient_SyncInventory(t *testing.T) {
+	backend := &syncTestServer{failBulk: 1}
+	server := httptest.NewServer(backend.handler(t))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	report, err := client.SyncInventory(context.Background(), syncTestDesired(), SyncOptions{
+		DeleteMissing: true,
+		ChunkSize:     3,
+		ChunkRetries:  1,
+		RetryBackoff:  time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("SyncInventory() error = %v", err)
+	}
+	if err := report.Err(); err != nil {
+		t.Fatalf("report.Err() = %v", err)
+	}
+
+	if len(backend.bulkCalls) != 2 || len(backend.bulkCalls[0]) != 3 || len(backend.bulkCalls[1]) != 1 {
+		t.Errorf("bulk calls = %v, want chunks of 3 and 1", backend.bulkCalls)
+	}
+	if strings.Join(backend.deleted, ",") != "4" {
+		t.Errorf("deleted = %v, want [4]", backend.deleted)
+	}
+	if report.String() != "2 created, 2 updated, 1 deleted, 1 unchanged, 0 failed" {
+		t.Errorf("report = %q", report.String())
+	}
+}
+
+func TestClient_SyncInventory_DryRun(t *testing.T) {
+	backend := &syncTestServer{}
+	server := httptest.NewServer(backend.handler(t))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	report, err := client.SyncInventory(context.Background(), syncTestDesired(), SyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("SyncInventory() error = %v", err)
+	}
+	if len(backend.bulkCalls) != 0 {
+		t.Errorf("dry run made %d bulk calls", len(backend.bulkCalls))
+	}
+	if report.Plan.Count(SyncCreate) != 2 {
+		t.Errorf("planned creates = %d, want 2", report.Plan.Count(SyncCreate))
+	}
+}
+
+func TestClient_SyncInventory_PartialFailure(t *testing.T) {
+	backend := &syncTestServer{failBulk: 5, failDelete: map[string]int{"4": 5}}
+	server := httptest.NewServer(backend.handler(t))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	report, err := client.SyncInventory(context.Background(), syncTestDesired(), SyncOptions{
+		DeleteMissing: true,
+		ChunkRetries:  1,
+		RetryBackoff:  time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("SyncInventory() error = %v", err)
+	}
+
+	if report.Failed() != 5 {
+		t.Errorf("Failed() = %d, want 5", report.Failed())
+	}
+	// Server errors are retried once; client errors are not.
+	if backend.failBulk != 3 || backend.failDelete["4"] != 4 {
+		t.Errorf("remaining failures = %d bulk, %d delete", backend.failBulk, backend.failDelete["4"])
+	}
+	reportErr := report.Err()
+	if reportErr == nil || !strings.Contains(reportErr.Error(), "5 of 5 sync actions failed") {
+		t.Errorf("report.Err() = %v", reportErr)
+	}
+	var apiErr *APIError
+	if !errors.As(reportErr, &apiErr) {
+		t.Errorf("report.Err() does not wrap APIError")
+	}
+}
+
+func TestClient_SyncInventory_LoadError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	if _, err := client.SyncInventory(context.Background(), nil, SyncOptions{}); err == nil {
+		t.Fatal("SyncInventory() error = nil, want error")
+	}
+}
+
+func TestClient_ApplySyncPlan_Cancelled(t *testing.T) {
+	client := NewClient("token", "email", WithBaseURL("http://127.0.0.1:0/"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	plan := PlanSync(syncTestDesired(), syncTestRemote(), SyncOptions{})
+	if _, err := client.ApplySyncPlan(ctx, plan, SyncOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+
+	deletes := &SyncPlan{Actions: []SyncAction{{Type: SyncDelete, SKU: 1}}}
+	if _, err := client.ApplySyncPlan(ctx, deletes, SyncOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("delete error = %v, want context.Canceled", err)
+	}
+}
+
+func TestRetrySyncStep_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := retrySyncStep(ctx, 3, time.Hour, func() error {
+		calls++
+		cancel()
+		return NewNetworkError("boom", errors.New("reset"))
+	})
+	if !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Errorf("err = %v, calls = %d", err, calls)
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{NewAPIError(http.StatusBadGateway, "x"), true},
+		{NewAPIError(http.StatusTooManyRequests, "x"), true},
+		{NewAPIError(http.StatusBadRequest, "x"), false},
+		{NewNetworkError("x", errors.New("reset")), true},
+		{NewNetworkError("x", context.Canceled), false},
+		{NewValidationError("x", "y"), false},
+	}
+	for _, tt := range tests {
+		if got := isTransientError(tt.err); got != tt.want {
+			t.Errorf("isTransientError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
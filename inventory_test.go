@@ -664,3 +664,75 @@ func generateMockItems(count int) string {
 	}
 	return result
 }
+
+func newSKUTestServer(t *testing.T) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/seller/inventory/tcgsku/42":
+			_, _ = w.Write([]byte(`{"inventory": ` + generateMockItems(1) + `}`))
+		case "/seller/inventory/tcgsku/500":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message": "boom"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "inventory item not found"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+}
+
+func TestClient_FindByTCGSKU(t *testing.T) {
+	client := newSKUTestServer(t)
+	ctx := context.Background()
+
+	item, err := client.FindByTCGSKU(ctx, 42)
+	if err != nil || item == nil || item.ID != "inv0" {
+		t.Errorf("FindByTCGSKU(42) = %+v, %v", item, err)
+	}
+	if item, err := client.FindByTCGSKU(ctx, 7); err != nil || item != nil {
+		t.Errorf("FindByTCGSKU(missing) = %+v, %v, want nil, nil", item, err)
+	}
+	var apiErr *APIError
+	if _, err := client.FindByTCGSKU(ctx, 500); !errors.As(err, &apiErr) || !apiErr.IsServerError() {
+		t.Errorf("FindByTCGSKU(500) error = %v", err)
+	}
+	var validationErr *ValidationError
+	if _, err := client.FindByTCGSKU(ctx, 0); !errors.As(err, &validationErr) {
+		t.Errorf("FindByTCGSKU(0) error = %v", err)
+	}
+}
+
+func TestClient_GetSellerInventory_TCGPlayerSKU(t *testing.T) {
+	client := newSKUTestServer(t)
+	ctx := context.Background()
+
+	resp, err := client.GetSellerInventory(ctx, InventoryOptions{TCGPlayerSKU: 42})
+	if err != nil || len(resp.Inventory) != 1 || resp.Pagination.Total != 1 || resp.Pagination.Returned != 1 {
+		t.Fatalf("GetSellerInventory(sku) = %+v, %v", resp, err)
+	}
+	resp, err = client.GetSellerInventory(ctx, InventoryOptions{TCGPlayerSKU: 42, Offset: 1})
+	if err != nil || len(resp.Inventory) != 0 || resp.Pagination.Total != 1 {
+		t.Errorf("GetSellerInventory(sku, offset 1) = %+v, %v", resp, err)
+	}
+	resp, err = client.GetSellerInventory(ctx, InventoryOptions{TCGPlayerSKU: 7})
+	if err != nil || len(resp.Inventory) != 0 || resp.Pagination.Total != 0 {
+		t.Errorf("GetSellerInventory(missing sku) = %+v, %v", resp, err)
+	}
+
+	var ids []string
+	page, err := client.ListInventoryStream(ctx, InventoryOptions{TCGPlayerSKU: 42}, func(item InventoryItem) error {
+		ids = append(ids, item.ID)
+		return nil
+	})
+	if err != nil || page.Returned != 1 || len(ids) != 1 {
+		t.Errorf("ListInventoryStream(sku) = %+v, %v, %v", page, ids, err)
+	}
+	_, err = client.ListInventoryStream(ctx, InventoryOptions{TCGPlayerSKU: 42}, func(InventoryItem) error {
+		return errors.New("stop")
+	})
+	if err == nil {
+		t.Error("ListInventoryStream(callback error) error = nil")
+	}
+}
@@ -0,0 +1,263 @@
+package manapool
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultPriceBuckets are the upper bounds, in cents, of the unit price
+// buckets used by valuation reports: under $1, $1 to $5, $5 to $20, $20 to
+// $100, and $100 and up.
+var DefaultPriceBuckets = []int{100, 500, 2000, 10000}
+
+// valuationPageSize is the page size used when streaming inventory.
+const valuationPageSize = 500
+
+// ValuationOptions configures a valuation report.
+type ValuationOptions struct {
+	// PriceBuckets are ascending upper bounds, in cents, of the unit price
+	// buckets; an item falls in the first bucket whose bound exceeds its
+	// price. Default: DefaultPriceBuckets.
+	PriceBuckets []int
+
+	// Filter, if set, limits the report to items it returns true for.
+	Filter InventoryFilter
+}
+
+// Validate checks that the price buckets are positive and ascending.
+func (o ValuationOptions) Validate() error {
+	for i, bound := range o.PriceBuckets {
+		if bound <= 0 {
+			return NewValidationError("price_buckets", "bounds must be positive")
+		}
+		if i > 0 && bound <= o.PriceBuckets[i-1] {
+			return NewValidationError("price_buckets", "bounds must be ascending")
+		}
+	}
+	return nil
+}
+
+// ValuationGroup totals the listings sharing one value of a grouping.
+type ValuationGroup struct {
+	// Key is the group's value, such as a set code or a price range like
+	// "1.00-4.99". It is empty for items the grouping does not apply to,
+	// such as the condition of sealed product.
+	Key string
+
+	// Listings is the number of inventory listings
+	Listings int
+
+	// Quantity is the total quantity across the listings
+	Quantity int
+
+	// ValueCents is the total of price times quantity
+	ValueCents int
+}
+
+// ValuationReport summarizes the value of an inventory. Groups are sorted by
+// value, highest first, except ByPriceBucket, which is in price order.
+type ValuationReport struct {
+	Listings   int
+	Quantity   int
+	ValueCents int
+
+	BySet         []ValuationGroup
+	ByCondition   []ValuationGroup
+	ByFinish      []ValuationGroup
+	ByPriceBucket []ValuationGroup
+}
+
+// valuationCSVHeader is the header line written by WriteCSV.
+var valuationCSVHeader = []string{"Group", "Key", "Listings", "Quantity", "Value"}
+
+// WriteCSV writes the report as CSV, one row per group preceded by a "total"
+// row, including the header line. Values are in dollars, such as "12.50".
+func (r *ValuationReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(valuationCSVHeader); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	write := func(group string, g ValuationGroup) error {
+		record := []string{group, g.Key, strconv.Itoa(g.Listings), strconv.Itoa(g.Quantity), FormatCents(g.ValueCents)}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write csv row: %w", err)
+		}
+		return nil
+	}
+
+	if err := write("total", ValuationGroup{Listings: r.Listings, Quantity: r.Quantity, ValueCents: r.ValueCents}); err != nil {
+		return err
+	}
+	for _, grouping := range []struct {
+		name   string
+		groups []ValuationGroup
+	}{
+		{"set", r.BySet},
+		{"condition", r.ByCondition},
+		{"finish", r.ByFinish},
+		{"price", r.ByPriceBucket},
+	} {
+		for _, g := range grouping.groups {
+			if err := write(grouping.name, g); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
+
+// ValueInventory builds a valuation report from items already in memory,
+// such as a snapshot read with ReadSnapshot.
+func ValueInventory(items []InventoryItem, opts ValuationOptions) (*ValuationReport, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	v := newValuation(opts)
+	for _, item := range items {
+		v.add(item)
+	}
+	return v.report(), nil
+}
+
+// InventoryValuation streams the seller's inventory and aggregates it into a
+// valuation report. Only the running totals are kept, so memory use does not
+// grow with inventory size.
+//
+// Example:
+//
+//	report, err := client.InventoryValuation(ctx, manapool.ValuationOptions{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d cards worth %s\n", report.Quantity, manapool.USDCents(report.ValueCents))
+//	for _, g := range report.BySet {
+//	    fmt.Printf("%-6s %6d %10d\n", g.Key, g.Quantity, g.ValueCents)
+//	}
+func (c *Client) InventoryValuation(ctx context.Context, opts ValuationOptions) (*ValuationReport, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	v := newValuation(opts)
+	err := c.streamInventory(ctx, valuationPageSize, "value inventory", func(item InventoryItem) error {
+		v.add(item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.report(), nil
+}
+
+// valuation accumulates a ValuationReport.
+type valuation struct {
+	buckets []int
+	filter  InventoryFilter
+
+	total                      ValuationGroup
+	sets, conditions, finishes map[string]*ValuationGroup
+	priceBuckets               []ValuationGroup
+}
+
+func newValuation(opts ValuationOptions) *valuation {
+	buckets := opts.PriceBuckets
+	if len(buckets) == 0 {
+		buckets = DefaultPriceBuckets
+	}
+
+	v := &valuation{
+		buckets:      buckets,
+		filter:       opts.Filter,
+		sets:         make(map[string]*ValuationGroup),
+		conditions:   make(map[string]*ValuationGroup),
+		finishes:     make(map[string]*ValuationGroup),
+		priceBuckets: make([]ValuationGroup, len(buckets)+1),
+	}
+	low := 0
+	for i, bound := range buckets {
+		v.priceBuckets[i].Key = FormatCents(low) + "-" + FormatCents(bound-1)
+		low = bound
+	}
+	v.priceBuckets[len(buckets)].Key = FormatCents(low) + "+"
+	return v
+}
+
+func (v *valuation) add(item InventoryItem) {
+	if v.filter != nil && !v.filter(item) {
+		return
+	}
+
+	var set, condition, finish string
+	switch {
+	case item.Product.Single != nil:
+		s := item.Product.Single
+		set, condition, finish = s.Set, s.ConditionID, s.FinishID
+	case item.Product.Sealed != nil:
+		set = item.Product.Sealed.Set
+	}
+
+	value := item.PriceCents * item.Quantity
+	count := func(g *ValuationGroup) {
+		g.Listings++
+		g.Quantity += item.Quantity
+		g.ValueCents += value
+	}
+	group := func(groups map[string]*ValuationGroup, key string) {
+		g, ok := groups[key]
+		if !ok {
+			g = &ValuationGroup{Key: key}
+			groups[key] = g
+		}
+		count(g)
+	}
+
+	count(&v.total)
+	group(v.sets, strings.ToUpper(set))
+	group(v.conditions, condition)
+	group(v.finishes, finish)
+	count(&v.priceBuckets[sort.SearchInts(v.buckets, item.PriceCents+1)])
+}
+
+func (v *valuation) report() *ValuationReport {
+	var buckets []ValuationGroup
+	for _, g := range v.priceBuckets {
+		if g.Listings > 0 {
+			buckets = append(buckets, g)
+		}
+	}
+	return &ValuationReport{
+		Listings:      v.total.Listings,
+		Quantity:      v.total.Quantity,
+		ValueCents:    v.total.ValueCents,
+		BySet:         sortedGroups(v.sets),
+		ByCondition:   sortedGroups(v.conditions),
+		ByFinish:      sortedGroups(v.finishes),
+		ByPriceBucket: buckets,
+	}
+}
+
+// sortedGroups returns groups by value, highest first, then by key.
+func sortedGroups(groups map[string]*ValuationGroup) []ValuationGroup {
+	sorted := make([]ValuationGroup, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, *g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].ValueCents != sorted[j].ValueCents {
+			return sorted[i].ValueCents > sorted[j].ValueCents
+		}
+		return sorted[i].Key < sorted[j].Key
+	})
+	return sorted
+}
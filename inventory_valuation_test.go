@@ -0,0 +1,111 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func valuationItems() []InventoryItem {
+	items := newDeleteTestServer().inventory
+	items[0].Product.Single.ConditionID, items[0].Product.Single.FinishID = "NM", "NF"
+	items[0].PriceCents, items[0].Quantity = 50, 4
+	items[1].Product.Single.ConditionID, items[1].Product.Single.FinishID = "LP", "FO"
+	items[1].PriceCents, items[1].Quantity = 2500, 1
+	items[2].Product.Single.ConditionID, items[2].Product.Single.FinishID = "NM", "FO"
+	items[2].PriceCents, items[2].Quantity = 100, 3
+	items[3].PriceCents, items[3].Quantity = 50000, 1
+	return items
+}
+
+func groupsString(groups []ValuationGroup) string {
+	parts := make([]string, len(groups))
+	for i, g := range groups {
+		parts[i] = fmt.Sprintf("%s:%d/%d/%d", g.Key, g.Listings, g.Quantity, g.ValueCents)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestValueInventory(t *testing.T) {
+	report, err := ValueInventory(valuationItems(), ValuationOptions{})
+	if err != nil {
+		t.Fatalf("ValueInventory() error = %v", err)
+	}
+
+	if report.Listings != 5 || report.Quantity != 9 || report.ValueCents != 200+2500+300+50000 {
+		t.Errorf("totals = %d listings, %d quantity, %d cents", report.Listings, report.Quantity, report.ValueCents)
+	}
+	for _, tt := range []struct {
+		name   string
+		groups []ValuationGroup
+		want   string
+	}{
+		{"set", report.BySet, "LEA:3/6/52700 MH3:1/3/300 :1/0/0"},
+		{"condition", report.ByCondition, ":2/1/50000 LP:1/1/2500 NM:2/7/500"},
+		{"finish", report.ByFinish, ":2/1/50000 FO:2/4/2800 NF:1/4/200"},
+		{"price", report.ByPriceBucket, "0.00-0.99:2/4/200 1.00-4.99:1/3/300 20.00-99.99:1/1/2500 100.00+:1/1/50000"},
+	} {
+		if got := groupsString(tt.groups); got != tt.want {
+			t.Errorf("%s groups = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "Group,Key,Listings,Quantity,Value" || lines[1] != "total,,5,9,530.00" ||
+		lines[2] != "set,LEA,3,6,527.00" || lines[len(lines)-1] != "price,100.00+,1,1,500.00" {
+		t.Errorf("csv =\n%s", buf.String())
+	}
+}
+
+func TestValueInventory_Options(t *testing.T) {
+	report, err := ValueInventory(valuationItems(), ValuationOptions{
+		PriceBuckets: []int{1000},
+		Filter:       InventoryInSet("LEA"),
+	})
+	if err != nil {
+		t.Fatalf("ValueInventory() error = %v", err)
+	}
+	if got := groupsString(report.ByPriceBucket); got != "0.00-9.99:1/4/200 10.00+:2/2/52500" {
+		t.Errorf("price groups = %s", got)
+	}
+
+	var validationErr *ValidationError
+	for _, buckets := range [][]int{{0}, {500, 100}, {100, 100}} {
+		if _, err := ValueInventory(nil, ValuationOptions{PriceBuckets: buckets}); !errors.As(err, &validationErr) {
+			t.Errorf("ValueInventory(%v) error = %v", buckets, err)
+		}
+	}
+}
+
+func TestClient_InventoryValuation(t *testing.T) {
+	backend := newDeleteTestServer()
+	backend.inventory = valuationItems()
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	report, err := client.InventoryValuation(context.Background(), ValuationOptions{})
+	if err != nil {
+		t.Fatalf("InventoryValuation() error = %v", err)
+	}
+	if report.Listings != 5 || report.ValueCents != 53000 {
+		t.Errorf("report = %+v", report)
+	}
+
+	if _, err := client.InventoryValuation(context.Background(), ValuationOptions{PriceBuckets: []int{-1}}); err == nil {
+		t.Error("InventoryValuation(invalid buckets) error = nil")
+	}
+
+	server.Close()
+	if _, err := client.InventoryValuation(context.Background(), ValuationOptions{}); err == nil || !strings.Contains(err.Error(), "failed to value inventory") {
+		t.Errorf("InventoryValuation(closed) error = %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultWatchInterval is the poll interval WatchInventory uses when given a
+// non-positive interval.
+const DefaultWatchInterval = time.Minute
+
+// InventoryEventType identifies the kind of inventory change.
+type InventoryEventType string
+
+const (
+	// InventoryAdded is a listing that was not in the previous poll.
+	InventoryAdded InventoryEventType = "added"
+
+	// InventoryRemoved is a listing that is no longer in inventory.
+	InventoryRemoved InventoryEventType = "removed"
+
+	// InventoryPriceChanged is a listing whose price changed.
+	InventoryPriceChanged InventoryEventType = "price_changed"
+
+	// InventoryQuantityChanged is a listing whose quantity changed.
+	InventoryQuantityChanged InventoryEventType = "quantity_changed"
+
+	// InventoryWatchError reports a failed poll. The watcher keeps the last
+	// good state and tries again on the next tick.
+	InventoryWatchError InventoryEventType = "error"
+)
+
+// InventoryEvent is a single change observed by WatchInventory.
+type InventoryEvent struct {
+	Type InventoryEventType
+
+	// Item is the listing's current state, or its last known state for
+	// InventoryRemoved
+	Item InventoryItem
+
+	// Previous is the listing's state before a price or quantity change
+	Previous *InventoryItem
+
+	// Err is the poll error for InventoryWatchError
+	Err error
+}
+
+// DiffInventory returns the events that turn prev into next. Listings are
+// matched by ID. A listing whose price and quantity both changed produces
+// both events. Events are ordered by type (added, removed, price, quantity),
+// then listing ID.
+func DiffInventory(prev, next []InventoryItem) []InventoryEvent {
+	before := make(map[string]InventoryItem, len(prev))
+	for _, item := range prev {
+		before[item.ID] = item
+	}
+	after := make(map[string]bool, len(next))
+
+	var events []InventoryEvent
+	for _, item := range next {
+		after[item.ID] = true
+		old, ok := before[item.ID]
+		if !ok {
+			events = append(events, InventoryEvent{Type: InventoryAdded, Item: item})
+			continue
+		}
+		if old.PriceCents != item.PriceCents {
+			events = append(events, InventoryEvent{Type: InventoryPriceChanged, Item: item, Previous: &old})
+		}
+		if old.Quantity != item.Quantity {
+			events = append(events, InventoryEvent{Type: InventoryQuantityChanged, Item: item, Previous: &old})
+		}
+	}
+	for _, item := range prev {
+		if !after[item.ID] {
+			events = append(events, InventoryEvent{Type: InventoryRemoved, Item: item})
+		}
+	}
+
+	order := map[InventoryEventType]int{
+		InventoryAdded: 0, InventoryRemoved: 1, InventoryPriceChanged: 2, InventoryQuantityChanged: 3,
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a.Type != b.Type {
+			return order[a.Type] < order[b.Type]
+		}
+		return a.Item.ID < b.Item.ID
+	})
+	return events
+}
+
+// WatchInventory polls the seller's full inventory every interval and sends
+// the differences from the previous poll on the returned channel. The first
+// poll establishes the baseline and emits nothing. Failed polls are reported
+// as InventoryWatchError events and retried on the next tick, except that
+// if the account has no seller inventory, the *UnsupportedEndpointError is
+// reported once and the channel is closed.
+//
+// The channel is unbuffered and polling pauses while events are unread, so
+// consume it promptly. It is closed when ctx is cancelled.
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//	for event := range client.WatchInventory(ctx, 5*time.Minute) {
+//	    switch event.Type {
+//	    case manapool.InventoryWatchError:
+//	        log.Printf("poll failed: %v", event.Err)
+//	    case manapool.InventoryPriceChanged:
+//	        log.Printf("%s: %d -> %d", event.Item.ID, event.Previous.PriceCents, event.Item.PriceCents)
+//	    }
+//	}
+func (c *Client) WatchInventory(ctx context.Context, interval time.Duration) <-chan InventoryEvent {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	events := make(chan InventoryEvent)
+	go func() {
+		defer close(events)
+
+		send := func(event InventoryEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		var prev []InventoryItem
+		baseline := false
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			next, err := c.loadInventory(ctx)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				c.logger.Errorf("Inventory watch poll failed: %v", err)
+				if !send(InventoryEvent{Type: InventoryWatchError, Err: err}) || errors.Is(err, ErrUnsupportedEndpoint) {
+					return
+				}
+			case !baseline:
+				prev, baseline = next, true
+			default:
+				for _, event := range DiffInventory(prev, next) {
+					if !send(event) {
+						return
+					}
+				}
+				prev = next
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return events
+}
+
+// loadInventory returns the seller's full inventory. It fails with an
+// *UnsupportedEndpointError if the account has no seller inventory.
+func (c *Client) loadInventory(ctx context.Context) ([]InventoryItem, error) {
+	if err := c.requireCapability(CapabilitySellerInventory); err != nil {
+		return nil, err
+	}
+	var items []InventoryItem
+	err := IterateInventory(ctx, c, func(item *InventoryItem) error {
+		items = append(items, *item)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load remote inventory: %w", c.unsupportedEndpoint(CapabilitySellerInventory, err))
+	}
+	return items, nil
+}
@@ -0,0 +1,149 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDiffInventory(t *testing.T) {
+	item := func(id string, price, qty int) InventoryItem {
+		return InventoryItem{ID: id, PriceCents: price, Quantity: qty}
+	}
+	prev := []InventoryItem{item("a", 100, 1), item("b", 200, 2), item("c", 300, 3), item("d", 400, 4)}
+	next := []InventoryItem{item("e", 500, 5), item("d", 450, 1), item("b", 200, 2), item("c", 300, 9)}
+
+	events := DiffInventory(prev, next)
+	want := []struct {
+		typ InventoryEventType
+		id  string
+	}{
+		{InventoryAdded, "e"},
+		{InventoryRemoved, "a"},
+		{InventoryPriceChanged, "d"},
+		{InventoryQuantityChanged, "c"},
+		{InventoryQuantityChanged, "d"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("DiffInventory() = %+v", events)
+	}
+	for i, w := range want {
+		if events[i].Type != w.typ || events[i].Item.ID != w.id {
+			t.Errorf("event %d = %s %s, want %s %s", i, events[i].Type, events[i].Item.ID, w.typ, w.id)
+		}
+	}
+	if events[2].Previous == nil || events[2].Previous.PriceCents != 400 || events[2].Item.PriceCents != 450 {
+		t.Errorf("price event = %+v", events[2])
+	}
+	if events[1].Previous != nil || events[1].Item.PriceCents != 100 {
+		t.Errorf("removed event = %+v", events[1])
+	}
+
+	if events := DiffInventory(prev, prev); len(events) != 0 {
+		t.Errorf("DiffInventory(same) = %+v", events)
+	}
+}
+
+// watchTestServer serves inventory and changes it on a given poll.
+type watchTestServer struct {
+	*deleteTestServer
+	polls  int
+	change map[int]func(s *deleteTestServer) bool
+}
+
+func (s *watchTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.polls++
+	fn := s.change[s.polls]
+	ok := fn == nil || fn(s.deleteTestServer)
+	s.mu.Unlock()
+
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"message":"down"}`))
+		return
+	}
+	s.deleteTestServer.ServeHTTP(w, r)
+}
+
+func TestClient_WatchInventory(t *testing.T) {
+	backend := &watchTestServer{deleteTestServer: newDeleteTestServer()}
+	backend.inventory = backend.inventory[:3]
+	backend.change = map[int]func(*deleteTestServer) bool{
+		2: func(s *deleteTestServer) bool {
+			added := s.inventory[0]
+			added.ID, added.ProductID = "new", "p-new"
+			s.inventory = []InventoryItem{s.inventory[0], s.inventory[1], added}
+			s.inventory[0].PriceCents = 999
+			return true
+		},
+		3: func(*deleteTestServer) bool { return false },
+		4: func(s *deleteTestServer) bool {
+			s.inventory[1].Quantity = 7
+			return true
+		},
+	}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := client.WatchInventory(ctx, 5*time.Millisecond)
+	var got []InventoryEvent
+	for len(got) < 5 {
+		select {
+		case event := <-events:
+			got = append(got, event)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out after events %+v", got)
+		}
+	}
+
+	want := []struct {
+		typ InventoryEventType
+		id  string
+	}{
+		{InventoryAdded, "new"},
+		{InventoryRemoved, "c"},
+		{InventoryPriceChanged, "a"},
+		{InventoryWatchError, ""},
+		{InventoryQuantityChanged, "b"},
+	}
+	for i, w := range want {
+		if got[i].Type != w.typ || got[i].Item.ID != w.id {
+			t.Errorf("event %d = %s %q, want %s %q", i, got[i].Type, got[i].Item.ID, w.typ, w.id)
+		}
+	}
+	var apiErr *APIError
+	if !errors.As(got[3].Err, &apiErr) || !apiErr.IsServerError() {
+		t.Errorf("error event Err = %v", got[3].Err)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestClient_WatchInventoryClosesOnCancel(t *testing.T) {
+	server := httptest.NewServer(newDeleteTestServer())
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	ctx, cancel := context.WithCancel(context.Background())
+	events := client.WatchInventory(ctx, 0)
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("WatchInventory() sent an event after the baseline poll")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("WatchInventory() did not close after cancel")
+	}
+}
@@ -0,0 +1,411 @@
+package manapool
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JournalStatus is the state of a journaled write.
+type JournalStatus string
+
+const (
+	// JournalPending means the write was recorded but not confirmed: it has
+	// not been sent yet, the process stopped while it was in flight, or it
+	// failed with a network error, a server error, 408 Request Timeout, or
+	// 429 Too Many Requests. ReplayJournal resends it.
+	JournalPending JournalStatus = "pending"
+
+	// JournalCompleted means the API accepted the write.
+	JournalCompleted JournalStatus = "completed"
+
+	// JournalFailed means the API rejected the write with a 4xx response
+	// other than 408 or 429. It is kept for auditing and is not replayed.
+	JournalFailed JournalStatus = "failed"
+)
+
+// JournalEntry is one inventory write recorded by a Journal.
+type JournalEntry struct {
+	// ID identifies the entry across status updates
+	ID string `json:"id"`
+
+	// Method and Endpoint are the HTTP method and API path, such as
+	// "PUT" and "/inventory/tcgsku/123"
+	Method   string `json:"method"`
+	Endpoint string `json:"endpoint"`
+
+	// Query is the encoded query string, if any
+	Query string `json:"query,omitempty"`
+
+	// Body is the JSON request body, if any
+	Body json.RawMessage `json:"body,omitempty"`
+
+	Status JournalStatus `json:"status"`
+
+	// Attempts is the number of times the write was sent, counting the
+	// client's own retries once
+	Attempts int `json:"attempts"`
+
+	// LastError is the most recent failure, if any
+	LastError string `json:"last_error,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Journal durably records inventory writes so that interrupted jobs can be
+// resumed with ReplayJournal and every change can be audited. Implement it
+// over a database to share a journal between processes; MemoryJournal and
+// FileJournal are provided.
+type Journal interface {
+	// Record stores entry, replacing any entry with the same ID. It must
+	// not return until the entry is durable.
+	Record(ctx context.Context, entry JournalEntry) error
+
+	// Pending returns the entries with JournalPending status, oldest first.
+	Pending(ctx context.Context) ([]JournalEntry, error)
+}
+
+// MemoryJournal is an in-memory Journal. It does not survive a restart, so
+// it is mainly useful for tests and for auditing a single run. It is safe
+// for concurrent use.
+type MemoryJournal struct {
+	mu      sync.Mutex
+	entries map[string]JournalEntry
+	order   []string
+}
+
+// NewMemoryJournal creates an empty journal.
+func NewMemoryJournal() *MemoryJournal {
+	return &MemoryJournal{entries: make(map[string]JournalEntry)}
+}
+
+// Record implements Journal.
+func (j *MemoryJournal) Record(_ context.Context, entry JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.put(entry)
+	return nil
+}
+
+// Pending implements Journal.
+func (j *MemoryJournal) Pending(_ context.Context) ([]JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.list(JournalPending), nil
+}
+
+// Entries returns every entry in the order they were first recorded.
+func (j *MemoryJournal) Entries() []JournalEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.list("")
+}
+
+func (j *MemoryJournal) put(entry JournalEntry) {
+	if _, ok := j.entries[entry.ID]; !ok {
+		j.order = append(j.order, entry.ID)
+	}
+	j.entries[entry.ID] = entry
+}
+
+// list returns the entries with status, or all entries if status is empty.
+func (j *MemoryJournal) list(status JournalStatus) []JournalEntry {
+	var entries []JournalEntry
+	for _, id := range j.order {
+		if entry := j.entries[id]; status == "" || entry.Status == status {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// FileJournal is a Journal stored as an append-only JSON Lines file. Each
+// status change appends the full entry, so the file is also an audit log of
+// every write and its outcome. It is safe for concurrent use within one
+// process; do not share a file between processes.
+type FileJournal struct {
+	mu     sync.Mutex
+	file   *os.File
+	memory *MemoryJournal
+}
+
+// OpenFileJournal opens the journal at path, creating it if needed, and
+// loads its entries. A final line cut short by a crash is discarded.
+//
+// Example:
+//
+//	journal, err := manapool.OpenFileJournal("manapool-writes.jsonl")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer journal.Close()
+//	client := manapool.NewClient(token, email, manapool.WithJournal(journal))
+//
+//	// Finish whatever the previous run left in flight
+//	report, err := client.ReplayJournal(ctx)
+func OpenFileJournal(path string) (*FileJournal, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+
+	journal := &FileJournal{file: file, memory: NewMemoryJournal()}
+	reader := bufio.NewReader(file)
+	var size int64
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(data) > 0 {
+				// Drop the torn write so the next entry starts on its own line.
+				if err := file.Truncate(size); err != nil {
+					_ = file.Close()
+					return nil, fmt.Errorf("failed to repair journal: %w", err)
+				}
+			}
+			break
+		}
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("failed to read journal: %w", err)
+		}
+		size += int64(len(data))
+		if len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("failed to read journal line %d: %w", line, err)
+		}
+		journal.memory.put(entry)
+	}
+	return journal, nil
+}
+
+// Record implements Journal. The entry is synced to disk before Record
+// returns.
+func (j *FileJournal) Record(_ context.Context, entry JournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode journal entry %s: %w", entry.ID, err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, err := j.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal entry %s: %w", entry.ID, err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal: %w", err)
+	}
+	j.memory.put(entry)
+	return nil
+}
+
+// Pending implements Journal.
+func (j *FileJournal) Pending(_ context.Context) ([]JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.memory.list(JournalPending), nil
+}
+
+// Entries returns the latest state of every entry in the order they were
+// first recorded.
+func (j *FileJournal) Entries() []JournalEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.memory.list("")
+}
+
+// Close closes the file.
+func (j *FileJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.file.Close()
+}
+
+// journalReplayKey marks a request context as the replay of a journal
+// entry, so the entry is updated instead of a new one being recorded.
+type journalReplayKey struct{}
+
+// journaled reports whether a request is an inventory write the client's
+// journal should record. Other writes, such as purchases, are not safe to
+// replay and are never journaled.
+func (c *Client) journaled(method, endpoint string) bool {
+	if c.journal == nil || method == http.MethodGet || method == http.MethodHead {
+		return false
+	}
+	endpoint = strings.TrimPrefix(endpoint, "/")
+	return strings.HasPrefix(endpoint, "inventory/") || endpoint == "seller/inventory" ||
+		strings.HasPrefix(endpoint, "seller/inventory/")
+}
+
+// doJournaledRequest records a write in the journal, sends it with send,
+// and records its outcome. The write is not sent if it cannot be recorded.
+func (c *Client) doJournaledRequest(ctx context.Context, method, endpoint string, params url.Values, body []byte, send func() (*http.Response, error)) (*http.Response, error) {
+	now := time.Now()
+	entry, replay := ctx.Value(journalReplayKey{}).(JournalEntry)
+	if !replay {
+		id, err := newJournalID()
+		if err != nil {
+			return nil, err
+		}
+		entry = JournalEntry{ID: id, Method: method, Endpoint: endpoint, Query: params.Encode(),
+			Body: bytes.TrimSpace(body), Status: JournalPending, CreatedAt: now, UpdatedAt: now}
+		if err := c.journal.Record(ctx, entry); err != nil {
+			return nil, fmt.Errorf("failed to journal %s %s: %w", method, endpoint, err)
+		}
+	}
+
+	resp, err := send()
+	entry.Attempts++
+	entry.UpdatedAt = time.Now()
+	switch {
+	case err != nil:
+		entry.LastError = err.Error()
+	case resp.StatusCode < http.StatusMultipleChoices:
+		entry.Status, entry.LastError = JournalCompleted, ""
+	case journalRejected(resp.StatusCode):
+		entry.Status, entry.LastError = JournalFailed, resp.Status
+	default:
+		entry.LastError = resp.Status
+	}
+
+	// The write has been sent either way, so a journal failure here is
+	// logged rather than returned; the entry stays pending and a replay
+	// resends it.
+	if recordErr := c.journal.Record(context.WithoutCancel(ctx), entry); recordErr != nil {
+		c.logger.Errorf("Failed to journal outcome of %s %s (%s): %v", method, endpoint, entry.ID, recordErr)
+	}
+	return resp, err
+}
+
+// journalRejected reports whether a write answered with status was rejected
+// for good. Timeouts and rate limiting are transient, so those writes stay
+// pending like server errors.
+func journalRejected(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+	return status >= http.StatusMultipleChoices && status < http.StatusInternalServerError
+}
+
+// newJournalID returns a random entry ID.
+func newJournalID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate journal id: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// JournalFailure is a journal entry that could not be replayed.
+type JournalFailure struct {
+	Entry JournalEntry
+	Err   error
+}
+
+// JournalReplayReport is the outcome of ReplayJournal.
+type JournalReplayReport struct {
+	// Completed are the entries the API accepted
+	Completed []JournalEntry
+
+	// Failures are the entries that failed again; rejected entries are now
+	// JournalFailed, others are still pending
+	Failures []JournalFailure
+}
+
+// Err returns an error summarizing the failures, or nil if every entry
+// completed.
+func (r *JournalReplayReport) Err() error {
+	if len(r.Failures) == 0 {
+		return nil
+	}
+	errs := make([]error, len(r.Failures))
+	for i, failure := range r.Failures {
+		errs[i] = fmt.Errorf("%s %s (%s): %w", failure.Entry.Method, failure.Entry.Endpoint, failure.Entry.ID, failure.Err)
+	}
+	return fmt.Errorf("%d journaled writes failed: %w", len(r.Failures), errors.Join(errs...))
+}
+
+// String renders a one-line summary of the report.
+func (r *JournalReplayReport) String() string {
+	return fmt.Sprintf("%d completed, %d failed", len(r.Completed), len(r.Failures))
+}
+
+// ReplayJournal resends every pending write in the client's journal, oldest
+// first, and records the outcome of each. Use it at startup to finish the
+// writes an interrupted run left unconfirmed.
+//
+// Writes are resent as recorded, so a replay can overwrite changes made
+// since; replay promptly, before starting new work. The returned error is
+// non-nil if the client has no journal, the journal cannot be read, or ctx
+// is cancelled; check JournalReplayReport.Err for failed writes.
+//
+// Example:
+//
+//	report, err := client.ReplayJournal(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	log.Printf("journal replay: %s", report)
+func (c *Client) ReplayJournal(ctx context.Context) (*JournalReplayReport, error) {
+	if c.journal == nil {
+		return nil, NewValidationError("journal", "client has no journal; use WithJournal")
+	}
+	pending, err := c.journal.Pending(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+	})
+
+	report := &JournalReplayReport{}
+	for _, entry := range pending {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if err := c.replayJournalEntry(ctx, entry); err != nil {
+			report.Failures = append(report.Failures, JournalFailure{Entry: entry, Err: err})
+			continue
+		}
+		report.Completed = append(report.Completed, entry)
+	}
+	return report, nil
+}
+
+// replayJournalEntry resends one entry through the normal request path.
+func (c *Client) replayJournalEntry(ctx context.Context, entry JournalEntry) error {
+	params, err := url.ParseQuery(entry.Query)
+	if err != nil {
+		return NewValidationError("query", fmt.Sprintf("invalid journaled query string: %v", err))
+	}
+	ctx = context.WithValue(ctx, journalReplayKey{}, entry)
+
+	var resp *http.Response
+	if len(entry.Body) > 0 {
+		resp, err = c.doJSONRequest(ctx, entry.Method, entry.Endpoint, params, entry.Body)
+	} else {
+		resp, err = c.doRequest(ctx, entry.Method, entry.Endpoint, params)
+	}
+	if err != nil {
+		return err
+	}
+	return c.decodeResponse(resp, nil)
+}
@@ -0,0 +1,238 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// journalTestServer answers listing updates by SKU with status, and every
+// other request with 200.
+type journalTestServer struct {
+	mu       sync.Mutex
+	status   int
+	requests []string
+}
+
+func (s *journalTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(body)))
+	if strings.HasPrefix(r.URL.Path, "/inventory/tcgsku/") && s.status != http.StatusOK {
+		w.WriteHeader(s.status)
+		_, _ = io.WriteString(w, `{"error":"nope"}`)
+		return
+	}
+	_, _ = io.WriteString(w, `{}`)
+}
+
+func newJournalBackend(t *testing.T, journal Journal) (*journalTestServer, *Client) {
+	t.Helper()
+	backend := &journalTestServer{status: http.StatusOK}
+	server := httptest.NewServer(backend)
+	t.Cleanup(server.Close)
+	return backend, NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0), WithJournal(journal))
+}
+
+func TestClient_Journal(t *testing.T) {
+	ctx := context.Background()
+	journal := NewMemoryJournal()
+	backend, client := newJournalBackend(t, journal)
+
+	if _, err := client.UpdateInventoryBySKU(ctx, 1, InventoryUpdateRequest{PriceCents: 100, Quantity: 2}); err != nil {
+		t.Fatal(err)
+	}
+	backend.status = http.StatusUnprocessableEntity
+	if _, err := client.UpdateInventoryBySKU(ctx, 2, InventoryUpdateRequest{PriceCents: 100, Quantity: 2}); err == nil {
+		t.Fatal("expected rejected update")
+	}
+	backend.status = http.StatusServiceUnavailable
+	if _, err := client.UpdateInventoryBySKU(ctx, 3, InventoryUpdateRequest{PriceCents: 300, Quantity: 1}); err == nil {
+		t.Fatal("expected server error")
+	}
+	if _, err := client.DeleteInventoryBySKU(ctx, 4); err == nil {
+		t.Fatal("expected server error")
+	}
+
+	// Reads and non-inventory writes are not journaled.
+	backend.status = http.StatusOK
+	if _, err := client.GetInventoryBySKU(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.PurchasePendingOrder(ctx, "p1", PurchasePendingOrderRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := journal.Entries()
+	if len(entries) != 4 {
+		t.Fatalf("entries = %+v", entries)
+	}
+	want := []JournalStatus{JournalCompleted, JournalFailed, JournalPending, JournalPending}
+	for i, entry := range entries {
+		if entry.Status != want[i] || entry.Attempts != 1 {
+			t.Errorf("entry %d = %s after %d attempts, want %s", i, entry.Status, entry.Attempts, want[i])
+		}
+	}
+	if entries[0].Method != "PUT" || entries[0].Endpoint != "/inventory/tcgsku/1" || string(entries[0].Body) != `{"price_cents":100,"quantity":2}` {
+		t.Errorf("entry = %+v", entries[0])
+	}
+	if entries[2].LastError != "503 Service Unavailable" || entries[3].Body != nil {
+		t.Errorf("pending entries = %+v", entries[2:])
+	}
+
+	backend.status = http.StatusOK
+	backend.requests = nil
+	report, err := client.ReplayJournal(ctx)
+	if err != nil {
+		t.Fatalf("ReplayJournal() error = %v", err)
+	}
+	if report.String() != "2 completed, 0 failed" || report.Err() != nil {
+		t.Errorf("report = %s", report)
+	}
+	if got := strings.Join(backend.requests, ","); got != `PUT /inventory/tcgsku/3 {"price_cents":300,"quantity":1},DELETE /inventory/tcgsku/4 ` {
+		t.Errorf("replayed = %s", got)
+	}
+	if pending, _ := journal.Pending(ctx); len(pending) != 0 || len(journal.Entries()) != 4 {
+		t.Errorf("after replay: pending %+v, %d entries", pending, len(journal.Entries()))
+	}
+	if entry := journal.Entries()[2]; entry.Status != JournalCompleted || entry.Attempts != 2 || entry.LastError != "" {
+		t.Errorf("replayed entry = %+v", entry)
+	}
+}
+
+// failingJournal cannot record anything.
+type failingJournal struct{}
+
+func (failingJournal) Record(context.Context, JournalEntry) error {
+	return errors.New("disk full")
+}
+
+func (failingJournal) Pending(context.Context) ([]JournalEntry, error) {
+	return nil, errors.New("disk full")
+}
+
+func TestClient_Journal_Failures(t *testing.T) {
+	ctx := context.Background()
+	backend, client := newJournalBackend(t, failingJournal{})
+	_, err := client.UpdateInventoryBySKU(ctx, 1, InventoryUpdateRequest{PriceCents: 100, Quantity: 2})
+	if err == nil || !strings.Contains(err.Error(), "failed to journal PUT /inventory/tcgsku/1: disk full") || len(backend.requests) != 0 {
+		t.Errorf("unjournaled write = %v, sent %v", err, backend.requests)
+	}
+	if _, err := client.ReplayJournal(ctx); err == nil {
+		t.Error("expected journal read error")
+	}
+
+	var validationErr *ValidationError
+	if _, err := NewClient("token", "email").ReplayJournal(ctx); !errors.As(err, &validationErr) {
+		t.Errorf("no journal error = %v", err)
+	}
+
+	journal := NewMemoryJournal()
+	backend, client = newJournalBackend(t, journal)
+	backend.status = http.StatusServiceUnavailable
+	_, _ = client.UpdateInventoryBySKU(ctx, 1, InventoryUpdateRequest{PriceCents: 100, Quantity: 2})
+	backend.status = http.StatusConflict
+	report, err := client.ReplayJournal(ctx)
+	if err != nil || len(report.Failures) != 1 || !strings.Contains(report.Err().Error(), "1 journaled writes failed: PUT /inventory/tcgsku/1") {
+		t.Errorf("replay = %v, %v", report, err)
+	}
+	if entry := journal.Entries()[0]; entry.Status != JournalFailed {
+		t.Errorf("rejected replay status = %s", entry.Status)
+	}
+}
+
+func TestClient_Journal_RateLimited(t *testing.T) {
+	ctx := context.Background()
+	journal := NewMemoryJournal()
+	backend, client := newJournalBackend(t, journal)
+
+	for sku, status := range map[int]int{1: http.StatusTooManyRequests, 2: http.StatusRequestTimeout} {
+		backend.status = status
+		if _, err := client.UpdateInventoryBySKU(ctx, sku, InventoryUpdateRequest{PriceCents: 100, Quantity: 1}); err == nil {
+			t.Fatalf("expected %d error", status)
+		}
+	}
+	pending, _ := journal.Pending(ctx)
+	if len(pending) != 2 {
+		t.Fatalf("pending = %+v, want both writes", journal.Entries())
+	}
+
+	backend.status = http.StatusOK
+	report, err := client.ReplayJournal(ctx)
+	if err != nil || report.String() != "2 completed, 0 failed" {
+		t.Errorf("replay = %v, %v", report, err)
+	}
+	for _, entry := range journal.Entries() {
+		if entry.Status != JournalCompleted || entry.Attempts != 2 {
+			t.Errorf("replayed entry = %+v", entry)
+		}
+	}
+}
+
+func TestFileJournal(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+
+	journal, err := OpenFileJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, entry := range []JournalEntry{
+		{ID: "a", Method: "PUT", Endpoint: "/inventory/tcgsku/1", Status: JournalPending, CreatedAt: created},
+		{ID: "b", Method: "DELETE", Endpoint: "/inventory/tcgsku/2", Status: JournalPending, CreatedAt: created.Add(time.Second)},
+		{ID: "a", Method: "PUT", Endpoint: "/inventory/tcgsku/1", Status: JournalCompleted, CreatedAt: created},
+	} {
+		if err := journal.Record(ctx, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := journal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a crash in the middle of writing an entry.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"id":"c","method":"PU`)
+	_ = f.Close()
+
+	journal, err = OpenFileJournal(path)
+	if err != nil {
+		t.Fatalf("reopen error = %v", err)
+	}
+	pending, err := journal.Pending(ctx)
+	if err != nil || len(pending) != 1 || pending[0].ID != "b" || !pending[0].CreatedAt.Equal(created.Add(time.Second)) {
+		t.Errorf("Pending() = %+v, %v", pending, err)
+	}
+	if err := journal.Record(ctx, JournalEntry{ID: "b", Status: JournalCompleted}); err != nil {
+		t.Fatal(err)
+	}
+	_ = journal.Close()
+
+	journal, err = OpenFileJournal(path)
+	if err != nil {
+		t.Fatalf("reopen after torn write error = %v", err)
+	}
+	defer journal.Close()
+	if entries := journal.Entries(); len(entries) != 2 || entries[1].Status != JournalCompleted {
+		t.Errorf("Entries() = %+v", entries)
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.jsonl")
+	_ = os.WriteFile(bad, []byte("{}\nnot json\n{}\n"), 0o600)
+	if _, err := OpenFileJournal(bad); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("corrupt journal error = %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package manapool
+
+import "encoding/json"
+
+// JSONMarshalFunc encodes v as JSON. json.Marshal has this signature, as do
+// the Marshal functions of most third-party JSON libraries.
+type JSONMarshalFunc func(v any) ([]byte, error)
+
+// JSONUnmarshalFunc decodes JSON data into v. json.Unmarshal has this
+// signature, as do the Unmarshal functions of most third-party JSON
+// libraries.
+type JSONUnmarshalFunc func(data []byte, v any) error
+
+// marshalJSON encodes v with the client's codec.
+func (c *Client) marshalJSON(v any) ([]byte, error) {
+	if c.jsonMarshal != nil {
+		return c.jsonMarshal(v)
+	}
+	return json.Marshal(v)
+}
+
+// unmarshalJSON decodes data into v with the client's codec.
+func (c *Client) unmarshalJSON(data []byte, v any) error {
+	if c.jsonUnmarshal != nil {
+		return c.jsonUnmarshal(data, v)
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,54 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithJSONCodec(t *testing.T) {
+	var gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		_, _ = w.Write([]byte(`{"username":"seller"}`))
+	}))
+	defer server.Close()
+
+	var marshals, unmarshals int
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithJSONCodec(
+		func(v any) ([]byte, error) {
+			marshals++
+			return json.Marshal(v)
+		},
+		func(data []byte, v any) error {
+			unmarshals++
+			return json.Unmarshal(data, v)
+		},
+	))
+
+	account, err := client.UpdateSellerAccount(context.Background(), SellerAccountUpdate{})
+	if err != nil || account.Username != "seller" {
+		t.Fatalf("UpdateSellerAccount() = %+v, %v", account, err)
+	}
+	if marshals != 1 || unmarshals != 1 || !strings.HasSuffix(gotBody, "}\n") {
+		t.Errorf("marshals = %d, unmarshals = %d, body %q", marshals, unmarshals, gotBody)
+	}
+
+	failing := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithJSONCodec(
+		func(any) ([]byte, error) { return nil, errors.New("no encoder") },
+		nil,
+	))
+	var netErr *NetworkError
+	if _, err := failing.UpdateSellerAccount(context.Background(), SellerAccountUpdate{}); !errors.As(err, &netErr) {
+		t.Errorf("UpdateSellerAccount(failing codec) error = %v", err)
+	}
+	if account, err := failing.GetSellerAccount(context.Background()); err != nil || account.Username != "seller" {
+		t.Errorf("GetSellerAccount(default unmarshal) = %+v, %v", account, err)
+	}
+}
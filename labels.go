@@ -0,0 +1,187 @@
+package manapool
+
+import (
+	"strings"
+	"sync"
+)
+
+// Labels holds the display text for one locale. Any entry may be missing;
+// lookups fall back to the locale's language and then to English.
+type Labels struct {
+	// Conditions names condition IDs, such as "NM": "Near Mint"
+	Conditions map[string]string
+
+	// Finishes names finish IDs, such as "FO": "Foil". The "FO" entry is
+	// also the suffix for etched foils in LabelStyleStandard.
+	Finishes map[string]string
+
+	// Statuses names order statuses, such as OrderStatusShipped: "Shipped"
+	Statuses map[OrderStatus]string
+
+	// Unknown is used for unknown condition and finish IDs
+	Unknown string
+
+	// ConditionFinish joins a condition and a foil finish, with
+	// "{condition}" and "{finish}" placeholders: "{condition} {finish}" in
+	// English
+	ConditionFinish string
+}
+
+// englishLabels is the bundled English text. It matches ConditionName and
+// FinishName.
+var englishLabels = Labels{
+	Conditions: map[string]string{
+		"NM":  "Near Mint",
+		"LP":  "Lightly Played",
+		"MP":  "Moderately Played",
+		"HP":  "Heavily Played",
+		"DMG": "Damaged",
+	},
+	Finishes: map[string]string{
+		"NF": "Non-Foil",
+		"FO": "Foil",
+		"EF": "Etched Foil",
+	},
+	Statuses: map[OrderStatus]string{
+		OrderStatusPending:    "Pending",
+		OrderStatusProcessing: "Processing",
+		OrderStatusShipped:    "Shipped",
+		OrderStatusDelivered:  "Delivered",
+		OrderStatusRefunded:   "Refunded",
+		OrderStatusReplaced:   "Replaced",
+		OrderStatusError:      "Error",
+	},
+	Unknown:         "Unknown",
+	ConditionFinish: "{condition} {finish}",
+}
+
+var (
+	labelsMu sync.RWMutex
+
+	// registeredLabels holds labels by canonical locale, such as "de" or
+	// "pt-BR"
+	registeredLabels = map[string]Labels{}
+)
+
+// RegisterLabels adds display text for a locale, such as "de" or "pt-BR".
+// Entries are merged into any already registered for the locale, so a
+// storefront can register a full translation and later override a single
+// label. Register a bare language to cover all its regions; a regional
+// locale falls back to its language, then to the bundled English. It is
+// safe for concurrent use, but is best called during program
+// initialization, since it affects every caller.
+//
+// Example:
+//
+//	func init() {
+//	    manapool.RegisterLabels("de", manapool.Labels{
+//	        Conditions: map[string]string{"NM": "Near Mint", "LP": "Leicht bespielt"},
+//	        Finishes:   map[string]string{"NF": "Nicht-Foil", "FO": "Foil", "EF": "Etched Foil"},
+//	        Statuses:   map[manapool.OrderStatus]string{manapool.OrderStatusShipped: "Versandt"},
+//	        Unknown:    "Unbekannt",
+//	    })
+//	}
+func RegisterLabels(locale Locale, labels Labels) {
+	key := canonicalLocale(locale)
+
+	labelsMu.Lock()
+	defer labelsMu.Unlock()
+	current := registeredLabels[key]
+	current.Conditions = mergeLabels(current.Conditions, labels.Conditions)
+	current.Finishes = mergeLabels(current.Finishes, labels.Finishes)
+	current.Statuses = mergeLabels(current.Statuses, labels.Statuses)
+	if labels.Unknown != "" {
+		current.Unknown = labels.Unknown
+	}
+	if labels.ConditionFinish != "" {
+		current.ConditionFinish = labels.ConditionFinish
+	}
+	registeredLabels[key] = current
+}
+
+func mergeLabels[K comparable](dst, src map[K]string) map[K]string {
+	if len(src) == 0 {
+		return dst
+	}
+	merged := make(map[K]string, len(dst)+len(src))
+	for k, v := range dst {
+		merged[k] = v
+	}
+	for k, v := range src {
+		if v != "" {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// canonicalLocale writes a locale as "de" or "de-AT".
+func canonicalLocale(locale Locale) string {
+	tag := strings.ReplaceAll(strings.TrimSpace(string(locale)), "_", "-")
+	language, region, ok := strings.Cut(tag, "-")
+	language = strings.ToLower(language)
+	if !ok || region == "" {
+		return language
+	}
+	return language + "-" + strings.ToUpper(region)
+}
+
+// lookupLabel returns the first non-empty text get finds for locale, its
+// language, or English.
+func lookupLabel(locale Locale, get func(Labels) string) string {
+	key := canonicalLocale(locale)
+	language, _, _ := strings.Cut(key, "-")
+
+	labelsMu.RLock()
+	defer labelsMu.RUnlock()
+	for _, k := range []string{key, language} {
+		if labels, ok := registeredLabels[k]; ok {
+			if text := get(labels); text != "" {
+				return text
+			}
+		}
+	}
+	return get(englishLabels)
+}
+
+// LocalizedConditionName returns ConditionFinishLabel in locale's language,
+// such as "Leicht bespielt Foil" for "de" with German labels registered.
+// Unregistered locales and missing labels use English.
+func (s Single) LocalizedConditionName(locale Locale, style LabelStyle) string {
+	condition := lookupLabel(locale, func(l Labels) string { return l.Conditions[s.ConditionID] })
+	if condition == "" {
+		condition = lookupLabel(locale, func(l Labels) string { return l.Unknown })
+	}
+
+	finishID := s.FinishID
+	switch {
+	case finishID == "EF" && style == LabelStyleStandard:
+		finishID = "FO"
+	case finishID != "FO" && finishID != "EF":
+		return condition
+	}
+	finish := lookupLabel(locale, func(l Labels) string { return l.Finishes[finishID] })
+	format := lookupLabel(locale, func(l Labels) string { return l.ConditionFinish })
+	return strings.NewReplacer("{condition}", condition, "{finish}", finish).Replace(format)
+}
+
+// LocalizedFinishName returns FinishName in locale's language.
+func (s Single) LocalizedFinishName(locale Locale) string {
+	if name := lookupLabel(locale, func(l Labels) string { return l.Finishes[s.FinishID] }); name != "" {
+		return name
+	}
+	return lookupLabel(locale, func(l Labels) string { return l.Unknown })
+}
+
+// LocalizedName returns the status's display name in locale's language,
+// such as "Shipped", or the raw status if it has no label.
+//
+// Example:
+//
+//	fmt.Println(order.FulfillmentStatus().LocalizedName("fr-FR"))
+func (s OrderStatus) LocalizedName(locale Locale) string {
+	if name := lookupLabel(locale, func(l Labels) string { return l.Statuses[s] }); name != "" {
+		return name
+	}
+	return string(s)
+}
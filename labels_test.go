@@ -0,0 +1,84 @@
+package manapool
+
+import "testing"
+
+func resetLabels(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		labelsMu.Lock()
+		registeredLabels = map[string]Labels{}
+		labelsMu.Unlock()
+	})
+}
+
+func TestLabels_EnglishMatchesDefaults(t *testing.T) {
+	for _, conditionID := range []string{"NM", "LP", "MP", "HP", "DMG", "XX"} {
+		for _, finishID := range []string{"NF", "FO", "EF", ""} {
+			s := Single{ConditionID: conditionID, FinishID: finishID}
+			for _, style := range []LabelStyle{LabelStyleStandard, LabelStyleEtched} {
+				if got, want := s.LocalizedConditionName("en-US", style), s.ConditionFinishLabel(style); got != want {
+					t.Errorf("LocalizedConditionName(%s/%s, %d) = %q, want %q", conditionID, finishID, style, got, want)
+				}
+			}
+			if got, want := s.LocalizedFinishName(""), s.FinishName(); got != want {
+				t.Errorf("LocalizedFinishName(%s) = %q, want %q", finishID, got, want)
+			}
+		}
+	}
+	for status := range orderTransitions {
+		if got := status.LocalizedName("en"); got == "" || got == string(status) {
+			t.Errorf("LocalizedName(%s) = %q, want an English label", status, got)
+		}
+	}
+}
+
+func TestRegisterLabels(t *testing.T) {
+	resetLabels(t)
+	RegisterLabels("de", Labels{
+		Conditions:      map[string]string{"NM": "Near Mint", "LP": "Leicht bespielt"},
+		Finishes:        map[string]string{"NF": "Nicht-Foil", "FO": "Foil"},
+		Statuses:        map[OrderStatus]string{OrderStatusShipped: "Versandt"},
+		Unknown:         "Unbekannt",
+		ConditionFinish: "{condition} ({finish})",
+	})
+	RegisterLabels("de_at", Labels{Statuses: map[OrderStatus]string{OrderStatusShipped: "Verschickt"}})
+
+	tests := []struct {
+		name, got, want string
+	}{
+		{"condition", Single{ConditionID: "LP", FinishID: "NF"}.LocalizedConditionName("de-DE", LabelStyleStandard), "Leicht bespielt"},
+		{"foil format", Single{ConditionID: "LP", FinishID: "FO"}.LocalizedConditionName("de", LabelStyleStandard), "Leicht bespielt (Foil)"},
+		{"etched standard", Single{ConditionID: "NM", FinishID: "EF"}.LocalizedConditionName("de", LabelStyleStandard), "Near Mint (Foil)"},
+		{"etched falls back to English", Single{ConditionID: "NM", FinishID: "EF"}.LocalizedConditionName("de", LabelStyleEtched), "Near Mint (Etched Foil)"},
+		{"missing condition falls back to English", Single{ConditionID: "HP", FinishID: "NF"}.LocalizedConditionName("de", LabelStyleStandard), "Heavily Played"},
+		{"unknown condition", Single{ConditionID: "XX", FinishID: "NF"}.LocalizedConditionName("de", LabelStyleStandard), "Unbekannt"},
+		{"finish", Single{FinishID: "NF"}.LocalizedFinishName("DE"), "Nicht-Foil"},
+		{"unknown finish", Single{FinishID: "ZZ"}.LocalizedFinishName("de"), "Unbekannt"},
+		{"regional status", OrderStatusShipped.LocalizedName("de-AT"), "Verschickt"},
+		{"language status", OrderStatusShipped.LocalizedName("de-CH"), "Versandt"},
+		{"status falls back to English", OrderStatusDelivered.LocalizedName("de"), "Delivered"},
+		{"unknown status", OrderStatus("lost").LocalizedName("de"), "lost"},
+		{"other locale", Single{ConditionID: "LP", FinishID: "NF"}.LocalizedConditionName("fr", LabelStyleStandard), "Lightly Played"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestRegisterLabels_Merges(t *testing.T) {
+	resetLabels(t)
+	RegisterLabels("fr", Labels{Conditions: map[string]string{"NM": "Neuf", "LP": "Très bon"}})
+	RegisterLabels("FR", Labels{Conditions: map[string]string{"LP": "Excellent", "MP": ""}})
+
+	if got := (Single{ConditionID: "NM"}).LocalizedConditionName("fr", LabelStyleStandard); got != "Neuf" {
+		t.Errorf("NM = %q, want earlier registration kept", got)
+	}
+	if got := (Single{ConditionID: "LP"}).LocalizedConditionName("fr", LabelStyleStandard); got != "Excellent" {
+		t.Errorf("LP = %q, want override", got)
+	}
+	if got := (Single{ConditionID: "MP"}).LocalizedConditionName("fr", LabelStyleStandard); got != "Moderately Played" {
+		t.Errorf("MP = %q, want empty entry ignored", got)
+	}
+}
@@ -0,0 +1,142 @@
+package manapool
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Language describes a ManaPool language ID.
+type Language struct {
+	// ID is ManaPool's language ID, such as "JA"
+	ID string
+
+	// ISO is the ISO 639-1 code, such as "ja", or "" for Phyrexian, which
+	// has none. Simplified and Traditional Chinese are both "zh".
+	ISO string
+
+	// Name is the English display name, such as "Japanese"
+	Name string
+}
+
+// languages lists every language ID the API accepts, in the API's order.
+var languages = []Language{
+	{ID: "EN", ISO: "en", Name: "English"},
+	{ID: "JA", ISO: "ja", Name: "Japanese"},
+	{ID: "FR", ISO: "fr", Name: "French"},
+	{ID: "IT", ISO: "it", Name: "Italian"},
+	{ID: "DE", ISO: "de", Name: "German"},
+	{ID: "ES", ISO: "es", Name: "Spanish"},
+	{ID: "AR", ISO: "ar", Name: "Arabic"},
+	{ID: "CS", ISO: "zh", Name: "Chinese Simplified"},
+	{ID: "CT", ISO: "zh", Name: "Chinese Traditional"},
+	{ID: "EL", ISO: "el", Name: "Greek"},
+	{ID: "HE", ISO: "he", Name: "Hebrew"},
+	{ID: "KO", ISO: "ko", Name: "Korean"},
+	{ID: "LA", ISO: "la", Name: "Latin"},
+	{ID: "PH", ISO: "", Name: "Phyrexian"},
+	{ID: "PT", ISO: "pt", Name: "Portuguese"},
+	{ID: "RU", ISO: "ru", Name: "Russian"},
+	{ID: "SA", ISO: "sa", Name: "Sanskrit"},
+}
+
+var (
+	languagesByID  = make(map[string]Language, len(languages))
+	languagesByISO = make(map[string]Language, len(languages))
+
+	// chineseScripts maps the script or region subtag of a Chinese language
+	// tag to a language ID.
+	chineseScripts = map[string]string{
+		"hans": "CS", "cn": "CS", "sg": "CS",
+		"hant": "CT", "tw": "CT", "hk": "CT", "mo": "CT",
+	}
+)
+
+func init() {
+	for _, lang := range languages {
+		languagesByID[lang.ID] = lang
+		if lang.ISO != "" && lang.ISO != "zh" {
+			languagesByISO[lang.ISO] = lang
+		}
+	}
+}
+
+// languageIDSet returns the set of known language IDs.
+func languageIDSet() map[string]bool {
+	set := make(map[string]bool, len(languages))
+	for _, lang := range languages {
+		set[lang.ID] = true
+	}
+	return set
+}
+
+// Languages returns every language the API accepts.
+func Languages() []Language {
+	return append([]Language(nil), languages...)
+}
+
+// LookupLanguage returns the language with a ManaPool language ID, such as
+// "JA". IDs are matched exactly, as the API requires.
+func LookupLanguage(id string) (Language, bool) {
+	lang, ok := languagesByID[id]
+	return lang, ok
+}
+
+// LanguageFromISO returns the language for an ISO 639-1 code or a language
+// tag built on one, ignoring case and region: "ja", "pt-BR", and "en_US" all
+// resolve. Chinese needs a script or region to choose between Simplified
+// and Traditional, such as "zh-Hans" or "zh-TW"; plain "zh" is ambiguous
+// and not found.
+//
+// Example:
+//
+//	lang, ok := manapool.LanguageFromISO(r.Header.Get("Content-Language"))
+//	if ok {
+//	    opts.LanguageID = lang.ID
+//	}
+func LanguageFromISO(tag string) (Language, bool) {
+	parts := strings.FieldsFunc(strings.ToLower(strings.TrimSpace(tag)), func(r rune) bool {
+		return r == '-' || r == '_'
+	})
+	if len(parts) == 0 {
+		return Language{}, false
+	}
+	if parts[0] == "zh" {
+		for _, sub := range parts[1:] {
+			if id, ok := chineseScripts[sub]; ok {
+				return languagesByID[id], true
+			}
+		}
+		return Language{}, false
+	}
+	lang, ok := languagesByISO[parts[0]]
+	return lang, ok
+}
+
+// LanguageName returns the display name of the single's language, such as
+// "Japanese", or the raw ID if it is unknown.
+func (s Single) LanguageName() string {
+	if lang, ok := languagesByID[s.LanguageID]; ok {
+		return lang.Name
+	}
+	return s.LanguageID
+}
+
+// ValidateLanguageID returns a *ValidationError if id is not a language ID
+// the API accepts. Inventory writes that take a language ID call it before
+// sending the request.
+func ValidateLanguageID(id string) error {
+	return validateLanguageID("language_id", id)
+}
+
+func validateLanguageID(field, id string) error {
+	if _, ok := languagesByID[id]; ok {
+		return nil
+	}
+	msg := fmt.Sprintf("unknown language ID %q", id)
+	if lang, ok := languagesByID[strings.ToUpper(id)]; ok {
+		msg += fmt.Sprintf(" (did you mean %q?)", lang.ID)
+	} else if lang, ok := LanguageFromISO(id); ok {
+		msg += fmt.Sprintf(" (%s is %q)", lang.Name, lang.ID)
+	}
+	return NewValidationError(field, msg)
+}
@@ -0,0 +1,154 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLanguages_CoverKnownIDs(t *testing.T) {
+	langs := Languages()
+	if len(langs) != len(knownLanguageIDs) {
+		t.Fatalf("Languages() has %d entries, want %d", len(langs), len(knownLanguageIDs))
+	}
+	for _, lang := range langs {
+		if lang.Name == "" {
+			t.Errorf("language %s has no name", lang.ID)
+		}
+		got, ok := LookupLanguage(lang.ID)
+		if !ok || got != lang {
+			t.Errorf("LookupLanguage(%q) = %+v, %v", lang.ID, got, ok)
+		}
+	}
+
+	langs[0].Name = "changed"
+	if lang, _ := LookupLanguage(langs[0].ID); lang.Name == "changed" {
+		t.Error("Languages() returned the shared table")
+	}
+}
+
+func TestLookupLanguage(t *testing.T) {
+	lang, ok := LookupLanguage("JA")
+	if !ok || lang.ISO != "ja" || lang.Name != "Japanese" {
+		t.Errorf("LookupLanguage(JA) = %+v, %v", lang, ok)
+	}
+	if _, ok := LookupLanguage("ja"); ok {
+		t.Error("LookupLanguage(ja) found a lower-case ID")
+	}
+	if lang, _ := LookupLanguage("PH"); lang.ISO != "" {
+		t.Errorf("Phyrexian ISO = %q, want none", lang.ISO)
+	}
+}
+
+func TestLanguageFromISO(t *testing.T) {
+	tests := []struct {
+		tag    string
+		want   string
+		wantOK bool
+	}{
+		{"ja", "JA", true},
+		{"JA", "JA", true},
+		{" en ", "EN", true},
+		{"pt-BR", "PT", true},
+		{"en_US", "EN", true},
+		{"zh-Hans", "CS", true},
+		{"zh-CN", "CS", true},
+		{"zh-Hant-TW", "CT", true},
+		{"zh_TW", "CT", true},
+		{"zh", "", false},
+		{"xx", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		lang, ok := LanguageFromISO(tt.tag)
+		if ok != tt.wantOK || lang.ID != tt.want {
+			t.Errorf("LanguageFromISO(%q) = %q, %v; want %q, %v", tt.tag, lang.ID, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestSingle_LanguageName(t *testing.T) {
+	if got := (Single{LanguageID: "CT"}).LanguageName(); got != "Chinese Traditional" {
+		t.Errorf("LanguageName() = %q", got)
+	}
+	if got := (Single{LanguageID: "XX"}).LanguageName(); got != "XX" {
+		t.Errorf("LanguageName() = %q, want raw ID", got)
+	}
+}
+
+func TestValidateLanguageID(t *testing.T) {
+	tests := []struct {
+		id      string
+		wantErr string
+	}{
+		{"EN", ""},
+		{"SA", ""},
+		{"ja", `unknown language ID "ja" (did you mean "JA"?)`},
+		{"pt-BR", `unknown language ID "pt-BR" (Portuguese is "PT")`},
+		{"XX", `unknown language ID "XX"`},
+		{"", `unknown language ID ""`},
+	}
+	for _, tt := range tests {
+		err := ValidateLanguageID(tt.id)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("ValidateLanguageID(%q) error = %v", tt.id, err)
+			}
+			continue
+		}
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) || valErr.Field != "language_id" || valErr.Message != tt.wantErr {
+			t.Errorf("ValidateLanguageID(%q) error = %v, want %q", tt.id, err, tt.wantErr)
+		}
+	}
+}
+
+func TestInventoryWrites_ValidateLanguage(t *testing.T) {
+	// No server: each call must fail before sending a request.
+	client := NewClient("token", "email", WithBaseURL("http://127.0.0.1:0/"), WithRetry(0, 0), WithLimiter(nil))
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		call  func() error
+		field string
+	}{
+		{"bulk by scryfall", func() error {
+			_, err := client.CreateInventoryBulkByScryfall(ctx, []InventoryBulkItemByScryfall{
+				{ScryfallID: "s", LanguageID: "EN"},
+				{ScryfallID: "s", LanguageID: "jp"},
+			})
+			return err
+		}, "items[1].language_id"},
+		{"bulk by tcgplayer id", func() error {
+			_, err := client.CreateInventoryBulkByTCGPlayerID(ctx, []InventoryBulkItemByTCGPlayerID{{TCGPlayerID: 1, LanguageID: "English"}})
+			return err
+		}, "items[0].language_id"},
+		{"update by scryfall", func() error {
+			_, err := client.UpdateSellerInventoryByScryfall(ctx, "s", InventoryByScryfallOptions{LanguageID: "en"}, InventoryUpdateRequest{})
+			return err
+		}, "language_id"},
+		{"delete by scryfall", func() error {
+			_, err := client.DeleteSellerInventoryByScryfall(ctx, "s", InventoryByScryfallOptions{LanguageID: "en"})
+			return err
+		}, "language_id"},
+		{"update by tcgplayer id", func() error {
+			_, err := client.UpdateSellerInventoryByTCGPlayerID(ctx, 1, InventoryByTCGPlayerOptions{LanguageID: "xx"}, InventoryUpdateRequest{})
+			return err
+		}, "language_id"},
+		{"delete by tcgplayer id", func() error {
+			_, err := client.DeleteSellerInventoryByTCGPlayerID(ctx, 1, InventoryByTCGPlayerOptions{LanguageID: "xx"})
+			return err
+		}, "language_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			var valErr *ValidationError
+			if !errors.As(err, &valErr) || valErr.Field != tt.field || !strings.Contains(valErr.Message, "unknown language ID") {
+				t.Errorf("error = %v, want validation error on %s", err, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package manapool
+
+import "context"
+
+// Limiter paces outgoing API requests. The client calls Wait before every
+// request that reaches the network; cache hits do not wait.
+//
+// *rate.Limiter from golang.org/x/time/rate satisfies this interface, which
+// is what WithRateLimit installs. Implement it to share a budget between
+// several clients or processes, for example with a Redis-backed limiter.
+// Implementations must be safe for concurrent use.
+type Limiter interface {
+	// Wait blocks until a request may proceed or ctx is done.
+	Wait(ctx context.Context) error
+}
+
+// LimiterFunc adapts a function to the Limiter interface.
+type LimiterFunc func(ctx context.Context) error
+
+// Wait calls f(ctx).
+func (f LimiterFunc) Wait(ctx context.Context) error {
+	return f(ctx)
+}
@@ -0,0 +1,78 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_WithLimiter_Shared(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var waits int32
+	shared := LimiterFunc(func(ctx context.Context) error {
+		atomic.AddInt32(&waits, 1)
+		return nil
+	})
+
+	clientA := NewClient("a", "a@example.com", WithBaseURL(server.URL+"/"), WithLimiter(shared))
+	clientB := NewClient("b", "b@example.com", WithBaseURL(server.URL+"/"), WithLimiter(shared))
+	ctx := context.Background()
+	for _, client := range []*Client{clientA, clientB, clientA} {
+		if _, err := client.GetSellerAccount(ctx); err != nil {
+			t.Fatalf("GetSellerAccount() error = %v", err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&waits); got != 3 {
+		t.Errorf("limiter waits = %d, want 3", got)
+	}
+}
+
+func TestClient_WithLimiter_Error(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	errRedis := errors.New("redis unavailable")
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithLimiter(LimiterFunc(func(context.Context) error { return errRedis })),
+	)
+
+	_, err := client.GetSellerAccount(context.Background())
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) || !errors.Is(err, errRedis) {
+		t.Fatalf("error = %v, want NetworkError wrapping limiter error", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 0 {
+		t.Errorf("requests = %d, want 0", got)
+	}
+}
+
+func TestClient_WithLimiter_Nil(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithLimiter(nil))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for i := 0; i < 20; i++ {
+		if _, err := client.GetSellerAccount(ctx); err != nil {
+			t.Fatalf("GetSellerAccount() error = %v", err)
+		}
+	}
+}
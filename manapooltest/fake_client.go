@@ -0,0 +1,250 @@
+package manapooltest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/repricah/manapool"
+)
+
+// FakeClient is an in-memory implementation of manapool.APIClient for unit
+// tests that should run without HTTP. Besides the interface methods it
+// implements the common seller inventory and order methods of
+// *manapool.Client with the same signatures, so code written against a
+// narrower local interface can accept either.
+//
+// Errors mirror the real client: invalid arguments return
+// *manapool.ValidationError and missing records return *manapool.APIError
+// with status 404.
+type FakeClient struct {
+	store *store
+
+	mu    sync.Mutex
+	calls []string
+	err   map[string]error
+}
+
+var _ manapool.APIClient = (*FakeClient)(nil)
+
+// NewFakeClient creates a fake client seeded with the given options. Options
+// that configure HTTP behavior (latency, rate limits, credentials) are ignored.
+//
+// Example:
+//
+//	fake := manapooltest.NewFakeClient(manapooltest.WithInventory(items...))
+//	err := manapool.IterateInventory(ctx, fake, func(item *manapool.InventoryItem) error {
+//	    ...
+//	})
+func NewFakeClient(opts ...Option) *FakeClient {
+	// Options operate on a Server, so apply them to one that never listens.
+	srv := &Server{store: newStore()}
+	for _, opt := range opts {
+		opt(srv)
+	}
+	return &FakeClient{store: srv.store, err: make(map[string]error)}
+}
+
+// FailMethod makes every call to the named method return err until cleared
+// with a nil err.
+func (f *FakeClient) FailMethod(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err == nil {
+		delete(f.err, method)
+		return
+	}
+	f.err[method] = err
+}
+
+// Calls returns the names of the methods called so far, in order.
+func (f *FakeClient) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+// Inventory returns a snapshot of the fake's inventory.
+func (f *FakeClient) Inventory() []manapool.InventoryItem {
+	return f.store.allInventory()
+}
+
+// SetInventory replaces the fake's inventory.
+func (f *FakeClient) SetInventory(items ...manapool.InventoryItem) {
+	f.store.setInventory(items)
+}
+
+// AddOrders adds orders to the fake.
+func (f *FakeClient) AddOrders(orders ...manapool.OrderDetails) {
+	f.store.addOrders(orders)
+}
+
+// call records a method call and returns its injected error, if any.
+func (f *FakeClient) call(ctx context.Context, method string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, method)
+	if err := ctx.Err(); err != nil {
+		return manapool.NewNetworkError("request failed", err)
+	}
+	return f.err[method]
+}
+
+func notFound(what string) error {
+	return manapool.NewAPIError(http.StatusNotFound, what+" not found")
+}
+
+// GetSellerAccount implements manapool.APIClient.
+func (f *FakeClient) GetSellerAccount(ctx context.Context) (*manapool.Account, error) {
+	if err := f.call(ctx, "GetSellerAccount"); err != nil {
+		return nil, err
+	}
+	account := f.store.getAccount()
+	return &account, nil
+}
+
+// UpdateSellerAccount mirrors (*manapool.Client).UpdateSellerAccount.
+func (f *FakeClient) UpdateSellerAccount(ctx context.Context, update manapool.SellerAccountUpdate) (*manapool.Account, error) {
+	if err := f.call(ctx, "UpdateSellerAccount"); err != nil {
+		return nil, err
+	}
+	account := f.store.updateAccount(update)
+	return &account, nil
+}
+
+// GetSellerInventory implements manapool.APIClient.
+func (f *FakeClient) GetSellerInventory(ctx context.Context, opts manapool.InventoryOptions) (*manapool.InventoryResponse, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if err := f.call(ctx, "GetSellerInventory"); err != nil {
+		return nil, err
+	}
+	resp := f.store.listInventory(opts.Limit, opts.Offset)
+	return &resp, nil
+}
+
+// GetInventoryByTCGPlayerID implements manapool.APIClient.
+func (f *FakeClient) GetInventoryByTCGPlayerID(ctx context.Context, tcgplayerID string) (*manapool.InventoryItem, error) {
+	if tcgplayerID == "" {
+		return nil, manapool.NewValidationError("tcgplayerID", "tcgplayerID cannot be empty")
+	}
+	if err := f.call(ctx, "GetInventoryByTCGPlayerID"); err != nil {
+		return nil, err
+	}
+	sku, err := strconv.Atoi(tcgplayerID)
+	if err != nil {
+		return nil, notFound("inventory item")
+	}
+	item, ok := f.store.getBySKU(sku)
+	if !ok {
+		return nil, notFound("inventory item")
+	}
+	return &item, nil
+}
+
+// GetSellerInventoryBySKU mirrors (*manapool.Client).GetSellerInventoryBySKU.
+func (f *FakeClient) GetSellerInventoryBySKU(ctx context.Context, sku int) (*manapool.InventoryListingResponse, error) {
+	if sku <= 0 {
+		return nil, manapool.NewValidationError("sku", "sku must be positive")
+	}
+	if err := f.call(ctx, "GetSellerInventoryBySKU"); err != nil {
+		return nil, err
+	}
+	item, ok := f.store.getBySKU(sku)
+	if !ok {
+		return nil, notFound("inventory item")
+	}
+	return &manapool.InventoryListingResponse{Inventory: item}, nil
+}
+
+// UpdateSellerInventoryBySKU mirrors (*manapool.Client).UpdateSellerInventoryBySKU.
+func (f *FakeClient) UpdateSellerInventoryBySKU(ctx context.Context, sku int, update manapool.InventoryUpdateRequest) (*manapool.InventoryListingResponse, error) {
+	if sku <= 0 {
+		return nil, manapool.NewValidationError("sku", "sku must be positive")
+	}
+	if err := f.call(ctx, "UpdateSellerInventoryBySKU"); err != nil {
+		return nil, err
+	}
+	item, ok := f.store.updateBySKU(sku, update)
+	if !ok {
+		return nil, notFound("inventory item")
+	}
+	return &manapool.InventoryListingResponse{Inventory: item}, nil
+}
+
+// DeleteSellerInventoryBySKU mirrors (*manapool.Client).DeleteSellerInventoryBySKU.
+func (f *FakeClient) DeleteSellerInventoryBySKU(ctx context.Context, sku int) (*manapool.InventoryListingResponse, error) {
+	if sku <= 0 {
+		return nil, manapool.NewValidationError("sku", "sku must be positive")
+	}
+	if err := f.call(ctx, "DeleteSellerInventoryBySKU"); err != nil {
+		return nil, err
+	}
+	item, ok := f.store.deleteBySKU(sku)
+	if !ok {
+		return nil, notFound("inventory item")
+	}
+	return &manapool.InventoryListingResponse{Inventory: item}, nil
+}
+
+// CreateInventoryBulkBySKU mirrors (*manapool.Client).CreateInventoryBulkBySKU.
+func (f *FakeClient) CreateInventoryBulkBySKU(ctx context.Context, items []manapool.InventoryBulkItemBySKU) (*manapool.InventoryItemsResponse, error) {
+	if len(items) == 0 {
+		return nil, manapool.NewValidationError("items", "items cannot be empty")
+	}
+	for i, item := range items {
+		if item.TCGPlayerSKU <= 0 || item.Quantity < 0 || item.PriceCents < 0 {
+			return nil, manapool.NewValidationError("items", fmt.Sprintf("item %d is invalid", i))
+		}
+	}
+	if err := f.call(ctx, "CreateInventoryBulkBySKU"); err != nil {
+		return nil, err
+	}
+	return &manapool.InventoryItemsResponse{Inventory: f.store.upsertBySKU(items)}, nil
+}
+
+// CreateInventoryBulk mirrors (*manapool.Client).CreateInventoryBulk.
+func (f *FakeClient) CreateInventoryBulk(ctx context.Context, items []manapool.InventoryBulkItemBySKU) (*manapool.InventoryItemsResponse, error) {
+	return f.CreateInventoryBulkBySKU(ctx, items)
+}
+
+// GetSellerOrders mirrors (*manapool.Client).GetSellerOrders.
+func (f *FakeClient) GetSellerOrders(ctx context.Context, opts manapool.OrdersOptions) (*manapool.OrdersResponse, error) {
+	if err := f.call(ctx, "GetSellerOrders"); err != nil {
+		return nil, err
+	}
+	return &manapool.OrdersResponse{Orders: f.store.listOrders(opts)}, nil
+}
+
+// GetSellerOrder mirrors (*manapool.Client).GetSellerOrder.
+func (f *FakeClient) GetSellerOrder(ctx context.Context, id string) (*manapool.OrderDetailsResponse, error) {
+	if id == "" {
+		return nil, manapool.NewValidationError("id", "id cannot be empty")
+	}
+	if err := f.call(ctx, "GetSellerOrder"); err != nil {
+		return nil, err
+	}
+	order, ok := f.store.getOrder(id)
+	if !ok {
+		return nil, notFound("order")
+	}
+	return &manapool.OrderDetailsResponse{Order: order}, nil
+}
+
+// UpdateSellerOrderFulfillment mirrors (*manapool.Client).UpdateSellerOrderFulfillment.
+func (f *FakeClient) UpdateSellerOrderFulfillment(ctx context.Context, id string, req manapool.OrderFulfillmentRequest) (*manapool.OrderFulfillmentResponse, error) {
+	if id == "" {
+		return nil, manapool.NewValidationError("id", "id cannot be empty")
+	}
+	if err := f.call(ctx, "UpdateSellerOrderFulfillment"); err != nil {
+		return nil, err
+	}
+	fulfillment, ok := f.store.updateFulfillment(id, req)
+	if !ok {
+		return nil, notFound("order")
+	}
+	return &manapool.OrderFulfillmentResponse{Fulfillment: fulfillment}, nil
+}
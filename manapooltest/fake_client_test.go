@@ -0,0 +1,165 @@
+package manapooltest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool"
+)
+
+func TestFakeClient_Inventory(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFakeClient(WithInventory(skuItem(1, 100, 1), skuItem(2, 200, 2)))
+
+	var seen int
+	err := manapool.IterateInventory(ctx, fake, func(*manapool.InventoryItem) error {
+		seen++
+		return nil
+	})
+	if err != nil || seen != 2 {
+		t.Fatalf("IterateInventory() = %v, seen %d", err, seen)
+	}
+
+	item, err := fake.GetInventoryByTCGPlayerID(ctx, "2")
+	if err != nil || item.PriceCents != 200 {
+		t.Errorf("GetInventoryByTCGPlayerID() = %+v, %v", item, err)
+	}
+
+	if _, err := fake.CreateInventoryBulk(ctx, []manapool.InventoryBulkItemBySKU{{TCGPlayerSKU: 3, PriceCents: 300, Quantity: 1}}); err != nil {
+		t.Fatalf("CreateInventoryBulk() error = %v", err)
+	}
+	if _, err := fake.UpdateSellerInventoryBySKU(ctx, 1, manapool.InventoryUpdateRequest{PriceCents: 150, Quantity: 5}); err != nil {
+		t.Fatalf("UpdateSellerInventoryBySKU() error = %v", err)
+	}
+	if _, err := fake.DeleteSellerInventoryBySKU(ctx, 2); err != nil {
+		t.Fatalf("DeleteSellerInventoryBySKU() error = %v", err)
+	}
+
+	listing, err := fake.GetSellerInventoryBySKU(ctx, 1)
+	if err != nil || listing.Inventory.Quantity != 5 {
+		t.Errorf("GetSellerInventoryBySKU() = %+v, %v", listing, err)
+	}
+	if len(fake.Inventory()) != 2 {
+		t.Errorf("Inventory() = %d items, want 2", len(fake.Inventory()))
+	}
+
+	var apiErr *manapool.APIError
+	if _, err := fake.GetSellerInventoryBySKU(ctx, 2); !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("GetSellerInventoryBySKU(deleted) error = %v, want 404", err)
+	}
+	if _, err := fake.GetInventoryByTCGPlayerID(ctx, "abc"); !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("GetInventoryByTCGPlayerID(abc) error = %v, want 404", err)
+	}
+	if _, err := fake.UpdateSellerInventoryBySKU(ctx, 99, manapool.InventoryUpdateRequest{}); !errors.As(err, &apiErr) {
+		t.Errorf("UpdateSellerInventoryBySKU(missing) error = %v, want 404", err)
+	}
+	if _, err := fake.DeleteSellerInventoryBySKU(ctx, 99); !errors.As(err, &apiErr) {
+		t.Errorf("DeleteSellerInventoryBySKU(missing) error = %v, want 404", err)
+	}
+}
+
+func TestFakeClient_Validation(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFakeClient()
+	var valErr *manapool.ValidationError
+
+	checks := map[string]error{}
+	_, checks["GetSellerInventory"] = fake.GetSellerInventory(ctx, manapool.InventoryOptions{Limit: 501})
+	_, checks["GetInventoryByTCGPlayerID"] = fake.GetInventoryByTCGPlayerID(ctx, "")
+	_, checks["GetSellerInventoryBySKU"] = fake.GetSellerInventoryBySKU(ctx, 0)
+	_, checks["UpdateSellerInventoryBySKU"] = fake.UpdateSellerInventoryBySKU(ctx, -1, manapool.InventoryUpdateRequest{})
+	_, checks["DeleteSellerInventoryBySKU"] = fake.DeleteSellerInventoryBySKU(ctx, 0)
+	_, checks["CreateInventoryBulkBySKU"] = fake.CreateInventoryBulkBySKU(ctx, nil)
+	_, checks["CreateInventoryBulkBySKU invalid"] = fake.CreateInventoryBulkBySKU(ctx, []manapool.InventoryBulkItemBySKU{{TCGPlayerSKU: 1, Quantity: -1}})
+	_, checks["GetSellerOrder"] = fake.GetSellerOrder(ctx, "")
+	_, checks["UpdateSellerOrderFulfillment"] = fake.UpdateSellerOrderFulfillment(ctx, "", manapool.OrderFulfillmentRequest{})
+
+	for name, err := range checks {
+		if err == nil {
+			t.Errorf("%s error = nil, want validation error", name)
+			continue
+		}
+		if name != "GetSellerInventory" && !errors.As(err, &valErr) {
+			t.Errorf("%s error = %v, want ValidationError", name, err)
+		}
+	}
+	if len(fake.Calls()) != 0 {
+		t.Errorf("invalid calls were recorded: %v", fake.Calls())
+	}
+}
+
+func TestFakeClient_AccountAndOrders(t *testing.T) {
+	ctx := context.Background()
+	order := manapool.OrderDetails{}
+	order.ID = "o1"
+	fake := NewFakeClient(WithAccount(manapool.Account{Username: "shop"}), WithOrders(order))
+
+	account, err := fake.GetSellerAccount(ctx)
+	if err != nil || account.Username != "shop" {
+		t.Fatalf("GetSellerAccount() = %+v, %v", account, err)
+	}
+	live := true
+	if account, _ := fake.UpdateSellerAccount(ctx, manapool.SellerAccountUpdate{SinglesLive: &live}); !account.SinglesLive {
+		t.Error("UpdateSellerAccount() did not apply")
+	}
+
+	status := "shipped"
+	if _, err := fake.UpdateSellerOrderFulfillment(ctx, "o1", manapool.OrderFulfillmentRequest{Status: &status}); err != nil {
+		t.Fatalf("UpdateSellerOrderFulfillment() error = %v", err)
+	}
+	fulfilled := true
+	orders, err := fake.GetSellerOrders(ctx, manapool.OrdersOptions{IsFulfilled: &fulfilled})
+	if err != nil || len(orders.Orders) != 1 {
+		t.Errorf("GetSellerOrders() = %+v, %v", orders, err)
+	}
+	got, err := fake.GetSellerOrder(ctx, "o1")
+	if err != nil || len(got.Order.Fulfillments) != 1 {
+		t.Errorf("GetSellerOrder() = %+v, %v", got, err)
+	}
+
+	fake.AddOrders(manapool.OrderDetails{})
+	orders, _ = fake.GetSellerOrders(ctx, manapool.OrdersOptions{})
+	if len(orders.Orders) != 2 {
+		t.Errorf("orders after AddOrders = %d, want 2", len(orders.Orders))
+	}
+
+	var apiErr *manapool.APIError
+	if _, err := fake.GetSellerOrder(ctx, "missing"); !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("GetSellerOrder(missing) error = %v", err)
+	}
+	if _, err := fake.UpdateSellerOrderFulfillment(ctx, "missing", manapool.OrderFulfillmentRequest{}); !errors.As(err, &apiErr) {
+		t.Errorf("UpdateSellerOrderFulfillment(missing) error = %v", err)
+	}
+
+	want := "GetSellerAccount,UpdateSellerAccount,UpdateSellerOrderFulfillment,GetSellerOrders,GetSellerOrder,GetSellerOrders,GetSellerOrder,UpdateSellerOrderFulfillment"
+	if got := strings.Join(fake.Calls(), ","); got != want {
+		t.Errorf("Calls() = %s", got)
+	}
+}
+
+func TestFakeClient_FailMethodAndContext(t *testing.T) {
+	fake := NewFakeClient()
+	boom := errors.New("boom")
+
+	fake.FailMethod("GetSellerAccount", boom)
+	if _, err := fake.GetSellerAccount(context.Background()); !errors.Is(err, boom) {
+		t.Errorf("error = %v, want boom", err)
+	}
+	fake.FailMethod("GetSellerAccount", nil)
+	if _, err := fake.GetSellerAccount(context.Background()); err != nil {
+		t.Errorf("error after clearing = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := fake.GetSellerAccount(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled error = %v, want context.Canceled", err)
+	}
+
+	fake.SetInventory(skuItem(7, 700, 1))
+	if inv := fake.Inventory(); len(inv) != 1 || inv[0].ID == "" {
+		t.Errorf("SetInventory() = %+v", inv)
+	}
+}
@@ -0,0 +1,87 @@
+package manapooltest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/repricah/manapool"
+)
+
+// NoPrices is a manapool.PriceSource that knows no prices, for tests that
+// exercise the no-market-price path.
+var NoPrices manapool.PriceSource = manapool.PriceSourceFunc(
+	func(ctx context.Context, _ []manapool.ProductKey) (map[manapool.ProductKey]manapool.ReferencePrice, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return map[manapool.ProductKey]manapool.ReferencePrice{}, nil
+	})
+
+// PriceFixture is a manapool.PriceSource backed by fixed prices. It records
+// every lookup so tests can check that prices were fetched in one batch.
+type PriceFixture struct {
+	mu     sync.Mutex
+	prices map[manapool.ProductKey]manapool.ReferencePrice
+	err    error
+	calls  [][]manapool.ProductKey
+}
+
+var _ manapool.PriceSource = (*PriceFixture)(nil)
+
+// NewPriceFixture creates a fixture serving prices.
+//
+// Example:
+//
+//	fixture := manapooltest.NewPriceFixture(map[manapool.ProductKey]manapool.ReferencePrice{
+//	    manapool.ProductKeyFor(item): {Cents: 450},
+//	})
+//	result, err := repricer.New(nil, rules).WithPrices(fixture).Evaluate(ctx, inventory)
+func NewPriceFixture(prices map[manapool.ProductKey]manapool.ReferencePrice) *PriceFixture {
+	f := &PriceFixture{prices: make(map[manapool.ProductKey]manapool.ReferencePrice, len(prices))}
+	for key, price := range prices {
+		f.prices[key] = price
+	}
+	return f
+}
+
+// SetPrice sets the price for key.
+func (f *PriceFixture) SetPrice(key manapool.ProductKey, price manapool.ReferencePrice) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.prices[key] = price
+}
+
+// Fail makes every lookup return err until cleared with a nil err.
+func (f *PriceFixture) Fail(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+// Calls returns the keys requested by each lookup so far, in order.
+func (f *PriceFixture) Calls() [][]manapool.ProductKey {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([][]manapool.ProductKey(nil), f.calls...)
+}
+
+// Prices implements manapool.PriceSource.
+func (f *PriceFixture) Prices(ctx context.Context, keys []manapool.ProductKey) (map[manapool.ProductKey]manapool.ReferencePrice, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, append([]manapool.ProductKey(nil), keys...))
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	prices := make(map[manapool.ProductKey]manapool.ReferencePrice)
+	for _, key := range keys {
+		if price, ok := f.prices[key]; ok {
+			prices[key] = price
+		}
+	}
+	return prices, nil
+}
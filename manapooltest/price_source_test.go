@@ -0,0 +1,49 @@
+package manapooltest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/repricah/manapool"
+)
+
+func TestPriceFixture(t *testing.T) {
+	ctx := context.Background()
+	bolt := manapool.ProductKey{ProductID: "bolt", ConditionID: "NM", FinishID: "NF", LanguageID: "EN"}
+	box := manapool.ProductKey{ProductID: "box"}
+
+	fixture := NewPriceFixture(map[manapool.ProductKey]manapool.ReferencePrice{bolt: {Cents: 450}})
+	fixture.SetPrice(box, manapool.ReferencePrice{Cents: 9999, AvailableQuantity: 3})
+
+	prices, err := fixture.Prices(ctx, []manapool.ProductKey{bolt, box, {ProductID: "missing"}})
+	if err != nil {
+		t.Fatalf("Prices() error = %v", err)
+	}
+	if len(prices) != 2 || prices[bolt].Cents != 450 || prices[box].AvailableQuantity != 3 {
+		t.Errorf("Prices() = %+v", prices)
+	}
+
+	boom := errors.New("boom")
+	fixture.Fail(boom)
+	if _, err := fixture.Prices(ctx, []manapool.ProductKey{bolt}); !errors.Is(err, boom) {
+		t.Errorf("Prices() after Fail error = %v", err)
+	}
+	fixture.Fail(nil)
+
+	if calls := fixture.Calls(); len(calls) != 2 || len(calls[0]) != 3 || calls[1][0] != bolt {
+		t.Errorf("Calls() = %+v", calls)
+	}
+}
+
+func TestNoPrices(t *testing.T) {
+	prices, err := NoPrices.Prices(context.Background(), []manapool.ProductKey{{ProductID: "bolt"}})
+	if err != nil || len(prices) != 0 {
+		t.Errorf("NoPrices.Prices() = %+v, %v", prices, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NoPrices.Prices(ctx, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("NoPrices.Prices(cancelled) error = %v", err)
+	}
+}
@@ -0,0 +1,536 @@
+// Package manapooltest provides test doubles for code that uses the manapool
+// client: an httptest-based fake of the ManaPool API, an in-memory fake
+// client, and a record/replay transport for fixture-based tests.
+//
+// # Fake Server
+//
+// NewServer starts an HTTP server that implements the account, seller
+// inventory, and seller order endpoints against in-memory state. Tests point
+// a real *manapool.Client at it and can inject errors, latency, and rate
+// limiting:
+//
+//	srv := manapooltest.NewServer(
+//	    manapooltest.WithInventory(items...),
+//	    manapooltest.WithLatency(10*time.Millisecond),
+//	)
+//	defer srv.Close()
+//
+//	srv.InjectFault(manapooltest.Fault{
+//	    Method: http.MethodPost,
+//	    Path:   "/seller/inventory/tcgsku",
+//	    Status: http.StatusServiceUnavailable,
+//	    Times:  1,
+//	})
+//
+//	client := srv.Client()
+//	report, err := client.SyncInventory(ctx, desired, manapool.SyncOptions{})
+//
+// # Fake Client
+//
+// NewFakeClient returns a FakeClient that implements manapool.APIClient and
+// the common seller methods against the same in-memory state, without HTTP.
+//
+// # Price Sources
+//
+// PriceFixture serves fixed competitor prices through manapool.PriceSource
+// and records each lookup; NoPrices knows no prices at all.
+//
+// # Recorded Fixtures
+//
+// Recorder is an http.RoundTripper that records real API traffic to a JSON
+// fixture with credentials scrubbed, then replays it offline. See NewRecorder.
+package manapooltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+// Fault describes an injected error response.
+type Fault struct {
+	// Method restricts the fault to one HTTP method ("" matches any)
+	Method string
+
+	// Path restricts the fault to one request path such as
+	// "/seller/inventory" ("" matches any)
+	Path string
+
+	// Status is the HTTP status code to return (default: 500)
+	Status int
+
+	// Message is returned in the JSON error body
+	Message string
+
+	// Times is the number of matching requests to fail (0 fails every
+	// matching request until ClearFaults is called)
+	Times int
+}
+
+func (f *Fault) matches(r *http.Request) bool {
+	return (f.Method == "" || f.Method == r.Method) && (f.Path == "" || f.Path == r.URL.Path)
+}
+
+// Request is a request received by the fake server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Server is a fake ManaPool API backed by in-memory state.
+// The embedded *httptest.Server exposes URL and Close.
+type Server struct {
+	*httptest.Server
+
+	store *store
+	token string
+	email string
+
+	mu          sync.Mutex
+	faults      []*Fault
+	latency     time.Duration
+	rateLimit   int
+	rateWindow  time.Duration
+	windowStart time.Time
+	windowCount int
+	requests    []Request
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithAccount sets the account returned by GET /account.
+func WithAccount(account manapool.Account) Option {
+	return func(s *Server) {
+		s.store.setAccount(account)
+	}
+}
+
+// WithInventory seeds the seller inventory. Missing IDs, product IDs, and
+// timestamps are filled in.
+func WithInventory(items ...manapool.InventoryItem) Option {
+	return func(s *Server) {
+		s.store.addInventory(items)
+	}
+}
+
+// WithOrders seeds the seller orders.
+func WithOrders(orders ...manapool.OrderDetails) Option {
+	return func(s *Server) {
+		s.store.addOrders(orders)
+	}
+}
+
+// WithCredentials makes the server reject requests whose access token and
+// email headers do not match, as the real API does.
+func WithCredentials(token, email string) Option {
+	return func(s *Server) {
+		s.token = token
+		s.email = email
+	}
+}
+
+// WithLatency delays every response by d.
+func WithLatency(d time.Duration) Option {
+	return func(s *Server) {
+		s.latency = d
+	}
+}
+
+// WithRateLimit allows at most requests per window; further requests in the
+// window receive 429 Too Many Requests with a Retry-After header.
+func WithRateLimit(requests int, window time.Duration) Option {
+	return func(s *Server) {
+		s.rateLimit = requests
+		s.rateWindow = window
+	}
+}
+
+// NewServer starts a fake ManaPool API server. Call Close when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		store: newStore(),
+		token: "test-token",
+		email: "seller@example.com",
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// Client returns a client configured to talk to the server with matching
+// credentials. Retries are disabled so injected faults surface immediately;
+// pass manapool.WithRetry to re-enable them. opts are applied last.
+func (s *Server) Client(opts ...manapool.ClientOption) *manapool.Client {
+	defaults := []manapool.ClientOption{
+		manapool.WithBaseURL(s.URL + "/"),
+		manapool.WithRetry(0, 0),
+		manapool.WithRateLimit(1000, 100),
+	}
+	return manapool.NewClient(s.token, s.email, append(defaults, opts...)...)
+}
+
+// InjectFault adds an error response for matching requests. Faults are
+// checked in the order they were added.
+func (s *Server) InjectFault(f Fault) {
+	if f.Status == 0 {
+		f.Status = http.StatusInternalServerError
+	}
+	if f.Message == "" {
+		f.Message = http.StatusText(f.Status)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// SetLatency changes the delay applied to every response.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// Requests returns every request received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Inventory returns a snapshot of the server's inventory.
+func (s *Server) Inventory() []manapool.InventoryItem {
+	return s.store.allInventory()
+}
+
+// SetInventory replaces the server's inventory.
+func (s *Server) SetInventory(items ...manapool.InventoryItem) {
+	s.store.setInventory(items)
+}
+
+// AddOrders adds orders to the server.
+func (s *Server) AddOrders(orders ...manapool.OrderDetails) {
+	s.store.addOrders(orders)
+}
+
+// Order returns the server's copy of an order.
+func (s *Server) Order(id string) (manapool.OrderDetails, bool) {
+	return s.store.getOrder(id)
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /account", s.handleGetAccount)
+	mux.HandleFunc("PUT /account", s.handleUpdateAccount)
+	mux.HandleFunc("GET /seller/inventory", s.handleListInventory)
+	mux.HandleFunc("POST /seller/inventory", s.handleUpsertInventory)
+	mux.HandleFunc("POST /seller/inventory/tcgsku", s.handleUpsertInventory)
+	mux.HandleFunc("POST /seller/inventory/product", s.handleUpsertByProduct)
+	mux.HandleFunc("GET /seller/inventory/tcgsku/{sku}", s.handleGetBySKU)
+	mux.HandleFunc("PUT /seller/inventory/tcgsku/{sku}", s.handleUpdateBySKU)
+	mux.HandleFunc("DELETE /seller/inventory/tcgsku/{sku}", s.handleDeleteBySKU)
+	mux.HandleFunc("GET /inventory/listings/{id}", s.handleGetListing)
+	for _, prefix := range []string{"/orders", "/seller/orders"} {
+		mux.HandleFunc("GET "+prefix, s.handleListOrders)
+		mux.HandleFunc("GET "+prefix+"/{id}", s.handleGetOrder)
+		mux.HandleFunc("PUT "+prefix+"/{id}/fulfillment", s.handleUpdateFulfillment)
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not found")
+	})
+	return s.middleware(mux)
+}
+
+// middleware records requests and applies latency, rate limiting,
+// authentication, and injected faults before routing.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.Query(),
+			Header: r.Header.Clone(),
+			Body:   body,
+		})
+		latency := s.latency
+		retryAfter, limited := s.checkRateLimit()
+		fault := s.takeFault(r)
+		s.mu.Unlock()
+
+		if latency > 0 {
+			timer := time.NewTimer(latency)
+			select {
+			case <-r.Context().Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+
+		switch {
+		case limited:
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds()+0.999)))
+			writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+		case r.Header.Get("X-ManaPool-Access-Token") != s.token || r.Header.Get("X-ManaPool-Email") != s.email:
+			writeError(w, http.StatusUnauthorized, "invalid credentials")
+		case fault != nil:
+			writeError(w, fault.Status, fault.Message)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// checkRateLimit counts the request against the current window. The caller
+// must hold s.mu.
+func (s *Server) checkRateLimit() (time.Duration, bool) {
+	if s.rateLimit <= 0 {
+		return 0, false
+	}
+	now := time.Now()
+	if now.Sub(s.windowStart) >= s.rateWindow {
+		s.windowStart = now
+		s.windowCount = 0
+	}
+	s.windowCount++
+	if s.windowCount > s.rateLimit {
+		return s.rateWindow - now.Sub(s.windowStart), true
+	}
+	return 0, false
+}
+
+// takeFault returns the first fault matching r, consuming one use. The
+// caller must hold s.mu.
+func (s *Server) takeFault(r *http.Request) *Fault {
+	for i, fault := range s.faults {
+		if !fault.matches(r) {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+func (s *Server) handleGetAccount(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.store.getAccount())
+}
+
+func (s *Server) handleUpdateAccount(w http.ResponseWriter, r *http.Request) {
+	var update manapool.SellerAccountUpdate
+	if !readJSON(w, r, &update) {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.store.updateAccount(update))
+}
+
+func (s *Server) handleListInventory(w http.ResponseWriter, r *http.Request) {
+	limit, offset := 500, 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > 500 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = n
+	}
+	if v := r.URL.Query().Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid offset")
+			return
+		}
+		offset = n
+	}
+	writeJSON(w, http.StatusOK, s.store.listInventory(limit, offset))
+}
+
+func (s *Server) handleUpsertInventory(w http.ResponseWriter, r *http.Request) {
+	var items []manapool.InventoryBulkItemBySKU
+	if !readJSON(w, r, &items) {
+		return
+	}
+	for _, item := range items {
+		if item.TCGPlayerSKU <= 0 || item.Quantity < 0 || item.PriceCents < 0 {
+			writeError(w, http.StatusBadRequest, "invalid inventory item")
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, manapool.InventoryItemsResponse{Inventory: s.store.upsertBySKU(items)})
+}
+
+func (s *Server) handleUpsertByProduct(w http.ResponseWriter, r *http.Request) {
+	var items []manapool.InventoryBulkItemByProduct
+	if !readJSON(w, r, &items) {
+		return
+	}
+	for _, item := range items {
+		if item.ProductType == "" || item.ProductID == "" || item.Quantity < 0 || item.PriceCents < 0 {
+			writeError(w, http.StatusBadRequest, "invalid inventory item")
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, manapool.InventoryItemsResponse{Inventory: s.store.upsertByProduct(items)})
+}
+
+func (s *Server) handleGetBySKU(w http.ResponseWriter, r *http.Request) {
+	sku, ok := pathSKU(w, r)
+	if !ok {
+		return
+	}
+	item, found := s.store.getBySKU(sku)
+	if !found {
+		writeError(w, http.StatusNotFound, "inventory item not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, manapool.InventoryListingResponse{Inventory: item})
+}
+
+func (s *Server) handleUpdateBySKU(w http.ResponseWriter, r *http.Request) {
+	sku, ok := pathSKU(w, r)
+	if !ok {
+		return
+	}
+	var update manapool.InventoryUpdateRequest
+	if !readJSON(w, r, &update) {
+		return
+	}
+	item, found := s.store.updateBySKU(sku, update)
+	if !found {
+		writeError(w, http.StatusNotFound, "inventory item not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, manapool.InventoryListingResponse{Inventory: item})
+}
+
+func (s *Server) handleDeleteBySKU(w http.ResponseWriter, r *http.Request) {
+	sku, ok := pathSKU(w, r)
+	if !ok {
+		return
+	}
+	item, found := s.store.deleteBySKU(sku)
+	if !found {
+		writeError(w, http.StatusNotFound, "inventory item not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, manapool.InventoryListingResponse{Inventory: item})
+}
+
+func (s *Server) handleGetListing(w http.ResponseWriter, r *http.Request) {
+	item, found := s.store.getByID(r.PathValue("id"))
+	if !found {
+		writeError(w, http.StatusNotFound, "inventory item not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, manapool.InventoryItemResponse{InventoryItem: item})
+}
+
+func (s *Server) handleListOrders(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var opts manapool.OrdersOptions
+	if v := query.Get("since"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid since")
+			return
+		}
+		opts.Since = &manapool.Timestamp{Time: t}
+	}
+	for name, dst := range map[string]**bool{
+		"is_unfulfilled":   &opts.IsUnfulfilled,
+		"is_fulfilled":     &opts.IsFulfilled,
+		"has_fulfillments": &opts.HasFulfillments,
+	} {
+		if v := query.Get(name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalid "+name)
+				return
+			}
+			*dst = &b
+		}
+	}
+	opts.Label = query.Get("label")
+	opts.Limit, _ = strconv.Atoi(query.Get("limit"))
+	opts.Offset, _ = strconv.Atoi(query.Get("offset"))
+
+	writeJSON(w, http.StatusOK, manapool.OrdersResponse{Orders: s.store.listOrders(opts)})
+}
+
+func (s *Server) handleGetOrder(w http.ResponseWriter, r *http.Request) {
+	order, found := s.store.getOrder(r.PathValue("id"))
+	if !found {
+		writeError(w, http.StatusNotFound, "order not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, manapool.OrderDetailsResponse{Order: order})
+}
+
+func (s *Server) handleUpdateFulfillment(w http.ResponseWriter, r *http.Request) {
+	var req manapool.OrderFulfillmentRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	fulfillment, found := s.store.updateFulfillment(r.PathValue("id"), req)
+	if !found {
+		writeError(w, http.StatusNotFound, "order not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, manapool.OrderFulfillmentResponse{Fulfillment: fulfillment})
+}
+
+func pathSKU(w http.ResponseWriter, r *http.Request) (int, bool) {
+	sku, err := strconv.Atoi(r.PathValue("sku"))
+	if err != nil || sku <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid sku")
+		return 0, false
+	}
+	return sku, true
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"status": status, "message": message})
+}
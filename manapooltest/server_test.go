@@ -0,0 +1,295 @@
+package manapooltest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+func skuItem(sku, priceCents, quantity int) manapool.InventoryItem {
+	return manapool.InventoryItem{
+		PriceCents: priceCents,
+		Quantity:   quantity,
+		Product:    manapool.Product{TCGPlayerSKU: &sku},
+	}
+}
+
+func TestServer_Account(t *testing.T) {
+	srv := NewServer(WithAccount(manapool.Account{Username: "shop", Email: "shop@example.com"}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	client := srv.Client()
+	account, err := client.GetSellerAccount(ctx)
+	if err != nil {
+		t.Fatalf("GetSellerAccount() error = %v", err)
+	}
+	if account.Username != "shop" {
+		t.Errorf("Username = %q, want shop", account.Username)
+	}
+
+	live := false
+	account, err = client.UpdateSellerAccount(ctx, manapool.SellerAccountUpdate{SealedLive: &live})
+	if err != nil {
+		t.Fatalf("UpdateSellerAccount() error = %v", err)
+	}
+	if account.SealedLive {
+		t.Error("SealedLive not updated")
+	}
+}
+
+func TestServer_InventoryPagination(t *testing.T) {
+	var items []manapool.InventoryItem
+	for i := 1; i <= 7; i++ {
+		items = append(items, skuItem(i, i*100, 1))
+	}
+	srv := NewServer(WithInventory(items...))
+	defer srv.Close()
+
+	ctx := context.Background()
+	client := srv.Client()
+	resp, err := client.GetSellerInventory(ctx, manapool.InventoryOptions{Limit: 3, Offset: 6})
+	if err != nil {
+		t.Fatalf("GetSellerInventory() error = %v", err)
+	}
+	if resp.Pagination.Total != 7 || resp.Pagination.Returned != 1 || resp.Inventory[0].PriceCents != 700 {
+		t.Errorf("page = %+v", resp)
+	}
+
+	count := 0
+	err = manapool.IterateInventory(ctx, client, func(*manapool.InventoryItem) error {
+		count++
+		return nil
+	})
+	if err != nil || count != 7 {
+		t.Errorf("IterateInventory() = %v, count %d", err, count)
+	}
+}
+
+func TestServer_InventoryCRUD(t *testing.T) {
+	srv := NewServer(WithInventory(skuItem(1, 100, 1)))
+	defer srv.Close()
+
+	ctx := context.Background()
+	client := srv.Client()
+
+	created, err := client.CreateInventoryBulkBySKU(ctx, []manapool.InventoryBulkItemBySKU{
+		{TCGPlayerSKU: 1, PriceCents: 150, Quantity: 2},
+		{TCGPlayerSKU: 2, PriceCents: 200, Quantity: 1},
+	})
+	if err != nil {
+		t.Fatalf("CreateInventoryBulkBySKU() error = %v", err)
+	}
+	if len(created.Inventory) != 2 || created.Inventory[1].ID == "" {
+		t.Errorf("created = %+v", created.Inventory)
+	}
+
+	listing, err := client.UpdateSellerInventoryBySKU(ctx, 2, manapool.InventoryUpdateRequest{PriceCents: 250, Quantity: 3})
+	if err != nil {
+		t.Fatalf("UpdateSellerInventoryBySKU() error = %v", err)
+	}
+	if listing.Inventory.PriceCents != 250 {
+		t.Errorf("PriceCents = %d, want 250", listing.Inventory.PriceCents)
+	}
+
+	got, err := client.GetInventoryListing(ctx, listing.Inventory.ID)
+	if err != nil || got.InventoryItem.Quantity != 3 {
+		t.Errorf("GetInventoryListing() = %+v, %v", got, err)
+	}
+
+	if _, err := client.DeleteSellerInventoryBySKU(ctx, 1); err != nil {
+		t.Fatalf("DeleteSellerInventoryBySKU() error = %v", err)
+	}
+	_, err = client.GetSellerInventoryBySKU(ctx, 1)
+	var apiErr *manapool.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("GetSellerInventoryBySKU(deleted) error = %v, want not found", err)
+	}
+
+	if inv := srv.Inventory(); len(inv) != 1 || *inv[0].Product.TCGPlayerSKU != 2 {
+		t.Errorf("Inventory() = %+v", inv)
+	}
+}
+
+func TestServer_UpsertByProduct(t *testing.T) {
+	srv := NewServer(WithInventory(manapool.InventoryItem{ID: "a", ProductType: "mtg_sealed", ProductID: "box", PriceCents: 100}))
+	defer srv.Close()
+
+	created, err := srv.Client().CreateInventoryBulkByProduct(context.Background(), []manapool.InventoryBulkItemByProduct{
+		{ProductType: "mtg_sealed", ProductID: "box", PriceCents: 150, Quantity: 2},
+		{ProductType: "mtg_single", ProductID: "card", PriceCents: 25, Quantity: 4},
+	})
+	if err != nil {
+		t.Fatalf("CreateInventoryBulkByProduct() error = %v", err)
+	}
+	if len(created.Inventory) != 2 || created.Inventory[0].ID != "a" || created.Inventory[1].ProductID != "card" {
+		t.Errorf("created = %+v", created.Inventory)
+	}
+	if inv := srv.Inventory(); len(inv) != 2 || inv[0].PriceCents != 150 || inv[0].Quantity != 2 {
+		t.Errorf("Inventory() = %+v", inv)
+	}
+}
+
+func TestServer_Orders(t *testing.T) {
+	now := time.Now().UTC()
+	older := manapool.OrderDetails{}
+	older.ID = "old"
+	older.CreatedAt = manapool.Timestamp{Time: now.Add(-48 * time.Hour)}
+	newer := manapool.OrderDetails{}
+	newer.ID = "new"
+	newer.Label = "L1"
+	newer.CreatedAt = manapool.Timestamp{Time: now}
+
+	srv := NewServer(WithOrders(older, newer))
+	defer srv.Close()
+
+	ctx := context.Background()
+	client := srv.Client()
+
+	since := manapool.Timestamp{Time: now.Add(-time.Hour)}
+	orders, err := client.GetSellerOrders(ctx, manapool.OrdersOptions{Since: &since})
+	if err != nil {
+		t.Fatalf("GetSellerOrders() error = %v", err)
+	}
+	if len(orders.Orders) != 1 || orders.Orders[0].ID != "new" {
+		t.Errorf("orders = %+v", orders.Orders)
+	}
+
+	status := "shipped"
+	if _, err := client.UpdateSellerOrderFulfillment(ctx, "new", manapool.OrderFulfillmentRequest{Status: &status}); err != nil {
+		t.Fatalf("UpdateSellerOrderFulfillment() error = %v", err)
+	}
+
+	unfulfilled := true
+	orders, err = client.GetOrders(ctx, manapool.OrdersOptions{IsUnfulfilled: &unfulfilled})
+	if err != nil {
+		t.Fatalf("GetOrders() error = %v", err)
+	}
+	if len(orders.Orders) != 1 || orders.Orders[0].ID != "old" {
+		t.Errorf("unfulfilled orders = %+v", orders.Orders)
+	}
+
+	order, err := client.GetSellerOrder(ctx, "new")
+	if err != nil {
+		t.Fatalf("GetSellerOrder() error = %v", err)
+	}
+	if len(order.Order.Fulfillments) != 1 || *order.Order.LatestFulfillmentStatus != "shipped" {
+		t.Errorf("order = %+v", order.Order)
+	}
+}
+
+func TestServer_InjectFault(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	client := srv.Client()
+
+	srv.InjectFault(Fault{Method: http.MethodGet, Path: "/account", Status: http.StatusServiceUnavailable, Times: 2})
+	for i := 0; i < 2; i++ {
+		_, err := client.GetSellerAccount(ctx)
+		var apiErr *manapool.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("call %d error = %v, want 503", i, err)
+		}
+	}
+	if _, err := client.GetSellerAccount(ctx); err != nil {
+		t.Errorf("GetSellerAccount() after fault error = %v", err)
+	}
+
+	srv.InjectFault(Fault{})
+	if _, err := client.GetSellerAccount(ctx); err == nil {
+		t.Error("persistent fault did not fail request")
+	}
+	srv.ClearFaults()
+	if _, err := client.GetSellerAccount(ctx); err != nil {
+		t.Errorf("GetSellerAccount() after ClearFaults error = %v", err)
+	}
+
+	// A client with retries enabled recovers from a single transient fault.
+	srv.InjectFault(Fault{Status: http.StatusBadGateway, Times: 1})
+	retrying := srv.Client(manapool.WithRetry(1, time.Millisecond))
+	if _, err := retrying.GetSellerAccount(ctx); err != nil {
+		t.Errorf("retrying client error = %v", err)
+	}
+}
+
+func TestServer_Credentials(t *testing.T) {
+	srv := NewServer(WithCredentials("secret", "me@example.com"))
+	defer srv.Close()
+
+	ctx := context.Background()
+	if _, err := srv.Client().GetSellerAccount(ctx); err != nil {
+		t.Fatalf("Client() with matching credentials error = %v", err)
+	}
+
+	bad := manapool.NewClient("wrong", "me@example.com", manapool.WithBaseURL(srv.URL+"/"), manapool.WithRetry(0, 0))
+	_, err := bad.GetSellerAccount(ctx)
+	var apiErr *manapool.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsUnauthorized() {
+		t.Errorf("error = %v, want 401", err)
+	}
+}
+
+func TestServer_Latency(t *testing.T) {
+	srv := NewServer(WithLatency(50 * time.Millisecond))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := srv.Client().GetSellerAccount(ctx); err == nil {
+		t.Error("request did not time out")
+	}
+
+	srv.SetLatency(0)
+	if _, err := srv.Client().GetSellerAccount(context.Background()); err != nil {
+		t.Errorf("GetSellerAccount() error = %v", err)
+	}
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	srv := NewServer(WithRateLimit(2, time.Hour))
+	defer srv.Close()
+
+	ctx := context.Background()
+	client := srv.Client()
+	var lastErr error
+	for i := 0; i < 3; i++ {
+		_, lastErr = client.GetSellerAccount(ctx)
+	}
+	var apiErr *manapool.APIError
+	if !errors.As(lastErr, &apiErr) || !apiErr.IsRateLimited() {
+		t.Fatalf("third request error = %v, want 429", lastErr)
+	}
+	if got := apiErr.Response.Header.Get("Retry-After"); got == "" {
+		t.Error("Retry-After header missing")
+	}
+}
+
+func TestServer_RequestsAndNotFound(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	client := srv.Client()
+	_, _ = client.GetSellerInventory(ctx, manapool.InventoryOptions{Limit: 10})
+	_, err := client.GetWebhooks(ctx, "")
+
+	var apiErr *manapool.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("unsupported endpoint error = %v, want 404", err)
+	}
+
+	requests := srv.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("Requests() = %d, want 2", len(requests))
+	}
+	if got := fmt.Sprint(requests[0].Method, " ", requests[0].Path, " ", requests[0].Query.Get("limit")); got != "GET /seller/inventory 10" {
+		t.Errorf("first request = %s", got)
+	}
+}
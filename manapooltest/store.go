@@ -0,0 +1,315 @@
+package manapooltest
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+// store is the in-memory seller state shared by Server and FakeClient.
+type store struct {
+	mu        sync.Mutex
+	account   manapool.Account
+	inventory []manapool.InventoryItem
+	orders    []manapool.OrderDetails
+	nextID    int
+	now       func() time.Time
+}
+
+func newStore() *store {
+	return &store{
+		account: manapool.Account{
+			Username:       "test-seller",
+			Email:          "seller@example.com",
+			Verified:       true,
+			SinglesLive:    true,
+			SealedLive:     true,
+			PayoutsEnabled: true,
+		},
+		now: time.Now,
+	}
+}
+
+func (s *store) getAccount() manapool.Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.account
+}
+
+func (s *store) setAccount(account manapool.Account) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.account = account
+}
+
+func (s *store) updateAccount(update manapool.SellerAccountUpdate) manapool.Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if update.SinglesLive != nil {
+		s.account.SinglesLive = *update.SinglesLive
+	}
+	if update.SealedLive != nil {
+		s.account.SealedLive = *update.SealedLive
+	}
+	return s.account
+}
+
+func (s *store) setInventory(items []manapool.InventoryItem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inventory = make([]manapool.InventoryItem, 0, len(items))
+	for _, item := range items {
+		s.inventory = append(s.inventory, s.normalize(item))
+	}
+}
+
+func (s *store) addInventory(items []manapool.InventoryItem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, item := range items {
+		s.inventory = append(s.inventory, s.normalize(item))
+	}
+}
+
+// normalize fills in fields the real API always returns. The caller must
+// hold s.mu.
+func (s *store) normalize(item manapool.InventoryItem) manapool.InventoryItem {
+	if item.ID == "" {
+		s.nextID++
+		item.ID = fmt.Sprintf("inv-%d", s.nextID)
+	}
+	if item.ProductType == "" {
+		item.ProductType = "mtg_single"
+	}
+	if item.ProductID == "" {
+		item.ProductID = "product-" + item.ID
+	}
+	if item.EffectiveAsOf.IsZero() {
+		item.EffectiveAsOf = manapool.Timestamp{Time: s.now().UTC()}
+	}
+	return item
+}
+
+func (s *store) allInventory() []manapool.InventoryItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]manapool.InventoryItem(nil), s.inventory...)
+}
+
+func (s *store) listInventory(limit, offset int) manapool.InventoryResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	total := len(s.inventory)
+	start, end := offset, offset+limit
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+	page := append([]manapool.InventoryItem{}, s.inventory[start:end]...)
+	return manapool.InventoryResponse{
+		Inventory: page,
+		Pagination: manapool.Pagination{
+			Total:    total,
+			Returned: len(page),
+			Offset:   offset,
+			Limit:    limit,
+		},
+	}
+}
+
+// indexBySKU returns the index of the item with sku. The caller must hold s.mu.
+func (s *store) indexBySKU(sku int) int {
+	for i, item := range s.inventory {
+		if item.Product.TCGPlayerSKU != nil && *item.Product.TCGPlayerSKU == sku {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *store) getBySKU(sku int) (manapool.InventoryItem, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.indexBySKU(sku)
+	if i < 0 {
+		return manapool.InventoryItem{}, false
+	}
+	return s.inventory[i], true
+}
+
+func (s *store) getByID(id string) (manapool.InventoryItem, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, item := range s.inventory {
+		if item.ID == id {
+			return item, true
+		}
+	}
+	return manapool.InventoryItem{}, false
+}
+
+func (s *store) updateBySKU(sku int, update manapool.InventoryUpdateRequest) (manapool.InventoryItem, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.indexBySKU(sku)
+	if i < 0 {
+		return manapool.InventoryItem{}, false
+	}
+	s.inventory[i].PriceCents = update.PriceCents
+	s.inventory[i].Quantity = update.Quantity
+	s.inventory[i].EffectiveAsOf = manapool.Timestamp{Time: s.now().UTC()}
+	return s.inventory[i], true
+}
+
+func (s *store) deleteBySKU(sku int) (manapool.InventoryItem, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.indexBySKU(sku)
+	if i < 0 {
+		return manapool.InventoryItem{}, false
+	}
+	item := s.inventory[i]
+	s.inventory = append(s.inventory[:i], s.inventory[i+1:]...)
+	return item, true
+}
+
+func (s *store) upsertBySKU(items []manapool.InventoryBulkItemBySKU) []manapool.InventoryItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]manapool.InventoryItem, 0, len(items))
+	for _, bulk := range items {
+		i := s.indexBySKU(bulk.TCGPlayerSKU)
+		if i < 0 {
+			sku := bulk.TCGPlayerSKU
+			s.inventory = append(s.inventory, s.normalize(manapool.InventoryItem{
+				Product: manapool.Product{Type: "mtg_single", TCGPlayerSKU: &sku},
+			}))
+			i = len(s.inventory) - 1
+		}
+		s.inventory[i].PriceCents = bulk.PriceCents
+		s.inventory[i].Quantity = bulk.Quantity
+		s.inventory[i].EffectiveAsOf = manapool.Timestamp{Time: s.now().UTC()}
+		result = append(result, s.inventory[i])
+	}
+	return result
+}
+
+func (s *store) upsertByProduct(items []manapool.InventoryBulkItemByProduct) []manapool.InventoryItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]manapool.InventoryItem, 0, len(items))
+	for _, bulk := range items {
+		i := -1
+		for j, item := range s.inventory {
+			if item.ProductType == bulk.ProductType && item.ProductID == bulk.ProductID {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			s.inventory = append(s.inventory, s.normalize(manapool.InventoryItem{
+				ProductType: bulk.ProductType,
+				ProductID:   bulk.ProductID,
+				Product:     manapool.Product{Type: bulk.ProductType},
+			}))
+			i = len(s.inventory) - 1
+		}
+		s.inventory[i].PriceCents = bulk.PriceCents
+		s.inventory[i].Quantity = bulk.Quantity
+		s.inventory[i].EffectiveAsOf = manapool.Timestamp{Time: s.now().UTC()}
+		result = append(result, s.inventory[i])
+	}
+	return result
+}
+
+func (s *store) addOrders(orders []manapool.OrderDetails) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, order := range orders {
+		if order.ID == "" {
+			s.nextID++
+			order.ID = fmt.Sprintf("order-%d", s.nextID)
+		}
+		if order.CreatedAt.IsZero() {
+			order.CreatedAt = manapool.Timestamp{Time: s.now().UTC()}
+		}
+		s.orders = append(s.orders, order)
+	}
+	sort.SliceStable(s.orders, func(i, j int) bool {
+		return s.orders[i].CreatedAt.After(s.orders[j].CreatedAt.Time)
+	})
+}
+
+func (s *store) listOrders(opts manapool.OrdersOptions) []manapool.OrderSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var summaries []manapool.OrderSummary
+	for _, order := range s.orders {
+		fulfilled := order.FulfillmentStatus().IsFulfilled()
+		switch {
+		case opts.Since != nil && order.CreatedAt.Before(opts.Since.Time):
+			continue
+		case opts.IsFulfilled != nil && *opts.IsFulfilled != fulfilled:
+			continue
+		case opts.IsUnfulfilled != nil && *opts.IsUnfulfilled == fulfilled:
+			continue
+		case opts.HasFulfillments != nil && *opts.HasFulfillments != (len(order.Fulfillments) > 0):
+			continue
+		case opts.Label != "" && order.Label != opts.Label:
+			continue
+		}
+		summaries = append(summaries, order.OrderSummary)
+	}
+
+	if opts.Offset >= len(summaries) {
+		return []manapool.OrderSummary{}
+	}
+	summaries = summaries[opts.Offset:]
+	if opts.Limit > 0 && opts.Limit < len(summaries) {
+		summaries = summaries[:opts.Limit]
+	}
+	return summaries
+}
+
+func (s *store) getOrder(id string) (manapool.OrderDetails, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, order := range s.orders {
+		if order.ID == id {
+			return order, true
+		}
+	}
+	return manapool.OrderDetails{}, false
+}
+
+func (s *store) updateFulfillment(id string, req manapool.OrderFulfillmentRequest) (manapool.OrderFulfillment, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.orders {
+		if s.orders[i].ID != id {
+			continue
+		}
+		fulfillment := manapool.OrderFulfillment{
+			Status:              req.Status,
+			TrackingCompany:     req.TrackingCompany,
+			TrackingNumber:      req.TrackingNumber,
+			TrackingURL:         req.TrackingURL,
+			InTransitAt:         req.InTransitAt,
+			EstimatedDeliveryAt: req.EstimatedDeliveryAt,
+			DeliveredAt:         req.DeliveredAt,
+		}
+		s.orders[i].Fulfillments = append(s.orders[i].Fulfillments, fulfillment)
+		s.orders[i].LatestFulfillmentStatus = req.Status
+		return fulfillment, true
+	}
+	return manapool.OrderFulfillment{}, false
+}
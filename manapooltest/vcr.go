@@ -0,0 +1,384 @@
+package manapooltest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// RecorderMode selects whether a Recorder talks to the network.
+type RecorderMode int
+
+const (
+	// ModeReplay serves responses from the fixture file and fails requests
+	// that have no recorded interaction.
+	ModeReplay RecorderMode = iota
+
+	// ModeRecord sends requests to the real transport and records them.
+	// Call Save to write the fixture file.
+	ModeRecord
+
+	// ModeAuto replays if the fixture file exists and records otherwise.
+	ModeAuto
+)
+
+// redacted replaces scrubbed secrets in fixtures.
+const redacted = "[REDACTED]"
+
+// minSecretLength is the shortest header value that is also redacted from
+// URLs and bodies; shorter values would corrupt unrelated text.
+const minSecretLength = 8
+
+// defaultScrubHeaders are removed from fixtures because they carry credentials.
+var defaultScrubHeaders = []string{
+	"X-ManaPool-Access-Token",
+	"X-ManaPool-Email",
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// Cassette is the fixture file format: an ordered list of interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the sanitized form of a recorded request. URL holds the
+// path and query only, so fixtures do not depend on the API host.
+type RecordedRequest struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 bool        `json:"body_base64,omitempty"`
+}
+
+// RecordedResponse is the sanitized form of a recorded response.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 bool        `json:"body_base64,omitempty"`
+}
+
+// Matcher reports whether a recorded request matches a live one. body is the
+// live request body.
+type Matcher func(req *http.Request, body []byte, recorded RecordedRequest) bool
+
+// DefaultMatcher matches on method, path and query, and body.
+func DefaultMatcher(req *http.Request, body []byte, recorded RecordedRequest) bool {
+	if req.Method != recorded.Method || req.URL.RequestURI() != recorded.URL {
+		return false
+	}
+	recordedBody, err := decodeBody(recorded.Body, recorded.BodyBase64)
+	return err == nil && bytes.Equal(body, recordedBody)
+}
+
+// Recorder is an http.RoundTripper that records API interactions to a
+// fixture file and replays them deterministically.
+//
+// Credentials are scrubbed before anything is written: the ManaPool token
+// and email headers (and other auth headers) are replaced with
+// "[REDACTED]", and their values (if at least 8 characters long) are also
+// redacted wherever they appear in URLs and bodies. Header values are not
+// part of replay matching, so tests can replay with dummy credentials.
+//
+// Example:
+//
+//	rec, err := manapooltest.NewRecorder("testdata/account.json", manapooltest.ModeAuto)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defer func() {
+//	    if err := rec.Save(); err != nil {
+//	        t.Fatal(err)
+//	    }
+//	}()
+//	client := manapool.NewClient(token, email, manapool.WithHTTPClient(rec.HTTPClient()))
+type Recorder struct {
+	path      string
+	mode      RecorderMode
+	transport http.RoundTripper
+	matcher   Matcher
+	scrub     []string
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// RecorderOption configures a Recorder.
+type RecorderOption func(*Recorder)
+
+// WithTransport sets the transport used when recording (default:
+// http.DefaultTransport).
+func WithTransport(rt http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = rt
+	}
+}
+
+// WithMatcher replaces DefaultMatcher for replay.
+func WithMatcher(m Matcher) RecorderOption {
+	return func(r *Recorder) {
+		r.matcher = m
+	}
+}
+
+// WithScrubHeaders adds headers whose values are redacted in fixtures.
+func WithScrubHeaders(names ...string) RecorderOption {
+	return func(r *Recorder) {
+		r.scrub = append(r.scrub, names...)
+	}
+}
+
+// NewRecorder creates a Recorder for the fixture at path. In replay mode the
+// fixture must exist.
+func NewRecorder(path string, mode RecorderMode, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		matcher:   DefaultMatcher,
+		scrub:     append([]string(nil), defaultScrubHeaders...),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode == ModeAuto {
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		} else if errors.Is(err, fs.ErrNotExist) {
+			r.mode = ModeRecord
+		} else {
+			return nil, fmt.Errorf("failed to stat fixture: %w", err)
+		}
+	}
+
+	if r.mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to decode fixture %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Mode returns the effective mode (ModeAuto is resolved at construction).
+func (r *Recorder) Mode() RecorderMode {
+	return r.mode
+}
+
+// HTTPClient returns an *http.Client that uses the recorder as its transport.
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Interactions returns a copy of the recorded or loaded interactions.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body, r.secrets(req.Header))
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !r.matcher(req, body, interaction.Request) {
+			continue
+		}
+		r.used[i] = true
+
+		respBody, err := decodeBody(interaction.Response.Body, interaction.Response.BodyBase64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode recorded response body: %w", err)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(respBody)),
+			ContentLength: int64(len(respBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction matches %s %s", req.Method, req.URL.RequestURI())
+}
+
+func (r *Recorder) record(req *http.Request, body []byte, secrets []string) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+
+	resp, err := r.transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Store plain bodies so fixtures are readable and diffable.
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		zr, err := gzip.NewReader(bytes.NewReader(respBody))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress response: %w", err)
+		}
+		respBody, err = io.ReadAll(zr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress response: %w", err)
+		}
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = int64(len(respBody))
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	reqRecord := RecordedRequest{
+		Method: req.Method,
+		URL:    scrubString(req.URL.RequestURI(), secrets),
+		Header: r.scrubHeader(req.Header, secrets),
+	}
+	reqRecord.Body, reqRecord.BodyBase64 = encodeBody([]byte(scrubString(string(body), secrets)))
+
+	respRecord := RecordedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     r.scrubHeader(resp.Header, secrets),
+	}
+	respRecord.Body, respRecord.BodyBase64 = encodeBody([]byte(scrubString(string(respBody), secrets)))
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{Request: reqRecord, Response: respRecord})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Save writes recorded interactions to the fixture file, creating parent
+// directories as needed. It does nothing in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+
+	if dir := filepath.Dir(r.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create fixture directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// secrets returns the values of scrubbed headers present on a request,
+// longest first so overlapping values are fully replaced.
+func (r *Recorder) secrets(header http.Header) []string {
+	var secrets []string
+	for _, name := range r.scrub {
+		for _, value := range header.Values(name) {
+			if len(value) >= minSecretLength && value != redacted {
+				secrets = append(secrets, value)
+			}
+		}
+	}
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+	return secrets
+}
+
+func (r *Recorder) scrubHeader(header http.Header, secrets []string) http.Header {
+	out := make(http.Header, len(header))
+	for name, values := range header {
+		scrubbed := make([]string, len(values))
+		for i, value := range values {
+			scrubbed[i] = scrubString(value, secrets)
+		}
+		out[name] = scrubbed
+	}
+	for _, name := range r.scrub {
+		if len(out.Values(name)) > 0 {
+			out.Set(name, redacted)
+		}
+	}
+	return out
+}
+
+func scrubString(s string, secrets []string) string {
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return s
+}
+
+func encodeBody(body []byte) (string, bool) {
+	if utf8.Valid(body) {
+		return string(body), false
+	}
+	return base64.StdEncoding.EncodeToString(body), true
+}
+
+func decodeBody(body string, isBase64 bool) ([]byte, error) {
+	if !isBase64 {
+		return []byte(body), nil
+	}
+	return base64.StdEncoding.DecodeString(body)
+}
@@ -0,0 +1,173 @@
+package manapooltest
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool"
+)
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures", "account.json")
+	srv := NewServer(
+		WithCredentials("live-secret-token", "real@seller.test"),
+		WithAccount(manapool.Account{Username: "shop", Email: "real@seller.test"}),
+		WithInventory(skuItem(1, 100, 1)),
+	)
+
+	rec, err := NewRecorder(path, ModeAuto)
+	if err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+	if rec.Mode() != ModeRecord {
+		t.Fatalf("Mode() = %v, want ModeRecord", rec.Mode())
+	}
+
+	ctx := context.Background()
+	live := srv.Client(manapool.WithHTTPClient(rec.HTTPClient()))
+	if _, err := live.GetSellerAccount(ctx); err != nil {
+		t.Fatalf("recording GetSellerAccount() error = %v", err)
+	}
+	if _, err := live.UpdateSellerInventoryBySKU(ctx, 1, manapool.InventoryUpdateRequest{PriceCents: 250, Quantity: 2}); err != nil {
+		t.Fatalf("recording UpdateSellerInventoryBySKU() error = %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	srv.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	fixture := string(data)
+	for _, secret := range []string{"live-secret-token", "real@seller.test"} {
+		if strings.Contains(fixture, secret) {
+			t.Errorf("fixture contains secret %q", secret)
+		}
+	}
+	if !strings.Contains(fixture, redacted) {
+		t.Error("fixture has no redaction markers")
+	}
+
+	replay, err := NewRecorder(path, ModeAuto)
+	if err != nil {
+		t.Fatalf("NewRecorder(replay) error = %v", err)
+	}
+	if replay.Mode() != ModeReplay || len(replay.Interactions()) != 2 {
+		t.Fatalf("replay mode = %v with %d interactions", replay.Mode(), len(replay.Interactions()))
+	}
+
+	client := manapool.NewClient("dummy", "dummy@example.com",
+		manapool.WithBaseURL("http://fixtures.invalid/"),
+		manapool.WithHTTPClient(replay.HTTPClient()),
+		manapool.WithRetry(0, 0),
+	)
+	account, err := client.GetSellerAccount(ctx)
+	if err != nil {
+		t.Fatalf("replayed GetSellerAccount() error = %v", err)
+	}
+	if account.Username != "shop" || account.Email != redacted {
+		t.Errorf("replayed account = %+v", account)
+	}
+	listing, err := client.UpdateSellerInventoryBySKU(ctx, 1, manapool.InventoryUpdateRequest{PriceCents: 250, Quantity: 2})
+	if err != nil {
+		t.Fatalf("replayed UpdateSellerInventoryBySKU() error = %v", err)
+	}
+	if listing.Inventory.PriceCents != 250 {
+		t.Errorf("replayed PriceCents = %d, want 250", listing.Inventory.PriceCents)
+	}
+
+	// Each interaction is replayed once, and bodies must match.
+	if _, err := client.GetSellerAccount(ctx); err == nil {
+		t.Error("exhausted interaction replayed twice")
+	}
+	if err := replay.Save(); err != nil {
+		t.Errorf("Save() in replay mode error = %v", err)
+	}
+}
+
+func TestRecorder_ReplayMismatchedBody(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	fixture := `{"interactions":[{"request":{"method":"PUT","url":"/seller/inventory/tcgsku/1","body":"{\"price_cents\":1,\"quantity\":1}\n"},"response":{"status_code":200,"body":"{}"}}]}`
+	if err := os.WriteFile(path, []byte(fixture), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+	client := manapool.NewClient("t", "e", manapool.WithBaseURL("http://fixtures.invalid/"),
+		manapool.WithHTTPClient(rec.HTTPClient()), manapool.WithRetry(0, 0))
+
+	_, err = client.UpdateSellerInventoryBySKU(context.Background(), 1, manapool.InventoryUpdateRequest{PriceCents: 2, Quantity: 1})
+	if err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("error = %v, want no recorded interaction", err)
+	}
+	if _, err := client.UpdateSellerInventoryBySKU(context.Background(), 1, manapool.InventoryUpdateRequest{PriceCents: 1, Quantity: 1}); err != nil {
+		t.Errorf("matching request error = %v", err)
+	}
+}
+
+func TestRecorder_GzipAndBinaryBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/binary" {
+			_, _ = w.Write([]byte{0xff, 0xfe, 0x00})
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		_, _ = zw.Write([]byte(`{"username":"zipped"}`))
+		_ = zw.Close()
+	}))
+	defer server.Close()
+
+	rec, err := NewRecorder(filepath.Join(t.TempDir(), "f.json"), ModeRecord)
+	if err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+	client := manapool.NewClient("t", "e", manapool.WithBaseURL(server.URL+"/"), manapool.WithHTTPClient(rec.HTTPClient()))
+	account, err := client.GetSellerAccount(context.Background())
+	if err != nil || account.Username != "zipped" {
+		t.Fatalf("GetSellerAccount() = %+v, %v", account, err)
+	}
+
+	resp, err := rec.HTTPClient().Get(server.URL + "/binary")
+	if err != nil {
+		t.Fatalf("Get(binary) error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	interactions := rec.Interactions()
+	if len(interactions) != 2 {
+		t.Fatalf("interactions = %d, want 2", len(interactions))
+	}
+	if got := interactions[0].Response; got.Body != `{"username":"zipped"}` || got.Header.Get("Content-Encoding") != "" {
+		t.Errorf("gzip response recorded as %+v", got)
+	}
+	if !interactions[1].Response.BodyBase64 {
+		t.Error("binary body not base64 encoded")
+	}
+}
+
+func TestNewRecorder_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewRecorder(filepath.Join(dir, "missing.json"), ModeReplay); err == nil {
+		t.Error("NewRecorder(missing, ModeReplay) error = nil")
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewRecorder(bad, ModeReplay); err == nil {
+		t.Error("NewRecorder(bad json) error = nil")
+	}
+}
@@ -0,0 +1,209 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// MarketPricePoint is the lowest in-stock listing price for one variant of a
+// product. Sealed products have no condition or finish.
+type MarketPricePoint struct {
+	ConditionID       string
+	FinishID          string
+	LanguageID        string
+	LowPriceCents     int
+	AvailableQuantity int
+}
+
+// MarketPrice holds the in-stock price points for a product.
+type MarketPrice struct {
+	ProductType        string
+	ProductID          string
+	Name               string
+	SetCode            string
+	Number             string
+	ScryfallID         string
+	TCGPlayerProductID *int
+
+	Points []MarketPricePoint
+
+	// AsOf is the time the underlying price export was generated
+	AsOf Timestamp
+}
+
+// Lowest returns the cheapest point matching the given condition, finish,
+// and language IDs. An empty ID matches any value. It returns false if no
+// point matches.
+func (m *MarketPrice) Lowest(conditionID, finishID, languageID string) (MarketPricePoint, bool) {
+	var best MarketPricePoint
+	found := false
+	for _, point := range m.Points {
+		if (conditionID != "" && point.ConditionID != conditionID) ||
+			(finishID != "" && point.FinishID != finishID) ||
+			(languageID != "" && point.LanguageID != languageID) {
+			continue
+		}
+		if !found || point.LowPriceCents < best.LowPriceCents {
+			best, found = point, true
+		}
+	}
+	return best, found
+}
+
+// MarketPriceIndex indexes the variant and sealed price exports by product ID.
+type MarketPriceIndex struct {
+	byProduct map[string]*MarketPrice
+}
+
+// NewMarketPriceIndex builds an index from price exports. Either list may be nil.
+func NewMarketPriceIndex(variants *VariantPricesList, sealed *SealedPricesList) *MarketPriceIndex {
+	index := &MarketPriceIndex{byProduct: make(map[string]*MarketPrice)}
+
+	if variants != nil {
+		for _, listing := range variants.Data {
+			price := index.entry(listing.ProductID, variants.Meta.AsOf)
+			price.ProductType = listing.ProductType
+			price.Name = listing.Name
+			price.SetCode = listing.SetCode
+			price.Number = listing.Number
+			price.ScryfallID = listing.ScryfallID
+			price.TCGPlayerProductID = listing.TCGPlayerProductID
+
+			point := MarketPricePoint{
+				LanguageID:        listing.LanguageID,
+				LowPriceCents:     listing.LowPrice,
+				AvailableQuantity: listing.AvailableQuantity,
+			}
+			if listing.ConditionID != nil {
+				point.ConditionID = *listing.ConditionID
+			}
+			if listing.FinishID != nil {
+				point.FinishID = *listing.FinishID
+			}
+			price.Points = append(price.Points, point)
+		}
+	}
+
+	if sealed != nil {
+		for _, listing := range sealed.Data {
+			price := index.entry(listing.ProductID, sealed.Meta.AsOf)
+			price.ProductType = listing.ProductType
+			price.Name = listing.Name
+			price.SetCode = listing.SetCode
+			price.TCGPlayerProductID = listing.TCGPlayerProductID
+			price.Points = append(price.Points, MarketPricePoint{
+				LanguageID:        listing.LanguageID,
+				LowPriceCents:     listing.LowPrice,
+				AvailableQuantity: listing.AvailableQuantity,
+			})
+		}
+	}
+
+	return index
+}
+
+func (i *MarketPriceIndex) entry(productID string, asOf Timestamp) *MarketPrice {
+	price, ok := i.byProduct[productID]
+	if !ok {
+		price = &MarketPrice{ProductID: productID, AsOf: asOf}
+		i.byProduct[productID] = price
+	}
+	return price
+}
+
+// Len returns the number of products in the index.
+func (i *MarketPriceIndex) Len() int {
+	return len(i.byProduct)
+}
+
+// Get returns the prices for productID.
+func (i *MarketPriceIndex) Get(productID string) (*MarketPrice, bool) {
+	price, ok := i.byProduct[productID]
+	return price, ok
+}
+
+// MarketPrice returns the lowest in-stock price for an inventory item,
+// matching the item's condition, finish, and language when it is a single.
+// Its signature matches MarketPriceFunc, so an index can be passed directly
+// to NewPriceRecorder or used as a repricing price source.
+func (i *MarketPriceIndex) MarketPrice(_ context.Context, item InventoryItem) (int, bool, error) {
+	price, ok := i.byProduct[item.ProductID]
+	if !ok {
+		return 0, false, nil
+	}
+
+	var point MarketPricePoint
+	if single := item.Product.Single; single != nil {
+		point, ok = price.Lowest(single.ConditionID, single.FinishID, single.LanguageID)
+	} else {
+		point, ok = price.Lowest("", "", "")
+	}
+	return point.LowPriceCents, ok, nil
+}
+
+// LoadMarketPriceIndex downloads the variant and sealed price exports and
+// indexes them by product ID.
+//
+// The exports cover every in-stock product, so prefer loading an index once
+// and reusing it over calling GetMarketPrice in a loop.
+//
+// Example:
+//
+//	index, err := client.LoadMarketPriceIndex(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	cents, ok, _ := index.MarketPrice(ctx, item)
+func (c *Client) LoadMarketPriceIndex(ctx context.Context) (*MarketPriceIndex, error) {
+	variants, err := c.GetVariantPrices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load market prices: %w", err)
+	}
+	sealed, err := c.GetSealedPrices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load market prices: %w", err)
+	}
+	return NewMarketPriceIndex(variants, sealed), nil
+}
+
+// GetMarketPrices returns the in-stock price points for each of productIDs.
+// Products with no in-stock listings are omitted from the result.
+func (c *Client) GetMarketPrices(ctx context.Context, productIDs []string) (map[string]*MarketPrice, error) {
+	if len(productIDs) == 0 {
+		return nil, NewValidationError("productIDs", "at least one product ID is required")
+	}
+
+	index, err := c.LoadMarketPriceIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]*MarketPrice, len(productIDs))
+	for _, id := range productIDs {
+		if price, ok := index.Get(id); ok {
+			prices[id] = price
+		}
+	}
+	return prices, nil
+}
+
+// GetMarketPrice returns the in-stock price points for a product. If the
+// product has no in-stock listings, the error is an *APIError for which
+// IsNotFound reports true.
+func (c *Client) GetMarketPrice(ctx context.Context, productID string) (*MarketPrice, error) {
+	if strings.TrimSpace(productID) == "" {
+		return nil, NewValidationError("productID", "product ID is required")
+	}
+
+	prices, err := c.GetMarketPrices(ctx, []string{productID})
+	if err != nil {
+		return nil, err
+	}
+	price, ok := prices[productID]
+	if !ok {
+		return nil, NewAPIError(http.StatusNotFound, "no in-stock prices for product "+productID)
+	}
+	return price, nil
+}
@@ -0,0 +1,163 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newMarketPriceServer(t *testing.T, requests *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		switch r.URL.Path {
+		case "/prices/variants":
+			_, _ = w.Write([]byte(`{"meta":{"as_of":"2026-01-01T00:00:00Z"},"data":[
+				{"product_type":"mtg_single","product_id":"bolt","name":"Lightning Bolt","set_code":"LEA","number":"161","language_id":"EN","condition_id":"NM","finish_id":"NF","low_price":1000,"available_quantity":3},
+				{"product_type":"mtg_single","product_id":"bolt","name":"Lightning Bolt","set_code":"LEA","number":"161","language_id":"EN","condition_id":"LP","finish_id":"NF","low_price":800,"available_quantity":1},
+				{"product_type":"mtg_single","product_id":"bolt","name":"Lightning Bolt","set_code":"LEA","number":"161","language_id":"EN","condition_id":"NM","finish_id":"FO","low_price":5000,"available_quantity":1}
+			]}`))
+		case "/prices/sealed":
+			_, _ = w.Write([]byte(`{"meta":{"as_of":"2026-01-01T00:00:00Z"},"data":[
+				{"product_type":"mtg_sealed","product_id":"box","name":"Ice Age Booster Box","set_code":"ICE","language_id":"EN","low_price":29999,"available_quantity":2}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestClient_GetMarketPrice(t *testing.T) {
+	var requests int32
+	server := newMarketPriceServer(t, &requests)
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	price, err := client.GetMarketPrice(ctx, "bolt")
+	if err != nil {
+		t.Fatalf("GetMarketPrice() error = %v", err)
+	}
+	if price.Name != "Lightning Bolt" || len(price.Points) != 3 {
+		t.Fatalf("price = %+v", price)
+	}
+	if point, ok := price.Lowest("", "NF", ""); !ok || point.LowPriceCents != 800 || point.ConditionID != "LP" {
+		t.Errorf("Lowest(NF) = %+v, %v", point, ok)
+	}
+	if point, ok := price.Lowest("NM", "FO", "EN"); !ok || point.LowPriceCents != 5000 {
+		t.Errorf("Lowest(NM, FO) = %+v, %v", point, ok)
+	}
+	if _, ok := price.Lowest("DMG", "", ""); ok {
+		t.Error("Lowest(DMG) returned ok")
+	}
+
+	_, err = client.GetMarketPrice(ctx, "missing")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("GetMarketPrice(missing) error = %v, want not found", err)
+	}
+
+	var valErr *ValidationError
+	if _, err := client.GetMarketPrice(ctx, " "); !errors.As(err, &valErr) {
+		t.Errorf("GetMarketPrice(blank) error = %v, want ValidationError", err)
+	}
+}
+
+func TestClient_GetMarketPrices(t *testing.T) {
+	var requests int32
+	server := newMarketPriceServer(t, &requests)
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	prices, err := client.GetMarketPrices(context.Background(), []string{"bolt", "box", "missing"})
+	if err != nil {
+		t.Fatalf("GetMarketPrices() error = %v", err)
+	}
+	if len(prices) != 2 {
+		t.Fatalf("prices = %d, want 2", len(prices))
+	}
+	if box := prices["box"]; box.ProductType != "mtg_sealed" || box.Points[0].LowPriceCents != 29999 {
+		t.Errorf("box = %+v", box)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+
+	var valErr *ValidationError
+	if _, err := client.GetMarketPrices(context.Background(), nil); !errors.As(err, &valErr) {
+		t.Errorf("GetMarketPrices(nil) error = %v, want ValidationError", err)
+	}
+}
+
+func TestClient_LoadMarketPriceIndex_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/prices/sealed" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"meta":{"as_of":"2026-01-01T00:00:00Z"},"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	_, err := client.LoadMarketPriceIndex(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("error = %v, want 400 APIError", err)
+	}
+}
+
+func TestMarketPriceIndex_MarketPrice(t *testing.T) {
+	var requests int32
+	server := newMarketPriceServer(t, &requests)
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	index, err := client.LoadMarketPriceIndex(ctx)
+	if err != nil {
+		t.Fatalf("LoadMarketPriceIndex() error = %v", err)
+	}
+	if index.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", index.Len())
+	}
+
+	tests := []struct {
+		name   string
+		item   InventoryItem
+		want   int
+		wantOK bool
+	}{
+		{
+			name:   "single matches variant",
+			item:   InventoryItem{ProductID: "bolt", Product: Product{Single: &Single{ConditionID: "NM", FinishID: "NF", LanguageID: "EN"}}},
+			want:   1000,
+			wantOK: true,
+		},
+		{
+			name: "single without matching variant",
+			item: InventoryItem{ProductID: "bolt", Product: Product{Single: &Single{ConditionID: "HP", FinishID: "NF", LanguageID: "EN"}}},
+		},
+		{name: "sealed", item: InventoryItem{ProductID: "box"}, want: 29999, wantOK: true},
+		{name: "unknown product", item: InventoryItem{ProductID: "missing"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := index.MarketPrice(ctx, tt.item)
+			if err != nil {
+				t.Fatalf("MarketPrice() error = %v", err)
+			}
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("MarketPrice() = %d, %v; want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	// The index satisfies MarketPriceFunc.
+	var _ MarketPriceFunc = index.MarketPrice
+}
@@ -0,0 +1,308 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MarketplaceAdapter lists ManaPool inventory on another sales channel, such
+// as a web shop or another marketplace. A CrossLister calls it to mirror
+// inventory changes; adapters only translate and send.
+//
+// Listings on the other channel are identified by the adapter's own
+// external IDs, which the CrossLister keeps in a ListingLinkStore.
+type MarketplaceAdapter interface {
+	// Name identifies the channel in links, sales, and reports, such as
+	// "shopify". It must not change once listings are linked.
+	Name() string
+
+	// ListItem creates a listing for item and returns its external ID.
+	ListItem(ctx context.Context, item InventoryItem) (externalID string, err error)
+
+	// UpdatePrice sets the price of a listing.
+	UpdatePrice(ctx context.Context, externalID string, priceCents int) error
+
+	// UpdateQuantity sets the available quantity of a listing.
+	UpdateQuantity(ctx context.Context, externalID string, quantity int) error
+
+	// Delist removes or archives a listing.
+	Delist(ctx context.Context, externalID string) error
+
+	// FetchSales returns sales made on the channel at or after since.
+	FetchSales(ctx context.Context, since time.Time) ([]MarketplaceSale, error)
+}
+
+// MarketplaceSale is a sale made on another channel.
+type MarketplaceSale struct {
+	// Marketplace is the adapter's Name
+	Marketplace string
+
+	// ExternalID is the channel's listing, and InventoryID the linked
+	// ManaPool listing, filled in by CrossLister.FetchSales ("" if not
+	// linked)
+	ExternalID  string
+	InventoryID string
+
+	// OrderID is the channel's order or receipt ID
+	OrderID string
+
+	Quantity   int
+	PriceCents int
+	SoldAt     time.Time
+}
+
+// ListingLinkStore remembers which external listing mirrors each ManaPool
+// listing on each marketplace.
+//
+// Implementations must be safe for concurrent use. MemoryListingLinks is an
+// in-memory implementation; links should normally be persisted so a
+// restart does not list everything twice.
+type ListingLinkStore interface {
+	// ExternalID returns the external listing linked to a ManaPool listing.
+	ExternalID(ctx context.Context, marketplace, inventoryID string) (string, bool, error)
+
+	// InventoryID returns the ManaPool listing linked to an external listing.
+	InventoryID(ctx context.Context, marketplace, externalID string) (string, bool, error)
+
+	// Link records that externalID mirrors inventoryID, replacing any
+	// earlier link for inventoryID.
+	Link(ctx context.Context, marketplace, inventoryID, externalID string) error
+
+	// Unlink removes the link for inventoryID, if any.
+	Unlink(ctx context.Context, marketplace, inventoryID string) error
+}
+
+// MemoryListingLinks is an in-memory ListingLinkStore.
+type MemoryListingLinks struct {
+	mu         sync.RWMutex
+	byListing  map[[2]string]string
+	byExternal map[[2]string]string
+}
+
+// NewMemoryListingLinks creates an empty in-memory link store.
+func NewMemoryListingLinks() *MemoryListingLinks {
+	return &MemoryListingLinks{
+		byListing:  make(map[[2]string]string),
+		byExternal: make(map[[2]string]string),
+	}
+}
+
+// ExternalID implements ListingLinkStore.
+func (m *MemoryListingLinks) ExternalID(_ context.Context, marketplace, inventoryID string) (string, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	id, ok := m.byListing[[2]string{marketplace, inventoryID}]
+	return id, ok, nil
+}
+
+// InventoryID implements ListingLinkStore.
+func (m *MemoryListingLinks) InventoryID(_ context.Context, marketplace, externalID string) (string, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	id, ok := m.byExternal[[2]string{marketplace, externalID}]
+	return id, ok, nil
+}
+
+// Link implements ListingLinkStore.
+func (m *MemoryListingLinks) Link(_ context.Context, marketplace, inventoryID, externalID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := [2]string{marketplace, inventoryID}
+	if old, ok := m.byListing[key]; ok {
+		delete(m.byExternal, [2]string{marketplace, old})
+	}
+	m.byListing[key] = externalID
+	m.byExternal[[2]string{marketplace, externalID}] = inventoryID
+	return nil
+}
+
+// Unlink implements ListingLinkStore.
+func (m *MemoryListingLinks) Unlink(_ context.Context, marketplace, inventoryID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := [2]string{marketplace, inventoryID}
+	if old, ok := m.byListing[key]; ok {
+		delete(m.byExternal, [2]string{marketplace, old})
+		delete(m.byListing, key)
+	}
+	return nil
+}
+
+// CrossLister mirrors ManaPool inventory changes to other marketplaces.
+// Feed it the events from DiffInventory or WatchInventory, or set it as
+// SyncOptions.CrossLister to mirror every sync.
+type CrossLister struct {
+	// Filter, if set, limits which listings are mirrored, such as singles
+	// over a price. A linked listing that stops matching is delisted.
+	Filter InventoryFilter
+
+	links    ListingLinkStore
+	adapters []MarketplaceAdapter
+}
+
+// NewCrossLister creates a cross-lister that mirrors to adapters, keeping
+// external listing IDs in links.
+//
+// Example:
+//
+//	lister := manapool.NewCrossLister(links, shop)
+//	lister.Filter = func(item manapool.InventoryItem) bool {
+//	    return item.Product.Single != nil && item.PriceCents >= 2000
+//	}
+//	for event := range client.WatchInventory(ctx, 5*time.Minute) {
+//	    report := lister.Propagate(ctx, []manapool.InventoryEvent{event})
+//	    if err := report.Err(); err != nil {
+//	        log.Print(err)
+//	    }
+//	}
+func NewCrossLister(links ListingLinkStore, adapters ...MarketplaceAdapter) *CrossLister {
+	return &CrossLister{links: links, adapters: adapters}
+}
+
+// CrossListFailure records an event that could not be mirrored to one
+// marketplace.
+type CrossListFailure struct {
+	Marketplace string
+	Event       InventoryEvent
+	Err         error
+}
+
+// CrossListReport summarizes a Propagate call. Counts are per marketplace,
+// so one event mirrored to two adapters counts twice.
+type CrossListReport struct {
+	Listed   int
+	Updated  int
+	Delisted int
+	Failures []CrossListFailure
+}
+
+// Err returns an error joining all failures, or nil if every change was
+// mirrored.
+func (r *CrossListReport) Err() error {
+	if len(r.Failures) == 0 {
+		return nil
+	}
+	errs := make([]error, len(r.Failures))
+	for i, failure := range r.Failures {
+		errs[i] = fmt.Errorf("%s: listing %s: %w", failure.Marketplace, failure.Event.Item.ID, failure.Err)
+	}
+	return fmt.Errorf("%d cross-listing changes failed: %w", len(r.Failures), errors.Join(errs...))
+}
+
+// String renders a one-line summary of the report.
+func (r *CrossListReport) String() string {
+	return fmt.Sprintf("%d listed, %d updated, %d delisted, %d failed", r.Listed, r.Updated, r.Delisted, len(r.Failures))
+}
+
+// Propagate mirrors events to every adapter:
+//   - a listing that is added, or changes while not yet linked, is listed
+//     if it is in stock and matches Filter
+//   - price and quantity changes update the linked listing
+//   - a listing that is removed, sells out, or stops matching Filter is
+//     delisted
+//
+// InventoryWatchError events are ignored. Failures are collected in the
+// report rather than stopping the run, except when ctx is cancelled.
+func (l *CrossLister) Propagate(ctx context.Context, events []InventoryEvent) *CrossListReport {
+	report := &CrossListReport{}
+	for _, event := range events {
+		if event.Type == InventoryWatchError {
+			continue
+		}
+		for _, adapter := range l.adapters {
+			if ctx.Err() != nil {
+				report.Failures = append(report.Failures, CrossListFailure{Marketplace: adapter.Name(), Event: event, Err: ctx.Err()})
+				return report
+			}
+			if err := l.propagate(ctx, adapter, event, report); err != nil {
+				report.Failures = append(report.Failures, CrossListFailure{Marketplace: adapter.Name(), Event: event, Err: err})
+			}
+		}
+	}
+	return report
+}
+
+// propagate mirrors one event to one adapter.
+func (l *CrossLister) propagate(ctx context.Context, adapter MarketplaceAdapter, event InventoryEvent, report *CrossListReport) error {
+	name, item := adapter.Name(), event.Item
+	externalID, linked, err := l.links.ExternalID(ctx, name, item.ID)
+	if err != nil {
+		return fmt.Errorf("failed to look up link: %w", err)
+	}
+
+	wanted := event.Type != InventoryRemoved && item.Quantity > 0 && (l.Filter == nil || l.Filter(item))
+	switch {
+	case !wanted && !linked:
+		return nil
+	case !wanted:
+		if err := adapter.Delist(ctx, externalID); err != nil {
+			return fmt.Errorf("failed to delist: %w", err)
+		}
+		report.Delisted++
+		if err := l.links.Unlink(ctx, name, item.ID); err != nil {
+			return fmt.Errorf("failed to remove link: %w", err)
+		}
+		return nil
+	case !linked:
+		externalID, err := adapter.ListItem(ctx, item)
+		if err != nil {
+			return fmt.Errorf("failed to list: %w", err)
+		}
+		report.Listed++
+		if err := l.links.Link(ctx, name, item.ID, externalID); err != nil {
+			return fmt.Errorf("failed to store link: %w", err)
+		}
+		return nil
+	}
+
+	switch event.Type {
+	case InventoryPriceChanged:
+		if err := adapter.UpdatePrice(ctx, externalID, item.PriceCents); err != nil {
+			return fmt.Errorf("failed to update price: %w", err)
+		}
+	case InventoryQuantityChanged:
+		if err := adapter.UpdateQuantity(ctx, externalID, item.Quantity); err != nil {
+			return fmt.Errorf("failed to update quantity: %w", err)
+		}
+	default:
+		// Already listed, such as a listing re-added with the same ID.
+		return nil
+	}
+	report.Updated++
+	return nil
+}
+
+// FetchSales returns the sales made on every marketplace at or after since,
+// with InventoryID set for sales of linked listings. Sales from adapters
+// that succeed are returned even if others fail; the error joins the
+// failures.
+func (l *CrossLister) FetchSales(ctx context.Context, since time.Time) ([]MarketplaceSale, error) {
+	var (
+		sales []MarketplaceSale
+		errs  []error
+	)
+	for _, adapter := range l.adapters {
+		name := adapter.Name()
+		fetched, err := adapter.FetchSales(ctx, since)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch %s sales: %w", name, err))
+			continue
+		}
+		for _, sale := range fetched {
+			sale.Marketplace = name
+			if sale.InventoryID == "" {
+				id, ok, err := l.links.InventoryID(ctx, name, sale.ExternalID)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("failed to look up %s link: %w", name, err))
+				} else if ok {
+					sale.InventoryID = id
+				}
+			}
+			sales = append(sales, sale)
+		}
+	}
+	return sales, errors.Join(errs...)
+}
@@ -0,0 +1,159 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// StockConflict is a sale on another channel of copies ManaPool no longer
+// had, usually because both channels sold the last copy before either
+// caught up. The listing is sold out and Shortfall orders must be cancelled
+// or refunded by hand on one channel.
+type StockConflict struct {
+	Sale MarketplaceSale
+
+	// Available is the ManaPool quantity when the sale was applied; it has
+	// been set to zero
+	Available int
+
+	// Shortfall is how many copies were sold that ManaPool did not have
+	Shortfall int
+}
+
+// String describes the conflict, such as "shopify order 1001: sold 2 of
+// listing abc, ManaPool had 1".
+func (c StockConflict) String() string {
+	return fmt.Sprintf("%s order %s: sold %d of listing %s, ManaPool had %d",
+		c.Sale.Marketplace, c.Sale.OrderID, c.Sale.Quantity, c.Sale.InventoryID, c.Available)
+}
+
+// SaleFailure records a sale that could not be applied.
+type SaleFailure struct {
+	Sale MarketplaceSale
+	Err  error
+}
+
+// SalesReconcileReport summarizes a ReconcileSales call.
+type SalesReconcileReport struct {
+	// Applied are sales taken off ManaPool in full
+	Applied []MarketplaceSale
+
+	// Conflicts are sales ManaPool had too few copies for
+	Conflicts []StockConflict
+
+	// Unlinked are sales of listings not linked to ManaPool, such as
+	// products only stocked in store
+	Unlinked []MarketplaceSale
+
+	Failures []SaleFailure
+}
+
+// Err returns an error joining all failures, or nil if every linked sale
+// was applied. Conflicts are not errors; check Conflicts separately.
+func (r *SalesReconcileReport) Err() error {
+	if len(r.Failures) == 0 {
+		return nil
+	}
+	errs := make([]error, len(r.Failures))
+	for i, failure := range r.Failures {
+		errs[i] = fmt.Errorf("%s order %s: %w", failure.Sale.Marketplace, failure.Sale.OrderID, failure.Err)
+	}
+	return fmt.Errorf("%d sales could not be applied: %w", len(r.Failures), errors.Join(errs...))
+}
+
+// String renders a summary of the report, followed by one line per
+// conflict.
+func (r *SalesReconcileReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d applied, %d conflicts, %d unlinked, %d failed",
+		len(r.Applied), len(r.Conflicts), len(r.Unlinked), len(r.Failures))
+	for _, conflict := range r.Conflicts {
+		b.WriteString("\n  conflict: " + conflict.String())
+	}
+	return b.String()
+}
+
+// ReconcileSales takes sales made on other channels, such as in-store
+// point-of-sale sales from CrossLister.FetchSales, off ManaPool stock so the
+// same copies cannot sell online.
+//
+// Each linked sale decrements its listing with AdjustQuantity. If the
+// listing has fewer copies than were sold, or is gone, the other channel
+// sold stock ManaPool had already sold: the listing is set to zero and the
+// sale reported as a StockConflict. Sales without an InventoryID, or with a
+// quantity of zero or less, such as returns, are not applied.
+//
+// Sales are applied once each time this is called, so callers must not pass
+// the same sale twice; fetch from where the previous run left off. Failures
+// are collected in the report rather than stopping the run, except when ctx
+// is cancelled.
+//
+// Example:
+//
+//	sales, err := lister.FetchSales(ctx, lastRun)
+//	if err != nil {
+//	    log.Print(err)
+//	}
+//	report := client.ReconcileSales(ctx, sales)
+//	for _, conflict := range report.Conflicts {
+//	    log.Printf("oversold: %s", conflict)
+//	}
+func (c *Client) ReconcileSales(ctx context.Context, sales []MarketplaceSale) *SalesReconcileReport {
+	report := &SalesReconcileReport{}
+	for _, sale := range sales {
+		switch {
+		case ctx.Err() != nil:
+			report.Failures = append(report.Failures, SaleFailure{Sale: sale, Err: ctx.Err()})
+			return report
+		case sale.Quantity <= 0:
+			continue
+		case sale.InventoryID == "":
+			report.Unlinked = append(report.Unlinked, sale)
+			continue
+		}
+
+		conflict, err := c.applySale(ctx, sale)
+		switch {
+		case err != nil:
+			report.Failures = append(report.Failures, SaleFailure{Sale: sale, Err: err})
+		case conflict != nil:
+			report.Conflicts = append(report.Conflicts, *conflict)
+		default:
+			report.Applied = append(report.Applied, sale)
+		}
+	}
+	return report
+}
+
+// applySale decrements a listing for one sale, returning a conflict if the
+// listing was short.
+func (c *Client) applySale(ctx context.Context, sale MarketplaceSale) (*StockConflict, error) {
+	_, err := c.AdjustQuantity(ctx, sale.InventoryID, -sale.Quantity)
+	if err == nil {
+		return nil, nil
+	}
+
+	var valErr *ValidationError
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.IsNotFound():
+		return &StockConflict{Sale: sale, Shortfall: sale.Quantity}, nil
+	case !errors.As(err, &valErr) || valErr.Field != "delta":
+		return nil, fmt.Errorf("failed to adjust listing %s: %w", sale.InventoryID, err)
+	}
+
+	// Short: sell out whatever is left.
+	read, err := c.GetInventoryListing(WithRequestOptions(ctx, BypassCache()), sale.InventoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read listing %s: %w", sale.InventoryID, err)
+	}
+	available := read.InventoryItem.Quantity
+	if available > 0 {
+		if _, err := c.AdjustQuantity(ctx, sale.InventoryID, -available); err != nil {
+			return nil, fmt.Errorf("failed to sell out listing %s: %w", sale.InventoryID, err)
+		}
+	}
+	return &StockConflict{Sale: sale, Available: available, Shortfall: sale.Quantity - available}, nil
+}
@@ -0,0 +1,63 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestClient_ReconcileSales(t *testing.T) {
+	backend, client := newAdjustBackend(t)
+	ctx := context.Background()
+
+	sales := []MarketplaceSale{
+		{Marketplace: "square", OrderID: "o1", InventoryID: "a", Quantity: 2},
+		{Marketplace: "square", OrderID: "o2", InventoryID: "b", Quantity: 3},
+		{Marketplace: "square", OrderID: "o3", InventoryID: "missing", Quantity: 1},
+		{Marketplace: "square", OrderID: "o4", ExternalID: "in-store-only", Quantity: 1},
+		{Marketplace: "square", OrderID: "o5", InventoryID: "c", Quantity: 0},
+	}
+	report := client.ReconcileSales(ctx, sales)
+	if err := report.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	want := "1 applied, 2 conflicts, 1 unlinked, 0 failed\n" +
+		"  conflict: square order o2: sold 3 of listing b, ManaPool had 1\n" +
+		"  conflict: square order o3: sold 1 of listing missing, ManaPool had 0"
+	if got := report.String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+	if c := report.Conflicts[0]; c.Available != 1 || c.Shortfall != 2 {
+		t.Errorf("conflict = %+v, want 1 available and 2 short", c)
+	}
+	for i, wantQty := range []int{3, 0, 1} {
+		if got := backend.inventory[i].Quantity; got != wantQty {
+			t.Errorf("%s quantity = %d, want %d", backend.inventory[i].ID, got, wantQty)
+		}
+	}
+}
+
+func TestClient_ReconcileSales_Failures(t *testing.T) {
+	backend, client := newAdjustBackend(t)
+	backend.inventory[0].ProductID = ""
+
+	report := client.ReconcileSales(context.Background(), []MarketplaceSale{
+		{Marketplace: "square", OrderID: "o1", InventoryID: "a", Quantity: 1},
+		{Marketplace: "square", OrderID: "o2", InventoryID: "b", Quantity: 1},
+	})
+	if len(report.Applied) != 1 || len(report.Failures) != 1 {
+		t.Fatalf("report = %s", report)
+	}
+	if err := report.Err(); err == nil || !strings.Contains(err.Error(), "square order o1: failed to adjust listing a") {
+		t.Errorf("Err() = %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	report = client.ReconcileSales(cancelled, []MarketplaceSale{{InventoryID: "c", Quantity: 1}, {InventoryID: "c", Quantity: 1}})
+	if len(report.Failures) != 1 || !errors.Is(report.Failures[0].Err, context.Canceled) {
+		t.Errorf("cancelled report = %+v", report)
+	}
+}
@@ -0,0 +1,222 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeMarketplace records adapter calls.
+type fakeMarketplace struct {
+	name    string
+	calls   []string
+	next    int
+	failOn  string
+	sales   []MarketplaceSale
+	fetchAt time.Time
+}
+
+func newFakeMarketplace(name string) *fakeMarketplace {
+	return &fakeMarketplace{name: name}
+}
+
+func (f *fakeMarketplace) Name() string { return f.name }
+
+func (f *fakeMarketplace) record(call string) error {
+	f.calls = append(f.calls, call)
+	if f.failOn != "" && strings.HasPrefix(call, f.failOn) {
+		return errors.New("channel unavailable")
+	}
+	return nil
+}
+
+func (f *fakeMarketplace) ListItem(_ context.Context, item InventoryItem) (string, error) {
+	if err := f.record("list " + item.ID); err != nil {
+		return "", err
+	}
+	f.next++
+	id := fmt.Sprintf("%s-%d", f.name, f.next)
+	return id, nil
+}
+
+func (f *fakeMarketplace) UpdatePrice(_ context.Context, externalID string, priceCents int) error {
+	return f.record(fmt.Sprintf("price %s %d", externalID, priceCents))
+}
+
+func (f *fakeMarketplace) UpdateQuantity(_ context.Context, externalID string, quantity int) error {
+	return f.record(fmt.Sprintf("quantity %s %d", externalID, quantity))
+}
+
+func (f *fakeMarketplace) Delist(_ context.Context, externalID string) error {
+	return f.record("delist " + externalID)
+}
+
+func (f *fakeMarketplace) FetchSales(_ context.Context, since time.Time) ([]MarketplaceSale, error) {
+	f.fetchAt = since
+	if err := f.record("sales"); err != nil {
+		return nil, err
+	}
+	return f.sales, nil
+}
+
+func TestCrossLister_Propagate(t *testing.T) {
+	ctx := context.Background()
+	shop := newFakeMarketplace("shop")
+	links := NewMemoryListingLinks()
+	lister := NewCrossLister(links, shop)
+	lister.Filter = func(item InventoryItem) bool { return item.PriceCents >= 100 }
+
+	prev := []InventoryItem{}
+	next := []InventoryItem{
+		{ID: "a", PriceCents: 500, Quantity: 1},
+		{ID: "cheap", PriceCents: 50, Quantity: 1},
+		{ID: "empty", PriceCents: 500, Quantity: 0},
+	}
+	report := lister.Propagate(ctx, DiffInventory(prev, next))
+	if report.String() != "1 listed, 0 updated, 0 delisted, 0 failed" {
+		t.Errorf("first report = %s", report)
+	}
+
+	prev, next = next, []InventoryItem{
+		{ID: "a", PriceCents: 450, Quantity: 2},
+		{ID: "cheap", PriceCents: 150, Quantity: 1},
+		{ID: "empty", PriceCents: 500, Quantity: 0},
+	}
+	report = lister.Propagate(ctx, DiffInventory(prev, next))
+	if report.String() != "1 listed, 2 updated, 0 delisted, 0 failed" {
+		t.Errorf("second report = %s", report)
+	}
+
+	prev, next = next, []InventoryItem{
+		{ID: "a", PriceCents: 450, Quantity: 0},
+	}
+	events := append(DiffInventory(prev, next), InventoryEvent{Type: InventoryWatchError, Err: errors.New("poll failed")})
+	report = lister.Propagate(ctx, events)
+	if report.String() != "0 listed, 0 updated, 2 delisted, 0 failed" {
+		t.Errorf("third report = %s", report)
+	}
+
+	want := "list a,price shop-1 450,list cheap,quantity shop-1 2,delist shop-2,delist shop-1"
+	if got := strings.Join(shop.calls, ","); got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+	if _, ok, _ := links.ExternalID(ctx, "shop", "a"); ok {
+		t.Error("delisted listing still linked")
+	}
+}
+
+func TestCrossLister_PropagateFailures(t *testing.T) {
+	ctx := context.Background()
+	good, bad := newFakeMarketplace("good"), newFakeMarketplace("bad")
+	bad.failOn = "list"
+	lister := NewCrossLister(NewMemoryListingLinks(), good, bad)
+
+	report := lister.Propagate(ctx, DiffInventory(nil, []InventoryItem{{ID: "a", PriceCents: 100, Quantity: 1}}))
+	if report.Listed != 1 || len(report.Failures) != 1 || report.Failures[0].Marketplace != "bad" {
+		t.Fatalf("report = %+v", report)
+	}
+	if err := report.Err(); err == nil || !strings.Contains(err.Error(), "bad: listing a: failed to list: channel unavailable") {
+		t.Errorf("Err() = %v", err)
+	}
+
+	// The failed channel is retried when the listing next changes.
+	bad.failOn = ""
+	report = lister.Propagate(ctx, DiffInventory(
+		[]InventoryItem{{ID: "a", PriceCents: 100, Quantity: 1}},
+		[]InventoryItem{{ID: "a", PriceCents: 120, Quantity: 1}},
+	))
+	if report.String() != "1 listed, 1 updated, 0 delisted, 0 failed" {
+		t.Errorf("retry report = %s", report)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	report = lister.Propagate(cancelled, DiffInventory(nil, []InventoryItem{{ID: "b", PriceCents: 100, Quantity: 1}}))
+	if len(report.Failures) != 1 || !errors.Is(report.Failures[0].Err, context.Canceled) {
+		t.Errorf("cancelled report = %+v", report)
+	}
+}
+
+func TestCrossLister_FetchSales(t *testing.T) {
+	ctx := context.Background()
+	links := NewMemoryListingLinks()
+	if err := links.Link(ctx, "shop", "a", "shop-1"); err != nil {
+		t.Fatal(err)
+	}
+	shop, broken := newFakeMarketplace("shop"), newFakeMarketplace("broken")
+	shop.sales = []MarketplaceSale{
+		{ExternalID: "shop-1", OrderID: "o1", Quantity: 1, PriceCents: 500},
+		{ExternalID: "shop-9", OrderID: "o2", Quantity: 1, PriceCents: 100},
+	}
+	broken.failOn = "sales"
+
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	sales, err := NewCrossLister(links, shop, broken).FetchSales(ctx, since)
+	if err == nil || !strings.Contains(err.Error(), "failed to fetch broken sales") {
+		t.Errorf("FetchSales() error = %v", err)
+	}
+	if len(sales) != 2 || sales[0].InventoryID != "a" || sales[0].Marketplace != "shop" || sales[1].InventoryID != "" {
+		t.Errorf("sales = %+v", sales)
+	}
+	if !shop.fetchAt.Equal(since) {
+		t.Errorf("since = %v", shop.fetchAt)
+	}
+}
+
+func TestMemoryListingLinks(t *testing.T) {
+	ctx := context.Background()
+	links := NewMemoryListingLinks()
+	_ = links.Link(ctx, "shop", "a", "x1")
+	_ = links.Link(ctx, "shop", "a", "x2")
+	_ = links.Link(ctx, "other", "a", "x1")
+
+	if id, ok, _ := links.ExternalID(ctx, "shop", "a"); !ok || id != "x2" {
+		t.Errorf("ExternalID = %q, %v", id, ok)
+	}
+	if _, ok, _ := links.InventoryID(ctx, "shop", "x1"); ok {
+		t.Error("replaced link still resolves")
+	}
+	if id, ok, _ := links.InventoryID(ctx, "other", "x1"); !ok || id != "a" {
+		t.Errorf("InventoryID(other) = %q, %v", id, ok)
+	}
+
+	_ = links.Unlink(ctx, "shop", "a")
+	if _, ok, _ := links.InventoryID(ctx, "shop", "x2"); ok {
+		t.Error("unlinked listing still resolves")
+	}
+}
+
+func TestClient_SyncInventory_CrossList(t *testing.T) {
+	synced := syncTestRemote()
+	synced[1].PriceCents = 250
+	synced = append(synced, InventoryItem{ID: "new", PriceCents: 60, Quantity: 1})
+	backend := &syncTestServer{synced: synced}
+	server := httptest.NewServer(backend.handler(t))
+	defer server.Close()
+
+	ctx := context.Background()
+	links := NewMemoryListingLinks()
+	_ = links.Link(ctx, "shop", "b", "shop-b")
+	shop := newFakeMarketplace("shop")
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	report, err := client.SyncInventory(ctx, syncTestDesired(), SyncOptions{CrossLister: NewCrossLister(links, shop)})
+	if err != nil {
+		t.Fatalf("SyncInventory() error = %v", err)
+	}
+	if report.CrossList == nil {
+		t.Fatal("CrossList report missing")
+	}
+	if got := strings.Join(shop.calls, ","); got != "list new,price shop-b 250" {
+		t.Errorf("calls = %s", got)
+	}
+
+	dry, err := client.SyncInventory(ctx, syncTestDesired(), SyncOptions{DryRun: true, CrossLister: NewCrossLister(links, shop)})
+	if err != nil || dry.CrossList != nil {
+		t.Errorf("dry run cross-listed: %+v, %v", dry.CrossList, err)
+	}
+}
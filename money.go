@@ -0,0 +1,147 @@
+package manapool
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Currency is an ISO 4217 currency code, such as "USD".
+type Currency string
+
+// Currencies used by ManaPool and the price sources this package supports.
+const (
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+	GBP Currency = "GBP"
+	CAD Currency = "CAD"
+)
+
+// Money is an amount in a currency, in hundredths of the currency's unit
+// (cents for USD and EUR). ManaPool prices are always USD.
+type Money struct {
+	Amount   int
+	Currency Currency
+}
+
+// USDCents returns a USD amount.
+func USDCents(cents int) Money {
+	return Money{Amount: cents, Currency: USD}
+}
+
+// ErrNoExchangeRate is returned, wrapped, when an ExchangeRates has no rate
+// for a currency pair.
+var ErrNoExchangeRate = errors.New("no exchange rate")
+
+// ExchangeRate is the rate for converting one currency to another, with
+// where and when it was obtained so converted prices can be audited.
+type ExchangeRate struct {
+	From Currency
+	To   Currency
+
+	// Rate is the number of To units per From unit, such as 1.08 USD per EUR
+	Rate float64
+
+	// Source names where the rate came from, such as "ECB reference rate"
+	Source string
+
+	// AsOf is when the rate was published
+	AsOf time.Time
+}
+
+// String describes the rate and its provenance, such as
+// "1 EUR = 1.08 USD (ECB reference rate, 2025-03-01)".
+func (r ExchangeRate) String() string {
+	var provenance []string
+	if r.Source != "" {
+		provenance = append(provenance, r.Source)
+	}
+	if !r.AsOf.IsZero() {
+		provenance = append(provenance, r.AsOf.Format("2006-01-02"))
+	}
+	s := fmt.Sprintf("1 %s = %g %s", r.From, r.Rate, r.To)
+	if len(provenance) > 0 {
+		s += " (" + strings.Join(provenance, ", ") + ")"
+	}
+	return s
+}
+
+// ExchangeRates looks up conversion rates.
+type ExchangeRates interface {
+	// Rate returns the rate for converting from into to. It returns an
+	// error wrapping ErrNoExchangeRate if the pair is unknown.
+	Rate(from, to Currency) (ExchangeRate, error)
+}
+
+// ConvertTo converts m into currency at the rate from rates, rounding to the
+// nearest hundredth, and returns the rate used. Converting to m's own
+// currency needs no rate and returns m unchanged with a rate of 1.
+//
+// Example:
+//
+//	rates, err := manapool.NewStaticRates(manapool.ExchangeRate{
+//	    From: manapool.EUR, To: manapool.USD, Rate: 1.08,
+//	    Source: "ECB reference rate", AsOf: published,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	usd, rate, err := manapool.Money{Amount: 250, Currency: manapool.EUR}.ConvertTo(manapool.USD, rates)
+//	// usd.Amount == 270, rate.Source == "ECB reference rate"
+func (m Money) ConvertTo(currency Currency, rates ExchangeRates) (Money, ExchangeRate, error) {
+	if m.Currency == currency {
+		return m, ExchangeRate{From: currency, To: currency, Rate: 1}, nil
+	}
+	if rates == nil {
+		return Money{}, ExchangeRate{}, fmt.Errorf("failed to convert %s to %s: %w", m.Currency, currency, ErrNoExchangeRate)
+	}
+	rate, err := rates.Rate(m.Currency, currency)
+	if err != nil {
+		return Money{}, ExchangeRate{}, fmt.Errorf("failed to convert %s to %s: %w", m.Currency, currency, err)
+	}
+	return Money{Amount: int(math.Round(float64(m.Amount) * rate.Rate)), Currency: currency}, rate, nil
+}
+
+// StaticRates is an ExchangeRates with fixed rates, such as rates loaded
+// once a day from a central bank feed.
+type StaticRates struct {
+	rates map[[2]Currency]ExchangeRate
+}
+
+// NewStaticRates creates a StaticRates. The inverse of each rate is added
+// unless it is given explicitly. It returns a *ValidationError if a rate is
+// not positive or its currencies are missing.
+func NewStaticRates(rates ...ExchangeRate) (*StaticRates, error) {
+	s := &StaticRates{rates: make(map[[2]Currency]ExchangeRate, 2*len(rates))}
+	for _, rate := range rates {
+		switch {
+		case rate.From == "" || rate.To == "":
+			return nil, NewValidationError("currency", "from and to currencies are required")
+		case !(rate.Rate > 0) || math.IsInf(rate.Rate, 0):
+			return nil, NewValidationError("rate", fmt.Sprintf("%s to %s rate must be positive", rate.From, rate.To))
+		}
+		s.rates[[2]Currency{rate.From, rate.To}] = rate
+	}
+	for _, rate := range rates {
+		inverse := [2]Currency{rate.To, rate.From}
+		if _, ok := s.rates[inverse]; !ok {
+			source := "inverse"
+			if rate.Source != "" {
+				source = "inverse of " + rate.Source
+			}
+			s.rates[inverse] = ExchangeRate{From: rate.To, To: rate.From, Rate: 1 / rate.Rate, Source: source, AsOf: rate.AsOf}
+		}
+	}
+	return s, nil
+}
+
+// Rate implements ExchangeRates.
+func (s *StaticRates) Rate(from, to Currency) (ExchangeRate, error) {
+	rate, ok := s.rates[[2]Currency{from, to}]
+	if !ok {
+		return ExchangeRate{}, fmt.Errorf("%s to %s: %w", from, to, ErrNoExchangeRate)
+	}
+	return rate, nil
+}
@@ -0,0 +1,69 @@
+package manapool
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMoney_ConvertTo(t *testing.T) {
+	published := time.Date(2025, 3, 1, 16, 0, 0, 0, time.UTC)
+	rates, err := NewStaticRates(
+		ExchangeRate{From: EUR, To: USD, Rate: 1.08, Source: "ECB reference rate", AsOf: published},
+		ExchangeRate{From: GBP, To: USD, Rate: 1.25},
+		ExchangeRate{From: USD, To: GBP, Rate: 0.8},
+	)
+	if err != nil {
+		t.Fatalf("NewStaticRates() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		money      Money
+		to         Currency
+		want       Money
+		wantSource string
+	}{
+		{"eur to usd", Money{Amount: 250, Currency: EUR}, USD, USDCents(270), "ECB reference rate"},
+		{"rounds to nearest cent", Money{Amount: 99, Currency: EUR}, USD, USDCents(107), "ECB reference rate"},
+		{"inverse", USDCents(1080), EUR, Money{Amount: 1000, Currency: EUR}, "inverse of ECB reference rate"},
+		{"explicit inverse kept", USDCents(1000), GBP, Money{Amount: 800, Currency: GBP}, ""},
+		{"same currency", USDCents(123), USD, USDCents(123), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rate, err := tt.money.ConvertTo(tt.to, rates)
+			if err != nil {
+				t.Fatalf("ConvertTo() error = %v", err)
+			}
+			if got != tt.want || rate.Source != tt.wantSource || rate.To != tt.to {
+				t.Errorf("ConvertTo() = %+v, %+v; want %+v from %q", got, rate, tt.want, tt.wantSource)
+			}
+		})
+	}
+
+	rate, _ := rates.Rate(EUR, USD)
+	if got := rate.String(); got != "1 EUR = 1.08 USD (ECB reference rate, 2025-03-01)" {
+		t.Errorf("String() = %q", got)
+	}
+
+	if _, _, err := (Money{Amount: 100, Currency: CAD}).ConvertTo(USD, rates); !errors.Is(err, ErrNoExchangeRate) {
+		t.Errorf("ConvertTo(CAD) error = %v, want ErrNoExchangeRate", err)
+	}
+	if _, _, err := USDCents(100).ConvertTo(EUR, nil); !errors.Is(err, ErrNoExchangeRate) {
+		t.Errorf("ConvertTo(nil rates) error = %v, want ErrNoExchangeRate", err)
+	}
+}
+
+func TestNewStaticRates_Invalid(t *testing.T) {
+	for name, rate := range map[string]ExchangeRate{
+		"zero rate":        {From: EUR, To: USD},
+		"negative rate":    {From: EUR, To: USD, Rate: -1},
+		"missing currency": {From: EUR, Rate: 1},
+	} {
+		var validationErr *ValidationError
+		if _, err := NewStaticRates(rate); !errors.As(err, &validationErr) {
+			t.Errorf("%s: NewStaticRates() error = %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+// Package mtgjson maps between MTGJSON, Scryfall, and TCGplayer identifiers
+// using MTGJSON's downloadable datasets.
+//
+// Load AllIdentifiers.json to join mtgjson_id, scryfall_id, and TCGplayer
+// product IDs. TCGplayer SKUs are not part of AllIdentifiers; load
+// TcgplayerSkus.json as well to resolve tcgplayer_sku values.
+//
+// # Basic Usage
+//
+//	ids, err := os.Open("AllIdentifiers.json")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer ids.Close()
+//
+//	m, err := mtgjson.LoadAllIdentifiers(ids)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if ident, ok := m.ForInventoryItem(item); ok {
+//	    fmt.Println(ident.UUID, ident.ScryfallID)
+//	}
+package mtgjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/repricah/manapool"
+)
+
+// Identifiers holds the cross-reference IDs for one MTGJSON card printing.
+type Identifiers struct {
+	// UUID is the MTGJSON card ID (mtgjson_id in the Manapool API)
+	UUID string
+
+	Name    string
+	SetCode string
+	Number  string
+
+	ScryfallID               string
+	TCGPlayerProductID       int
+	TCGPlayerEtchedProductID int
+
+	// CardmarketID is the Cardmarket product ID (idProduct), or 0 if unknown
+	CardmarketID int
+}
+
+// SKU is a TCGplayer SKU entry from TcgplayerSkus.json.
+type SKU struct {
+	SKUID     int    `json:"skuId"`
+	ProductID int    `json:"productId"`
+	Condition string `json:"condition"`
+	Language  string `json:"language"`
+	Printing  string `json:"printing"`
+	Finish    string `json:"finish"`
+}
+
+// Map is an in-memory identifier index. It is safe for concurrent reads once
+// loading has finished.
+type Map struct {
+	byUUID     map[string]*Identifiers
+	byScryfall map[string][]string
+	byProduct  map[int][]string
+	skus       map[string][]SKU
+	bySKU      map[int]string
+}
+
+// NewMap returns an empty Map.
+func NewMap() *Map {
+	return &Map{
+		byUUID:     make(map[string]*Identifiers),
+		byScryfall: make(map[string][]string),
+		byProduct:  make(map[int][]string),
+		skus:       make(map[string][]SKU),
+		bySKU:      make(map[int]string),
+	}
+}
+
+// allIdentifiersCard is the subset of an AllIdentifiers card entry we index.
+type allIdentifiersCard struct {
+	Name        string `json:"name"`
+	SetCode     string `json:"setCode"`
+	Number      string `json:"number"`
+	Identifiers struct {
+		ScryfallID               string `json:"scryfallId"`
+		TCGPlayerProductID       string `json:"tcgplayerProductId"`
+		TCGPlayerEtchedProductID string `json:"tcgplayerEtchedProductId"`
+		CardmarketID             string `json:"mcmId"`
+	} `json:"identifiers"`
+}
+
+// LoadAllIdentifiers builds a Map from an MTGJSON AllIdentifiers.json file.
+// The file is decoded card by card so the full dataset is never held as raw
+// JSON in memory.
+func LoadAllIdentifiers(r io.Reader) (*Map, error) {
+	m := NewMap()
+	if err := m.LoadAllIdentifiers(r); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// LoadAllIdentifiers adds the cards in an AllIdentifiers.json file to m.
+func (m *Map) LoadAllIdentifiers(r io.Reader) error {
+	return decodeData(r, func(uuid string, dec *json.Decoder) error {
+		var card allIdentifiersCard
+		if err := dec.Decode(&card); err != nil {
+			return fmt.Errorf("failed to decode card %s: %w", uuid, err)
+		}
+
+		ident := &Identifiers{
+			UUID:       uuid,
+			Name:       card.Name,
+			SetCode:    card.SetCode,
+			Number:     card.Number,
+			ScryfallID: card.Identifiers.ScryfallID,
+		}
+		ident.TCGPlayerProductID, _ = strconv.Atoi(card.Identifiers.TCGPlayerProductID)
+		ident.TCGPlayerEtchedProductID, _ = strconv.Atoi(card.Identifiers.TCGPlayerEtchedProductID)
+		ident.CardmarketID, _ = strconv.Atoi(card.Identifiers.CardmarketID)
+
+		m.byUUID[uuid] = ident
+		if ident.ScryfallID != "" {
+			m.byScryfall[ident.ScryfallID] = append(m.byScryfall[ident.ScryfallID], uuid)
+		}
+		for _, productID := range []int{ident.TCGPlayerProductID, ident.TCGPlayerEtchedProductID} {
+			if productID > 0 {
+				m.byProduct[productID] = append(m.byProduct[productID], uuid)
+			}
+		}
+		return nil
+	})
+}
+
+// LoadTCGPlayerSKUs adds the SKUs in an MTGJSON TcgplayerSkus.json file to m.
+func (m *Map) LoadTCGPlayerSKUs(r io.Reader) error {
+	return decodeData(r, func(uuid string, dec *json.Decoder) error {
+		var skus []SKU
+		if err := dec.Decode(&skus); err != nil {
+			return fmt.Errorf("failed to decode skus for %s: %w", uuid, err)
+		}
+		m.skus[uuid] = append(m.skus[uuid], skus...)
+		for _, sku := range skus {
+			m.bySKU[sku.SKUID] = uuid
+		}
+		return nil
+	})
+}
+
+// Len returns the number of cards indexed.
+func (m *Map) Len() int {
+	return len(m.byUUID)
+}
+
+// ByMTGJSONID returns the identifiers for an MTGJSON UUID.
+func (m *Map) ByMTGJSONID(uuid string) (Identifiers, bool) {
+	ident, ok := m.byUUID[uuid]
+	if !ok {
+		return Identifiers{}, false
+	}
+	return *ident, true
+}
+
+// ByScryfallID returns the identifiers for a Scryfall ID. When several MTGJSON
+// printings share a Scryfall ID, the first loaded is returned.
+func (m *Map) ByScryfallID(scryfallID string) (Identifiers, bool) {
+	uuids := m.byScryfall[scryfallID]
+	if len(uuids) == 0 {
+		return Identifiers{}, false
+	}
+	return m.ByMTGJSONID(uuids[0])
+}
+
+// ByTCGPlayerProductID returns the identifiers for a TCGplayer product ID.
+func (m *Map) ByTCGPlayerProductID(productID int) (Identifiers, bool) {
+	uuids := m.byProduct[productID]
+	if len(uuids) == 0 {
+		return Identifiers{}, false
+	}
+	return m.ByMTGJSONID(uuids[0])
+}
+
+// ByTCGPlayerSKU returns the identifiers for a TCGplayer SKU. It requires
+// LoadTCGPlayerSKUs to have been called.
+func (m *Map) ByTCGPlayerSKU(sku int) (Identifiers, bool) {
+	uuid, ok := m.bySKU[sku]
+	if !ok {
+		return Identifiers{}, false
+	}
+	return m.ByMTGJSONID(uuid)
+}
+
+// SKUs returns the TCGplayer SKUs known for an MTGJSON UUID.
+func (m *Map) SKUs(uuid string) []SKU {
+	return m.skus[uuid]
+}
+
+// ForInventoryItem resolves the identifiers for a Manapool inventory item,
+// trying its mtgjson_id, then scryfall_id, then tcgplayer_sku.
+func (m *Map) ForInventoryItem(item manapool.InventoryItem) (Identifiers, bool) {
+	if single := item.Product.Single; single != nil {
+		if ident, ok := m.ByMTGJSONID(single.MTGJsonID); ok {
+			return ident, true
+		}
+		if ident, ok := m.ByScryfallID(single.ScryfallID); ok {
+			return ident, true
+		}
+	}
+	if sealed := item.Product.Sealed; sealed != nil {
+		if ident, ok := m.ByMTGJSONID(sealed.MTGJsonID); ok {
+			return ident, true
+		}
+	}
+	if sku := item.Product.TCGPlayerSKU; sku != nil {
+		return m.ByTCGPlayerSKU(*sku)
+	}
+	return Identifiers{}, false
+}
+
+// decodeData walks the top-level "data" object of an MTGJSON file, calling fn
+// with the decoder positioned at each entry's value.
+func decodeData(r io.Reader, fn func(key string, dec *json.Decoder) error) error {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		key, err := readKey(dec)
+		if err != nil {
+			return err
+		}
+		if key != "data" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return fmt.Errorf("failed to decode mtgjson file: %w", err)
+			}
+			continue
+		}
+
+		if err := expectDelim(dec, '{'); err != nil {
+			return err
+		}
+		for dec.More() {
+			uuid, err := readKey(dec)
+			if err != nil {
+				return err
+			}
+			if err := fn(uuid, dec); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(dec, '}'); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+func readKey(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", fmt.Errorf("failed to decode mtgjson file: %w", err)
+	}
+	key, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("failed to decode mtgjson file: expected key, got %v", tok)
+	}
+	return key, nil
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to decode mtgjson file: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("failed to decode mtgjson file: expected %v, got %v", want, tok)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package mtgjson
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool"
+)
+
+const allIdentifiersSample = `{
+  "meta": {"date": "2026-01-01", "version": "5.2.2"},
+  "data": {
+    "uuid-bolt": {
+      "name": "Lightning Bolt",
+      "setCode": "LEA",
+      "number": "161",
+      "identifiers": {"scryfallId": "sf-bolt", "tcgplayerProductId": "1001", "mcmId": "7001"}
+    },
+    "uuid-etched": {
+      "name": "Sol Ring",
+      "setCode": "CMR",
+      "number": "472",
+      "identifiers": {"scryfallId": "sf-sol", "tcgplayerProductId": "2001", "tcgplayerEtchedProductId": "2002"}
+    }
+  }
+}`
+
+const skusSample = `{
+  "meta": {},
+  "data": {
+    "uuid-bolt": [
+      {"skuId": 5001, "productId": 1001, "condition": "NEAR MINT", "language": "ENGLISH", "printing": "NON FOIL"},
+      {"skuId": 5002, "productId": 1001, "condition": "LIGHTLY PLAYED", "language": "ENGLISH", "printing": "NON FOIL"}
+    ]
+  }
+}`
+
+func loadSample(t *testing.T) *Map {
+	t.Helper()
+	m, err := LoadAllIdentifiers(strings.NewReader(allIdentifiersSample))
+	if err != nil {
+		t.Fatalf("LoadAllIdentifiers() error = %v", err)
+	}
+	if err := m.LoadTCGPlayerSKUs(strings.NewReader(skusSample)); err != nil {
+		t.Fatalf("LoadTCGPlayerSKUs() error = %v", err)
+	}
+	return m
+}
+
+func TestMap_Lookups(t *testing.T) {
+	m := loadSample(t)
+
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", m.Len())
+	}
+
+	ident, ok := m.ByMTGJSONID("uuid-bolt")
+	if !ok || ident.ScryfallID != "sf-bolt" || ident.TCGPlayerProductID != 1001 || ident.Name != "Lightning Bolt" ||
+		ident.CardmarketID != 7001 {
+		t.Errorf("ByMTGJSONID() = %+v, %v", ident, ok)
+	}
+	if ident, ok := m.ByScryfallID("sf-sol"); !ok || ident.UUID != "uuid-etched" {
+		t.Errorf("ByScryfallID() = %+v, %v", ident, ok)
+	}
+	if ident, ok := m.ByTCGPlayerProductID(2002); !ok || ident.UUID != "uuid-etched" {
+		t.Errorf("ByTCGPlayerProductID(etched) = %+v, %v", ident, ok)
+	}
+	if ident, ok := m.ByTCGPlayerSKU(5002); !ok || ident.UUID != "uuid-bolt" {
+		t.Errorf("ByTCGPlayerSKU() = %+v, %v", ident, ok)
+	}
+	if skus := m.SKUs("uuid-bolt"); len(skus) != 2 || skus[1].Condition != "LIGHTLY PLAYED" {
+		t.Errorf("SKUs() = %+v", skus)
+	}
+
+	for name, found := range map[string]bool{
+		"uuid":     func() bool { _, ok := m.ByMTGJSONID("nope"); return ok }(),
+		"scryfall": func() bool { _, ok := m.ByScryfallID("nope"); return ok }(),
+		"product":  func() bool { _, ok := m.ByTCGPlayerProductID(9); return ok }(),
+		"sku":      func() bool { _, ok := m.ByTCGPlayerSKU(9); return ok }(),
+	} {
+		if found {
+			t.Errorf("unknown %s lookup returned ok", name)
+		}
+	}
+}
+
+func TestMap_ForInventoryItem(t *testing.T) {
+	m := loadSample(t)
+	sku := 5001
+	unknownSKU := 1
+
+	tests := []struct {
+		name     string
+		item     manapool.InventoryItem
+		wantUUID string
+		wantOK   bool
+	}{
+		{
+			name:     "by mtgjson id",
+			item:     manapool.InventoryItem{Product: manapool.Product{Single: &manapool.Single{MTGJsonID: "uuid-etched"}}},
+			wantUUID: "uuid-etched",
+			wantOK:   true,
+		},
+		{
+			name:     "by scryfall id",
+			item:     manapool.InventoryItem{Product: manapool.Product{Single: &manapool.Single{ScryfallID: "sf-bolt"}}},
+			wantUUID: "uuid-bolt",
+			wantOK:   true,
+		},
+		{
+			name:     "by sealed mtgjson id",
+			item:     manapool.InventoryItem{Product: manapool.Product{Sealed: &manapool.Sealed{MTGJsonID: "uuid-bolt"}}},
+			wantUUID: "uuid-bolt",
+			wantOK:   true,
+		},
+		{
+			name:     "by sku",
+			item:     manapool.InventoryItem{Product: manapool.Product{TCGPlayerSKU: &sku, Single: &manapool.Single{}}},
+			wantUUID: "uuid-bolt",
+			wantOK:   true,
+		},
+		{
+			name: "unknown sku",
+			item: manapool.InventoryItem{Product: manapool.Product{TCGPlayerSKU: &unknownSKU}},
+		},
+		{
+			name: "no identifiers",
+			item: manapool.InventoryItem{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ident, ok := m.ForInventoryItem(tt.item)
+			if ok != tt.wantOK || ident.UUID != tt.wantUUID {
+				t.Errorf("ForInventoryItem() = %q, %v; want %q, %v", ident.UUID, ok, tt.wantUUID, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		skus  bool
+	}{
+		{name: "empty", input: ``},
+		{name: "not object", input: `[]`},
+		{name: "bad key", input: `{"data": {`},
+		{name: "data not object", input: `{"data": []}`},
+		{name: "bad card", input: `{"data": {"u": {"name": 1}}}`},
+		{name: "bad skus", input: `{"data": {"u": {}}}`, skus: true},
+		{name: "bad meta", input: `{"meta": [}`},
+		{name: "unterminated", input: `{"data": {}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.skus {
+				err = NewMap().LoadTCGPlayerSKUs(strings.NewReader(tt.input))
+			} else {
+				_, err = LoadAllIdentifiers(strings.NewReader(tt.input))
+			}
+			if err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
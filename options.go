@@ -1,6 +1,7 @@
 package manapool
 
 import (
+	"io"
 	"net/http"
 	"time"
 
@@ -12,6 +13,8 @@ type ClientOption func(*Client)
 
 // WithHTTPClient sets a custom HTTP client.
 // Use this to configure timeouts, transport, TLS settings, etc.
+// Unless WithEndpointTimeouts is also used, the client's timeout then
+// applies to every request in place of the per-endpoint timeouts.
 //
 // Example:
 //
@@ -27,6 +30,7 @@ type ClientOption func(*Client)
 func WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) {
 		c.httpClient = httpClient
+		c.clientTimeoutSet = true
 	}
 }
 
@@ -63,10 +67,30 @@ func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
 	}
 }
 
+// WithLimiter replaces the client's rate limiter with limiter. Use it to
+// share one limiter between several clients, or to plug in a distributed
+// limiter so that multiple processes stay within the account's rate limit
+// together. Passing nil disables client-side rate limiting.
+//
+// WithLimiter and WithRateLimit replace each other; the last one applied wins.
+//
+// Example:
+//
+//	shared := rate.NewLimiter(5, 1)
+//	sellerA := manapool.NewClient(tokenA, emailA, manapool.WithLimiter(shared))
+//	sellerB := manapool.NewClient(tokenB, emailB, manapool.WithLimiter(shared))
+func WithLimiter(limiter Limiter) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
+
 // WithTimeout sets the HTTP client timeout.
-// This is a convenience method that wraps WithHTTPClient.
+// This is a convenience method that wraps WithHTTPClient. Like
+// WithHTTPClient, it replaces the per-endpoint timeouts unless
+// WithEndpointTimeouts is also used.
 //
-// Default: 30 seconds.
+// Default: 30 seconds, for requests without a per-endpoint timeout.
 //
 // Example:
 //
@@ -79,6 +103,7 @@ func WithTimeout(timeout time.Duration) ClientOption {
 			c.httpClient = &http.Client{}
 		}
 		c.httpClient.Timeout = timeout
+		c.clientTimeoutSet = true
 	}
 }
 
@@ -100,6 +125,50 @@ func WithRetry(maxRetries int, initialBackoff time.Duration) ClientOption {
 	}
 }
 
+// WithMaxBackoff caps each sleep between retries at d. Backoffs double from
+// the initial backoff set by WithRetry, so with many retries they would
+// otherwise grow to minutes; once capped, retries continue every d. A
+// non-positive d removes the cap. The backoffs applied to a request are
+// included in debug logs.
+//
+// Default: no cap.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithRetry(10, 500*time.Millisecond),
+//	    manapool.WithMaxBackoff(10*time.Second), // 0.5s, 1s, 2s, 4s, 8s, 10s, 10s, ...
+//	)
+func WithMaxBackoff(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxBackoff = d
+	}
+}
+
+// WithMaxRetryDuration limits the total time spent on a request's attempts
+// and backoff sleeps to d, regardless of how many retries WithRetry allows.
+// A retry is skipped when its backoff would end past the budget, and the last
+// response or error is returned instead. A non-positive d removes the limit.
+//
+// Independently of this option, a retry is skipped when its backoff plus the
+// latency of the last attempt would outlast the request context's deadline;
+// the request then fails with a *RetryAbortedError wrapping the last
+// attempt's error.
+//
+// Default: no limit.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithRetry(10, 500*time.Millisecond),
+//	    manapool.WithMaxRetryDuration(15*time.Second),
+//	)
+func WithMaxRetryDuration(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxRetryDuration = d
+	}
+}
+
 // WithUserAgent sets a custom User-Agent header for API requests.
 //
 // Default: "manapool-go/<version>"
@@ -136,3 +205,301 @@ func WithLogger(logger Logger) ClientOption {
 		c.logger = logger
 	}
 }
+
+// WithDebugDump writes wire-level dumps of every request and response to w.
+// Each dump includes the request line, status line, headers, and body.
+// Bodies are truncated to 4 KiB, the X-ManaPool-Access-Token and
+// X-ManaPool-Email headers are redacted, and the account email is masked
+// wherever it appears in a body.
+//
+// This is intended for troubleshooting API mismatches and should not be
+// enabled in production. Passing nil disables dumping.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithDebugDump(os.Stderr),
+//	)
+func WithDebugDump(w io.Writer) ClientOption {
+	return func(c *Client) {
+		c.debugDump = w
+	}
+}
+
+// WithConditionalRequests enables ETag-based conditional GET requests.
+// Responses carrying an ETag header are cached in memory; later requests for
+// the same URL send If-None-Match and a 304 Not Modified reply is served from
+// the cache as if the API had returned the full payload.
+//
+// This is useful for frequent pollers of account and inventory endpoints.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithConditionalRequests(),
+//	)
+func WithConditionalRequests() ClientOption {
+	return func(c *Client) {
+		c.etagCache = newETagCache()
+	}
+}
+
+// WithCache caches successful GET responses in cache for the duration chosen
+// by ttlPolicy. Cache hits are served without a network round trip or rate
+// limiter wait. Responses are cached per account, so clients using
+// WithCredentialsProvider never share them between accounts. Successful
+// non-GET requests invalidate the cached GET responses for the same path and
+// the paths below it, with any query parameters.
+//
+// A nil ttlPolicy caches every endpoint for one minute.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithCache(manapool.NewMemoryCache(1000), func(endpoint string) time.Duration {
+//	        if endpoint == "account" {
+//	            return 5 * time.Minute
+//	        }
+//	        return 0 // do not cache
+//	    }),
+//	)
+func WithCache(cache Cache, ttlPolicy CacheTTLPolicy) ClientOption {
+	return func(c *Client) {
+		if ttlPolicy == nil {
+			ttlPolicy = FixedTTL(time.Minute)
+		}
+		c.cache = cache
+		c.cacheKeys = newCacheIndex()
+		c.cacheTTL = ttlPolicy
+	}
+}
+
+// WithCompression enables or disables gzip compression.
+// Compression is enabled by default: requests advertise Accept-Encoding: gzip
+// and compressed responses are decompressed transparently. Disabling it
+// requests identity encoding and never compresses request bodies, which
+// keeps traffic readable when debugging with a proxy or WithDebugDump.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithCompression(false),
+//	)
+func WithCompression(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.disableCompression = !enabled
+	}
+}
+
+// WithRequestCompression gzips JSON request bodies of at least minBytes
+// bytes and sends them with Content-Encoding: gzip. This is useful for large
+// bulk inventory writes. A non-positive minBytes disables request compression.
+//
+// Default: request bodies are not compressed.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithRequestCompression(64*1024),
+//	)
+func WithRequestCompression(minBytes int) ClientOption {
+	return func(c *Client) {
+		c.requestCompressionMin = minBytes
+	}
+}
+
+// WithCredentialsProvider makes the client fetch its access token and email
+// from provider before every request instead of using the values passed to
+// NewClient. This lets tokens be rotated, or loaded from a secrets manager,
+// without recreating the client. If the provider fails, the request is not
+// sent and the error is returned wrapped.
+//
+// Example:
+//
+//	client := manapool.NewClient("", "",
+//	    manapool.WithCredentialsProvider(manapool.NewCachingCredentials(provider, time.Minute)),
+//	)
+func WithCredentialsProvider(provider CredentialsProvider) ClientOption {
+	return func(c *Client) {
+		c.credentialsProvider = provider
+	}
+}
+
+// WithRequestCoalescing collapses identical GET requests that are in flight
+// at the same time into a single upstream call. Every caller receives its own
+// copy of the response, including error responses. This helps web backends
+// that fetch the same account or inventory page for many concurrent users.
+//
+// Requests are only coalesced when they use the same URL and credentials and
+// carry no per-request options. A caller that cancels its context stops
+// waiting without cancelling the shared call for the others.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithRequestCoalescing(),
+//	)
+func WithRequestCoalescing() ClientOption {
+	return func(c *Client) {
+		c.flights = newFlightGroup()
+	}
+}
+
+// WithRawCapture keeps the undecoded JSON body of responses on the types
+// that embed RawResponse, such as Account, InventoryResponse, and
+// OrdersResponse, so callers can read fields this package does not model
+// yet. Capture is off by default because it keeps each body in memory for as
+// long as the decoded response.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithRawCapture(),
+//	)
+//	account, err := client.GetSellerAccount(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	var tier string
+//	if ok, err := account.Field("seller_tier", &tier); err == nil && ok {
+//	    fmt.Println("tier:", tier)
+//	}
+func WithRawCapture() ClientOption {
+	return func(c *Client) {
+		c.captureRaw = true
+	}
+}
+
+// WithJSONCodec replaces encoding/json for request bodies and response
+// decoding, so high-volume users can plug in a faster library. A nil
+// function keeps encoding/json for that direction. The codec must honor the
+// json struct tags and the Marshaler and Unmarshaler methods of this
+// package's types, as the common drop-in replacements do.
+//
+// Error bodies, snapshots, and the incremental decoding of
+// ListInventoryStream still use encoding/json.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithJSONCodec(sonic.Marshal, sonic.Unmarshal),
+//	)
+func WithJSONCodec(marshal JSONMarshalFunc, unmarshal JSONUnmarshalFunc) ClientOption {
+	return func(c *Client) {
+		c.jsonMarshal = marshal
+		c.jsonUnmarshal = unmarshal
+	}
+}
+
+// WithStrictDecoding makes response decoding fail on fields this package
+// does not model and on unknown enum values, such as a condition ID or
+// fulfillment status missing from the API's documentation. It is meant for
+// CI runs against staging, to catch API drift early; decoding is lenient by
+// default so new API fields do not break production callers.
+//
+// Strict decoding always uses encoding/json, even with WithJSONCodec, and
+// does not apply to ListInventoryStream.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithBaseURL(stagingURL),
+//	    manapool.WithStrictDecoding(),
+//	)
+func WithStrictDecoding() ClientOption {
+	return func(c *Client) {
+		c.strictDecoding = true
+	}
+}
+
+// WithResponseValidation runs ValidateResponse on every decoded response, so
+// bad upstream data, such as a negative price or an unknown condition, fails
+// the call with a *DecodeValidationError instead of reaching the caller's
+// database. It does not apply to ListInventoryStream; validate streamed
+// items with ValidateResponse.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithResponseValidation(),
+//	)
+//	_, err := client.GetSellerInventory(ctx, opts)
+//	var invalid *manapool.DecodeValidationError
+//	if errors.As(err, &invalid) {
+//	    for _, problem := range invalid.Problems {
+//	        log.Println(problem)
+//	    }
+//	}
+func WithResponseValidation() ClientOption {
+	return func(c *Client) {
+		c.validateResponses = true
+	}
+}
+
+// WithJournal records every inventory write in journal before it is sent
+// and marks it completed or failed once the API answers: single listing
+// updates and deletions, bulk upserts, and the writes made by helpers such
+// as AdjustQuantity and ApplySyncPlan. Writes a crash or outage left
+// unconfirmed stay pending and can be resent with ReplayJournal, which makes
+// long sync jobs resumable, and the journal doubles as an audit trail.
+//
+// A write is not sent if it cannot be journaled. Other writes, such as
+// purchases, are never journaled because replaying them is not safe.
+//
+// Example:
+//
+//	journal, err := manapool.OpenFileJournal("manapool-writes.jsonl")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer journal.Close()
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithJournal(journal),
+//	)
+func WithJournal(journal Journal) ClientOption {
+	return func(c *Client) {
+		c.journal = journal
+	}
+}
+
+// WithAuditLog records every successful mutating call in sink: the method
+// and endpoint, a short summary of the request body, the API's request ID,
+// an actor label, and the time. The actor is the AuditActor request option
+// if set, or the client's account email. Calls that fail are not recorded,
+// and a sink error is logged rather than failing the call.
+//
+// Example:
+//
+//	f, err := os.OpenFile("manapool-audit.jsonl", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithAuditLog(manapool.NewAuditLogWriter(f)),
+//	)
+func WithAuditLog(sink AuditSink) ClientOption {
+	return func(c *Client) {
+		c.auditSink = sink
+	}
+}
+
+// WithRequestSigning signs every request with secret: an HMAC-SHA256 over
+// the method, path, sorted query, and body hash, sent with a timestamp in
+// the X-ManaPool-Timestamp and X-ManaPool-Signature headers. The format
+// matches webhook signatures, and VerifyRequestSignature checks it. Each
+// retry is signed again with a fresh timestamp. An empty secret disables
+// signing.
+//
+// The ManaPool API does not require signed requests today; this is for
+// deployments behind a gateway that does.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithRequestSigning(os.Getenv("MANAPOOL_SIGNING_SECRET")),
+//	)
+func WithRequestSigning(secret string) ClientOption {
+	return func(c *Client) {
+		c.signingSecret = secret
+	}
+}
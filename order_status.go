@@ -0,0 +1,159 @@
+package manapool
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// OrderStatus is the fulfillment state of a seller order. The API reports it
+// as latest_fulfillment_status on order summaries and as status on each
+// fulfillment, with null meaning nothing has been fulfilled yet; that state
+// is OrderStatusPending here.
+//
+// The API has no separate paid or cancelled states: orders are paid when
+// they are created, and cancelled orders are refunded.
+type OrderStatus string
+
+const (
+	// OrderStatusPending is an order with no fulfillment yet. It is encoded
+	// as JSON null.
+	OrderStatusPending OrderStatus = "pending"
+
+	// OrderStatusProcessing is an order being prepared for shipment.
+	OrderStatusProcessing OrderStatus = "processing"
+
+	// OrderStatusShipped is an order handed to the carrier.
+	OrderStatusShipped OrderStatus = "shipped"
+
+	// OrderStatusDelivered is an order the carrier reports as delivered.
+	OrderStatusDelivered OrderStatus = "delivered"
+
+	// OrderStatusRefunded is an order that was refunded or cancelled.
+	OrderStatusRefunded OrderStatus = "refunded"
+
+	// OrderStatusReplaced is an order whose items were replaced.
+	OrderStatusReplaced OrderStatus = "replaced"
+
+	// OrderStatusError is an order whose fulfillment hit a problem.
+	OrderStatusError OrderStatus = "error"
+)
+
+// orderTransitions lists the statuses each status may move to.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending:    {OrderStatusProcessing, OrderStatusShipped, OrderStatusDelivered, OrderStatusRefunded, OrderStatusError},
+	OrderStatusProcessing: {OrderStatusShipped, OrderStatusDelivered, OrderStatusRefunded, OrderStatusError},
+	OrderStatusShipped:    {OrderStatusDelivered, OrderStatusRefunded, OrderStatusReplaced, OrderStatusError},
+	OrderStatusDelivered:  {OrderStatusRefunded, OrderStatusReplaced},
+	OrderStatusError:      {OrderStatusProcessing, OrderStatusShipped, OrderStatusRefunded},
+	OrderStatusRefunded:   nil,
+	OrderStatusReplaced:   nil,
+}
+
+// ParseOrderStatus converts an API status string to an OrderStatus. The
+// empty string is OrderStatusPending.
+func ParseOrderStatus(s string) (OrderStatus, error) {
+	if s == "" {
+		return OrderStatusPending, nil
+	}
+	status := OrderStatus(s)
+	if !status.Valid() {
+		return "", NewValidationError("status", fmt.Sprintf("unknown order status %q", s))
+	}
+	return status, nil
+}
+
+// Valid reports whether s is a known status.
+func (s OrderStatus) Valid() bool {
+	_, ok := orderTransitions[s]
+	return ok
+}
+
+// String returns the status name.
+func (s OrderStatus) String() string {
+	return string(s)
+}
+
+// IsFulfilled reports whether the order has left the seller (shipped or
+// delivered).
+func (s OrderStatus) IsFulfilled() bool {
+	return s == OrderStatusShipped || s == OrderStatusDelivered
+}
+
+// IsFinal reports whether no further transitions are possible.
+func (s OrderStatus) IsFinal() bool {
+	next, ok := orderTransitions[s]
+	return ok && len(next) == 0
+}
+
+// CanTransitionTo reports whether an order in status s may move to next.
+// A status never transitions to itself, and unknown statuses never
+// transition.
+//
+// Example:
+//
+//	current := order.FulfillmentStatus()
+//	if !current.CanTransitionTo(manapool.OrderStatusShipped) {
+//	    return fmt.Errorf("order %s is %s and cannot be shipped", order.ID, current)
+//	}
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Ptr returns the status as the *string used by OrderFulfillmentRequest, or
+// nil for OrderStatusPending.
+func (s OrderStatus) Ptr() *string {
+	if s == OrderStatusPending {
+		return nil
+	}
+	v := string(s)
+	return &v
+}
+
+// MarshalJSON encodes OrderStatusPending as null and other statuses as
+// strings.
+func (s OrderStatus) MarshalJSON() ([]byte, error) {
+	if s == OrderStatusPending || s == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(string(s))
+}
+
+// UnmarshalJSON decodes null as OrderStatusPending. Unknown strings are kept
+// as-is so new API statuses do not break decoding; check Valid.
+func (s *OrderStatus) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*s = OrderStatusPending
+		return nil
+	}
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return fmt.Errorf("failed to decode order status: %w", err)
+	}
+	if v == "" {
+		v = string(OrderStatusPending)
+	}
+	*s = OrderStatus(v)
+	return nil
+}
+
+// FulfillmentStatus returns the order's latest fulfillment status.
+func (o OrderSummary) FulfillmentStatus() OrderStatus {
+	return statusFromPtr(o.LatestFulfillmentStatus)
+}
+
+// FulfillmentStatus returns the fulfillment's status.
+func (f OrderFulfillment) FulfillmentStatus() OrderStatus {
+	return statusFromPtr(f.Status)
+}
+
+func statusFromPtr(s *string) OrderStatus {
+	if s == nil || *s == "" {
+		return OrderStatusPending
+	}
+	return OrderStatus(*s)
+}
@@ -0,0 +1,95 @@
+package manapool
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseOrderStatus(t *testing.T) {
+	for _, s := range []string{"processing", "shipped", "delivered", "refunded", "replaced", "error"} {
+		status, err := ParseOrderStatus(s)
+		if err != nil || status.String() != s {
+			t.Errorf("ParseOrderStatus(%q) = %q, %v", s, status, err)
+		}
+	}
+
+	if status, err := ParseOrderStatus(""); err != nil || status != OrderStatusPending {
+		t.Errorf("ParseOrderStatus(\"\") = %q, %v", status, err)
+	}
+
+	_, err := ParseOrderStatus("lost")
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("ParseOrderStatus(lost) error = %v", err)
+	}
+}
+
+func TestOrderStatus_Transitions(t *testing.T) {
+	tests := []struct {
+		from, to OrderStatus
+		want     bool
+	}{
+		{OrderStatusPending, OrderStatusShipped, true},
+		{OrderStatusProcessing, OrderStatusShipped, true},
+		{OrderStatusShipped, OrderStatusDelivered, true},
+		{OrderStatusDelivered, OrderStatusRefunded, true},
+		{OrderStatusError, OrderStatusProcessing, true},
+		{OrderStatusShipped, OrderStatusPending, false},
+		{OrderStatusDelivered, OrderStatusShipped, false},
+		{OrderStatusRefunded, OrderStatusShipped, false},
+		{OrderStatusShipped, OrderStatusShipped, false},
+		{OrderStatus("lost"), OrderStatusShipped, false},
+	}
+	for _, tt := range tests {
+		if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+			t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	if !OrderStatusRefunded.IsFinal() || !OrderStatusReplaced.IsFinal() || OrderStatusShipped.IsFinal() || OrderStatus("lost").IsFinal() {
+		t.Error("IsFinal() mismatch")
+	}
+	if !OrderStatusShipped.IsFulfilled() || !OrderStatusDelivered.IsFulfilled() || OrderStatusProcessing.IsFulfilled() {
+		t.Error("IsFulfilled() mismatch")
+	}
+}
+
+func TestOrderStatus_JSON(t *testing.T) {
+	var v struct {
+		A OrderStatus `json:"a"`
+		B OrderStatus `json:"b"`
+		C OrderStatus `json:"c"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":null,"b":"shipped","c":"lost"}`), &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if v.A != OrderStatusPending || v.B != OrderStatusShipped || v.C != "lost" || v.C.Valid() {
+		t.Errorf("Unmarshal() = %+v", v)
+	}
+
+	out, err := json.Marshal(v)
+	if err != nil || string(out) != `{"a":null,"b":"shipped","c":"lost"}` {
+		t.Errorf("Marshal() = %s, %v", out, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"a":3}`), &v); err == nil {
+		t.Error("Unmarshal(number) succeeded")
+	}
+}
+
+func TestOrderStatus_Accessors(t *testing.T) {
+	shipped := "shipped"
+	if s := (OrderSummary{LatestFulfillmentStatus: &shipped}).FulfillmentStatus(); s != OrderStatusShipped {
+		t.Errorf("OrderSummary.FulfillmentStatus() = %s", s)
+	}
+	if s := (OrderFulfillment{}).FulfillmentStatus(); s != OrderStatusPending {
+		t.Errorf("OrderFulfillment.FulfillmentStatus() = %s", s)
+	}
+	if p := OrderStatusDelivered.Ptr(); p == nil || *p != "delivered" {
+		t.Errorf("Ptr() = %v", p)
+	}
+	if OrderStatusPending.Ptr() != nil {
+		t.Error("OrderStatusPending.Ptr() != nil")
+	}
+}
@@ -0,0 +1,223 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultOrderPollLookback is how far before the newest seen order WatchOrders
+// asks for orders on each poll, so orders that become visible late are not
+// missed.
+const DefaultOrderPollLookback = time.Hour
+
+// orderPollPageSize is the page size used when polling orders.
+const orderPollPageSize = 500
+
+// SeenOrderStore records which orders WatchOrders has delivered. Implement it
+// over a file or database to keep deduplication across restarts.
+type SeenOrderStore interface {
+	// Seen reports whether the order has been delivered.
+	Seen(ctx context.Context, id string) (bool, error)
+
+	// MarkSeen records that the order has been delivered.
+	MarkSeen(ctx context.Context, id string) error
+}
+
+// MemorySeenOrders is an in-memory SeenOrderStore. It is safe for concurrent
+// use.
+type MemorySeenOrders struct {
+	mu  sync.Mutex
+	ids map[string]bool
+}
+
+// NewMemorySeenOrders creates an empty in-memory store, optionally pre-seeded
+// with order IDs that should never be delivered.
+func NewMemorySeenOrders(ids ...string) *MemorySeenOrders {
+	s := &MemorySeenOrders{ids: make(map[string]bool, len(ids))}
+	for _, id := range ids {
+		s.ids[id] = true
+	}
+	return s
+}
+
+// Seen implements SeenOrderStore.
+func (s *MemorySeenOrders) Seen(_ context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ids[id], nil
+}
+
+// MarkSeen implements SeenOrderStore.
+func (s *MemorySeenOrders) MarkSeen(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ids[id] = true
+	return nil
+}
+
+// PollOptions configures WatchOrders.
+type PollOptions struct {
+	// Interval is the time between polls (default: DefaultWatchInterval).
+	Interval time.Duration
+
+	// Since limits delivery to orders created at or after this time. If nil,
+	// every order not already in Store is delivered on the first poll.
+	Since *Timestamp
+
+	// Label limits delivery to orders with this label.
+	Label string
+
+	// Lookback is how far before the newest seen order each poll starts
+	// (default: DefaultOrderPollLookback).
+	Lookback time.Duration
+
+	// Store records delivered orders (default: a new MemorySeenOrders).
+	Store SeenOrderStore
+
+	// MaxBackoff caps the wait between polls after consecutive errors
+	// (default: 10 times Interval).
+	MaxBackoff time.Duration
+
+	// OnError, if set, is called with each poll error.
+	OnError func(error)
+}
+
+// WatchOrders polls seller orders and sends each order not yet in
+// opts.Store on the returned channel, oldest first. An order is marked seen
+// after it is received from the channel, so with a persistent store each
+// order is delivered once even across restarts; if the process stops between
+// delivery and marking, that order is delivered again.
+//
+// Poll errors are logged and passed to opts.OnError, and the wait before the
+// next poll doubles after each consecutive error up to opts.MaxBackoff. The
+// channel is closed when ctx is cancelled, or after reporting an
+// *UnsupportedEndpointError if the account has no seller orders.
+//
+// Example:
+//
+//	since := manapool.Timestamp{Time: time.Now().Add(-24 * time.Hour)}
+//	orders := client.WatchOrders(ctx, manapool.PollOptions{
+//	    Interval: time.Minute,
+//	    Since:    &since,
+//	    OnError:  func(err error) { log.Printf("order poll failed: %v", err) },
+//	})
+//	for order := range orders {
+//	    log.Printf("new order %s: %d cents", order.ID, order.TotalCents)
+//	}
+func (c *Client) WatchOrders(ctx context.Context, opts PollOptions) <-chan OrderSummary {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	lookback := opts.Lookback
+	if lookback <= 0 {
+		lookback = DefaultOrderPollLookback
+	}
+	maxBackoff := opts.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 10 * interval
+	}
+	store := opts.Store
+	if store == nil {
+		store = NewMemorySeenOrders()
+	}
+
+	out := make(chan OrderSummary)
+	go func() {
+		defer close(out)
+
+		since := opts.Since
+		delay := interval
+		for {
+			newest, err := c.pollOrders(ctx, since, opts.Label, store, out)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				c.logger.Errorf("Order poll failed: %v", err)
+				if opts.OnError != nil {
+					opts.OnError(err)
+				}
+				if errors.Is(err, ErrUnsupportedEndpoint) {
+					return
+				}
+				delay *= 2
+				if delay > maxBackoff {
+					delay = maxBackoff
+				}
+			} else {
+				delay = interval
+				if !newest.IsZero() {
+					from := newest.Add(-lookback)
+					if opts.Since == nil || from.After(opts.Since.Time) {
+						since = &Timestamp{Time: from}
+					}
+				}
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+	return out
+}
+
+// pollOrders fetches every order created since since, delivers the unseen
+// ones to out, and returns the newest creation time fetched.
+func (c *Client) pollOrders(ctx context.Context, since *Timestamp, label string, store SeenOrderStore, out chan<- OrderSummary) (time.Time, error) {
+	if err := c.requireCapability(CapabilitySellerOrders); err != nil {
+		return time.Time{}, err
+	}
+	var orders []OrderSummary
+	for offset := 0; ; offset += orderPollPageSize {
+		page, err := c.GetSellerOrders(ctx, OrdersOptions{Since: since, Label: label, Limit: orderPollPageSize, Offset: offset})
+		if err != nil {
+			return time.Time{}, c.unsupportedEndpoint(CapabilitySellerOrders, err)
+		}
+		orders = append(orders, page.Orders...)
+		if len(page.Orders) < orderPollPageSize {
+			break
+		}
+	}
+
+	sort.SliceStable(orders, func(i, j int) bool {
+		if !orders[i].CreatedAt.Equal(orders[j].CreatedAt.Time) {
+			return orders[i].CreatedAt.Before(orders[j].CreatedAt.Time)
+		}
+		return orders[i].ID < orders[j].ID
+	})
+
+	var newest time.Time
+	for _, order := range orders {
+		if order.CreatedAt.After(newest) {
+			newest = order.CreatedAt.Time
+		}
+
+		seen, err := store.Seen(ctx, order.ID)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to check order %s: %w", order.ID, err)
+		}
+		if seen {
+			continue
+		}
+
+		select {
+		case out <- order:
+		case <-ctx.Done():
+			return time.Time{}, ctx.Err()
+		}
+		if err := store.MarkSeen(ctx, order.ID); err != nil {
+			return time.Time{}, fmt.Errorf("failed to mark order %s seen: %w", order.ID, err)
+		}
+	}
+	return newest, nil
+}
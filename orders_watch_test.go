@@ -0,0 +1,193 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ordersPollServer serves /seller/orders from a list that tests can grow,
+// honoring since, label, limit, and offset.
+type ordersPollServer struct {
+	mu     sync.Mutex
+	orders []OrderSummary
+	since  []string
+	fail   map[int]bool
+	polls  int
+	onPoll map[int]func(s *ordersPollServer)
+}
+
+func (s *ordersPollServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q := r.URL.Query()
+	if q.Get("offset") == "" {
+		s.polls++
+		s.since = append(s.since, q.Get("since"))
+		if fn := s.onPoll[s.polls]; fn != nil {
+			fn(s)
+		}
+	}
+	if s.fail[s.polls] {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"message":"maintenance"}`))
+		return
+	}
+
+	var matched []OrderSummary
+	for _, order := range s.orders {
+		if since, err := time.Parse(time.RFC3339Nano, q.Get("since")); err == nil && order.CreatedAt.Before(since) {
+			continue
+		}
+		if label := q.Get("label"); label != "" && order.Label != label {
+			continue
+		}
+		matched = append(matched, order)
+	}
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if offset > len(matched) {
+		offset = len(matched)
+	}
+	matched = matched[offset:]
+	if limit > 0 && limit < len(matched) {
+		matched = matched[:limit]
+	}
+	_ = json.NewEncoder(w).Encode(OrdersResponse{Orders: matched})
+}
+
+func pollOrder(id string, createdAt time.Time) OrderSummary {
+	return OrderSummary{ID: id, CreatedAt: Timestamp{Time: createdAt}, Label: "web", TotalCents: 100}
+}
+
+func receiveOrders(t *testing.T, orders <-chan OrderSummary, n int) []string {
+	t.Helper()
+	var ids []string
+	for len(ids) < n {
+		select {
+		case order := <-orders:
+			ids = append(ids, order.ID)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out after orders %v", ids)
+		}
+	}
+	return ids
+}
+
+func TestClient_WatchOrders(t *testing.T) {
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	backend := &ordersPollServer{
+		orders: []OrderSummary{pollOrder("b", base.Add(time.Minute)), pollOrder("a", base), pollOrder("old", base.Add(-time.Hour))},
+		fail:   map[int]bool{3: true},
+	}
+	backend.onPoll = map[int]func(*ordersPollServer){
+		2: func(s *ordersPollServer) {
+			s.orders = append(s.orders, pollOrder("c", base.Add(2*time.Minute)))
+		},
+		4: func(s *ordersPollServer) {
+			other := pollOrder("d", base.Add(3*time.Minute))
+			other.Label = "store"
+			s.orders = append(s.orders, other, pollOrder("e", base.Add(4*time.Minute)))
+		},
+	}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var errs []error
+	var errMu sync.Mutex
+	store := NewMemorySeenOrders("a")
+	since := Timestamp{Time: base.Add(-time.Minute)}
+	orders := client.WatchOrders(ctx, PollOptions{
+		Interval:   5 * time.Millisecond,
+		MaxBackoff: 10 * time.Millisecond,
+		Since:      &since,
+		Label:      "web",
+		Lookback:   30 * time.Second,
+		Store:      store,
+		OnError: func(err error) {
+			errMu.Lock()
+			errs = append(errs, err)
+			errMu.Unlock()
+		},
+	})
+
+	ids := receiveOrders(t, orders, 3)
+	if want := "b,c,e"; strings.Join(ids, ",") != want {
+		t.Errorf("orders = %v, want %s", ids, want)
+	}
+	cancel()
+	for range orders {
+	}
+
+	errMu.Lock()
+	var apiErr *APIError
+	if len(errs) != 1 || !errors.As(errs[0], &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("OnError got %v", errs)
+	}
+	errMu.Unlock()
+
+	if seen, _ := store.Seen(context.Background(), "e"); !seen {
+		t.Error("order e was not marked seen")
+	}
+
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	if backend.since[0] != since.Format(time.RFC3339Nano) {
+		t.Errorf("first poll since = %q", backend.since[0])
+	}
+	if want := base.Add(time.Minute - 30*time.Second).Format(time.RFC3339Nano); backend.since[1] != want {
+		t.Errorf("second poll since = %q, want %q", backend.since[1], want)
+	}
+}
+
+func TestClient_WatchOrdersStoreError(t *testing.T) {
+	backend := &ordersPollServer{orders: []OrderSummary{pollOrder("a", time.Now())}}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	orders := client.WatchOrders(ctx, PollOptions{
+		Interval: time.Hour,
+		Store:    failingSeenStore{},
+		OnError: func(err error) {
+			select {
+			case errCh <- err:
+			default:
+			}
+		},
+	})
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, errStoreDown) {
+			t.Errorf("OnError got %v", err)
+		}
+	case order := <-orders:
+		t.Fatalf("delivered %s despite store error", order.ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for store error")
+	}
+}
+
+var errStoreDown = errors.New("store down")
+
+type failingSeenStore struct{}
+
+func (failingSeenStore) Seen(context.Context, string) (bool, error) { return false, errStoreDown }
+func (failingSeenStore) MarkSeen(context.Context, string) error     { return errStoreDown }
@@ -0,0 +1,97 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// PingStatus summarizes the API's health as seen by Ping.
+type PingStatus string
+
+const (
+	// PingOK means the API answered and accepted the client's credentials.
+	PingOK PingStatus = "ok"
+
+	// PingRateLimited means the API is up but asked the client to back off.
+	PingRateLimited PingStatus = "rate_limited"
+
+	// PingUnauthorized means the API rejected the client's credentials.
+	PingUnauthorized PingStatus = "unauthorized"
+
+	// PingUnavailable means the API could not be reached or answered with
+	// an error.
+	PingUnavailable PingStatus = "unavailable"
+)
+
+// PingResult is the outcome of Ping.
+type PingResult struct {
+	// Status summarizes the API's health
+	Status PingStatus
+
+	// StatusCode is the HTTP status of the response, or 0 if the API could
+	// not be reached
+	StatusCode int
+
+	// Latency is the time taken by the request, including any wait for the
+	// client's rate limiter
+	Latency time.Duration
+
+	// RequestID is the API's identifier for the request, from the
+	// X-Request-Id header (empty if absent)
+	RequestID string
+}
+
+// Ready reports whether the client can use the API: it is up and accepts
+// the client's credentials, even if it is currently rate limiting them.
+func (r *PingResult) Ready() bool {
+	return r.Status == PingOK || r.Status == PingRateLimited
+}
+
+// Ping checks that the API is reachable and accepts the client's
+// credentials with a single HEAD request on the account endpoint, which
+// has no response body. It is not retried and skips the response cache.
+//
+// Ping returns a result whenever it made the request. The error is nil for
+// PingOK and PingRateLimited and describes the failure otherwise, so
+// readiness probes can simply check it.
+//
+// Example:
+//
+//	result, err := client.Ping(ctx)
+//	if err != nil {
+//	    http.Error(w, string(result.Status), http.StatusServiceUnavailable)
+//	    return
+//	}
+//	log.Printf("ManaPool API %s in %s", result.Status, result.Latency)
+func (c *Client) Ping(ctx context.Context) (*PingResult, error) {
+	c.logger.Debugf("Pinging API")
+
+	ctx = WithRequestOptions(ctx, NoRetry(), BypassCache())
+	started := time.Now()
+	resp, err := c.doRequest(ctx, http.MethodHead, "/account", nil)
+	result := &PingResult{Latency: time.Since(started)}
+	if err != nil {
+		result.Status = PingUnavailable
+		return result, err
+	}
+	result.StatusCode = resp.StatusCode
+	result.RequestID = resp.Header.Get("X-Request-Id")
+
+	err = c.decodeResponse(resp, nil)
+	var apiErr *APIError
+	switch {
+	case err == nil:
+		result.Status = PingOK
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests:
+		result.Status = PingRateLimited
+		err = nil
+	case errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden):
+		result.Status = PingUnauthorized
+	default:
+		result.Status = PingUnavailable
+	}
+	c.logger.Debugf("Ping: %s in %s", result.Status, result.Latency)
+	return result, err
+}
@@ -0,0 +1,65 @@
+package manapool
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Ping(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		want      PingStatus
+		wantErr   bool
+		wantReady bool
+	}{
+		{"ok", http.StatusOK, PingOK, false, true},
+		{"rate limited", http.StatusTooManyRequests, PingRateLimited, false, true},
+		{"unauthorized", http.StatusUnauthorized, PingUnauthorized, true, false},
+		{"forbidden", http.StatusForbidden, PingUnauthorized, true, false},
+		{"server error", http.StatusServiceUnavailable, PingUnavailable, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.Method != http.MethodHead || r.URL.Path != "/account" {
+					t.Errorf("request = %s %s, want HEAD /account", r.Method, r.URL.Path)
+				}
+				w.Header().Set("X-Request-Id", "req-1")
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(3, 0))
+			result, err := client.Ping(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Ping() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result.Status != tt.want || result.StatusCode != tt.status || result.RequestID != "req-1" ||
+				result.Latency <= 0 || result.Ready() != tt.wantReady {
+				t.Errorf("Ping() = %+v", result)
+			}
+			if requests != 1 {
+				t.Errorf("requests = %d, want 1 (no retries)", requests)
+			}
+		})
+	}
+}
+
+func TestClient_Ping_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	result, err := client.Ping(context.Background())
+	if err == nil {
+		t.Fatal("expected network error")
+	}
+	if result.Status != PingUnavailable || result.StatusCode != 0 || result.Ready() {
+		t.Errorf("Ping() = %+v", result)
+	}
+}
@@ -0,0 +1,196 @@
+package manapool
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Locale is a BCP 47 language tag, such as "en-US" or "de-DE", that selects
+// how FormatPrice writes amounts.
+type Locale string
+
+// Locales with built-in price formats.
+const (
+	LocaleUS      Locale = "en-US"
+	LocaleCanada  Locale = "en-CA"
+	LocaleUK      Locale = "en-GB"
+	LocaleGermany Locale = "de-DE"
+	LocaleFrance  Locale = "fr-FR"
+	LocaleItaly   Locale = "it-IT"
+	LocaleSpain   Locale = "es-ES"
+)
+
+// priceFormat describes how a locale writes currency amounts.
+type priceFormat struct {
+	decimal     string
+	group       string
+	symbolFirst bool
+
+	// symbols overrides currencySymbols for currencies the locale writes
+	// differently, such as "$" for CAD in Canada
+	symbols map[Currency]string
+}
+
+var (
+	englishFormat = priceFormat{decimal: ".", group: ",", symbolFirst: true}
+	euroFormat    = priceFormat{decimal: ",", group: "."}
+
+	priceFormats = map[Locale]priceFormat{
+		LocaleUS:      englishFormat,
+		LocaleCanada:  {decimal: ".", group: ",", symbolFirst: true, symbols: map[Currency]string{CAD: "$", USD: "US$"}},
+		LocaleUK:      {decimal: ".", group: ",", symbolFirst: true, symbols: map[Currency]string{USD: "US$"}},
+		LocaleGermany: euroFormat,
+		LocaleFrance:  {decimal: ",", group: " "},
+		LocaleItaly:   euroFormat,
+		LocaleSpain:   euroFormat,
+	}
+
+	// languageLocales picks a locale for a bare or unknown-region language tag.
+	languageLocales = map[string]Locale{
+		"en": LocaleUS,
+		"de": LocaleGermany,
+		"fr": LocaleFrance,
+		"it": LocaleItaly,
+		"es": LocaleSpain,
+	}
+
+	currencySymbols = map[Currency]string{USD: "$", EUR: "€", GBP: "£", CAD: "CA$"}
+
+	// currencyLocales is the locale Money.String uses for each currency.
+	currencyLocales = map[Currency]Locale{USD: LocaleUS, CAD: LocaleCanada, GBP: LocaleUK, EUR: LocaleGermany}
+)
+
+// FormatPrice formats an amount in hundredths of currency for display in
+// locale, such as "$1,234.56" for en-US or "1.234,56 €" for de-DE.
+//
+// Locales are matched case-insensitively and may use "_" for "-". A locale
+// without a built-in format falls back to its language, such as de-AT to
+// de-DE, and then to en-US. Unknown currencies are written with their code.
+// Spaces are plain ASCII so output lines up in terminals and text reports.
+//
+// Example:
+//
+//	manapool.FormatPrice(123456, manapool.EUR, "de-DE") // "1.234,56 €"
+//	manapool.FormatPrice(-250, manapool.USD, "en-US")   // "-$2.50"
+func FormatPrice(cents int, currency Currency, locale Locale) string {
+	format := lookupPriceFormat(locale)
+
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	units := strconv.Itoa(cents / 100)
+	for i := len(units) - 3; i > 0; i -= 3 {
+		units = units[:i] + format.group + units[i:]
+	}
+	number := units + format.decimal + twoDigits(cents%100)
+
+	symbol, ok := format.symbols[currency]
+	if !ok {
+		symbol, ok = currencySymbols[currency]
+	}
+	switch {
+	case !ok:
+		return sign + number + " " + string(currency)
+	case format.symbolFirst:
+		return sign + symbol + number
+	default:
+		return sign + number + " " + symbol
+	}
+}
+
+// FormatCents formats an amount in cents as a plain decimal number, such as
+// "1.25" or "-0.50", with no currency symbol or digit grouping. It suits CSV
+// files and API fields; use FormatPrice for display.
+//
+// Example:
+//
+//	manapool.FormatCents(123456) // "1234.56"
+func FormatCents(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return sign + strconv.Itoa(cents/100) + "." + twoDigits(cents%100)
+}
+
+// String formats m in its currency's customary locale: en-US for USD, en-CA
+// for CAD, en-GB for GBP, and de-DE for EUR. Use Format for a specific
+// locale.
+func (m Money) String() string {
+	return m.Format(currencyLocales[m.Currency])
+}
+
+// Format formats m for display in locale. See FormatPrice.
+func (m Money) Format(locale Locale) string {
+	return FormatPrice(m.Amount, m.Currency, locale)
+}
+
+// errDollarDecimals is wrapped by ParseDollars for amounts with fractions
+// of a cent.
+var errDollarDecimals = errors.New("must have at most two decimal places")
+
+// ParseDollars parses a dollar amount such as "1.25", "$1,000.50", or
+// "-0.10" into cents without floating point rounding. Fractions of a cent
+// are rejected.
+//
+// Example:
+//
+//	cents, err := manapool.ParseDollars("$4.99") // 499
+func ParseDollars(s string) (int, error) {
+	value := strings.TrimSpace(s)
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+	value = strings.ReplaceAll(strings.TrimPrefix(value, "$"), ",", "")
+
+	whole, frac, _ := strings.Cut(value, ".")
+	if len(frac) > 2 {
+		return 0, fmt.Errorf("invalid amount %q: %w", s, errDollarDecimals)
+	}
+	if whole == "" && frac == "" || strings.ContainsAny(value, "+-") {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	frac += strings.Repeat("0", 2-len(frac))
+
+	dollars, err := strconv.Atoi(whole)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	cents, err := strconv.Atoi(frac)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+
+	total := dollars*100 + cents
+	if negative {
+		total = -total
+	}
+	return total, nil
+}
+
+func lookupPriceFormat(locale Locale) priceFormat {
+	tag := strings.ReplaceAll(string(locale), "_", "-")
+	language, region, _ := strings.Cut(tag, "-")
+	language = strings.ToLower(language)
+	if format, ok := priceFormats[Locale(language+"-"+strings.ToUpper(region))]; ok {
+		return format
+	}
+	if fallback, ok := languageLocales[language]; ok {
+		return priceFormats[fallback]
+	}
+	return englishFormat
+}
+
+func twoDigits(n int) string {
+	if n < 10 {
+		return "0" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
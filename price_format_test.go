@@ -0,0 +1,77 @@
+package manapool
+
+import "testing"
+
+func TestFormatPrice(t *testing.T) {
+	tests := []struct {
+		cents    int
+		currency Currency
+		locale   Locale
+		want     string
+	}{
+		{123456, USD, LocaleUS, "$1,234.56"},
+		{123456, EUR, LocaleGermany, "1.234,56 €"},
+		{123456789, USD, LocaleUS, "$1,234,567.89"},
+		{5, USD, LocaleUS, "$0.05"},
+		{-250, USD, LocaleUS, "-$2.50"},
+		{-250, EUR, LocaleGermany, "-2,50 €"},
+		{123456, EUR, LocaleFrance, "1 234,56 €"},
+		{123456, GBP, LocaleUK, "£1,234.56"},
+		{100, USD, LocaleUK, "US$1.00"},
+		{100, CAD, LocaleCanada, "$1.00"},
+		{100, CAD, LocaleUS, "CA$1.00"},
+		{100, USD, LocaleItaly, "1,00 $"},
+		{100, "JPY", LocaleUS, "1.00 JPY"},
+		{100000, EUR, "de_de", "1.000,00 €"},
+		{100000, EUR, "de-AT", "1.000,00 €"},
+		{100000, USD, "", "$1,000.00"},
+		{100000, USD, "xx-YY", "$1,000.00"},
+	}
+	for _, tt := range tests {
+		if got := FormatPrice(tt.cents, tt.currency, tt.locale); got != tt.want {
+			t.Errorf("FormatPrice(%d, %s, %q) = %q, want %q", tt.cents, tt.currency, tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	for money, want := range map[Money]string{
+		USDCents(123456):                "$1,234.56",
+		{Amount: 123456, Currency: EUR}: "1.234,56 €",
+		{Amount: 999, Currency: GBP}:    "£9.99",
+		{Amount: 999, Currency: CAD}:    "$9.99",
+		{Amount: 999, Currency: "CHF"}:  "9.99 CHF",
+	} {
+		if got := money.String(); got != want {
+			t.Errorf("%#v.String() = %q, want %q", money, got, want)
+		}
+	}
+	if got := USDCents(123456).Format(LocaleGermany); got != "1.234,56 $" {
+		t.Errorf("Format(de-DE) = %q", got)
+	}
+}
+
+func TestParseDollars(t *testing.T) {
+	for input, want := range map[string]int{
+		"1": 100, "1.5": 150, "1.": 100, "$0.25": 25, "-0.10": -10, "-$2": -200,
+		".99": 99, " 12.34 ": 1234, "$1,000.50": 100050,
+	} {
+		if got, err := ParseDollars(input); err != nil || got != want {
+			t.Errorf("ParseDollars(%q) = %d, %v, want %d", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "$", ".", "1.234", "1.-5", "abc", "1e3", "--1", "+1", "$-1", "1.x"} {
+		if _, err := ParseDollars(input); err == nil {
+			t.Errorf("ParseDollars(%q) error = nil", input)
+		}
+	}
+}
+
+func TestFormatCents(t *testing.T) {
+	tests := map[int]string{0: "0.00", 5: "0.05", 125: "1.25", 123456: "1234.56", -5: "-0.05", -150: "-1.50", -250: "-2.50"}
+	for cents, want := range tests {
+		if got := FormatCents(cents); got != want {
+			t.Errorf("FormatCents(%d) = %q, want %q", cents, got, want)
+		}
+	}
+}
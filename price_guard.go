@@ -0,0 +1,158 @@
+package manapool
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// PriceGuard bounds automated price changes so a bad rule or a bad market
+// feed cannot list cards at $0.01 or $10,000. Out-of-bounds prices are
+// clamped to the nearest allowed price, or rejected with RejectOutOfBounds.
+//
+// It is used by BulkOptions.Guard and by the repricer. A nil *PriceGuard
+// allows every price.
+type PriceGuard struct {
+	floor            int
+	ceiling          int
+	maxChangePercent float64
+	reject           bool
+}
+
+// PriceGuardOption configures a PriceGuard.
+type PriceGuardOption func(*PriceGuard)
+
+// WithPriceFloor sets the lowest allowed price in cents.
+func WithPriceFloor(cents int) PriceGuardOption {
+	return func(g *PriceGuard) {
+		g.floor = cents
+	}
+}
+
+// WithPriceCeiling sets the highest allowed price in cents.
+func WithPriceCeiling(cents int) PriceGuardOption {
+	return func(g *PriceGuard) {
+		g.ceiling = cents
+	}
+}
+
+// WithMaxChangePercent limits how far a price may move from its current
+// value in one update, as a percentage of the current price. It only applies
+// when the current price is known.
+func WithMaxChangePercent(p float64) PriceGuardOption {
+	return func(g *PriceGuard) {
+		g.maxChangePercent = p
+	}
+}
+
+// RejectOutOfBounds makes the guard reject out-of-bounds prices instead of
+// clamping them.
+func RejectOutOfBounds() PriceGuardOption {
+	return func(g *PriceGuard) {
+		g.reject = true
+	}
+}
+
+// NewPriceGuard creates a PriceGuard. Bounds left unset do not apply.
+//
+// Example:
+//
+//	guard, err := manapool.NewPriceGuard(
+//	    manapool.WithPriceFloor(25),
+//	    manapool.WithPriceCeiling(500000),
+//	    manapool.WithMaxChangePercent(30),
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	result, err := client.UpsertInventory(ctx, upserts, manapool.BulkOptions{Guard: guard})
+//	for _, item := range result.ClampedItems() {
+//	    log.Printf("sku %d: %s", item.Item.TCGPlayerSKU, strings.Join(item.Clamped, "; "))
+//	}
+func NewPriceGuard(opts ...PriceGuardOption) (*PriceGuard, error) {
+	g := &PriceGuard{}
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	switch {
+	case g.floor < 0:
+		return nil, NewValidationError("floor", "must not be negative")
+	case g.ceiling < 0:
+		return nil, NewValidationError("ceiling", "must not be negative")
+	case g.ceiling > 0 && g.floor > g.ceiling:
+		return nil, NewValidationError("floor", fmt.Sprintf("%d exceeds ceiling %d", g.floor, g.ceiling))
+	case g.maxChangePercent < 0 || math.IsNaN(g.maxChangePercent) || math.IsInf(g.maxChangePercent, 0):
+		return nil, NewValidationError("max_change_percent", "must be a non-negative number")
+	}
+	return g, nil
+}
+
+// PriceCheck is the outcome of guarding one price.
+type PriceCheck struct {
+	// Cents is the allowed price: the proposed price, or the clamped price
+	Cents int
+
+	// Violations describes each bound the proposed price broke
+	Violations []string
+
+	// Rejected is true if the guard rejects out-of-bounds prices and the
+	// proposed price broke a bound
+	Rejected bool
+}
+
+// Clamped reports whether the guard changed the proposed price.
+func (c PriceCheck) Clamped() bool {
+	return len(c.Violations) > 0 && !c.Rejected
+}
+
+// Err returns a *ValidationError describing a rejected price, or nil.
+func (c PriceCheck) Err() error {
+	if !c.Rejected {
+		return nil
+	}
+	return NewValidationError("price_cents", "rejected by price guard: "+strings.Join(c.Violations, "; "))
+}
+
+// Check guards a change from currentCents to proposedCents. currentCents is
+// 0 when the current price is unknown, which disables the change limit.
+// The change limit is applied before the floor and ceiling, so the absolute
+// bounds always hold.
+func (g *PriceGuard) Check(currentCents, proposedCents int) PriceCheck {
+	check := PriceCheck{Cents: proposedCents}
+	if g == nil {
+		return check
+	}
+
+	cents := proposedCents
+	if g.maxChangePercent > 0 && currentCents > 0 {
+		limit := currentCents * PercentToBasisPoints(g.maxChangePercent) / 10000
+		switch {
+		case cents > currentCents+limit:
+			check.Violations = append(check.Violations, fmt.Sprintf("change from %s to %s exceeds %g%%",
+				FormatCents(currentCents), FormatCents(proposedCents), g.maxChangePercent))
+			cents = currentCents + limit
+		case cents < currentCents-limit:
+			check.Violations = append(check.Violations, fmt.Sprintf("change from %s to %s exceeds %g%%",
+				FormatCents(currentCents), FormatCents(proposedCents), g.maxChangePercent))
+			cents = currentCents - limit
+		}
+	}
+	if g.floor > 0 && cents < g.floor {
+		check.Violations = append(check.Violations, fmt.Sprintf("%s is below floor %s", FormatCents(cents), FormatCents(g.floor)))
+		cents = g.floor
+	}
+	if g.ceiling > 0 && cents > g.ceiling {
+		check.Violations = append(check.Violations, fmt.Sprintf("%s is above ceiling %s", FormatCents(cents), FormatCents(g.ceiling)))
+		cents = g.ceiling
+	}
+
+	if len(check.Violations) > 0 {
+		if g.reject {
+			check.Rejected = true
+		} else {
+			check.Cents = cents
+		}
+	}
+	return check
+}
@@ -0,0 +1,72 @@
+package manapool
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPriceGuard_Check(t *testing.T) {
+	clamp, err := NewPriceGuard(WithPriceFloor(25), WithPriceCeiling(10000), WithMaxChangePercent(50))
+	if err != nil {
+		t.Fatalf("NewPriceGuard() error = %v", err)
+	}
+	reject, err := NewPriceGuard(WithPriceFloor(25), RejectOutOfBounds())
+	if err != nil {
+		t.Fatalf("NewPriceGuard() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		guard      *PriceGuard
+		current    int
+		proposed   int
+		want       int
+		violations int
+		rejected   bool
+	}{
+		{"within bounds", clamp, 1000, 1200, 1200, 0, false},
+		{"below floor", clamp, 0, 1, 25, 1, false},
+		{"above ceiling", clamp, 0, 20000, 10000, 1, false},
+		{"change too large up", clamp, 1000, 1600, 1500, 1, false},
+		{"change too large down", clamp, 1000, 100, 500, 1, false},
+		{"change then floor", clamp, 30, 1, 25, 2, false},
+		{"unknown current skips change limit", clamp, 0, 5000, 5000, 0, false},
+		{"rejected", reject, 100, 1, 1, 1, true},
+		{"nil guard", nil, 100, 1, 1, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := tt.guard.Check(tt.current, tt.proposed)
+			if check.Cents != tt.want || len(check.Violations) != tt.violations || check.Rejected != tt.rejected {
+				t.Errorf("Check(%d, %d) = %+v", tt.current, tt.proposed, check)
+			}
+			if check.Clamped() != (tt.violations > 0 && !tt.rejected) {
+				t.Errorf("Clamped() = %v", check.Clamped())
+			}
+			var validationErr *ValidationError
+			if err := check.Err(); tt.rejected != errors.As(err, &validationErr) {
+				t.Errorf("Err() = %v", err)
+			}
+		})
+	}
+
+	check := clamp.Check(1000, 1)
+	if got := strings.Join(check.Violations, "; "); got != "change from 10.00 to 0.01 exceeds 50%" {
+		t.Errorf("Violations = %q", got)
+	}
+}
+
+func TestNewPriceGuard_Invalid(t *testing.T) {
+	for name, opts := range map[string][]PriceGuardOption{
+		"negative floor":      {WithPriceFloor(-1)},
+		"negative ceiling":    {WithPriceCeiling(-1)},
+		"floor above ceiling": {WithPriceFloor(500), WithPriceCeiling(100)},
+		"negative max change": {WithMaxChangePercent(-5)},
+	} {
+		var validationErr *ValidationError
+		if _, err := NewPriceGuard(opts...); !errors.As(err, &validationErr) {
+			t.Errorf("%s: NewPriceGuard() error = %v", name, err)
+		}
+	}
+}
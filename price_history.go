@@ -0,0 +1,199 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PricePoint is a snapshot of a listing's price at a point in time.
+type PricePoint struct {
+	ProductID string
+
+	// TCGPlayerSKU is the listing's TCGPlayer SKU (0 if unknown)
+	TCGPlayerSKU int
+
+	PriceCents int
+
+	// MarketCents is the market price at the time of the snapshot (nil if
+	// no market price source was configured or no price was known)
+	MarketCents *int
+
+	RecordedAt time.Time
+}
+
+// PriceHistoryStore persists price snapshots.
+//
+// Implementations must be safe for concurrent use. MemoryPriceHistory is an
+// in-memory implementation; persistent stores (files, databases) can be
+// plugged in by implementing this interface.
+type PriceHistoryStore interface {
+	// AppendPrices stores points.
+	AppendPrices(ctx context.Context, points []PricePoint) error
+
+	// PriceHistory returns the points for productID recorded at or after
+	// since, oldest first.
+	PriceHistory(ctx context.Context, productID string, since time.Time) ([]PricePoint, error)
+}
+
+// MemoryPriceHistory is an in-memory PriceHistoryStore.
+type MemoryPriceHistory struct {
+	mu     sync.RWMutex
+	points map[string][]PricePoint
+}
+
+// NewMemoryPriceHistory creates an empty in-memory price history store.
+func NewMemoryPriceHistory() *MemoryPriceHistory {
+	return &MemoryPriceHistory{points: make(map[string][]PricePoint)}
+}
+
+// AppendPrices implements PriceHistoryStore.
+func (m *MemoryPriceHistory) AppendPrices(_ context.Context, points []PricePoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, point := range points {
+		history := append(m.points[point.ProductID], point)
+		// Keep each product's history ordered even if points arrive out of order.
+		if n := len(history); n > 1 && history[n-1].RecordedAt.Before(history[n-2].RecordedAt) {
+			sort.SliceStable(history, func(i, j int) bool {
+				return history[i].RecordedAt.Before(history[j].RecordedAt)
+			})
+		}
+		m.points[point.ProductID] = history
+	}
+	return nil
+}
+
+// PriceHistory implements PriceHistoryStore.
+func (m *MemoryPriceHistory) PriceHistory(_ context.Context, productID string, since time.Time) ([]PricePoint, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	history := m.points[productID]
+	start := sort.Search(len(history), func(i int) bool {
+		return !history[i].RecordedAt.Before(since)
+	})
+	return append([]PricePoint(nil), history[start:]...), nil
+}
+
+// MarketPriceFunc returns the market price in cents for an inventory item,
+// or ok=false if no price is known.
+type MarketPriceFunc func(ctx context.Context, item InventoryItem) (cents int, ok bool, err error)
+
+// PriceRecorder snapshots listing prices into a PriceHistoryStore.
+type PriceRecorder struct {
+	store  PriceHistoryStore
+	market MarketPriceFunc
+	now    func() time.Time
+}
+
+// NewPriceRecorder creates a recorder writing to store. If market is non-nil,
+// it is consulted for each item and the market price is stored alongside the
+// listing price.
+//
+// Example:
+//
+//	history := manapool.NewMemoryPriceHistory()
+//	recorder := manapool.NewPriceRecorder(history, nil)
+//	report, err := client.SyncInventory(ctx, desired, manapool.SyncOptions{
+//	    Recorder: recorder,
+//	})
+//	...
+//	points, err := recorder.PriceHistory(ctx, productID, time.Now().AddDate(0, 0, -30))
+func NewPriceRecorder(store PriceHistoryStore, market MarketPriceFunc) *PriceRecorder {
+	return &PriceRecorder{store: store, market: market, now: time.Now}
+}
+
+// Record snapshots the current price of every item. Items without a product
+// ID are skipped.
+func (r *PriceRecorder) Record(ctx context.Context, items []InventoryItem) error {
+	recordedAt := r.now()
+	points := make([]PricePoint, 0, len(items))
+	for _, item := range items {
+		if item.ProductID == "" {
+			continue
+		}
+		point := PricePoint{
+			ProductID:  item.ProductID,
+			PriceCents: item.PriceCents,
+			RecordedAt: recordedAt,
+		}
+		if item.Product.TCGPlayerSKU != nil {
+			point.TCGPlayerSKU = *item.Product.TCGPlayerSKU
+		}
+		if r.market != nil {
+			cents, ok, err := r.market(ctx, item)
+			if err != nil {
+				return fmt.Errorf("failed to get market price for %s: %w", item.ProductID, err)
+			}
+			if ok {
+				point.MarketCents = &cents
+			}
+		}
+		points = append(points, point)
+	}
+
+	if len(points) == 0 {
+		return nil
+	}
+	if err := r.store.AppendPrices(ctx, points); err != nil {
+		return fmt.Errorf("failed to store price history: %w", err)
+	}
+	return nil
+}
+
+// PriceHistory returns the recorded points for productID since the given
+// time, oldest first.
+func (r *PriceRecorder) PriceHistory(ctx context.Context, productID string, since time.Time) ([]PricePoint, error) {
+	points, err := r.store.PriceHistory(ctx, productID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load price history: %w", err)
+	}
+	return points, nil
+}
+
+// PriceChange returns the difference in cents between the newest and oldest
+// listing price in points. It returns false if there are fewer than two points.
+func PriceChange(points []PricePoint) (int, bool) {
+	if len(points) < 2 {
+		return 0, false
+	}
+	return points[len(points)-1].PriceCents - points[0].PriceCents, true
+}
+
+// syncedInventory returns remote with the successfully applied actions of
+// report folded in. Created listings are omitted because their product IDs
+// are not known until the next inventory fetch.
+func syncedInventory(remote []InventoryItem, report *SyncReport) []InventoryItem {
+	failed := make(map[int]bool)
+	for _, failure := range report.Failures {
+		for _, action := range failure.Actions {
+			failed[action.SKU] = true
+		}
+	}
+
+	changes := make(map[int]SyncAction)
+	for _, action := range report.Plan.Actions {
+		if !failed[action.SKU] {
+			changes[action.SKU] = action
+		}
+	}
+
+	items := make([]InventoryItem, 0, len(remote))
+	for _, item := range remote {
+		if item.Product.TCGPlayerSKU != nil {
+			if action, ok := changes[*item.Product.TCGPlayerSKU]; ok {
+				if action.Type == SyncDelete {
+					continue
+				}
+				item.PriceCents = action.Desired.PriceCents
+				item.Quantity = action.Desired.Quantity
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
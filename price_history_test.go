@@ -0,0 +1,149 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryPriceHistory(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryPriceHistory()
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	err := store.AppendPrices(ctx, []PricePoint{
+		{ProductID: "p", PriceCents: 100, RecordedAt: base},
+		{ProductID: "p", PriceCents: 300, RecordedAt: base.Add(2 * time.Hour)},
+		{ProductID: "p", PriceCents: 200, RecordedAt: base.Add(time.Hour)},
+		{ProductID: "q", PriceCents: 999, RecordedAt: base},
+	})
+	if err != nil {
+		t.Fatalf("AppendPrices() error = %v", err)
+	}
+
+	points, err := store.PriceHistory(ctx, "p", base.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("PriceHistory() error = %v", err)
+	}
+	if len(points) != 2 || points[0].PriceCents != 200 || points[1].PriceCents != 300 {
+		t.Errorf("PriceHistory() = %+v, want [200 300]", points)
+	}
+
+	all, _ := store.PriceHistory(ctx, "p", time.Time{})
+	if change, ok := PriceChange(all); !ok || change != 200 {
+		t.Errorf("PriceChange() = %d, %v; want 200, true", change, ok)
+	}
+	if _, ok := PriceChange(all[:1]); ok {
+		t.Error("PriceChange() with one point returned ok")
+	}
+
+	if missing, _ := store.PriceHistory(ctx, "missing", time.Time{}); len(missing) != 0 {
+		t.Errorf("PriceHistory(missing) = %+v, want empty", missing)
+	}
+}
+
+func TestPriceRecorder_Record(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryPriceHistory()
+	market := func(_ context.Context, item InventoryItem) (int, bool, error) {
+		if item.ProductID == "p-a" {
+			return 150, true, nil
+		}
+		return 0, false, nil
+	}
+	recorder := NewPriceRecorder(store, market)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	recorder.now = func() time.Time { return now }
+
+	if err := recorder.Record(ctx, syncTestRemote()); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	points, err := recorder.PriceHistory(ctx, "p-a", now)
+	if err != nil {
+		t.Fatalf("PriceHistory() error = %v", err)
+	}
+	if len(points) != 1 {
+		t.Fatalf("PriceHistory() = %+v, want 1 point", points)
+	}
+	point := points[0]
+	if point.PriceCents != 100 || point.TCGPlayerSKU != 1 || point.MarketCents == nil || *point.MarketCents != 150 {
+		t.Errorf("point = %+v", point)
+	}
+
+	points, _ = recorder.PriceHistory(ctx, "p-b", now)
+	if len(points) != 1 || points[0].MarketCents != nil {
+		t.Errorf("p-b points = %+v, want one point without market price", points)
+	}
+}
+
+func TestPriceRecorder_MarketError(t *testing.T) {
+	market := func(context.Context, InventoryItem) (int, bool, error) {
+		return 0, false, errors.New("boom")
+	}
+	recorder := NewPriceRecorder(NewMemoryPriceHistory(), market)
+	err := recorder.Record(context.Background(), syncTestRemote())
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Record() error = %v, want wrapped boom", err)
+	}
+}
+
+func TestClient_SyncInventory_RecordsPriceHistory(t *testing.T) {
+	backend := &syncTestServer{}
+	server := httptest.NewServer(backend.handler(t))
+	defer server.Close()
+
+	ctx := context.Background()
+	store := NewMemoryPriceHistory()
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	_, err := client.SyncInventory(ctx, syncTestDesired(), SyncOptions{
+		DeleteMissing: true,
+		Recorder:      NewPriceRecorder(store, nil),
+	})
+	if err != nil {
+		t.Fatalf("SyncInventory() error = %v", err)
+	}
+
+	want := map[string]int{"p-a": 100, "p-b": 250, "p-c": 300}
+	for productID, cents := range want {
+		points, _ := store.PriceHistory(ctx, productID, time.Time{})
+		if len(points) != 1 || points[0].PriceCents != cents {
+			t.Errorf("%s history = %+v, want price %d", productID, points, cents)
+		}
+	}
+	if points, _ := store.PriceHistory(ctx, "p-d", time.Time{}); len(points) != 0 {
+		t.Errorf("deleted listing recorded: %+v", points)
+	}
+}
+
+func TestSyncedInventory_SkipsFailures(t *testing.T) {
+	remote := syncTestRemote()
+	plan := PlanSync(syncTestDesired(), remote, SyncOptions{DeleteMissing: true})
+	var failed []SyncAction
+	for _, action := range plan.Actions {
+		if action.SKU == 2 || action.SKU == 4 {
+			failed = append(failed, action)
+		}
+	}
+	report := &SyncReport{Plan: plan, Failures: []SyncFailure{{Actions: failed, Err: errors.New("boom")}}}
+
+	items := syncedInventory(remote, report)
+	if len(items) != len(remote) {
+		t.Fatalf("items = %d, want %d", len(items), len(remote))
+	}
+	for _, item := range items {
+		switch item.ID {
+		case "b":
+			if item.PriceCents != 200 {
+				t.Errorf("failed update applied: price %d", item.PriceCents)
+			}
+		case "c":
+			if item.Quantity != 9 {
+				t.Errorf("quantity change not applied: %d", item.Quantity)
+			}
+		}
+	}
+}
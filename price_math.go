@@ -0,0 +1,68 @@
+package manapool
+
+import "math"
+
+// Price math in this package works in integer cents. Percentages are given
+// in basis points (100 is 1%) so they are exact, and results are rounded
+// half to even ("banker's rounding") so that rounding errors do not drift in
+// one direction when many prices are adjusted and summed.
+
+// PercentOfCents returns basisPoints hundredths of a percent of cents,
+// rounded half to even. 10000 basis points is 100%.
+//
+// Example:
+//
+//	manapool.PercentOfCents(1999, 9500) // 1899 (95% of $19.99 is $18.9905)
+//	manapool.PercentOfCents(50, 5000)   // 25
+//	manapool.PercentOfCents(5, 5000)    // 2 ($0.025 rounds to even)
+func PercentOfCents(cents, basisPoints int) int {
+	return divRoundHalfEven(cents*basisPoints, 10000)
+}
+
+// MarginCents returns the margin on a sale: the price less its cost. Costs
+// such as fees and shipping can be subtracted beforehand.
+//
+// Example:
+//
+//	profit := manapool.MarginCents(item.PriceCents, costCents)
+func MarginCents(priceCents, costCents int) int {
+	return priceCents - costCents
+}
+
+// MarginBasisPoints returns the margin on a sale as basis points of the
+// price, rounded half to even, such as 2500 for $1.00 of margin on a $4.00
+// sale. It returns 0 if priceCents is not positive.
+func MarginBasisPoints(priceCents, costCents int) int {
+	if priceCents <= 0 {
+		return 0
+	}
+	return divRoundHalfEven(MarginCents(priceCents, costCents)*10000, priceCents)
+}
+
+// PercentToBasisPoints converts a percentage such as 95.5 to basis points,
+// rounded to the nearest basis point, for callers that take percentages as
+// float64.
+func PercentToBasisPoints(pct float64) int {
+	return int(math.Round(pct * 100))
+}
+
+// Percent returns basisPoints hundredths of a percent of m, rounded half to
+// even. See PercentOfCents.
+func (m Money) Percent(basisPoints int) Money {
+	return Money{Amount: PercentOfCents(m.Amount, basisPoints), Currency: m.Currency}
+}
+
+// divRoundHalfEven returns n/d rounded to the nearest integer, with ties
+// rounded to the even neighbor. d must be positive.
+func divRoundHalfEven(n, d int) int {
+	q, r := n/d, n%d
+	if r < 0 {
+		q--
+		r += d
+	}
+	switch {
+	case 2*r > d, 2*r == d && q%2 != 0:
+		q++
+	}
+	return q
+}
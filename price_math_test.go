@@ -0,0 +1,83 @@
+package manapool
+
+import "testing"
+
+func TestPercentOfCents(t *testing.T) {
+	tests := []struct {
+		name        string
+		cents       int
+		basisPoints int
+		want        int
+	}{
+		{"whole", 1000, 5000, 500},
+		{"rounds down", 1999, 9500, 1899},
+		{"rounds up", 1999, 9550, 1909},
+		{"tie to even down", 5, 5000, 2},
+		{"tie to even up", 15, 5000, 8},
+		{"negative tie to even", -5, 5000, -2},
+		{"negative", -1999, 9500, -1899},
+		{"zero", 1999, 0, 0},
+		{"over 100%", 250, 12000, 300},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PercentOfCents(tt.cents, tt.basisPoints); got != tt.want {
+				t.Errorf("PercentOfCents(%d, %d) = %d, want %d", tt.cents, tt.basisPoints, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercentOfCents_NoDrift(t *testing.T) {
+	// Rounding half up would add 0.5 cents per term and give 1275.
+	total := 0
+	for cents := 1; cents <= 100; cents += 2 {
+		total += PercentOfCents(cents, 5000)
+	}
+	if total != 1250 {
+		t.Errorf("sum of halves = %d, want 1250", total)
+	}
+}
+
+func TestMargin(t *testing.T) {
+	if got := MarginCents(400, 300); got != 100 {
+		t.Errorf("MarginCents() = %d, want 100", got)
+	}
+	if got := MarginCents(300, 400); got != -100 {
+		t.Errorf("MarginCents() = %d, want -100", got)
+	}
+
+	tests := []struct {
+		price, cost int
+		want        int
+	}{
+		{400, 300, 2500},
+		{300, 200, 3333},
+		{300, 100, 6667},
+		{300, 400, -3333},
+		{0, 100, 0},
+	}
+	for _, tt := range tests {
+		if got := MarginBasisPoints(tt.price, tt.cost); got != tt.want {
+			t.Errorf("MarginBasisPoints(%d, %d) = %d, want %d", tt.price, tt.cost, got, tt.want)
+		}
+	}
+}
+
+func TestPercentToBasisPoints(t *testing.T) {
+	for pct, want := range map[float64]int{95: 9500, 95.5: 9550, 0.07: 7, 100: 10000, 33.333: 3333} {
+		if got := PercentToBasisPoints(pct); got != want {
+			t.Errorf("PercentToBasisPoints(%g) = %d, want %d", pct, got, want)
+		}
+	}
+}
+
+func TestMoney_Percent(t *testing.T) {
+	got := Money{Amount: 1999, Currency: EUR}.Percent(9500)
+	if got != (Money{Amount: 1899, Currency: EUR}) {
+		t.Errorf("Percent() = %+v", got)
+	}
+	if item := (InventoryItem{PriceCents: 1234}); item.Price() != USDCents(1234) {
+		t.Errorf("Price() = %+v", item.Price())
+	}
+}
@@ -0,0 +1,82 @@
+package manapool
+
+import (
+	"context"
+	"time"
+)
+
+// ProductKey identifies one variant of a product for price lookups. Sealed
+// products have no condition or finish.
+type ProductKey struct {
+	ProductID   string
+	ConditionID string
+	FinishID    string
+	LanguageID  string
+}
+
+// ProductKeyFor returns the key for an inventory item's variant. Like
+// MarketPriceIndex.MarketPrice, it matches singles by condition, finish, and
+// language, and sealed products by product alone.
+func ProductKeyFor(item InventoryItem) ProductKey {
+	key := ProductKey{ProductID: item.ProductID}
+	if single := item.Product.Single; single != nil {
+		key.ConditionID = single.ConditionID
+		key.FinishID = single.FinishID
+		key.LanguageID = single.LanguageID
+	}
+	return key
+}
+
+// ReferencePrice is a competitor or market price for one product variant.
+type ReferencePrice struct {
+	// Cents is the reference price, such as the lowest competing listing
+	Cents int
+
+	// AvailableQuantity is the quantity offered at or near Cents, or 0 if
+	// the source does not report it
+	AvailableQuantity int
+
+	// AsOf is when the price was observed, or zero if unknown
+	AsOf time.Time
+}
+
+// PriceSource supplies competitor or market prices for many product variants
+// at once. Implementations return a point only for the keys they have a price
+// for; a missing key means no price is known, not an error.
+//
+// MarketPriceIndex implements PriceSource from ManaPool's own price exports.
+// The repricer accepts any PriceSource through Repricer.WithPrices, so other
+// marketplaces or a local price database can be plugged in.
+type PriceSource interface {
+	Prices(ctx context.Context, keys []ProductKey) (map[ProductKey]ReferencePrice, error)
+}
+
+// PriceSourceFunc adapts a function to the PriceSource interface.
+type PriceSourceFunc func(ctx context.Context, keys []ProductKey) (map[ProductKey]ReferencePrice, error)
+
+// Prices implements PriceSource.
+func (f PriceSourceFunc) Prices(ctx context.Context, keys []ProductKey) (map[ProductKey]ReferencePrice, error) {
+	return f(ctx, keys)
+}
+
+// Prices implements PriceSource with the lowest in-stock price for each key,
+// matching condition, finish, and language where the key sets them. It
+// agrees with MarketPrice for keys built by ProductKeyFor.
+func (i *MarketPriceIndex) Prices(ctx context.Context, keys []ProductKey) (map[ProductKey]ReferencePrice, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	prices := make(map[ProductKey]ReferencePrice, len(keys))
+	for _, key := range keys {
+		price, ok := i.byProduct[key.ProductID]
+		if !ok {
+			continue
+		}
+		point, ok := price.Lowest(key.ConditionID, key.FinishID, key.LanguageID)
+		if !ok {
+			continue
+		}
+		prices[key] = ReferencePrice{Cents: point.LowPriceCents, AvailableQuantity: point.AvailableQuantity, AsOf: price.AsOf.Time}
+	}
+	return prices, nil
+}
@@ -0,0 +1,57 @@
+package manapool
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMarketPriceIndex_Prices(t *testing.T) {
+	var requests int32
+	server := newMarketPriceServer(t, &requests)
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	index, err := client.LoadMarketPriceIndex(ctx)
+	if err != nil {
+		t.Fatalf("LoadMarketPriceIndex() error = %v", err)
+	}
+
+	items := []InventoryItem{
+		{ProductID: "bolt", Product: Product{Single: &Single{ConditionID: "NM", FinishID: "NF", LanguageID: "EN"}}},
+		{ProductID: "bolt", Product: Product{Single: &Single{ConditionID: "HP", FinishID: "NF", LanguageID: "EN"}}},
+		{ProductID: "box", Product: Product{Sealed: &Sealed{LanguageID: "EN"}}},
+		{ProductID: "missing"},
+	}
+	keys := make([]ProductKey, len(items))
+	for i, item := range items {
+		keys[i] = ProductKeyFor(item)
+	}
+	if keys[2] != (ProductKey{ProductID: "box"}) {
+		t.Errorf("ProductKeyFor(sealed) = %+v", keys[2])
+	}
+
+	prices, err := index.Prices(ctx, keys)
+	if err != nil {
+		t.Fatalf("Prices() error = %v", err)
+	}
+	if len(prices) != 2 {
+		t.Errorf("Prices() = %+v, want 2 prices", prices)
+	}
+	for i, item := range items {
+		want, wantOK, _ := index.MarketPrice(ctx, item)
+		got, ok := prices[keys[i]]
+		if ok != wantOK || got.Cents != want {
+			t.Errorf("Prices()[%+v] = %+v, %v; MarketPrice() = %d, %v", keys[i], got, ok, want, wantOK)
+		}
+		if ok && got.AsOf.IsZero() {
+			t.Errorf("Prices()[%+v].AsOf is zero", keys[i])
+		}
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := index.Prices(cancelled, keys); err == nil {
+		t.Error("Prices(cancelled) error = nil")
+	}
+}
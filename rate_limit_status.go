@@ -0,0 +1,153 @@
+package manapool
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitStatus is a snapshot of the client's rate-limit state, gathered
+// from recent API responses and the client's own limiter.
+type RateLimitStatus struct {
+	// Observed reports whether any response has carried quota headers. When
+	// false, Limit, Remaining, and Reset are unknown.
+	Observed bool
+
+	// Limit is the request quota of the current window, or -1 if unknown
+	Limit int
+
+	// Remaining is the number of requests left in the current window, or -1
+	// if unknown
+	Remaining int
+
+	// Reset is when the current window ends (zero if unknown)
+	Reset time.Time
+
+	// RetryAfter is when the API asked the client to retry after its last
+	// 429 Too Many Requests response (zero if none is pending)
+	RetryAfter time.Time
+
+	// UpdatedAt is when the quota headers were last seen
+	UpdatedAt time.Time
+
+	// LimiterTokens is the number of requests the client's limiter would
+	// allow right now, or -1 if there is no limiter or it cannot report its
+	// tokens. The *rate.Limiter installed by WithRateLimit can.
+	LimiterTokens float64
+}
+
+// Throttled reports whether the API has asked the client to back off and the
+// requested pause has not yet passed at now.
+func (s RateLimitStatus) Throttled(now time.Time) bool {
+	return now.Before(s.RetryAfter)
+}
+
+// RateLimitStatus returns the client's current rate-limit state, so
+// schedulers can start large jobs when quota is plentiful.
+//
+// The API does not document rate-limit headers. The client reads the common
+// X-RateLimit-Limit, X-RateLimit-Remaining, and X-RateLimit-Reset headers
+// (or their unprefixed RateLimit-* forms) when responses carry them, and
+// Retry-After on 429 responses. Responses served from the cache are not
+// counted.
+//
+// Example:
+//
+//	status := client.RateLimitStatus()
+//	if status.Throttled(time.Now()) || (status.Observed && status.Remaining < 100) {
+//	    log.Printf("quota low, delaying sync until %s", status.Reset)
+//	    return
+//	}
+func (c *Client) RateLimitStatus() RateLimitStatus {
+	status := c.rateStatus.snapshot()
+	status.LimiterTokens = -1
+	if limiter, ok := c.rateLimiter.(interface{ Tokens() float64 }); ok {
+		status.LimiterTokens = limiter.Tokens()
+	}
+	return status
+}
+
+// rateLimitTracker records the quota headers of API responses. It is safe
+// for concurrent use; a nil tracker records nothing.
+type rateLimitTracker struct {
+	mu     sync.Mutex
+	status RateLimitStatus
+}
+
+func newRateLimitTracker() *rateLimitTracker {
+	return &rateLimitTracker{status: RateLimitStatus{Limit: -1, Remaining: -1}}
+}
+
+// observe records the quota headers of resp, received at now.
+func (t *rateLimitTracker) observe(resp *http.Response, now time.Time) {
+	if t == nil {
+		return
+	}
+	limit, hasLimit := headerInt(resp.Header, "X-RateLimit-Limit", "RateLimit-Limit")
+	remaining, hasRemaining := headerInt(resp.Header, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	reset, hasReset := headerInt(resp.Header, "X-RateLimit-Reset", "RateLimit-Reset")
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if hasLimit || hasRemaining || hasReset {
+		t.status.Observed = true
+		t.status.UpdatedAt = now
+		if hasLimit {
+			t.status.Limit = limit
+		}
+		if hasRemaining {
+			t.status.Remaining = remaining
+		}
+		if hasReset {
+			t.status.Reset = resetTime(reset, now)
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		t.status.RetryAfter = retryAfterTime(resp.Header.Get("Retry-After"), now)
+	}
+}
+
+func (t *rateLimitTracker) snapshot() RateLimitStatus {
+	if t == nil {
+		return RateLimitStatus{Limit: -1, Remaining: -1}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.status
+}
+
+// headerInt returns the first of keys present in header as a non-negative
+// integer.
+func headerInt(header http.Header, keys ...string) (int, bool) {
+	for _, key := range keys {
+		if value := header.Get(key); value != "" {
+			n, err := strconv.Atoi(value)
+			if err == nil && n >= 0 {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// resetTime interprets a reset header, which servers send either as a Unix
+// timestamp or as seconds from now.
+func resetTime(reset int, now time.Time) time.Time {
+	if reset > 1_000_000_000 {
+		return time.Unix(int64(reset), 0)
+	}
+	return now.Add(time.Duration(reset) * time.Second)
+}
+
+// retryAfterTime interprets a Retry-After header, in seconds or as an HTTP
+// date. It returns now if the header is missing or malformed.
+func retryAfterTime(value string, now time.Time) time.Time {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return now.Add(time.Duration(seconds) * time.Second)
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return at
+	}
+	return now
+}
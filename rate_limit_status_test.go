@@ -0,0 +1,82 @@
+package manapool
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_RateLimitStatus(t *testing.T) {
+	reset := time.Now().Add(time.Minute).Truncate(time.Second)
+	var throttle atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if throttle.Load() {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message":"slow down"}`))
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "600")
+		w.Header().Set("X-RateLimit-Remaining", "598")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		_, _ = w.Write([]byte(`{"username":"seller"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0), WithRateLimit(10, 5))
+	status := client.RateLimitStatus()
+	if status.Observed || status.Limit != -1 || status.Remaining != -1 || status.LimiterTokens != 5 {
+		t.Errorf("initial status = %+v", status)
+	}
+
+	if _, err := client.GetSellerAccount(context.Background()); err != nil {
+		t.Fatalf("GetSellerAccount() error = %v", err)
+	}
+	status = client.RateLimitStatus()
+	if !status.Observed || status.Limit != 600 || status.Remaining != 598 || !status.Reset.Equal(reset) {
+		t.Errorf("status = %+v", status)
+	}
+	if status.LimiterTokens < 3 || status.LimiterTokens >= 5 || status.Throttled(time.Now()) {
+		t.Errorf("status = %+v", status)
+	}
+
+	throttle.Store(true)
+	if _, err := client.GetSellerAccount(context.Background()); err == nil {
+		t.Fatal("GetSellerAccount(429) error = nil")
+	}
+	status = client.RateLimitStatus()
+	if !status.Throttled(time.Now()) || status.Throttled(time.Now().Add(31*time.Second)) || status.Remaining != 598 {
+		t.Errorf("throttled status = %+v", status)
+	}
+}
+
+func TestRateLimitTracker_Headers(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	tracker := newRateLimitTracker()
+
+	header := http.Header{}
+	header.Set("RateLimit-Remaining", "7")
+	header.Set("RateLimit-Reset", "15")
+	tracker.observe(&http.Response{StatusCode: http.StatusOK, Header: header}, now)
+	status := tracker.snapshot()
+	if status.Limit != -1 || status.Remaining != 7 || !status.Reset.Equal(now.Add(15*time.Second)) || !status.UpdatedAt.Equal(now) {
+		t.Errorf("status = %+v", status)
+	}
+
+	header = http.Header{}
+	header.Set("Retry-After", now.Add(time.Hour).Format(http.TimeFormat))
+	tracker.observe(&http.Response{StatusCode: http.StatusTooManyRequests, Header: header}, now)
+	if got := tracker.snapshot().RetryAfter; !got.Equal(now.Add(time.Hour)) {
+		t.Errorf("RetryAfter = %v", got)
+	}
+
+	var nilTracker *rateLimitTracker
+	nilTracker.observe(&http.Response{Header: header}, now)
+	if nilTracker.snapshot().Remaining != -1 {
+		t.Error("nil tracker snapshot should be unknown")
+	}
+}
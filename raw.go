@@ -0,0 +1,48 @@
+package manapool
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RawResponse holds the undecoded JSON body of an API response. Top-level
+// response types embed it; the body is only kept by clients created with
+// WithRawCapture, and is never encoded. It is held by pointer so the types
+// embedding it stay comparable.
+type RawResponse struct {
+	raw *json.RawMessage
+}
+
+// Raw returns the undecoded JSON body, or nil if it was not captured.
+func (r RawResponse) Raw() json.RawMessage {
+	if r.raw == nil {
+		return nil
+	}
+	return *r.raw
+}
+
+// setRaw stores body as the raw response.
+func (r *RawResponse) setRaw(body []byte) {
+	raw := json.RawMessage(body)
+	r.raw = &raw
+}
+
+// Field decodes the top-level JSON field name of the raw response into v. It
+// reports false if the response was not captured or has no such field.
+func (r RawResponse) Field(name string, v any) (bool, error) {
+	if r.raw == nil {
+		return false, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(*r.raw, &fields); err != nil {
+		return false, fmt.Errorf("failed to decode raw response: %w", err)
+	}
+	value, ok := fields[name]
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(value, v); err != nil {
+		return true, fmt.Errorf("failed to decode raw field %q: %w", name, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,60 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithRawCapture(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"username":"seller","seller_tier":"gold","limits":{"listings":5000}}`))
+	}))
+	defer server.Close()
+
+	plain := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	account, err := plain.GetSellerAccount(context.Background())
+	if err != nil {
+		t.Fatalf("GetSellerAccount() error = %v", err)
+	}
+	if account.Raw() != nil {
+		t.Errorf("Raw() without capture = %s", account.Raw())
+	}
+	if ok, err := account.Field("seller_tier", new(string)); ok || err != nil {
+		t.Errorf("Field() without capture = %v, %v", ok, err)
+	}
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRawCapture())
+	account, err = client.GetSellerAccount(context.Background())
+	if err != nil {
+		t.Fatalf("GetSellerAccount() error = %v", err)
+	}
+	if account.Username != "seller" || len(account.Raw()) == 0 {
+		t.Errorf("account = %+v, raw %s", account, account.Raw())
+	}
+
+	var tier string
+	if ok, err := account.Field("seller_tier", &tier); !ok || err != nil || tier != "gold" {
+		t.Errorf("Field(seller_tier) = %v, %v, %q", ok, err, tier)
+	}
+	var limits struct {
+		Listings int `json:"listings"`
+	}
+	if ok, err := account.Field("limits", &limits); !ok || err != nil || limits.Listings != 5000 {
+		t.Errorf("Field(limits) = %v, %v, %+v", ok, err, limits)
+	}
+	if ok, err := account.Field("missing", &tier); ok || err != nil {
+		t.Errorf("Field(missing) = %v, %v", ok, err)
+	}
+	var n int
+	if ok, err := account.Field("seller_tier", &n); !ok || err == nil {
+		t.Errorf("Field(wrong type) = %v, %v", ok, err)
+	}
+
+	encoded, _ := json.Marshal(account)
+	if string(encoded) != `{"username":"seller","email":"","verified":false,"singles_live":false,"sealed_live":false,"payouts_enabled":false}` {
+		t.Errorf("json.Marshal() = %s", encoded)
+	}
+}
@@ -0,0 +1,311 @@
+// Package repricer computes inventory price updates from composable rules.
+//
+// Rules are evaluated in order against each inventory item and a reference
+// market price from a pluggable PriceSource. Each rule may transform the
+// working price and records why, so every proposed update carries a
+// human-readable explanation.
+//
+// # Basic Usage
+//
+//	r := repricer.New(source,
+//	    repricer.When(repricer.And(repricer.Condition("NM"), repricer.Finish("NF")),
+//	        repricer.PercentOfMarket(95),
+//	        repricer.Floor(25),
+//	    ),
+//	)
+//	result, err := r.Evaluate(ctx, inventory)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, update := range result.Updates {
+//	    fmt.Printf("%s: %d -> %d (%s)\n", update.Item.ID, update.OldCents,
+//	        update.NewCents, strings.Join(update.Explanation, "; "))
+//	}
+//	_, err = client.CreateInventoryBulkBySKU(ctx, result.BulkItems())
+package repricer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/repricah/manapool"
+)
+
+// PriceSource supplies reference market prices for inventory items.
+type PriceSource interface {
+	// MarketPrice returns the market price in cents for item. It returns
+	// ok=false if no price is known.
+	MarketPrice(ctx context.Context, item manapool.InventoryItem) (cents int, ok bool, err error)
+}
+
+// PriceSourceFunc adapts a function to the PriceSource interface.
+type PriceSourceFunc func(ctx context.Context, item manapool.InventoryItem) (int, bool, error)
+
+// MarketPrice implements PriceSource.
+func (f PriceSourceFunc) MarketPrice(ctx context.Context, item manapool.InventoryItem) (int, bool, error) {
+	return f(ctx, item)
+}
+
+// Quote is the input to a rule: the item being priced and its market price.
+type Quote struct {
+	Item manapool.InventoryItem
+
+	// MarketCents is the market price in cents (valid if HasMarket is true)
+	MarketCents int
+	HasMarket   bool
+}
+
+// PriceRule transforms a working price for a quote.
+type PriceRule interface {
+	// Apply returns the new price and an explanation. If applied is false,
+	// the rule did not change the price and the explanation is ignored.
+	Apply(q Quote, cents int) (newCents int, explanation string, applied bool)
+}
+
+// RuleFunc adapts a function to the PriceRule interface.
+type RuleFunc func(q Quote, cents int) (int, string, bool)
+
+// Apply implements PriceRule.
+func (f RuleFunc) Apply(q Quote, cents int) (int, string, bool) {
+	return f(q, cents)
+}
+
+// Update is a proposed price change for a single inventory item.
+type Update struct {
+	Item     manapool.InventoryItem
+	OldCents int
+	NewCents int
+
+	// Explanation lists the rules that contributed to the new price, in order
+	Explanation []string
+
+	// Clamped lists the price guard bounds NewCents was clamped to meet
+	Clamped []string
+
+	// Rejected lists the price guard bounds the rules' price broke when the
+	// guard rejects instead of clamping. NewCents is then OldCents.
+	Rejected []string
+}
+
+// Skip records an item that was not repriced.
+type Skip struct {
+	Item   manapool.InventoryItem
+	Reason string
+}
+
+// Result is the outcome of evaluating rules against inventory.
+type Result struct {
+	Updates []Update
+	Skipped []Skip
+}
+
+// Clamped returns the updates whose price was clamped by the price guard.
+func (r *Result) Clamped() []Update {
+	var clamped []Update
+	for _, update := range r.Updates {
+		if len(update.Clamped) > 0 {
+			clamped = append(clamped, update)
+		}
+	}
+	return clamped
+}
+
+// BulkItems returns the updates as a bulk upsert payload by TCGPlayer SKU.
+// Items without a SKU are omitted; quantities are preserved. Use Upserts to
+// apply every update.
+func (r *Result) BulkItems() []manapool.InventoryBulkItemBySKU {
+	items := make([]manapool.InventoryBulkItemBySKU, 0, len(r.Updates))
+	for _, update := range r.Updates {
+		if update.Item.Product.TCGPlayerSKU == nil {
+			continue
+		}
+		items = append(items, manapool.InventoryBulkItemBySKU{
+			TCGPlayerSKU: *update.Item.Product.TCGPlayerSKU,
+			PriceCents:   update.NewCents,
+			Quantity:     update.Item.Quantity,
+		})
+	}
+	return items
+}
+
+// Upserts returns every update for Client.UpsertInventory, which splits
+// them into bulk requests. Listings with a TCGPlayer SKU are identified by
+// SKU and others by product; quantities are preserved.
+//
+// Example:
+//
+//	bulk, err := client.UpsertInventory(ctx, result.Upserts(), manapool.BulkOptions{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if err := bulk.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+func (r *Result) Upserts() []manapool.InventoryUpsert {
+	upserts := make([]manapool.InventoryUpsert, 0, len(r.Updates))
+	for _, update := range r.Updates {
+		upsert := manapool.InventoryUpsert{PriceCents: update.NewCents, Quantity: update.Item.Quantity}
+		if sku := update.Item.Product.TCGPlayerSKU; sku != nil {
+			upsert.TCGPlayerSKU = *sku
+		} else {
+			upsert.ProductType = update.Item.ProductType
+			upsert.ProductID = update.Item.ProductID
+		}
+		upserts = append(upserts, upsert)
+	}
+	return upserts
+}
+
+// Repricer evaluates rules against inventory items.
+type Repricer struct {
+	source PriceSource
+	prices manapool.PriceSource
+	rules  []PriceRule
+	guard  *manapool.PriceGuard
+}
+
+// New creates a Repricer. A nil source means no market prices are known.
+func New(source PriceSource, rules ...PriceRule) *Repricer {
+	return &Repricer{source: source, rules: rules}
+}
+
+// WithGuard checks every computed price against guard after the rules run,
+// so no rule can move a price outside its bounds. It returns r.
+//
+// Example:
+//
+//	guard, err := manapool.NewPriceGuard(manapool.WithPriceFloor(25), manapool.WithMaxChangePercent(30))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	result, err := repricer.New(source, rules).WithGuard(guard).Evaluate(ctx, inventory)
+//	for _, update := range result.Clamped() {
+//	    log.Printf("%s: %s", update.Item.ID, strings.Join(update.Clamped, "; "))
+//	}
+func (r *Repricer) WithGuard(guard *manapool.PriceGuard) *Repricer {
+	r.guard = guard
+	return r
+}
+
+// WithPrices takes market prices from a batch price source, such as a
+// competitor feed, instead of the per-item source passed to New. Evaluate
+// asks it for every item's price in a single call. It returns r.
+//
+// Example:
+//
+//	result, err := repricer.New(nil, rules).WithPrices(feed).Evaluate(ctx, inventory)
+func (r *Repricer) WithPrices(prices manapool.PriceSource) *Repricer {
+	r.prices = prices
+	return r
+}
+
+// Price evaluates the rules for a single item.
+func (r *Repricer) Price(ctx context.Context, item manapool.InventoryItem) (Update, error) {
+	prices, err := r.fetchPrices(ctx, []manapool.InventoryItem{item})
+	if err != nil {
+		return Update{}, err
+	}
+	return r.price(ctx, item, prices)
+}
+
+// fetchPrices looks up the batch prices for items, or returns nil if the
+// repricer has no batch source.
+func (r *Repricer) fetchPrices(ctx context.Context, items []manapool.InventoryItem) (map[manapool.ProductKey]manapool.ReferencePrice, error) {
+	if r.prices == nil {
+		return nil, nil
+	}
+	keys := make([]manapool.ProductKey, 0, len(items))
+	seen := make(map[manapool.ProductKey]bool, len(items))
+	for _, item := range items {
+		key := manapool.ProductKeyFor(item)
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	prices, err := r.prices.Prices(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market prices: %w", err)
+	}
+	return prices, nil
+}
+
+// price evaluates the rules for item, taking its market price from prices
+// when the repricer has a batch source.
+func (r *Repricer) price(ctx context.Context, item manapool.InventoryItem, prices map[manapool.ProductKey]manapool.ReferencePrice) (Update, error) {
+	q := Quote{Item: item}
+	switch {
+	case r.prices != nil:
+		point, ok := prices[manapool.ProductKeyFor(item)]
+		q.MarketCents, q.HasMarket = point.Cents, ok
+	case r.source != nil:
+		cents, ok, err := r.source.MarketPrice(ctx, item)
+		if err != nil {
+			return Update{}, fmt.Errorf("failed to get market price for %s: %w", item.ID, err)
+		}
+		q.MarketCents, q.HasMarket = cents, ok
+	}
+
+	update := Update{Item: item, OldCents: item.PriceCents, NewCents: item.PriceCents}
+	update.NewCents, update.Explanation = applyRules(r.rules, q, item.PriceCents, nil)
+	if update.NewCents != update.OldCents {
+		check := r.guard.Check(update.OldCents, update.NewCents)
+		switch {
+		case check.Rejected:
+			update.NewCents, update.Rejected = update.OldCents, check.Violations
+		case check.Clamped():
+			update.NewCents, update.Clamped = check.Cents, check.Violations
+			update.Explanation = append(update.Explanation, "guard: "+strings.Join(check.Violations, "; "))
+		}
+	}
+	return update, nil
+}
+
+// Evaluate prices every item and returns the items whose price would change.
+// Items the rules leave unchanged are reported in Skipped.
+func (r *Repricer) Evaluate(ctx context.Context, items []manapool.InventoryItem) (*Result, error) {
+	prices, err := r.fetchPrices(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		update, err := r.price(ctx, item, prices)
+		if err != nil {
+			return nil, err
+		}
+
+		if update.NewCents == update.OldCents {
+			reason := "no rule changed the price"
+			switch {
+			case len(update.Rejected) > 0:
+				reason = "rejected by price guard: " + strings.Join(update.Rejected, "; ")
+			case len(update.Explanation) > 0:
+				reason = "price already matches rules"
+			}
+			result.Skipped = append(result.Skipped, Skip{Item: item, Reason: reason})
+			continue
+		}
+		result.Updates = append(result.Updates, update)
+	}
+	return result, nil
+}
+
+// applyRules runs rules in order, accumulating explanations.
+func applyRules(rules []PriceRule, q Quote, cents int, explanation []string) (int, []string) {
+	for _, rule := range rules {
+		newCents, why, applied := rule.Apply(q, cents)
+		if !applied {
+			continue
+		}
+		cents = newCents
+		explanation = append(explanation, why)
+	}
+	return cents, explanation
+}
@@ -0,0 +1,293 @@
+package repricer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool"
+	"github.com/repricah/manapool/manapooltest"
+)
+
+func single(id string, sku int, condition, finish string, priceCents int) manapool.InventoryItem {
+	return manapool.InventoryItem{
+		ID:          id,
+		ProductType: "mtg_single",
+		PriceCents:  priceCents,
+		Quantity:    2,
+		Product: manapool.Product{
+			TCGPlayerSKU: &sku,
+			Single: &manapool.Single{
+				Name:        id,
+				Set:         "LEA",
+				ConditionID: condition,
+				FinishID:    finish,
+				LanguageID:  "EN",
+			},
+		},
+	}
+}
+
+func mapSource(prices map[string]int) PriceSource {
+	return PriceSourceFunc(func(_ context.Context, item manapool.InventoryItem) (int, bool, error) {
+		cents, ok := prices[item.ID]
+		return cents, ok, nil
+	})
+}
+
+func TestRepricer_Evaluate(t *testing.T) {
+	items := []manapool.InventoryItem{
+		single("nm-nf", 1, "NM", "NF", 100),
+		single("nm-nf-cheap", 2, "NM", "NF", 50),
+		single("nm-foil", 3, "NM", "FO", 500),
+		single("lp-nf", 4, "LP", "NF", 90),
+		single("no-market", 5, "NM", "NF", 75),
+		single("already", 6, "NM", "NF", 190),
+	}
+	source := mapSource(map[string]int{
+		"nm-nf":       1000,
+		"nm-nf-cheap": 10,
+		"nm-foil":     2000,
+		"lp-nf":       1000,
+		"already":     200,
+	})
+
+	r := New(source,
+		When(And(Condition("NM"), Finish("NF")), PercentOfMarket(95), Floor(25)),
+		When(Finish("FO"), OffsetFromMarket(-100)),
+	)
+
+	result, err := r.Evaluate(context.Background(), items)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+
+	got := make(map[string]Update)
+	for _, update := range result.Updates {
+		got[update.Item.ID] = update
+	}
+	want := map[string]int{"nm-nf": 950, "nm-nf-cheap": 25, "nm-foil": 1900}
+	if len(got) != len(want) {
+		t.Fatalf("Updates = %+v, want %d", result.Updates, len(want))
+	}
+	for id, cents := range want {
+		if got[id].NewCents != cents {
+			t.Errorf("%s NewCents = %d, want %d", id, got[id].NewCents, cents)
+		}
+	}
+
+	explanation := strings.Join(got["nm-nf-cheap"].Explanation, "; ")
+	if explanation != "95% of market $0.10, floor $0.25" {
+		t.Errorf("explanation = %q", explanation)
+	}
+	if got["nm-nf"].OldCents != 100 {
+		t.Errorf("OldCents = %d, want 100", got["nm-nf"].OldCents)
+	}
+
+	if len(result.Skipped) != 3 {
+		t.Fatalf("Skipped = %+v, want 3", result.Skipped)
+	}
+	reasons := make(map[string]string)
+	for _, skip := range result.Skipped {
+		reasons[skip.Item.ID] = skip.Reason
+	}
+	if reasons["already"] != "price already matches rules" {
+		t.Errorf("already reason = %q", reasons["already"])
+	}
+	if reasons["no-market"] != "no rule changed the price" {
+		t.Errorf("no-market reason = %q", reasons["no-market"])
+	}
+
+	bulk := result.BulkItems()
+	if len(bulk) != 3 {
+		t.Fatalf("BulkItems() = %+v", bulk)
+	}
+	for _, item := range bulk {
+		if item.Quantity != 2 {
+			t.Errorf("sku %d quantity = %d, want 2", item.TCGPlayerSKU, item.Quantity)
+		}
+	}
+}
+
+func TestResult_Upserts(t *testing.T) {
+	bySKU := single("sku", 7, "NM", "NF", 100)
+	byProduct := single("product", 0, "NM", "NF", 100)
+	byProduct.ProductID = "p-1"
+	byProduct.Product.TCGPlayerSKU = nil
+	result := &Result{Updates: []Update{
+		{Item: bySKU, OldCents: 100, NewCents: 150},
+		{Item: byProduct, OldCents: 100, NewCents: 250},
+	}}
+
+	want := []manapool.InventoryUpsert{
+		{TCGPlayerSKU: 7, PriceCents: 150, Quantity: 2},
+		{ProductType: "mtg_single", ProductID: "p-1", PriceCents: 250, Quantity: 2},
+	}
+	got := result.Upserts()
+	if len(got) != len(want) {
+		t.Fatalf("Upserts() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Upserts()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if len(result.BulkItems()) != 1 {
+		t.Errorf("BulkItems() = %+v, want only the SKU listing", result.BulkItems())
+	}
+}
+
+func TestRepricer_WithGuard(t *testing.T) {
+	items := []manapool.InventoryItem{
+		single("crash", 1, "NM", "NF", 1000),
+		single("ok", 2, "NM", "NF", 1000),
+	}
+	source := mapSource(map[string]int{"crash": 1, "ok": 1100})
+
+	clamp, err := manapool.NewPriceGuard(manapool.WithPriceFloor(25), manapool.WithMaxChangePercent(20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := New(source, PercentOfMarket(100)).WithGuard(clamp).Evaluate(context.Background(), items)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if len(result.Updates) != 2 || result.Updates[0].NewCents != 800 || result.Updates[1].NewCents != 1100 {
+		t.Fatalf("updates = %+v", result.Updates)
+	}
+	clamped := result.Clamped()
+	if len(clamped) != 1 || clamped[0].Item.ID != "crash" || !strings.Contains(clamped[0].Explanation[1], "exceeds 20%") {
+		t.Errorf("Clamped() = %+v", clamped)
+	}
+
+	reject, err := manapool.NewPriceGuard(manapool.WithMaxChangePercent(20), manapool.RejectOutOfBounds())
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err = New(source, PercentOfMarket(100)).WithGuard(reject).Evaluate(context.Background(), items)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if len(result.Updates) != 1 || len(result.Skipped) != 1 ||
+		!strings.HasPrefix(result.Skipped[0].Reason, "rejected by price guard: change from") {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestRepricer_WithPrices(t *testing.T) {
+	items := []manapool.InventoryItem{
+		single("a", 1, "NM", "NF", 100),
+		single("b", 2, "LP", "NF", 100),
+		single("a-copy", 3, "NM", "NF", 100),
+	}
+	items[2].ProductID = items[0].ProductID
+	fixture := manapooltest.NewPriceFixture(map[manapool.ProductKey]manapool.ReferencePrice{
+		manapool.ProductKeyFor(items[0]): {Cents: 400},
+	})
+
+	// The per-item source must not be consulted once a batch source is set.
+	unused := PriceSourceFunc(func(context.Context, manapool.InventoryItem) (int, bool, error) {
+		t.Error("per-item source called")
+		return 0, false, nil
+	})
+	result, err := New(unused, PercentOfMarket(50)).WithPrices(fixture).Evaluate(context.Background(), items)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if len(result.Updates) != 2 || result.Updates[0].NewCents != 200 || result.Updates[1].Item.ID != "a-copy" {
+		t.Errorf("updates = %+v", result.Updates)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Item.ID != "b" {
+		t.Errorf("skipped = %+v", result.Skipped)
+	}
+	if calls := fixture.Calls(); len(calls) != 1 || len(calls[0]) != 2 {
+		t.Errorf("price lookups = %+v, want one batch of 2 keys", calls)
+	}
+
+	fixture.Fail(errors.New("feed down"))
+	if _, err := New(nil, PercentOfMarket(50)).WithPrices(fixture).Price(context.Background(), items[0]); err == nil ||
+		!strings.Contains(err.Error(), "feed down") {
+		t.Errorf("Price() error = %v", err)
+	}
+	update, err := New(nil, PercentOfMarket(50)).WithPrices(manapooltest.NoPrices).Price(context.Background(), items[0])
+	if err != nil || update.NewCents != 100 {
+		t.Errorf("Price(NoPrices) = %+v, %v", update, err)
+	}
+}
+
+func TestRepricer_SourceError(t *testing.T) {
+	source := PriceSourceFunc(func(context.Context, manapool.InventoryItem) (int, bool, error) {
+		return 0, false, errors.New("boom")
+	})
+	_, err := New(source, PercentOfMarket(100)).Evaluate(context.Background(),
+		[]manapool.InventoryItem{single("a", 1, "NM", "NF", 100)})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("error = %v, want wrapped boom", err)
+	}
+}
+
+func TestRepricer_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := New(nil, Fixed(100)).Evaluate(ctx, []manapool.InventoryItem{single("a", 1, "NM", "NF", 50)})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+}
+
+func TestRules(t *testing.T) {
+	nm := Quote{Item: single("a", 1, "NM", "NF", 100), MarketCents: 1000, HasMarket: true}
+	noMarket := Quote{Item: single("b", 2, "NM", "NF", 100)}
+	sealed := Quote{Item: manapool.InventoryItem{ProductType: "mtg_sealed"}}
+
+	tests := []struct {
+		name    string
+		rule    PriceRule
+		quote   Quote
+		cents   int
+		want    int
+		applied bool
+	}{
+		{"percent", PercentOfMarket(50), nm, 100, 500, true},
+		{"percent no market", PercentOfMarket(50), noMarket, 100, 100, false},
+		{"offset", OffsetFromMarket(25), nm, 100, 1025, true},
+		{"offset no market", OffsetFromMarket(25), noMarket, 100, 100, false},
+		{"offset to zero", OffsetFromMarket(-1000), nm, 100, 100, false},
+		{"offset below zero", OffsetFromMarket(-1500), nm, 100, 100, false},
+		{"fixed", Fixed(42), nm, 100, 42, true},
+		{"floor raises", Floor(200), nm, 100, 200, true},
+		{"floor keeps", Floor(50), nm, 100, 100, false},
+		{"ceiling lowers", Ceiling(50), nm, 100, 50, true},
+		{"ceiling keeps", Ceiling(200), nm, 100, 100, false},
+		{"round", Round(manapool.RoundToEnding(99)), nm, 487, 499, true},
+		{"round keeps", Round(manapool.RoundToNearest(5)), nm, 100, 100, false},
+		{"chain empty", Chain(), nm, 100, 100, false},
+		{"first match", FirstMatch(PercentOfMarket(10), Fixed(1)), nm, 100, 100, true},
+		{"first match falls through", FirstMatch(PercentOfMarket(10), Fixed(1)), noMarket, 100, 1, true},
+		{"when or", When(Or(Condition("LP"), Finish("NF")), Fixed(7)), nm, 100, 7, true},
+		{"when not", When(Not(Condition("NM")), Fixed(7)), nm, 100, 100, false},
+		{"when language", When(Language("EN"), Fixed(7)), nm, 100, 7, true},
+		{"when set", When(Set("lea"), Fixed(7)), nm, 100, 7, true},
+		{"when set sealed", When(Set("lea"), Fixed(7)), sealed, 100, 100, false},
+		{"when condition sealed", When(Condition("NM"), Fixed(7)), sealed, 100, 100, false},
+		{"when product type", When(ProductType("mtg_sealed"), Fixed(7)), sealed, 100, 7, true},
+		{"when market below", When(MarketBelow(1001), Fixed(7)), nm, 100, 7, true},
+		{"when market at least", When(MarketAtLeast(1001), Fixed(7)), nm, 100, 100, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, why, applied := tt.rule.Apply(tt.quote, tt.cents)
+			if applied != tt.applied {
+				t.Fatalf("applied = %v, want %v", applied, tt.applied)
+			}
+			if got != tt.want {
+				t.Errorf("price = %d, want %d", got, tt.want)
+			}
+			if applied && why == "" {
+				t.Error("applied rule returned empty explanation")
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package repricer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/repricah/manapool"
+)
+
+// Matcher selects the quotes a conditional rule applies to.
+type Matcher func(q Quote) bool
+
+// PercentOfMarket sets the price to pct percent of the market price, rounded
+// to the nearest cent with ties to even (see manapool.PercentOfCents). pct is
+// taken to the nearest basis point. It does not apply if the market price is
+// unknown.
+func PercentOfMarket(pct float64) PriceRule {
+	return RuleFunc(func(q Quote, cents int) (int, string, bool) {
+		if !q.HasMarket {
+			return cents, "", false
+		}
+		newCents := manapool.PercentOfCents(q.MarketCents, manapool.PercentToBasisPoints(pct))
+		return newCents, fmt.Sprintf("%g%% of market %s", pct, manapool.USDCents(q.MarketCents).String()), true
+	})
+}
+
+// OffsetFromMarket sets the price to the market price plus delta cents
+// (which may be negative). It does not apply if the market price is unknown
+// or the offset price would be zero or less.
+func OffsetFromMarket(delta int) PriceRule {
+	return RuleFunc(func(q Quote, cents int) (int, string, bool) {
+		if !q.HasMarket || q.MarketCents+delta <= 0 {
+			return cents, "", false
+		}
+		return q.MarketCents + delta, fmt.Sprintf("market %s %+d cents", manapool.USDCents(q.MarketCents).String(), delta), true
+	})
+}
+
+// Fixed sets the price to cents.
+func Fixed(cents int) PriceRule {
+	return RuleFunc(func(q Quote, _ int) (int, string, bool) {
+		return cents, "fixed " + manapool.USDCents(cents).String(), true
+	})
+}
+
+// Floor raises the price to at least cents.
+func Floor(cents int) PriceRule {
+	return RuleFunc(func(q Quote, current int) (int, string, bool) {
+		if current >= cents {
+			return current, "", false
+		}
+		return cents, "floor " + manapool.USDCents(cents).String(), true
+	})
+}
+
+// Ceiling lowers the price to at most cents.
+func Ceiling(cents int) PriceRule {
+	return RuleFunc(func(q Quote, current int) (int, string, bool) {
+		if current <= cents {
+			return current, "", false
+		}
+		return cents, "ceiling " + manapool.USDCents(cents).String(), true
+	})
+}
+
+// Round rounds the price with r, such as manapool.RoundToEnding(49, 99), so
+// percentage rules do not leave prices like $4.87. Place it after the rules
+// that compute the price and before Floor and Ceiling, which should have the
+// last word.
+func Round(r manapool.Rounder) PriceRule {
+	return RuleFunc(func(q Quote, cents int) (int, string, bool) {
+		rounded := r.Round(cents)
+		if rounded == cents {
+			return cents, "", false
+		}
+		return rounded, "rounded to " + manapool.USDCents(rounded).String(), true
+	})
+}
+
+// Chain groups rules so they can be passed around as a single rule.
+func Chain(rules ...PriceRule) PriceRule {
+	return RuleFunc(func(q Quote, cents int) (int, string, bool) {
+		newCents, explanation := applyRules(rules, q, cents, nil)
+		if len(explanation) == 0 {
+			return cents, "", false
+		}
+		return newCents, strings.Join(explanation, ", "), true
+	})
+}
+
+// When applies rules only to quotes matching match.
+func When(match Matcher, rules ...PriceRule) PriceRule {
+	chain := Chain(rules...)
+	return RuleFunc(func(q Quote, cents int) (int, string, bool) {
+		if !match(q) {
+			return cents, "", false
+		}
+		return chain.Apply(q, cents)
+	})
+}
+
+// FirstMatch applies the first rule that changes the price and ignores the
+// rest, which is useful for mutually exclusive tiers.
+func FirstMatch(rules ...PriceRule) PriceRule {
+	return RuleFunc(func(q Quote, cents int) (int, string, bool) {
+		for _, rule := range rules {
+			if newCents, why, applied := rule.Apply(q, cents); applied {
+				return newCents, why, true
+			}
+		}
+		return cents, "", false
+	})
+}
+
+// And matches quotes that satisfy every matcher.
+func And(matchers ...Matcher) Matcher {
+	return func(q Quote) bool {
+		for _, m := range matchers {
+			if !m(q) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or matches quotes that satisfy any matcher.
+func Or(matchers ...Matcher) Matcher {
+	return func(q Quote) bool {
+		for _, m := range matchers {
+			if m(q) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not inverts a matcher.
+func Not(m Matcher) Matcher {
+	return func(q Quote) bool {
+		return !m(q)
+	}
+}
+
+// Condition matches singles with one of the given condition IDs (e.g. "NM").
+func Condition(ids ...string) Matcher {
+	return singleField(func(s *manapool.Single) string { return s.ConditionID }, ids)
+}
+
+// Finish matches singles with one of the given finish IDs (e.g. "NF", "FO").
+func Finish(ids ...string) Matcher {
+	return singleField(func(s *manapool.Single) string { return s.FinishID }, ids)
+}
+
+// Language matches singles with one of the given language IDs (e.g. "EN").
+func Language(ids ...string) Matcher {
+	return singleField(func(s *manapool.Single) string { return s.LanguageID }, ids)
+}
+
+// Set matches singles from one of the given set codes, case-insensitively.
+func Set(codes ...string) Matcher {
+	return func(q Quote) bool {
+		single := q.Item.Product.Single
+		if single == nil {
+			return false
+		}
+		for _, code := range codes {
+			if strings.EqualFold(single.Set, code) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ProductType matches items of the given product type (e.g. "mtg_single").
+func ProductType(productType string) Matcher {
+	return func(q Quote) bool {
+		return q.Item.ProductType == productType
+	}
+}
+
+// MarketBelow matches quotes whose market price is known and below cents.
+func MarketBelow(cents int) Matcher {
+	return func(q Quote) bool {
+		return q.HasMarket && q.MarketCents < cents
+	}
+}
+
+// MarketAtLeast matches quotes whose market price is known and at least cents.
+func MarketAtLeast(cents int) Matcher {
+	return func(q Quote) bool {
+		return q.HasMarket && q.MarketCents >= cents
+	}
+}
+
+func singleField(get func(*manapool.Single) string, values []string) Matcher {
+	return func(q Quote) bool {
+		single := q.Item.Product.Single
+		if single == nil {
+			return false
+		}
+		got := get(single)
+		for _, value := range values {
+			if got == value {
+				return true
+			}
+		}
+		return false
+	}
+}
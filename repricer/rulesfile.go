@@ -0,0 +1,266 @@
+package repricer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/repricah/manapool"
+	"github.com/repricah/manapool/config"
+)
+
+// RulesError lists every problem found in a rules file.
+type RulesError struct {
+	Errors []config.FieldError
+}
+
+// Error implements the error interface.
+func (e *RulesError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		msgs[i] = fieldErr.Error()
+	}
+	return "invalid rules: " + strings.Join(msgs, "; ")
+}
+
+// LoadRules reads a rules file, choosing the format by extension as
+// config.Load does.
+//
+// Example:
+//
+//	rules, err := repricer.LoadRules("rules.yaml")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	result, err := repricer.New(index, rules).Evaluate(ctx, inventory)
+func LoadRules(path string) (PriceRule, error) {
+	format, err := config.FormatForPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rules: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return ParseRules(f, format)
+}
+
+// ParseRules decodes a rules file from r. Unknown keys, duplicate keys, and
+// malformed values are reported together in a *RulesError.
+//
+// Rules files let sellers configure repricing without writing Go. They use
+// the same TOML, YAML, and JSON subset as config files. Each section is a
+// pricing tier: its filter keys select the items it applies to and its price
+// keys set their price. Tiers are tried in file order and the first whose
+// rules apply wins. Top-level floor and ceiling keys then apply to every
+// item. JSON objects are unordered, so JSON tiers are tried in name order.
+//
+// Filter keys, whose lists are comma-separated: set, condition, finish,
+// language, product_type, market_below, market_at_least.
+//
+// Price keys, in dollars: fixed, percent_of_market, or offset_from_market
+// (at most one), then floor and ceiling.
+//
+// Example:
+//
+//	# rules.yaml
+//	floor: 0.25
+//	foils:
+//	  finish: FO, EF
+//	  percent_of_market: 110
+//	bulk:
+//	  market_below: 1.00
+//	  fixed: 0.50
+//	default:
+//	  percent_of_market: 95
+func ParseRules(r io.Reader, format config.Format) (PriceRule, error) {
+	settings, err := config.ParseSettings(r, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rules: %w", err)
+	}
+
+	var problems []config.FieldError
+	fail := func(s config.Setting, format string, args ...interface{}) {
+		problems = append(problems, config.FieldError{Key: s.Key, Line: s.Line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	global := &ruleTier{}
+	var tiers []*ruleTier
+	byName := make(map[string]*ruleTier)
+	seen := make(map[string]int)
+	for _, s := range settings {
+		if first, dup := seen[s.Key]; dup {
+			fail(s, "duplicate key (first set on line %d)", first)
+			continue
+		}
+		seen[s.Key] = s.Line
+
+		name, key, inTier := strings.Cut(s.Key, ".")
+		if !inTier {
+			if key = name; key != "floor" && key != "ceiling" {
+				fail(s, "only floor and ceiling may be set outside a section")
+				continue
+			}
+			if err := global.set(key, s.Value); err != nil {
+				fail(s, "%v", err)
+			}
+			continue
+		}
+
+		tier, ok := byName[name]
+		if !ok {
+			tier = &ruleTier{name: name, line: s.Line}
+			byName[name] = tier
+			tiers = append(tiers, tier)
+		}
+		if err := tier.set(key, s.Value); err != nil {
+			fail(s, "%v", err)
+		}
+	}
+
+	rules := make([]PriceRule, 0, len(tiers))
+	for _, tier := range tiers {
+		rule, err := tier.rule()
+		if err != nil {
+			problems = append(problems, config.FieldError{Key: tier.name, Line: tier.line, Message: err.Error()})
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	guards, err := global.priceRules()
+	if err != nil {
+		problems = append(problems, config.FieldError{Message: err.Error()})
+	}
+	if len(problems) > 0 {
+		return nil, &RulesError{Errors: problems}
+	}
+
+	return Chain(append([]PriceRule{FirstMatch(rules...)}, guards...)...), nil
+}
+
+// ruleTier is one section of a rules file.
+type ruleTier struct {
+	name string
+	line int
+
+	matchers []Matcher
+
+	// price is the fixed, percent_of_market, or offset_from_market rule
+	price    PriceRule
+	priceKey string
+
+	floor, ceiling *int
+}
+
+// set applies one key of the section.
+func (t *ruleTier) set(key, value string) error {
+	switch key {
+	case "set", "condition", "finish", "language":
+		values := splitList(value)
+		if len(values) == 0 {
+			return fmt.Errorf("must list at least one value")
+		}
+		matcher := map[string]func(...string) Matcher{
+			"set": Set, "condition": Condition, "finish": Finish, "language": Language,
+		}[key]
+		t.matchers = append(t.matchers, matcher(values...))
+	case "product_type":
+		t.matchers = append(t.matchers, ProductType(strings.TrimSpace(value)))
+	case "market_below", "market_at_least":
+		cents, err := manapool.ParseDollars(value)
+		if err != nil {
+			return err
+		}
+		if key == "market_below" {
+			t.matchers = append(t.matchers, MarketBelow(cents))
+		} else {
+			t.matchers = append(t.matchers, MarketAtLeast(cents))
+		}
+
+	case "fixed", "offset_from_market", "percent_of_market":
+		if t.price != nil {
+			return fmt.Errorf("conflicts with %s", t.priceKey)
+		}
+		switch key {
+		case "percent_of_market":
+			pct, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || pct <= 0 {
+				return fmt.Errorf("must be a positive number")
+			}
+			t.price = PercentOfMarket(pct)
+		default:
+			cents, err := manapool.ParseDollars(value)
+			if err != nil {
+				return err
+			}
+			if key == "fixed" {
+				t.price = Fixed(cents)
+			} else {
+				t.price = OffsetFromMarket(cents)
+			}
+		}
+		t.priceKey = key
+
+	case "floor", "ceiling":
+		cents, err := manapool.ParseDollars(value)
+		if err != nil {
+			return err
+		}
+		if key == "floor" {
+			t.floor = &cents
+		} else {
+			t.ceiling = &cents
+		}
+
+	default:
+		return fmt.Errorf("unknown key")
+	}
+	return nil
+}
+
+// priceRules returns the section's price rules in evaluation order.
+func (t *ruleTier) priceRules() ([]PriceRule, error) {
+	var rules []PriceRule
+	if t.price != nil {
+		rules = append(rules, t.price)
+	}
+	if t.floor != nil {
+		rules = append(rules, Floor(*t.floor))
+	}
+	if t.ceiling != nil {
+		if t.floor != nil && *t.ceiling < *t.floor {
+			return nil, fmt.Errorf("ceiling is below floor")
+		}
+		rules = append(rules, Ceiling(*t.ceiling))
+	}
+	return rules, nil
+}
+
+// rule returns the section as a single conditional rule.
+func (t *ruleTier) rule() (PriceRule, error) {
+	rules, err := t.priceRules()
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("section sets no price")
+	}
+	return When(And(t.matchers...), rules...), nil
+}
+
+// splitList splits a comma-separated list, dropping blanks.
+func splitList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
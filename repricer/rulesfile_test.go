@@ -0,0 +1,144 @@
+package repricer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool"
+	"github.com/repricah/manapool/config"
+)
+
+const testRulesYAML = `# tiers are tried in order
+floor: 0.25
+ceiling: 100
+foils:
+  finish: FO, EF
+  percent_of_market: 110
+bulk:
+  market_below: 1.00
+  fixed: 0.50
+default:
+  product_type: mtg_single
+  offset_from_market: -0.10
+  floor: 0.30
+`
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules(strings.NewReader(testRulesYAML), config.FormatYAML)
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+
+	items := []manapool.InventoryItem{
+		single("foil", 1, "NM", "FO", 100),
+		single("bulk", 2, "NM", "NF", 100),
+		single("regular", 3, "NM", "NF", 100),
+		single("cheap", 4, "NM", "NF", 100),
+		single("pricey", 5, "NM", "FO", 100),
+		single("unknown", 6, "NM", "NF", 10),
+	}
+	source := mapSource(map[string]int{
+		"foil":    1000,
+		"bulk":    40,
+		"regular": 500,
+		"cheap":   120,
+		"pricey":  20000,
+	})
+
+	result, err := New(source, rules).Evaluate(context.Background(), items)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	got := make(map[string]int)
+	for _, update := range result.Updates {
+		got[update.Item.ID] = update.NewCents
+	}
+	want := map[string]int{
+		"foil":    1100,
+		"bulk":    50,
+		"regular": 490,
+		"cheap":   110,
+		"pricey":  10000,
+		"unknown": 30,
+	}
+	for id, cents := range want {
+		if got[id] != cents {
+			t.Errorf("%s = %d, want %d", id, got[id], cents)
+		}
+	}
+}
+
+func TestParseRules_JSONAndTOML(t *testing.T) {
+	item := single("x", 1, "LP", "NF", 100)
+	source := mapSource(map[string]int{"x": 1000})
+
+	for _, tt := range []struct {
+		format config.Format
+		input  string
+	}{
+		{config.FormatTOML, "[lp]\ncondition = \"LP\"\nfixed = \"$2\"\n"},
+		{config.FormatJSON, `{"lp":{"condition":"LP","fixed":2}}`},
+	} {
+		rules, err := ParseRules(strings.NewReader(tt.input), tt.format)
+		if err != nil {
+			t.Fatalf("ParseRules(%s) error = %v", tt.format, err)
+		}
+		update, err := New(source, rules).Price(context.Background(), item)
+		if err != nil || update.NewCents != 200 {
+			t.Errorf("%s: Price() = %+v, %v", tt.format, update, err)
+		}
+	}
+}
+
+func TestParseRules_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"unknown key", "a:\n  fixed: 1\n  colour: red\n", "line 3: a.colour: unknown key"},
+		{"global key", "fixed: 1\n", "only floor and ceiling"},
+		{"no price", "a:\n  condition: NM\n", "line 2: a: section sets no price"},
+		{"two prices", "a:\n  fixed: 1\n  percent_of_market: 90\n", "conflicts with fixed"},
+		{"bad amount", "a:\n  fixed: 1.234\n", "invalid amount"},
+		{"bad percent", "a:\n  percent_of_market: -5\n", "must be a positive number"},
+		{"empty list", "a:\n  set: ' , '\n  fixed: 1\n", "at least one value"},
+		{"inverted", "a:\n  floor: 2\n  ceiling: 1\n", "ceiling is below floor"},
+		{"inverted global", "floor: 2\nceiling: 1\n", "ceiling is below floor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRules(strings.NewReader(tt.input), config.FormatYAML)
+			var rulesErr *RulesError
+			if !errors.As(err, &rulesErr) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseRules() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseRules(strings.NewReader("[a\n"), config.FormatTOML); err == nil || !strings.Contains(err.Error(), "failed to parse rules") {
+		t.Errorf("ParseRules(bad toml) error = %v", err)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rules.yml")
+	if err := os.WriteFile(path, []byte(testRulesYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadRules(path); err != nil {
+		t.Errorf("LoadRules() error = %v", err)
+	}
+
+	if _, err := LoadRules(filepath.Join(dir, "missing.yaml")); err == nil || !strings.Contains(err.Error(), "failed to open rules") {
+		t.Errorf("LoadRules(missing) error = %v", err)
+	}
+	if _, err := LoadRules(filepath.Join(dir, "rules.ini")); err == nil {
+		t.Error("LoadRules(.ini) error = nil")
+	}
+}
@@ -0,0 +1,92 @@
+package manapool
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestOption overrides client behavior for the requests made with a
+// context returned by WithRequestOptions.
+type RequestOption func(*requestOptions)
+
+// requestOptions holds the per-request overrides stored in a context.
+type requestOptions struct {
+	timeout     time.Duration
+	header      http.Header
+	noRetry     bool
+	bypassCache bool
+	auditActor  string
+}
+
+// requestOptionsKey is the context key for requestOptions.
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a copy of ctx that applies opts to every client
+// request made with it. Options from an enclosing WithRequestOptions call are
+// kept, and opts are applied on top of them.
+//
+// This lets interactive and batch callers share one client while choosing a
+// different policy per call.
+//
+// Example:
+//
+//	// Fail fast for an interactive lookup
+//	ctx := manapool.WithRequestOptions(ctx,
+//	    manapool.RequestTimeout(2*time.Second),
+//	    manapool.NoRetry(),
+//	    manapool.BypassCache(),
+//	)
+//	account, err := client.GetSellerAccount(ctx)
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	ro := &requestOptions{}
+	if parent, ok := ctx.Value(requestOptionsKey{}).(*requestOptions); ok {
+		*ro = *parent
+		ro.header = parent.header.Clone()
+	}
+	for _, opt := range opts {
+		opt(ro)
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, ro)
+}
+
+// requestOptionsFrom returns the request options stored in ctx, or nil.
+func requestOptionsFrom(ctx context.Context) *requestOptions {
+	ro, _ := ctx.Value(requestOptionsKey{}).(*requestOptions)
+	return ro
+}
+
+// RequestTimeout overrides the HTTP client timeout for the request. The
+// timeout covers each attempt, including reading the response body. A
+// non-positive timeout keeps the client's timeout.
+func RequestTimeout(timeout time.Duration) RequestOption {
+	return func(ro *requestOptions) {
+		ro.timeout = timeout
+	}
+}
+
+// RequestHeader adds a header to the request. The authentication headers
+// cannot be overridden.
+func RequestHeader(key, value string) RequestOption {
+	return func(ro *requestOptions) {
+		if ro.header == nil {
+			ro.header = http.Header{}
+		}
+		ro.header.Add(key, value)
+	}
+}
+
+// NoRetry disables automatic retries for the request.
+func NoRetry() RequestOption {
+	return func(ro *requestOptions) {
+		ro.noRetry = true
+	}
+}
+
+// BypassCache skips the response cache and ETag revalidation for the
+// request. The fresh response is still stored, so later requests see it.
+func BypassCache() RequestOption {
+	return func(ro *requestOptions) {
+		ro.bypassCache = true
+	}
+}
@@ -0,0 +1,122 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRequestOptions_Headers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Request-Id"); got != "abc" {
+			t.Errorf("X-Request-Id = %q, want abc", got)
+		}
+		if got := r.Header.Values("X-Trace"); len(got) != 2 {
+			t.Errorf("X-Trace = %v, want two values", got)
+		}
+		if got := r.Header.Get("X-ManaPool-Access-Token"); got != "token" {
+			t.Errorf("access token overridden: %q", got)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	ctx := WithRequestOptions(context.Background(), RequestHeader("X-Trace", "1"))
+	ctx = WithRequestOptions(ctx,
+		RequestHeader("x-request-id", "abc"),
+		RequestHeader("X-Trace", "2"),
+		RequestHeader("X-ManaPool-Access-Token", "stolen"),
+	)
+	if _, err := client.GetSellerAccount(ctx); err != nil {
+		t.Fatalf("GetSellerAccount() error = %v", err)
+	}
+}
+
+func TestWithRequestOptions_NoRetry(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(3, time.Millisecond))
+	_, err := client.GetSellerAccount(WithRequestOptions(context.Background(), NoRetry()))
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("error = %v, want 503 APIError", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestWithRequestOptions_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithTimeout(10*time.Millisecond),
+		WithRetry(0, 0),
+	)
+	if _, err := client.GetSellerAccount(context.Background()); err == nil {
+		t.Fatal("GetSellerAccount() with client timeout error = nil")
+	}
+
+	ctx := WithRequestOptions(context.Background(), RequestTimeout(5*time.Second))
+	if _, err := client.GetSellerAccount(ctx); err != nil {
+		t.Fatalf("GetSellerAccount() with longer request timeout error = %v", err)
+	}
+	if client.httpClient.Timeout != 10*time.Millisecond {
+		t.Errorf("client timeout changed to %v", client.httpClient.Timeout)
+	}
+}
+
+func TestWithRequestOptions_BypassCache(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			t.Error("If-None-Match sent with BypassCache")
+		}
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"username":"seller"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email",
+		WithBaseURL(server.URL+"/"),
+		WithCache(NewMemoryCache(10), nil),
+	)
+	ctx := context.Background()
+	bypass := WithRequestOptions(ctx, BypassCache())
+
+	for _, c := range []context.Context{ctx, ctx, bypass, ctx} {
+		if _, err := client.GetSellerAccount(c); err != nil {
+			t.Fatalf("GetSellerAccount() error = %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+
+	etagClient := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithConditionalRequests())
+	for i := 0; i < 2; i++ {
+		if _, err := etagClient.GetSellerAccount(bypass); err != nil {
+			t.Fatalf("GetSellerAccount() error = %v", err)
+		}
+	}
+}
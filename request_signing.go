@@ -0,0 +1,61 @@
+package manapool
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Request signature headers, set by clients created with WithRequestSigning.
+// They use the same names and format as the webhook signature headers.
+const (
+	RequestTimestampHeader = WebhookTimestampHeader
+	RequestSignatureHeader = WebhookSignatureHeader
+)
+
+// ErrInvalidRequestSignature is wrapped by the errors VerifyRequestSignature
+// returns for a missing, malformed, stale, or mismatched signature.
+var ErrInvalidRequestSignature = errors.New("invalid request signature")
+
+// signRequest sets the signature headers on req for body at now.
+func signRequest(req *http.Request, secret string, body []byte, now time.Time) {
+	req.Header.Set(RequestTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(RequestSignatureHeader, formatSignature(secret, now, canonicalRequest(req, body)))
+}
+
+// canonicalRequest is the payload signed for a request: the method, the
+// escaped path, the query parameters sorted by key, and the hex SHA-256 of
+// the body as sent (after any compression), separated by newlines.
+func canonicalRequest(req *http.Request, body []byte) []byte {
+	sum := sha256.Sum256(body)
+	return []byte(req.Method + "\n" +
+		req.URL.EscapedPath() + "\n" +
+		req.URL.Query().Encode() + "\n" +
+		hex.EncodeToString(sum[:]))
+}
+
+// VerifyRequestSignature checks the X-ManaPool-Signature header of a request
+// signed by a client created with WithRequestSigning, where body is the
+// request body as received. It returns an error wrapping
+// ErrInvalidRequestSignature unless the digest matches and its timestamp is
+// within tolerance of now; a tolerance of zero or less skips the age check.
+//
+// This is for proxies and test servers that sit in front of the API.
+//
+// Example:
+//
+//	body, err := io.ReadAll(r.Body)
+//	if err != nil {
+//	    http.Error(w, err.Error(), http.StatusBadRequest)
+//	    return
+//	}
+//	if _, err := manapool.VerifyRequestSignature(secret, r, body, 5*time.Minute); err != nil {
+//	    http.Error(w, err.Error(), http.StatusUnauthorized)
+//	    return
+//	}
+func VerifyRequestSignature(secret string, r *http.Request, body []byte, tolerance time.Duration) (time.Time, error) {
+	return verifySignature(secret, r.Header.Get(RequestSignatureHeader), canonicalRequest(r, body), tolerance, ErrInvalidRequestSignature)
+}
@@ -0,0 +1,125 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_RequestSigning(t *testing.T) {
+	var verifyErrs []error
+	var signatures []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, err := VerifyRequestSignature("s3cret", r, body, time.Minute)
+		verifyErrs = append(verifyErrs, err)
+		signatures = append(signatures, r.Header.Get(RequestSignatureHeader))
+		if r.Header.Get(RequestTimestampHeader) == "" {
+			t.Errorf("%s %s: missing timestamp header", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRequestSigning("s3cret"),
+		WithRequestCompression(64))
+	ctx := context.Background()
+
+	if _, err := client.GetSellerInventory(ctx, InventoryOptions{Limit: 10, Offset: 20}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.UpdateInventoryBySKU(ctx, 123, InventoryUpdateRequest{PriceCents: 125, Quantity: 4}); err != nil {
+		t.Fatal(err)
+	}
+	bulk := make([]InventoryBulkItemBySKU, 5)
+	for i := range bulk {
+		bulk[i] = InventoryBulkItemBySKU{TCGPlayerSKU: i + 1, PriceCents: 100, Quantity: 1}
+	}
+	// The bulk body is gzipped, and the signature covers the compressed bytes.
+	if _, err := client.CreateInventoryBulkBySKU(ctx, bulk); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(verifyErrs) != 3 {
+		t.Fatalf("requests = %d, want 3", len(verifyErrs))
+	}
+	for i, err := range verifyErrs {
+		if err != nil {
+			t.Errorf("request %d: VerifyRequestSignature() error = %v", i, err)
+		}
+		if !strings.HasPrefix(signatures[i], "t=") || !strings.Contains(signatures[i], ",v1=") {
+			t.Errorf("request %d: signature = %q", i, signatures[i])
+		}
+	}
+}
+
+func TestClient_RequestSigning_Disabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sig := r.Header.Get(RequestSignatureHeader); sig != "" {
+			t.Errorf("unexpected signature %q", sig)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRequestSigning(""))
+	if _, err := client.GetSellerAccount(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyRequestSignature(t *testing.T) {
+	body := []byte(`{"price_cents":125}`)
+	newRequest := func(target string) *http.Request {
+		req := httptest.NewRequest(http.MethodPut, target, nil)
+		signRequest(req, "s3cret", body, time.Now())
+		return req
+	}
+
+	// Query parameter order does not matter.
+	req := newRequest("/api/v1/inventory/tcgsku/1?b=2&a=1")
+	req.URL.RawQuery = "a=1&b=2"
+	if _, err := VerifyRequestSignature("s3cret", req, body, time.Minute); err != nil {
+		t.Errorf("reordered query: error = %v", err)
+	}
+
+	stale := httptest.NewRequest(http.MethodPut, "/api/v1/inventory/tcgsku/1", nil)
+	signRequest(stale, "s3cret", body, time.Now().Add(-time.Hour))
+
+	tests := []struct {
+		name   string
+		req    *http.Request
+		secret string
+		body   []byte
+	}{
+		{"wrong secret", newRequest("/api/v1/inventory/tcgsku/1"), "other", body},
+		{"tampered body", newRequest("/api/v1/inventory/tcgsku/1"), "s3cret", []byte(`{"price_cents":1}`)},
+		{"stale", stale, "s3cret", body},
+		{"unsigned", httptest.NewRequest(http.MethodPut, "/api/v1/inventory/tcgsku/1", nil), "s3cret", body},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := VerifyRequestSignature(tt.secret, tt.req, tt.body, time.Minute)
+			if !errors.Is(err, ErrInvalidRequestSignature) {
+				t.Errorf("error = %v, want ErrInvalidRequestSignature", err)
+			}
+		})
+	}
+
+	tampered := newRequest("/api/v1/inventory/tcgsku/1")
+	tampered.URL.Path = "/api/v1/inventory/tcgsku/2"
+	if _, err := VerifyRequestSignature("s3cret", tampered, body, time.Minute); !errors.Is(err, ErrInvalidRequestSignature) {
+		t.Errorf("tampered path: error = %v", err)
+	}
+	tampered = newRequest("/api/v1/inventory/tcgsku/1")
+	tampered.URL.RawQuery = url.Values{"x": {"1"}}.Encode()
+	if _, err := VerifyRequestSignature("s3cret", tampered, body, time.Minute); !errors.Is(err, ErrInvalidRequestSignature) {
+		t.Errorf("tampered query: error = %v", err)
+	}
+}
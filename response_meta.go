@@ -0,0 +1,104 @@
+package manapool
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ResponseMeta describes the HTTP response behind a client call.
+type ResponseMeta struct {
+	// Method and URL identify the request
+	Method string
+	URL    string
+
+	// StatusCode is the HTTP status of the final response; 304 when an ETag
+	// revalidation served the cached body
+	StatusCode int
+
+	// RequestID is the API's identifier for the request, from the
+	// X-Request-Id header (empty if absent)
+	RequestID string
+
+	// Latency is the time from the first attempt to the final response,
+	// including rate-limit waits and retries
+	Latency time.Duration
+
+	// Attempts is the number of HTTP attempts made (0 for cache hits)
+	Attempts int
+
+	// FromCache reports whether the response was served from the response
+	// cache or revalidated with an ETag instead of transferred again
+	FromCache bool
+
+	// Header holds the response headers, including any rate-limit headers
+	Header http.Header
+}
+
+// responseMetaKey is the context key for a responseMetaRecorder.
+type responseMetaKey struct{}
+
+// responseMetaRecorder holds the most recent ResponseMeta for a context.
+type responseMetaRecorder struct {
+	mu   sync.Mutex
+	meta ResponseMeta
+	set  bool
+}
+
+// WithResponseMeta returns a copy of ctx that records metadata about the
+// responses to client requests made with it. Read it with MetaFromContext
+// after the call returns. Only errors carry a request ID otherwise, so this
+// is the way to log the request ID, latency, or rate-limit headers of
+// successful calls.
+//
+// Identical concurrent GET requests are not coalesced under such a context,
+// so each call records its own response.
+//
+// Example:
+//
+//	ctx := manapool.WithResponseMeta(ctx)
+//	account, err := client.GetSellerAccount(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if meta, ok := manapool.MetaFromContext(ctx); ok {
+//	    log.Printf("request %s took %s", meta.RequestID, meta.Latency)
+//	}
+func WithResponseMeta(ctx context.Context) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, &responseMetaRecorder{})
+}
+
+// MetaFromContext returns the metadata of the most recent response received
+// with a context returned by WithResponseMeta. It returns false if ctx does
+// not record metadata or no response has been received yet. Calls that make
+// several requests, such as paginated helpers, leave the last one.
+func MetaFromContext(ctx context.Context) (ResponseMeta, bool) {
+	rec, ok := ctx.Value(responseMetaKey{}).(*responseMetaRecorder)
+	if !ok {
+		return ResponseMeta{}, false
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	meta := rec.meta
+	meta.Header = meta.Header.Clone()
+	return meta, rec.set
+}
+
+// recordResponseMeta stores meta in ctx's recorder, if it has one.
+func recordResponseMeta(ctx context.Context, meta ResponseMeta) {
+	rec, ok := ctx.Value(responseMetaKey{}).(*responseMetaRecorder)
+	if !ok {
+		return
+	}
+	meta.Header = meta.Header.Clone()
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.meta, rec.set = meta, true
+}
+
+// recordsResponseMeta reports whether ctx was returned by WithResponseMeta.
+func recordsResponseMeta(ctx context.Context) bool {
+	_, ok := ctx.Value(responseMetaKey{}).(*responseMetaRecorder)
+	return ok
+}
@@ -0,0 +1,53 @@
+package manapool
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithResponseMeta(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("X-Request-Id", "req-123")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		_, _ = w.Write([]byte(`{"username":"seller"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(1, time.Millisecond),
+		WithCache(NewMemoryCache(10), FixedTTL(time.Minute)))
+
+	if _, ok := MetaFromContext(context.Background()); ok {
+		t.Error("MetaFromContext(background) ok = true")
+	}
+	ctx := WithResponseMeta(context.Background())
+	if _, ok := MetaFromContext(ctx); ok {
+		t.Error("MetaFromContext(before request) ok = true")
+	}
+
+	if _, err := client.GetSellerAccount(ctx); err != nil {
+		t.Fatalf("GetSellerAccount() error = %v", err)
+	}
+	meta, ok := MetaFromContext(ctx)
+	if !ok || meta.RequestID != "req-123" || meta.StatusCode != http.StatusOK || meta.Attempts != 2 || meta.FromCache {
+		t.Errorf("meta = %+v", meta)
+	}
+	if meta.Method != http.MethodGet || meta.URL != server.URL+"/account" || meta.Latency <= 0 || meta.Header.Get("X-RateLimit-Remaining") != "42" {
+		t.Errorf("meta = %+v", meta)
+	}
+
+	if _, err := client.GetSellerAccount(ctx); err != nil {
+		t.Fatalf("GetSellerAccount(cached) error = %v", err)
+	}
+	if meta, _ := MetaFromContext(ctx); !meta.FromCache || meta.Attempts != 0 || meta.RequestID != "" {
+		t.Errorf("cached meta = %+v", meta)
+	}
+}
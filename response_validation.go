@@ -0,0 +1,118 @@
+package manapool
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DecodeValidationError lists the problems found in a decoded response by
+// ValidateResponse or a client created with WithResponseValidation.
+type DecodeValidationError struct {
+	// Problems describes each invalid field, prefixed with its JSON path,
+	// such as `inventory[3].price_cents: must be non-negative, got -100`
+	Problems []string
+}
+
+// Error implements the error interface.
+func (e *DecodeValidationError) Error() string {
+	return fmt.Sprintf("invalid response (%d problems): %s", len(e.Problems), strings.Join(e.Problems, "; "))
+}
+
+// ValidateResponse checks a decoded response, or any value built from API
+// types such as a snapshot's items, for data that would be unsafe to store:
+// missing IDs, negative prices or quantities, and condition, finish,
+// language, or fulfillment status values the API does not document. It
+// returns a *DecodeValidationError listing every problem, or nil.
+//
+// Example:
+//
+//	resp, err := client.GetSellerInventory(ctx, opts)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if err := manapool.ValidateResponse(resp); err != nil {
+//	    log.Printf("skipping page: %v", err)
+//	}
+func ValidateResponse(v any) error {
+	var problems []string
+	walkDecoded(reflect.ValueOf(v), "", func(path string, value reflect.Value) {
+		if checker, ok := value.Interface().(enumChecker); ok {
+			problems = append(problems, checker.checkEnums(path)...)
+		}
+		if validator, ok := value.Interface().(fieldValidator); ok {
+			problems = append(problems, validator.validateFields(path)...)
+		}
+	})
+	if len(problems) > 0 {
+		return &DecodeValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// fieldValidator is implemented by decoded types with required or
+// non-negative fields. validateFields returns a problem for each invalid
+// field, prefixed with path.
+type fieldValidator interface {
+	validateFields(path string) []string
+}
+
+// fieldChecks collects field problems for one struct.
+type fieldChecks struct {
+	path     string
+	problems []string
+}
+
+func (c *fieldChecks) required(field, value string) {
+	if value == "" {
+		c.problems = append(c.problems, fieldPath(c.path, field)+": is required")
+	}
+}
+
+func (c *fieldChecks) nonNegative(field string, value int) {
+	if value < 0 {
+		c.problems = append(c.problems, fmt.Sprintf("%s: must be non-negative, got %d", fieldPath(c.path, field), value))
+	}
+}
+
+func (i InventoryItem) validateFields(path string) []string {
+	c := fieldChecks{path: path}
+	c.required("id", i.ID)
+	c.required("product_type", i.ProductType)
+	c.required("product_id", i.ProductID)
+	c.nonNegative("price_cents", i.PriceCents)
+	c.nonNegative("quantity", i.Quantity)
+	return c.problems
+}
+
+func (p Pagination) validateFields(path string) []string {
+	c := fieldChecks{path: path}
+	c.nonNegative("total", p.Total)
+	c.nonNegative("returned", p.Returned)
+	c.nonNegative("offset", p.Offset)
+	return c.problems
+}
+
+func (o OrderSummary) validateFields(path string) []string {
+	c := fieldChecks{path: path}
+	c.required("id", o.ID)
+	c.nonNegative("total_cents", o.TotalCents)
+	return c.problems
+}
+
+func (i OrderItem) validateFields(path string) []string {
+	c := fieldChecks{path: path}
+	c.required("product_id", i.ProductID)
+	c.nonNegative("quantity", i.Quantity)
+	c.nonNegative("price_cents", i.PriceCents)
+	return c.problems
+}
+
+func (p OrderPayment) validateFields(path string) []string {
+	c := fieldChecks{path: path}
+	c.nonNegative("subtotal_cents", p.SubtotalCents)
+	c.nonNegative("shipping_cents", p.ShippingCents)
+	c.nonNegative("total_cents", p.TotalCents)
+	c.nonNegative("fee_cents", p.FeeCents)
+	return c.problems
+}
@@ -0,0 +1,70 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateResponse(t *testing.T) {
+	status := "lost"
+	resp := &OrderDetailsResponse{Order: OrderDetails{
+		OrderSummary: OrderSummary{TotalCents: -5, LatestFulfillmentStatus: &status},
+		Payment:      OrderPayment{FeeCents: -1, NetCents: -1},
+		Items: []OrderItem{
+			{ProductID: "p1", Quantity: 1, Product: Product{Single: &Single{ConditionID: "NM", FinishID: "XF"}}},
+			{Quantity: -2},
+		},
+	}}
+
+	err := ValidateResponse(resp)
+	var invalid *DecodeValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("ValidateResponse() error = %v", err)
+	}
+	want := []string{
+		`order.latest_fulfillment_status: unknown value "lost"`,
+		"order.id: is required",
+		"order.total_cents: must be non-negative, got -5",
+		"order.payment.fee_cents: must be non-negative, got -1",
+		`order.items[0].product.single.finish_id: unknown value "XF"`,
+		"order.items[1].product_id: is required",
+		"order.items[1].quantity: must be non-negative, got -2",
+	}
+	if got := strings.Join(invalid.Problems, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("Problems =\n%s\nwant\n%s", got, strings.Join(want, "\n"))
+	}
+	if !strings.HasPrefix(err.Error(), "invalid response (7 problems): ") {
+		t.Errorf("Error() = %s", err)
+	}
+
+	items := []InventoryItem{{ID: "a", ProductType: "mtg_single", ProductID: "p", PriceCents: 100, Quantity: 1}}
+	if err := ValidateResponse(items); err != nil {
+		t.Errorf("ValidateResponse(valid) error = %v", err)
+	}
+	items[0].PriceCents = -100
+	if err := ValidateResponse(items); err == nil || !strings.Contains(err.Error(), "[0].price_cents: must be non-negative") {
+		t.Errorf("ValidateResponse(negative price) error = %v", err)
+	}
+}
+
+func TestWithResponseValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"inventory":[{"id":"a","product_type":"mtg_single","product_id":"p","price_cents":-1,"quantity":1}],` +
+			`"pagination":{"total":1,"returned":1}}`))
+	}))
+	defer server.Close()
+
+	if _, err := NewClient("token", "email", WithBaseURL(server.URL+"/")).GetSellerInventory(context.Background(), InventoryOptions{}); err != nil {
+		t.Errorf("GetSellerInventory() without validation error = %v", err)
+	}
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithResponseValidation())
+	_, err := client.GetSellerInventory(context.Background(), InventoryOptions{})
+	var invalid *DecodeValidationError
+	if !errors.As(err, &invalid) || len(invalid.Problems) != 1 || invalid.Problems[0] != "inventory[0].price_cents: must be non-negative, got -1" {
+		t.Errorf("GetSellerInventory() error = %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package manapool
+
+import (
+	"slices"
+	"strings"
+)
+
+// RetryPolicy decides which failed requests are retried. The zero policy
+// retries every 5xx response and network error, for every method.
+type RetryPolicy struct {
+	// StatusCodes lists the response statuses to retry, such as 408 Request
+	// Timeout or 503 Service Unavailable. If empty, every 5xx status is
+	// retried. Retries wait for the usual backoff, not Retry-After.
+	StatusCodes []int
+
+	// Methods lists the HTTP methods to retry, such as "GET" and "PUT".
+	// Requests with other methods are sent once, even after a network
+	// error. If empty, every method is retried.
+	Methods []string
+}
+
+// retriesStatus reports whether a response with status should be retried.
+func (p RetryPolicy) retriesStatus(status int) bool {
+	if len(p.StatusCodes) == 0 {
+		return status >= 500
+	}
+	return slices.Contains(p.StatusCodes, status)
+}
+
+// retriesMethod reports whether requests with method may be retried.
+func (p RetryPolicy) retriesMethod(method string) bool {
+	if len(p.Methods) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(p.Methods, func(m string) bool { return strings.EqualFold(m, method) })
+}
+
+// WithRetryPolicy sets which response statuses and HTTP methods are
+// retried, within the retry count set by WithRetry. The policy is copied.
+//
+// Example:
+//
+//	// Retry timeouts and early data rejections, leave 502s from the
+//	// gateway alone, and never resend a POST
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithRetryPolicy(manapool.RetryPolicy{
+//	        StatusCodes: []int{408, 425, 500, 503, 504},
+//	        Methods:     []string{"GET", "HEAD", "PUT", "DELETE"},
+//	    }),
+//	)
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = RetryPolicy{
+			StatusCodes: slices.Clone(policy.StatusCodes),
+			Methods:     slices.Clone(policy.Methods),
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package manapool
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_RetryPolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       RetryPolicy
+		method       string
+		status       int
+		wantAttempts int
+	}{
+		{"default retries 5xx", RetryPolicy{}, http.MethodGet, http.StatusBadGateway, 3},
+		{"default ignores 408", RetryPolicy{}, http.MethodGet, http.StatusRequestTimeout, 1},
+		{"included 408", RetryPolicy{StatusCodes: []int{408, 425, 503}}, http.MethodGet, http.StatusRequestTimeout, 3},
+		{"included 425", RetryPolicy{StatusCodes: []int{408, 425, 503}}, http.MethodGet, http.StatusTooEarly, 3},
+		{"excluded 502", RetryPolicy{StatusCodes: []int{408, 425, 503}}, http.MethodGet, http.StatusBadGateway, 1},
+		{"allowed method", RetryPolicy{Methods: []string{"get", "PUT"}}, http.MethodPut, http.StatusServiceUnavailable, 3},
+		{"excluded method", RetryPolicy{Methods: []string{"GET", "PUT"}}, http.MethodPost, http.StatusServiceUnavailable, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(2, 0),
+				WithRetryPolicy(tt.policy))
+			resp, err := client.doRequest(context.Background(), tt.method, "/test", nil)
+			if err != nil {
+				t.Fatalf("doRequest() error = %v", err)
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode != tt.status || attempts != tt.wantAttempts {
+				t.Errorf("status %d after %d attempts, want %d after %d", resp.StatusCode, attempts, tt.status, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestClient_RetryPolicy_NetworkErrorMethod(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	logger := &testLogger{}
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(2, 0), WithLogger(logger),
+		WithRetryPolicy(RetryPolicy{Methods: []string{http.MethodGet}}))
+	if _, err := client.doRequest(context.Background(), http.MethodPost, "/test", nil); err == nil {
+		t.Fatal("expected network error")
+	}
+	if len(logger.errorMessages) != 1 {
+		t.Errorf("POST made %d attempts, want 1", len(logger.errorMessages))
+	}
+}
+
+func TestWithRetryPolicy_CopiesPolicy(t *testing.T) {
+	codes := []int{503}
+	client := NewClient("token", "email", WithRetryPolicy(RetryPolicy{StatusCodes: codes}))
+	codes[0] = 500
+	if !client.retryPolicy.retriesStatus(503) || client.retryPolicy.retriesStatus(500) {
+		t.Error("policy was not copied")
+	}
+}
@@ -0,0 +1,92 @@
+package manapool
+
+import "fmt"
+
+// Rounder adjusts a computed price, in cents, to a price worth listing.
+// Percentage rules produce prices such as $4.87; a Rounder turns them into
+// $4.85 or $4.99. It is used by the repricer's Round rule and by
+// BulkOptions.Rounder.
+type Rounder interface {
+	Round(cents int) int
+}
+
+// RounderFunc adapts a function to the Rounder interface.
+type RounderFunc func(cents int) int
+
+// Round implements Rounder.
+func (f RounderFunc) Round(cents int) int {
+	return f(cents)
+}
+
+// RoundNearestCent leaves prices unchanged, since they are already whole
+// cents. It is the explicit form of not rounding.
+var RoundNearestCent Rounder = RounderFunc(func(cents int) int { return cents })
+
+// RoundToNearest returns a Rounder that rounds to the nearest multiple of
+// step cents, such as 5 or 25, with halves rounding up. Positive prices never
+// round down to zero. It panics if step is not positive.
+//
+// Example:
+//
+//	manapool.RoundToNearest(25).Round(487) // 475
+func RoundToNearest(step int) Rounder {
+	if step <= 0 {
+		panic(fmt.Sprintf("manapool: rounding step must be positive, got %d", step))
+	}
+	return RounderFunc(func(cents int) int {
+		if cents <= 0 {
+			return cents
+		}
+		rounded := (cents + step/2) / step * step
+		if rounded == 0 {
+			rounded = step
+		}
+		return rounded
+	})
+}
+
+// RoundToEnding returns a Rounder that moves prices to the nearest price
+// whose cents end in one of endings, such as 99 and 49 for $4.99 and $4.49.
+// Ties go to the higher price, and positive prices never round down to zero.
+// It panics if endings is empty or an ending is outside 0-99.
+//
+// Example:
+//
+//	charm := manapool.RoundToEnding(49, 99)
+//	charm.Round(487) // 499
+//	charm.Round(460) // 449
+func RoundToEnding(endings ...int) Rounder {
+	if len(endings) == 0 {
+		panic("manapool: at least one price ending is required")
+	}
+	for _, ending := range endings {
+		if ending < 0 || ending > 99 {
+			panic(fmt.Sprintf("manapool: price ending must be 0-99, got %d", ending))
+		}
+	}
+	endings = append([]int(nil), endings...)
+
+	return RounderFunc(func(cents int) int {
+		if cents <= 0 {
+			return cents
+		}
+		best, bestDistance := 0, -1
+		dollars := cents / 100
+		for _, d := range []int{dollars - 1, dollars, dollars + 1} {
+			for _, ending := range endings {
+				candidate := d*100 + ending
+				if candidate <= 0 {
+					continue
+				}
+				distance := candidate - cents
+				if distance < 0 {
+					distance = -distance
+				}
+				if bestDistance < 0 || distance < bestDistance || distance == bestDistance && candidate > best {
+					best, bestDistance = candidate, distance
+				}
+			}
+		}
+		return best
+	})
+}
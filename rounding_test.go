@@ -0,0 +1,53 @@
+package manapool
+
+import "testing"
+
+func TestRounders(t *testing.T) {
+	tests := []struct {
+		name    string
+		rounder Rounder
+		cents   int
+		want    int
+	}{
+		{"nearest cent", RoundNearestCent, 487, 487},
+		{"nearest 5 down", RoundToNearest(5), 487, 485},
+		{"nearest 5 up", RoundToNearest(5), 483, 485},
+		{"nearest 10 half up", RoundToNearest(10), 485, 490},
+		{"nearest 25", RoundToNearest(25), 487, 475},
+		{"nearest 25 up", RoundToNearest(25), 490, 500},
+		{"nearest 25 never zero", RoundToNearest(25), 3, 25},
+		{"nearest zero", RoundToNearest(25), 0, 0},
+		{"ending 99 up", RoundToEnding(99), 487, 499},
+		{"ending 99 down", RoundToEnding(99), 520, 499},
+		{"ending 49 or 99", RoundToEnding(49, 99), 460, 449},
+		{"ending tie goes up", RoundToEnding(49, 99), 474, 499},
+		{"ending already", RoundToEnding(49, 99), 149, 149},
+		{"ending small price", RoundToEnding(99), 10, 99},
+		{"ending zero never free", RoundToEnding(0), 30, 100},
+		{"ending negative", RoundToEnding(99), -5, -5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rounder.Round(tt.cents); got != tt.want {
+				t.Errorf("Round(%d) = %d, want %d", tt.cents, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRounders_InvalidConfig(t *testing.T) {
+	for name, build := range map[string]func(){
+		"zero step":      func() { RoundToNearest(0) },
+		"no endings":     func() { RoundToEnding() },
+		"ending too big": func() { RoundToEnding(100) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("did not panic")
+				}
+			}()
+			build()
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// salesPageSize is the page size used when loading orders for sales stats.
+const salesPageSize = 500
+
+// SalesStatsOptions configures SalesStats.
+type SalesStatsOptions struct {
+	// From is the start of the range, inclusive (required).
+	From time.Time
+
+	// To is the end of the range, exclusive (default: now).
+	To time.Time
+
+	// Location sets the day boundaries for Days (default: UTC).
+	Location *time.Location
+
+	// Label limits the stats to orders with this label.
+	Label string
+
+	// TopItems is the number of best-selling products to return. Computing
+	// them fetches each order's details, one request per order, so leave it
+	// zero unless needed.
+	TopItems int
+}
+
+// DailySales is the sales total for one calendar day.
+type DailySales struct {
+	// Date is the day in YYYY-MM-DD form
+	Date         string
+	Orders       int
+	RevenueCents int
+}
+
+// ItemSales is the sales total for one product.
+type ItemSales struct {
+	ProductType  string
+	ProductID    string
+	Name         string
+	Quantity     int
+	RevenueCents int
+}
+
+// SalesStats summarizes seller orders over a date range.
+type SalesStats struct {
+	From         time.Time
+	To           time.Time
+	Orders       int
+	RevenueCents int
+
+	// Days has one entry per day in the range, including days without sales
+	Days []DailySales
+
+	// TopItems are the best-selling products by quantity, if requested
+	TopItems []ItemSales
+}
+
+// AverageOrderCents returns the mean order total, or 0 with no orders.
+func (s *SalesStats) AverageOrderCents() int {
+	if s.Orders == 0 {
+		return 0
+	}
+	return s.RevenueCents / s.Orders
+}
+
+// SalesStats computes sales by day, revenue totals, and optionally the
+// top-selling products for orders created in [opts.From, opts.To).
+//
+// The API has no analytics endpoints, so the stats are computed from seller
+// orders; revenue is the order total_cents, including shipping.
+//
+// Example:
+//
+//	stats, err := client.SalesStats(ctx, manapool.SalesStatsOptions{
+//	    From:     time.Now().AddDate(0, 0, -30),
+//	    TopItems: 10,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, day := range stats.Days {
+//	    fmt.Printf("%s: %d orders, %s\n", day.Date, day.Orders, manapool.USDCents(day.RevenueCents))
+//	}
+func (c *Client) SalesStats(ctx context.Context, opts SalesStatsOptions) (*SalesStats, error) {
+	if opts.From.IsZero() {
+		return nil, NewValidationError("from", "from is required")
+	}
+	to := opts.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if !to.After(opts.From) {
+		return nil, NewValidationError("to", "to must be after from")
+	}
+	if opts.TopItems < 0 {
+		return nil, NewValidationError("top_items", "top_items cannot be negative")
+	}
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var orders []OrderSummary
+	since := &Timestamp{Time: opts.From}
+	for offset := 0; ; offset += salesPageSize {
+		page, err := c.GetSellerOrders(ctx, OrdersOptions{Since: since, Label: opts.Label, Limit: salesPageSize, Offset: offset})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load orders at offset %d: %w", offset, err)
+		}
+		for _, order := range page.Orders {
+			if !order.CreatedAt.Before(opts.From) && order.CreatedAt.Before(to) {
+				orders = append(orders, order)
+			}
+		}
+		if len(page.Orders) < salesPageSize {
+			break
+		}
+	}
+
+	stats := &SalesStats{From: opts.From, To: to}
+	days := make(map[string]*DailySales)
+	start := opts.From.In(loc)
+	for d := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc); d.Before(to); d = d.AddDate(0, 0, 1) {
+		stats.Days = append(stats.Days, DailySales{Date: d.Format("2006-01-02")})
+	}
+	for i := range stats.Days {
+		days[stats.Days[i].Date] = &stats.Days[i]
+	}
+
+	for _, order := range orders {
+		stats.Orders++
+		stats.RevenueCents += order.TotalCents
+		if day := days[order.CreatedAt.In(loc).Format("2006-01-02")]; day != nil {
+			day.Orders++
+			day.RevenueCents += order.TotalCents
+		}
+	}
+
+	if opts.TopItems > 0 {
+		top, err := c.topSellingItems(ctx, orders, opts.TopItems)
+		if err != nil {
+			return nil, err
+		}
+		stats.TopItems = top
+	}
+
+	c.logger.Debugf("Sales stats: %d orders, %d cents", stats.Orders, stats.RevenueCents)
+	return stats, nil
+}
+
+// topSellingItems loads each order's items and returns the n products with
+// the highest quantity sold, ties broken by revenue then product ID.
+func (c *Client) topSellingItems(ctx context.Context, orders []OrderSummary, n int) ([]ItemSales, error) {
+	byProduct := make(map[string]*ItemSales)
+	for _, order := range orders {
+		details, err := c.GetSellerOrder(ctx, order.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load order %s: %w", order.ID, err)
+		}
+		for _, item := range details.Order.Items {
+			key := item.ProductType + "/" + item.ProductID
+			sales := byProduct[key]
+			if sales == nil {
+				sales = &ItemSales{ProductType: item.ProductType, ProductID: item.ProductID}
+				switch {
+				case item.Product.Single != nil:
+					sales.Name = item.Product.Single.Name
+				case item.Product.Sealed != nil:
+					sales.Name = item.Product.Sealed.Name
+				}
+				byProduct[key] = sales
+			}
+			sales.Quantity += item.Quantity
+			sales.RevenueCents += item.Quantity * item.PriceCents
+		}
+	}
+
+	items := make([]ItemSales, 0, len(byProduct))
+	for _, sales := range byProduct {
+		items = append(items, *sales)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.Quantity != b.Quantity {
+			return a.Quantity > b.Quantity
+		}
+		if a.RevenueCents != b.RevenueCents {
+			return a.RevenueCents > b.RevenueCents
+		}
+		return a.ProductID < b.ProductID
+	})
+	if len(items) > n {
+		items = items[:n]
+	}
+	return items, nil
+}
@@ -0,0 +1,108 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_SalesStats(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	order := func(id string, at time.Time, total int) OrderSummary {
+		o := pollOrder(id, at)
+		o.TotalCents = total
+		return o
+	}
+	orders := &ordersPollServer{orders: []OrderSummary{
+		order("early", base.Add(-time.Hour), 999),
+		order("a", base.Add(2*time.Hour), 1000),
+		order("b", base.Add(26*time.Hour), 500),
+		order("c", base.Add(30*time.Hour), 1500),
+		order("late", base.Add(72*time.Hour), 999),
+	}}
+	items := map[string][]OrderItem{
+		"a": {{ProductType: "mtg_single", ProductID: "bolt", Product: Product{Single: &Single{Name: "Lightning Bolt"}}, Quantity: 4, PriceCents: 200}},
+		"b": {{ProductType: "mtg_single", ProductID: "bolt", Quantity: 1, PriceCents: 200},
+			{ProductType: "mtg_sealed", ProductID: "box", Product: Product{Sealed: &Sealed{Name: "Box"}}, Quantity: 1, PriceCents: 300}},
+		"c": {{ProductType: "mtg_single", ProductID: "ring", Quantity: 1, PriceCents: 1500}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := strings.TrimPrefix(r.URL.Path, "/seller/orders/"); id != r.URL.Path {
+			_ = json.NewEncoder(w).Encode(OrderDetailsResponse{Order: OrderDetails{
+				OrderSummary: pollOrder(id, base), Items: items[id],
+			}})
+			return
+		}
+		orders.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	stats, err := client.SalesStats(context.Background(), SalesStatsOptions{
+		From:     base,
+		To:       base.Add(48 * time.Hour),
+		TopItems: 2,
+	})
+	if err != nil {
+		t.Fatalf("SalesStats() error = %v", err)
+	}
+
+	if stats.Orders != 3 || stats.RevenueCents != 3000 || stats.AverageOrderCents() != 1000 {
+		t.Errorf("totals = %d orders, %d cents", stats.Orders, stats.RevenueCents)
+	}
+	if len(stats.Days) != 2 || stats.Days[0] != (DailySales{Date: "2024-06-01", Orders: 1, RevenueCents: 1000}) ||
+		stats.Days[1] != (DailySales{Date: "2024-06-02", Orders: 2, RevenueCents: 2000}) {
+		t.Errorf("Days = %+v", stats.Days)
+	}
+	if len(stats.TopItems) != 2 || stats.TopItems[0].ProductID != "bolt" || stats.TopItems[0].Quantity != 5 ||
+		stats.TopItems[0].RevenueCents != 1000 || stats.TopItems[0].Name != "Lightning Bolt" || stats.TopItems[1].ProductID != "ring" {
+		t.Errorf("TopItems = %+v", stats.TopItems)
+	}
+
+	// Day boundaries follow Location: in UTC-5, order a falls on May 31.
+	stats, err = client.SalesStats(context.Background(), SalesStatsOptions{
+		From:     base,
+		To:       base.Add(48 * time.Hour),
+		Location: time.FixedZone("EST", -5*3600),
+	})
+	if err != nil {
+		t.Fatalf("SalesStats() error = %v", err)
+	}
+	if len(stats.Days) != 3 || stats.Days[0].Date != "2024-05-31" || stats.Days[0].Orders != 1 || stats.TopItems != nil {
+		t.Errorf("Days = %+v", stats.Days)
+	}
+}
+
+func TestClient_SalesStatsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"bad token"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, opts := range []SalesStatsOptions{
+		{},
+		{From: now, To: now.Add(-time.Hour)},
+		{From: now.Add(-time.Hour), TopItems: -1},
+	} {
+		var validationErr *ValidationError
+		if _, err := client.SalesStats(ctx, opts); !errors.As(err, &validationErr) {
+			t.Errorf("SalesStats(%+v) error = %v", opts, err)
+		}
+	}
+
+	_, err := client.SalesStats(ctx, SalesStatsOptions{From: now.Add(-time.Hour)})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsUnauthorized() || !strings.Contains(err.Error(), "failed to load orders") {
+		t.Errorf("SalesStats() error = %v", err)
+	}
+}
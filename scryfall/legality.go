@@ -0,0 +1,87 @@
+package scryfall
+
+import (
+	"sort"
+
+	"github.com/repricah/manapool"
+)
+
+// Formats with legality data on Scryfall cards. Scryfall reports more, such
+// as "historic" and "oathbreaker"; any format key it uses can be passed to
+// the Legalities methods.
+const (
+	Standard  = "standard"
+	Pioneer   = "pioneer"
+	Modern    = "modern"
+	Legacy    = "legacy"
+	Vintage   = "vintage"
+	Commander = "commander"
+	Pauper    = "pauper"
+)
+
+// Legality statuses reported by Scryfall.
+const (
+	Legal      = "legal"
+	NotLegal   = "not_legal"
+	Restricted = "restricted"
+	Banned     = "banned"
+)
+
+// Legalities maps format names to a card's legality status in each.
+type Legalities map[string]string
+
+// Status returns the card's status in format, or NotLegal if Scryfall does
+// not list the format.
+func (l Legalities) Status(format string) string {
+	if status, ok := l[format]; ok {
+		return status
+	}
+	return NotLegal
+}
+
+// Legal reports whether the card may be played in format. Restricted cards
+// are legal, limited to one copy.
+func (l Legalities) Legal(format string) bool {
+	switch l.Status(format) {
+	case Legal, Restricted:
+		return true
+	default:
+		return false
+	}
+}
+
+// Formats returns the formats the card is legal in, sorted by name.
+func (l Legalities) Formats() []string {
+	var formats []string
+	for format := range l {
+		if l.Legal(format) {
+			formats = append(formats, format)
+		}
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// LegalIn returns a filter matching singles whose card, looked up by
+// Scryfall ID in cards as returned by EnrichInventory, is legal in format.
+// Sealed products and singles missing from cards do not match.
+//
+// Example:
+//
+//	cards, err := sf.EnrichInventory(ctx, items)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	report, err := client.InventoryValuation(ctx, manapool.ValuationOptions{
+//	    Filter: scryfall.LegalIn(cards, scryfall.Pioneer),
+//	})
+func LegalIn(cards map[string]Card, format string) manapool.InventoryFilter {
+	return func(item manapool.InventoryItem) bool {
+		single := item.Product.Single
+		if single == nil {
+			return false
+		}
+		card, ok := cards[single.ScryfallID]
+		return ok && card.Legalities.Legal(format)
+	}
+}
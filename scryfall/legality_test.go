@@ -0,0 +1,64 @@
+package scryfall
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool"
+)
+
+func TestLegalities(t *testing.T) {
+	var card Card
+	data := `{"id":"bolt","legalities":{"standard":"not_legal","modern":"legal","legacy":"legal","vintage":"restricted","pauper":"banned"}}`
+	if err := json.Unmarshal([]byte(data), &card); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	tests := []struct {
+		format string
+		status string
+		legal  bool
+	}{
+		{Modern, Legal, true},
+		{Vintage, Restricted, true},
+		{Pauper, Banned, false},
+		{Standard, NotLegal, false},
+		{"unknown", NotLegal, false},
+	}
+	for _, tt := range tests {
+		if got := card.Legalities.Status(tt.format); got != tt.status {
+			t.Errorf("Status(%s) = %s, want %s", tt.format, got, tt.status)
+		}
+		if got := card.Legalities.Legal(tt.format); got != tt.legal {
+			t.Errorf("Legal(%s) = %v, want %v", tt.format, got, tt.legal)
+		}
+	}
+	if got := strings.Join(card.Legalities.Formats(), ","); got != "legacy,modern,vintage" {
+		t.Errorf("Formats() = %s", got)
+	}
+	if (Legalities(nil)).Legal(Modern) {
+		t.Error("nil Legalities is legal")
+	}
+}
+
+func TestLegalIn(t *testing.T) {
+	cards := map[string]Card{
+		"bolt": {ID: "bolt", Legalities: Legalities{Modern: Legal}},
+		"opt":  {ID: "opt", Legalities: Legalities{Modern: NotLegal}},
+	}
+	single := func(id string) manapool.InventoryItem {
+		return manapool.InventoryItem{Product: manapool.Product{Single: &manapool.Single{ScryfallID: id}}}
+	}
+	modern := LegalIn(cards, Modern)
+
+	if !modern(single("bolt")) {
+		t.Error("bolt not matched")
+	}
+	if modern(single("opt")) || modern(single("unknown")) {
+		t.Error("illegal or unknown card matched")
+	}
+	if modern(manapool.InventoryItem{Product: manapool.Product{Sealed: &manapool.Sealed{}}}) {
+		t.Error("sealed product matched")
+	}
+}
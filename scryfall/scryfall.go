@@ -0,0 +1,345 @@
+// Package scryfall resolves Scryfall card IDs to card data for enriching
+// Manapool inventory.
+//
+// Manapool singles carry a scryfall_id but no card details. This package looks
+// those IDs up through Scryfall's /cards/collection endpoint in batches of up
+// to 75, caches the results, and respects Scryfall's request rate guidance.
+//
+// # Basic Usage
+//
+//	sf := scryfall.NewClient()
+//	cards, err := sf.EnrichInventory(ctx, inventory.Inventory)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, item := range inventory.Inventory {
+//	    if item.Product.Single == nil {
+//	        continue
+//	    }
+//	    card := cards[item.Product.Single.ScryfallID]
+//	    fmt.Printf("%s (%s) %s\n", card.Name, card.Rarity, card.ImageURIs.Normal)
+//	}
+//
+// # Format Legality
+//
+// Cards carry Scryfall's format legalities, and LegalIn turns them into a
+// manapool.InventoryFilter, for exports such as every Modern-legal card over
+// $5:
+//
+//	modern := scryfall.LegalIn(cards, scryfall.Modern)
+//	for _, item := range inventory.Inventory {
+//	    if modern(item) && item.PriceCents > 500 {
+//	        ...
+//	    }
+//	}
+package scryfall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/repricah/manapool"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultBaseURL is the default base URL for the Scryfall API.
+	DefaultBaseURL = "https://api.scryfall.com/"
+
+	// DefaultCacheTTL is how long resolved cards are cached by default.
+	DefaultCacheTTL = 24 * time.Hour
+
+	// MaxCollectionSize is the maximum number of identifiers Scryfall accepts
+	// in a single /cards/collection request.
+	MaxCollectionSize = 75
+
+	// defaultRateLimit follows Scryfall's guidance of 50-100ms between requests.
+	defaultRateLimit = 10.0
+)
+
+// Card is the subset of a Scryfall card object used for enrichment.
+type Card struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	Set             string     `json:"set"`
+	SetName         string     `json:"set_name"`
+	CollectorNumber string     `json:"collector_number"`
+	Rarity          string     `json:"rarity"`
+	Lang            string     `json:"lang"`
+	TypeLine        string     `json:"type_line"`
+	ScryfallURI     string     `json:"scryfall_uri"`
+	ImageURIs       ImageURIs  `json:"image_uris"`
+	Prices          Prices     `json:"prices"`
+	Legalities      Legalities `json:"legalities"`
+}
+
+// ImageURIs contains links to card images in several sizes.
+type ImageURIs struct {
+	Small      string `json:"small"`
+	Normal     string `json:"normal"`
+	Large      string `json:"large"`
+	PNG        string `json:"png"`
+	ArtCrop    string `json:"art_crop"`
+	BorderCrop string `json:"border_crop"`
+}
+
+// Prices contains Scryfall's daily price estimates as decimal strings.
+// Missing prices are nil.
+type Prices struct {
+	USD       *string `json:"usd"`
+	USDFoil   *string `json:"usd_foil"`
+	USDEtched *string `json:"usd_etched"`
+	EUR       *string `json:"eur"`
+	EURFoil   *string `json:"eur_foil"`
+	TIX       *string `json:"tix"`
+}
+
+// Client resolves Scryfall IDs to cards. It is safe for concurrent use.
+type Client struct {
+	httpClient  *http.Client
+	baseURL     string
+	userAgent   string
+	rateLimiter *rate.Limiter
+	cache       manapool.Cache
+	cacheTTL    time.Duration
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseURL sets a custom base URL, e.g. for testing against a mock server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithUserAgent sets the User-Agent header. Scryfall asks that applications
+// identify themselves.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithCache stores resolved cards in cache for ttl. Passing a nil cache
+// disables caching.
+//
+// Default: an in-memory LRU of 10,000 cards cached for 24 hours.
+func WithCache(cache manapool.Cache, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheTTL = ttl
+	}
+}
+
+// WithRateLimit configures the maximum request rate to Scryfall.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) {
+		c.rateLimiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+}
+
+// NewClient creates a Scryfall enrichment client.
+func NewClient(opts ...Option) *Client {
+	client := &Client{
+		httpClient:  &http.Client{Timeout: manapool.DefaultTimeout},
+		baseURL:     DefaultBaseURL,
+		userAgent:   fmt.Sprintf("manapool-go/%s", manapool.Version),
+		rateLimiter: rate.NewLimiter(defaultRateLimit, 1),
+		cache:       manapool.NewMemoryCache(10000),
+		cacheTTL:    DefaultCacheTTL,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client
+}
+
+// collectionRequest is the body of a /cards/collection request.
+type collectionRequest struct {
+	Identifiers []collectionIdentifier `json:"identifiers"`
+}
+
+type collectionIdentifier struct {
+	ID string `json:"id"`
+}
+
+// collectionResponse is the body of a /cards/collection response.
+type collectionResponse struct {
+	Data     []json.RawMessage      `json:"data"`
+	NotFound []collectionIdentifier `json:"not_found"`
+}
+
+// errorResponse is a Scryfall error object.
+type errorResponse struct {
+	Details string `json:"details"`
+}
+
+// Cards resolves Scryfall IDs to cards, keyed by ID.
+//
+// Cached cards are returned without a request; the rest are fetched in
+// batches of MaxCollectionSize. IDs that Scryfall does not recognize are
+// omitted from the result. Duplicate and empty IDs are ignored.
+func (c *Client) Cards(ctx context.Context, ids []string) (map[string]Card, error) {
+	cards := make(map[string]Card, len(ids))
+	var missing []string
+	seen := make(map[string]bool, len(ids))
+
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if card, ok := c.cached(id); ok {
+			cards[id] = card
+			continue
+		}
+		missing = append(missing, id)
+	}
+
+	for start := 0; start < len(missing); start += MaxCollectionSize {
+		end := start + MaxCollectionSize
+		if end > len(missing) {
+			end = len(missing)
+		}
+		if err := c.fetchCollection(ctx, missing[start:end], cards); err != nil {
+			return nil, err
+		}
+	}
+
+	return cards, nil
+}
+
+// Card resolves a single Scryfall ID. It returns a 404 *manapool.APIError if
+// Scryfall does not know the ID.
+func (c *Client) Card(ctx context.Context, id string) (*Card, error) {
+	if id == "" {
+		return nil, manapool.NewValidationError("id", "id cannot be empty")
+	}
+
+	cards, err := c.Cards(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	card, ok := cards[id]
+	if !ok {
+		return nil, manapool.NewAPIError(http.StatusNotFound, fmt.Sprintf("card %s not found", id))
+	}
+	return &card, nil
+}
+
+// EnrichInventory resolves the Scryfall IDs of every single in items.
+// Sealed products are skipped.
+func (c *Client) EnrichInventory(ctx context.Context, items []manapool.InventoryItem) (map[string]Card, error) {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.Product.Single != nil {
+			ids = append(ids, item.Product.Single.ScryfallID)
+		}
+	}
+	return c.Cards(ctx, ids)
+}
+
+// cached returns a card from the cache, if present and decodable.
+func (c *Client) cached(id string) (Card, bool) {
+	if c.cache == nil {
+		return Card{}, false
+	}
+	data, ok := c.cache.Get(cacheKey(id))
+	if !ok {
+		return Card{}, false
+	}
+	var card Card
+	if err := json.Unmarshal(data, &card); err != nil {
+		return Card{}, false
+	}
+	return card, true
+}
+
+// fetchCollection fetches one batch of IDs and adds the results to cards.
+func (c *Client) fetchCollection(ctx context.Context, ids []string, cards map[string]Card) error {
+	payload := collectionRequest{Identifiers: make([]collectionIdentifier, len(ids))}
+	for i, id := range ids {
+		payload.Identifiers[i] = collectionIdentifier{ID: id}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return manapool.NewNetworkError("failed to encode request body", err)
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return manapool.NewNetworkError("rate limiter error", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"cards/collection", bytes.NewReader(body))
+	if err != nil {
+		return manapool.NewNetworkError("failed to create request", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return manapool.NewNetworkError("failed to fetch scryfall collection", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return manapool.NewNetworkError("failed to read response body", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		message := string(respBody)
+		var errResp errorResponse
+		if json.Unmarshal(respBody, &errResp) == nil && errResp.Details != "" {
+			message = errResp.Details
+		}
+		return &manapool.APIError{StatusCode: resp.StatusCode, Message: message, Response: resp}
+	}
+
+	var collection collectionResponse
+	if err := json.Unmarshal(respBody, &collection); err != nil {
+		return fmt.Errorf("failed to decode scryfall collection: %w", err)
+	}
+
+	for _, raw := range collection.Data {
+		var card Card
+		if err := json.Unmarshal(raw, &card); err != nil {
+			return fmt.Errorf("failed to decode scryfall card: %w", err)
+		}
+		cards[card.ID] = card
+		if c.cache != nil {
+			data, _ := json.Marshal(card)
+			c.cache.Set(cacheKey(card.ID), data, c.cacheTTL)
+		}
+	}
+
+	return nil
+}
+
+// cacheKey namespaces card IDs so a shared cache can be reused safely. The
+// version changes when Card gains fields, so cards cached without them are
+// fetched again rather than returned incomplete.
+func cacheKey(id string) string {
+	return "scryfall:card:v2:" + id
+}
@@ -0,0 +1,190 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+func newCollectionServer(t *testing.T, requests *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if r.Method != http.MethodPost || r.URL.Path != "/cards/collection" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var req collectionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if len(req.Identifiers) > MaxCollectionSize {
+			t.Errorf("batch size = %d, exceeds %d", len(req.Identifiers), MaxCollectionSize)
+		}
+
+		var resp struct {
+			Data     []Card                 `json:"data"`
+			NotFound []collectionIdentifier `json:"not_found"`
+		}
+		for _, ident := range req.Identifiers {
+			if ident.ID == "missing" {
+				resp.NotFound = append(resp.NotFound, ident)
+				continue
+			}
+			resp.Data = append(resp.Data, Card{ID: ident.ID, Name: "Card " + ident.ID, Rarity: "rare"})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestClient_Cards(t *testing.T) {
+	var requests int32
+	server := newCollectionServer(t, &requests)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithRateLimit(1000, 1))
+
+	ids := []string{"", "missing"}
+	for i := 0; i < 100; i++ {
+		ids = append(ids, fmt.Sprintf("id-%d", i), fmt.Sprintf("id-%d", i))
+	}
+
+	cards, err := client.Cards(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("Cards() error = %v", err)
+	}
+	if len(cards) != 100 {
+		t.Errorf("cards = %d, want 100", len(cards))
+	}
+	if cards["id-7"].Name != "Card id-7" {
+		t.Errorf("cards[id-7] = %+v", cards["id-7"])
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+
+	// Second lookup is served from cache except for the unknown ID.
+	if _, err := client.Cards(context.Background(), ids); err != nil {
+		t.Fatalf("Cards() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("requests after cached lookup = %d, want 3", got)
+	}
+}
+
+func TestClient_Card(t *testing.T) {
+	var requests int32
+	server := newCollectionServer(t, &requests)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithCache(nil, 0))
+	ctx := context.Background()
+
+	card, err := client.Card(ctx, "abc")
+	if err != nil {
+		t.Fatalf("Card() error = %v", err)
+	}
+	if card.Name != "Card abc" {
+		t.Errorf("Name = %q, want Card abc", card.Name)
+	}
+
+	_, err = client.Card(ctx, "missing")
+	var apiErr *manapool.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("Card(missing) error = %v, want not found", err)
+	}
+
+	var valErr *manapool.ValidationError
+	if _, err := client.Card(ctx, ""); !errors.As(err, &valErr) {
+		t.Errorf("Card(\"\") error = %v, want ValidationError", err)
+	}
+}
+
+func TestClient_EnrichInventory(t *testing.T) {
+	var requests int32
+	server := newCollectionServer(t, &requests)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithUserAgent("test/1.0"), WithHTTPClient(server.Client()))
+	items := []manapool.InventoryItem{
+		{Product: manapool.Product{Single: &manapool.Single{ScryfallID: "s1"}}},
+		{Product: manapool.Product{Sealed: &manapool.Sealed{Name: "Box"}}},
+	}
+
+	cards, err := client.EnrichInventory(context.Background(), items)
+	if err != nil {
+		t.Fatalf("EnrichInventory() error = %v", err)
+	}
+	if len(cards) != 1 || cards["s1"].Rarity != "rare" {
+		t.Errorf("cards = %+v", cards)
+	}
+}
+
+func TestClient_Cards_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{name: "api error", status: http.StatusBadRequest, body: `{"details":"bad identifiers"}`, wantErr: "bad identifiers"},
+		{name: "invalid json", status: http.StatusOK, body: `{`, wantErr: "failed to decode scryfall collection"},
+		{name: "invalid card", status: http.StatusOK, body: `{"data":[{"id":1}]}`, wantErr: "failed to decode scryfall card"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL + "/"))
+			_, err := client.Cards(context.Background(), []string{"a"})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_Cards_NetworkErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewClient(WithBaseURL("http://127.0.0.1:0/"))
+	var netErr *manapool.NetworkError
+	if _, err := client.Cards(ctx, []string{"a"}); !errors.As(err, &netErr) {
+		t.Errorf("cancelled error = %v, want NetworkError", err)
+	}
+
+	client = NewClient(WithBaseURL("http://127.0.0.1:0/"), WithRateLimit(1000, 1))
+	if _, err := client.Cards(context.Background(), []string{"a"}); !errors.As(err, &netErr) {
+		t.Errorf("connection error = %v, want NetworkError", err)
+	}
+
+	client = NewClient(WithBaseURL("://bad"))
+	if _, err := client.Cards(context.Background(), []string{"a"}); !errors.As(err, &netErr) {
+		t.Errorf("bad url error = %v, want NetworkError", err)
+	}
+}
+
+func TestClient_cached_CorruptEntry(t *testing.T) {
+	cache := manapool.NewMemoryCache(1)
+	cache.Set(cacheKey("a"), []byte("not json"), time.Minute)
+
+	client := NewClient(WithCache(cache, time.Minute))
+	if _, ok := client.cached("a"); ok {
+		t.Error("cached() returned corrupt entry")
+	}
+}
@@ -0,0 +1,230 @@
+// Package sets normalizes Magic set identifiers between ManaPool, Scryfall,
+// and TCGplayer.
+//
+// ManaPool set codes are Scryfall's codes in upper case ("M10"), Scryfall
+// writes them in lower case ("m10"), and TCGplayer names its groups after
+// the set ("Magic 2010"), sometimes with the code appended. Promo and token
+// printings live in their own sets, such as "PM10" and "TM10", which import
+// files often confuse with the main set. Mismatched set identifiers are the
+// most common reason imported rows fail to match a SKU.
+//
+// An Index is built from set data downloaded once: MTGJSON's SetList.json
+// or a saved response from Scryfall's /sets endpoint. Both include each
+// set's type, parent set, and TCGplayer group ID.
+//
+// # Basic Usage
+//
+//	f, err := os.Open("SetList.json")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer f.Close()
+//
+//	index, err := sets.LoadMTGJSONSetList(f)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	set, ok := index.Resolve("Magic 2010 (M10)") // TCGplayer group name
+//	if ok {
+//	    fmt.Println(set.Code, set.ScryfallCode()) // M10 m10
+//	}
+package sets
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Set types for promo and token sets. Other types, such as "core" and
+// "expansion", are kept as reported by the source.
+const (
+	TypePromo = "promo"
+	TypeToken = "token"
+)
+
+// Set describes one Magic set.
+type Set struct {
+	// Code is the ManaPool set code, such as "M10"
+	Code string
+
+	Name string
+
+	// Type is the set type, such as "core", "expansion", TypePromo, or
+	// TypeToken
+	Type string
+
+	// ParentCode is the main set of a promo or token set, or ""
+	ParentCode string
+
+	// TCGPlayerGroupID is TCGplayer's group ID for the set, or 0
+	TCGPlayerGroupID int
+}
+
+// ScryfallCode returns the set's code as Scryfall writes it.
+func (s Set) ScryfallCode() string {
+	return strings.ToLower(s.Code)
+}
+
+// Normalize returns a set code in ManaPool's form: trimmed and upper case.
+func Normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Index looks up sets by code, name, or TCGplayer group. It is safe for
+// concurrent reads once built.
+type Index struct {
+	byCode    map[string]Set
+	byName    map[string]Set
+	byGroupID map[int]Set
+}
+
+// NewIndex builds an index of sets. Later sets replace earlier ones with the
+// same code.
+func NewIndex(sets ...Set) *Index {
+	index := &Index{
+		byCode:    make(map[string]Set, len(sets)),
+		byName:    make(map[string]Set, len(sets)),
+		byGroupID: make(map[int]Set),
+	}
+	for _, set := range sets {
+		set.Code = Normalize(set.Code)
+		set.ParentCode = Normalize(set.ParentCode)
+		if set.Code == "" {
+			continue
+		}
+		index.byCode[set.Code] = set
+		if name := normalizeName(set.Name); name != "" {
+			index.byName[name] = set
+		}
+		if set.TCGPlayerGroupID != 0 {
+			index.byGroupID[set.TCGPlayerGroupID] = set
+		}
+	}
+	return index
+}
+
+// Len returns the number of sets in the index.
+func (i *Index) Len() int {
+	return len(i.byCode)
+}
+
+// Lookup returns the set with a ManaPool or Scryfall code, in any case.
+func (i *Index) Lookup(code string) (Set, bool) {
+	set, ok := i.byCode[Normalize(code)]
+	return set, ok
+}
+
+// ByTCGPlayerGroupID returns the set for a TCGplayer group ID.
+func (i *Index) ByTCGPlayerGroupID(groupID int) (Set, bool) {
+	set, ok := i.byGroupID[groupID]
+	return set, ok
+}
+
+// ByName returns the set with a name or TCGplayer group name. Names are
+// compared ignoring case, punctuation, "&" versus "and", and a trailing
+// code in parentheses, so "Magic 2010 (M10)" and "magic 2010" both find
+// M10.
+func (i *Index) ByName(name string) (Set, bool) {
+	set, ok := i.byName[normalizeName(name)]
+	if !ok {
+		if m := trailingCodePattern.FindStringSubmatch(strings.TrimSpace(name)); m != nil {
+			set, ok = i.byName[normalizeName(m[1])]
+		}
+	}
+	return set, ok
+}
+
+// Resolve finds a set from whatever identifier an import file uses: a
+// ManaPool or Scryfall code, a set name, or a TCGplayer group name.
+func (i *Index) Resolve(s string) (Set, bool) {
+	if set, ok := i.Lookup(s); ok {
+		return set, true
+	}
+	return i.ByName(s)
+}
+
+// Base returns the main set for a code, following promo and token sets to
+// their parent, so "PM10" and "TM10" both return M10. A set without a
+// known parent is its own base.
+func (i *Index) Base(code string) (Set, bool) {
+	set, ok := i.Lookup(code)
+	for depth := 0; ok && set.ParentCode != "" && depth < 4; depth++ {
+		parent, found := i.byCode[set.ParentCode]
+		if !found {
+			break
+		}
+		set = parent
+	}
+	return set, ok
+}
+
+// Same reports whether a and b, each a code, set name, or TCGplayer group
+// name, identify the same set. Unknown identifiers are compared as codes.
+func (i *Index) Same(a, b string) bool {
+	sa, okA := i.Resolve(a)
+	sb, okB := i.Resolve(b)
+	if okA && okB {
+		return sa.Code == sb.Code
+	}
+	return Normalize(a) == Normalize(b)
+}
+
+var (
+	// trailingCodePattern matches a name ending in a code, "Magic 2010 (M10)"
+	trailingCodePattern = regexp.MustCompile(`^(.*\S)\s*\(([A-Za-z0-9]+)\)$`)
+
+	nameReplacer = strings.NewReplacer("&", " and ", ":", " ", "-", " ", "–", " ", ".", "", "'", "", "’", "", ",", "")
+)
+
+// normalizeName folds a set or group name for comparison.
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(nameReplacer.Replace(strings.ToLower(name))), " ")
+}
+
+// LoadMTGJSONSetList builds an index from MTGJSON's SetList.json.
+func LoadMTGJSONSetList(r io.Reader) (*Index, error) {
+	var file struct {
+		Data []struct {
+			Code             string `json:"code"`
+			Name             string `json:"name"`
+			Type             string `json:"type"`
+			ParentCode       string `json:"parentCode"`
+			TCGPlayerGroupID int    `json:"tcgplayerGroupId"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode mtgjson set list: %w", err)
+	}
+
+	sets := make([]Set, len(file.Data))
+	for n, s := range file.Data {
+		sets[n] = Set{Code: s.Code, Name: s.Name, Type: s.Type, ParentCode: s.ParentCode, TCGPlayerGroupID: s.TCGPlayerGroupID}
+	}
+	return NewIndex(sets...), nil
+}
+
+// LoadScryfallSets builds an index from a saved response of Scryfall's
+// /sets endpoint.
+func LoadScryfallSets(r io.Reader) (*Index, error) {
+	var file struct {
+		Data []struct {
+			Code          string `json:"code"`
+			Name          string `json:"name"`
+			SetType       string `json:"set_type"`
+			ParentSetCode string `json:"parent_set_code"`
+			TCGPlayerID   int    `json:"tcgplayer_id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode scryfall sets: %w", err)
+	}
+
+	sets := make([]Set, len(file.Data))
+	for n, s := range file.Data {
+		sets[n] = Set{Code: s.Code, Name: s.Name, Type: s.SetType, ParentCode: s.ParentSetCode, TCGPlayerGroupID: s.TCGPlayerID}
+	}
+	return NewIndex(sets...), nil
+}
@@ -0,0 +1,127 @@
+package sets
+
+import (
+	"strings"
+	"testing"
+)
+
+const mtgjsonSetList = `{"meta":{"version":"5.2.2"},"data":[
+	{"code":"M10","name":"Magic 2010","type":"core","tcgplayerGroupId":21},
+	{"code":"PM10","name":"Magic 2010 Promos","type":"promo","parentCode":"M10"},
+	{"code":"TM10","name":"Magic 2010 Tokens","type":"token","parentCode":"M10","tcgplayerGroupId":0},
+	{"code":"KTK","name":"Khans of Tarkir","type":"expansion","tcgplayerGroupId":1497},
+	{"code":"MH2","name":"Modern Horizons 2","type":"draft_innovation"},
+	{"code":"ELD","name":"Throne of Eldraine","type":"expansion"},
+	{"code":"C21","name":"Commander 2021","type":"commander"},
+	{"code":"SLD","name":"Secret Lair Drop","type":"box"},
+	{"code":"UNF","name":"Unfinity","type":"funny"},
+	{"code":"D&D","name":"Dungeons & Dragons: Adventures in the Forgotten Realms","type":"expansion"}
+]}`
+
+func TestLoadMTGJSONSetList(t *testing.T) {
+	index, err := LoadMTGJSONSetList(strings.NewReader(mtgjsonSetList))
+	if err != nil {
+		t.Fatalf("LoadMTGJSONSetList() error = %v", err)
+	}
+	if index.Len() != 10 {
+		t.Errorf("Len() = %d, want 10", index.Len())
+	}
+
+	set, ok := index.Lookup("pm10")
+	if !ok || set.Code != "PM10" || set.Type != TypePromo || set.ParentCode != "M10" || set.ScryfallCode() != "pm10" {
+		t.Errorf("Lookup(pm10) = %+v, %v", set, ok)
+	}
+	if set, ok := index.ByTCGPlayerGroupID(1497); !ok || set.Code != "KTK" {
+		t.Errorf("ByTCGPlayerGroupID(1497) = %+v, %v", set, ok)
+	}
+	if _, ok := index.ByTCGPlayerGroupID(0); ok {
+		t.Error("ByTCGPlayerGroupID(0) found a set")
+	}
+}
+
+func TestIndex_Resolve(t *testing.T) {
+	index, err := LoadMTGJSONSetList(strings.NewReader(mtgjsonSetList))
+	if err != nil {
+		t.Fatalf("LoadMTGJSONSetList() error = %v", err)
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"M10", "M10"},
+		{" m10 ", "M10"},
+		{"Magic 2010", "M10"},
+		{"MAGIC 2010 (M10)", "M10"},
+		{"Magic 2010 Tokens", "TM10"},
+		{"Khans of Tarkir", "KTK"},
+		{"Adventures in the Forgotten Realms", ""},
+		{"Dungeons and Dragons - Adventures in the Forgotten Realms", "D&D"},
+		{"Modern Horizons 2 (MH2)", "MH2"},
+		{"Unknown Set", ""},
+	}
+	for _, tt := range tests {
+		set, ok := index.Resolve(tt.input)
+		if got := set.Code; got != tt.want || ok != (tt.want != "") {
+			t.Errorf("Resolve(%q) = %q, %v, want %q", tt.input, got, ok, tt.want)
+		}
+	}
+}
+
+func TestIndex_BaseAndSame(t *testing.T) {
+	index := NewIndex(
+		Set{Code: "m10", Name: "Magic 2010"},
+		Set{Code: "pm10", Name: "Magic 2010 Promos", Type: TypePromo, ParentCode: "m10"},
+		Set{Code: "tm10", Name: "Magic 2010 Tokens", Type: TypeToken, ParentCode: "m10"},
+		Set{Code: "porphan", ParentCode: "gone"},
+		Set{Code: "loop1", ParentCode: "loop2"},
+		Set{Code: "loop2", ParentCode: "loop1"},
+		Set{Code: ""},
+	)
+
+	for _, code := range []string{"M10", "pm10", "TM10"} {
+		if set, ok := index.Base(code); !ok || set.Code != "M10" {
+			t.Errorf("Base(%s) = %+v, %v, want M10", code, set, ok)
+		}
+	}
+	if set, ok := index.Base("PORPHAN"); !ok || set.Code != "PORPHAN" {
+		t.Errorf("Base(PORPHAN) = %+v, %v", set, ok)
+	}
+	if _, ok := index.Base("loop1"); !ok {
+		t.Error("Base(loop1) not found")
+	}
+	if _, ok := index.Base("xyz"); ok {
+		t.Error("Base(xyz) found a set")
+	}
+
+	if !index.Same("m10", "Magic 2010") || index.Same("M10", "TM10") {
+		t.Error("Same() compared known sets incorrectly")
+	}
+	if !index.Same("abc", " ABC") || index.Same("abc", "abd") {
+		t.Error("Same() compared unknown codes incorrectly")
+	}
+}
+
+func TestLoadScryfallSets(t *testing.T) {
+	input := `{"object":"list","has_more":false,"data":[
+		{"code":"m10","name":"Magic 2010","set_type":"core","tcgplayer_id":21},
+		{"code":"tm10","name":"Magic 2010 Tokens","set_type":"token","parent_set_code":"m10"}
+	]}`
+	index, err := LoadScryfallSets(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("LoadScryfallSets() error = %v", err)
+	}
+	if set, ok := index.Lookup("TM10"); !ok || set.ParentCode != "M10" || set.Type != TypeToken {
+		t.Errorf("Lookup(TM10) = %+v, %v", set, ok)
+	}
+	if set, ok := index.ByTCGPlayerGroupID(21); !ok || set.Code != "M10" {
+		t.Errorf("ByTCGPlayerGroupID(21) = %+v, %v", set, ok)
+	}
+
+	if _, err := LoadScryfallSets(strings.NewReader("{")); err == nil {
+		t.Error("LoadScryfallSets() with bad JSON error = nil")
+	}
+	if _, err := LoadMTGJSONSetList(strings.NewReader("[")); err == nil {
+		t.Error("LoadMTGJSONSetList() with bad JSON error = nil")
+	}
+}
@@ -0,0 +1,560 @@
+// Package shopify mirrors ManaPool inventory to a Shopify store.
+//
+// Client implements manapool.MarketplaceAdapter against the Shopify Admin
+// REST API, for stores that run their own web shop alongside ManaPool. Each
+// ManaPool listing becomes a Shopify product with one variant, whose SKU is
+// the ManaPool listing ID. Price changes update the variant, quantity
+// changes set the variant's stock at one Shopify location, and delisted
+// listings are archived rather than deleted so their order history stays
+// intact.
+//
+// Client only translates and sends; a manapool.CrossLister decides what to
+// list, update, or archive from the sync engine's diff.
+//
+// # Basic Usage
+//
+//	shop, err := shopify.NewClient("my-store", os.Getenv("SHOPIFY_TOKEN"), locationID)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	lister := manapool.NewCrossLister(links, shop)
+//	report, err := client.SyncInventory(ctx, desired, manapool.SyncOptions{
+//	    CrossLister: lister,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if err := report.CrossList.Err(); err != nil {
+//	    log.Print(err)
+//	}
+//
+// # Sales
+//
+// FetchSales reads Shopify orders so web shop sales can be taken off
+// ManaPool:
+//
+//	sales, err := lister.FetchSales(ctx, lastRun)
+//	for _, sale := range sales {
+//	    if sale.InventoryID != "" {
+//	        // adjust the ManaPool listing by -sale.Quantity
+//	    }
+//	}
+package shopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/repricah/manapool"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultAPIVersion is the Shopify Admin API version requests are made
+	// against.
+	DefaultAPIVersion = "2025-01"
+
+	// DefaultVendor is the vendor set on created products.
+	DefaultVendor = "Magic: The Gathering"
+
+	// MarketplaceName is the adapter's marketplace name in links and reports.
+	MarketplaceName = "shopify"
+
+	// ordersPageSize is the largest page Shopify returns for orders.
+	ordersPageSize = 250
+
+	// defaultRateLimit follows Shopify's REST leaky bucket of 2 requests per
+	// second with a burst of 40.
+	defaultRateLimit = 2.0
+	defaultBurst     = 40
+)
+
+// Client is a manapool.MarketplaceAdapter for a Shopify store. It is safe
+// for concurrent use.
+type Client struct {
+	httpClient  *http.Client
+	baseURL     string
+	apiVersion  string
+	accessToken string
+	locationID  int64
+	vendor      string
+	tags        []string
+	markup      int
+	rateLimiter *rate.Limiter
+}
+
+var _ manapool.MarketplaceAdapter = (*Client)(nil)
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseURL sets a custom store URL in place of
+// https://<shop>.myshopify.com/, e.g. for testing against a mock server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithAPIVersion sets the Admin API version, such as "2025-04".
+//
+// Default: DefaultAPIVersion
+func WithAPIVersion(version string) Option {
+	return func(c *Client) {
+		c.apiVersion = version
+	}
+}
+
+// WithVendor sets the vendor of created products.
+//
+// Default: DefaultVendor
+func WithVendor(vendor string) Option {
+	return func(c *Client) {
+		c.vendor = vendor
+	}
+}
+
+// WithTags adds tags to every created product, alongside the set code and
+// finish, for example to place them in a collection.
+func WithTags(tags ...string) Option {
+	return func(c *Client) {
+		c.tags = append(c.tags, tags...)
+	}
+}
+
+// WithMarkup raises each price by basisPoints, such as 500 for 5%, to
+// cover payment fees.
+func WithMarkup(basisPoints int) Option {
+	return func(c *Client) {
+		c.markup = basisPoints
+	}
+}
+
+// WithRateLimit configures the maximum request rate to Shopify.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) {
+		c.rateLimiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+}
+
+// NewClient creates a Shopify adapter for shop, either its myshopify.com
+// handle ("my-store") or domain ("my-store.myshopify.com"). accessToken is
+// an Admin API token with the products, inventory, and orders scopes, and
+// locationID the Shopify location whose stock mirrors ManaPool quantities.
+func NewClient(shop, accessToken string, locationID int64, opts ...Option) (*Client, error) {
+	switch {
+	case shop == "":
+		return nil, manapool.NewValidationError("shop", "shop cannot be empty")
+	case accessToken == "":
+		return nil, manapool.NewValidationError("access_token", "access token cannot be empty")
+	case locationID <= 0:
+		return nil, manapool.NewValidationError("location_id", "location ID must be positive")
+	}
+
+	domain := strings.TrimSuffix(strings.TrimPrefix(shop, "https://"), "/")
+	if !strings.Contains(domain, ".") {
+		domain += ".myshopify.com"
+	}
+	client := &Client{
+		httpClient:  &http.Client{Timeout: manapool.DefaultTimeout},
+		baseURL:     "https://" + domain + "/",
+		apiVersion:  DefaultAPIVersion,
+		accessToken: accessToken,
+		locationID:  locationID,
+		vendor:      DefaultVendor,
+		rateLimiter: rate.NewLimiter(defaultRateLimit, defaultBurst),
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client, nil
+}
+
+// Name implements manapool.MarketplaceAdapter.
+func (c *Client) Name() string {
+	return MarketplaceName
+}
+
+// product is a Shopify product, as sent and received.
+type product struct {
+	ID          int64           `json:"id,omitempty"`
+	Title       string          `json:"title,omitempty"`
+	BodyHTML    string          `json:"body_html,omitempty"`
+	Vendor      string          `json:"vendor,omitempty"`
+	ProductType string          `json:"product_type,omitempty"`
+	Tags        string          `json:"tags,omitempty"`
+	Status      string          `json:"status,omitempty"`
+	Options     []productOption `json:"options,omitempty"`
+	Variants    []variant       `json:"variants,omitempty"`
+}
+
+type productOption struct {
+	Name string `json:"name"`
+}
+
+// variant is a Shopify product variant, as sent and received.
+type variant struct {
+	ID                  int64  `json:"id,omitempty"`
+	ProductID           int64  `json:"product_id,omitempty"`
+	InventoryItemID     int64  `json:"inventory_item_id,omitempty"`
+	Option1             string `json:"option1,omitempty"`
+	Price               string `json:"price,omitempty"`
+	SKU                 string `json:"sku,omitempty"`
+	InventoryManagement string `json:"inventory_management,omitempty"`
+	InventoryPolicy     string `json:"inventory_policy,omitempty"`
+}
+
+type productEnvelope struct {
+	Product product `json:"product"`
+}
+
+type variantEnvelope struct {
+	Variant variant `json:"variant"`
+}
+
+type inventoryLevel struct {
+	LocationID      int64 `json:"location_id"`
+	InventoryItemID int64 `json:"inventory_item_id"`
+	Available       int   `json:"available"`
+}
+
+type order struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+	LineItems []lineItem `json:"line_items"`
+}
+
+type lineItem struct {
+	ProductID int64  `json:"product_id"`
+	VariantID int64  `json:"variant_id"`
+	Quantity  int    `json:"quantity"`
+	Price     string `json:"price"`
+}
+
+type ordersResponse struct {
+	Orders []order `json:"orders"`
+}
+
+// ListItem implements manapool.MarketplaceAdapter. It creates an active
+// product with one variant and sets its stock at the client's location. If
+// the stock cannot be set, the product is deleted again so a retry does not
+// leave a duplicate.
+func (c *Client) ListItem(ctx context.Context, item manapool.InventoryItem) (string, error) {
+	var created productEnvelope
+	if err := c.do(ctx, http.MethodPost, c.url("products.json", nil), productEnvelope{Product: c.newProduct(item)}, &created); err != nil {
+		return "", fmt.Errorf("failed to create product: %w", err)
+	}
+	if len(created.Product.Variants) == 0 {
+		return "", fmt.Errorf("product %d was created without a variant", created.Product.ID)
+	}
+	v := created.Product.Variants[0]
+
+	if err := c.setAvailable(ctx, v.InventoryItemID, item.Quantity); err != nil {
+		_ = c.do(ctx, http.MethodDelete, c.url(fmt.Sprintf("products/%d.json", created.Product.ID), nil), nil, nil)
+		return "", err
+	}
+	return formatExternalID(created.Product.ID, v.ID), nil
+}
+
+// UpdatePrice implements manapool.MarketplaceAdapter.
+func (c *Client) UpdatePrice(ctx context.Context, externalID string, priceCents int) error {
+	_, variantID, err := parseExternalID(externalID)
+	if err != nil {
+		return err
+	}
+	body := variantEnvelope{Variant: variant{ID: variantID, Price: c.price(priceCents)}}
+	if err := c.do(ctx, http.MethodPut, c.url(fmt.Sprintf("variants/%d.json", variantID), nil), body, nil); err != nil {
+		return fmt.Errorf("failed to update variant: %w", err)
+	}
+	return nil
+}
+
+// UpdateQuantity implements manapool.MarketplaceAdapter. It sets the
+// variant's stock at the client's location.
+func (c *Client) UpdateQuantity(ctx context.Context, externalID string, quantity int) error {
+	_, variantID, err := parseExternalID(externalID)
+	if err != nil {
+		return err
+	}
+	var got variantEnvelope
+	if err := c.do(ctx, http.MethodGet, c.url(fmt.Sprintf("variants/%d.json", variantID), nil), nil, &got); err != nil {
+		return fmt.Errorf("failed to get variant: %w", err)
+	}
+	return c.setAvailable(ctx, got.Variant.InventoryItemID, quantity)
+}
+
+// Delist implements manapool.MarketplaceAdapter. The product is archived,
+// which hides it from the store but keeps it in past orders.
+func (c *Client) Delist(ctx context.Context, externalID string) error {
+	productID, _, err := parseExternalID(externalID)
+	if err != nil {
+		return err
+	}
+	body := productEnvelope{Product: product{ID: productID, Status: "archived"}}
+	if err := c.do(ctx, http.MethodPut, c.url(fmt.Sprintf("products/%d.json", productID), nil), body, nil); err != nil {
+		return fmt.Errorf("failed to archive product: %w", err)
+	}
+	return nil
+}
+
+// FetchSales implements manapool.MarketplaceAdapter. It returns one sale
+// per order line created at or after since, following Shopify's pagination.
+// Lines without a product, such as custom items, are skipped.
+func (c *Client) FetchSales(ctx context.Context, since time.Time) ([]manapool.MarketplaceSale, error) {
+	query := url.Values{}
+	query.Set("status", "any")
+	query.Set("created_at_min", since.UTC().Format(time.RFC3339))
+	query.Set("limit", strconv.Itoa(ordersPageSize))
+
+	var sales []manapool.MarketplaceSale
+	next := c.url("orders.json", query)
+	for next != "" {
+		var page ordersResponse
+		header, err := c.doHeader(ctx, http.MethodGet, next, nil, &page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list orders: %w", err)
+		}
+		for _, o := range page.Orders {
+			for _, line := range o.LineItems {
+				if line.ProductID == 0 || line.VariantID == 0 {
+					continue
+				}
+				price, err := parsePrice(line.Price)
+				if err != nil {
+					return nil, fmt.Errorf("order %s: %w", o.Name, err)
+				}
+				sales = append(sales, manapool.MarketplaceSale{
+					ExternalID: formatExternalID(line.ProductID, line.VariantID),
+					OrderID:    strconv.FormatInt(o.ID, 10),
+					Quantity:   line.Quantity,
+					PriceCents: price,
+					SoldAt:     o.CreatedAt,
+				})
+			}
+		}
+		next = nextPageURL(header.Get("Link"))
+	}
+	return sales, nil
+}
+
+// newProduct maps an inventory item to a product with a single variant.
+func (c *Client) newProduct(item manapool.InventoryItem) product {
+	p := product{
+		Vendor: c.vendor,
+		Status: "active",
+	}
+	v := variant{
+		Price:               c.price(item.PriceCents),
+		SKU:                 item.ID,
+		InventoryManagement: "shopify",
+		InventoryPolicy:     "deny",
+	}
+	tags := []string{"manapool"}
+
+	switch {
+	case item.Product.Single != nil:
+		single := item.Product.Single
+		set := strings.ToUpper(single.Set)
+		p.Title = fmt.Sprintf("%s (%s %s)", single.Name, set, single.Number)
+		if single.Number == "" {
+			p.Title = fmt.Sprintf("%s (%s)", single.Name, set)
+		}
+		p.ProductType = "MTG Single"
+		p.BodyHTML = fmt.Sprintf("<p>%s, %s.</p>",
+			html.EscapeString(single.ConditionFinishLabel(manapool.LabelStyleEtched)),
+			html.EscapeString(single.LanguageName()))
+		p.Options = []productOption{{Name: "Condition"}}
+		v.Option1 = single.ConditionFinishLabel(manapool.LabelStyleEtched)
+		tags = append(tags, set)
+		if single.FinishID == "FO" || single.FinishID == "EF" {
+			tags = append(tags, single.FinishName())
+		}
+	case item.Product.Sealed != nil:
+		sealed := item.Product.Sealed
+		p.Title = sealed.Name
+		p.ProductType = "MTG Sealed"
+		tags = append(tags, strings.ToUpper(sealed.Set))
+	default:
+		p.Title = item.ProductID
+	}
+
+	p.Tags = strings.Join(append(tags, c.tags...), ", ")
+	p.Variants = []variant{v}
+	return p
+}
+
+// setAvailable sets an inventory item's stock at the client's location.
+func (c *Client) setAvailable(ctx context.Context, inventoryItemID int64, quantity int) error {
+	body := inventoryLevel{LocationID: c.locationID, InventoryItemID: inventoryItemID, Available: quantity}
+	if err := c.do(ctx, http.MethodPost, c.url("inventory_levels/set.json", nil), body, nil); err != nil {
+		return fmt.Errorf("failed to set inventory level: %w", err)
+	}
+	return nil
+}
+
+// price formats cents, with the client's markup, as a Shopify price.
+func (c *Client) price(cents int) string {
+	cents += manapool.PercentOfCents(cents, c.markup)
+	return manapool.FormatCents(cents)
+}
+
+// url builds an Admin API URL for path.
+func (c *Client) url(path string, query url.Values) string {
+	u := c.baseURL + "admin/api/" + c.apiVersion + "/" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+// errorResponse is a Shopify error body. Errors is a message, a list of
+// messages, or a map of fields to messages.
+type errorResponse struct {
+	Errors json.RawMessage `json:"errors"`
+}
+
+// do sends a request and decodes the response into out, if non-nil.
+func (c *Client) do(ctx context.Context, method, rawURL string, body, out any) error {
+	_, err := c.doHeader(ctx, method, rawURL, body, out)
+	return err
+}
+
+// doHeader is do, also returning the response headers.
+func (c *Client) doHeader(ctx context.Context, method, rawURL string, body, out any) (http.Header, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, manapool.NewNetworkError("failed to encode request body", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, manapool.NewNetworkError("rate limiter error", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, reader)
+	if err != nil {
+		return nil, manapool.NewNetworkError("failed to create request", err)
+	}
+	req.Header.Set("X-Shopify-Access-Token", c.accessToken)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("manapool-go/%s", manapool.Version))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, manapool.NewNetworkError("failed to reach shopify", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, manapool.NewNetworkError("failed to read response body", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &manapool.APIError{StatusCode: resp.StatusCode, Message: errorMessage(respBody), Response: resp}
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return nil, fmt.Errorf("failed to decode shopify response: %w", err)
+		}
+	}
+	return resp.Header, nil
+}
+
+// errorMessage extracts a readable message from a Shopify error body.
+func errorMessage(body []byte) string {
+	var errResp errorResponse
+	if json.Unmarshal(body, &errResp) != nil || len(errResp.Errors) == 0 {
+		return strings.TrimSpace(string(body))
+	}
+	var message string
+	if json.Unmarshal(errResp.Errors, &message) == nil {
+		return message
+	}
+	var messages []string
+	if json.Unmarshal(errResp.Errors, &messages) == nil {
+		return strings.Join(messages, "; ")
+	}
+	var fields map[string][]string
+	if json.Unmarshal(errResp.Errors, &fields) == nil {
+		var parts []string
+		for field, msgs := range fields {
+			parts = append(parts, field+" "+strings.Join(msgs, ", "))
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, "; ")
+	}
+	return string(errResp.Errors)
+}
+
+// linkNextPattern matches the next page in a Link header.
+var linkNextPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// nextPageURL returns the next page URL from a Link header, or "".
+func nextPageURL(link string) string {
+	if m := linkNextPattern.FindStringSubmatch(link); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// formatExternalID encodes a product and variant as an external listing
+// ID, such as "632910392/808950810".
+func formatExternalID(productID, variantID int64) string {
+	return fmt.Sprintf("%d/%d", productID, variantID)
+}
+
+// parseExternalID decodes an ID from formatExternalID.
+func parseExternalID(externalID string) (productID, variantID int64, err error) {
+	productPart, variantPart, ok := strings.Cut(externalID, "/")
+	if ok {
+		productID, err = strconv.ParseInt(productPart, 10, 64)
+		if err == nil {
+			variantID, err = strconv.ParseInt(variantPart, 10, 64)
+		}
+	}
+	if !ok || err != nil {
+		return 0, 0, manapool.NewValidationError("external_id", fmt.Sprintf("%q is not a shopify product/variant ID", externalID))
+	}
+	return productID, variantID, nil
+}
+
+// parsePrice converts a Shopify price such as "12.50" to cents.
+func parsePrice(price string) (int, error) {
+	value, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse price %q: %w", price, err)
+	}
+	return int(math.Round(value * 100)), nil
+}
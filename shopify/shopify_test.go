@@ -0,0 +1,340 @@
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+// fakeShop is a minimal Shopify Admin API.
+type fakeShop struct {
+	mu       sync.Mutex
+	requests []string
+	products map[int64]product
+	levels   map[int64]int
+	failSet  bool
+}
+
+func newFakeShop() *fakeShop {
+	return &fakeShop{products: make(map[int64]product), levels: make(map[int64]int)}
+}
+
+func (f *fakeShop) handler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if r.Header.Get("X-Shopify-Access-Token") != "shpat_test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"errors":"[API] Invalid API key or access token"}`)
+			return
+		}
+		path := strings.TrimPrefix(r.URL.Path, "/admin/api/"+DefaultAPIVersion+"/")
+		f.requests = append(f.requests, r.Method+" "+path)
+
+		switch {
+		case r.Method == http.MethodPost && path == "products.json":
+			var body productEnvelope
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			p := body.Product
+			if p.Title == "" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = io.WriteString(w, `{"errors":{"title":["can't be blank"]}}`)
+				return
+			}
+			p.ID = int64(100 + len(f.products))
+			p.Variants[0].ID = p.ID * 10
+			p.Variants[0].ProductID = p.ID
+			p.Variants[0].InventoryItemID = p.ID * 100
+			f.products[p.ID] = p
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(productEnvelope{Product: p})
+		case r.Method == http.MethodPost && path == "inventory_levels/set.json":
+			if f.failSet {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = io.WriteString(w, `{"errors":["Inventory item does not have inventory tracking enabled"]}`)
+				return
+			}
+			var level inventoryLevel
+			_ = json.NewDecoder(r.Body).Decode(&level)
+			if level.LocationID != 7 {
+				t.Errorf("location_id = %d, want 7", level.LocationID)
+			}
+			f.levels[level.InventoryItemID] = level.Available
+			_, _ = io.WriteString(w, `{"inventory_level":{}}`)
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "variants/"):
+			for _, p := range f.products {
+				if path == "variants/"+itoa(p.Variants[0].ID)+".json" {
+					_ = json.NewEncoder(w).Encode(variantEnvelope{Variant: p.Variants[0]})
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"errors":"Not Found"}`)
+		case r.Method == http.MethodPut && strings.HasPrefix(path, "variants/"):
+			var body variantEnvelope
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			p := f.products[body.Variant.ID/10]
+			p.Variants[0].Price = body.Variant.Price
+			f.products[p.ID] = p
+			_ = json.NewEncoder(w).Encode(variantEnvelope{Variant: p.Variants[0]})
+		case r.Method == http.MethodPut && strings.HasPrefix(path, "products/"):
+			var body productEnvelope
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			p := f.products[body.Product.ID]
+			p.Status = body.Product.Status
+			f.products[p.ID] = p
+			_ = json.NewEncoder(w).Encode(productEnvelope{Product: p})
+		case r.Method == http.MethodDelete && strings.HasPrefix(path, "products/"):
+			for id := range f.products {
+				if path == "products/"+itoa(id)+".json" {
+					delete(f.products, id)
+				}
+			}
+			_, _ = io.WriteString(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"errors":"Not Found"}`)
+		}
+	})
+}
+
+func itoa(n int64) string {
+	b, _ := json.Marshal(n)
+	return string(b)
+}
+
+func newTestClient(t *testing.T, server *httptest.Server, opts ...Option) *Client {
+	t.Helper()
+	opts = append([]Option{WithBaseURL(server.URL + "/"), WithRateLimit(1000, 1000)}, opts...)
+	client, err := NewClient("my-store", "shpat_test", 7, opts...)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func boltItem() manapool.InventoryItem {
+	return manapool.InventoryItem{ID: "inv-bolt", PriceCents: 1000, Quantity: 3, Product: manapool.Product{Single: &manapool.Single{
+		Name: "Lightning Bolt", Set: "m10", Number: "146", ConditionID: "LP", FinishID: "EF", LanguageID: "EN",
+	}}}
+}
+
+func TestClient_ListingLifecycle(t *testing.T) {
+	shop := newFakeShop()
+	server := httptest.NewServer(shop.handler(t))
+	defer server.Close()
+	ctx := context.Background()
+	client := newTestClient(t, server, WithMarkup(500), WithTags("Singles"))
+
+	externalID, err := client.ListItem(ctx, boltItem())
+	if err != nil {
+		t.Fatalf("ListItem() error = %v", err)
+	}
+	if externalID != "100/1000" {
+		t.Errorf("external ID = %q, want 100/1000", externalID)
+	}
+	p := shop.products[100]
+	v := p.Variants[0]
+	for _, tt := range []struct{ field, got, want string }{
+		{"title", p.Title, "Lightning Bolt (M10 146)"},
+		{"body", p.BodyHTML, "<p>Lightly Played Etched Foil, English.</p>"},
+		{"vendor", p.Vendor, DefaultVendor},
+		{"type", p.ProductType, "MTG Single"},
+		{"tags", p.Tags, "manapool, M10, Etched Foil, Singles"},
+		{"status", p.Status, "active"},
+		{"option", v.Option1, "Lightly Played Etched Foil"},
+		{"price", v.Price, "10.50"},
+		{"sku", v.SKU, "inv-bolt"},
+		{"inventory", v.InventoryManagement, "shopify"},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.field, tt.got, tt.want)
+		}
+	}
+	if shop.levels[10000] != 3 {
+		t.Errorf("available = %d, want 3", shop.levels[10000])
+	}
+
+	if err := client.UpdatePrice(ctx, externalID, 1200); err != nil {
+		t.Fatalf("UpdatePrice() error = %v", err)
+	}
+	if got := shop.products[100].Variants[0].Price; got != "12.60" {
+		t.Errorf("price = %q, want 12.60", got)
+	}
+	if err := client.UpdateQuantity(ctx, externalID, 1); err != nil {
+		t.Fatalf("UpdateQuantity() error = %v", err)
+	}
+	if shop.levels[10000] != 1 {
+		t.Errorf("available = %d, want 1", shop.levels[10000])
+	}
+	if err := client.Delist(ctx, externalID); err != nil {
+		t.Fatalf("Delist() error = %v", err)
+	}
+	if got := shop.products[100].Status; got != "archived" {
+		t.Errorf("status = %q, want archived", got)
+	}
+
+	want := "POST products.json,POST inventory_levels/set.json,PUT variants/1000.json," +
+		"GET variants/1000.json,POST inventory_levels/set.json,PUT products/100.json"
+	if got := strings.Join(shop.requests, ","); got != want {
+		t.Errorf("requests = %s, want %s", got, want)
+	}
+}
+
+func TestClient_ListItem_Sealed(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	p := newTestClient(t, server).newProduct(manapool.InventoryItem{ID: "box", PriceCents: 9999, Product: manapool.Product{
+		Sealed: &manapool.Sealed{Name: "Ice Age Booster Box", Set: "ice"},
+	}})
+	if p.Title != "Ice Age Booster Box" || p.ProductType != "MTG Sealed" || p.Tags != "manapool, ICE" || len(p.Options) != 0 {
+		t.Errorf("product = %+v", p)
+	}
+}
+
+func TestClient_ListItem_RollsBack(t *testing.T) {
+	shop := newFakeShop()
+	shop.failSet = true
+	server := httptest.NewServer(shop.handler(t))
+	defer server.Close()
+
+	_, err := newTestClient(t, server).ListItem(context.Background(), boltItem())
+	if err == nil || !strings.Contains(err.Error(), "failed to set inventory level") ||
+		!strings.Contains(err.Error(), "Inventory item does not have inventory tracking enabled") {
+		t.Fatalf("ListItem() error = %v", err)
+	}
+	if len(shop.products) != 0 {
+		t.Errorf("products = %d, want the product deleted", len(shop.products))
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	shop := newFakeShop()
+	server := httptest.NewServer(shop.handler(t))
+	defer server.Close()
+	ctx := context.Background()
+
+	var apiErr *manapool.APIError
+	_, err := newTestClient(t, server).ListItem(ctx, manapool.InventoryItem{ID: "x"})
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity || apiErr.Message != "title can't be blank" {
+		t.Errorf("blank title error = %v", err)
+	}
+
+	client, _ := NewClient("my-store", "wrong", 7, WithBaseURL(server.URL+"/"))
+	err = client.UpdatePrice(ctx, "1/2", 100)
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized || !strings.Contains(apiErr.Message, "Invalid API key") {
+		t.Errorf("unauthorized error = %v", err)
+	}
+
+	var valErr *manapool.ValidationError
+	if err := client.Delist(ctx, "shop-1"); !errors.As(err, &valErr) || valErr.Field != "external_id" {
+		t.Errorf("bad external ID error = %v", err)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	for _, tt := range []struct {
+		shop, token string
+		location    int64
+		wantField   string
+	}{
+		{"", "t", 1, "shop"},
+		{"s", "", 1, "access_token"},
+		{"s", "t", 0, "location_id"},
+	} {
+		_, err := NewClient(tt.shop, tt.token, tt.location)
+		var valErr *manapool.ValidationError
+		if !errors.As(err, &valErr) || valErr.Field != tt.wantField {
+			t.Errorf("NewClient(%q, %q, %d) error = %v, want field %s", tt.shop, tt.token, tt.location, err, tt.wantField)
+		}
+	}
+
+	for shop, want := range map[string]string{
+		"my-store":                       "https://my-store.myshopify.com/admin/api/2025-04/products.json",
+		"my-store.myshopify.com":         "https://my-store.myshopify.com/admin/api/2025-04/products.json",
+		"https://shop.example.com/":      "https://shop.example.com/admin/api/2025-04/products.json",
+		"https://my-store.myshopify.com": "https://my-store.myshopify.com/admin/api/2025-04/products.json",
+	} {
+		client, err := NewClient(shop, "t", 1, WithAPIVersion("2025-04"))
+		if err != nil {
+			t.Fatalf("NewClient(%q) error = %v", shop, err)
+		}
+		if got := client.url("products.json", nil); got != want {
+			t.Errorf("NewClient(%q) url = %s, want %s", shop, got, want)
+		}
+	}
+}
+
+func TestClient_FetchSales(t *testing.T) {
+	var server *httptest.Server
+	var queries []string
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("page_info") == "" {
+			w.Header().Set("Link", `<`+server.URL+`/admin/api/2025-01/orders.json?limit=250&page_info=abc>; rel="next"`)
+			_, _ = io.WriteString(w, `{"orders":[{"id":1,"name":"#1001","created_at":"2025-03-02T10:00:00-05:00","line_items":[
+				{"product_id":100,"variant_id":1000,"quantity":2,"price":"10.50"},
+				{"product_id":null,"variant_id":null,"quantity":1,"price":"5.00"}]}]}`)
+			return
+		}
+		w.Header().Set("Link", `<`+server.URL+`/admin/api/2025-01/orders.json?page_info=xyz>; rel="previous"`)
+		_, _ = io.WriteString(w, `{"orders":[{"id":2,"name":"#1002","created_at":"2025-03-03T10:00:00Z","line_items":[
+			{"product_id":101,"variant_id":1010,"quantity":1,"price":"0.25"}]}]}`)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	links := manapool.NewMemoryListingLinks()
+	_ = links.Link(ctx, MarketplaceName, "inv-bolt", "100/1000")
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	sales, err := manapool.NewCrossLister(links, newTestClient(t, server)).FetchSales(ctx, since)
+	if err != nil {
+		t.Fatalf("FetchSales() error = %v", err)
+	}
+	if len(sales) != 2 {
+		t.Fatalf("sales = %+v, want 2", sales)
+	}
+	first, second := sales[0], sales[1]
+	if first.InventoryID != "inv-bolt" || first.OrderID != "1" || first.Quantity != 2 || first.PriceCents != 1050 ||
+		first.Marketplace != "shopify" || !first.SoldAt.Equal(time.Date(2025, 3, 2, 15, 0, 0, 0, time.UTC)) {
+		t.Errorf("first sale = %+v", first)
+	}
+	if second.ExternalID != "101/1010" || second.InventoryID != "" || second.PriceCents != 25 {
+		t.Errorf("second sale = %+v", second)
+	}
+	if !strings.Contains(queries[0], "created_at_min=2025-03-01T00%3A00%3A00Z") || !strings.Contains(queries[0], "status=any") {
+		t.Errorf("first query = %s", queries[0])
+	}
+}
+
+func TestCrossLister_Shopify(t *testing.T) {
+	shop := newFakeShop()
+	server := httptest.NewServer(shop.handler(t))
+	defer server.Close()
+	ctx := context.Background()
+
+	lister := manapool.NewCrossLister(manapool.NewMemoryListingLinks(), newTestClient(t, server))
+	item := boltItem()
+	report := lister.Propagate(ctx, manapool.DiffInventory(nil, []manapool.InventoryItem{item}))
+	if err := report.Err(); err != nil || report.Listed != 1 {
+		t.Fatalf("list report = %s, %v", report, err)
+	}
+	report = lister.Propagate(ctx, manapool.DiffInventory([]manapool.InventoryItem{item}, nil))
+	if err := report.Err(); err != nil || report.Delisted != 1 {
+		t.Fatalf("delist report = %s, %v", report, err)
+	}
+	if shop.products[100].Status != "archived" {
+		t.Errorf("status = %q, want archived", shop.products[100].Status)
+	}
+}
@@ -0,0 +1,65 @@
+package manapool
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Webhook and request signatures share one scheme: the header value is
+// "t=<unix seconds>,v1=<hex digest>", where the digest is the HMAC-SHA256,
+// keyed with the shared secret, of "v1:<unix seconds>:<payload>". For
+// webhooks the payload is the body; for requests it is the canonical
+// request.
+
+// formatSignature returns the signature header value for payload at
+// timestamp.
+func formatSignature(secret string, timestamp time.Time, payload []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + ts + ",v1=" + signatureDigest(secret, ts, payload)
+}
+
+// signatureDigest is the hex HMAC-SHA256 of "v1:<timestamp>:<payload>".
+func signatureDigest(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v1:" + timestamp + ":"))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifySignature checks a signature header value against payload and
+// returns its timestamp. Failures wrap invalid. A tolerance of zero or less
+// skips the age check.
+func verifySignature(secret, signature string, payload []byte, tolerance time.Duration, invalid error) (time.Time, error) {
+	var timestamp, digest string
+	for _, part := range strings.Split(signature, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			digest = value
+		}
+	}
+	if timestamp == "" || digest == "" {
+		return time.Time{}, fmt.Errorf("%w: missing t or v1", invalid)
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: bad timestamp %q", invalid, timestamp)
+	}
+	signedAt := time.Unix(seconds, 0)
+	if tolerance > 0 {
+		if age := time.Since(signedAt); age > tolerance || age < -tolerance {
+			return time.Time{}, fmt.Errorf("%w: timestamp %s is outside the %v tolerance", invalid, signedAt.UTC().Format(time.RFC3339), tolerance)
+		}
+	}
+	if !hmac.Equal([]byte(digest), []byte(signatureDigest(secret, timestamp, payload))) {
+		return time.Time{}, fmt.Errorf("%w: digest mismatch", invalid)
+	}
+	return signedAt, nil
+}
@@ -0,0 +1,489 @@
+// Package square keeps a Square point of sale in step with ManaPool stock.
+//
+// Client implements manapool.MarketplaceAdapter against the Square Catalog,
+// Inventory, and Orders APIs. Each ManaPool listing becomes a Square item
+// with one variation, whose SKU is the ManaPool listing ID, and ManaPool
+// quantities are pushed to one Square location as physical counts. Cards
+// sold over the counter come back through FetchSales, and
+// manapool.Client.ReconcileSales takes them off ManaPool, reporting a
+// conflict when the last copy sold on both channels.
+//
+// # Basic Usage
+//
+//	pos, err := square.NewClient(os.Getenv("SQUARE_TOKEN"), "L8XYZ...")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	lister := manapool.NewCrossLister(links, pos)
+//
+//	// Take in-store sales off ManaPool, then push ManaPool stock to Square.
+//	sales, err := lister.FetchSales(ctx, lastRun)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	sold := client.ReconcileSales(ctx, sales)
+//	for _, conflict := range sold.Conflicts {
+//	    log.Printf("oversold: %s", conflict)
+//	}
+//	report, err := client.SyncInventory(ctx, desired, manapool.SyncOptions{CrossLister: lister})
+package square
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+const (
+	// DefaultBaseURL is the Square production API.
+	DefaultBaseURL = "https://connect.squareup.com/"
+
+	// SandboxBaseURL is the Square sandbox API.
+	SandboxBaseURL = "https://connect.squareupsandbox.com/"
+
+	// DefaultAPIVersion is the Square-Version requests are made against.
+	DefaultAPIVersion = "2025-01-23"
+
+	// MarketplaceName is the adapter's marketplace name in links and reports.
+	MarketplaceName = "square"
+
+	// ordersPageSize is the largest page Square returns for order searches.
+	ordersPageSize = 500
+)
+
+// Client is a manapool.MarketplaceAdapter for one Square location. It is
+// safe for concurrent use.
+type Client struct {
+	httpClient  *http.Client
+	baseURL     string
+	apiVersion  string
+	accessToken string
+	locationID  string
+}
+
+var _ manapool.MarketplaceAdapter = (*Client)(nil)
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseURL sets a custom base URL, e.g. SandboxBaseURL or a mock server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithAPIVersion sets the Square-Version header.
+//
+// Default: DefaultAPIVersion
+func WithAPIVersion(version string) Option {
+	return func(c *Client) {
+		c.apiVersion = version
+	}
+}
+
+// NewClient creates a Square adapter. accessToken needs the ITEMS,
+// INVENTORY, and ORDERS read and write permissions, and locationID is the
+// store whose stock mirrors ManaPool quantities and whose sales are
+// fetched.
+func NewClient(accessToken, locationID string, opts ...Option) (*Client, error) {
+	switch {
+	case accessToken == "":
+		return nil, manapool.NewValidationError("access_token", "access token cannot be empty")
+	case locationID == "":
+		return nil, manapool.NewValidationError("location_id", "location ID cannot be empty")
+	}
+
+	client := &Client{
+		httpClient:  &http.Client{Timeout: manapool.DefaultTimeout},
+		baseURL:     DefaultBaseURL,
+		apiVersion:  DefaultAPIVersion,
+		accessToken: accessToken,
+		locationID:  locationID,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client, nil
+}
+
+// Name implements manapool.MarketplaceAdapter.
+func (c *Client) Name() string {
+	return MarketplaceName
+}
+
+// catalogObject is a Square catalog object, as sent and received.
+type catalogObject struct {
+	Type              string             `json:"type"`
+	ID                string             `json:"id"`
+	Version           int64              `json:"version,omitempty"`
+	ItemData          *itemData          `json:"item_data,omitempty"`
+	ItemVariationData *itemVariationData `json:"item_variation_data,omitempty"`
+}
+
+type itemData struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Variations  []catalogObject `json:"variations,omitempty"`
+}
+
+type itemVariationData struct {
+	ItemID         string `json:"item_id"`
+	Name           string `json:"name"`
+	SKU            string `json:"sku,omitempty"`
+	PricingType    string `json:"pricing_type"`
+	PriceMoney     *money `json:"price_money,omitempty"`
+	TrackInventory bool   `json:"track_inventory"`
+}
+
+type money struct {
+	Amount   int    `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type upsertRequest struct {
+	IdempotencyKey string        `json:"idempotency_key"`
+	Object         catalogObject `json:"object"`
+}
+
+type objectResponse struct {
+	CatalogObject catalogObject `json:"catalog_object"`
+	Object        catalogObject `json:"object"`
+}
+
+type inventoryChangeRequest struct {
+	IdempotencyKey string            `json:"idempotency_key"`
+	Changes        []inventoryChange `json:"changes"`
+}
+
+type inventoryChange struct {
+	Type          string        `json:"type"`
+	PhysicalCount physicalCount `json:"physical_count"`
+}
+
+type physicalCount struct {
+	CatalogObjectID string `json:"catalog_object_id"`
+	LocationID      string `json:"location_id"`
+	State           string `json:"state"`
+	Quantity        string `json:"quantity"`
+	OccurredAt      string `json:"occurred_at"`
+}
+
+type searchOrdersRequest struct {
+	LocationIDs []string    `json:"location_ids"`
+	Cursor      string      `json:"cursor,omitempty"`
+	Limit       int         `json:"limit"`
+	Query       ordersQuery `json:"query"`
+}
+
+type ordersQuery struct {
+	Filter ordersFilter `json:"filter"`
+	Sort   ordersSort   `json:"sort"`
+}
+
+type ordersFilter struct {
+	StateFilter    stateFilter    `json:"state_filter"`
+	DateTimeFilter dateTimeFilter `json:"date_time_filter"`
+}
+
+type stateFilter struct {
+	States []string `json:"states"`
+}
+
+type dateTimeFilter struct {
+	ClosedAt timeRange `json:"closed_at"`
+}
+
+type timeRange struct {
+	StartAt string `json:"start_at"`
+}
+
+type ordersSort struct {
+	SortField string `json:"sort_field"`
+	SortOrder string `json:"sort_order"`
+}
+
+type searchOrdersResponse struct {
+	Orders []order `json:"orders"`
+	Cursor string  `json:"cursor"`
+}
+
+type order struct {
+	ID        string     `json:"id"`
+	ClosedAt  time.Time  `json:"closed_at"`
+	LineItems []lineItem `json:"line_items"`
+}
+
+type lineItem struct {
+	CatalogObjectID string `json:"catalog_object_id"`
+	Quantity        string `json:"quantity"`
+	BasePriceMoney  money  `json:"base_price_money"`
+}
+
+// ListItem implements manapool.MarketplaceAdapter. It creates an item with
+// one variation and sets the variation's count at the client's location,
+// returning the variation ID. If the count cannot be set, the item is
+// deleted again so a retry does not leave a duplicate.
+func (c *Client) ListItem(ctx context.Context, item manapool.InventoryItem) (string, error) {
+	var created objectResponse
+	if err := c.do(ctx, http.MethodPost, "v2/catalog/object", upsertRequest{IdempotencyKey: idempotencyKey(), Object: newItem(item)}, &created); err != nil {
+		return "", fmt.Errorf("failed to create item: %w", err)
+	}
+	if created.CatalogObject.ItemData == nil || len(created.CatalogObject.ItemData.Variations) == 0 {
+		return "", fmt.Errorf("item %s was created without a variation", created.CatalogObject.ID)
+	}
+	variationID := created.CatalogObject.ItemData.Variations[0].ID
+
+	if err := c.setCount(ctx, variationID, item.Quantity); err != nil {
+		_ = c.do(ctx, http.MethodDelete, "v2/catalog/object/"+url.PathEscape(created.CatalogObject.ID), nil, nil)
+		return "", err
+	}
+	return variationID, nil
+}
+
+// UpdatePrice implements manapool.MarketplaceAdapter.
+func (c *Client) UpdatePrice(ctx context.Context, externalID string, priceCents int) error {
+	variation, err := c.variation(ctx, externalID)
+	if err != nil {
+		return err
+	}
+	variation.ItemVariationData.PriceMoney = &money{Amount: priceCents, Currency: string(manapool.USD)}
+	if err := c.do(ctx, http.MethodPost, "v2/catalog/object", upsertRequest{IdempotencyKey: idempotencyKey(), Object: *variation}, nil); err != nil {
+		return fmt.Errorf("failed to update variation: %w", err)
+	}
+	return nil
+}
+
+// UpdateQuantity implements manapool.MarketplaceAdapter. It records a
+// physical count at the client's location.
+func (c *Client) UpdateQuantity(ctx context.Context, externalID string, quantity int) error {
+	return c.setCount(ctx, externalID, quantity)
+}
+
+// Delist implements manapool.MarketplaceAdapter. The variation's item is
+// deleted from the catalog; past orders keep their line items.
+func (c *Client) Delist(ctx context.Context, externalID string) error {
+	variation, err := c.variation(ctx, externalID)
+	if err != nil {
+		return err
+	}
+	if err := c.do(ctx, http.MethodDelete, "v2/catalog/object/"+url.PathEscape(variation.ItemVariationData.ItemID), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete item: %w", err)
+	}
+	return nil
+}
+
+// FetchSales implements manapool.MarketplaceAdapter. It returns one sale
+// per line of the orders completed at the client's location at or after
+// since, following Square's pagination. Lines without a catalog variation,
+// such as custom amounts, are skipped.
+func (c *Client) FetchSales(ctx context.Context, since time.Time) ([]manapool.MarketplaceSale, error) {
+	search := searchOrdersRequest{
+		LocationIDs: []string{c.locationID},
+		Limit:       ordersPageSize,
+		Query: ordersQuery{
+			Filter: ordersFilter{
+				StateFilter:    stateFilter{States: []string{"COMPLETED"}},
+				DateTimeFilter: dateTimeFilter{ClosedAt: timeRange{StartAt: since.UTC().Format(time.RFC3339)}},
+			},
+			Sort: ordersSort{SortField: "CLOSED_AT", SortOrder: "ASC"},
+		},
+	}
+
+	var sales []manapool.MarketplaceSale
+	for {
+		var page searchOrdersResponse
+		if err := c.do(ctx, http.MethodPost, "v2/orders/search", search, &page); err != nil {
+			return nil, fmt.Errorf("failed to search orders: %w", err)
+		}
+		for _, o := range page.Orders {
+			for _, line := range o.LineItems {
+				if line.CatalogObjectID == "" {
+					continue
+				}
+				quantity, err := strconv.Atoi(line.Quantity)
+				if err != nil {
+					return nil, fmt.Errorf("order %s: failed to parse quantity %q: %w", o.ID, line.Quantity, err)
+				}
+				sales = append(sales, manapool.MarketplaceSale{
+					ExternalID: line.CatalogObjectID,
+					OrderID:    o.ID,
+					Quantity:   quantity,
+					PriceCents: line.BasePriceMoney.Amount,
+					SoldAt:     o.ClosedAt,
+				})
+			}
+		}
+		if page.Cursor == "" {
+			return sales, nil
+		}
+		search.Cursor = page.Cursor
+	}
+}
+
+// newItem maps an inventory item to a catalog item with one variation. IDs
+// starting with "#" are temporary, replaced by Square on creation.
+func newItem(item manapool.InventoryItem) catalogObject {
+	name, variationName, description := item.ProductID, "Regular", ""
+	switch {
+	case item.Product.Single != nil:
+		single := item.Product.Single
+		name = fmt.Sprintf("%s (%s %s)", single.Name, strings.ToUpper(single.Set), single.Number)
+		if single.Number == "" {
+			name = fmt.Sprintf("%s (%s)", single.Name, strings.ToUpper(single.Set))
+		}
+		variationName = single.ConditionFinishLabel(manapool.LabelStyleEtched)
+		description = fmt.Sprintf("%s, %s", variationName, single.LanguageName())
+	case item.Product.Sealed != nil:
+		name = item.Product.Sealed.Name
+	}
+
+	return catalogObject{
+		Type: "ITEM",
+		ID:   "#item",
+		ItemData: &itemData{
+			Name:        name,
+			Description: description,
+			Variations: []catalogObject{{
+				Type: "ITEM_VARIATION",
+				ID:   "#variation",
+				ItemVariationData: &itemVariationData{
+					ItemID:         "#item",
+					Name:           variationName,
+					SKU:            item.ID,
+					PricingType:    "FIXED_PRICING",
+					PriceMoney:     &money{Amount: item.PriceCents, Currency: string(manapool.USD)},
+					TrackInventory: true,
+				},
+			}},
+		},
+	}
+}
+
+// variation retrieves a catalog variation by ID.
+func (c *Client) variation(ctx context.Context, id string) (*catalogObject, error) {
+	var got objectResponse
+	if err := c.do(ctx, http.MethodGet, "v2/catalog/object/"+url.PathEscape(id), nil, &got); err != nil {
+		return nil, fmt.Errorf("failed to get variation: %w", err)
+	}
+	if got.Object.ItemVariationData == nil {
+		return nil, manapool.NewValidationError("external_id", fmt.Sprintf("%s is a %s, not an item variation", id, got.Object.Type))
+	}
+	return &got.Object, nil
+}
+
+// setCount records a physical count for a variation at the client's
+// location.
+func (c *Client) setCount(ctx context.Context, variationID string, quantity int) error {
+	body := inventoryChangeRequest{
+		IdempotencyKey: idempotencyKey(),
+		Changes: []inventoryChange{{
+			Type: "PHYSICAL_COUNT",
+			PhysicalCount: physicalCount{
+				CatalogObjectID: variationID,
+				LocationID:      c.locationID,
+				State:           "IN_STOCK",
+				Quantity:        strconv.Itoa(quantity),
+				OccurredAt:      time.Now().UTC().Format(time.RFC3339),
+			},
+		}},
+	}
+	if err := c.do(ctx, http.MethodPost, "v2/inventory/changes/batch-create", body, nil); err != nil {
+		return fmt.Errorf("failed to set inventory count: %w", err)
+	}
+	return nil
+}
+
+// errorResponse is a Square error body.
+type errorResponse struct {
+	Errors []struct {
+		Code   string `json:"code"`
+		Detail string `json:"detail"`
+	} `json:"errors"`
+}
+
+// do sends a request and decodes the response into out, if non-nil.
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return manapool.NewNetworkError("failed to encode request body", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return manapool.NewNetworkError("failed to create request", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	req.Header.Set("Square-Version", c.apiVersion)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("manapool-go/%s", manapool.Version))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return manapool.NewNetworkError("failed to reach square", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return manapool.NewNetworkError("failed to read response body", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &manapool.APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(respBody)), Response: resp}
+		var errResp errorResponse
+		if json.Unmarshal(respBody, &errResp) == nil && len(errResp.Errors) > 0 {
+			details := make([]string, len(errResp.Errors))
+			for i, e := range errResp.Errors {
+				details[i] = e.Detail
+			}
+			apiErr.Message = strings.Join(details, "; ")
+			apiErr.Code = errResp.Errors[0].Code
+		}
+		return apiErr
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("failed to decode square response: %w", err)
+		}
+	}
+	return nil
+}
+
+// idempotencyKey returns a random key so Square applies a write once even
+// if the request is retried.
+func idempotencyKey() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,260 @@
+package square
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+// fakeSquare is a minimal Square API for one location.
+type fakeSquare struct {
+	mu       sync.Mutex
+	requests []string
+	objects  map[string]catalogObject
+	counts   map[string]string
+	keys     map[string]bool
+	orders   []order
+	failSet  bool
+	nextID   int
+}
+
+func newFakeSquare() *fakeSquare {
+	return &fakeSquare{objects: make(map[string]catalogObject), counts: make(map[string]string), keys: make(map[string]bool)}
+}
+
+func (f *fakeSquare) handler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if r.Header.Get("Authorization") != "Bearer sq-token" || r.Header.Get("Square-Version") != DefaultAPIVersion {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"errors":[{"category":"AUTHENTICATION_ERROR","code":"UNAUTHORIZED","detail":"This request could not be authorized."}]}`)
+			return
+		}
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+		id := strings.TrimPrefix(r.URL.Path, "/v2/catalog/object/")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/catalog/object":
+			var body upsertRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.IdempotencyKey == "" || f.keys[body.IdempotencyKey] {
+				t.Errorf("idempotency key %q missing or reused", body.IdempotencyKey)
+			}
+			f.keys[body.IdempotencyKey] = true
+			obj := body.Object
+			if obj.Type == "ITEM" {
+				f.nextID++
+				obj.ID = fmt.Sprintf("ITEM%d", f.nextID)
+				variation := &obj.ItemData.Variations[0]
+				variation.ID = fmt.Sprintf("VAR%d", f.nextID)
+				variation.ItemVariationData.ItemID = obj.ID
+				f.objects[variation.ID] = *variation
+			} else {
+				obj.Version++
+			}
+			f.objects[obj.ID] = obj
+			_ = json.NewEncoder(w).Encode(objectResponse{CatalogObject: obj})
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v2/catalog/object/"):
+			obj, ok := f.objects[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = io.WriteString(w, `{"errors":[{"category":"INVALID_REQUEST_ERROR","code":"NOT_FOUND","detail":"Object not found."}]}`)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(objectResponse{Object: obj})
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v2/catalog/object/"):
+			delete(f.objects, id)
+			_, _ = io.WriteString(w, `{}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/inventory/changes/batch-create":
+			if f.failSet {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = io.WriteString(w, `{"errors":[{"category":"INVALID_REQUEST_ERROR","code":"INVALID_VALUE","detail":"Invalid location."}]}`)
+				return
+			}
+			var body inventoryChangeRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			count := body.Changes[0].PhysicalCount
+			if count.LocationID != "LOC1" || count.State != "IN_STOCK" {
+				t.Errorf("physical count = %+v", count)
+			}
+			f.counts[count.CatalogObjectID] = count.Quantity
+			_, _ = io.WriteString(w, `{"counts":[]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/orders/search":
+			var body searchOrdersRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.LocationIDs[0] != "LOC1" || body.Query.Filter.StateFilter.States[0] != "COMPLETED" {
+				t.Errorf("search = %+v", body)
+			}
+			page := searchOrdersResponse{}
+			if body.Cursor == "" {
+				page.Orders, page.Cursor = f.orders[:1], "next"
+			} else {
+				page.Orders = f.orders[1:]
+			}
+			_ = json.NewEncoder(w).Encode(page)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"errors":[{"code":"NOT_FOUND","detail":"Not found."}]}`)
+		}
+	})
+}
+
+func newTestClient(t *testing.T, server *httptest.Server) *Client {
+	t.Helper()
+	client, err := NewClient("sq-token", "LOC1", WithBaseURL(server.URL+"/"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func boltItem() manapool.InventoryItem {
+	return manapool.InventoryItem{ID: "inv-bolt", PriceCents: 1000, Quantity: 2, Product: manapool.Product{Single: &manapool.Single{
+		Name: "Lightning Bolt", Set: "m10", Number: "146", ConditionID: "NM", FinishID: "FO", LanguageID: "JA",
+	}}}
+}
+
+func TestClient_ListingLifecycle(t *testing.T) {
+	pos := newFakeSquare()
+	server := httptest.NewServer(pos.handler(t))
+	defer server.Close()
+	ctx := context.Background()
+	client := newTestClient(t, server)
+
+	externalID, err := client.ListItem(ctx, boltItem())
+	if err != nil {
+		t.Fatalf("ListItem() error = %v", err)
+	}
+	if externalID != "VAR1" {
+		t.Errorf("external ID = %q, want VAR1", externalID)
+	}
+	item := pos.objects["ITEM1"].ItemData
+	variation := pos.objects["VAR1"].ItemVariationData
+	if item.Name != "Lightning Bolt (M10 146)" || item.Description != "Near Mint Foil, Japanese" {
+		t.Errorf("item = %+v", item)
+	}
+	if variation.Name != "Near Mint Foil" || variation.SKU != "inv-bolt" || variation.PriceMoney.Amount != 1000 ||
+		variation.PriceMoney.Currency != "USD" || !variation.TrackInventory {
+		t.Errorf("variation = %+v", variation)
+	}
+	if pos.counts["VAR1"] != "2" {
+		t.Errorf("count = %q, want 2", pos.counts["VAR1"])
+	}
+
+	if err := client.UpdatePrice(ctx, externalID, 1250); err != nil {
+		t.Fatalf("UpdatePrice() error = %v", err)
+	}
+	if got := pos.objects["VAR1"]; got.ItemVariationData.PriceMoney.Amount != 1250 || got.Version != 1 {
+		t.Errorf("updated variation = %+v", got)
+	}
+	if err := client.UpdateQuantity(ctx, externalID, 0); err != nil {
+		t.Fatalf("UpdateQuantity() error = %v", err)
+	}
+	if pos.counts["VAR1"] != "0" {
+		t.Errorf("count = %q, want 0", pos.counts["VAR1"])
+	}
+	if err := client.Delist(ctx, externalID); err != nil {
+		t.Fatalf("Delist() error = %v", err)
+	}
+	if _, ok := pos.objects["ITEM1"]; ok {
+		t.Error("item not deleted")
+	}
+
+	want := "POST /v2/catalog/object,POST /v2/inventory/changes/batch-create," +
+		"GET /v2/catalog/object/VAR1,POST /v2/catalog/object," +
+		"POST /v2/inventory/changes/batch-create," +
+		"GET /v2/catalog/object/VAR1,DELETE /v2/catalog/object/ITEM1"
+	if got := strings.Join(pos.requests, ","); got != want {
+		t.Errorf("requests = %s, want %s", got, want)
+	}
+}
+
+func TestClient_ListItem_RollsBack(t *testing.T) {
+	pos := newFakeSquare()
+	pos.failSet = true
+	server := httptest.NewServer(pos.handler(t))
+	defer server.Close()
+
+	_, err := newTestClient(t, server).ListItem(context.Background(), boltItem())
+	var apiErr *manapool.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "INVALID_VALUE" || apiErr.Message != "Invalid location." {
+		t.Fatalf("ListItem() error = %v", err)
+	}
+	if _, ok := pos.objects["ITEM1"]; ok {
+		t.Error("item not deleted after failed count")
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	pos := newFakeSquare()
+	server := httptest.NewServer(pos.handler(t))
+	defer server.Close()
+	ctx := context.Background()
+
+	var apiErr *manapool.APIError
+	if err := newTestClient(t, server).UpdatePrice(ctx, "VAR9", 100); !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("unknown variation error = %v", err)
+	}
+
+	client, _ := NewClient("wrong", "LOC1", WithBaseURL(server.URL+"/"))
+	if err := client.UpdateQuantity(ctx, "VAR1", 1); !errors.As(err, &apiErr) || apiErr.Code != "UNAUTHORIZED" {
+		t.Errorf("unauthorized error = %v", err)
+	}
+
+	var valErr *manapool.ValidationError
+	if _, err := NewClient("", "LOC1"); !errors.As(err, &valErr) || valErr.Field != "access_token" {
+		t.Errorf("NewClient(no token) error = %v", err)
+	}
+	if _, err := NewClient("t", ""); !errors.As(err, &valErr) || valErr.Field != "location_id" {
+		t.Errorf("NewClient(no location) error = %v", err)
+	}
+}
+
+func TestClient_FetchSales(t *testing.T) {
+	pos := newFakeSquare()
+	closed := time.Date(2025, 3, 2, 18, 30, 0, 0, time.UTC)
+	pos.orders = []order{
+		{ID: "ORD1", ClosedAt: closed, LineItems: []lineItem{
+			{CatalogObjectID: "VAR1", Quantity: "1", BasePriceMoney: money{Amount: 1000, Currency: "USD"}},
+			{Quantity: "1", BasePriceMoney: money{Amount: 500, Currency: "USD"}},
+		}},
+		{ID: "ORD2", ClosedAt: closed.Add(time.Hour), LineItems: []lineItem{
+			{CatalogObjectID: "VAR7", Quantity: "3", BasePriceMoney: money{Amount: 25, Currency: "USD"}},
+		}},
+	}
+	server := httptest.NewServer(pos.handler(t))
+	defer server.Close()
+
+	ctx := context.Background()
+	links := manapool.NewMemoryListingLinks()
+	_ = links.Link(ctx, MarketplaceName, "inv-bolt", "VAR1")
+	sales, err := manapool.NewCrossLister(links, newTestClient(t, server)).FetchSales(ctx, closed.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("FetchSales() error = %v", err)
+	}
+	if len(sales) != 2 {
+		t.Fatalf("sales = %+v, want 2", sales)
+	}
+	if s := sales[0]; s.InventoryID != "inv-bolt" || s.OrderID != "ORD1" || s.Quantity != 1 || s.PriceCents != 1000 ||
+		s.Marketplace != "square" || !s.SoldAt.Equal(closed) {
+		t.Errorf("first sale = %+v", s)
+	}
+	if s := sales[1]; s.ExternalID != "VAR7" || s.InventoryID != "" || s.Quantity != 3 {
+		t.Errorf("second sale = %+v", s)
+	}
+	if len(pos.requests) != 2 {
+		t.Errorf("requests = %v, want two pages", pos.requests)
+	}
+}
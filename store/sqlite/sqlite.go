@@ -0,0 +1,437 @@
+// Package sqlite maintains a local SQLite mirror of a seller's ManaPool
+// inventory and orders, so reporting and search can run as local SQL queries
+// instead of repeated API calls.
+//
+// The package uses database/sql and does not import a driver; register one in
+// your program and pass the opened *sql.DB to New:
+//
+//	import _ "modernc.org/sqlite" // or github.com/mattn/go-sqlite3
+//
+//	db, err := sql.Open("sqlite", "manapool.db")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	mirror := sqlite.New(db, client)
+//	if err := mirror.Migrate(ctx); err != nil {
+//	    log.Fatal(err)
+//	}
+//	stats, err := mirror.Sync(ctx)
+//
+// Tables:
+//   - inventory: one row per listing, with the full item as JSON in data
+//   - orders: one row per order summary, with the summary as JSON in data
+//   - sync_state: sync cursors
+//
+// Timestamps are stored as RFC 3339 text in UTC with a fixed nine-digit
+// fraction, so they sort correctly as text.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+// DefaultOrderLookback is how far before the newest mirrored order an
+// incremental order sync starts, so recent orders whose fulfillment status
+// changed are refreshed.
+const DefaultOrderLookback = 72 * time.Hour
+
+// pageSize is the page size used for inventory and order listing calls.
+const pageSize = 500
+
+// orderCursor is the sync_state key holding the newest mirrored order time.
+const orderCursor = "orders.created_at"
+
+// Source is the subset of *manapool.Client the mirror reads from.
+type Source interface {
+	manapool.APIClient
+	GetSellerOrders(ctx context.Context, opts manapool.OrdersOptions) (*manapool.OrdersResponse, error)
+}
+
+// SQL statements. SQLite 3.24+ is required for ON CONFLICT upserts.
+var schemaStatements = []string{
+	`CREATE TABLE IF NOT EXISTS inventory (
+	id TEXT PRIMARY KEY,
+	product_type TEXT NOT NULL,
+	product_id TEXT NOT NULL,
+	tcgplayer_sku INTEGER,
+	name TEXT NOT NULL,
+	set_code TEXT NOT NULL,
+	price_cents INTEGER NOT NULL,
+	quantity INTEGER NOT NULL,
+	effective_as_of TEXT NOT NULL,
+	data TEXT NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS inventory_tcgplayer_sku ON inventory (tcgplayer_sku)`,
+	`CREATE INDEX IF NOT EXISTS inventory_name ON inventory (name)`,
+	`CREATE TABLE IF NOT EXISTS orders (
+	id TEXT PRIMARY KEY,
+	created_at TEXT NOT NULL,
+	label TEXT NOT NULL,
+	total_cents INTEGER NOT NULL,
+	shipping_method TEXT NOT NULL,
+	latest_fulfillment_status TEXT,
+	data TEXT NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS orders_created_at ON orders (created_at)`,
+	`CREATE TABLE IF NOT EXISTS sync_state (
+	name TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+)`,
+}
+
+const (
+	selectInventoryVersions = `SELECT id, effective_as_of FROM inventory`
+
+	upsertInventory = `INSERT INTO inventory
+	(id, product_type, product_id, tcgplayer_sku, name, set_code, price_cents, quantity, effective_as_of, data)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (id) DO UPDATE SET
+	product_type = excluded.product_type, product_id = excluded.product_id,
+	tcgplayer_sku = excluded.tcgplayer_sku, name = excluded.name, set_code = excluded.set_code,
+	price_cents = excluded.price_cents, quantity = excluded.quantity,
+	effective_as_of = excluded.effective_as_of, data = excluded.data`
+
+	deleteInventory = `DELETE FROM inventory WHERE id = ?`
+
+	selectInventoryData = `SELECT data FROM inventory ORDER BY id`
+
+	upsertOrder = `INSERT INTO orders
+	(id, created_at, label, total_cents, shipping_method, latest_fulfillment_status, data)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (id) DO UPDATE SET
+	created_at = excluded.created_at, label = excluded.label, total_cents = excluded.total_cents,
+	shipping_method = excluded.shipping_method,
+	latest_fulfillment_status = excluded.latest_fulfillment_status, data = excluded.data`
+
+	selectOrdersData = `SELECT data FROM orders WHERE created_at >= ? ORDER BY created_at, id`
+
+	selectState = `SELECT value FROM sync_state WHERE name = ?`
+
+	upsertState = `INSERT INTO sync_state (name, value) VALUES (?, ?)
+	ON CONFLICT (name) DO UPDATE SET value = excluded.value`
+)
+
+// Mirror keeps a SQLite database in step with a seller's inventory and
+// orders. It is safe for concurrent use, but concurrent syncs do redundant
+// work.
+type Mirror struct {
+	db     *sql.DB
+	source Source
+
+	// OrderLookback overrides DefaultOrderLookback when positive.
+	OrderLookback time.Duration
+}
+
+// New creates a mirror that stores data in db and reads from source.
+func New(db *sql.DB, source Source) *Mirror {
+	return &Mirror{db: db, source: source}
+}
+
+// DB returns the underlying database for ad hoc queries.
+func (m *Mirror) DB() *sql.DB {
+	return m.db
+}
+
+// Migrate creates the mirror's tables and indexes if they do not exist.
+func (m *Mirror) Migrate(ctx context.Context) error {
+	for _, stmt := range schemaStatements {
+		if _, err := m.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to migrate mirror schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// SyncStats summarizes a sync run.
+type SyncStats struct {
+	InventoryInserted  int
+	InventoryUpdated   int
+	InventoryDeleted   int
+	InventoryUnchanged int
+	OrdersUpserted     int
+}
+
+// String renders a one-line summary of the stats.
+func (s *SyncStats) String() string {
+	return fmt.Sprintf("inventory: %d inserted, %d updated, %d deleted, %d unchanged; orders: %d upserted",
+		s.InventoryInserted, s.InventoryUpdated, s.InventoryDeleted, s.InventoryUnchanged, s.OrdersUpserted)
+}
+
+// Sync brings the mirror up to date with SyncInventory and then SyncOrders.
+func (m *Mirror) Sync(ctx context.Context) (*SyncStats, error) {
+	stats := &SyncStats{}
+	if err := m.SyncInventory(ctx, stats); err != nil {
+		return stats, err
+	}
+	if err := m.SyncOrders(ctx, stats); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+// SyncInventory pages through the seller's inventory and writes only the
+// listings whose effective_as_of is newer than the mirrored copy. Listings
+// that no longer exist remotely are deleted. Changes are applied in a single
+// transaction, so readers never see a half-synced inventory. Counts are added
+// to stats.
+func (m *Mirror) SyncInventory(ctx context.Context, stats *SyncStats) error {
+	versions, err := m.inventoryVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var changed []manapool.InventoryItem
+	seen := make(map[string]bool, len(versions))
+	err = manapool.IterateInventory(ctx, m.source, func(item *manapool.InventoryItem) error {
+		seen[item.ID] = true
+		stored, ok := versions[item.ID]
+		if ok && !item.EffectiveAsOf.After(stored) {
+			stats.InventoryUnchanged++
+			return nil
+		}
+		if ok {
+			stats.InventoryUpdated++
+		} else {
+			stats.InventoryInserted++
+		}
+		changed = append(changed, *item)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load remote inventory: %w", err)
+	}
+
+	return m.inTx(ctx, func(tx *sql.Tx) error {
+		for _, item := range changed {
+			if err := writeInventoryItem(ctx, tx, item); err != nil {
+				return err
+			}
+		}
+		for id := range versions {
+			if seen[id] {
+				continue
+			}
+			if _, err := tx.ExecContext(ctx, deleteInventory, id); err != nil {
+				return fmt.Errorf("failed to delete mirrored item %s: %w", id, err)
+			}
+			stats.InventoryDeleted++
+		}
+		return nil
+	})
+}
+
+// SyncOrders fetches orders created since the newest mirrored order, less
+// the lookback window, and upserts them. The first sync fetches every order.
+// Counts are added to stats.
+func (m *Mirror) SyncOrders(ctx context.Context, stats *SyncStats) error {
+	cursor, err := m.state(ctx, orderCursor)
+	if err != nil {
+		return err
+	}
+
+	var since *manapool.Timestamp
+	if cursor != "" {
+		t, err := time.Parse(time.RFC3339Nano, cursor)
+		if err != nil {
+			return fmt.Errorf("failed to parse order cursor %q: %w", cursor, err)
+		}
+		lookback := m.OrderLookback
+		if lookback <= 0 {
+			lookback = DefaultOrderLookback
+		}
+		since = &manapool.Timestamp{Time: t.Add(-lookback)}
+	}
+
+	var orders []manapool.OrderSummary
+	for offset := 0; ; offset += pageSize {
+		page, err := m.source.GetSellerOrders(ctx, manapool.OrdersOptions{Since: since, Limit: pageSize, Offset: offset})
+		if err != nil {
+			return fmt.Errorf("failed to load orders at offset %d: %w", offset, err)
+		}
+		orders = append(orders, page.Orders...)
+		if len(page.Orders) < pageSize {
+			break
+		}
+	}
+
+	return m.inTx(ctx, func(tx *sql.Tx) error {
+		newest := cursor
+		for _, order := range orders {
+			data, err := json.Marshal(order)
+			if err != nil {
+				return fmt.Errorf("failed to encode order %s: %w", order.ID, err)
+			}
+			createdAt := formatTime(order.CreatedAt.Time)
+			_, err = tx.ExecContext(ctx, upsertOrder,
+				order.ID, createdAt, order.Label, order.TotalCents, order.ShippingMethod,
+				order.LatestFulfillmentStatus, string(data))
+			if err != nil {
+				return fmt.Errorf("failed to write mirrored order %s: %w", order.ID, err)
+			}
+			stats.OrdersUpserted++
+			if createdAt > newest {
+				newest = createdAt
+			}
+		}
+		if newest == cursor {
+			return nil
+		}
+		if _, err := tx.ExecContext(ctx, upsertState, orderCursor, newest); err != nil {
+			return fmt.Errorf("failed to save order cursor: %w", err)
+		}
+		return nil
+	})
+}
+
+// Inventory returns every mirrored listing, ordered by ID.
+func (m *Mirror) Inventory(ctx context.Context) ([]manapool.InventoryItem, error) {
+	var items []manapool.InventoryItem
+	err := m.queryJSON(ctx, selectInventoryData, nil, func(data []byte) error {
+		var item manapool.InventoryItem
+		if err := json.Unmarshal(data, &item); err != nil {
+			return err
+		}
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mirrored inventory: %w", err)
+	}
+	return items, nil
+}
+
+// Orders returns mirrored orders created at or after since, oldest first.
+func (m *Mirror) Orders(ctx context.Context, since time.Time) ([]manapool.OrderSummary, error) {
+	var orders []manapool.OrderSummary
+	err := m.queryJSON(ctx, selectOrdersData, []interface{}{formatTime(since)}, func(data []byte) error {
+		var order manapool.OrderSummary
+		if err := json.Unmarshal(data, &order); err != nil {
+			return err
+		}
+		orders = append(orders, order)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mirrored orders: %w", err)
+	}
+	return orders, nil
+}
+
+// inventoryVersions returns the effective_as_of of every mirrored listing.
+func (m *Mirror) inventoryVersions(ctx context.Context) (map[string]time.Time, error) {
+	rows, err := m.db.QueryContext(ctx, selectInventoryVersions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mirrored inventory: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	versions := make(map[string]time.Time)
+	for rows.Next() {
+		var id, asOf string
+		if err := rows.Scan(&id, &asOf); err != nil {
+			return nil, fmt.Errorf("failed to read mirrored inventory: %w", err)
+		}
+		t, err := time.Parse(time.RFC3339Nano, asOf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse effective_as_of for %s: %w", id, err)
+		}
+		versions[id] = t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read mirrored inventory: %w", err)
+	}
+	return versions, nil
+}
+
+// state returns a sync_state value, or "" if it is not set.
+func (m *Mirror) state(ctx context.Context, name string) (string, error) {
+	var value string
+	err := m.db.QueryRowContext(ctx, selectState, name).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read sync state %s: %w", name, err)
+	}
+	return value, nil
+}
+
+// queryJSON runs query and calls fn with the single data column of each row.
+func (m *Mirror) queryJSON(ctx context.Context, query string, args []interface{}, fn func([]byte) error) error {
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return err
+		}
+		if err := fn([]byte(data)); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// inTx runs fn in a transaction, committing if it succeeds.
+func (m *Mirror) inTx(ctx context.Context, fn func(*sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// writeInventoryItem upserts one listing.
+func writeInventoryItem(ctx context.Context, tx *sql.Tx, item manapool.InventoryItem) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("failed to encode item %s: %w", item.ID, err)
+	}
+
+	var name, set string
+	switch {
+	case item.Product.Single != nil:
+		name, set = item.Product.Single.Name, item.Product.Single.Set
+	case item.Product.Sealed != nil:
+		name, set = item.Product.Sealed.Name, item.Product.Sealed.Set
+	}
+
+	_, err = tx.ExecContext(ctx, upsertInventory,
+		item.ID, item.ProductType, item.ProductID, item.Product.TCGPlayerSKU, name, set,
+		item.PriceCents, item.Quantity, formatTime(item.EffectiveAsOf.Time), string(data))
+	if err != nil {
+		return fmt.Errorf("failed to write mirrored item %s: %w", item.ID, err)
+	}
+	return nil
+}
+
+// timeLayout is RFC 3339 with a fixed-width fraction. time.RFC3339Nano
+// trims trailing zeros, and "05Z" sorts after "05.5Z" as text.
+const timeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// formatTime formats t as sortable RFC 3339 text in UTC.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
@@ -0,0 +1,443 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/repricah/manapool"
+	"github.com/repricah/manapool/manapooltest"
+)
+
+// memDB is an in-memory database/sql driver that understands exactly the
+// statements this package issues, so the mirror logic can be tested without
+// a SQLite driver.
+type memDB struct {
+	mu        sync.Mutex
+	inventory map[string][]driver.Value
+	orders    map[string][]driver.Value
+	state     map[string]string
+	saved     *memDB
+	migrated  int
+	failOn    string
+}
+
+func newMemDB() *memDB {
+	return &memDB{
+		inventory: make(map[string][]driver.Value),
+		orders:    make(map[string][]driver.Value),
+		state:     make(map[string]string),
+	}
+}
+
+func (m *memDB) Connect(context.Context) (driver.Conn, error) { return &memConn{db: m}, nil }
+func (m *memDB) Driver() driver.Driver                        { return nil }
+
+func (m *memDB) clone() *memDB {
+	c := newMemDB()
+	for k, v := range m.inventory {
+		c.inventory[k] = v
+	}
+	for k, v := range m.orders {
+		c.orders[k] = v
+	}
+	for k, v := range m.state {
+		c.state[k] = v
+	}
+	return c
+}
+
+func (m *memDB) exec(query string, args []driver.Value) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.failOn != "" && query == m.failOn {
+		return errors.New("disk I/O error")
+	}
+	switch query {
+	case upsertInventory:
+		m.inventory[args[0].(string)] = args
+	case deleteInventory:
+		delete(m.inventory, args[0].(string))
+	case upsertOrder:
+		m.orders[args[0].(string)] = args
+	case upsertState:
+		m.state[args[0].(string)] = args[1].(string)
+	default:
+		if !strings.HasPrefix(query, "CREATE ") {
+			return errors.New("unexpected statement: " + query)
+		}
+		m.migrated++
+	}
+	return nil
+}
+
+func (m *memDB) query(query string, args []driver.Value) (driver.Rows, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rows := &memRows{}
+	switch query {
+	case selectInventoryVersions:
+		rows.columns = []string{"id", "effective_as_of"}
+		for id, row := range m.inventory {
+			rows.values = append(rows.values, []driver.Value{id, row[8]})
+		}
+	case selectInventoryData:
+		rows.columns = []string{"data"}
+		for _, id := range sortedKeys(m.inventory) {
+			rows.values = append(rows.values, []driver.Value{m.inventory[id][9]})
+		}
+	case selectOrdersData:
+		rows.columns = []string{"data"}
+		var matched [][]driver.Value
+		for _, row := range m.orders {
+			if row[1].(string) >= args[0].(string) {
+				matched = append(matched, row)
+			}
+		}
+		sort.Slice(matched, func(i, j int) bool {
+			if matched[i][1] != matched[j][1] {
+				return matched[i][1].(string) < matched[j][1].(string)
+			}
+			return matched[i][0].(string) < matched[j][0].(string)
+		})
+		for _, row := range matched {
+			rows.values = append(rows.values, []driver.Value{row[6]})
+		}
+	case selectState:
+		rows.columns = []string{"value"}
+		if value, ok := m.state[args[0].(string)]; ok {
+			rows.values = append(rows.values, []driver.Value{value})
+		}
+	default:
+		return nil, errors.New("unexpected query: " + query)
+	}
+	return rows, nil
+}
+
+func sortedKeys(m map[string][]driver.Value) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type memConn struct{ db *memDB }
+
+func (c *memConn) Prepare(query string) (driver.Stmt, error) {
+	return &memStmt{db: c.db, query: query}, nil
+}
+func (c *memConn) Close() error { return nil }
+
+func (c *memConn) Begin() (driver.Tx, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.saved = c.db.clone()
+	return &memTx{db: c.db}, nil
+}
+
+type memTx struct{ db *memDB }
+
+func (t *memTx) Commit() error {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+	t.db.saved = nil
+	return nil
+}
+
+func (t *memTx) Rollback() error {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+	t.db.inventory, t.db.orders, t.db.state = t.db.saved.inventory, t.db.saved.orders, t.db.saved.state
+	t.db.saved = nil
+	return nil
+}
+
+type memStmt struct {
+	db    *memDB
+	query string
+}
+
+func (s *memStmt) Close() error  { return nil }
+func (s *memStmt) NumInput() int { return -1 }
+
+func (s *memStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), s.db.exec(s.query, args)
+}
+
+func (s *memStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.db.query(s.query, args)
+}
+
+type memRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *memRows) Columns() []string { return r.columns }
+func (r *memRows) Close() error      { return nil }
+
+func (r *memRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func newTestMirror(t *testing.T, fake *manapooltest.FakeClient) (*Mirror, *memDB) {
+	t.Helper()
+	mem := newMemDB()
+	db := sql.OpenDB(mem)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mirror := New(db, fake)
+	if err := mirror.Migrate(context.Background()); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	return mirror, mem
+}
+
+var base = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func item(id string, price int, asOf time.Time) manapool.InventoryItem {
+	sku := len(id) * 1000
+	return manapool.InventoryItem{
+		ID:          id,
+		ProductType: "mtg_single",
+		ProductID:   "p" + id,
+		Product: manapool.Product{
+			TCGPlayerSKU: &sku,
+			Single:       &manapool.Single{Name: "Card " + id, Set: "LEA"},
+		},
+		PriceCents:    price,
+		Quantity:      1,
+		EffectiveAsOf: manapool.Timestamp{Time: asOf},
+	}
+}
+
+func order(id string, createdAt time.Time) manapool.OrderDetails {
+	return manapool.OrderDetails{OrderSummary: manapool.OrderSummary{
+		ID:         id,
+		CreatedAt:  manapool.Timestamp{Time: createdAt},
+		Label:      "label-" + id,
+		TotalCents: 1000,
+	}}
+}
+
+func TestMirror_Migrate(t *testing.T) {
+	_, mem := newTestMirror(t, manapooltest.NewFakeClient())
+	if mem.migrated != len(schemaStatements) {
+		t.Errorf("migrated %d statements, want %d", mem.migrated, len(schemaStatements))
+	}
+
+	mem.failOn = schemaStatements[0]
+	err := New(sql.OpenDB(mem), nil).Migrate(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to migrate mirror schema") {
+		t.Errorf("Migrate() error = %v", err)
+	}
+}
+
+func TestMirror_SyncInventory(t *testing.T) {
+	ctx := context.Background()
+	fake := manapooltest.NewFakeClient(manapooltest.WithInventory(
+		item("a", 100, base), item("b", 200, base), item("c", 300, base),
+	))
+	mirror, _ := newTestMirror(t, fake)
+
+	stats, err := mirror.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if stats.InventoryInserted != 3 || stats.InventoryUpdated != 0 || stats.InventoryDeleted != 0 {
+		t.Errorf("first Sync() = %s", stats)
+	}
+
+	// "a" changes without a newer timestamp and is left alone, "b" is
+	// updated, "c" is gone, and "d" is new.
+	fake.SetInventory(item("a", 150, base), item("b", 250, base.Add(time.Minute)), item("d", 400, base))
+
+	stats, err = mirror.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	want := SyncStats{InventoryInserted: 1, InventoryUpdated: 1, InventoryDeleted: 1, InventoryUnchanged: 1}
+	if *stats != want {
+		t.Errorf("second Sync() = %s, want %s", stats, &want)
+	}
+
+	items, err := mirror.Inventory(ctx)
+	if err != nil {
+		t.Fatalf("Inventory() error = %v", err)
+	}
+	var got []string
+	for _, it := range items {
+		got = append(got, it.ID)
+	}
+	if strings.Join(got, ",") != "a,b,d" || items[0].PriceCents != 100 || items[1].PriceCents != 250 {
+		t.Errorf("Inventory() = %+v", items)
+	}
+	if items[1].Product.Single == nil || items[1].Product.Single.Name != "Card b" ||
+		!items[1].EffectiveAsOf.Equal(base.Add(time.Minute)) {
+		t.Errorf("Inventory() did not round-trip item b: %+v", items[1])
+	}
+}
+
+func TestMirror_SyncInventoryRollsBack(t *testing.T) {
+	ctx := context.Background()
+	fake := manapooltest.NewFakeClient(manapooltest.WithInventory(item("a", 100, base), item("b", 200, base)))
+	mirror, mem := newTestMirror(t, fake)
+
+	if _, err := mirror.Sync(ctx); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	fake.SetInventory(item("a", 500, base.Add(time.Hour)))
+	mem.failOn = deleteInventory
+	if _, err := mirror.Sync(ctx); err == nil || !strings.Contains(err.Error(), "failed to delete mirrored item b") {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	mem.failOn = ""
+	items, err := mirror.Inventory(ctx)
+	if err != nil || len(items) != 2 || items[0].PriceCents != 100 {
+		t.Errorf("Inventory() after failed sync = %+v, %v", items, err)
+	}
+}
+
+func TestMirror_SyncOrders(t *testing.T) {
+	ctx := context.Background()
+	fake := manapooltest.NewFakeClient(manapooltest.WithOrders(
+		order("o1", base.Add(-10*24*time.Hour)), order("o2", base),
+	))
+	mirror, mem := newTestMirror(t, fake)
+	mirror.OrderLookback = time.Hour
+
+	stats, err := mirror.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if stats.OrdersUpserted != 2 || mem.state[orderCursor] != formatTime(base) {
+		t.Errorf("first Sync() = %s, cursor %q", stats, mem.state[orderCursor])
+	}
+
+	// Only orders inside the lookback window are fetched again; an order
+	// backdated before it is not.
+	fake.AddOrders(order("o3", base.Add(-2*time.Hour)), order("o4", base.Add(time.Hour)))
+	stats, err = mirror.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if stats.OrdersUpserted != 2 || mem.state[orderCursor] != formatTime(base.Add(time.Hour)) {
+		t.Errorf("second Sync() = %s, cursor %q", stats, mem.state[orderCursor])
+	}
+
+	orders, err := mirror.Orders(ctx, base.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("Orders() error = %v", err)
+	}
+	if len(orders) != 2 || orders[0].ID != "o2" || orders[1].ID != "o4" || orders[1].Label != "label-o4" {
+		t.Errorf("Orders() = %+v", orders)
+	}
+	if all, _ := mirror.Orders(ctx, time.Time{}); len(all) != 3 {
+		t.Errorf("Orders(zero) = %d orders, want 3", len(all))
+	}
+}
+
+func TestMirror_SyncOrdersFractionalSeconds(t *testing.T) {
+	ctx := context.Background()
+	fake := manapooltest.NewFakeClient(manapooltest.WithOrders(
+		order("whole", base), order("half", base.Add(500*time.Millisecond)),
+	))
+	mirror, mem := newTestMirror(t, fake)
+
+	if _, err := mirror.Sync(ctx); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if want := formatTime(base.Add(500 * time.Millisecond)); mem.state[orderCursor] != want {
+		t.Errorf("cursor = %q, want %q", mem.state[orderCursor], want)
+	}
+
+	orders, err := mirror.Orders(ctx, base.Add(250*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Orders() error = %v", err)
+	}
+	if len(orders) != 1 || orders[0].ID != "half" {
+		t.Errorf("Orders() = %+v, want only the later order", orders)
+	}
+	if all, _ := mirror.Orders(ctx, base); len(all) != 2 || all[0].ID != "whole" {
+		t.Errorf("Orders(base) = %+v, want both in time order", all)
+	}
+}
+
+func TestFormatTime_Sorts(t *testing.T) {
+	times := []time.Time{
+		base,
+		base.Add(time.Nanosecond),
+		base.Add(500 * time.Millisecond),
+		base.Add(time.Second),
+		base.Add(time.Second + 50*time.Millisecond),
+		base.Add(time.Minute).In(time.FixedZone("EST", -5*3600)),
+	}
+	for i := 1; i < len(times); i++ {
+		prev, cur := formatTime(times[i-1]), formatTime(times[i])
+		if len(prev) != len(cur) || prev >= cur {
+			t.Errorf("formatTime(%v) = %q does not sort before %q", times[i-1], prev, cur)
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, cur)
+		if err != nil || !parsed.Equal(times[i]) {
+			t.Errorf("time.Parse(%q) = %v, %v, want %v", cur, parsed, err, times[i])
+		}
+	}
+}
+
+func TestMirror_SyncOrdersPages(t *testing.T) {
+	orders := make([]manapool.OrderDetails, pageSize+1)
+	for i := range orders {
+		orders[i] = order(fmt.Sprintf("o%d", i), base.Add(time.Duration(i)*time.Second))
+	}
+	fake := manapooltest.NewFakeClient(manapooltest.WithOrders(orders...))
+	mirror, _ := newTestMirror(t, fake)
+
+	stats, err := mirror.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if stats.OrdersUpserted != pageSize+1 {
+		t.Errorf("OrdersUpserted = %d, want %d", stats.OrdersUpserted, pageSize+1)
+	}
+}
+
+func TestMirror_SourceErrors(t *testing.T) {
+	ctx := context.Background()
+	boom := manapool.NewAPIError(500, "boom")
+
+	fake := manapooltest.NewFakeClient(manapooltest.WithInventory(item("a", 100, base)))
+	mirror, _ := newTestMirror(t, fake)
+	fake.FailMethod("GetSellerInventory", boom)
+	if _, err := mirror.Sync(ctx); !errors.Is(err, boom) || !strings.Contains(err.Error(), "failed to load remote inventory") {
+		t.Errorf("Sync() error = %v", err)
+	}
+
+	fake.FailMethod("GetSellerInventory", nil)
+	fake.FailMethod("GetSellerOrders", boom)
+	stats, err := mirror.Sync(ctx)
+	if !errors.Is(err, boom) || !strings.Contains(err.Error(), "failed to load orders at offset 0") {
+		t.Errorf("Sync() error = %v", err)
+	}
+	if stats.InventoryInserted != 1 {
+		t.Errorf("inventory should sync before orders fail: %s", stats)
+	}
+}
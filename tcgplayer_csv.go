@@ -0,0 +1,278 @@
+package manapool
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// tcgplayerCSVHeader is the column layout of TCGplayer's staged-inventory
+// and pricing export.
+var tcgplayerCSVHeader = []string{
+	"TCGplayer Id",
+	"Product Line",
+	"Set Name",
+	"Product Name",
+	"Title",
+	"Number",
+	"Rarity",
+	"Condition",
+	"TCG Market Price",
+	"TCG Direct Low",
+	"TCG Low Price With Shipping",
+	"TCG Low Price",
+	"Total Quantity",
+	"Add to Quantity",
+	"TCG Marketplace Price",
+	"Photo URL",
+}
+
+// TCGPlayerCSVRow is a single row in TCGplayer's staged-inventory CSV format.
+// Prices are in cents; optional reference prices are nil when blank.
+type TCGPlayerCSVRow struct {
+	// TCGPlayerID is the TCGplayer SKU ("TCGplayer Id" column)
+	TCGPlayerID int
+
+	ProductLine string
+	SetName     string
+	ProductName string
+	Title       string
+	Number      string
+	Rarity      string
+	Condition   string
+
+	MarketPriceCents          *int
+	DirectLowCents            *int
+	LowPriceWithShippingCents *int
+	LowPriceCents             *int
+	TotalQuantity             int
+	AddToQuantity             int
+	MarketplacePriceCents     int
+	PhotoURL                  string
+}
+
+// BulkItem converts the row into a ManaPool bulk upsert item. The quantity is
+// the sum of Total Quantity and Add to Quantity.
+func (r TCGPlayerCSVRow) BulkItem() InventoryBulkItemBySKU {
+	return InventoryBulkItemBySKU{
+		TCGPlayerSKU: r.TCGPlayerID,
+		PriceCents:   r.MarketplacePriceCents,
+		Quantity:     r.TotalQuantity + r.AddToQuantity,
+	}
+}
+
+// TCGPlayerRowFromInventoryItem converts a ManaPool inventory item into a
+// TCGplayer CSV row. It returns false if the item has no TCGplayer SKU.
+func TCGPlayerRowFromInventoryItem(item InventoryItem) (TCGPlayerCSVRow, bool) {
+	if item.Product.TCGPlayerSKU == nil {
+		return TCGPlayerCSVRow{}, false
+	}
+
+	row := TCGPlayerCSVRow{
+		TCGPlayerID:           *item.Product.TCGPlayerSKU,
+		ProductLine:           "Magic",
+		TotalQuantity:         item.Quantity,
+		MarketplacePriceCents: item.PriceCents,
+	}
+	switch {
+	case item.Product.Single != nil:
+		single := item.Product.Single
+		row.SetName = single.Set
+		row.ProductName = single.Name
+		row.Number = single.Number
+		row.Condition = single.ConditionName()
+	case item.Product.Sealed != nil:
+		row.SetName = item.Product.Sealed.Set
+		row.ProductName = item.Product.Sealed.Name
+	}
+
+	return row, true
+}
+
+// ReadTCGPlayerCSV parses a TCGplayer staged-inventory CSV export.
+//
+// Columns are matched by header name, so column order does not matter and
+// unknown columns are ignored. The "TCGplayer Id" column is required. Every
+// row is validated; if any row is invalid, no rows are returned and the
+// error is a *CSVValidationError listing all problems.
+func ReadTCGPlayerCSV(r io.Reader) ([]TCGPlayerCSVRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, NewValidationError("csv", "missing header row")
+		}
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["tcgplayer id"]; !ok {
+		return nil, NewValidationError("csv", "missing required column TCGplayer Id")
+	}
+
+	field := func(record []string, name string) string {
+		index, ok := columns[strings.ToLower(name)]
+		if !ok {
+			return ""
+		}
+		return csvField(record, index)
+	}
+
+	var rows []TCGPlayerCSVRow
+	var rowErrs []CSVRowError
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrs = append(rowErrs, CSVRowError{Line: parseErr.Line, Message: parseErr.Err.Error()})
+				continue
+			}
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		row := TCGPlayerCSVRow{
+			ProductLine: field(record, "Product Line"),
+			SetName:     field(record, "Set Name"),
+			ProductName: field(record, "Product Name"),
+			Title:       field(record, "Title"),
+			Number:      field(record, "Number"),
+			Rarity:      field(record, "Rarity"),
+			Condition:   field(record, "Condition"),
+			PhotoURL:    field(record, "Photo URL"),
+		}
+		rowOK := true
+		fail := func(column, message string) {
+			rowErrs = append(rowErrs, CSVRowError{Line: line, Column: column, Message: message})
+			rowOK = false
+		}
+
+		if id, err := strconv.Atoi(field(record, "TCGplayer Id")); err != nil || id <= 0 {
+			fail("TCGplayer Id", "must be a positive integer")
+		} else {
+			row.TCGPlayerID = id
+		}
+
+		for _, col := range []struct {
+			name string
+			dst  *int
+		}{
+			{"Total Quantity", &row.TotalQuantity},
+			{"Add to Quantity", &row.AddToQuantity},
+		} {
+			value := field(record, col.name)
+			if value == "" {
+				continue
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				fail(col.name, "must be a non-negative integer")
+				continue
+			}
+			*col.dst = n
+		}
+
+		if value := field(record, "TCG Marketplace Price"); value != "" {
+			cents, err := parseDollarsToCents(value)
+			if err != nil {
+				fail("TCG Marketplace Price", err.Error())
+			} else {
+				row.MarketplacePriceCents = cents
+			}
+		}
+
+		for _, col := range []struct {
+			name string
+			dst  **int
+		}{
+			{"TCG Market Price", &row.MarketPriceCents},
+			{"TCG Direct Low", &row.DirectLowCents},
+			{"TCG Low Price With Shipping", &row.LowPriceWithShippingCents},
+			{"TCG Low Price", &row.LowPriceCents},
+		} {
+			value := field(record, col.name)
+			if value == "" {
+				continue
+			}
+			cents, err := parseDollarsToCents(value)
+			if err != nil {
+				fail(col.name, err.Error())
+				continue
+			}
+			*col.dst = &cents
+		}
+
+		if rowOK {
+			rows = append(rows, row)
+		}
+	}
+
+	if len(rowErrs) > 0 {
+		return nil, &CSVValidationError{Errors: rowErrs}
+	}
+
+	return rows, nil
+}
+
+// WriteTCGPlayerCSV writes rows in TCGplayer's staged-inventory CSV format,
+// including the header line.
+func WriteTCGPlayerCSV(w io.Writer, rows []TCGPlayerCSVRow) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(tcgplayerCSVHeader); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	for _, row := range rows {
+		record := []string{
+			strconv.Itoa(row.TCGPlayerID),
+			row.ProductLine,
+			row.SetName,
+			row.ProductName,
+			row.Title,
+			row.Number,
+			row.Rarity,
+			row.Condition,
+			formatOptionalCents(row.MarketPriceCents),
+			formatOptionalCents(row.DirectLowCents),
+			formatOptionalCents(row.LowPriceWithShippingCents),
+			formatOptionalCents(row.LowPriceCents),
+			strconv.Itoa(row.TotalQuantity),
+			strconv.Itoa(row.AddToQuantity),
+			FormatCents(row.MarketplacePriceCents),
+			row.PhotoURL,
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write csv row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
+
+// formatOptionalCents formats cents, returning "" for nil.
+func formatOptionalCents(cents *int) string {
+	if cents == nil {
+		return ""
+	}
+	return FormatCents(*cents)
+}
@@ -0,0 +1,134 @@
+package manapool
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const tcgplayerCSVSample = "TCGplayer Id,Product Line,Set Name,Product Name,Title,Number,Rarity,Condition,TCG Market Price,TCG Direct Low,TCG Low Price With Shipping,TCG Low Price,Total Quantity,Add to Quantity,TCG Marketplace Price,Photo URL\n" +
+	"4549403,Magic,Alpha,Lightning Bolt,,161,C,Near Mint,1.50,,2.25,1.10,2,1,1.45,\n"
+
+func TestReadTCGPlayerCSV(t *testing.T) {
+	rows, err := ReadTCGPlayerCSV(strings.NewReader(tcgplayerCSVSample))
+	if err != nil {
+		t.Fatalf("ReadTCGPlayerCSV() error = %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("rows = %d, want 1", len(rows))
+	}
+
+	row := rows[0]
+	if row.TCGPlayerID != 4549403 || row.ProductName != "Lightning Bolt" || row.Condition != "Near Mint" {
+		t.Errorf("row = %+v", row)
+	}
+	if row.MarketPriceCents == nil || *row.MarketPriceCents != 150 {
+		t.Errorf("MarketPriceCents = %v, want 150", row.MarketPriceCents)
+	}
+	if row.DirectLowCents != nil {
+		t.Errorf("DirectLowCents = %v, want nil", *row.DirectLowCents)
+	}
+
+	item := row.BulkItem()
+	want := InventoryBulkItemBySKU{TCGPlayerSKU: 4549403, PriceCents: 145, Quantity: 3}
+	if item != want {
+		t.Errorf("BulkItem() = %+v, want %+v", item, want)
+	}
+}
+
+func TestReadTCGPlayerCSV_RoundTrip(t *testing.T) {
+	rows, err := ReadTCGPlayerCSV(strings.NewReader(tcgplayerCSVSample))
+	if err != nil {
+		t.Fatalf("ReadTCGPlayerCSV() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTCGPlayerCSV(&buf, rows); err != nil {
+		t.Fatalf("WriteTCGPlayerCSV() error = %v", err)
+	}
+	if buf.String() != tcgplayerCSVSample {
+		t.Errorf("round trip mismatch\ngot:  %q\nwant: %q", buf.String(), tcgplayerCSVSample)
+	}
+}
+
+func TestReadTCGPlayerCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "empty", input: "", wantErr: "missing header row"},
+		{name: "no id column", input: "Product Name\nBolt\n", wantErr: "TCGplayer Id"},
+		{name: "bad header", input: "\"x\n", wantErr: "failed to read csv header"},
+		{
+			name:    "invalid values",
+			input:   "TCGplayer Id,Total Quantity,TCG Marketplace Price,TCG Low Price\nx,-1,abc,1.234\n",
+			wantErr: "4 errors",
+		},
+		{
+			name:    "parse error",
+			input:   "TCGplayer Id\n\"1\n",
+			wantErr: "line 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadTCGPlayerCSV(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadTCGPlayerCSV_BOMAndBlankLines(t *testing.T) {
+	rows, err := ReadTCGPlayerCSV(strings.NewReader("\ufeffTCGplayer Id,Total Quantity\n\n12,3\n"))
+	if err != nil {
+		t.Fatalf("ReadTCGPlayerCSV() error = %v", err)
+	}
+	if len(rows) != 1 || rows[0].TCGPlayerID != 12 || rows[0].TotalQuantity != 3 {
+		t.Errorf("rows = %+v", rows)
+	}
+}
+
+func TestTCGPlayerRowFromInventoryItem(t *testing.T) {
+	sku := 42
+	single := InventoryItem{
+		PriceCents: 199,
+		Quantity:   5,
+		Product: Product{
+			TCGPlayerSKU: &sku,
+			Single:       &Single{Name: "Bolt", Set: "LEA", Number: "161", ConditionID: "LP", FinishID: "FO"},
+		},
+	}
+	row, ok := TCGPlayerRowFromInventoryItem(single)
+	if !ok {
+		t.Fatal("TCGPlayerRowFromInventoryItem() ok = false")
+	}
+	if row.TCGPlayerID != 42 || row.Condition != "Lightly Played Foil" || row.TotalQuantity != 5 || row.MarketplacePriceCents != 199 {
+		t.Errorf("row = %+v", row)
+	}
+
+	sealed := InventoryItem{Product: Product{TCGPlayerSKU: &sku, Sealed: &Sealed{Name: "Box", Set: "MH3"}}}
+	row, _ = TCGPlayerRowFromInventoryItem(sealed)
+	if row.ProductName != "Box" || row.SetName != "MH3" {
+		t.Errorf("sealed row = %+v", row)
+	}
+
+	if _, ok := TCGPlayerRowFromInventoryItem(InventoryItem{}); ok {
+		t.Error("item without sku returned ok")
+	}
+}
+
+func TestWriteTCGPlayerCSV_WriteError(t *testing.T) {
+	err := WriteTCGPlayerCSV(failingWriter{}, []TCGPlayerCSVRow{{TCGPlayerID: 1}})
+	if err == nil {
+		t.Fatal("WriteTCGPlayerCSV() error = nil, want error")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
@@ -0,0 +1,149 @@
+package manapool
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// builtinTimestampLayouts are the layouts the API is known to use.
+var builtinTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999-0700", // no-colon offset like +0000
+}
+
+var (
+	timestampLayoutsMu sync.RWMutex
+	timestampLayouts   []string
+
+	timestampLocation atomic.Pointer[time.Location]
+)
+
+// RegisterTimestampLayout makes Timestamp accept another time.Parse layout
+// when decoding. Registered layouts are tried after the built-in ones, in
+// registration order, so a change in the API's timestamp format can be
+// tolerated without a new release. Registering a layout twice has no
+// effect. It is safe for concurrent use, but is best called during program
+// initialization, since it affects every client.
+//
+// Example:
+//
+//	func init() {
+//	    manapool.RegisterTimestampLayout("2006-01-02 15:04:05")
+//	}
+func RegisterTimestampLayout(layout string) {
+	timestampLayoutsMu.Lock()
+	defer timestampLayoutsMu.Unlock()
+	for _, l := range timestampLayouts {
+		if l == layout {
+			return
+		}
+	}
+	timestampLayouts = append(timestampLayouts, layout)
+}
+
+// WithLenientTimestamps makes the client accept timestamps that match no
+// layout. By default such a response fails to decode with a
+// *TimestampError. With this option the Timestamp is left zero with the
+// original string in its Raw field, and the rest of the response decodes
+// normally. It affects only this client.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email, manapool.WithLenientTimestamps())
+func WithLenientTimestamps() ClientOption {
+	return func(c *Client) {
+		c.lenientTimestamps = true
+	}
+}
+
+// CheckTimestamps returns a *TimestampError for the first Timestamp
+// reachable from v that matched no layout when it was decoded. Timestamp
+// itself decodes such values into Raw without failing, so that leniency can
+// be chosen per client; clients call CheckTimestamps on every response
+// unless WithLenientTimestamps is used. Call it after decoding API JSON
+// yourself to get the same strictness.
+//
+// Example:
+//
+//	var order manapool.OrderDetails
+//	if err := json.Unmarshal(data, &order); err != nil {
+//	    return err
+//	}
+//	if err := manapool.CheckTimestamps(&order); err != nil {
+//	    return err
+//	}
+func CheckTimestamps(v any) error {
+	var err error
+	walkDecoded(reflect.ValueOf(v), "", func(path string, value reflect.Value) {
+		if ts, ok := value.Interface().(Timestamp); ok && ts.unparsed && err == nil {
+			err = &TimestampError{Path: path, Value: ts.Raw}
+		}
+	})
+	return err
+}
+
+// SetTimestampLocation converts every decoded Timestamp to loc, such as
+// time.UTC, so timestamps from endpoints that use different offsets compare
+// equal with == and store uniformly. A nil loc keeps each timestamp's
+// offset as received, which is the default. It affects every client.
+//
+// Example:
+//
+//	func init() {
+//	    manapool.SetTimestampLocation(time.UTC)
+//	}
+func SetTimestampLocation(loc *time.Location) {
+	timestampLocation.Store(loc)
+}
+
+// TimestampError reports a timestamp that matched no known layout.
+type TimestampError struct {
+	// Path locates the timestamp in the decoded value, such as
+	// "inventory[3].effective_as_of"; it is empty for a bare Timestamp
+	Path string
+
+	// Value is the timestamp string as received
+	Value string
+}
+
+// Error implements the error interface.
+func (e *TimestampError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("cannot parse timestamp: %q", e.Value)
+	}
+	return fmt.Sprintf("cannot parse timestamp at %s: %q", e.Path, e.Value)
+}
+
+// parseTimestamp parses s with the built-in layouts, then the registered
+// ones, and converts the result to the location set by
+// SetTimestampLocation.
+func parseTimestamp(s string) (time.Time, error) {
+	parsed, err := parseTimestampLayouts(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if loc := timestampLocation.Load(); loc != nil {
+		parsed = parsed.In(loc)
+	}
+	return parsed, nil
+}
+
+func parseTimestampLayouts(s string) (time.Time, error) {
+	for _, layout := range builtinTimestampLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			return parsed, nil
+		}
+	}
+
+	timestampLayoutsMu.RLock()
+	defer timestampLayoutsMu.RUnlock()
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, &TimestampError{Value: s}
+}
@@ -0,0 +1,145 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRegisterTimestampLayout(t *testing.T) {
+	t.Cleanup(func() {
+		timestampLayoutsMu.Lock()
+		timestampLayouts = nil
+		timestampLayoutsMu.Unlock()
+	})
+
+	var ts Timestamp
+	var tsErr *TimestampError
+	if err := json.Unmarshal([]byte(`"2025-08-05 20:38:54"`), &ts); err != nil {
+		t.Fatalf("Unmarshal(unregistered) error = %v", err)
+	}
+	if err := CheckTimestamps(ts); !errors.As(err, &tsErr) || tsErr.Value != "2025-08-05 20:38:54" {
+		t.Fatalf("CheckTimestamps(unregistered) error = %v", err)
+	}
+
+	RegisterTimestampLayout("2006-01-02 15:04:05")
+	RegisterTimestampLayout("2006-01-02 15:04:05")
+	if len(timestampLayouts) != 1 {
+		t.Errorf("layouts = %v, want one", timestampLayouts)
+	}
+	if err := json.Unmarshal([]byte(`"2025-08-05 20:38:54"`), &ts); err != nil {
+		t.Fatalf("Unmarshal(registered) error = %v", err)
+	}
+	if !ts.Equal(time.Date(2025, 8, 5, 20, 38, 54, 0, time.UTC)) || ts.Raw != "" {
+		t.Errorf("Unmarshal(registered) = %+v", ts)
+	}
+}
+
+func TestCheckTimestamps(t *testing.T) {
+	var resp InventoryResponse
+	err := json.Unmarshal([]byte(`{"inventory":[{"id":"a","effective_as_of":"2025-08-05T20:38:54Z"},{"id":"b","quantity":2,"effective_as_of":"yesterday"}]}`), &resp)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	item := resp.Inventory[1]
+	if item.Quantity != 2 || !item.EffectiveAsOf.IsZero() || item.EffectiveAsOf.Raw != "yesterday" {
+		t.Errorf("item = %+v", item)
+	}
+
+	var tsErr *TimestampError
+	err = CheckTimestamps(&resp)
+	if !errors.As(err, &tsErr) || tsErr.Path != "inventory[1].effective_as_of" ||
+		err.Error() != `cannot parse timestamp at inventory[1].effective_as_of: "yesterday"` {
+		t.Errorf("CheckTimestamps() = %v", err)
+	}
+	if err := CheckTimestamps(resp.Inventory[0]); err != nil {
+		t.Errorf("CheckTimestamps(valid) = %v", err)
+	}
+
+	// An empty string matches no layout either.
+	var empty Timestamp
+	if err := json.Unmarshal([]byte(`""`), &empty); err != nil || CheckTimestamps(&empty) == nil {
+		t.Errorf("empty timestamp = %+v, %v", empty, err)
+	}
+
+	ts := Timestamp{Raw: "stale", unparsed: true}
+	if err := json.Unmarshal([]byte(`"2025-08-05T20:38:54Z"`), &ts); err != nil || ts.Raw != "" || ts.IsZero() || CheckTimestamps(ts) != nil {
+		t.Errorf("Unmarshal(valid) = %+v, %v", ts, err)
+	}
+
+	// The raw string survives a round trip.
+	data, err := json.Marshal(item)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var again InventoryItem
+	if err := json.Unmarshal(data, &again); err != nil || again.EffectiveAsOf.Raw != "yesterday" {
+		t.Errorf("round trip = %+v, %v (json %s)", again.EffectiveAsOf, err, data)
+	}
+	if data, _ := json.Marshal(empty); string(data) != `""` {
+		t.Errorf("Marshal(empty) = %s, want \"\"", data)
+	}
+	if data, _ := json.Marshal(Timestamp{}); string(data) != "null" {
+		t.Errorf("Marshal(zero) = %s, want null", data)
+	}
+}
+
+func TestClient_LenientTimestamps(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"inventory":[{"id":"a","quantity":2,"effective_as_of":"yesterday"}],"pagination":{"total":1,"returned":1}}`))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	noop := func(InventoryItem) error { return nil }
+
+	strict := NewClient("token", "email", WithBaseURL(server.URL+"/"))
+	var tsErr *TimestampError
+	if _, err := strict.GetSellerInventory(ctx, InventoryOptions{}); !errors.As(err, &tsErr) || tsErr.Path != "inventory[0].effective_as_of" {
+		t.Errorf("GetSellerInventory() error = %v, want TimestampError", err)
+	}
+	if _, err := strict.ListInventoryStream(ctx, InventoryOptions{}, noop); !errors.As(err, &tsErr) {
+		t.Errorf("ListInventoryStream() error = %v, want TimestampError", err)
+	}
+
+	lenient := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithLenientTimestamps())
+	resp, err := lenient.GetSellerInventory(ctx, InventoryOptions{})
+	if err != nil || resp.Inventory[0].Quantity != 2 || resp.Inventory[0].EffectiveAsOf.Raw != "yesterday" {
+		t.Errorf("GetSellerInventory(lenient) = %+v, %v", resp, err)
+	}
+	if _, err := lenient.ListInventoryStream(ctx, InventoryOptions{}, noop); err != nil {
+		t.Errorf("ListInventoryStream(lenient) error = %v", err)
+	}
+}
+
+func TestSetTimestampLocation(t *testing.T) {
+	decode := func(s string) Timestamp {
+		t.Helper()
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(s), &ts); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", s, err)
+		}
+		return ts
+	}
+
+	a, b := decode(`"2025-08-05T20:38:54Z"`), decode(`"2025-08-05T15:38:54-0500"`)
+	if a == b || !a.Equal(b.Time) {
+		t.Errorf("without location: %v and %v", a, b)
+	}
+
+	SetTimestampLocation(time.UTC)
+	t.Cleanup(func() { SetTimestampLocation(nil) })
+	a, b = decode(`"2025-08-05T20:38:54Z"`), decode(`"2025-08-05T15:38:54-0500"`)
+	if a != b || b.Location() != time.UTC {
+		t.Errorf("with UTC: %v and %v", a, b)
+	}
+
+	tokyo := time.FixedZone("JST", 9*3600)
+	SetTimestampLocation(tokyo)
+	if got := decode(`"2025-08-05T20:38:54Z"`); got.Location() != tokyo || got.Hour() != 5 {
+		t.Errorf("with JST: %v", got)
+	}
+}
@@ -0,0 +1,176 @@
+package manapool
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+// WithTLSConfig sets the TLS configuration of the client's transport, such
+// as a custom RootCAs pool for networks with TLS interception or a minimum
+// TLS version. The config is cloned, so later changes to it have no effect.
+// It replaces any earlier TLS configuration, including certificates added
+// by WithClientCertificate, so apply it first.
+//
+// The option changes a copy of the client's transport: the default
+// transport, or the transport of a client passed to WithHTTPClient, is
+// never modified. It has no effect if that transport is not an
+// *http.Transport.
+//
+// Example:
+//
+//	pool, err := x509.SystemCertPool()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	pool.AppendCertsFromPEM(corporateCA)
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithTLSConfig(&tls.Config{RootCAs: pool}),
+//	)
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(c *Client) {
+		c.configureTransport(func(t *http.Transport) {
+			t.TLSClientConfig = config.Clone()
+		})
+	}
+}
+
+// WithClientCertificate presents cert during the TLS handshake, for gateways
+// that require mutual TLS. It can be applied more than once to offer several
+// certificates, and keeps the rest of the TLS configuration. Like
+// WithTLSConfig, it changes a copy of the client's transport.
+//
+// Example:
+//
+//	cert, err := tls.LoadX509KeyPair("client.crt", "client.key")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithClientCertificate(cert),
+//	)
+func WithClientCertificate(cert tls.Certificate) ClientOption {
+	return func(c *Client) {
+		c.configureTransport(func(t *http.Transport) {
+			config := t.TLSClientConfig.Clone()
+			if config == nil {
+				config = &tls.Config{}
+			}
+			config.Certificates = append(config.Certificates, cert)
+			t.TLSClientConfig = config
+		})
+	}
+}
+
+// configureTransport applies configure to a clone of the client's
+// transport and installs the clone on a copy of the HTTP client, so neither
+// http.DefaultTransport nor a caller's http.Client is modified. It does
+// nothing if the transport is not an *http.Transport.
+func (c *Client) configureTransport(configure func(*http.Transport)) {
+	httpClient := &http.Client{Timeout: DefaultTimeout}
+	if c.httpClient != nil {
+		copied := *c.httpClient
+		httpClient = &copied
+	}
+	roundTripper := httpClient.Transport
+	if roundTripper == nil {
+		roundTripper = http.DefaultTransport
+	}
+	transport, ok := roundTripper.(*http.Transport)
+	if !ok {
+		return
+	}
+	transport = transport.Clone()
+	configure(transport)
+	httpClient.Transport = transport
+	c.httpClient = httpClient
+}
+
+// TransportProfile is a preset of connection pool sizes and timeouts for
+// WithTransportProfile.
+type TransportProfile string
+
+const (
+	// ProfileDefault keeps a small idle pool per host with the standard
+	// library's dial and TLS handshake timeouts. It suits occasional calls
+	// and single-threaded scripts.
+	ProfileDefault TransportProfile = "default"
+
+	// ProfileHighThroughput keeps many idle connections to the API so that
+	// concurrent bulk syncs reuse connections instead of redialing.
+	ProfileHighThroughput TransportProfile = "high-throughput"
+
+	// ProfileLowLatency fails fast on slow dials, handshakes, and response
+	// headers, for interactive callers that would rather retry than wait.
+	ProfileLowLatency TransportProfile = "low-latency"
+)
+
+// transportSettings are the http.Transport fields a TransportProfile sets.
+type transportSettings struct {
+	maxIdleConns          int
+	maxIdleConnsPerHost   int
+	idleConnTimeout       time.Duration
+	dialTimeout           time.Duration
+	tlsHandshakeTimeout   time.Duration
+	responseHeaderTimeout time.Duration
+}
+
+var transportProfiles = map[TransportProfile]transportSettings{
+	ProfileDefault: {
+		maxIdleConns:        100,
+		maxIdleConnsPerHost: 10,
+		idleConnTimeout:     90 * time.Second,
+		dialTimeout:         30 * time.Second,
+		tlsHandshakeTimeout: 10 * time.Second,
+	},
+	ProfileHighThroughput: {
+		maxIdleConns:        256,
+		maxIdleConnsPerHost: 64,
+		idleConnTimeout:     120 * time.Second,
+		dialTimeout:         30 * time.Second,
+		tlsHandshakeTimeout: 10 * time.Second,
+	},
+	ProfileLowLatency: {
+		maxIdleConns:          100,
+		maxIdleConnsPerHost:   16,
+		idleConnTimeout:       90 * time.Second,
+		dialTimeout:           5 * time.Second,
+		tlsHandshakeTimeout:   5 * time.Second,
+		responseHeaderTimeout: 10 * time.Second,
+	},
+}
+
+// WithTransportProfile tunes the client's transport with a preset: the idle
+// connection pool, HTTP/2, and dial, TLS handshake, and response header
+// timeouts. The standard library keeps only two idle connections per host,
+// which starves concurrent bulk syncs; ProfileHighThroughput raises that
+// limit.
+//
+// Like WithTLSConfig, it changes a copy of the client's transport, and it
+// keeps the TLS configuration. It replaces the transport's dialer. An
+// unknown profile is ignored.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithTransportProfile(manapool.ProfileHighThroughput),
+//	)
+func WithTransportProfile(profile TransportProfile) ClientOption {
+	return func(c *Client) {
+		settings, ok := transportProfiles[profile]
+		if !ok {
+			return
+		}
+		c.configureTransport(func(t *http.Transport) {
+			dialer := &net.Dialer{Timeout: settings.dialTimeout, KeepAlive: 30 * time.Second}
+			t.DialContext = dialer.DialContext
+			t.ForceAttemptHTTP2 = true
+			t.MaxIdleConns = settings.maxIdleConns
+			t.MaxIdleConnsPerHost = settings.maxIdleConnsPerHost
+			t.IdleConnTimeout = settings.idleConnTimeout
+			t.TLSHandshakeTimeout = settings.tlsHandshakeTimeout
+			t.ResponseHeaderTimeout = settings.responseHeaderTimeout
+		})
+	}
+}
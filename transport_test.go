@@ -0,0 +1,166 @@
+package manapool
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newClientCertificate creates a self-signed client certificate.
+func newClientCertificate(t *testing.T, name string) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestClient_MutualTLS(t *testing.T) {
+	var peer string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) > 0 {
+			peer = r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	ctx := context.Background()
+
+	// Without the server's CA, the handshake fails.
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0),
+		WithClientCertificate(newClientCertificate(t, "repricer")))
+	if _, err := client.GetSellerAccount(ctx); err == nil {
+		t.Fatal("expected certificate verification error")
+	}
+
+	// Without a client certificate, the server rejects the handshake.
+	client = NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0),
+		WithTLSConfig(&tls.Config{RootCAs: roots}))
+	if _, err := client.GetSellerAccount(ctx); err == nil {
+		t.Fatal("expected handshake error without a client certificate")
+	}
+
+	client = NewClient("token", "email", WithBaseURL(server.URL+"/"), WithRetry(0, 0),
+		WithTLSConfig(&tls.Config{RootCAs: roots}),
+		WithClientCertificate(newClientCertificate(t, "repricer")))
+	if _, err := client.GetSellerAccount(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if peer != "repricer" {
+		t.Errorf("peer certificate = %q, want repricer", peer)
+	}
+}
+
+func TestWithTLSConfig_DoesNotModifyCallerClient(t *testing.T) {
+	transport := &http.Transport{}
+	custom := &http.Client{Timeout: 5 * time.Second, Transport: transport}
+	config := &tls.Config{MinVersion: tls.VersionTLS13}
+
+	client := NewClient("token", "email", WithHTTPClient(custom), WithTLSConfig(config))
+	config.MinVersion = tls.VersionTLS12
+
+	// Cloning a transport fills in its HTTP/2 defaults, so only check that
+	// our settings did not leak into it.
+	if custom.Transport != transport || transport.TLSClientConfig.MinVersion != 0 {
+		t.Error("caller's http.Client was modified")
+	}
+	got, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok || got.TLSClientConfig.MinVersion != tls.VersionTLS13 {
+		t.Errorf("transport TLS config = %+v, want cloned MinVersion TLS 1.3", got.TLSClientConfig)
+	}
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("timeout = %v, want caller's timeout kept", client.httpClient.Timeout)
+	}
+}
+
+func TestWithTLSConfig_CustomRoundTripper(t *testing.T) {
+	rt := roundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, nil })
+	client := NewClient("token", "email", WithHTTPClient(&http.Client{Transport: rt}),
+		WithTLSConfig(&tls.Config{}))
+	if _, ok := client.httpClient.Transport.(roundTripperFunc); !ok {
+		t.Errorf("transport = %T, want the custom round tripper kept", client.httpClient.Transport)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestWithTransportProfile(t *testing.T) {
+	tests := []struct {
+		profile        TransportProfile
+		idlePerHost    int
+		tlsTimeout     time.Duration
+		headerTimeout  time.Duration
+		wantConfigured bool
+	}{
+		{ProfileDefault, 10, 10 * time.Second, 0, true},
+		{ProfileHighThroughput, 64, 10 * time.Second, 0, true},
+		{ProfileLowLatency, 16, 5 * time.Second, 10 * time.Second, true},
+		{"unknown", 0, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.profile), func(t *testing.T) {
+			client := NewClient("token", "email",
+				WithTLSConfig(&tls.Config{MinVersion: tls.VersionTLS13}),
+				WithTransportProfile(tt.profile))
+			transport := client.httpClient.Transport.(*http.Transport)
+			if transport.TLSClientConfig.MinVersion != tls.VersionTLS13 {
+				t.Error("TLS config was not kept")
+			}
+			if !tt.wantConfigured {
+				if transport.MaxIdleConnsPerHost != 0 {
+					t.Errorf("MaxIdleConnsPerHost = %d, want unknown profile ignored", transport.MaxIdleConnsPerHost)
+				}
+				return
+			}
+			if transport.MaxIdleConnsPerHost != tt.idlePerHost || transport.TLSHandshakeTimeout != tt.tlsTimeout ||
+				transport.ResponseHeaderTimeout != tt.headerTimeout || !transport.ForceAttemptHTTP2 || transport.DialContext == nil {
+				t.Errorf("transport = %+v", transport)
+			}
+		})
+	}
+}
+
+func TestWithTransportProfile_Requests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "email", WithBaseURL(server.URL+"/"),
+		WithTimeout(5*time.Second), WithTransportProfile(ProfileHighThroughput))
+	if _, err := client.GetSellerAccount(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("timeout = %v, want 5s kept", client.httpClient.Timeout)
+	}
+}
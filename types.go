@@ -55,12 +55,16 @@ type Account struct {
 	SinglesLive    bool   `json:"singles_live"`
 	SealedLive     bool   `json:"sealed_live"`
 	PayoutsEnabled bool   `json:"payouts_enabled"`
+
+	RawResponse
 }
 
 // InventoryResponse represents a paginated response from the inventory API.
 type InventoryResponse struct {
 	Inventory  []InventoryItem `json:"inventory"`
 	Pagination Pagination      `json:"pagination"`
+
+	RawResponse
 }
 
 // InventoryItem represents a single inventory item in the Manapool system.
@@ -117,32 +121,44 @@ type Pagination struct {
 // The Manapool API returns timestamps in multiple formats:
 //   - RFC3339Nano: "2025-08-05T20:38:54.549229Z"
 //   - No-colon offset: "2025-08-05T20:38:54.549229+0000"
+//
+// More layouts can be accepted with RegisterTimestampLayout.
 type Timestamp struct {
 	time.Time
+
+	// Raw is the original string of a timestamp that matched no layout,
+	// with Time left zero. Clients reject responses holding one unless
+	// WithLenientTimestamps is used; see CheckTimestamps. It is empty
+	// otherwise.
+	Raw string
+
+	// unparsed is set with Raw, so that an empty string is caught too
+	unparsed bool
 }
 
-// UnmarshalJSON implements json.Unmarshaler for Timestamp.
+// UnmarshalJSON implements json.Unmarshaler for Timestamp. A string that
+// matches no layout is kept in Raw rather than failing, so that the rest
+// of the value still decodes; CheckTimestamps reports it.
 func (t *Timestamp) UnmarshalJSON(b []byte) error {
 	s := strings.Trim(string(b), `"`) // strip quotes
 
-	// Try standard RFC3339Nano first
-	if parsed, err := time.Parse(time.RFC3339Nano, s); err == nil {
-		t.Time = parsed
+	parsed, err := parseTimestamp(s)
+	if err != nil {
+		*t = Timestamp{Raw: s, unparsed: true}
 		return nil
 	}
-
-	// Fallback: no-colon offset like +0000
-	if parsed, err := time.Parse("2006-01-02T15:04:05.999999-0700", s); err == nil {
-		t.Time = parsed
-		return nil
-	}
-
-	return fmt.Errorf("cannot parse timestamp: %q", s)
+	*t = Timestamp{Time: parsed}
+	return nil
 }
 
-// MarshalJSON implements json.Marshaler for Timestamp.
+// MarshalJSON implements json.Marshaler for Timestamp. A Timestamp that
+// matched no layout marshals as its raw string, so it survives a round
+// trip.
 func (t Timestamp) MarshalJSON() ([]byte, error) {
 	if t.IsZero() {
+		if t.unparsed || t.Raw != "" {
+			return json.Marshal(t.Raw)
+		}
 		return []byte("null"), nil
 	}
 	return json.Marshal(t.Format(time.RFC3339Nano))
@@ -155,6 +171,11 @@ type InventoryOptions struct {
 
 	// Offset specifies the starting position in the result set (default: 0)
 	Offset int
+
+	// TCGPlayerSKU restricts the results to the listing with this TCGPlayer
+	// SKU (0 disables). The listing is fetched by SKU, so the page holds at
+	// most one item.
+	TCGPlayerSKU int
 }
 
 // Validate validates the inventory options and sets defaults.
@@ -173,6 +194,10 @@ func (o *InventoryOptions) Validate() error {
 		return fmt.Errorf("offset must be non-negative, got %d", o.Offset)
 	}
 
+	if o.TCGPlayerSKU < 0 {
+		return fmt.Errorf("tcgplayer sku must be non-negative, got %d", o.TCGPlayerSKU)
+	}
+
 	return nil
 }
 
@@ -190,7 +215,34 @@ func (o *InventoryOptions) Validate() error {
 //   - NF: Non-foil (no suffix)
 //   - FO: Foil (adds " Foil" suffix)
 //   - EF: Etched Foil (adds " Foil" suffix)
+//
+// ConditionName is ConditionFinishLabel(LabelStyleStandard); use
+// LabelStyleEtched to tell etched foils apart.
 func (s Single) ConditionName() string {
+	return s.ConditionFinishLabel(LabelStyleStandard)
+}
+
+// LabelStyle selects how ConditionFinishLabel names a card's finish.
+type LabelStyle int
+
+const (
+	// LabelStyleStandard labels etched foils "Foil", matching TCGplayer's
+	// condition names. It is the default.
+	LabelStyleStandard LabelStyle = iota
+
+	// LabelStyleEtched labels etched foils "Etched Foil", for buyers who
+	// care about the difference.
+	LabelStyleEtched
+)
+
+// ConditionFinishLabel returns the condition name with a finish suffix in
+// the given style, such as "Near Mint Foil" or, with LabelStyleEtched,
+// "Near Mint Etched Foil". Non-foil cards have no suffix.
+//
+// Example:
+//
+//	label := single.ConditionFinishLabel(manapool.LabelStyleEtched)
+func (s Single) ConditionFinishLabel(style LabelStyle) string {
 	var condition string
 
 	switch s.ConditionID {
@@ -209,15 +261,74 @@ func (s Single) ConditionName() string {
 	}
 
 	// Add foil suffix for foil finishes
-	switch s.FinishID {
-	case "FO", "EF":
+	switch {
+	case s.FinishID == "EF" && style == LabelStyleEtched:
+		condition += " Etched Foil"
+	case s.FinishID == "FO", s.FinishID == "EF":
 		condition += " Foil"
 	}
 
 	return condition
 }
 
+// FinishName returns the name of the card's finish: "Non-Foil", "Foil",
+// "Etched Foil", or "Unknown".
+func (s Single) FinishName() string {
+	switch s.FinishID {
+	case "NF":
+		return "Non-Foil"
+	case "FO":
+		return "Foil"
+	case "EF":
+		return "Etched Foil"
+	default:
+		return "Unknown"
+	}
+}
+
+// Price returns the listing price as USD Money.
+func (i InventoryItem) Price() Money {
+	return USDCents(i.PriceCents)
+}
+
 // PriceDollars returns the price in dollars (converts from cents).
+//
+// Deprecated: float64 dollars cannot represent most cent amounts exactly, so
+// arithmetic on them can be off by a cent. Use Price or PriceCents with
+// PercentOfCents and MarginCents, and FormatPrice for display.
 func (i InventoryItem) PriceDollars() float64 {
 	return float64(i.PriceCents) / 100.0
 }
+
+// HasMore reports whether items remain after this page. A page that returned
+// nothing ends the listing even if Total says otherwise, so loops driven by
+// HasMore always terminate.
+func (p Pagination) HasMore() bool {
+	return p.Returned > 0 && p.Offset+p.Returned < p.Total
+}
+
+// Next returns opts advanced to the page after this one, keeping its limit
+// and filters, and whether there is such a page.
+//
+// Example:
+//
+//	opts := manapool.InventoryOptions{Limit: 500}
+//	for {
+//	    resp, err := client.GetSellerInventory(ctx, opts)
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    process(resp.Inventory)
+//
+//	    var more bool
+//	    if opts, more = resp.Pagination.Next(opts); !more {
+//	        break
+//	    }
+//	}
+func (p Pagination) Next(opts InventoryOptions) (InventoryOptions, bool) {
+	if !p.HasMore() {
+		return opts, false
+	}
+	opts.Offset = p.Offset + p.Returned
+	return opts, true
+}
@@ -135,6 +135,8 @@ type BuyerOrdersOptions struct {
 // BuyerOrdersResponse represents a list of buyer orders.
 type BuyerOrdersResponse struct {
 	Orders []BuyerOrderSummary `json:"orders"`
+
+	RawResponse
 }
 
 // BuyerOrderSummary represents a summary of a buyer order.
@@ -206,6 +208,8 @@ type BuyerOrderSealed struct {
 // BuyerOrderResponse represents a buyer order response.
 type BuyerOrderResponse struct {
 	Order BuyerOrderDetails `json:"order"`
+
+	RawResponse
 }
 
 // BuyerOrderDetails represents detailed buyer order data.
@@ -305,6 +309,8 @@ type DeckCreateResponse struct {
 	Valid   bool                  `json:"valid"`
 	BuyURL  string                `json:"buy_url,omitempty"`
 	Details DeckValidationDetails `json:"details"`
+
+	RawResponse
 }
 
 // DeckValidationDetails represents deck validation details.
@@ -339,21 +345,29 @@ type DeckColorIdentityViolation struct {
 // InventoryItemsResponse represents a response with inventory items.
 type InventoryItemsResponse struct {
 	Inventory []InventoryItem `json:"inventory"`
+
+	RawResponse
 }
 
 // InventoryListingResponse represents a response with a single inventory item.
 type InventoryListingResponse struct {
 	Inventory InventoryItem `json:"inventory"`
+
+	RawResponse
 }
 
 // InventoryListingsResponse represents inventory listing items.
 type InventoryListingsResponse struct {
 	InventoryItems []InventoryItem `json:"inventory_items"`
+
+	RawResponse
 }
 
 // InventoryItemResponse represents inventory item response for /inventory/listings/{id}.
 type InventoryItemResponse struct {
 	InventoryItem InventoryItem `json:"inventory_item"`
+
+	RawResponse
 }
 
 // InventoryUpdateRequest represents a request to update inventory.
@@ -404,6 +418,14 @@ type InventoryByScryfallOptions struct {
 	ConditionID string
 }
 
+// validate checks the language ID, if one is set.
+func (opts InventoryByScryfallOptions) validate() error {
+	if opts.LanguageID == "" {
+		return nil
+	}
+	return ValidateLanguageID(opts.LanguageID)
+}
+
 func (opts InventoryByScryfallOptions) toParams() url.Values {
 	params := url.Values{}
 	if opts.LanguageID != "" {
@@ -425,6 +447,14 @@ type InventoryByTCGPlayerOptions struct {
 	ConditionID string
 }
 
+// validate checks the language ID, if one is set.
+func (opts InventoryByTCGPlayerOptions) validate() error {
+	if opts.LanguageID == "" {
+		return nil
+	}
+	return ValidateLanguageID(opts.LanguageID)
+}
+
 func (opts InventoryByTCGPlayerOptions) toParams() url.Values {
 	params := url.Values{}
 	if opts.LanguageID != "" {
@@ -453,6 +483,8 @@ type OrdersOptions struct {
 // OrdersResponse represents order summaries.
 type OrdersResponse struct {
 	Orders []OrderSummary `json:"orders"`
+
+	RawResponse
 }
 
 // OrderSummary represents order summary information.
@@ -468,6 +500,8 @@ type OrderSummary struct {
 // OrderDetailsResponse represents detailed order response.
 type OrderDetailsResponse struct {
 	Order OrderDetails `json:"order"`
+
+	RawResponse
 }
 
 // OrderDetails represents detailed order data.
@@ -524,11 +558,15 @@ type OrderFulfillmentRequest struct {
 // OrderFulfillmentResponse represents fulfillment response.
 type OrderFulfillmentResponse struct {
 	Fulfillment OrderFulfillment `json:"fulfillment"`
+
+	RawResponse
 }
 
 // OrderReportsResponse represents order reports.
 type OrderReportsResponse struct {
 	Reports []OrderReport `json:"reports"`
+
+	RawResponse
 }
 
 // OrderReport represents an order report.
@@ -580,6 +618,8 @@ type Webhook struct {
 // WebhooksResponse represents webhooks list response.
 type WebhooksResponse struct {
 	Webhooks []Webhook `json:"webhooks"`
+
+	RawResponse
 }
 
 // WebhookRegisterRequest represents webhook register request.
@@ -597,6 +637,8 @@ type CardInfoRequest struct {
 type CardInfoResponse struct {
 	Cards    []CardInfo `json:"cards"`
 	NotFound []string   `json:"not_found"`
+
+	RawResponse
 }
 
 // CardInfo represents card metadata.
@@ -639,4 +681,6 @@ type JobApplicationRequest struct {
 type JobApplicationResponse struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
+
+	RawResponse
 }
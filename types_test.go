@@ -49,6 +49,9 @@ func TestTimestamp_UnmarshalJSON(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			var ts Timestamp
 			err := json.Unmarshal([]byte(tt.input), &ts)
+			if err == nil {
+				err = CheckTimestamps(&ts)
+			}
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Timestamp.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
@@ -145,6 +148,12 @@ func TestInventoryOptions_Validate(t *testing.T) {
 			opts:    InventoryOptions{Limit: 100, Offset: 10000},
 			wantErr: false,
 		},
+		{
+			name:    "negative tcgplayer sku",
+			opts:    InventoryOptions{TCGPlayerSKU: -1},
+			wantErr: true,
+			wantMsg: "tcgplayer sku must be non-negative",
+		},
 	}
 
 	for _, tt := range tests {
@@ -244,6 +253,34 @@ func TestSingle_ConditionName(t *testing.T) {
 	}
 }
 
+func TestSingle_ConditionFinishLabel(t *testing.T) {
+	tests := []struct {
+		conditionID, finishID string
+		style                 LabelStyle
+		want                  string
+	}{
+		{"NM", "EF", LabelStyleStandard, "Near Mint Foil"},
+		{"NM", "EF", LabelStyleEtched, "Near Mint Etched Foil"},
+		{"LP", "FO", LabelStyleEtched, "Lightly Played Foil"},
+		{"HP", "NF", LabelStyleEtched, "Heavily Played"},
+		{"XX", "EF", LabelStyleEtched, "Unknown Etched Foil"},
+	}
+	for _, tt := range tests {
+		s := Single{ConditionID: tt.conditionID, FinishID: tt.finishID}
+		if got := s.ConditionFinishLabel(tt.style); got != tt.want {
+			t.Errorf("ConditionFinishLabel(%s/%s, %d) = %q, want %q", tt.conditionID, tt.finishID, tt.style, got, tt.want)
+		}
+	}
+}
+
+func TestSingle_FinishName(t *testing.T) {
+	for finishID, want := range map[string]string{"NF": "Non-Foil", "FO": "Foil", "EF": "Etched Foil", "": "Unknown"} {
+		if got := (Single{FinishID: finishID}).FinishName(); got != want {
+			t.Errorf("FinishName(%q) = %q, want %q", finishID, got, want)
+		}
+	}
+}
+
 func TestInventoryItem_PriceDollars(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -286,6 +323,63 @@ func TestInventoryItem_PriceDollars(t *testing.T) {
 	}
 }
 
+func TestPagination_Next(t *testing.T) {
+	tests := []struct {
+		name       string
+		pagination Pagination
+		wantMore   bool
+		wantOffset int
+	}{
+		{
+			name:       "first of three pages",
+			pagination: Pagination{Total: 25, Returned: 10, Offset: 0, Limit: 10},
+			wantMore:   true,
+			wantOffset: 10,
+		},
+		{
+			name:       "last full page",
+			pagination: Pagination{Total: 20, Returned: 10, Offset: 10, Limit: 10},
+			wantMore:   false,
+			wantOffset: 10,
+		},
+		{
+			name:       "short last page",
+			pagination: Pagination{Total: 25, Returned: 5, Offset: 20, Limit: 10},
+			wantMore:   false,
+			wantOffset: 20,
+		},
+		{
+			name:       "empty page before total",
+			pagination: Pagination{Total: 25, Returned: 0, Offset: 10, Limit: 10},
+			wantMore:   false,
+			wantOffset: 10,
+		},
+		{
+			name:       "empty inventory",
+			pagination: Pagination{},
+			wantMore:   false,
+			wantOffset: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pagination.HasMore(); got != tt.wantMore {
+				t.Errorf("Pagination.HasMore() = %v, want %v", got, tt.wantMore)
+			}
+
+			opts := InventoryOptions{Limit: tt.pagination.Limit, Offset: tt.pagination.Offset, TCGPlayerSKU: 7}
+			next, more := tt.pagination.Next(opts)
+			if more != tt.wantMore || next.Offset != tt.wantOffset {
+				t.Errorf("Pagination.Next() = %+v, %v, want offset %d, %v", next, more, tt.wantOffset, tt.wantMore)
+			}
+			if next.Limit != opts.Limit || next.TCGPlayerSKU != opts.TCGPlayerSKU {
+				t.Errorf("Pagination.Next() changed options: %+v", next)
+			}
+		})
+	}
+}
+
 func TestAccount_JSON(t *testing.T) {
 	// Test JSON marshaling/unmarshaling
 	account := Account{
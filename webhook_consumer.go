@@ -0,0 +1,337 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Defaults for WebhookConsumerOptions.
+const (
+	DefaultWebhookMaxAttempts  = 8
+	DefaultWebhookRetryBackoff = time.Second
+	DefaultWebhookMaxBackoff   = 5 * time.Minute
+	DefaultWebhookPollInterval = time.Second
+)
+
+// webhookBatchSize is how many due events a consumer loads at a time.
+const webhookBatchSize = 100
+
+// QueuedWebhook is a webhook event waiting in a WebhookQueue.
+type QueuedWebhook struct {
+	Event WebhookEvent
+
+	// EnqueuedAt is when the event was first queued
+	EnqueuedAt time.Time
+
+	// Attempts is the number of failed processing attempts so far, and
+	// LastError the most recent failure
+	Attempts  int
+	LastError string
+
+	// NextAttempt is when the event is next due
+	NextAttempt time.Time
+}
+
+// WebhookQueue persists webhook events between receipt and processing.
+// Implement it over a database or file so events survive a crash; an event
+// stays queued, and is processed again after a restart, until Remove.
+//
+// Implementations must be safe for concurrent use. MemoryWebhookQueue is an
+// in-memory implementation for tests.
+type WebhookQueue interface {
+	// Enqueue durably stores event, due now. Enqueuing an event whose ID is
+	// already queued does nothing, so redeliveries are not processed twice
+	// while the first is pending.
+	Enqueue(ctx context.Context, event WebhookEvent, now time.Time) error
+
+	// Due returns up to limit events due at or before now, oldest first.
+	Due(ctx context.Context, now time.Time, limit int) ([]QueuedWebhook, error)
+
+	// Reschedule records a failed attempt and when the event is next due.
+	Reschedule(ctx context.Context, id string, attempts int, next time.Time, lastErr string) error
+
+	// Remove deletes a processed or dead-lettered event.
+	Remove(ctx context.Context, id string) error
+}
+
+// MemoryWebhookQueue is an in-memory WebhookQueue. Events are lost when the
+// process exits, so it gives no durability on its own.
+type MemoryWebhookQueue struct {
+	mu     sync.Mutex
+	events map[string]*QueuedWebhook
+}
+
+// NewMemoryWebhookQueue creates an empty in-memory queue.
+func NewMemoryWebhookQueue() *MemoryWebhookQueue {
+	return &MemoryWebhookQueue{events: make(map[string]*QueuedWebhook)}
+}
+
+// Enqueue implements WebhookQueue.
+func (q *MemoryWebhookQueue) Enqueue(_ context.Context, event WebhookEvent, now time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.events[event.ID]; !ok {
+		q.events[event.ID] = &QueuedWebhook{Event: event, EnqueuedAt: now, NextAttempt: now}
+	}
+	return nil
+}
+
+// Due implements WebhookQueue.
+func (q *MemoryWebhookQueue) Due(_ context.Context, now time.Time, limit int) ([]QueuedWebhook, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var due []QueuedWebhook
+	for _, queued := range q.events {
+		if !queued.NextAttempt.After(now) {
+			due = append(due, *queued)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].EnqueuedAt.Equal(due[j].EnqueuedAt) {
+			return due[i].EnqueuedAt.Before(due[j].EnqueuedAt)
+		}
+		return due[i].Event.ID < due[j].Event.ID
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// Reschedule implements WebhookQueue.
+func (q *MemoryWebhookQueue) Reschedule(_ context.Context, id string, attempts int, next time.Time, lastErr string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if queued, ok := q.events[id]; ok {
+		queued.Attempts, queued.NextAttempt, queued.LastError = attempts, next, lastErr
+	}
+	return nil
+}
+
+// Remove implements WebhookQueue.
+func (q *MemoryWebhookQueue) Remove(_ context.Context, id string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.events, id)
+	return nil
+}
+
+// Len returns the number of queued events.
+func (q *MemoryWebhookQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.events)
+}
+
+// WebhookConsumerOptions configures a WebhookConsumer.
+type WebhookConsumerOptions struct {
+	// MaxAttempts is how many times an event is processed before it is
+	// dead-lettered (default: DefaultWebhookMaxAttempts)
+	MaxAttempts int
+
+	// RetryBackoff is the wait after the first failure, doubling after each
+	// one up to MaxBackoff (defaults: DefaultWebhookRetryBackoff and
+	// DefaultWebhookMaxBackoff)
+	RetryBackoff time.Duration
+	MaxBackoff   time.Duration
+
+	// PollInterval is how often Run checks the queue for due events
+	// (default: DefaultWebhookPollInterval). Received events are processed
+	// immediately.
+	PollInterval time.Duration
+
+	// DeadLetter, if set, is called with an event that failed MaxAttempts
+	// times and its last error, before it is removed from the queue. If it
+	// returns an error, the event stays queued and is dead-lettered again
+	// after the next backoff.
+	DeadLetter func(ctx context.Context, queued QueuedWebhook, err error) error
+
+	// OnError, if set, is called with each processing and queue error
+	OnError func(error)
+}
+
+// WebhookConsumer processes webhooks at least once. Its HTTP handler stores
+// each verified event in a WebhookQueue before acknowledging it, and Run
+// processes queued events with retries, so a crash between receipt and
+// processing never loses an order event; ManaPool redelivers anything not
+// yet acknowledged.
+//
+// Because an event can be processed more than once, such as after a crash
+// mid-processing, the handler should be idempotent, for example by keying
+// its writes on the order ID. Run only one consumer per queue.
+type WebhookConsumer struct {
+	queue  WebhookQueue
+	handle WebhookHandlerFunc
+	opts   WebhookConsumerOptions
+	wake   chan struct{}
+	now    func() time.Time
+}
+
+// NewWebhookConsumer creates a consumer that queues events in queue and
+// processes them with handle.
+//
+// Example:
+//
+//	consumer := manapool.NewWebhookConsumer(queue, handleOrder, manapool.WebhookConsumerOptions{
+//	    DeadLetter: func(ctx context.Context, queued manapool.QueuedWebhook, err error) error {
+//	        log.Printf("giving up on %s after %d attempts: %v", queued.Event.ID, queued.Attempts, err)
+//	        return nil
+//	    },
+//	})
+//	http.Handle("/manapool/webhook", consumer.Handler(secret))
+//	go consumer.Run(ctx)
+func NewWebhookConsumer(queue WebhookQueue, handle WebhookHandlerFunc, opts WebhookConsumerOptions) *WebhookConsumer {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultWebhookMaxAttempts
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = DefaultWebhookRetryBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = DefaultWebhookMaxBackoff
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultWebhookPollInterval
+	}
+	return &WebhookConsumer{
+		queue:  queue,
+		handle: handle,
+		opts:   opts,
+		wake:   make(chan struct{}, 1),
+		now:    time.Now,
+	}
+}
+
+// Handler returns a WebhookHandler that verifies webhooks with secret and
+// queues them. It responds 204 once the event is stored, and 500 if it
+// cannot be, so ManaPool delivers it again.
+func (c *WebhookConsumer) Handler(secret string) *WebhookHandler {
+	handler := NewWebhookHandler(secret, c.Receive)
+	handler.OnError = c.opts.OnError
+	return handler
+}
+
+// Receive queues an event for processing, for events received some other
+// way than Handler.
+func (c *WebhookConsumer) Receive(ctx context.Context, event *WebhookEvent) error {
+	if err := c.queue.Enqueue(ctx, *event, c.now()); err != nil {
+		return fmt.Errorf("failed to queue webhook %s: %w", event.ID, err)
+	}
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run processes queued events until ctx is cancelled, starting with any
+// left from a previous run. It returns ctx's error.
+func (c *WebhookConsumer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := c.ProcessDue(ctx); err != nil && ctx.Err() == nil {
+			c.reportError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue processes every event currently due and returns how many were
+// handled successfully. Run calls it; call it directly to drain the queue
+// from a scheduled job instead.
+func (c *WebhookConsumer) ProcessDue(ctx context.Context) (int, error) {
+	processed := 0
+	// An event whose removal or rescheduling failed is still due; attempt
+	// it once per call rather than spinning on it.
+	attempted := make(map[string]bool)
+	for {
+		due, err := c.queue.Due(ctx, c.now(), webhookBatchSize)
+		if err != nil {
+			return processed, fmt.Errorf("failed to load queued webhooks: %w", err)
+		}
+		fresh := 0
+		for _, queued := range due {
+			if attempted[queued.Event.ID] {
+				continue
+			}
+			attempted[queued.Event.ID] = true
+			fresh++
+			if ctx.Err() != nil {
+				return processed, ctx.Err()
+			}
+			ok, err := c.process(ctx, queued)
+			if err != nil {
+				c.reportError(err)
+			}
+			if ok {
+				processed++
+			}
+		}
+		if fresh == 0 {
+			return processed, nil
+		}
+	}
+}
+
+// process handles one queued event, then removes or reschedules it.
+func (c *WebhookConsumer) process(ctx context.Context, queued QueuedWebhook) (bool, error) {
+	event := queued.Event
+	handleErr := c.handle(ctx, &event)
+	if handleErr == nil {
+		if err := c.queue.Remove(ctx, event.ID); err != nil {
+			return true, fmt.Errorf("failed to remove processed webhook %s: %w", event.ID, err)
+		}
+		return true, nil
+	}
+
+	queued.Attempts++
+	queued.LastError = handleErr.Error()
+	c.reportError(fmt.Errorf("webhook %s attempt %d failed: %w", event.ID, queued.Attempts, handleErr))
+
+	if queued.Attempts >= c.opts.MaxAttempts {
+		var deadErr error
+		if c.opts.DeadLetter != nil {
+			deadErr = c.opts.DeadLetter(ctx, queued, handleErr)
+		}
+		if deadErr == nil {
+			if err := c.queue.Remove(ctx, event.ID); err != nil {
+				return false, fmt.Errorf("failed to remove dead-lettered webhook %s: %w", event.ID, err)
+			}
+			return false, nil
+		}
+		c.reportError(fmt.Errorf("failed to dead-letter webhook %s: %w", event.ID, deadErr))
+	}
+
+	next := c.now().Add(c.backoff(queued.Attempts))
+	if err := c.queue.Reschedule(ctx, event.ID, queued.Attempts, next, queued.LastError); err != nil {
+		return false, fmt.Errorf("failed to reschedule webhook %s: %w", event.ID, err)
+	}
+	return false, nil
+}
+
+// backoff returns the wait after the given number of failed attempts.
+func (c *WebhookConsumer) backoff(attempts int) time.Duration {
+	delay := c.opts.RetryBackoff
+	for i := 1; i < attempts && delay < c.opts.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > c.opts.MaxBackoff {
+		delay = c.opts.MaxBackoff
+	}
+	return delay
+}
+
+func (c *WebhookConsumer) reportError(err error) {
+	if c.opts.OnError != nil {
+		c.opts.OnError(err)
+	}
+}
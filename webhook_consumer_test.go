@@ -0,0 +1,205 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// failingQueue is a MemoryWebhookQueue whose writes can be made to fail.
+type failingQueue struct {
+	*MemoryWebhookQueue
+	failEnqueue bool
+	failRemove  bool
+}
+
+func (q *failingQueue) Enqueue(ctx context.Context, event WebhookEvent, now time.Time) error {
+	if q.failEnqueue {
+		return errors.New("disk full")
+	}
+	return q.MemoryWebhookQueue.Enqueue(ctx, event, now)
+}
+
+func (q *failingQueue) Remove(ctx context.Context, id string) error {
+	if q.failRemove {
+		return errors.New("disk full")
+	}
+	return q.MemoryWebhookQueue.Remove(ctx, id)
+}
+
+func orderEvent(id string) *WebhookEvent {
+	return &WebhookEvent{ID: "order_created:" + id, Topic: WebhookTopicOrderCreated, Body: []byte(`{"order":{"id":"` + id + `"}}`)}
+}
+
+func TestWebhookConsumer_RetriesAndDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	queue := NewMemoryWebhookQueue()
+	failures := map[string]int{"order_created:flaky": 2, "order_created:broken": 100}
+	var handled []string
+	var dead []QueuedWebhook
+
+	consumer := NewWebhookConsumer(queue, func(_ context.Context, event *WebhookEvent) error {
+		handled = append(handled, event.ID)
+		if failures[event.ID] > 0 {
+			failures[event.ID]--
+			return errors.New("handler failed")
+		}
+		return nil
+	}, WebhookConsumerOptions{
+		MaxAttempts:  3,
+		RetryBackoff: time.Second,
+		DeadLetter: func(_ context.Context, queued QueuedWebhook, err error) error {
+			dead = append(dead, queued)
+			return nil
+		},
+	})
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	consumer.now = func() time.Time { return now }
+
+	for _, id := range []string{"ok", "flaky", "broken"} {
+		if err := consumer.Receive(ctx, orderEvent(id)); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(time.Millisecond)
+	}
+	// A redelivery while the first is pending is ignored.
+	_ = consumer.Receive(ctx, orderEvent("ok"))
+
+	if n, err := consumer.ProcessDue(ctx); n != 1 || err != nil {
+		t.Errorf("first pass = %d, %v; want 1", n, err)
+	}
+	if n, _ := consumer.ProcessDue(ctx); n != 0 {
+		t.Errorf("pass before backoff processed %d", n)
+	}
+	now = now.Add(time.Second)
+	consumer.ProcessDue(ctx)
+	now = now.Add(2 * time.Second)
+	if n, _ := consumer.ProcessDue(ctx); n != 1 {
+		t.Errorf("third pass = %d, want flaky to succeed", n)
+	}
+
+	want := "ok,flaky,broken,flaky,broken,flaky,broken"
+	if got := strings.ReplaceAll(strings.Join(handled, ","), "order_created:", ""); got != want {
+		t.Errorf("handled = %s, want %s", got, want)
+	}
+	if len(dead) != 1 || dead[0].Event.ID != "order_created:broken" || dead[0].Attempts != 3 || dead[0].LastError != "handler failed" {
+		t.Errorf("dead letters = %+v", dead)
+	}
+	if queue.Len() != 0 {
+		t.Errorf("queue length = %d, want 0", queue.Len())
+	}
+}
+
+func TestWebhookConsumer_DeadLetterFailureKeepsEvent(t *testing.T) {
+	ctx := context.Background()
+	queue := NewMemoryWebhookQueue()
+	consumer := NewWebhookConsumer(queue, func(context.Context, *WebhookEvent) error {
+		return errors.New("handler failed")
+	}, WebhookConsumerOptions{
+		MaxAttempts: 1,
+		DeadLetter: func(context.Context, QueuedWebhook, error) error {
+			return errors.New("dead letter store down")
+		},
+	})
+	_ = consumer.Receive(ctx, orderEvent("a"))
+	consumer.ProcessDue(ctx)
+
+	due, _ := queue.Due(ctx, time.Now().Add(time.Hour), 0)
+	if len(due) != 1 || due[0].Attempts != 1 {
+		t.Errorf("queued = %+v, want the event kept", due)
+	}
+}
+
+func TestWebhookConsumer_QueueErrors(t *testing.T) {
+	ctx := context.Background()
+	queue := &failingQueue{MemoryWebhookQueue: NewMemoryWebhookQueue(), failRemove: true}
+	var errs []error
+	calls := 0
+	consumer := NewWebhookConsumer(queue, func(context.Context, *WebhookEvent) error {
+		calls++
+		return nil
+	}, WebhookConsumerOptions{OnError: func(err error) { errs = append(errs, err) }})
+
+	_ = consumer.Receive(ctx, orderEvent("a"))
+	// The event cannot be removed, so it stays due; one pass handles it once.
+	if n, _ := consumer.ProcessDue(ctx); n != 1 || calls != 1 || len(errs) != 1 {
+		t.Errorf("processed %d with %d calls and errors %v", n, calls, errs)
+	}
+
+	queue.failEnqueue = true
+	rec := httptest.NewRecorder()
+	consumer.Handler("secret").ServeHTTP(rec, newWebhookRequest("secret", testWebhookBody, time.Now()))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500 when the event cannot be stored", rec.Code)
+	}
+}
+
+func TestWebhookConsumer_Run(t *testing.T) {
+	queue := NewMemoryWebhookQueue()
+	done := make(chan string, 10)
+	consumer := NewWebhookConsumer(queue, func(_ context.Context, event *WebhookEvent) error {
+		done <- event.ID
+		return nil
+	}, WebhookConsumerOptions{PollInterval: time.Hour})
+
+	// An event left from a previous run is processed on start.
+	_ = queue.Enqueue(context.Background(), *orderEvent("left-over"), time.Now())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() { errc <- consumer.Run(ctx) }()
+
+	server := httptest.NewServer(consumer.Handler("secret"))
+	defer server.Close()
+	req := newWebhookRequest("secret", testWebhookBody, time.Now())
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(testWebhookBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("unsigned status = %d", resp.StatusCode)
+	}
+	httpReq, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(testWebhookBody))
+	httpReq.Header = req.Header
+	resp, err = http.DefaultClient.Do(httpReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("signed status = %d", resp.StatusCode)
+	}
+
+	var got []string
+	for len(got) < 2 {
+		select {
+		case id := <-done:
+			got = append(got, id)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("processed %v, want 2 events", got)
+		}
+	}
+	if fmt.Sprint(got) != "[order_created:left-over order_created:order-1]" {
+		t.Errorf("processed = %v", got)
+	}
+
+	cancel()
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() = %v", err)
+	}
+}
+
+func TestWebhookConsumer_Backoff(t *testing.T) {
+	consumer := NewWebhookConsumer(NewMemoryWebhookQueue(), nil, WebhookConsumerOptions{RetryBackoff: time.Second, MaxBackoff: 10 * time.Second})
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 4: 8 * time.Second, 5: 10 * time.Second, 50: 10 * time.Second} {
+		if got := consumer.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package manapool
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WebhookTopicOrderCreated is the topic of webhooks sent when an order is
+// placed.
+const WebhookTopicOrderCreated = "order_created"
+
+// Webhook request headers.
+const (
+	WebhookEventHeader     = "X-ManaPool-Event"
+	WebhookTimestampHeader = "X-ManaPool-Timestamp"
+	WebhookSignatureHeader = "X-ManaPool-Signature"
+)
+
+// DefaultWebhookTolerance is how old a webhook's signature timestamp may be
+// before the webhook is rejected as a possible replay.
+const DefaultWebhookTolerance = 5 * time.Minute
+
+// maxWebhookBody limits the size of webhook bodies read by WebhookHandler.
+const maxWebhookBody = 1 << 20
+
+// ErrInvalidWebhookSignature is returned, wrapped, when a webhook's
+// signature is missing, malformed, expired, or does not match.
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+// WebhookEvent is a webhook delivery from ManaPool.
+type WebhookEvent struct {
+	// ID identifies the event for deduplication. ManaPool does not send
+	// one, so it is derived from the payload, such as
+	// "order_created:<order id>"; redeliveries of the same event share it.
+	ID string `json:"id"`
+
+	// Topic is the event type, such as WebhookTopicOrderCreated
+	Topic string `json:"topic"`
+
+	// SignedAt is the signature timestamp
+	SignedAt time.Time `json:"signed_at"`
+
+	// Body is the raw JSON payload
+	Body json.RawMessage `json:"body"`
+
+	// Replay is true when the event is re-run by ReplayEvents rather than
+	// delivered by ManaPool
+	Replay bool `json:"replay,omitempty"`
+}
+
+// Order decodes the order of an order_created event.
+func (e *WebhookEvent) Order() (*OrderDetails, error) {
+	var payload struct {
+		Order *OrderDetails `json:"order"`
+	}
+	if err := json.Unmarshal(e.Body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook order: %w", err)
+	}
+	if err := CheckTimestamps(&payload); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook order: %w", err)
+	}
+	if payload.Order == nil {
+		return nil, fmt.Errorf("webhook %s has no order", e.ID)
+	}
+	return payload.Order, nil
+}
+
+// WebhookHandlerFunc processes a verified webhook event. Returning an error
+// makes WebhookHandler respond with a 500 so ManaPool delivers it again.
+type WebhookHandlerFunc func(ctx context.Context, event *WebhookEvent) error
+
+// SignWebhook returns the X-ManaPool-Signature value ManaPool sends for
+// body at timestamp, for testing webhook receivers.
+func SignWebhook(secret string, timestamp time.Time, body []byte) string {
+	return formatSignature(secret, timestamp, body)
+}
+
+// VerifyWebhookSignature checks an X-ManaPool-Signature header against
+// body. It returns an error wrapping ErrInvalidWebhookSignature unless the
+// v1 digest matches and its timestamp is within tolerance of now; a
+// tolerance of zero or less skips the age check.
+func VerifyWebhookSignature(secret, signature string, body []byte, tolerance time.Duration) (time.Time, error) {
+	return verifySignature(secret, signature, body, tolerance, ErrInvalidWebhookSignature)
+}
+
+// ParseWebhook reads and verifies a webhook request and returns its event.
+// Verification failures wrap ErrInvalidWebhookSignature.
+func ParseWebhook(r *http.Request, secret string, tolerance time.Duration) (*WebhookEvent, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook body: %w", err)
+	}
+	signedAt, err := VerifyWebhookSignature(secret, r.Header.Get(WebhookSignatureHeader), body, tolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	topic := r.Header.Get(WebhookEventHeader)
+	if topic == "" {
+		return nil, NewValidationError(WebhookEventHeader, "webhook topic is missing")
+	}
+	if !json.Valid(body) {
+		return nil, NewValidationError("body", "webhook body is not valid JSON")
+	}
+	return &WebhookEvent{ID: webhookEventID(topic, body), Topic: topic, SignedAt: signedAt, Body: body}, nil
+}
+
+// webhookEventID derives an event ID from the order ID, or from a hash of
+// the body for payloads without one.
+func webhookEventID(topic string, body []byte) string {
+	var payload struct {
+		Order struct {
+			ID string `json:"id"`
+		} `json:"order"`
+	}
+	if json.Unmarshal(body, &payload) == nil && payload.Order.ID != "" {
+		return topic + ":" + payload.Order.ID
+	}
+	sum := sha256.Sum256(body)
+	return topic + ":" + hex.EncodeToString(sum[:8])
+}
+
+// WebhookHandler is an http.Handler that verifies ManaPool webhooks and
+// passes them to a WebhookHandlerFunc. It responds 401 to bad signatures,
+// 400 to malformed requests, 500 if the handler fails, and 204 otherwise.
+type WebhookHandler struct {
+	// Tolerance is the maximum signature age (default:
+	// DefaultWebhookTolerance)
+	Tolerance time.Duration
+
+	// OnError, if set, is called with each rejected or failed delivery
+	OnError func(error)
+
+	secret string
+	handle WebhookHandlerFunc
+}
+
+// NewWebhookHandler creates a handler that verifies webhooks with the
+// secret from registration and passes them to handle.
+//
+// handle runs before the response is sent, so a crash while it runs leaves
+// the webhook to be delivered again, but slow work delays the response;
+// see WebhookConsumer to queue events and process them separately.
+//
+// Example:
+//
+//	handler := manapool.NewWebhookHandler(secret, func(ctx context.Context, event *manapool.WebhookEvent) error {
+//	    order, err := event.Order()
+//	    if err != nil {
+//	        return err
+//	    }
+//	    log.Printf("new order %s", order.ID)
+//	    return nil
+//	})
+//	http.Handle("/manapool/webhook", handler)
+func NewWebhookHandler(secret string, handle WebhookHandlerFunc) *WebhookHandler {
+	return &WebhookHandler{secret: secret, handle: handle}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	tolerance := h.Tolerance
+	if tolerance == 0 {
+		tolerance = DefaultWebhookTolerance
+	}
+	event, err := ParseWebhook(r, h.secret, tolerance)
+	if err != nil {
+		h.reportError(err)
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrInvalidWebhookSignature) {
+			status = http.StatusUnauthorized
+		}
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	if err := h.handle(r.Context(), event); err != nil {
+		h.reportError(fmt.Errorf("failed to handle webhook %s: %w", event.ID, err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) reportError(err error) {
+	if h.OnError != nil {
+		h.OnError(err)
+	}
+}
@@ -0,0 +1,128 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testWebhookBody = `{"order":{"id":"order-1","total_cents":1250}}`
+
+// newWebhookRequest builds a signed order_created delivery.
+func newWebhookRequest(secret, body string, signedAt time.Time) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set(WebhookEventHeader, WebhookTopicOrderCreated)
+	req.Header.Set(WebhookTimestampHeader, "0")
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(secret, signedAt, []byte(body)))
+	return req
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	now := time.Now()
+	body := []byte(testWebhookBody)
+	signature := SignWebhook("secret", now, body)
+
+	signedAt, err := VerifyWebhookSignature("secret", signature, body, time.Minute)
+	if err != nil || signedAt.Unix() != now.Unix() {
+		t.Fatalf("VerifyWebhookSignature() = %v, %v", signedAt, err)
+	}
+
+	for name, tt := range map[string]struct {
+		secret, signature, body string
+	}{
+		"wrong secret":  {"other", signature, testWebhookBody},
+		"altered body":  {"secret", signature, testWebhookBody + " "},
+		"missing v1":    {"secret", "t=123", testWebhookBody},
+		"bad timestamp": {"secret", "t=abc,v1=00", testWebhookBody},
+		"expired":       {"secret", SignWebhook("secret", now.Add(-time.Hour), body), testWebhookBody},
+	} {
+		if _, err := VerifyWebhookSignature(tt.secret, tt.signature, []byte(tt.body), time.Minute); !errors.Is(err, ErrInvalidWebhookSignature) {
+			t.Errorf("%s: error = %v, want ErrInvalidWebhookSignature", name, err)
+		}
+	}
+
+	old := SignWebhook("secret", now.Add(-time.Hour), body)
+	if _, err := VerifyWebhookSignature("secret", old, body, 0); err != nil {
+		t.Errorf("zero tolerance error = %v", err)
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	var got *WebhookEvent
+	handler := NewWebhookHandler("secret", func(_ context.Context, event *WebhookEvent) error {
+		got = event
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newWebhookRequest("secret", testWebhookBody, time.Now()))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204", rec.Code)
+	}
+	if got.ID != "order_created:order-1" || got.Topic != WebhookTopicOrderCreated {
+		t.Errorf("event = %+v", got)
+	}
+	order, err := got.Order()
+	if err != nil || order.ID != "order-1" || order.TotalCents != 1250 {
+		t.Errorf("Order() = %+v, %v", order, err)
+	}
+}
+
+func TestWebhookHandler_Rejects(t *testing.T) {
+	var errs []error
+	handled := 0
+	handler := NewWebhookHandler("secret", func(_ context.Context, event *WebhookEvent) error {
+		handled++
+		if strings.Contains(string(event.Body), "fail") {
+			return errors.New("database down")
+		}
+		return nil
+	})
+	handler.OnError = func(err error) { errs = append(errs, err) }
+
+	noTopic := newWebhookRequest("secret", testWebhookBody, time.Now())
+	noTopic.Header.Del(WebhookEventHeader)
+	get := httptest.NewRequest(http.MethodGet, "/webhook", nil)
+
+	for name, tt := range map[string]struct {
+		req  *http.Request
+		want int
+	}{
+		"bad signature": {newWebhookRequest("other", testWebhookBody, time.Now()), http.StatusUnauthorized},
+		"stale":         {newWebhookRequest("secret", testWebhookBody, time.Now().Add(-time.Hour)), http.StatusUnauthorized},
+		"no topic":      {noTopic, http.StatusBadRequest},
+		"invalid json":  {newWebhookRequest("secret", `{"order":`, time.Now()), http.StatusBadRequest},
+		"handler error": {newWebhookRequest("secret", `{"fail":true}`, time.Now()), http.StatusInternalServerError},
+		"method":        {get, http.StatusMethodNotAllowed},
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, tt.req)
+		if rec.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d", name, rec.Code, tt.want)
+		}
+	}
+	if handled != 1 || len(errs) != 5 {
+		t.Errorf("handled %d, errors %d; want 1 and 5", handled, len(errs))
+	}
+}
+
+func TestWebhookEvent_IDWithoutOrder(t *testing.T) {
+	a, b := webhookEventID("ping", []byte(`{"a":1}`)), webhookEventID("ping", []byte(`{"a":2}`))
+	if a == b || !strings.HasPrefix(a, "ping:") {
+		t.Errorf("IDs = %s, %s", a, b)
+	}
+	event := &WebhookEvent{ID: a, Body: []byte(`{"a":1}`)}
+	if _, err := event.Order(); err == nil {
+		t.Error("Order() without order succeeded")
+	}
+
+	event.Body = []byte(`{"order":{"id":"o1","created_at":"yesterday"}}`)
+	var tsErr *TimestampError
+	if _, err := event.Order(); !errors.As(err, &tsErr) || tsErr.Path != "order.created_at" {
+		t.Errorf("Order() with a bad timestamp error = %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package manapool
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// WebhookEventSource yields stored webhook events for ReplayEvents.
+type WebhookEventSource interface {
+	// Next returns the next event, or io.EOF when there are none left.
+	Next(ctx context.Context) (*WebhookEvent, error)
+}
+
+// WebhookLogWriter stores webhook events as JSON Lines, one event per line,
+// for replaying later with a WebhookLogReader. It is safe for concurrent
+// use.
+type WebhookLogWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewWebhookLogWriter creates a log writing to w, such as a file opened for
+// appending.
+func NewWebhookLogWriter(w io.Writer) *WebhookLogWriter {
+	return &WebhookLogWriter{enc: json.NewEncoder(w)}
+}
+
+// Record appends an event to the log.
+func (l *WebhookLogWriter) Record(_ context.Context, event *WebhookEvent) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.enc.Encode(event); err != nil {
+		return fmt.Errorf("failed to write webhook %s: %w", event.ID, err)
+	}
+	return nil
+}
+
+// Wrap returns a handler that records each event before passing it to
+// next, so every delivery can be replayed later.
+//
+// Example:
+//
+//	f, err := os.OpenFile("webhooks.jsonl", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	archive := manapool.NewWebhookLogWriter(f)
+//	http.Handle("/manapool/webhook", manapool.NewWebhookHandler(secret, archive.Wrap(handleOrder)))
+func (l *WebhookLogWriter) Wrap(next WebhookHandlerFunc) WebhookHandlerFunc {
+	return func(ctx context.Context, event *WebhookEvent) error {
+		if !event.Replay {
+			if err := l.Record(ctx, event); err != nil {
+				return err
+			}
+		}
+		return next(ctx, event)
+	}
+}
+
+// WebhookLogReader reads events written by a WebhookLogWriter.
+type WebhookLogReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// NewWebhookLogReader creates a WebhookEventSource reading JSON Lines from
+// r. Blank lines are skipped.
+func NewWebhookLogReader(r io.Reader) *WebhookLogReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxWebhookBody+1024)
+	return &WebhookLogReader{scanner: scanner}
+}
+
+// Next implements WebhookEventSource.
+func (r *WebhookLogReader) Next(ctx context.Context) (*WebhookEvent, error) {
+	for r.scanner.Scan() {
+		r.line++
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		text := strings.TrimSpace(r.scanner.Text())
+		if text == "" {
+			continue
+		}
+		var event WebhookEvent
+		if err := json.Unmarshal([]byte(text), &event); err != nil {
+			return nil, fmt.Errorf("failed to decode webhook log line %d: %w", r.line, err)
+		}
+		return &event, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read webhook log: %w", err)
+	}
+	return nil, io.EOF
+}
+
+// WebhookEvents returns a WebhookEventSource over events, such as ones
+// loaded from a database.
+func WebhookEvents(events ...WebhookEvent) WebhookEventSource {
+	return &sliceWebhookSource{events: events}
+}
+
+type sliceWebhookSource struct {
+	events []WebhookEvent
+}
+
+func (s *sliceWebhookSource) Next(ctx context.Context) (*WebhookEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(s.events) == 0 {
+		return nil, io.EOF
+	}
+	event := s.events[0]
+	s.events = s.events[1:]
+	return &event, nil
+}
+
+// ReplayFailure records a replayed event the handler rejected.
+type ReplayFailure struct {
+	Event WebhookEvent
+	Err   error
+}
+
+// ReplayReport summarizes a ReplayEvents call.
+type ReplayReport struct {
+	// Replayed is the number of events the handler accepted
+	Replayed int
+
+	Failures []ReplayFailure
+}
+
+// Err returns an error joining all failures, or nil if every event was
+// handled.
+func (r *ReplayReport) Err() error {
+	if len(r.Failures) == 0 {
+		return nil
+	}
+	errs := make([]error, len(r.Failures))
+	for i, failure := range r.Failures {
+		errs[i] = fmt.Errorf("webhook %s: %w", failure.Event.ID, failure.Err)
+	}
+	return fmt.Errorf("%d replayed webhooks failed: %w", len(r.Failures), errors.Join(errs...))
+}
+
+// String renders a one-line summary of the report.
+func (r *ReplayReport) String() string {
+	return fmt.Sprintf("%d replayed, %d failed", r.Replayed, len(r.Failures))
+}
+
+// ReplayEvents runs every event from source through handler again, with
+// WebhookEvent.Replay set, for backfilling after a handler bug or downtime.
+// Handlers can check Replay to skip side effects such as notifications.
+//
+// Handler errors are collected in the report and replay continues; the
+// returned error is non-nil only if source fails or ctx is cancelled, in
+// which case the report covers the events replayed so far.
+//
+// Example:
+//
+//	f, err := os.Open("webhooks.jsonl")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer f.Close()
+//	report, err := manapool.ReplayEvents(ctx, manapool.NewWebhookLogReader(f), handleOrder)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	log.Print(report)
+func ReplayEvents(ctx context.Context, source WebhookEventSource, handler WebhookHandlerFunc) (*ReplayReport, error) {
+	report := &ReplayReport{}
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		event, err := source.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return report, nil
+		}
+		if err != nil {
+			return report, fmt.Errorf("failed to read webhook events: %w", err)
+		}
+
+		event.Replay = true
+		if err := handler(ctx, event); err != nil {
+			report.Failures = append(report.Failures, ReplayFailure{Event: *event, Err: err})
+			continue
+		}
+		report.Replayed++
+	}
+}
@@ -0,0 +1,95 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookLog_RecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	var log bytes.Buffer
+	archive := NewWebhookLogWriter(&log)
+
+	var live []bool
+	handle := archive.Wrap(func(_ context.Context, event *WebhookEvent) error {
+		live = append(live, event.Replay)
+		return nil
+	})
+	for _, id := range []string{"a", "b"} {
+		event := orderEvent(id)
+		event.SignedAt = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+		if err := handle(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	log.WriteString("\n")
+
+	var replayed []*WebhookEvent
+	report, err := ReplayEvents(ctx, NewWebhookLogReader(bytes.NewReader(log.Bytes())), handle)
+	if err != nil {
+		t.Fatalf("ReplayEvents() error = %v", err)
+	}
+	if report.String() != "2 replayed, 0 failed" {
+		t.Errorf("report = %s", report)
+	}
+
+	// Replays pass through the archive without being logged again.
+	reader := NewWebhookLogReader(bytes.NewReader(log.Bytes()))
+	for {
+		event, err := reader.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		replayed = append(replayed, event)
+	}
+	if len(replayed) != 2 || replayed[1].ID != "order_created:b" || !replayed[1].SignedAt.Equal(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("log = %+v", replayed)
+	}
+	if order, err := replayed[0].Order(); err != nil || order.ID != "a" {
+		t.Errorf("Order() = %+v, %v", order, err)
+	}
+	if got := fmt.Sprint(live); got != "[false false true true]" {
+		t.Errorf("replay flags = %s, want live then replayed", got)
+	}
+}
+
+func TestReplayEvents_Failures(t *testing.T) {
+	ctx := context.Background()
+	source := WebhookEvents(*orderEvent("a"), *orderEvent("b"), *orderEvent("c"))
+	report, err := ReplayEvents(ctx, source, func(_ context.Context, event *WebhookEvent) error {
+		if event.ID == "order_created:b" {
+			return errors.New("still broken")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReplayEvents() error = %v", err)
+	}
+	if report.Replayed != 2 || len(report.Failures) != 1 {
+		t.Fatalf("report = %s", report)
+	}
+	if err := report.Err(); err == nil || !strings.Contains(err.Error(), "webhook order_created:b: still broken") {
+		t.Errorf("Err() = %v", err)
+	}
+
+	bad := NewWebhookLogReader(strings.NewReader(`{"id":"x","topic":"order_created","body":{}}` + "\nnot json\n"))
+	report, err = ReplayEvents(ctx, bad, func(context.Context, *WebhookEvent) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "line 2") || report.Replayed != 1 {
+		t.Errorf("bad log = %v, %v", report, err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := ReplayEvents(cancelled, WebhookEvents(*orderEvent("a")), nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled error = %v", err)
+	}
+}